@@ -16,7 +16,10 @@ package main
 import (
 	"os"
 
+	"github.com/perses/perses/internal/cli/cmd/alerts"
+	"github.com/perses/perses/internal/cli/cmd/analyze"
 	"github.com/perses/perses/internal/cli/cmd/apply"
+	"github.com/perses/perses/internal/cli/cmd/dashboards"
 	"github.com/perses/perses/internal/cli/cmd/describe"
 	"github.com/perses/perses/internal/cli/cmd/get"
 	"github.com/perses/perses/internal/cli/cmd/lint"
@@ -24,6 +27,7 @@ import (
 	"github.com/perses/perses/internal/cli/cmd/migrate"
 	"github.com/perses/perses/internal/cli/cmd/project"
 	"github.com/perses/perses/internal/cli/cmd/remove"
+	"github.com/perses/perses/internal/cli/cmd/team"
 	"github.com/perses/perses/internal/cli/cmd/version"
 	"github.com/perses/perses/internal/cli/config"
 	"github.com/sirupsen/logrus"
@@ -42,7 +46,10 @@ func newRootCommand() *cobra.Command {
 	}
 
 	// The list of the commands supported
+	cmd.AddCommand(alerts.NewCMD())
+	cmd.AddCommand(analyze.NewCMD())
 	cmd.AddCommand(apply.NewCMD())
+	cmd.AddCommand(dashboards.NewCMD())
 	cmd.AddCommand(describe.NewCMD())
 	cmd.AddCommand(get.NewCMD())
 	cmd.AddCommand(lint.NewCMD())
@@ -50,6 +57,7 @@ func newRootCommand() *cobra.Command {
 	cmd.AddCommand(migrate.NewCMD())
 	cmd.AddCommand(project.NewCMD())
 	cmd.AddCommand(remove.NewCMD())
+	cmd.AddCommand(team.NewCMD())
 	cmd.AddCommand(version.NewCMD())
 
 	// the list of the global flags supported
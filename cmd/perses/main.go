@@ -16,8 +16,7 @@ package main
 import (
 	"flag"
 
-	"github.com/perses/perses/internal/api/config"
-	"github.com/perses/perses/internal/api/core"
+	"github.com/perses/perses/pkg/server"
 	"github.com/sirupsen/logrus"
 )
 
@@ -40,20 +39,14 @@ func main() {
 	configFile := flag.String("config", "", "Path to the YAML configuration file for the API. Configuration settings can be overridden when using environment variables.")
 	flag.Parse()
 	// load the config from file or/and from environment
-	conf, err := config.Resolve(*configFile)
+	conf, err := server.ResolveConfig(*configFile)
 	if err != nil {
 		logrus.WithError(err).Fatalf("error reading configuration from file %q or from environment", *configFile)
 	}
-	runner, persistentManager, err := core.New(conf, banner)
+	srv, err := server.New(conf, server.WithBanner(banner))
 	if err != nil {
 		logrus.Fatal(err)
 	}
-	defer func() {
-		if daoCloseErr := persistentManager.GetPersesDAO().Close(); daoCloseErr != nil {
-			logrus.WithError(daoCloseErr).Error("unable to close the connection to the database")
-		}
-	}()
-
 	// start the application
-	runner.Start()
+	srv.Start()
 }
@@ -25,6 +25,8 @@ function getKindID() {
     echo "datasources"
   elif [ "${kind}" = "Dashboard" ]; then
     echo "dashboards"
+  elif [ "${kind}" = "GlobalHomeConfig" ]; then
+    echo "globalhomeconfigs"
   fi
 }
 
@@ -60,6 +62,7 @@ function injectAllData() {
   insertResourceData ./data/dashboard.json true
   insertResourceData ./data/project.json
   insertResourceData ./data/globaldatasource.json
+  insertResourceData ./data/globalhomeconfig.json
 }
 
 injectAllData
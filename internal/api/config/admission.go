@@ -0,0 +1,91 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/perses/perses/pkg/model/api/admission"
+	"github.com/prometheus/common/config"
+	"github.com/prometheus/common/model"
+)
+
+const DefaultAdmissionWebhookTimeout = model.Duration(10 * time.Second)
+
+type AdmissionWebhookType string
+
+const (
+	// AdmissionWebhookValidating can only accept or reject the request.
+	AdmissionWebhookValidating AdmissionWebhookType = "validating"
+	// AdmissionWebhookMutating can also modify the resource by returning a JSON patch.
+	AdmissionWebhookMutating AdmissionWebhookType = "mutating"
+)
+
+type FailurePolicy string
+
+const (
+	// FailurePolicyClosed rejects the request when the webhook cannot be reached or returns an invalid response.
+	FailurePolicyClosed FailurePolicy = "closed"
+	// FailurePolicyOpen ignores the webhook when it cannot be reached or returns an invalid response.
+	FailurePolicyOpen FailurePolicy = "open"
+)
+
+// AdmissionWebhook is an HTTP endpoint called with the candidate resource before it is created, updated or deleted.
+type AdmissionWebhook struct {
+	// Name identifies the webhook in the logs and in the error messages.
+	Name string `json:"name" yaml:"name"`
+	// Type is either validating or mutating. The mutating webhooks are called first.
+	Type AdmissionWebhookType `json:"type" yaml:"type"`
+	// URL is the endpoint receiving the admission request with a POST.
+	URL string `json:"url" yaml:"url"`
+	// Timeout of the call. Default is 10s.
+	Timeout model.Duration `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	// FailurePolicy is either closed or open. Default is closed.
+	FailurePolicy FailurePolicy `json:"failure_policy,omitempty" yaml:"failure_policy,omitempty"`
+	// Kinds is the list of the kinds of resource sent to the webhook. Every kind is sent when it is empty.
+	Kinds []string `json:"kinds,omitempty" yaml:"kinds,omitempty"`
+	// Operations is the list of the operations (CREATE, UPDATE, DELETE) sent to the webhook. Every operation is sent when it is empty.
+	Operations []admission.Operation `json:"operations,omitempty" yaml:"operations,omitempty"`
+	TLSConfig  *config.TLSConfig     `json:"tls_config,omitempty" yaml:"tls_config,omitempty"`
+}
+
+func (a *AdmissionWebhook) Verify() error {
+	if len(a.Name) == 0 {
+		return fmt.Errorf("the name of an admission webhook cannot be empty")
+	}
+	if a.Type != AdmissionWebhookValidating && a.Type != AdmissionWebhookMutating {
+		return fmt.Errorf("invalid type %q for the admission webhook %q, it must be %q or %q", a.Type, a.Name, AdmissionWebhookValidating, AdmissionWebhookMutating)
+	}
+	u, err := url.Parse(a.URL)
+	if err != nil || len(u.Host) == 0 {
+		return fmt.Errorf("invalid url %q for the admission webhook %q", a.URL, a.Name)
+	}
+	if a.Timeout <= 0 {
+		a.Timeout = DefaultAdmissionWebhookTimeout
+	}
+	if len(a.FailurePolicy) == 0 {
+		a.FailurePolicy = FailurePolicyClosed
+	}
+	if a.FailurePolicy != FailurePolicyClosed && a.FailurePolicy != FailurePolicyOpen {
+		return fmt.Errorf("invalid failure_policy %q for the admission webhook %q, it must be %q or %q", a.FailurePolicy, a.Name, FailurePolicyClosed, FailurePolicyOpen)
+	}
+	for _, operation := range a.Operations {
+		if operation != admission.OperationCreate && operation != admission.OperationUpdate && operation != admission.OperationDelete {
+			return fmt.Errorf("invalid operation %q for the admission webhook %q, it must be CREATE, UPDATE or DELETE", operation, a.Name)
+		}
+	}
+	return nil
+}
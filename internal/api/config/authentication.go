@@ -0,0 +1,213 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/prometheus/common/config"
+	"github.com/prometheus/common/model"
+)
+
+const (
+	DefaultUserHeader   = "X-Forwarded-User"
+	DefaultEmailHeader  = "X-Forwarded-Email"
+	DefaultGroupsHeader = "X-Forwarded-Groups"
+)
+
+const (
+	DefaultLDAPTimeout       = model.Duration(10 * time.Second)
+	DefaultLDAPUserFilter    = "(uid=%s)"
+	DefaultLDAPEmailAttr     = "mail"
+	DefaultLDAPGroupFilter   = "(member=%s)"
+	DefaultLDAPGroupNameAttr = "cn"
+	DefaultSessionTTL        = model.Duration(12 * time.Hour)
+	minSessionKeyLength      = 32
+)
+
+// HeaderAuthentication is the configuration to trust the identity provided by an authenticating reverse proxy
+// (like oauth2-proxy) through HTTP headers.
+type HeaderAuthentication struct {
+	// TrustedCIDRs is the list of networks the reverse proxy is running from.
+	// The headers of a request coming from any other address are ignored.
+	TrustedCIDRs []string `json:"trusted_cidrs" yaml:"trusted_cidrs"`
+	// UserHeader is the header containing the name of the user. Default is X-Forwarded-User.
+	UserHeader string `json:"user_header,omitempty" yaml:"user_header,omitempty"`
+	// EmailHeader is the header containing the email of the user. Default is X-Forwarded-Email.
+	EmailHeader string `json:"email_header,omitempty" yaml:"email_header,omitempty"`
+	// GroupsHeader is the header containing the comma separated list of the groups of the user. Default is X-Forwarded-Groups.
+	GroupsHeader string `json:"groups_header,omitempty" yaml:"groups_header,omitempty"`
+}
+
+func (h *HeaderAuthentication) Verify() error {
+	if len(h.TrustedCIDRs) == 0 {
+		return fmt.Errorf("trusted_cidrs must be specified when using the header authentication")
+	}
+	if _, err := h.GetTrustedNetworks(); err != nil {
+		return err
+	}
+	if len(h.UserHeader) == 0 {
+		h.UserHeader = DefaultUserHeader
+	}
+	if len(h.EmailHeader) == 0 {
+		h.EmailHeader = DefaultEmailHeader
+	}
+	if len(h.GroupsHeader) == 0 {
+		h.GroupsHeader = DefaultGroupsHeader
+	}
+	return nil
+}
+
+// GetTrustedNetworks parses the list of the trusted CIDRs.
+func (h *HeaderAuthentication) GetTrustedNetworks() ([]*net.IPNet, error) {
+	result := make([]*net.IPNet, 0, len(h.TrustedCIDRs))
+	for _, cidr := range h.TrustedCIDRs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted CIDR %q: %w", cidr, err)
+		}
+		result = append(result, network)
+	}
+	return result, nil
+}
+
+type LDAPUserSearch struct {
+	// BaseDN is the base of the search of the users.
+	BaseDN string `json:"base_dn" yaml:"base_dn"`
+	// Filter is used to find the user. %s is replaced by the login (escaped). Default is (uid=%s).
+	Filter string `json:"filter,omitempty" yaml:"filter,omitempty"`
+	// EmailAttribute is the attribute containing the email of the user. Default is mail.
+	EmailAttribute string `json:"email_attribute,omitempty" yaml:"email_attribute,omitempty"`
+}
+
+func (s *LDAPUserSearch) Verify() error {
+	if len(s.BaseDN) == 0 {
+		return fmt.Errorf("user_search.base_dn must be specified")
+	}
+	if len(s.Filter) == 0 {
+		s.Filter = DefaultLDAPUserFilter
+	}
+	if !strings.Contains(s.Filter, "%s") {
+		return fmt.Errorf("user_search.filter must contain %%s that is replaced by the login")
+	}
+	if len(s.EmailAttribute) == 0 {
+		s.EmailAttribute = DefaultLDAPEmailAttr
+	}
+	return nil
+}
+
+type LDAPGroupSearch struct {
+	// BaseDN is the base of the search of the groups.
+	BaseDN string `json:"base_dn" yaml:"base_dn"`
+	// Filter is used to find the groups of a member. %s is replaced by the DN of the member (escaped). Default is (member=%s).
+	Filter string `json:"filter,omitempty" yaml:"filter,omitempty"`
+	// NameAttribute is the attribute containing the name of the group. Default is cn.
+	NameAttribute string `json:"name_attribute,omitempty" yaml:"name_attribute,omitempty"`
+	// Nested when true also looks for the groups the groups of the user are member of.
+	Nested bool `json:"nested,omitempty" yaml:"nested,omitempty"`
+}
+
+func (s *LDAPGroupSearch) Verify() error {
+	if len(s.BaseDN) == 0 {
+		return fmt.Errorf("group_search.base_dn must be specified")
+	}
+	if len(s.Filter) == 0 {
+		s.Filter = DefaultLDAPGroupFilter
+	}
+	if !strings.Contains(s.Filter, "%s") {
+		return fmt.Errorf("group_search.filter must contain %%s that is replaced by the DN of the member")
+	}
+	if len(s.NameAttribute) == 0 {
+		s.NameAttribute = DefaultLDAPGroupNameAttr
+	}
+	return nil
+}
+
+// LDAPProvider is the configuration to authenticate the users against an LDAP directory, like Active Directory.
+type LDAPProvider struct {
+	// URL of the directory. The scheme is either ldap or ldaps. For example: ldaps://ldap.example.com:636
+	URL string `json:"url" yaml:"url"`
+	// StartTLS upgrades the connection to TLS. Only available with the scheme ldap.
+	StartTLS bool `json:"start_tls,omitempty" yaml:"start_tls,omitempty"`
+	// TLSConfig is used with the scheme ldaps or StartTLS.
+	TLSConfig *config.TLSConfig `json:"tls_config,omitempty" yaml:"tls_config,omitempty"`
+	// BindDN is the service account used to search the users and the groups. Anonymous when empty.
+	BindDN string `json:"bind_dn,omitempty" yaml:"bind_dn,omitempty"`
+	// BindPassword is the password of the service account.
+	BindPassword config.Secret `json:"bind_password,omitempty" yaml:"bind_password,omitempty"`
+	// Timeout of the connection and of each request. Default is 10s.
+	Timeout     model.Duration   `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	UserSearch  LDAPUserSearch   `json:"user_search" yaml:"user_search"`
+	GroupSearch *LDAPGroupSearch `json:"group_search,omitempty" yaml:"group_search,omitempty"`
+}
+
+func (l *LDAPProvider) Verify() error {
+	u, err := url.Parse(l.URL)
+	if err != nil || len(u.Host) == 0 {
+		return fmt.Errorf("invalid ldap url %q", l.URL)
+	}
+	if u.Scheme != "ldap" && u.Scheme != "ldaps" {
+		return fmt.Errorf("unsupported scheme %q in the ldap url, only ldap and ldaps are supported", u.Scheme)
+	}
+	if l.StartTLS && u.Scheme == "ldaps" {
+		return fmt.Errorf("start_tls cannot be used with the scheme ldaps")
+	}
+	if l.Timeout <= 0 {
+		l.Timeout = DefaultLDAPTimeout
+	}
+	return nil
+}
+
+// Session is the configuration of the access tokens delivered to the users once they are logged in.
+type Session struct {
+	// Key is the secret used to sign the access tokens. It must contain at least 32 characters.
+	Key config.Secret `json:"key,omitempty" yaml:"key,omitempty"`
+	// TTL is the validity of the access tokens. Default is 12h.
+	TTL model.Duration `json:"ttl,omitempty" yaml:"ttl,omitempty"`
+}
+
+func (s *Session) Verify() error {
+	if len(s.Key) > 0 && len(s.Key) < minSessionKeyLength {
+		return fmt.Errorf("session.key must contain at least %d characters", minSessionKeyLength)
+	}
+	if s.TTL <= 0 {
+		s.TTL = DefaultSessionTTL
+	}
+	return nil
+}
+
+type Authentication struct {
+	// Header when set makes Perses trust the identity headers set by an authenticating reverse proxy.
+	Header *HeaderAuthentication `json:"header,omitempty" yaml:"header,omitempty"`
+	// LDAP when set allows the users to log in with their LDAP credentials.
+	LDAP *LDAPProvider `json:"ldap,omitempty" yaml:"ldap,omitempty"`
+	// Session is required by the providers the users log in with, like LDAP.
+	Session Session `json:"session" yaml:"session"`
+}
+
+func (a *Authentication) Verify() error {
+	if a.LDAP != nil && len(a.Session.Key) == 0 {
+		return fmt.Errorf("session.key must be specified to log in with ldap")
+	}
+	return nil
+}
+
+// IsEnabled returns true when at least one provider is configured.
+func (a *Authentication) IsEnabled() bool {
+	return a.Header != nil || a.LDAP != nil
+}
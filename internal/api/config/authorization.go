@@ -0,0 +1,50 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+// GroupPermissions grants permissions to the members of a group. The groups are provided by the authentication, for
+// example the LDAP groups or the groups header set by the reverse proxy.
+type GroupPermissions struct {
+	Group       string          `json:"group" yaml:"group"`
+	Permissions []v1.Permission `json:"permissions" yaml:"permissions"`
+}
+
+func (g *GroupPermissions) Verify() error {
+	if len(g.Group) == 0 {
+		return fmt.Errorf("group cannot be empty in the authorization")
+	}
+	for _, permission := range g.Permissions {
+		if err := permission.Validate(); err != nil {
+			return fmt.Errorf("invalid permission of the group %q: %w", g.Group, err)
+		}
+	}
+	return nil
+}
+
+type Authorization struct {
+	// Groups maps the groups of the users to their permissions.
+	// When empty, the authorization is disabled and every authenticated user can do anything.
+	Groups []GroupPermissions `json:"groups,omitempty" yaml:"groups,omitempty"`
+}
+
+// IsEnabled returns true when at least one group is mapped to permissions.
+func (a *Authorization) IsEnabled() bool {
+	return len(a.Groups) > 0
+}
@@ -14,27 +14,33 @@
 package config
 
 import (
+	"fmt"
+
 	"github.com/perses/common/config"
 )
 
-type dashboardSelector struct {
-	// Project correspond to the name of the project (dashboard.metadata.project)
-	Project string `json:"project" yaml:"project"`
-	// Dashboard correspond to the name of the dashboard (dashboard.metadata.name)
-	Dashboard string `json:"dashboard" yaml:"dashboard"`
-}
-
 type Config struct {
 	// Readonly will deactivate any HTTP POST, PUT, DELETE endpoint
 	Readonly bool `json:"readonly" yaml:"readonly"`
+	// Authentication contains the configuration of the way the users are identified. By default, there is no authentication.
+	Authentication Authentication `json:"authentication" yaml:"authentication"`
+	// Authorization contains the permissions granted to the authenticated users.
+	Authorization Authorization `json:"authorization" yaml:"authorization"`
 	// Database contains the different configuration depending on the database you want to use
 	Database Database `json:"database" yaml:"database"`
 	// Schemas contains the configuration to get access to the CUE schemas
 	Schemas Schemas `json:"schemas" yaml:"schemas"`
-	// ImportantDashboards contains important dashboard selectors
-	ImportantDashboards []dashboardSelector `json:"important_dashboards,omitempty" yaml:"important_dashboards,omitempty"`
-	// Information contains markdown content to be display on the home page
-	Information string `json:"information,omitempty" yaml:"information,omitempty"`
+	// Draft contains the configuration of the draft and publish workflow of the dashboards
+	Draft Draft `json:"draft" yaml:"draft"`
+	// AdmissionWebhooks is the list of the webhooks called before a resource is created, updated or deleted.
+	AdmissionWebhooks []AdmissionWebhook `json:"admission_webhooks,omitempty" yaml:"admission_webhooks,omitempty"`
+}
+
+func (c *Config) Verify() error {
+	if c.Authorization.IsEnabled() && !c.Authentication.IsEnabled() {
+		return fmt.Errorf("the authorization requires at least one authentication provider")
+	}
+	return nil
 }
 
 func Resolve(configFile string) (Config, error) {
@@ -19,29 +19,32 @@ import (
 )
 
 const (
-	DefaultPanelsPath      = "schemas/panels"
-	DefaultQueriesPath     = "schemas/queries"
-	DefaultDatasourcesPath = "schemas/datasources"
-	DefaultVariablesPath   = "schemas/variables"
-	defaultInterval        = 1 * time.Hour
+	DefaultPanelsPath          = "schemas/panels"
+	DefaultQueriesPath         = "schemas/queries"
+	DefaultDatasourcesPath     = "schemas/datasources"
+	DefaultVariablesPath       = "schemas/variables"
+	DefaultTransformationsPath = "schemas/transformations"
+	defaultInterval            = 1 * time.Hour
 )
 
 // jsonSchemas is only used to marshal the config in a proper json format
 // (mainly because of the duration that is not yet supported by json).
 type jsonSchemas struct {
-	PanelsPath      string `json:"panels_path,omitempty"`
-	QueriesPath     string `json:"queries_path,omitempty" `
-	DatasourcesPath string `json:"datasources_path,omitempty" `
-	VariablesPath   string `json:"variables_path,omitempty"`
-	Interval        string `json:"interval,omitempty"`
+	PanelsPath          string `json:"panels_path,omitempty"`
+	QueriesPath         string `json:"queries_path,omitempty" `
+	DatasourcesPath     string `json:"datasources_path,omitempty" `
+	VariablesPath       string `json:"variables_path,omitempty"`
+	TransformationsPath string `json:"transformations_path,omitempty"`
+	Interval            string `json:"interval,omitempty"`
 }
 
 type Schemas struct {
-	PanelsPath      string        `yaml:"panels_path,omitempty"`
-	QueriesPath     string        `yaml:"queries_path,omitempty"`
-	DatasourcesPath string        `yaml:"datasources_path,omitempty"`
-	VariablesPath   string        `yaml:"variables_path,omitempty"`
-	Interval        time.Duration `yaml:"interval,omitempty"`
+	PanelsPath          string        `yaml:"panels_path,omitempty"`
+	QueriesPath         string        `yaml:"queries_path,omitempty"`
+	DatasourcesPath     string        `yaml:"datasources_path,omitempty"`
+	VariablesPath       string        `yaml:"variables_path,omitempty"`
+	TransformationsPath string        `yaml:"transformations_path,omitempty"`
+	Interval            time.Duration `yaml:"interval,omitempty"`
 }
 
 func (s *Schemas) Verify() error {
@@ -57,6 +60,9 @@ func (s *Schemas) Verify() error {
 	if len(s.VariablesPath) == 0 {
 		s.VariablesPath = DefaultVariablesPath
 	}
+	if len(s.TransformationsPath) == 0 {
+		s.TransformationsPath = DefaultTransformationsPath
+	}
 	if s.Interval <= 0 {
 		s.Interval = defaultInterval
 	}
@@ -65,11 +71,12 @@ func (s *Schemas) Verify() error {
 
 func (s Schemas) MarshalJSON() ([]byte, error) {
 	j := &jsonSchemas{
-		PanelsPath:      s.PanelsPath,
-		QueriesPath:     s.QueriesPath,
-		DatasourcesPath: s.DatasourcesPath,
-		VariablesPath:   s.VariablesPath,
-		Interval:        s.Interval.String(),
+		PanelsPath:          s.PanelsPath,
+		QueriesPath:         s.QueriesPath,
+		DatasourcesPath:     s.DatasourcesPath,
+		VariablesPath:       s.VariablesPath,
+		TransformationsPath: s.TransformationsPath,
+		Interval:            s.Interval.String(),
 	}
 	return json.Marshal(j)
 }
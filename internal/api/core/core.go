@@ -16,54 +16,159 @@ package core
 import (
 	"fmt"
 
+	"github.com/labstack/echo/v4"
 	"github.com/perses/common/app"
+	echoUtils "github.com/perses/common/echo"
 	"github.com/perses/perses/internal/api/config"
 	"github.com/perses/perses/internal/api/core/middleware"
+	"github.com/perses/perses/internal/api/impl/v1/ephemeraldashboard"
+	"github.com/perses/perses/internal/api/impl/v1/sharetoken"
+	"github.com/perses/perses/internal/api/impl/v1/usage"
+	"github.com/perses/perses/internal/api/shared"
+	"github.com/perses/perses/internal/api/shared/admission"
+	databaseModel "github.com/perses/perses/internal/api/shared/database/model"
 	"github.com/perses/perses/internal/api/shared/dependency"
 	"github.com/perses/perses/internal/api/shared/migrate"
 	"github.com/perses/perses/internal/api/shared/schemas"
 	"github.com/perses/perses/ui"
-	"github.com/sirupsen/logrus"
 )
 
+// Options are the dependencies that can be injected when Perses is embedded in another application.
+// Every field is optional.
+type Options struct {
+	// DAO replaces the database configured.
+	DAO databaseModel.DAO
+	// Schemas replaces the CUE schemas loaded from the folders configured.
+	Schemas schemas.Schemas
+	// APIs are registered next to the API of Perses.
+	APIs []echoUtils.Register
+	// Middlewares are executed after the authentication, so the identity of the user is known.
+	Middlewares []echo.MiddlewareFunc
+	// Authenticators are tried before the authentication methods configured. Setting one enables the authentication.
+	Authenticators []func(c echo.Context) (*shared.Identity, error)
+	// DisableHotReload keeps the schemas loaded at the start, without watching their folders nor reloading them
+	// periodically.
+	DisableHotReload bool
+}
+
 func New(conf config.Config, banner string) (*app.Runner, dependency.PersistenceManager, error) {
-	persistenceManager, err := dependency.NewPersistenceManager(conf.Database)
+	return NewWithOptions(conf, banner, Options{})
+}
+
+func NewWithOptions(conf config.Config, banner string, opts Options) (*app.Runner, dependency.PersistenceManager, error) {
+	var persistenceManager dependency.PersistenceManager
+	var err error
+	if opts.DAO != nil {
+		persistenceManager, err = dependency.NewPersistenceManagerFromDAO(opts.DAO)
+	} else {
+		persistenceManager, err = dependency.NewPersistenceManager(conf.Database)
+	}
 	if err != nil {
-		logrus.WithError(err).Fatal("unable to instantiate the persistence manager")
+		return nil, nil, fmt.Errorf("unable to instantiate the persistence manager: %w", err)
 	}
 	persesDAO := persistenceManager.GetPersesDAO()
 	if dbInitError := persesDAO.Init(); dbInitError != nil {
 		return nil, nil, fmt.Errorf("unable to initialize the database: %w", dbInitError)
 	}
-	serviceManager, err := dependency.NewServiceManager(persistenceManager, conf)
+	var serviceManager dependency.ServiceManager
+	if opts.Schemas != nil {
+		serviceManager, err = dependency.NewServiceManagerWithSchemas(persistenceManager, conf, opts.Schemas)
+	} else {
+		serviceManager, err = dependency.NewServiceManager(persistenceManager, conf)
+	}
 	if err != nil {
 		return nil, nil, fmt.Errorf("unable to initialize the service manager: %w", err)
 	}
+	// the projects created before the organizations exist are moved into the default organization
+	if migrateErr := serviceManager.GetOrganization().MigrateProjects(); migrateErr != nil {
+		return nil, nil, fmt.Errorf("unable to move the projects into the default organization: %w", migrateErr)
+	}
 	persesAPI := NewPersesAPI(serviceManager, conf)
 	persesFrontend := ui.NewPersesFrontend()
 	runner := app.NewRunner().WithDefaultHTTPServer("perses").SetBanner(banner)
 
-	// enable hot reload of CUE schemas for dashboards validation:
-	// - watch for changes on the schemas folders
-	// - register a cron task to reload all the schemas every <interval>
-	watcher, reloader, err := schemas.NewHotReloaders(serviceManager.GetSchemas().GetLoaders())
-	if err != nil {
-		return nil, nil, fmt.Errorf("unable to instantiate the tasks for hot reload of schemas: %w", err)
+	if !opts.DisableHotReload {
+		// enable hot reload of CUE schemas for dashboards validation:
+		// - watch for changes on the schemas folders
+		// - register a cron task to reload all the schemas every <interval>
+		watcher, reloader, hotReloadErr := schemas.NewHotReloaders(serviceManager.GetSchemas().GetLoaders())
+		if hotReloadErr != nil {
+			return nil, nil, fmt.Errorf("unable to instantiate the tasks for hot reload of schemas: %w", hotReloadErr)
+		}
+		// enable hot reload of the migration schemas
+		migrateWatcher, migrateReloader, hotReloadErr := migrate.NewHotReloaders(serviceManager.GetMigration())
+		if hotReloadErr != nil {
+			return nil, nil, fmt.Errorf("unable to instantiate the tasks for hot reload of migration schema: %w", hotReloadErr)
+		}
+		runner.WithTasks(watcher, migrateWatcher)
+		runner.WithCronTasks(conf.Schemas.Interval, reloader, migrateReloader)
 	}
-	// enable hot reload of the migration schemas
-	migrateWatcher, migrateReloader, err := migrate.NewHotReloaders(serviceManager.GetMigration())
-	if err != nil {
-		return nil, nil, fmt.Errorf("unable to instantiate the tasks for hot reload of migration schema: %w", err)
+	// the views of the dashboards are kept in memory and persisted periodically
+	runner.WithCronTasks(usage.FlushInterval, usage.NewFlusher(serviceManager.GetUsage()))
+	if !conf.Readonly {
+		// the expired ephemeral dashboards are deleted periodically
+		runner.WithCronTasks(ephemeraldashboard.CleanupInterval, ephemeraldashboard.NewCleaner(serviceManager.GetEphemeralDashboard()))
+		// the same for the expired share tokens
+		runner.WithCronTasks(sharetoken.CleanupInterval, sharetoken.NewCleaner(serviceManager.GetShareToken()))
 	}
-	runner.WithTasks(watcher, migrateWatcher)
-	runner.WithCronTasks(conf.Schemas.Interval, reloader, migrateReloader)
 
 	// register the API
-	runner.HTTPServerBuilder().
+	httpServerBuilder := runner.HTTPServerBuilder()
+	if conf.Authentication.IsEnabled() || len(opts.Authenticators) > 0 {
+		// the identity must be known before anything else, including the proxy to the datasources
+		authMiddlewares, authErr := newAuthMiddlewares(conf, serviceManager, opts.Authenticators)
+		if authErr != nil {
+			return nil, nil, authErr
+		}
+		for _, mdw := range authMiddlewares {
+			httpServerBuilder.Middleware(mdw)
+		}
+	}
+	httpServerBuilder.Middleware(middleware.CheckOrganizationProject(persistenceManager.GetProject()))
+	admissionController, err := admission.New(conf.AdmissionWebhooks)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to instantiate the admission webhooks: %w", err)
+	}
+	if admissionController != nil {
+		httpServerBuilder.Middleware(middleware.Admission(admissionController))
+	}
+	for _, mdw := range opts.Middlewares {
+		httpServerBuilder.Middleware(mdw)
+	}
+	for _, api := range opts.APIs {
+		httpServerBuilder.APIRegistration(api)
+	}
+	httpServerBuilder.
 		APIRegistration(persesAPI).
 		APIRegistration(persesFrontend).
-		Middleware(middleware.Proxy(persistenceManager.GetDatasource(), persistenceManager.GetGlobalDatasource())).
+		Middleware(middleware.Proxy(persistenceManager.GetDatasource(), persistenceManager.GetGlobalDatasource(), persistenceManager.GetGlobalDatasourceOverride(), persistenceManager.GetOrganizationDatasource(), persistenceManager.GetProject())).
 		Middleware(middleware.HandleError()).
-		Middleware(middleware.CheckProject(serviceManager.GetProject()))
+		Middleware(middleware.CheckProject(serviceManager.GetProject())).
+		Middleware(middleware.CheckOwners(persistenceManager.GetTeam())).
+		Middleware(middleware.CheckReadonlyOrganization(persistenceManager.GetOrganization(), persistenceManager.GetProject())).
+		Middleware(middleware.RecordDashboardView(serviceManager.GetUsage()))
 	return runner, persistenceManager, nil
 }
+
+func newAuthMiddlewares(conf config.Config, serviceManager dependency.ServiceManager, authenticators []func(c echo.Context) (*shared.Identity, error)) ([]echo.MiddlewareFunc, error) {
+	var result []echo.MiddlewareFunc
+	for _, authenticate := range authenticators {
+		result = append(result, middleware.CustomAuthentication(authenticate))
+	}
+	if conf.Authentication.Header != nil {
+		headerMiddleware, err := middleware.HeaderAuthentication(conf.Authentication.Header)
+		if err != nil {
+			return nil, fmt.Errorf("unable to instantiate the header authentication: %w", err)
+		}
+		result = append(result, headerMiddleware)
+	}
+	if token := serviceManager.GetToken(); token != nil {
+		result = append(result, middleware.TokenAuthentication(token))
+	}
+	result = append(result, middleware.ShareTokenAuthentication(serviceManager.GetShareToken()))
+	result = append(result, middleware.RequireIdentity())
+	if authorization := serviceManager.GetAuthorization(); authorization.IsEnabled() {
+		result = append(result, middleware.Authorize(authorization, serviceManager.GetAccess()))
+	}
+	return result, nil
+}
@@ -0,0 +1,30 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/shared"
+)
+
+// Admission attaches to the request the controller calling the admission webhooks, so the endpoints writing the
+// resources of the users can send them to the webhooks.
+func Admission(controller shared.AdmissionController) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			shared.SetAdmissionController(c, controller)
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,276 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/config"
+	"github.com/perses/perses/internal/api/interface/v1/sharetoken"
+	"github.com/perses/perses/internal/api/shared"
+	"github.com/perses/perses/internal/api/shared/auth"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	bearerPrefix = "Bearer "
+	// maxShareTokenFormSize is the size of the form read to verify the queries made with a share token.
+	maxShareTokenFormSize = 1 << 20
+)
+
+var (
+	// authenticatedPathPrefixes are the paths requiring an identity. The frontend assets remain public.
+	authenticatedPathPrefixes = []string{"/api", "/proxy"}
+	// anonymousPaths are the paths that can be reached without any identity, so the probes keep working.
+	anonymousPaths = []string{fmt.Sprintf("%s/health", shared.APIV1Prefix)}
+	// anonymousPathPrefixes are the paths used to log in, and the paths reached with a share token.
+	anonymousPathPrefixes = []string{"/api/auth/", "/api/public/"}
+)
+
+// HeaderAuthentication trusts the identity headers set by an authenticating reverse proxy, but only when the request
+// is coming from one of the trusted networks. The identity is then available with shared.GetIdentity.
+func HeaderAuthentication(conf *config.HeaderAuthentication) (echo.MiddlewareFunc, error) {
+	networks, err := conf.GetTrustedNetworks()
+	if err != nil {
+		return nil, err
+	}
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			if shared.GetIdentity(c) != nil || !requireAuthentication(req.URL.Path) {
+				return next(c)
+			}
+			// The remote address is used rather than c.RealIP(), since X-Forwarded-For can be set by anyone.
+			remoteIP := extractIP(req.RemoteAddr)
+			if !isTrusted(remoteIP, networks) {
+				logrus.Debugf("request coming from the untrusted address %q, identity headers are ignored", req.RemoteAddr)
+				return next(c)
+			}
+			username := strings.TrimSpace(req.Header.Get(conf.UserHeader))
+			if len(username) == 0 {
+				logrus.Debugf("header %s is missing in the request coming from %q", conf.UserHeader, req.RemoteAddr)
+				return next(c)
+			}
+			shared.SetIdentity(c, &shared.Identity{
+				Username: username,
+				Email:    strings.TrimSpace(req.Header.Get(conf.EmailHeader)),
+				Groups:   splitGroups(req.Header.Get(conf.GroupsHeader)),
+			})
+			return next(c)
+		}
+	}, nil
+}
+
+// TokenAuthentication verifies the access token delivered when the user has logged in. The token is read from the
+// Authorization header or from the cookie set for the frontend.
+func TokenAuthentication(token auth.Token) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if shared.GetIdentity(c) != nil || !requireAuthentication(c.Request().URL.Path) {
+				return next(c)
+			}
+			signedToken := extractToken(c)
+			if len(signedToken) == 0 {
+				return next(c)
+			}
+			identity, err := token.Parse(signedToken)
+			if err != nil {
+				logrus.WithError(err).Debug("invalid access token")
+				return echo.NewHTTPError(http.StatusUnauthorized, "invalid or expired access token")
+			}
+			shared.SetIdentity(c, identity)
+			return next(c)
+		}
+	}
+}
+
+// ShareTokenAuthentication lets the anonymous requests presenting a share token reach the proxy of the datasources
+// used by the shared dashboard, with the queries of the dashboard only. The token is then available with
+// shared.GetShareToken.
+func ShareTokenAuthentication(svc sharetoken.Service) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			token := req.Header.Get(shared.ShareTokenHeader)
+			if shared.GetIdentity(c) != nil || len(token) == 0 {
+				return next(c)
+			}
+			// Any other path requiring an authentication is rejected by RequireIdentity.
+			proxyRequest, ok := matchShareTokenProxy(req.URL.Path)
+			if !ok {
+				return next(c)
+			}
+			shareToken, err := svc.Verify(token)
+			if err != nil {
+				return err
+			}
+			if proxyRequest.Parameters, err = readQueryParameters(req); err != nil {
+				return err
+			}
+			if err := svc.AuthorizeProxyRequest(shareToken, proxyRequest); err != nil {
+				return err
+			}
+			shared.SetShareToken(c, shareToken)
+			return next(c)
+		}
+	}
+}
+
+// CustomAuthentication identifies the user with a function provided when Perses is embedded in another application.
+// The function returns a nil identity when it cannot identify the user, so the next authentication methods are tried.
+func CustomAuthentication(authenticate func(c echo.Context) (*shared.Identity, error)) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if shared.GetIdentity(c) != nil || !requireAuthentication(c.Request().URL.Path) {
+				return next(c)
+			}
+			identity, err := authenticate(c)
+			if err != nil {
+				return err
+			}
+			if identity != nil {
+				shared.SetIdentity(c, identity)
+			}
+			return next(c)
+		}
+	}
+}
+
+// RequireIdentity rejects the requests that none of the authentication methods has been able to identify.
+func RequireIdentity() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if shared.GetIdentity(c) == nil && shared.GetShareToken(c) == nil && requireAuthentication(c.Request().URL.Path) {
+				return echo.NewHTTPError(http.StatusUnauthorized, "authentication required")
+			}
+			return next(c)
+		}
+	}
+}
+
+func requireAuthentication(path string) bool {
+	for _, anonymousPath := range anonymousPaths {
+		if path == anonymousPath {
+			return false
+		}
+	}
+	for _, prefix := range anonymousPathPrefixes {
+		if strings.HasPrefix(path, prefix) {
+			return false
+		}
+	}
+	for _, prefix := range authenticatedPathPrefixes {
+		if path == prefix || strings.HasPrefix(path, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func extractToken(c echo.Context) string {
+	if header := c.Request().Header.Get(echo.HeaderAuthorization); strings.HasPrefix(header, bearerPrefix) {
+		return strings.TrimSpace(strings.TrimPrefix(header, bearerPrefix))
+	}
+	if cookie, err := c.Cookie(auth.CookieName); err == nil {
+		return cookie.Value
+	}
+	return ""
+}
+
+func extractIP(remoteAddr string) net.IP {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		// the address doesn't contain any port
+		host = remoteAddr
+	}
+	return net.ParseIP(host)
+}
+
+func isTrusted(ip net.IP, networks []*net.IPNet) bool {
+	if ip == nil {
+		return false
+	}
+	for _, network := range networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func splitGroups(value string) []string {
+	var groups []string
+	for _, group := range strings.Split(value, ",") {
+		if group = strings.TrimSpace(group); len(group) > 0 {
+			groups = append(groups, group)
+		}
+	}
+	return groups
+}
+
+// matchShareTokenProxy returns the datasource targeted by any of the proxy paths.
+func matchShareTokenProxy(path string) (*sharetoken.ProxyRequest, bool) {
+	if matches := localProxyMatcher.FindStringSubmatch(path); matches != nil {
+		return &sharetoken.ProxyRequest{Project: matches[1], Datasource: matches[2], Path: matches[3]}, true
+	}
+	if matches := projectGlobalProxyMatcher.FindStringSubmatch(path); matches != nil {
+		return &sharetoken.ProxyRequest{Project: matches[1], Global: true, Datasource: matches[2], Path: matches[3]}, true
+	}
+	if matches := orgProxyMatcher.FindStringSubmatch(path); matches != nil {
+		return &sharetoken.ProxyRequest{Organization: matches[1], Datasource: matches[2], Path: matches[3]}, true
+	}
+	if matches := globalProxyMatcher.FindStringSubmatch(path); matches != nil {
+		return &sharetoken.ProxyRequest{Global: true, Datasource: matches[1], Path: matches[2]}, true
+	}
+	return nil, false
+}
+
+// readQueryParameters returns the parameters of the URL and of the form sent with POST. The body is restored so it
+// can still be forwarded to the datasource.
+func readQueryParameters(req *http.Request) (url.Values, error) {
+	params := req.URL.Query()
+	switch req.Method {
+	case http.MethodGet, http.MethodHead:
+		return params, nil
+	case http.MethodPost:
+		if !strings.HasPrefix(req.Header.Get(echo.HeaderContentType), echo.MIMEApplicationForm) {
+			return nil, echo.NewHTTPError(http.StatusForbidden, "only the queries sent as a form can be made with a share token")
+		}
+	default:
+		return nil, echo.NewHTTPError(http.StatusForbidden, "only the queries can be made with a share token")
+	}
+	body, err := io.ReadAll(io.LimitReader(req.Body, maxShareTokenFormSize+1))
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "unable to read the body of the request")
+	}
+	if len(body) > maxShareTokenFormSize {
+		return nil, echo.NewHTTPError(http.StatusRequestEntityTooLarge, "the body of the request is too large")
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "invalid form")
+	}
+	for key, values := range form {
+		params[key] = append(params[key], values...)
+	}
+	return params, nil
+}
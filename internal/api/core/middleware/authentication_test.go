@@ -0,0 +1,383 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/config"
+	"github.com/perses/perses/internal/api/interface/v1/sharetoken"
+	"github.com/perses/perses/internal/api/shared"
+	"github.com/perses/perses/internal/api/shared/auth"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeShareToken knows the token "perses.wall.secret" sharing the dashboard "incident" querying "up" with the
+// datasource "prom", local or global, through the proxy of the project. The token "perses.wall.busy" has reached its rate limit.
+type fakeShareToken struct {
+	sharetoken.Service
+}
+
+func (f *fakeShareToken) Verify(token string) (*v1.ShareToken, error) {
+	switch token {
+	case "perses.wall.secret":
+		return &v1.ShareToken{
+			Kind:     v1.KindShareToken,
+			Metadata: *v1.NewProjectMetadata("perses", "wall"),
+			Spec:     v1.ShareTokenSpec{Dashboard: "incident"},
+		}, nil
+	case "perses.wall.busy":
+		return nil, sharetoken.ErrRateLimited
+	default:
+		return nil, sharetoken.ErrInvalidToken
+	}
+}
+
+func (f *fakeShareToken) AuthorizeProxyRequest(shareToken *v1.ShareToken, request *sharetoken.ProxyRequest) error {
+	if request.Project != shareToken.Metadata.Project || request.Datasource != "prom" {
+		return echo.NewHTTPError(http.StatusForbidden, "the share token doesn't give access to this datasource")
+	}
+	if request.Path != "/api/v1/query" || request.Parameters.Get("query") != "up" {
+		return echo.NewHTTPError(http.StatusForbidden, "the query is not one of the dashboard shared with the token")
+	}
+	return nil
+}
+
+type authTestCase struct {
+	title            string
+	path             string
+	remoteAddr       string
+	headers          map[string]string
+	expectedStatus   int
+	expectedIdentity *shared.Identity
+}
+
+func runAuthTestCases(t *testing.T, middlewares []echo.MiddlewareFunc, testSuites []authTestCase) {
+	for _, test := range testSuites {
+		t.Run(test.title, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, test.path, nil)
+			req.RemoteAddr = test.remoteAddr
+			for key, value := range test.headers {
+				req.Header.Set(key, value)
+			}
+			c := e.NewContext(req, httptest.NewRecorder())
+			var identity *shared.Identity
+			handler := func(c echo.Context) error {
+				identity = shared.GetIdentity(c)
+				return c.NoContent(http.StatusOK)
+			}
+			for i := len(middlewares) - 1; i >= 0; i-- {
+				handler = middlewares[i](handler)
+			}
+			err := handler(c)
+			if test.expectedStatus == http.StatusOK {
+				assert.NoError(t, err)
+			} else {
+				httpErr, ok := err.(*echo.HTTPError)
+				assert.True(t, ok)
+				assert.Equal(t, test.expectedStatus, httpErr.Code)
+			}
+			assert.Equal(t, test.expectedIdentity, identity)
+		})
+	}
+}
+
+func TestHeaderAuthentication(t *testing.T) {
+	conf := &config.HeaderAuthentication{TrustedCIDRs: []string{"10.0.0.0/8"}}
+	assert.NoError(t, conf.Verify())
+	authMiddleware, err := HeaderAuthentication(conf)
+	assert.NoError(t, err)
+
+	runAuthTestCases(t, []echo.MiddlewareFunc{authMiddleware, RequireIdentity()}, []authTestCase{
+		{
+			title:      "trusted proxy",
+			path:       "/api/v1/projects",
+			remoteAddr: "10.1.2.3:4567",
+			headers: map[string]string{
+				"X-Forwarded-User":   "jdoe",
+				"X-Forwarded-Email":  "jdoe@example.com",
+				"X-Forwarded-Groups": "admin, dev,",
+			},
+			expectedStatus: http.StatusOK,
+			expectedIdentity: &shared.Identity{
+				Username: "jdoe",
+				Email:    "jdoe@example.com",
+				Groups:   []string{"admin", "dev"},
+			},
+		},
+		{
+			title:          "untrusted source",
+			path:           "/api/v1/projects",
+			remoteAddr:     "192.168.1.1:4567",
+			headers:        map[string]string{"X-Forwarded-User": "jdoe", "X-Forwarded-For": "10.1.2.3"},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			title:          "missing user header",
+			path:           "/proxy/globaldatasources/prom/api/v1/query",
+			remoteAddr:     "10.1.2.3:4567",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			title:          "api outside of v1",
+			path:           "/api/config",
+			remoteAddr:     "192.168.1.1:4567",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			title:          "health is anonymous",
+			path:           "/api/v1/health",
+			remoteAddr:     "192.168.1.1:4567",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			title:          "frontend is anonymous",
+			path:           "/projects/perses",
+			remoteAddr:     "192.168.1.1:4567",
+			expectedStatus: http.StatusOK,
+		},
+	})
+}
+
+func TestTokenAuthentication(t *testing.T) {
+	session := config.Session{Key: "01234567890123456789012345678901"}
+	assert.NoError(t, session.Verify())
+	token := auth.NewToken(session)
+	identity := &shared.Identity{Username: "alice", Groups: []string{"devs"}}
+	signedToken, _, err := token.Sign(identity)
+	assert.NoError(t, err)
+
+	runAuthTestCases(t, []echo.MiddlewareFunc{TokenAuthentication(token), RequireIdentity()}, []authTestCase{
+		{
+			title:            "bearer token",
+			path:             "/api/v1/projects",
+			headers:          map[string]string{echo.HeaderAuthorization: "Bearer " + signedToken},
+			expectedStatus:   http.StatusOK,
+			expectedIdentity: identity,
+		},
+		{
+			title:            "cookie",
+			path:             "/api/v1/projects",
+			headers:          map[string]string{"Cookie": auth.CookieName + "=" + signedToken},
+			expectedStatus:   http.StatusOK,
+			expectedIdentity: identity,
+		},
+		{
+			title:          "invalid token",
+			path:           "/api/v1/projects",
+			headers:        map[string]string{echo.HeaderAuthorization: "Bearer " + signedToken + "x"},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			title:          "no token",
+			path:           "/api/v1/projects",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			title:          "login is anonymous",
+			path:           "/api/auth/providers/ldap/login",
+			expectedStatus: http.StatusOK,
+		},
+	})
+}
+
+func TestCustomAuthentication(t *testing.T) {
+	authenticate := func(c echo.Context) (*shared.Identity, error) {
+		switch c.Request().Header.Get("X-Api-Key") {
+		case "":
+			return nil, nil
+		case "secret":
+			return &shared.Identity{Username: "robot"}, nil
+		default:
+			return nil, echo.NewHTTPError(http.StatusForbidden, "invalid api key")
+		}
+	}
+
+	runAuthTestCases(t, []echo.MiddlewareFunc{CustomAuthentication(authenticate), RequireIdentity()}, []authTestCase{
+		{
+			title:            "identified",
+			path:             "/api/v1/projects",
+			headers:          map[string]string{"X-Api-Key": "secret"},
+			expectedStatus:   http.StatusOK,
+			expectedIdentity: &shared.Identity{Username: "robot"},
+		},
+		{
+			title:          "rejected",
+			path:           "/api/v1/projects",
+			headers:        map[string]string{"X-Api-Key": "wrong"},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			title:          "not identified",
+			path:           "/api/v1/projects",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			title:          "health is anonymous",
+			path:           "/api/v1/health",
+			headers:        map[string]string{"X-Api-Key": "wrong"},
+			expectedStatus: http.StatusOK,
+		},
+	})
+}
+
+func TestShareTokenAuthentication(t *testing.T) {
+	runAuthTestCases(t, []echo.MiddlewareFunc{ShareTokenAuthentication(&fakeShareToken{}), RequireIdentity()}, []authTestCase{
+		{
+			title:          "datasource used by the shared dashboard",
+			path:           "/proxy/projects/perses/datasources/prom/api/v1/query?query=up",
+			headers:        map[string]string{shared.ShareTokenHeader: "perses.wall.secret"},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			title:          "query not used by the shared dashboard",
+			path:           "/proxy/projects/perses/datasources/prom/api/v1/query?query=secret",
+			headers:        map[string]string{shared.ShareTokenHeader: "perses.wall.secret"},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			title:          "other endpoint of the datasource",
+			path:           "/proxy/projects/perses/datasources/prom/api/v1/admin/tsdb/snapshot?query=up",
+			headers:        map[string]string{shared.ShareTokenHeader: "perses.wall.secret"},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			title:          "datasource not used by the shared dashboard",
+			path:           "/proxy/projects/perses/datasources/loki/api/v1/query?query=up",
+			headers:        map[string]string{shared.ShareTokenHeader: "perses.wall.secret"},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			title:          "datasource of another project",
+			path:           "/proxy/projects/other/datasources/prom/api/v1/query?query=up",
+			headers:        map[string]string{shared.ShareTokenHeader: "perses.wall.secret"},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			title:          "global datasource used by the shared dashboard",
+			path:           "/proxy/projects/perses/globaldatasources/prom/api/v1/query?query=up",
+			headers:        map[string]string{shared.ShareTokenHeader: "perses.wall.secret"},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			title:          "global datasource outside the project",
+			path:           "/proxy/globaldatasources/prom/api/v1/query?query=up",
+			headers:        map[string]string{shared.ShareTokenHeader: "perses.wall.secret"},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			title:          "global datasource of another project",
+			path:           "/proxy/projects/other/globaldatasources/prom/api/v1/query?query=up",
+			headers:        map[string]string{shared.ShareTokenHeader: "perses.wall.secret"},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			title:          "datasource of an organization",
+			path:           "/proxy/organizations/acme/datasources/prom/api/v1/query?query=up",
+			headers:        map[string]string{shared.ShareTokenHeader: "perses.wall.secret"},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			title:          "invalid token",
+			path:           "/proxy/projects/perses/datasources/prom/api/v1/query?query=up",
+			headers:        map[string]string{shared.ShareTokenHeader: "perses.wall.wrong"},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			title:          "rate limited token",
+			path:           "/proxy/projects/perses/datasources/prom/api/v1/query?query=up",
+			headers:        map[string]string{shared.ShareTokenHeader: "perses.wall.busy"},
+			expectedStatus: http.StatusTooManyRequests,
+		},
+		{
+			title:          "api is not reachable with a token",
+			path:           "/api/v1/projects/perses/dashboards/incident",
+			headers:        map[string]string{shared.ShareTokenHeader: "perses.wall.secret"},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			title:          "public api is anonymous",
+			path:           "/api/public/dashboard",
+			expectedStatus: http.StatusOK,
+		},
+	})
+}
+
+func TestShareTokenAuthenticationForm(t *testing.T) {
+	middleware := ShareTokenAuthentication(&fakeShareToken{})
+	testSuites := []struct {
+		title          string
+		method         string
+		contentType    string
+		body           string
+		expectedStatus int
+	}{
+		{
+			title:          "query sent as a form",
+			method:         http.MethodPost,
+			contentType:    echo.MIMEApplicationForm,
+			body:           "query=up&start=1",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			title:          "query not used by the shared dashboard sent as a form",
+			method:         http.MethodPost,
+			contentType:    echo.MIMEApplicationForm,
+			body:           "query=secret",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			title:          "query sent as JSON",
+			method:         http.MethodPost,
+			contentType:    echo.MIMEApplicationJSON,
+			body:           `{"query":"up"}`,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			title:          "modification",
+			method:         http.MethodDelete,
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+	for _, test := range testSuites {
+		t.Run(test.title, func(t *testing.T) {
+			req := httptest.NewRequest(test.method, "/proxy/projects/perses/datasources/prom/api/v1/query", strings.NewReader(test.body))
+			req.Header.Set(echo.HeaderContentType, test.contentType)
+			req.Header.Set(shared.ShareTokenHeader, "perses.wall.secret")
+			c := echo.New().NewContext(req, httptest.NewRecorder())
+			var forwardedBody string
+			err := middleware(func(c echo.Context) error {
+				// the body must still be available for the proxy
+				body, readErr := io.ReadAll(c.Request().Body)
+				forwardedBody = string(body)
+				return readErr
+			})(c)
+			if test.expectedStatus == http.StatusOK {
+				assert.NoError(t, err)
+				assert.Equal(t, test.body, forwardedBody)
+			} else {
+				httpErr, ok := err.(*echo.HTTPError)
+				assert.True(t, ok)
+				assert.Equal(t, test.expectedStatus, httpErr.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,223 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/interface/v1/access"
+	"github.com/perses/perses/internal/api/shared"
+	"github.com/perses/perses/internal/api/shared/auth"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+// DashboardHeader is the header used to tell which dashboard is querying a datasource through the proxy,
+// so the grants of the dashboard are considered.
+const DashboardHeader = "X-Perses-Dashboard"
+
+var (
+	projectPathMatcher   = regexp.MustCompile(`^/api/v1/projects/([a-zA-Z0-9_-]+)(/.*)?$`)
+	dashboardViewPath    = regexp.MustCompile(`^/dashboards/[a-zA-Z0-9_-]+/view$`)
+	dashboardPathMatcher = regexp.MustCompile(`^/dashboards/([a-zA-Z0-9_-]+)(/view|/access|/grants|/merge)?$`)
+	// draftPathMatcher matches the drafts of the dashboards, only available to the ones allowed to modify the dashboards.
+	draftPathMatcher = regexp.MustCompile(`^/(drafts|dashboards/[a-zA-Z0-9_-]+/draft)(/.*)?$`)
+	// globalDatasourceOverridePathMatcher matches the overrides of the global datasources, only modified by the admins of
+	// the project since they change a datasource shared with the other projects.
+	globalDatasourceOverridePathMatcher = regexp.MustCompile(`^/globaldatasourceoverrides(/.*)?$`)
+	// organizationResourcePathMatcher matches the datasources and the variables of an organization.
+	organizationResourcePathMatcher = regexp.MustCompile(`^/api/v1/organizations/([a-zA-Z0-9_-]+)/(datasources|variables)(/.*)?$`)
+	organizationProjectPathMatcher  = regexp.MustCompile(`^/api/v1/organizations/([a-zA-Z0-9_-]+)/projects(/([a-zA-Z0-9_-]+))?$`)
+	globalResourcePaths             = []string{
+		fmt.Sprintf("%s/%s", shared.APIV1Prefix, shared.PathProject),
+		fmt.Sprintf("%s/%s", shared.APIV1Prefix, shared.PathGlobalDashboard),
+		fmt.Sprintf("%s/%s", shared.APIV1Prefix, shared.PathGlobalDatasource),
+		fmt.Sprintf("%s/%s", shared.APIV1Prefix, shared.PathGlobalHomeConfig),
+		fmt.Sprintf("%s/%s", shared.APIV1Prefix, shared.PathGlobalVariable),
+		fmt.Sprintf("%s/%s", shared.APIV1Prefix, shared.PathOrganization),
+		fmt.Sprintf("%s/%s", shared.APIV1Prefix, shared.PathTeam),
+	}
+	// sharedGlobalResourcePaths are the global resources only available to the projects of the default organization.
+	sharedGlobalResourcePaths = []string{
+		fmt.Sprintf("%s/%s", shared.APIV1Prefix, shared.PathGlobalDatasource),
+		fmt.Sprintf("%s/%s", shared.APIV1Prefix, shared.PathGlobalVariable),
+	}
+)
+
+// Authorize checks the authenticated user has the role required by the request on the project or the organization
+// targeted. The requests that are not related to a project or to an organization only require the user to be
+// authenticated, except the modifications of the global resources that require to be admin of all projects.
+// The global datasources and variables are only available to the projects of the default organization, so reading them
+// requires the role viewer on this organization.
+// When the role on the project is missing, the grants of the dashboard targeted by the request are considered.
+// The global datasources queried outside a project ignore the overrides of the projects, so this path is only available
+// to the users whose permissions are not limited to some projects.
+func Authorize(authorization auth.Authorization, accessService access.Service) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			identity := shared.GetIdentity(c)
+			if identity == nil {
+				// the path is anonymous, otherwise the request would have been rejected by RequireIdentity
+				return next(c)
+			}
+			// the resources created or listed outside a project path are checked by the toolbox
+			shared.SetPermissionChecker(c, authorization)
+			if globalProxyMatcher.MatchString(c.Request().URL.Path) && !isGlobalViewer(authorization, identity) {
+				return echo.NewHTTPError(http.StatusForbidden, "the global datasources must be queried through the proxy of a project, so its overrides apply")
+			}
+			permission, ok := requiredPermission(c.Request().Method, c.Request().URL.Path)
+			if !ok || isAllowed(authorization, identity, permission) {
+				return next(c)
+			}
+			if len(permission.Organization) > 0 {
+				return echo.NewHTTPError(http.StatusForbidden, fmt.Sprintf("the role %s on the organization %q is required", permission.Role, permission.Organization))
+			}
+			if isGrantedByDashboard(c, accessService, identity, permission.Project) {
+				return next(c)
+			}
+			if permission.Project == v1.WildcardProject {
+				return echo.NewHTTPError(http.StatusForbidden, fmt.Sprintf("the role %s on every project is required", permission.Role))
+			}
+			return echo.NewHTTPError(http.StatusForbidden, fmt.Sprintf("the role %s on the project %q is required", permission.Role, permission.Project))
+		}
+	}
+}
+
+func isAllowed(authorization auth.Authorization, identity *shared.Identity, permission v1.Permission) bool {
+	if len(permission.Organization) > 0 {
+		return authorization.HasOrganizationPermission(identity, permission.Organization, permission.Role)
+	}
+	return authorization.HasPermission(identity, permission.Project, permission.Role)
+}
+
+// isGlobalViewer returns true when the user can read the organization default through a permission on the organization
+// itself, and not only through a permission on one of its projects.
+func isGlobalViewer(authorization auth.Authorization, identity *shared.Identity) bool {
+	if !authorization.IsEnabled() {
+		return true
+	}
+	for _, permission := range authorization.GetPermissions(identity) {
+		if permission.AllowsOrganization(v1.DefaultOrganization, v1.RoleViewer) {
+			return true
+		}
+	}
+	return false
+}
+
+// requiredPermission returns the permission required by the request, or false when being authenticated is enough.
+func requiredPermission(method string, path string) (v1.Permission, bool) {
+	readonly := method == http.MethodGet || method == http.MethodHead
+	// querying the datasources doesn't modify anything, whatever the method is
+	if project, _, ok := matchProjectProxy(path); ok {
+		return v1.Permission{Project: project, Role: v1.RoleViewer}, true
+	}
+	if matches := orgProxyMatcher.FindStringSubmatch(path); matches != nil {
+		return v1.Permission{Organization: matches[1], Role: v1.RoleViewer}, true
+	}
+	if globalProxyMatcher.MatchString(path) {
+		return v1.Permission{Organization: v1.DefaultOrganization, Role: v1.RoleViewer}, true
+	}
+	if matches := projectPathMatcher.FindStringSubmatch(path); matches != nil {
+		project, subPath := matches[1], matches[2]
+		switch {
+		case draftPathMatcher.MatchString(subPath):
+			return v1.Permission{Project: project, Role: v1.RoleEditor}, true
+		case readonly:
+			return v1.Permission{Project: project, Role: v1.RoleViewer}, true
+		case len(subPath) == 0 || globalDatasourceOverridePathMatcher.MatchString(subPath):
+			// modification of the project itself, or of a datasource shared with the other projects
+			return v1.Permission{Project: project, Role: v1.RoleAdmin}, true
+		case method == http.MethodPost && dashboardViewPath.MatchString(subPath):
+			return v1.Permission{Project: project, Role: v1.RoleViewer}, true
+		default:
+			return v1.Permission{Project: project, Role: v1.RoleEditor}, true
+		}
+	}
+	if matches := organizationResourcePathMatcher.FindStringSubmatch(path); matches != nil {
+		if readonly {
+			return v1.Permission{Organization: matches[1], Role: v1.RoleViewer}, true
+		}
+		return v1.Permission{Organization: matches[1], Role: v1.RoleAdmin}, true
+	}
+	if matches := organizationProjectPathMatcher.FindStringSubmatch(path); matches != nil {
+		project := matches[3]
+		switch {
+		case readonly:
+			// the list of the projects is filtered according to the permissions of the user
+			return v1.Permission{}, false
+		case len(project) == 0:
+			return v1.Permission{Organization: matches[1], Role: v1.RoleAdmin}, true
+		default:
+			return v1.Permission{Project: project, Role: v1.RoleAdmin}, true
+		}
+	}
+	if readonly {
+		for _, globalPath := range sharedGlobalResourcePaths {
+			if path == globalPath || strings.HasPrefix(path, globalPath+"/") {
+				return v1.Permission{Organization: v1.DefaultOrganization, Role: v1.RoleViewer}, true
+			}
+		}
+		return v1.Permission{}, false
+	}
+	for _, globalPath := range globalResourcePaths {
+		if path == globalPath || strings.HasPrefix(path, globalPath+"/") {
+			return v1.Permission{Project: v1.WildcardProject, Role: v1.RoleAdmin}, true
+		}
+	}
+	return v1.Permission{}, false
+}
+
+// isGrantedByDashboard returns true when a grant on the dashboard targeted by the request gives enough access.
+// For the proxy, the dashboard is given by the header DashboardHeader, and the datasource must be used by the dashboard.
+func isGrantedByDashboard(c echo.Context, accessService access.Service, identity *shared.Identity, project string) bool {
+	request := c.Request()
+	if _, datasourceName, ok := matchProjectProxy(request.URL.Path); ok {
+		dashboard := request.Header.Get(DashboardHeader)
+		if len(dashboard) == 0 {
+			return false
+		}
+		return accessService.GetRole(identity, project, dashboard).Includes(v1.RoleViewer) &&
+			accessService.UsesDatasource(project, dashboard, datasourceName)
+	}
+	matches := projectPathMatcher.FindStringSubmatch(request.URL.Path)
+	if matches == nil {
+		return false
+	}
+	dashboard, role, ok := requiredDashboardRole(request.Method, matches[2])
+	return ok && accessService.GetRole(identity, project, dashboard).Includes(role)
+}
+
+// requiredDashboardRole returns the role that must be granted on the dashboard to perform the request.
+// subPath is the path following the project. Only reading, updating and viewing a dashboard can be granted.
+func requiredDashboardRole(method string, subPath string) (string, v1.Role, bool) {
+	matches := dashboardPathMatcher.FindStringSubmatch(subPath)
+	if matches == nil {
+		return "", "", false
+	}
+	readonly := method == http.MethodGet || method == http.MethodHead
+	dashboard, action := matches[1], matches[2]
+	switch {
+	case readonly:
+		return dashboard, v1.RoleViewer, true
+	case (len(action) == 0 || action == "/merge") && method == http.MethodPut:
+		return dashboard, v1.RoleEditor, true
+	case action == "/view" && method == http.MethodPost:
+		return dashboard, v1.RoleViewer, true
+	default:
+		return "", "", false
+	}
+}
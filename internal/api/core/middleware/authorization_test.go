@@ -0,0 +1,249 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/config"
+	"github.com/perses/perses/internal/api/interface/v1/access"
+	"github.com/perses/perses/internal/api/shared"
+	"github.com/perses/perses/internal/api/shared/auth"
+	databaseModel "github.com/perses/perses/internal/api/shared/database/model"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeAccess grants the role viewer on the dashboard "incident" of the project "perses" to the group "partners".
+// The dashboard is only using the datasource "prom".
+type fakeAccess struct {
+	access.Service
+}
+
+func (f *fakeAccess) GetRole(identity *shared.Identity, project string, dashboard string) v1.Role {
+	spec := v1.DashboardAccessSpec{Grants: []v1.DashboardGrant{{Group: "partners", Role: v1.RoleViewer}}}
+	if project != "perses" || dashboard != "incident" {
+		return ""
+	}
+	return spec.GetRole(identity.Username, identity.Groups)
+}
+
+func (f *fakeAccess) UsesDatasource(project string, dashboard string, datasource string) bool {
+	return project == "perses" && dashboard == "incident" && datasource == "prom"
+}
+
+func TestRequiredPermission(t *testing.T) {
+	testSuites := []struct {
+		method             string
+		path               string
+		expectedPermission v1.Permission
+		expectedOK         bool
+	}{
+		{method: http.MethodGet, path: "/api/v1/projects/perses/dashboards", expectedPermission: v1.Permission{Project: "perses", Role: v1.RoleViewer}, expectedOK: true},
+		{method: http.MethodPost, path: "/api/v1/projects/perses/dashboards", expectedPermission: v1.Permission{Project: "perses", Role: v1.RoleEditor}, expectedOK: true},
+		{method: http.MethodGet, path: "/api/v1/projects/perses/drafts", expectedPermission: v1.Permission{Project: "perses", Role: v1.RoleEditor}, expectedOK: true},
+		{method: http.MethodGet, path: "/api/v1/projects/perses/dashboards/demo/draft/diff", expectedPermission: v1.Permission{Project: "perses", Role: v1.RoleEditor}, expectedOK: true},
+		{method: http.MethodPost, path: "/api/v1/projects/perses/dashboards/cpu/view", expectedPermission: v1.Permission{Project: "perses", Role: v1.RoleViewer}, expectedOK: true},
+		{method: http.MethodPost, path: "/api/v1/projects/perses/dashboards/cpu/rename", expectedPermission: v1.Permission{Project: "perses", Role: v1.RoleEditor}, expectedOK: true},
+		{method: http.MethodDelete, path: "/api/v1/projects/perses", expectedPermission: v1.Permission{Project: "perses", Role: v1.RoleAdmin}, expectedOK: true},
+		{method: http.MethodPost, path: "/proxy/projects/perses/datasources/prom/api/v1/query", expectedPermission: v1.Permission{Project: "perses", Role: v1.RoleViewer}, expectedOK: true},
+		{method: http.MethodPost, path: "/proxy/projects/perses/globaldatasources/prom/api/v1/query", expectedPermission: v1.Permission{Project: "perses", Role: v1.RoleViewer}, expectedOK: true},
+		{method: http.MethodGet, path: "/api/v1/projects/perses/globaldatasourceoverrides/prom", expectedPermission: v1.Permission{Project: "perses", Role: v1.RoleViewer}, expectedOK: true},
+		{method: http.MethodPut, path: "/api/v1/projects/perses/globaldatasourceoverrides/prom", expectedPermission: v1.Permission{Project: "perses", Role: v1.RoleAdmin}, expectedOK: true},
+		{method: http.MethodPost, path: "/api/v1/projects/perses/globaldatasourceoverrides", expectedPermission: v1.Permission{Project: "perses", Role: v1.RoleAdmin}, expectedOK: true},
+		{method: http.MethodPost, path: "/api/v1/projects", expectedPermission: v1.Permission{Project: v1.WildcardProject, Role: v1.RoleAdmin}, expectedOK: true},
+		{method: http.MethodPut, path: "/api/v1/globaldatasources/prom", expectedPermission: v1.Permission{Project: v1.WildcardProject, Role: v1.RoleAdmin}, expectedOK: true},
+		{method: http.MethodPost, path: "/api/v1/globalvariables/job/rename", expectedPermission: v1.Permission{Project: v1.WildcardProject, Role: v1.RoleAdmin}, expectedOK: true},
+		{method: http.MethodPut, path: "/api/v1/teams/observability", expectedPermission: v1.Permission{Project: v1.WildcardProject, Role: v1.RoleAdmin}, expectedOK: true},
+		{method: http.MethodDelete, path: "/api/v1/teams/observability", expectedPermission: v1.Permission{Project: v1.WildcardProject, Role: v1.RoleAdmin}, expectedOK: true},
+		{method: http.MethodGet, path: "/api/v1/teams", expectedOK: false},
+		{method: http.MethodGet, path: "/api/v1/globaldatasources", expectedPermission: v1.Permission{Organization: v1.DefaultOrganization, Role: v1.RoleViewer}, expectedOK: true},
+		{method: http.MethodGet, path: "/api/v1/globalvariables/job", expectedPermission: v1.Permission{Organization: v1.DefaultOrganization, Role: v1.RoleViewer}, expectedOK: true},
+		{method: http.MethodPost, path: "/proxy/globaldatasources/prom/api/v1/query", expectedPermission: v1.Permission{Organization: v1.DefaultOrganization, Role: v1.RoleViewer}, expectedOK: true},
+		{method: http.MethodGet, path: "/api/v1/globaldashboards", expectedOK: false},
+		{method: http.MethodGet, path: "/api/v1/organizations/acme/datasources", expectedPermission: v1.Permission{Organization: "acme", Role: v1.RoleViewer}, expectedOK: true},
+		{method: http.MethodPut, path: "/api/v1/organizations/acme/variables/job", expectedPermission: v1.Permission{Organization: "acme", Role: v1.RoleAdmin}, expectedOK: true},
+		{method: http.MethodPost, path: "/proxy/organizations/acme/datasources/prom/api/v1/query", expectedPermission: v1.Permission{Organization: "acme", Role: v1.RoleViewer}, expectedOK: true},
+		{method: http.MethodPost, path: "/api/v1/organizations/acme/projects", expectedPermission: v1.Permission{Organization: "acme", Role: v1.RoleAdmin}, expectedOK: true},
+		{method: http.MethodDelete, path: "/api/v1/organizations/acme/projects/perses", expectedPermission: v1.Permission{Project: "perses", Role: v1.RoleAdmin}, expectedOK: true},
+		{method: http.MethodGet, path: "/api/v1/organizations/acme/projects", expectedOK: false},
+		{method: http.MethodPut, path: "/api/v1/organizations/acme", expectedPermission: v1.Permission{Project: v1.WildcardProject, Role: v1.RoleAdmin}, expectedOK: true},
+		{method: http.MethodPost, path: "/api/validate/dashboards", expectedOK: false},
+	}
+	for _, test := range testSuites {
+		t.Run(test.method+" "+test.path, func(t *testing.T) {
+			permission, ok := requiredPermission(test.method, test.path)
+			assert.Equal(t, test.expectedOK, ok)
+			assert.Equal(t, test.expectedPermission, permission)
+		})
+	}
+}
+
+func TestRequiredDashboardRole(t *testing.T) {
+	testSuites := []struct {
+		method            string
+		subPath           string
+		expectedDashboard string
+		expectedRole      v1.Role
+		expectedOK        bool
+	}{
+		{method: http.MethodGet, subPath: "/dashboards/incident", expectedDashboard: "incident", expectedRole: v1.RoleViewer, expectedOK: true},
+		{method: http.MethodPut, subPath: "/dashboards/incident", expectedDashboard: "incident", expectedRole: v1.RoleEditor, expectedOK: true},
+		{method: http.MethodPut, subPath: "/dashboards/incident/merge", expectedDashboard: "incident", expectedRole: v1.RoleEditor, expectedOK: true},
+		{method: http.MethodPost, subPath: "/dashboards/incident/view", expectedDashboard: "incident", expectedRole: v1.RoleViewer, expectedOK: true},
+		{method: http.MethodGet, subPath: "/dashboards/incident/access", expectedDashboard: "incident", expectedRole: v1.RoleViewer, expectedOK: true},
+		{method: http.MethodDelete, subPath: "/dashboards/incident", expectedOK: false},
+		{method: http.MethodPut, subPath: "/dashboards/incident/grants", expectedOK: false},
+		{method: http.MethodGet, subPath: "/dashboards/incident/draft", expectedOK: false},
+		{method: http.MethodPost, subPath: "/dashboards/incident/rename", expectedOK: false},
+		{method: http.MethodGet, subPath: "/dashboards", expectedOK: false},
+		{method: http.MethodGet, subPath: "/datasources/prom", expectedOK: false},
+	}
+	for _, test := range testSuites {
+		t.Run(test.method+" "+test.subPath, func(t *testing.T) {
+			dashboard, role, ok := requiredDashboardRole(test.method, test.subPath)
+			assert.Equal(t, test.expectedOK, ok)
+			assert.Equal(t, test.expectedDashboard, dashboard)
+			assert.Equal(t, test.expectedRole, role)
+		})
+	}
+}
+
+func TestAuthorizeWithDashboardGrants(t *testing.T) {
+	conf := &config.HeaderAuthentication{TrustedCIDRs: []string{"10.0.0.0/8"}}
+	assert.NoError(t, conf.Verify())
+	authMiddleware, err := HeaderAuthentication(conf)
+	assert.NoError(t, err)
+	authorization := auth.NewAuthorization(config.Authorization{
+		Groups: []config.GroupPermissions{
+			{Group: "devs", Permissions: []v1.Permission{{Project: "perses", Role: v1.RoleViewer}}},
+		},
+	}, nil)
+	partner := &shared.Identity{Username: "jdoe", Groups: []string{"partners"}}
+	headers := func(extra map[string]string) map[string]string {
+		result := map[string]string{"X-Forwarded-User": "jdoe", "X-Forwarded-Groups": "partners"}
+		for key, value := range extra {
+			result[key] = value
+		}
+		return result
+	}
+	runAuthTestCases(t, []echo.MiddlewareFunc{authMiddleware, Authorize(authorization, &fakeAccess{})}, []authTestCase{
+		{
+			title:            "granted dashboard",
+			path:             "/api/v1/projects/perses/dashboards/incident",
+			remoteAddr:       "10.1.2.3:4567",
+			headers:          headers(nil),
+			expectedStatus:   http.StatusOK,
+			expectedIdentity: partner,
+		},
+		{
+			title:          "other dashboard of the project",
+			path:           "/api/v1/projects/perses/dashboards/cpu",
+			remoteAddr:     "10.1.2.3:4567",
+			headers:        headers(nil),
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			title:          "list of the dashboards",
+			path:           "/api/v1/projects/perses/dashboards",
+			remoteAddr:     "10.1.2.3:4567",
+			headers:        headers(nil),
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			title:            "datasource used by the granted dashboard",
+			path:             "/proxy/projects/perses/datasources/prom/api/v1/query",
+			remoteAddr:       "10.1.2.3:4567",
+			headers:          headers(map[string]string{DashboardHeader: "incident"}),
+			expectedStatus:   http.StatusOK,
+			expectedIdentity: partner,
+		},
+		{
+			title:          "datasource not used by the granted dashboard",
+			path:           "/proxy/projects/perses/datasources/loki/api/v1/query",
+			remoteAddr:     "10.1.2.3:4567",
+			headers:        headers(map[string]string{DashboardHeader: "incident"}),
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			title:          "proxy without dashboard",
+			path:           "/proxy/projects/perses/datasources/prom/api/v1/query",
+			remoteAddr:     "10.1.2.3:4567",
+			headers:        headers(nil),
+			expectedStatus: http.StatusForbidden,
+		},
+	})
+}
+
+// fakeProjects contains the project "perses" in the default organization.
+type fakeProjects struct{}
+
+func (f *fakeProjects) Find(name string) (*v1.Project, error) {
+	if name != "perses" {
+		return nil, &databaseModel.Error{Key: name, Code: databaseModel.ErrorCodeNotFound}
+	}
+	return &v1.Project{Kind: v1.KindProject, Metadata: v1.OrganizationMetadata{Metadata: v1.Metadata{Name: name}, Organization: v1.DefaultOrganization}}, nil
+}
+
+func TestAuthorizeGlobalProxy(t *testing.T) {
+	conf := &config.HeaderAuthentication{TrustedCIDRs: []string{"10.0.0.0/8"}}
+	assert.NoError(t, conf.Verify())
+	authMiddleware, err := HeaderAuthentication(conf)
+	assert.NoError(t, err)
+	authorization := auth.NewAuthorization(config.Authorization{
+		Groups: []config.GroupPermissions{
+			{Group: "devs", Permissions: []v1.Permission{{Project: "perses", Role: v1.RoleViewer}}},
+			{Group: "ops", Permissions: []v1.Permission{{Organization: v1.DefaultOrganization, Role: v1.RoleViewer}}},
+			{Group: "admins", Permissions: []v1.Permission{{Project: v1.WildcardProject, Role: v1.RoleViewer}}},
+		},
+	}, &fakeProjects{})
+	asGroup := func(group string) map[string]string {
+		return map[string]string{"X-Forwarded-User": "jdoe", "X-Forwarded-Groups": group}
+	}
+	runAuthTestCases(t, []echo.MiddlewareFunc{authMiddleware, Authorize(authorization, &fakeAccess{})}, []authTestCase{
+		{
+			title:            "global datasource through the proxy of the project",
+			path:             "/proxy/projects/perses/globaldatasources/prom/api/v1/query",
+			remoteAddr:       "10.1.2.3:4567",
+			headers:          asGroup("devs"),
+			expectedStatus:   http.StatusOK,
+			expectedIdentity: &shared.Identity{Username: "jdoe", Groups: []string{"devs"}},
+		},
+		{
+			title:          "global datasource without the overrides of the project",
+			path:           "/proxy/globaldatasources/prom/api/v1/query",
+			remoteAddr:     "10.1.2.3:4567",
+			headers:        asGroup("devs"),
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			title:            "global datasource queried by a viewer of the organization",
+			path:             "/proxy/globaldatasources/prom/api/v1/query",
+			remoteAddr:       "10.1.2.3:4567",
+			headers:          asGroup("ops"),
+			expectedStatus:   http.StatusOK,
+			expectedIdentity: &shared.Identity{Username: "jdoe", Groups: []string{"ops"}},
+		},
+		{
+			title:            "global datasource queried by a viewer of every project",
+			path:             "/proxy/globaldatasources/prom/api/v1/query",
+			remoteAddr:       "10.1.2.3:4567",
+			headers:          asGroup("admins"),
+			expectedStatus:   http.StatusOK,
+			expectedIdentity: &shared.Identity{Username: "jdoe", Groups: []string{"admins"}},
+		},
+	})
+}
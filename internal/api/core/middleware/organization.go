@@ -0,0 +1,76 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/interface/v1/project"
+	databaseModel "github.com/perses/perses/internal/api/shared/database/model"
+)
+
+const (
+	organizationContextKey = "perses.organization"
+	projectContextKey      = "perses.project"
+)
+
+// organizationProjectResourcePathMatcher matches the resources of a project, and its proxy, under the path of its organization.
+var organizationProjectResourcePathMatcher = regexp.MustCompile(`^(/api/v1|/proxy)/organizations/([a-zA-Z0-9_-]+)/projects/([a-zA-Z0-9_-]+)/(.+)$`)
+
+// RewriteOrganizationProjectPath is a middleware to register with echo.Echo.Pre, so it runs before the routing.
+// The resources of a project are available under the path of its organization:
+// /api/v1/organizations/<organization>/projects/<project>/<resource> is routed to /api/v1/projects/<project>/<resource>,
+// and the same for the proxy. CheckOrganizationProject verifies afterward that the project belongs to the organization.
+func RewriteOrganizationProjectPath() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			request := c.Request()
+			matches := organizationProjectResourcePathMatcher.FindStringSubmatch(request.URL.Path)
+			if matches == nil {
+				return next(c)
+			}
+			c.Set(organizationContextKey, matches[2])
+			c.Set(projectContextKey, matches[3])
+			request.URL.Path = fmt.Sprintf("%s/projects/%s/%s", matches[1], matches[3], matches[4])
+			if len(request.URL.RawPath) > 0 {
+				request.URL.RawPath = organizationProjectResourcePathMatcher.ReplaceAllString(request.URL.RawPath, "$1/projects/$3/$4")
+			}
+			return next(c)
+		}
+	}
+}
+
+// CheckOrganizationProject is a middleware that rejects the requests reaching a project through the path of another
+// organization than its own.
+func CheckOrganizationProject(projectDAO project.DAO) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			organizationName, ok := c.Get(organizationContextKey).(string)
+			if !ok {
+				return next(c)
+			}
+			projectName, _ := c.Get(projectContextKey).(string)
+			if _, err := projectDAO.Get(organizationName, projectName); err != nil {
+				if databaseModel.IsKeyNotFound(err) {
+					return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("project %q doesn't exist in the organization %q", projectName, organizationName))
+				}
+				return err
+			}
+			return next(c)
+		}
+	}
+}
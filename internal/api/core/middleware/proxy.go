@@ -20,11 +20,15 @@ import (
 	"net/http"
 	"net/http/httputil"
 	"regexp"
+	"strings"
 	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/perses/perses/internal/api/interface/v1/datasource"
 	"github.com/perses/perses/internal/api/interface/v1/globaldatasource"
+	"github.com/perses/perses/internal/api/interface/v1/globaldatasourceoverride"
+	"github.com/perses/perses/internal/api/interface/v1/organizationdatasource"
+	"github.com/perses/perses/internal/api/interface/v1/project"
 	databaseModel "github.com/perses/perses/internal/api/shared/database/model"
 	v1 "github.com/perses/perses/pkg/model/api/v1"
 	datasourceHTTP "github.com/perses/perses/pkg/model/api/v1/datasource/http"
@@ -34,12 +38,28 @@ import (
 var (
 	globalProxyMatcher = regexp.MustCompile(`/proxy/globaldatasources/([a-zA-Z-0-9_-]+)(/.*)?`)
 	localProxyMatcher  = regexp.MustCompile(`/proxy/projects/([a-zA-Z-0-9_-]+)/datasources/([a-zA-Z-0-9_-]+)(/.*)?`)
+	orgProxyMatcher    = regexp.MustCompile(`/proxy/organizations/([a-zA-Z-0-9_-]+)/datasources/([a-zA-Z-0-9_-]+)(/.*)?`)
+	// projectGlobalProxyMatcher is used to query a global datasource with the override of the project merged over it.
+	projectGlobalProxyMatcher = regexp.MustCompile(`/proxy/projects/([a-zA-Z-0-9_-]+)/globaldatasources/([a-zA-Z-0-9_-]+)(/.*)?`)
 )
 
-func Proxy(dts datasource.DAO, globalDTS globaldatasource.DAO) echo.MiddlewareFunc {
+// matchProjectProxy returns the project and the datasource targeted when the path is querying a datasource through
+// the proxy of a project, whether it is a datasource of the project or a global datasource overridden by the project.
+func matchProjectProxy(path string) (string, string, bool) {
+	matches := localProxyMatcher.FindStringSubmatch(path)
+	if matches == nil {
+		matches = projectGlobalProxyMatcher.FindStringSubmatch(path)
+	}
+	if matches == nil {
+		return "", "", false
+	}
+	return matches[1], matches[2], true
+}
+
+func Proxy(dts datasource.DAO, globalDTS globaldatasource.DAO, overrideDAO globaldatasourceoverride.DAO, orgDTS organizationdatasource.DAO, projectDAO project.DAO) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
-			spec, path, err := extractDatasourceAndPath(c, dts, globalDTS)
+			spec, path, err := extractDatasourceAndPath(c, dts, globalDTS, overrideDAO, orgDTS, projectDAO)
 			if err != nil {
 				return err
 			}
@@ -55,11 +75,13 @@ func Proxy(dts datasource.DAO, globalDTS globaldatasource.DAO) echo.MiddlewareFu
 	}
 }
 
-func extractDatasourceAndPath(c echo.Context, dts datasource.DAO, globalDTS globaldatasource.DAO) (v1.DatasourceSpec, string, error) {
+func extractDatasourceAndPath(c echo.Context, dts datasource.DAO, globalDTS globaldatasource.DAO, overrideDAO globaldatasourceoverride.DAO, orgDTS organizationdatasource.DAO, projectDAO project.DAO) (v1.DatasourceSpec, string, error) {
 	requestPath := c.Request().URL.Path
 	globalDatasourceMatch := globalProxyMatcher.MatchString(requestPath)
 	localDatasourceMatch := localProxyMatcher.MatchString(requestPath)
-	if !globalDatasourceMatch && !localDatasourceMatch {
+	orgDatasourceMatch := orgProxyMatcher.MatchString(requestPath)
+	projectGlobalDatasourceMatch := projectGlobalProxyMatcher.MatchString(requestPath)
+	if !globalDatasourceMatch && !localDatasourceMatch && !orgDatasourceMatch && !projectGlobalDatasourceMatch {
 		// this is likely a request for the API itself
 		return v1.DatasourceSpec{}, "", nil
 	}
@@ -67,6 +89,12 @@ func extractDatasourceAndPath(c echo.Context, dts datasource.DAO, globalDTS glob
 	if globalDatasourceMatch {
 		return getGlobalDatasourceAndPath(globalDTS, requestPath)
 	}
+	if projectGlobalDatasourceMatch {
+		return getProjectGlobalDatasourceAndPath(globalDTS, overrideDAO, projectDAO, requestPath)
+	}
+	if orgDatasourceMatch {
+		return getOrganizationDatasourceAndPath(orgDTS, requestPath)
+	}
 	return getLocalDatasourceAndPath(dts, requestPath)
 }
 
@@ -95,6 +123,55 @@ func getGlobalDatasourceAndPath(dao globaldatasource.DAO, requestPath string) (v
 	return dts.Spec, path, nil
 }
 
+func getProjectGlobalDatasourceAndPath(dao globaldatasource.DAO, overrideDAO globaldatasourceoverride.DAO, projectDAO project.DAO, requestPath string) (v1.DatasourceSpec, string, error) {
+	matchingGroups := projectGlobalProxyMatcher.FindAllStringSubmatch(requestPath, -1)
+	if len(matchingGroups) > 1 || len(matchingGroups[0]) <= 2 {
+		return v1.DatasourceSpec{}, "", echo.NewHTTPError(http.StatusBadGateway, "unable to forward the request to the datasource, request not properly formatted")
+	}
+	projectName := matchingGroups[0][1]
+	datasourceName := matchingGroups[0][2]
+	// the global datasources are only available to the projects of the default organization
+	projectEntity, err := projectDAO.Find(projectName)
+	if err != nil {
+		if databaseModel.IsKeyNotFound(err) {
+			return v1.DatasourceSpec{}, "", echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("unable to forward the request, the project %q doesn't exist", projectName))
+		}
+		logrus.WithError(err).Errorf("unable to find the project %q, something wrong with the database", projectName)
+		return v1.DatasourceSpec{}, "", echo.NewHTTPError(http.StatusInternalServerError, "internal server error")
+	}
+	if projectEntity.GetOrganization() != v1.DefaultOrganization {
+		return v1.DatasourceSpec{}, "", echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("unable to forward the request to the datasource %q, the global datasources are not available in the organization %q", datasourceName, projectEntity.GetOrganization()))
+	}
+	// getting the datasource object
+	dts, err := dao.Get(datasourceName)
+	if err != nil {
+		if databaseModel.IsKeyNotFound(err) {
+			logrus.Debugf("unable to find the Datasource %q", datasourceName)
+			return v1.DatasourceSpec{}, "", echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("unable to forward the request to the datasource %q, datasource doesn't exist", datasourceName))
+		}
+		logrus.WithError(err).Errorf("unable to find the datasource %q, something wrong with the database", datasourceName)
+		return v1.DatasourceSpec{}, "", echo.NewHTTPError(http.StatusInternalServerError, "internal server error")
+	}
+	// merging the override of the project, if any
+	override, err := overrideDAO.Get(projectName, datasourceName)
+	if err == nil {
+		if dts, err = dts.Override(override.Spec); err != nil {
+			logrus.WithError(err).Errorf("unable to apply the override of the project %q on the datasource %q", projectName, datasourceName)
+			return v1.DatasourceSpec{}, "", echo.NewHTTPError(http.StatusInternalServerError, "internal server error")
+		}
+	} else if !databaseModel.IsKeyNotFound(err) {
+		logrus.WithError(err).Errorf("unable to find the override of the datasource %q in project %q, something wrong with the database", datasourceName, projectName)
+		return v1.DatasourceSpec{}, "", echo.NewHTTPError(http.StatusInternalServerError, "internal server error")
+	}
+	// Based on the HTTP 1.1 RFC, a `/` should be the minimum path.
+	// https://datatracker.ietf.org/doc/html/rfc2616#section-5.1.2
+	path := "/"
+	if len(matchingGroups[0]) > 3 {
+		path = matchingGroups[0][3]
+	}
+	return dts.Spec, path, nil
+}
+
 func getLocalDatasourceAndPath(dao datasource.DAO, requestPath string) (v1.DatasourceSpec, string, error) {
 	matchingGroups := localProxyMatcher.FindAllStringSubmatch(requestPath, -1)
 	if len(matchingGroups) > 1 || len(matchingGroups[0]) <= 2 {
@@ -121,6 +198,32 @@ func getLocalDatasourceAndPath(dao datasource.DAO, requestPath string) (v1.Datas
 	return dts.Spec, path, nil
 }
 
+func getOrganizationDatasourceAndPath(dao organizationdatasource.DAO, requestPath string) (v1.DatasourceSpec, string, error) {
+	matchingGroups := orgProxyMatcher.FindAllStringSubmatch(requestPath, -1)
+	if len(matchingGroups) > 1 || len(matchingGroups[0]) <= 2 {
+		return v1.DatasourceSpec{}, "", echo.NewHTTPError(http.StatusBadGateway, "unable to forward the request to the datasource, request not properly formatted")
+	}
+	organizationName := matchingGroups[0][1]
+	datasourceName := matchingGroups[0][2]
+	// getting the datasource object
+	dts, err := dao.Get(organizationName, datasourceName)
+	if err != nil {
+		if databaseModel.IsKeyNotFound(err) {
+			logrus.Debugf("unable to find the Datasource %q in organization %q", datasourceName, organizationName)
+			return v1.DatasourceSpec{}, "", echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("unable to forward the request to the datasource %q, datasource doesn't exist", datasourceName))
+		}
+		logrus.WithError(err).Errorf("unable to find the datasource %q, something wrong with the database", datasourceName)
+		return v1.DatasourceSpec{}, "", echo.NewHTTPError(http.StatusInternalServerError, "internal server error")
+	}
+	// Based on the HTTP 1.1 RFC, a `/` should be the minimum path.
+	// https://datatracker.ietf.org/doc/html/rfc2616#section-5.1.2
+	path := "/"
+	if len(matchingGroups[0]) > 3 {
+		path = matchingGroups[0][3]
+	}
+	return dts.Spec, path, nil
+}
+
 type proxy interface {
 	serve(c echo.Context) error
 }
@@ -162,6 +265,11 @@ func (h *httpProxy) serve(c echo.Context) error {
 	}
 
 	h.prepareRequest(c)
+	if isUpgradeRequest(req) {
+		// The connection is going to be hijacked to stream the data (like the tail of the logs with Loki).
+		// The compression of the response, set up front by the gzip middleware, doesn't apply to it.
+		res.Header().Del(echo.HeaderContentEncoding)
+	}
 
 	// redirect the request to the datasource
 	req.URL.Path = h.path
@@ -183,6 +291,13 @@ func (h *httpProxy) serve(c echo.Context) error {
 	return proxyErr
 }
 
+// isUpgradeRequest returns true when the request asks to switch to another protocol, like a websocket.
+// The reverse proxy supports it, as long as the response can be hijacked.
+func isUpgradeRequest(req *http.Request) bool {
+	return len(req.Header.Get(echo.HeaderUpgrade)) > 0 &&
+		strings.Contains(strings.ToLower(req.Header.Get(echo.HeaderConnection)), "upgrade")
+}
+
 func (h *httpProxy) prepareRequest(c echo.Context) {
 	req := c.Request()
 	// We have to modify the HOST of the request in order to match the host of the targetURL
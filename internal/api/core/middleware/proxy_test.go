@@ -0,0 +1,100 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	echoMiddleware "github.com/labstack/echo/v4/middleware"
+	"github.com/perses/perses/pkg/model/api/v1/common"
+	datasourceHTTP "github.com/perses/perses/pkg/model/api/v1/datasource/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTailServer mimics the tail endpoint of Loki: it switches to another protocol and sends back what it receives.
+func newTailServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/loki/api/v1/tail" || !isUpgradeRequest(r) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		conn, buf, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		defer conn.Close()
+		_, _ = buf.WriteString("HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n\r\n")
+		_ = buf.Flush()
+		_, _ = io.Copy(conn, buf)
+	}))
+}
+
+func TestHTTPProxyUpgrade(t *testing.T) {
+	backend := newTailServer(t)
+	defer backend.Close()
+	backendURL, err := url.Parse(backend.URL)
+	require.NoError(t, err)
+
+	e := echo.New()
+	// the gzip middleware is part of the default middlewares of the server
+	e.Use(echoMiddleware.Gzip())
+	e.GET("/proxy/*", func(c echo.Context) error {
+		pr := &httpProxy{
+			config: &datasourceHTTP.Config{
+				URL: backendURL,
+				AllowedEndpoints: []datasourceHTTP.AllowedEndpoint{
+					{EndpointPattern: common.Regexp{Regexp: regexp.MustCompile("/loki/api/v1/tail")}, Method: http.MethodGet},
+				},
+			},
+			path: "/loki/api/v1/tail",
+		}
+		return pr.serve(c)
+	})
+	frontend := httptest.NewServer(e)
+	defer frontend.Close()
+
+	conn, err := net.Dial("tcp", frontend.Listener.Addr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+	req, err := http.NewRequest(http.MethodGet, frontend.URL+"/proxy/globaldatasources/loki/loki/api/v1/tail", nil)
+	require.NoError(t, err)
+	req.Header.Set(echo.HeaderConnection, "Upgrade")
+	req.Header.Set(echo.HeaderUpgrade, "websocket")
+	req.Header.Set(echo.HeaderAcceptEncoding, "gzip")
+	require.NoError(t, req.Write(conn))
+
+	reader := bufio.NewReader(conn)
+	res, err := http.ReadResponse(reader, req)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusSwitchingProtocols, res.StatusCode)
+	assert.Empty(t, res.Header.Get(echo.HeaderContentEncoding))
+
+	// the connection is now streaming in both directions through the proxy
+	_, err = conn.Write([]byte("ping"))
+	require.NoError(t, err)
+	data := make([]byte, 4)
+	_, err = io.ReadFull(reader, data)
+	require.NoError(t, err)
+	assert.Equal(t, "ping", string(data))
+}
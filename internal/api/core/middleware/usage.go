@@ -0,0 +1,38 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/interface/v1/usage"
+	"github.com/perses/perses/internal/api/shared"
+)
+
+var dashboardPath = fmt.Sprintf("%s/%s/:%s/%s/:%s", shared.APIV1Prefix, shared.PathProject, shared.ParamProject, shared.PathDashboard, shared.ParamName)
+
+// RecordDashboardView is a middleware that counts every successful GET of a single dashboard as a view.
+func RecordDashboardView(svc usage.Service) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			err := next(c)
+			if err == nil && c.Request().Method == http.MethodGet && c.Path() == dashboardPath && c.Response().Status == http.StatusOK {
+				svc.Record(shared.GetProjectParameter(c), c.Param(shared.ParamName))
+			}
+			return err
+		}
+	}
+}
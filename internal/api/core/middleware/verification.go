@@ -22,13 +22,18 @@ import (
 	"strings"
 
 	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/interface/v1/organization"
 	"github.com/perses/perses/internal/api/interface/v1/project"
+	"github.com/perses/perses/internal/api/interface/v1/team"
 	"github.com/perses/perses/internal/api/shared"
 	databaseModel "github.com/perses/perses/internal/api/shared/database/model"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
 )
 
 type partialMetadata struct {
-	Project string `json:"project"`
+	Project      string   `json:"project"`
+	Organization string   `json:"organization"`
+	Owners       []string `json:"owners"`
 }
 
 type partialObject struct {
@@ -53,24 +58,9 @@ func CheckProject(svc project.Service) echo.MiddlewareFunc {
 				// And just to avoid a non-necessary deserialization, we will ensure we are managing a resource that is part of a project by checking the HTTP Path.
 				for _, path := range shared.ProjectResourcePathList {
 					if strings.HasPrefix(c.Path(), fmt.Sprintf("%s/%s", shared.APIV1Prefix, path)) {
-						// Parsing the body in an Echo middleware may cause the error code=400, message=EOF.
-						//
-						// Context.Bind only can be called only once in the life of the request as it read the body which can only be read once.
-						// The request data reader is running out, Context.Bind() function read request body data from the socket buffer, once you took it out, it is just gone
-						// That’s why it returns EOF error.
-						//
-						// In this middleware we need to partially decode the body to see if the project is set.
-						// So we read the body, and then we re-inject it in the request.
-						bodyBytes, err := io.ReadAll(c.Request().Body)
+						o, err := decodePartialObject(c)
 						if err != nil {
-							return shared.HandleBadRequestError(err.Error())
-						}
-						// write back to request body
-						c.Request().Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
-						// now we can safely partially decode the body
-						o := &partialObject{}
-						if unmarshalErr := json.Unmarshal(bodyBytes, o); unmarshalErr != nil {
-							return shared.HandleBadRequestError(unmarshalErr.Error())
+							return err
 						}
 						if len(o.Metadata.Project) == 0 {
 							return shared.HandleBadRequestError("metadata.project cannot be empty")
@@ -92,3 +82,130 @@ func CheckProject(svc project.Service) echo.MiddlewareFunc {
 		}
 	}
 }
+
+// CheckOwners is a middleware that will verify if the teams owning the resource created or updated exist.
+func CheckOwners(teamDAO team.DAO) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			method := c.Request().Method
+			if (method != http.MethodPost && method != http.MethodPut) || c.Request().Body == nil || !strings.HasPrefix(c.Path(), shared.APIV1Prefix) {
+				return next(c)
+			}
+			o, err := decodePartialObject(c)
+			if err != nil {
+				// not every endpoint is receiving a resource, so the body is left to the handler that will report the accurate error.
+				return next(c)
+			}
+			for _, owner := range o.Metadata.Owners {
+				if _, getErr := teamDAO.Get(owner); getErr != nil {
+					if databaseModel.IsKeyNotFound(getErr) {
+						return shared.HandleBadRequestError(fmt.Sprintf("metadata.owners: the team %q doesn't exist", owner))
+					}
+					return getErr
+				}
+			}
+			return next(c)
+		}
+	}
+}
+
+// CheckReadonlyOrganization is a middleware that rejects the modifications of the resources belonging to an organization
+// with the setting readonly. The organization itself can still be modified, so the setting can be removed.
+func CheckReadonlyOrganization(organizationDAO organization.DAO, projectDAO project.DAO) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			method := c.Request().Method
+			if method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions {
+				return next(c)
+			}
+			organizationName, err := getOrganizationName(c, projectDAO)
+			if err != nil {
+				return err
+			}
+			if len(organizationName) == 0 {
+				return next(c)
+			}
+			org, err := organizationDAO.Get(organizationName)
+			if err != nil {
+				if databaseModel.IsKeyNotFound(err) {
+					// the services are returning the accurate error when the organization doesn't exist
+					return next(c)
+				}
+				return err
+			}
+			if org.Spec.Settings.Readonly {
+				return echo.NewHTTPError(http.StatusForbidden, fmt.Sprintf("organization %q is readonly", organizationName))
+			}
+			return next(c)
+		}
+	}
+}
+
+// getOrganizationName returns the organization targeted by the request. It's empty when the request is not related to an organization.
+func getOrganizationName(c echo.Context, projectDAO project.DAO) (string, error) {
+	if organizationName := shared.GetOrganizationParameter(c); len(organizationName) > 0 {
+		return organizationName, nil
+	}
+	projectName := shared.GetProjectParameter(c)
+	projectPath := fmt.Sprintf("%s/%s", shared.APIV1Prefix, shared.PathProject)
+	if len(projectName) == 0 && c.Path() == fmt.Sprintf("%s/:%s", projectPath, shared.ParamName) {
+		projectName = c.Param(shared.ParamName)
+	}
+	if len(projectName) == 0 && c.Request().Method == http.MethodPost && c.Request().Body != nil {
+		// the resource is created from the root endpoint, so the organization or the project is only in the body.
+		if c.Path() == projectPath {
+			o, err := decodePartialObject(c)
+			if err != nil {
+				return "", err
+			}
+			if len(o.Metadata.Organization) == 0 {
+				return v1.DefaultOrganization, nil
+			}
+			return o.Metadata.Organization, nil
+		}
+		for _, path := range shared.ProjectResourcePathList {
+			if strings.HasPrefix(c.Path(), fmt.Sprintf("%s/%s", shared.APIV1Prefix, path)) {
+				o, err := decodePartialObject(c)
+				if err != nil {
+					return "", err
+				}
+				projectName = o.Metadata.Project
+				break
+			}
+		}
+	}
+	if len(projectName) == 0 {
+		return "", nil
+	}
+	entity, err := projectDAO.Find(projectName)
+	if err != nil {
+		if databaseModel.IsKeyNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	return entity.Metadata.Organization, nil
+}
+
+func decodePartialObject(c echo.Context) (*partialObject, error) {
+	// Parsing the body in an Echo middleware may cause the error code=400, message=EOF.
+	//
+	// Context.Bind only can be called only once in the life of the request as it read the body which can only be read once.
+	// The request data reader is running out, Context.Bind() function read request body data from the socket buffer, once you took it out, it is just gone
+	// That’s why it returns EOF error.
+	//
+	// In this middleware we need to partially decode the body to see if the project is set.
+	// So we read the body, and then we re-inject it in the request.
+	bodyBytes, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return nil, shared.HandleBadRequestError(err.Error())
+	}
+	// write back to request body
+	c.Request().Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
+	// now we can safely partially decode the body
+	o := &partialObject{}
+	if unmarshalErr := json.Unmarshal(bodyBytes, o); unmarshalErr != nil {
+		return nil, shared.HandleBadRequestError(unmarshalErr.Error())
+	}
+	return o, nil
+}
@@ -17,15 +17,37 @@ import (
 	"github.com/labstack/echo/v4"
 	echoUtils "github.com/perses/common/echo"
 	"github.com/perses/perses/internal/api/config"
+	"github.com/perses/perses/internal/api/core/middleware"
+	alertendpoint "github.com/perses/perses/internal/api/impl/alert"
+	analysisendpoint "github.com/perses/perses/internal/api/impl/analysis"
+	authendpoint "github.com/perses/perses/internal/api/impl/auth"
 	configendpoint "github.com/perses/perses/internal/api/impl/config"
 	migrateendpoint "github.com/perses/perses/internal/api/impl/migrate"
+	renameendpoint "github.com/perses/perses/internal/api/impl/rename"
+	shareendpoint "github.com/perses/perses/internal/api/impl/share"
+	transformendpoint "github.com/perses/perses/internal/api/impl/transform"
+	"github.com/perses/perses/internal/api/impl/v1/access"
 	"github.com/perses/perses/internal/api/impl/v1/dashboard"
 	"github.com/perses/perses/internal/api/impl/v1/datasource"
+	"github.com/perses/perses/internal/api/impl/v1/draft"
+	"github.com/perses/perses/internal/api/impl/v1/ephemeraldashboard"
 	"github.com/perses/perses/internal/api/impl/v1/folder"
+	"github.com/perses/perses/internal/api/impl/v1/globaldashboard"
 	"github.com/perses/perses/internal/api/impl/v1/globaldatasource"
+	"github.com/perses/perses/internal/api/impl/v1/globaldatasourceoverride"
+	"github.com/perses/perses/internal/api/impl/v1/globalhomeconfig"
 	"github.com/perses/perses/internal/api/impl/v1/globalvariable"
 	"github.com/perses/perses/internal/api/impl/v1/health"
+	"github.com/perses/perses/internal/api/impl/v1/homeconfig"
+	"github.com/perses/perses/internal/api/impl/v1/organization"
+	"github.com/perses/perses/internal/api/impl/v1/organizationdatasource"
+	"github.com/perses/perses/internal/api/impl/v1/organizationvariable"
+	"github.com/perses/perses/internal/api/impl/v1/playlist"
 	"github.com/perses/perses/internal/api/impl/v1/project"
+	"github.com/perses/perses/internal/api/impl/v1/savedview"
+	"github.com/perses/perses/internal/api/impl/v1/sharetoken"
+	"github.com/perses/perses/internal/api/impl/v1/team"
+	"github.com/perses/perses/internal/api/impl/v1/usage"
 	"github.com/perses/perses/internal/api/impl/v1/variable"
 	validateendpoint "github.com/perses/perses/internal/api/impl/validate"
 	"github.com/perses/perses/internal/api/shared"
@@ -45,18 +67,39 @@ type api struct {
 func NewPersesAPI(serviceManager dependency.ServiceManager, cfg config.Config) echoUtils.Register {
 	readonly := cfg.Readonly
 	apiV1Endpoints := []endpoint{
-		dashboard.NewEndpoint(serviceManager.GetDashboard(), readonly),
+		access.NewEndpoint(serviceManager.GetAccess(), readonly),
+		dashboard.NewEndpoint(serviceManager.GetDashboard(), readonly, cfg.Draft.RequireReview),
 		datasource.NewEndpoint(serviceManager.GetDatasource(), readonly),
+		draft.NewEndpoint(serviceManager.GetDraft(), readonly),
+		ephemeraldashboard.NewEndpoint(serviceManager.GetEphemeralDashboard(), readonly),
 		folder.NewEndpoint(serviceManager.GetFolder(), readonly),
+		globaldashboard.NewEndpoint(serviceManager.GetGlobalDashboard(), readonly),
 		globaldatasource.NewEndpoint(serviceManager.GetGlobalDatasource(), readonly),
+		globaldatasourceoverride.NewEndpoint(serviceManager.GetGlobalDatasourceOverride(), readonly),
+		globalhomeconfig.NewEndpoint(serviceManager.GetGlobalHomeConfig(), readonly),
 		globalvariable.NewEndpoint(serviceManager.GetGlobalVariable(), readonly),
 		health.NewEndpoint(serviceManager.GetHealth()),
+		homeconfig.NewEndpoint(serviceManager.GetHomeConfig(), readonly),
+		organization.NewEndpoint(serviceManager.GetOrganization(), readonly),
+		organizationdatasource.NewEndpoint(serviceManager.GetOrganizationDatasource(), readonly),
+		organizationvariable.NewEndpoint(serviceManager.GetOrganizationVariable(), readonly),
+		playlist.NewEndpoint(serviceManager.GetPlaylist(), readonly),
 		project.NewEndpoint(serviceManager.GetProject(), readonly),
+		renameendpoint.New(serviceManager.GetRename(), readonly),
+		savedview.NewEndpoint(serviceManager.GetSavedView(), readonly),
+		sharetoken.NewEndpoint(serviceManager.GetShareToken(), readonly),
+		team.NewEndpoint(serviceManager.GetTeam(), readonly),
+		usage.NewEndpoint(serviceManager.GetUsage()),
 		variable.NewEndpoint(serviceManager.GetVariable(), readonly),
 	}
 	apiEndpoints := []endpoint{
+		alertendpoint.New(),
+		analysisendpoint.New(serviceManager.GetAnalysis()),
+		authendpoint.New(serviceManager.GetLDAP(), serviceManager.GetToken()),
 		configendpoint.New(cfg),
 		migrateendpoint.New(serviceManager.GetMigration()),
+		shareendpoint.New(serviceManager.GetShareToken()),
+		transformendpoint.New(),
 		validateendpoint.New(serviceManager.GetSchemas()),
 	}
 	return &api{
@@ -66,6 +109,8 @@ func NewPersesAPI(serviceManager dependency.ServiceManager, cfg config.Config) e
 }
 
 func (a *api) RegisterRoute(e *echo.Echo) {
+	// the resources of the projects are also available under the path of their organization
+	e.Pre(middleware.RewriteOrganizationProjectPath())
 	a.registerAPIV1Route(e)
 }
 
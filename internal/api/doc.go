@@ -14,10 +14,11 @@
 package api
 
 // this file is just there to run the command generate
-//go:generate go run generate.go -package=globaldatasource -plural=globaldatasources -kind=GlobalDatasource
+//go:generate go run generate.go -package=globaldatasourceoverride -plural=globaldatasourceoverrides -kind=GlobalDatasourceOverride -isProjectResource=true
 //go:generate go run generate.go -package=datasource -plural=datasources -kind=Datasource -isProjectResource=true
-//go:generate go run generate.go -package=project -plural=projects -kind=Project
-//go:generate go run generate.go -package=dashboard -plural=dashboards -kind=Dashboard -isProjectResource=true
 //go:generate go run generate.go -package=folder -plural=folders -kind=Folder -isProjectResource=true
 //go:generate go run generate.go -package=globalvariable -plural=globalvariables -kind=GlobalVariable
 //go:generate go run generate.go -package=variable -plural=variables -kind=Variable -isProjectResource=true
+//go:generate go run generate.go -package=team -plural=teams -kind=Team
+//go:generate go run generate.go -package=globalhomeconfig -plural=globalhomeconfigs -kind=GlobalHomeConfig
+//go:generate go run generate.go -package=organization -plural=organizations -kind=Organization
@@ -0,0 +1,308 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build integration
+
+package e2e
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/gavv/httpexpect/v2"
+	"github.com/perses/perses/internal/api/config"
+	e2eframework "github.com/perses/perses/internal/api/e2e/framework"
+	"github.com/perses/perses/internal/api/shared"
+	"github.com/perses/perses/internal/api/shared/dependency"
+	"github.com/perses/perses/pkg/model/api"
+	"github.com/perses/perses/pkg/model/api/admission"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+type admissionReceiver struct {
+	*httptest.Server
+	mutex    sync.Mutex
+	requests []admission.Request
+}
+
+// newAdmissionReceiver starts a local webhook answering with the given response and recording the requests received.
+func newAdmissionReceiver(t *testing.T, response admission.Response) *admissionReceiver {
+	receiver := &admissionReceiver{}
+	receiver.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request := admission.Request{}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		receiver.mutex.Lock()
+		receiver.requests = append(receiver.requests, request)
+		receiver.mutex.Unlock()
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	t.Cleanup(receiver.Close)
+	return receiver
+}
+
+func (r *admissionReceiver) getRequests() []admission.Request {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return append([]admission.Request{}, r.requests...)
+}
+
+func withAdmissionWebhook(webhook config.AdmissionWebhook) func(conf *config.Config) {
+	return func(conf *config.Config) {
+		conf.AdmissionWebhooks = []config.AdmissionWebhook{webhook}
+	}
+}
+
+func TestAdmissionWebhookReceivesTheUser(t *testing.T) {
+	receiver := newAdmissionReceiver(t, admission.Response{Allowed: false, Message: "forbidden by the policy"})
+	customize := func(conf *config.Config) {
+		withAuthorization(conf)
+		withAdmissionWebhook(config.AdmissionWebhook{
+			Name:  "policy",
+			Type:  config.AdmissionWebhookValidating,
+			URL:   receiver.URL,
+			Kinds: []string{string(v1.KindDashboard)},
+		})(conf)
+	}
+	e2eframework.WithServerConfig(t, customize, func(expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
+		project := e2eframework.NewProject("perses")
+		e2eframework.CreateAndWaitUntilEntitiesExist(t, manager, project)
+		entity := e2eframework.NewDashboard(t, "perses", "test")
+
+		asUser(expect.POST(fmt.Sprintf("%s/%s/%s/%s", shared.APIV1Prefix, shared.PathProject, "perses", shared.PathDashboard)), "editors").
+			WithJSON(entity).
+			Expect().
+			Status(http.StatusForbidden).
+			JSON().Object().Value("message").String().Contains("forbidden by the policy")
+
+		requests := receiver.getRequests()
+		if assert.Len(t, requests, 1) {
+			assert.Equal(t, admission.OperationCreate, requests[0].Operation)
+			assert.Equal(t, &admission.UserInfo{Username: "jdoe", Groups: []string{"editors"}}, requests[0].User)
+		}
+		return []api.Entity{project}
+	})
+}
+
+func TestAdmissionWebhookMutatedResourceIsValidated(t *testing.T) {
+	entity := e2eframework.NewDashboard(t, "perses", "test")
+	var panel string
+	for key := range entity.Spec.Panels {
+		panel = key
+		break
+	}
+	receiver := newAdmissionReceiver(t, admission.Response{
+		Allowed: true,
+		Patch: []admission.PatchOperation{
+			{Op: "replace", Path: fmt.Sprintf("/spec/panels/%s/spec/plugin/kind", panel), Value: []byte(`"UnknownChart"`)},
+		},
+	})
+	customize := withAdmissionWebhook(config.AdmissionWebhook{
+		Name:  "mutate",
+		Type:  config.AdmissionWebhookMutating,
+		URL:   receiver.URL,
+		Kinds: []string{string(v1.KindDashboard)},
+	})
+	e2eframework.WithServerConfig(t, customize, func(expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
+		project := e2eframework.NewProject("perses")
+		e2eframework.CreateAndWaitUntilEntitiesExist(t, manager, project)
+
+		// the dashboard is valid, but not once modified by the webhook
+		expect.POST(fmt.Sprintf("%s/%s/%s/%s", shared.APIV1Prefix, shared.PathProject, "perses", shared.PathDashboard)).
+			WithJSON(entity).
+			Expect().
+			Status(http.StatusBadRequest)
+		assert.Len(t, receiver.getRequests(), 1)
+
+		// the same for the draft, that is sent as the dashboard it will publish
+		expect.PUT(fmt.Sprintf("%s/%s/%s/%s/%s/draft", shared.APIV1Prefix, shared.PathProject, "perses", shared.PathDashboard, "test")).
+			WithJSON(entity).
+			Expect().
+			Status(http.StatusBadRequest)
+		assert.Len(t, receiver.getRequests(), 2)
+		return []api.Entity{project}
+	})
+}
+
+func TestAdmissionWebhookNotCalledOnDeletionInCascade(t *testing.T) {
+	receiver := newAdmissionReceiver(t, admission.Response{Allowed: false, Message: "the dashboards cannot be deleted"})
+	customize := withAdmissionWebhook(config.AdmissionWebhook{
+		Name:       "protect",
+		Type:       config.AdmissionWebhookValidating,
+		URL:        receiver.URL,
+		Kinds:      []string{string(v1.KindDashboard)},
+		Operations: []admission.Operation{admission.OperationDelete},
+	})
+	e2eframework.WithServerConfig(t, customize, func(expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
+		project := e2eframework.NewProject("perses")
+		entity := e2eframework.NewDashboard(t, "perses", "test")
+		e2eframework.CreateAndWaitUntilEntitiesExist(t, manager, project, entity)
+
+		expect.DELETE(fmt.Sprintf("%s/%s/%s/%s/%s", shared.APIV1Prefix, shared.PathProject, "perses", shared.PathDashboard, "test")).
+			Expect().
+			Status(http.StatusForbidden)
+		assert.Len(t, receiver.getRequests(), 1)
+
+		// the dashboard is deleted along with its project without asking the webhook
+		expect.DELETE(fmt.Sprintf("%s/%s/%s", shared.APIV1Prefix, shared.PathProject, "perses")).
+			Expect().
+			Status(http.StatusNoContent)
+		assert.Len(t, receiver.getRequests(), 1)
+		return []api.Entity{}
+	})
+}
+
+func TestAdmissionWebhookReceivesTheResourceWritten(t *testing.T) {
+	receiver := newAdmissionReceiver(t, admission.Response{Allowed: true})
+	customize := withAdmissionWebhook(config.AdmissionWebhook{
+		Name:  "audit",
+		Type:  config.AdmissionWebhookValidating,
+		URL:   receiver.URL,
+		Kinds: []string{string(v1.KindDashboard), string(v1.KindEphemeralDashboard)},
+	})
+	e2eframework.WithServerConfig(t, customize, func(expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
+		project := e2eframework.NewProject("perses")
+		entity := e2eframework.NewDashboard(t, "perses", "test")
+		e2eframework.CreateAndWaitUntilEntitiesExist(t, manager, project, entity)
+		dashboardPath := fmt.Sprintf("%s/%s/%s/%s", shared.APIV1Prefix, shared.PathProject, "perses", shared.PathDashboard)
+
+		// the webhooks only receive the resources that passed the validation
+		invalid := e2eframework.NewDashboard(t, "perses", "invalid")
+		for _, panel := range invalid.Spec.Panels {
+			panel.Spec.Plugin.Kind = "UnknownChart"
+		}
+		expect.POST(dashboardPath).
+			WithJSON(invalid).
+			Expect().
+			Status(http.StatusBadRequest)
+		assert.Len(t, receiver.getRequests(), 0)
+
+		// the merge sends the dashboard merged with the changes done in the meantime, not the one of the request
+		stored := e2eframework.NewDashboard(t, "perses", "test")
+		stored.Spec.Duration = entity.Spec.Duration * 2
+		assert.NoError(t, manager.GetDashboard().Update(stored))
+		modified := e2eframework.NewDashboard(t, "perses", "test")
+		modified.Spec.Tags = []string{"merged"}
+		expect.PUT(fmt.Sprintf("%s/%s/merge", dashboardPath, "test")).
+			WithJSON(&v1.DashboardMerge{Base: entity, Dashboard: modified}).
+			Expect().
+			Status(http.StatusOK)
+		requests := receiver.getRequests()
+		if assert.Len(t, requests, 1) {
+			assert.Equal(t, admission.OperationUpdate, requests[0].Operation)
+			merged := &v1.Dashboard{}
+			assert.NoError(t, json.Unmarshal(requests[0].Object, merged))
+			assert.Equal(t, []string{"merged"}, merged.Spec.Tags)
+			assert.Equal(t, stored.Spec.Duration, merged.Spec.Duration)
+		}
+
+		// extending an ephemeral dashboard is an update
+		ephemeralPath := fmt.Sprintf("%s/%s/%s/%s", shared.APIV1Prefix, shared.PathProject, "perses", shared.PathEphemeralDashboard)
+		ephemeral := newEphemeralDashboard(t, "perses", "incident")
+		expect.POST(ephemeralPath).
+			WithJSON(ephemeral).
+			Expect().
+			Status(http.StatusOK)
+		expect.POST(fmt.Sprintf("%s/%s/extend", ephemeralPath, "incident")).
+			WithJSON(map[string]string{"ttl": "1h"}).
+			Expect().
+			Status(http.StatusOK)
+		requests = receiver.getRequests()
+		if assert.Len(t, requests, 3) {
+			assert.Equal(t, v1.KindEphemeralDashboard, requests[2].Kind)
+			assert.Equal(t, admission.OperationUpdate, requests[2].Operation)
+			assert.NotEmpty(t, requests[2].OldObject)
+		}
+		return []api.Entity{project, entity, ephemeral}
+	})
+}
+
+func TestAdmissionWebhookReceivesTheChangesOfARename(t *testing.T) {
+	receiver := newAdmissionReceiver(t, admission.Response{Allowed: true})
+	customize := withAdmissionWebhook(config.AdmissionWebhook{
+		Name:  "audit",
+		Type:  config.AdmissionWebhookValidating,
+		URL:   receiver.URL,
+		Kinds: []string{string(v1.KindDashboard), string(v1.KindHomeConfig)},
+	})
+	e2eframework.WithServerConfig(t, customize, func(expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
+		project := e2eframework.NewProject("perses")
+		dashboard := e2eframework.NewDashboard(t, "perses", "cpu")
+		homeConfig := e2eframework.NewHomeConfig("perses", "default", v1.DashboardSelector{Project: "perses", Dashboard: "cpu"})
+		e2eframework.CreateAndWaitUntilEntitiesExist(t, manager, project, dashboard, homeConfig)
+
+		expect.POST(fmt.Sprintf("%s/%s/%s/%s/%s/rename", shared.APIV1Prefix, shared.PathProject, "perses", shared.PathDashboard, "cpu")).
+			WithJSON(&v1.Rename{Name: "node"}).
+			Expect().
+			Status(http.StatusOK)
+
+		requests := receiver.getRequests()
+		if assert.Len(t, requests, 3) {
+			assert.Equal(t, v1.KindDashboard, requests[0].Kind)
+			assert.Equal(t, admission.OperationCreate, requests[0].Operation)
+			created := &v1.Dashboard{}
+			assert.NoError(t, json.Unmarshal(requests[0].Object, created))
+			assert.Equal(t, "node", created.Metadata.Name)
+			assert.Equal(t, v1.KindDashboard, requests[1].Kind)
+			assert.Equal(t, admission.OperationDelete, requests[1].Operation)
+			deleted := &v1.Dashboard{}
+			assert.NoError(t, json.Unmarshal(requests[1].OldObject, deleted))
+			assert.Equal(t, "cpu", deleted.Metadata.Name)
+			assert.Equal(t, v1.KindHomeConfig, requests[2].Kind)
+			assert.Equal(t, admission.OperationUpdate, requests[2].Operation)
+			assert.NotEmpty(t, requests[2].OldObject)
+		}
+		return []api.Entity{project, e2eframework.NewDashboard(t, "perses", "node"), homeConfig}
+	})
+}
+
+func TestAdmissionWebhookRefusingAChangeRevertsTheRename(t *testing.T) {
+	receiver := newAdmissionReceiver(t, admission.Response{Allowed: false, Message: "the home configs are frozen"})
+	customize := withAdmissionWebhook(config.AdmissionWebhook{
+		Name:       "freeze",
+		Type:       config.AdmissionWebhookValidating,
+		URL:        receiver.URL,
+		Kinds:      []string{string(v1.KindHomeConfig)},
+		Operations: []admission.Operation{admission.OperationUpdate},
+	})
+	e2eframework.WithServerConfig(t, customize, func(expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
+		project := e2eframework.NewProject("perses")
+		dashboard := e2eframework.NewDashboard(t, "perses", "cpu")
+		homeConfig := e2eframework.NewHomeConfig("perses", "default", v1.DashboardSelector{Project: "perses", Dashboard: "cpu"})
+		e2eframework.CreateAndWaitUntilEntitiesExist(t, manager, project, dashboard, homeConfig)
+		dashboardPath := fmt.Sprintf("%s/%s/%s/%s", shared.APIV1Prefix, shared.PathProject, "perses", shared.PathDashboard)
+
+		expect.POST(fmt.Sprintf("%s/%s/rename", dashboardPath, "cpu")).
+			WithJSON(&v1.Rename{Name: "node"}).
+			Expect().
+			Status(http.StatusForbidden).
+			JSON().Object().Value("message").String().Contains("the home configs are frozen")
+		assert.Len(t, receiver.getRequests(), 1)
+
+		// the dashboard keeps its name
+		expect.GET(fmt.Sprintf("%s/%s", dashboardPath, "cpu")).
+			Expect().
+			Status(http.StatusOK)
+		expect.GET(fmt.Sprintf("%s/%s", dashboardPath, "node")).
+			Expect().
+			Status(http.StatusNotFound)
+		return []api.Entity{project, dashboard, homeConfig}
+	})
+}
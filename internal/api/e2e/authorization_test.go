@@ -0,0 +1,179 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build integration
+
+package e2e
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/gavv/httpexpect/v2"
+	"github.com/perses/perses/internal/api/config"
+	e2eframework "github.com/perses/perses/internal/api/e2e/framework"
+	"github.com/perses/perses/internal/api/shared"
+	"github.com/perses/perses/internal/api/shared/dependency"
+	"github.com/perses/perses/pkg/model/api"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+func withAuthorization(conf *config.Config) {
+	conf.Authentication = config.Authentication{
+		Header: &config.HeaderAuthentication{
+			TrustedCIDRs: []string{"127.0.0.0/8", "::1/128"},
+			UserHeader:   config.DefaultUserHeader,
+			EmailHeader:  config.DefaultEmailHeader,
+			GroupsHeader: config.DefaultGroupsHeader,
+		},
+	}
+	conf.Authorization = config.Authorization{
+		Groups: []config.GroupPermissions{
+			{Group: "viewers", Permissions: []v1.Permission{{Project: "perses", Role: v1.RoleViewer}}},
+			{Group: "editors", Permissions: []v1.Permission{{Project: "perses", Role: v1.RoleEditor}}},
+		},
+	}
+}
+
+func asUser(request *httpexpect.Request, group string) *httpexpect.Request {
+	return request.
+		WithHeader(config.DefaultUserHeader, "jdoe").
+		WithHeader(config.DefaultGroupsHeader, group)
+}
+
+func TestCreateWithoutProjectPathRequiresTheRoleOnTheProject(t *testing.T) {
+	e2eframework.WithServerConfig(t, withAuthorization, func(expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
+		perses := e2eframework.NewProject("perses")
+		other := e2eframework.NewProject("other")
+		e2eframework.CreateAndWaitUntilEntitiesExist(t, manager, perses, other)
+
+		entities := map[string]func(projectName string) api.Entity{
+			shared.PathDashboard: func(projectName string) api.Entity {
+				return e2eframework.NewDashboard(t, projectName, "test")
+			},
+			shared.PathDatasource: func(projectName string) api.Entity {
+				return e2eframework.NewDatasource(t, projectName, "test")
+			},
+			shared.PathFolder: func(projectName string) api.Entity {
+				return &v1.Folder{
+					Kind:     v1.KindFolder,
+					Metadata: v1.ProjectMetadata{Metadata: v1.Metadata{Name: "test"}, Project: projectName},
+					Spec:     []v1.FolderSpec{{Kind: v1.KindDashboard, Name: "test"}},
+				}
+			},
+			shared.PathVariable: func(projectName string) api.Entity {
+				return e2eframework.NewVariable(projectName, "test")
+			},
+			shared.PathPlaylist: func(projectName string) api.Entity {
+				return e2eframework.NewPlaylist(projectName, "test")
+			},
+			shared.PathSavedView: func(projectName string) api.Entity {
+				return e2eframework.NewSavedView(projectName, "test", "test")
+			},
+			shared.PathHomeConfig: func(projectName string) api.Entity {
+				return e2eframework.NewHomeConfig(projectName, "test")
+			},
+			shared.PathEphemeralDashboard: func(projectName string) api.Entity {
+				return newEphemeralDashboard(t, projectName, "test")
+			},
+		}
+		for path, creator := range entities {
+			rootPath := fmt.Sprintf("%s/%s", shared.APIV1Prefix, path)
+			// a viewer of the project cannot create anything in it
+			asUser(expect.POST(rootPath), "viewers").
+				WithJSON(creator("perses")).
+				Expect().
+				Status(http.StatusForbidden)
+			// an editor of a project cannot create anything in another one
+			asUser(expect.POST(rootPath), "editors").
+				WithJSON(creator("other")).
+				Expect().
+				Status(http.StatusForbidden)
+		}
+		return []api.Entity{perses, other}
+	})
+}
+
+func TestListWithoutProjectPathOnlyReturnsTheReadableProjects(t *testing.T) {
+	e2eframework.WithServerConfig(t, withAuthorization, func(expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
+		perses := e2eframework.NewProject("perses")
+		other := e2eframework.NewProject("other")
+		readable := e2eframework.NewDashboard(t, "perses", "readable")
+		hidden := e2eframework.NewDashboard(t, "other", "hidden")
+		e2eframework.CreateAndWaitUntilEntitiesExist(t, manager, perses, other, readable, hidden)
+
+		dashboards := asUser(expect.GET(fmt.Sprintf("%s/%s", shared.APIV1Prefix, shared.PathDashboard)), "viewers").
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Array()
+		dashboards.Length().IsEqual(1)
+		dashboards.Element(0).Object().Value("metadata").Object().ValueEqual("name", "readable")
+
+		projects := asUser(expect.GET(fmt.Sprintf("%s/%s", shared.APIV1Prefix, shared.PathProject)), "viewers").
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Array()
+		projects.Length().IsEqual(1)
+		projects.Element(0).Object().Value("metadata").Object().ValueEqual("name", "perses")
+
+		usage := asUser(expect.GET(fmt.Sprintf("%s/%s/%s", shared.APIV1Prefix, shared.PathUsage, shared.PathDashboard)), "viewers").
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Array()
+		usage.Length().IsEqual(1)
+		usage.Element(0).Object().ValueEqual("project", "perses").ValueEqual("dashboard", "readable")
+
+		asUser(expect.GET(fmt.Sprintf("%s/%s", shared.APIV1Prefix, shared.PathDraft)), "viewers").
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Array().
+			Length().
+			IsEqual(0)
+		return []api.Entity{perses, other, readable, hidden}
+	})
+}
+
+func TestAnalyzeQueriesOnlyConsidersTheReadableProjects(t *testing.T) {
+	e2eframework.WithServerConfig(t, withAuthorization, func(expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
+		perses := e2eframework.NewProject("perses")
+		other := e2eframework.NewProject("other")
+		readable := e2eframework.NewDashboard(t, "perses", "readable")
+		copied := e2eframework.NewDashboard(t, "perses", "copied")
+		hidden := e2eframework.NewDashboard(t, "other", "hidden")
+		e2eframework.CreateAndWaitUntilEntitiesExist(t, manager, perses, other, readable, copied, hidden)
+
+		asUser(expect.GET("/api/analysis/queries").WithQuery("project", "other"), "viewers").
+			Expect().
+			Status(http.StatusForbidden)
+
+		duplicates := asUser(expect.GET("/api/analysis/queries"), "viewers").
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Object().
+			Value("duplicates").
+			Array()
+		duplicates.NotEmpty()
+		for _, duplicate := range duplicates.Iter() {
+			for _, source := range duplicate.Object().Value("sources").Array().Iter() {
+				source.Object().ValueEqual("project", "perses")
+			}
+		}
+		return []api.Entity{perses, other, readable, copied, hidden}
+	})
+}
@@ -0,0 +1,128 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build integration
+
+package e2e
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/gavv/httpexpect/v2"
+	"github.com/perses/perses/internal/api/config"
+	e2eframework "github.com/perses/perses/internal/api/e2e/framework"
+	accessImpl "github.com/perses/perses/internal/api/impl/v1/access"
+	"github.com/perses/perses/internal/api/shared"
+	"github.com/perses/perses/internal/api/shared/auth"
+	databaseModel "github.com/perses/perses/internal/api/shared/database/model"
+	"github.com/perses/perses/internal/api/shared/dependency"
+	"github.com/perses/perses/pkg/model/api"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDashboardGrants(t *testing.T) {
+	e2eframework.WithServer(t, func(expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
+		project := e2eframework.NewProject("perses")
+		dashboard := e2eframework.NewDashboard(t, "perses", "incident")
+		e2eframework.CreateAndWaitUntilEntitiesExist(t, manager, project, dashboard)
+
+		dashboardPath := fmt.Sprintf("%s/%s/%s/%s/%s", shared.APIV1Prefix, shared.PathProject, "perses", shared.PathDashboard, "incident")
+		expect.GET(fmt.Sprintf("%s/grants", dashboardPath)).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object().Value("spec").Object().Value("grants").Array().Length().IsEqual(0)
+
+		expect.PUT(fmt.Sprintf("%s/grants", dashboardPath)).
+			WithJSON(map[string]interface{}{
+				"grants": []map[string]string{{"group": "partners", "role": "admin"}},
+			}).
+			Expect().
+			Status(http.StatusBadRequest)
+
+		expect.PUT(fmt.Sprintf("%s/grants", dashboardPath)).
+			WithJSON(v1.DashboardAccessSpec{Grants: []v1.DashboardGrant{
+				{Group: "partners", Role: v1.RoleViewer},
+				{User: "jdoe", Role: v1.RoleEditor},
+			}}).
+			Expect().
+			Status(http.StatusOK)
+
+		result := expect.GET(fmt.Sprintf("%s/access", dashboardPath)).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Array()
+		result.Length().IsEqual(2)
+		result.Element(0).Object().ValueEqual("group", "partners").ValueEqual("role", "viewer").ValueEqual("source", "Dashboard")
+		result.Element(1).Object().ValueEqual("user", "jdoe").ValueEqual("role", "editor").ValueEqual("source", "Dashboard")
+
+		expect.PUT(fmt.Sprintf("%s/%s/%s/%s/%s/grants", shared.APIV1Prefix, shared.PathProject, "perses", shared.PathDashboard, "unknown")).
+			WithJSON(v1.DashboardAccessSpec{}).
+			Expect().
+			Status(http.StatusNotFound)
+
+		// deleting the dashboard removes its grants
+		expect.DELETE(dashboardPath).
+			Expect().
+			Status(http.StatusNoContent)
+		_, err := manager.GetAccess().Get("perses", "incident")
+		assert.True(t, databaseModel.IsKeyNotFound(err))
+		return []api.Entity{project}
+	})
+}
+
+func TestDashboardGrantsOnDatasources(t *testing.T) {
+	e2eframework.WithServer(t, func(expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
+		project := e2eframework.NewProject("perses")
+		dashboard := e2eframework.NewDashboard(t, "perses", "incident")
+		dashboard.Spec.Panels["explicit"] = &v1.Panel{
+			Kind: "Panel",
+			Spec: v1.PanelSpec{
+				Display: common.Display{Name: "explicit"},
+				Plugin:  common.Plugin{Kind: "TimeSeriesChart", Spec: map[string]interface{}{}},
+				Queries: []v1.Query{
+					{
+						Kind: "TimeSeriesQuery",
+						Spec: v1.QuerySpec{
+							Plugin: common.Plugin{
+								Kind: "PrometheusTimeSeriesQuery",
+								Spec: map[string]interface{}{
+									"query":      "up",
+									"datasource": map[string]interface{}{"kind": "PrometheusDatasource", "name": "explicit"},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+		defaultDatasource := e2eframework.NewDatasource(t, "perses", "default")
+		defaultDatasource.Spec.Default = true
+		otherDatasource := e2eframework.NewDatasource(t, "perses", "other")
+		e2eframework.CreateAndWaitUntilEntitiesExist(t, manager, project, dashboard, defaultDatasource, otherDatasource)
+
+		service := accessImpl.NewService(manager.GetAccess(), manager.GetDashboard(), manager.GetDatasource(), auth.NewAuthorization(config.Authorization{}, nil))
+		entity, err := service.Set("perses", "incident", v1.DashboardAccessSpec{Grants: []v1.DashboardGrant{{Group: "partners", Role: v1.RoleViewer}}})
+		assert.NoError(t, err)
+		partner := &shared.Identity{Username: "jdoe", Groups: []string{"partners"}}
+		assert.Equal(t, v1.RoleViewer, service.GetRole(partner, "perses", "incident"))
+		assert.Equal(t, v1.Role(""), service.GetRole(&shared.Identity{Username: "jdoe"}, "perses", "incident"))
+		assert.True(t, service.UsesDatasource("perses", "incident", "explicit"))
+		assert.True(t, service.UsesDatasource("perses", "incident", "default"))
+		assert.False(t, service.UsesDatasource("perses", "incident", "other"))
+		return []api.Entity{project, dashboard, defaultDatasource, otherDatasource, entity}
+	})
+}
@@ -102,6 +102,71 @@ func TestListDashboardInEmptyProject(t *testing.T) {
 	})
 }
 
+func TestMergeDashboard(t *testing.T) {
+	e2eframework.WithServer(t, func(expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
+		base := e2eframework.NewDashboard(t, "perses", "Demo")
+		project := e2eframework.NewProject("perses")
+		e2eframework.CreateAndWaitUntilEntitiesExist(t, manager, project, base)
+		path := fmt.Sprintf("%s/%s/%s/%s/%s", shared.APIV1Prefix, shared.PathProject, "perses", shared.PathDashboard, "Demo")
+
+		// someone else renames a panel and removes a variable in the meantime
+		stored := e2eframework.NewDashboard(t, "perses", "Demo")
+		stored.Spec.Panels["basicEx"].Spec.Display.Name = "Renamed in the meantime"
+		stored.Spec.Variables = stored.Spec.Variables[:len(stored.Spec.Variables)-1]
+		expect.PUT(path).
+			WithJSON(stored).
+			Expect().
+			Status(http.StatusOK)
+
+		local := e2eframework.NewDashboard(t, "perses", "Demo")
+		local.Spec.Panels["legendEx"].Spec.Display.Name = "Renamed locally"
+		merged := extractDashboardFromHTTPBody(expect.PUT(fmt.Sprintf("%s/merge", path)).
+			WithJSON(modelV1.DashboardMerge{Base: base, Dashboard: local}).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Raw(), t)
+
+		assert.Equal(t, "Renamed in the meantime", merged.Spec.Panels["basicEx"].Spec.Display.Name)
+		assert.Equal(t, "Renamed locally", merged.Spec.Panels["legendEx"].Spec.Display.Name)
+		assert.Equal(t, len(stored.Spec.Variables), len(merged.Spec.Variables))
+		return []api.Entity{project, base}
+	})
+}
+
+func TestMergeDashboardWithConflicts(t *testing.T) {
+	e2eframework.WithServer(t, func(expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
+		base := e2eframework.NewDashboard(t, "perses", "Demo")
+		project := e2eframework.NewProject("perses")
+		e2eframework.CreateAndWaitUntilEntitiesExist(t, manager, project, base)
+		path := fmt.Sprintf("%s/%s/%s/%s/%s", shared.APIV1Prefix, shared.PathProject, "perses", shared.PathDashboard, "Demo")
+
+		stored := e2eframework.NewDashboard(t, "perses", "Demo")
+		stored.Spec.Panels["basicEx"].Spec.Display.Name = "Renamed in the meantime"
+		expect.PUT(path).
+			WithJSON(stored).
+			Expect().
+			Status(http.StatusOK)
+
+		local := e2eframework.NewDashboard(t, "perses", "Demo")
+		local.Spec.Panels["basicEx"].Spec.Display.Name = "Renamed locally"
+		conflicts := expect.PUT(fmt.Sprintf("%s/merge", path)).
+			WithJSON(modelV1.DashboardMerge{Base: base, Dashboard: local}).
+			Expect().
+			Status(http.StatusConflict).
+			JSON().
+			Object().
+			Value("conflicts").
+			Array()
+		conflicts.Length().IsEqual(1)
+		conflict := conflicts.Element(0).Object()
+		conflict.Value("path").IsEqual("spec.panels.basicEx.spec.display.name")
+		conflict.Value("local").IsEqual("Renamed locally")
+		conflict.Value("stored").IsEqual("Renamed in the meantime")
+		return []api.Entity{project, base}
+	})
+}
+
 func extractDashboardFromHTTPBody(body interface{}, t *testing.T) *modelV1.Dashboard {
 	b := testUtils.JSONMarshalStrict(body)
 	dashboard := &modelV1.Dashboard{}
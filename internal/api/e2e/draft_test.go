@@ -0,0 +1,281 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build integration
+
+package e2e
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/gavv/httpexpect/v2"
+	"github.com/perses/perses/internal/api/config"
+	e2eframework "github.com/perses/perses/internal/api/e2e/framework"
+	"github.com/perses/perses/internal/api/shared"
+	"github.com/perses/perses/internal/api/shared/dependency"
+	"github.com/perses/perses/pkg/model/api"
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDraftWorkflow(t *testing.T) {
+	e2eframework.WithServer(t, func(expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
+		project := e2eframework.NewProject("perses")
+		entity := e2eframework.NewDashboard(t, "perses", "test")
+		e2eframework.CreateAndWaitUntilEntitiesExist(t, manager, project, entity)
+		publishedDuration := entity.Spec.Duration
+
+		dashboardPath := fmt.Sprintf("%s/%s/%s/%s/%s", shared.APIV1Prefix, shared.PathProject, "perses", shared.PathDashboard, "test")
+		draftPath := fmt.Sprintf("%s/draft", dashboardPath)
+		draftEntity := e2eframework.NewDashboard(t, "perses", "test")
+		draftEntity.Spec.Duration = model.Duration(publishedDuration * 2)
+
+		expect.PUT(draftPath).
+			WithJSON(draftEntity).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object().
+			Value("spec").Object().
+			ValueEqual("status", modelV1.DraftStatusDraft)
+
+		// the published dashboard is not modified
+		expect.GET(dashboardPath).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object().
+			Value("spec").Object().
+			ValueEqual("duration", publishedDuration.String())
+
+		diff := expect.GET(fmt.Sprintf("%s/diff", draftPath)).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object()
+		diff.ValueEqual("outdated", false)
+		diff.Value("changes").Array().Length().IsEqual(1)
+		diff.Value("changes").Array().Element(0).Object().
+			ValueEqual("kind", modelV1.ChangeModified).
+			ValueEqual("path", "spec.duration")
+
+		expect.POST(fmt.Sprintf("%s/approve", draftPath)).
+			Expect().
+			Status(http.StatusBadRequest)
+		expect.POST(fmt.Sprintf("%s/review", draftPath)).
+			Expect().
+			Status(http.StatusOK)
+		expect.POST(fmt.Sprintf("%s/approve", draftPath)).
+			Expect().
+			Status(http.StatusOK)
+
+		published := extractDashboardFromHTTPBody(expect.POST(fmt.Sprintf("%s/publish", draftPath)).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Raw(), t)
+		assert.Equal(t, draftEntity.Spec.Duration, published.Spec.Duration)
+		assert.Equal(t, entity.Metadata.Version+1, published.Metadata.Version)
+
+		expect.GET(draftPath).
+			Expect().
+			Status(http.StatusNotFound)
+		return []api.Entity{project, entity}
+	})
+}
+
+func TestDiscardDraftOfNewDashboard(t *testing.T) {
+	e2eframework.WithServer(t, func(expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
+		project := e2eframework.NewProject("perses")
+		e2eframework.CreateAndWaitUntilEntityExists(t, manager, project)
+
+		draftPath := fmt.Sprintf("%s/%s/%s/%s/%s/draft", shared.APIV1Prefix, shared.PathProject, "perses", shared.PathDashboard, "new")
+		expect.PUT(draftPath).
+			WithJSON(e2eframework.NewDashboard(t, "perses", "new")).
+			Expect().
+			Status(http.StatusOK)
+		expect.GET(fmt.Sprintf("%s/%s/%s/%s", shared.APIV1Prefix, shared.PathProject, "perses", shared.PathDraft)).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Array().Length().IsEqual(1)
+		expect.GET(fmt.Sprintf("%s/diff", draftPath)).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object().
+			ValueEqual("outdated", false)
+		expect.DELETE(draftPath).
+			Expect().
+			Status(http.StatusNoContent)
+		expect.GET(fmt.Sprintf("%s/%s/%s/%s/%s", shared.APIV1Prefix, shared.PathProject, "perses", shared.PathDashboard, "new")).
+			Expect().
+			Status(http.StatusNotFound)
+		return []api.Entity{project}
+	})
+}
+
+func TestPublishOutdatedDraft(t *testing.T) {
+	e2eframework.WithServer(t, func(expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
+		project := e2eframework.NewProject("perses")
+		entity := e2eframework.NewDashboard(t, "perses", "test")
+		e2eframework.CreateAndWaitUntilEntitiesExist(t, manager, project, entity)
+
+		dashboardPath := fmt.Sprintf("%s/%s/%s/%s/%s", shared.APIV1Prefix, shared.PathProject, "perses", shared.PathDashboard, "test")
+		draftPath := fmt.Sprintf("%s/draft", dashboardPath)
+		draftEntity := e2eframework.NewDashboard(t, "perses", "test")
+		draftEntity.Spec.Duration = model.Duration(entity.Spec.Duration * 2)
+		expect.PUT(draftPath).
+			WithJSON(draftEntity).
+			Expect().
+			Status(http.StatusOK)
+
+		// the dashboard is published again in the meantime
+		updatedEntity := e2eframework.NewDashboard(t, "perses", "test")
+		updatedEntity.Spec.Tags = []string{"production"}
+		expect.PUT(dashboardPath).
+			WithJSON(updatedEntity).
+			Expect().
+			Status(http.StatusOK)
+
+		expect.POST(fmt.Sprintf("%s/publish", draftPath)).
+			Expect().
+			Status(http.StatusConflict)
+		published := extractDashboardFromHTTPBody(expect.POST(fmt.Sprintf("%s/publish", draftPath)).
+			WithQuery("merge", true).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Raw(), t)
+		// both the draft and the changes published in the meantime are kept
+		assert.Equal(t, draftEntity.Spec.Duration, published.Spec.Duration)
+		assert.Equal(t, []string{"production"}, published.Spec.Tags)
+
+		// a new draft, outdated as well, replaces the published dashboard when it's forced
+		expect.PUT(draftPath).
+			WithJSON(draftEntity).
+			Expect().
+			Status(http.StatusOK)
+		expect.PUT(dashboardPath).
+			WithJSON(updatedEntity).
+			Expect().
+			Status(http.StatusOK)
+		published = extractDashboardFromHTTPBody(expect.POST(fmt.Sprintf("%s/publish", draftPath)).
+			WithQuery("force", true).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Raw(), t)
+		assert.Empty(t, published.Spec.Tags)
+		return []api.Entity{project, entity}
+	})
+}
+
+func TestDeleteDashboardDeletesItsDraft(t *testing.T) {
+	e2eframework.WithServer(t, func(expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
+		project := e2eframework.NewProject("perses")
+		entity := e2eframework.NewDashboard(t, "perses", "test")
+		e2eframework.CreateAndWaitUntilEntitiesExist(t, manager, project, entity)
+
+		dashboardPath := fmt.Sprintf("%s/%s/%s/%s/%s", shared.APIV1Prefix, shared.PathProject, "perses", shared.PathDashboard, "test")
+		expect.PUT(fmt.Sprintf("%s/draft", dashboardPath)).
+			WithJSON(entity).
+			Expect().
+			Status(http.StatusOK)
+		expect.DELETE(dashboardPath).
+			Expect().
+			Status(http.StatusNoContent)
+		expect.GET(fmt.Sprintf("%s/draft", dashboardPath)).
+			Expect().
+			Status(http.StatusNotFound)
+		return []api.Entity{project}
+	})
+}
+
+func TestDraftReview(t *testing.T) {
+	e2eframework.WithServerConfig(t, withAuthorization, func(expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
+		project := e2eframework.NewProject("perses")
+		entity := e2eframework.NewDashboard(t, "perses", "test")
+		e2eframework.CreateAndWaitUntilEntitiesExist(t, manager, project, entity)
+		draftPath := fmt.Sprintf("%s/%s/%s/%s/%s/draft", shared.APIV1Prefix, shared.PathProject, "perses", shared.PathDashboard, "test")
+		asAuthor := func(request *httpexpect.Request) *httpexpect.Request {
+			return request.WithHeader(config.DefaultUserHeader, "author").WithHeader(config.DefaultGroupsHeader, "editors")
+		}
+		asReviewer := func(request *httpexpect.Request) *httpexpect.Request {
+			return request.WithHeader(config.DefaultUserHeader, "reviewer").WithHeader(config.DefaultGroupsHeader, "editors")
+		}
+
+		asAuthor(expect.PUT(draftPath)).
+			WithJSON(entity).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object().
+			Path("$.spec.author").String().IsEqual("author")
+		// the drafts are only visible to the editors
+		asUser(expect.GET(draftPath), "viewers").
+			Expect().
+			Status(http.StatusForbidden)
+		asUser(expect.GET(fmt.Sprintf("%s/%s", shared.APIV1Prefix, shared.PathDraft)), "viewers").
+			Expect().
+			Status(http.StatusOK).
+			JSON().Array().IsEmpty()
+
+		asAuthor(expect.POST(fmt.Sprintf("%s/review", draftPath))).
+			Expect().
+			Status(http.StatusOK)
+		asAuthor(expect.POST(fmt.Sprintf("%s/approve", draftPath))).
+			Expect().
+			Status(http.StatusForbidden)
+		asReviewer(expect.POST(fmt.Sprintf("%s/approve", draftPath))).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object().
+			Path("$.spec.reviewer").String().IsEqual("reviewer")
+		return []api.Entity{project, entity}
+	})
+}
+
+func TestRequireReviewRefusesTheDirectModifications(t *testing.T) {
+	requireReview := func(conf *config.Config) {
+		conf.Draft.RequireReview = true
+	}
+	e2eframework.WithServerConfig(t, requireReview, func(expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
+		project := e2eframework.NewProject("perses")
+		entity := e2eframework.NewDashboard(t, "perses", "test")
+		e2eframework.CreateAndWaitUntilEntitiesExist(t, manager, project, entity)
+		dashboardPath := fmt.Sprintf("%s/%s/%s/%s/%s", shared.APIV1Prefix, shared.PathProject, "perses", shared.PathDashboard, "test")
+
+		modified := e2eframework.NewDashboard(t, "perses", "test")
+		modified.Spec.Duration = model.Duration(entity.Spec.Duration * 2)
+		expect.PUT(dashboardPath).
+			WithJSON(modified).
+			Expect().
+			Status(http.StatusConflict)
+		expect.PUT(fmt.Sprintf("%s/merge", dashboardPath)).
+			WithJSON(&modelV1.DashboardMerge{Base: entity, Dashboard: modified}).
+			Expect().
+			Status(http.StatusConflict)
+
+		// the draft workflow is still available
+		expect.PUT(fmt.Sprintf("%s/draft", dashboardPath)).
+			WithJSON(modified).
+			Expect().
+			Status(http.StatusOK)
+		expect.POST(fmt.Sprintf("%s/draft/publish", dashboardPath)).
+			Expect().
+			Status(http.StatusBadRequest)
+
+		stored, err := manager.GetDashboard().Get("perses", "test")
+		assert.NoError(t, err)
+		assert.Equal(t, entity.Spec.Duration, stored.Spec.Duration)
+		return []api.Entity{project, entity}
+	})
+}
@@ -0,0 +1,116 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build integration
+
+package e2e
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gavv/httpexpect/v2"
+	e2eframework "github.com/perses/perses/internal/api/e2e/framework"
+	ephemeralDashboardImpl "github.com/perses/perses/internal/api/impl/v1/ephemeraldashboard"
+	"github.com/perses/perses/internal/api/shared"
+	databaseModel "github.com/perses/perses/internal/api/shared/database/model"
+	"github.com/perses/perses/internal/api/shared/dependency"
+	testUtils "github.com/perses/perses/internal/test"
+	"github.com/perses/perses/pkg/model/api"
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func newEphemeralDashboard(t *testing.T, projectName string, name string) *modelV1.EphemeralDashboard {
+	dashboard := e2eframework.NewDashboard(t, projectName, name)
+	return &modelV1.EphemeralDashboard{
+		Kind:     modelV1.KindEphemeralDashboard,
+		Metadata: dashboard.Metadata,
+		Spec: modelV1.EphemeralDashboardSpec{
+			TTL:       model.Duration(time.Hour),
+			Dashboard: dashboard.Spec,
+		},
+	}
+}
+
+func TestEphemeralDashboardTTL(t *testing.T) {
+	e2eframework.WithServer(t, func(expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
+		project := e2eframework.NewProject("perses")
+		e2eframework.CreateAndWaitUntilEntityExists(t, manager, project)
+		entity := newEphemeralDashboard(t, "perses", "incident")
+		basePath := fmt.Sprintf("%s/%s/%s/%s", shared.APIV1Prefix, shared.PathProject, "perses", shared.PathEphemeralDashboard)
+
+		created := extractEphemeralDashboardFromHTTPBody(expect.POST(basePath).
+			WithJSON(entity).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Raw(), t)
+		assert.Equal(t, created.Metadata.CreatedAt.Add(time.Hour), created.Spec.ExpireAt)
+
+		expect.GET(basePath).
+			WithQuery("expire_within", "2h").
+			Expect().
+			Status(http.StatusOK).
+			JSON().Array().Length().IsEqual(1)
+		expect.GET(basePath).
+			WithQuery("expire_within", "30m").
+			Expect().
+			Status(http.StatusOK).
+			JSON().Array().Length().IsEqual(0)
+
+		extended := extractEphemeralDashboardFromHTTPBody(expect.POST(fmt.Sprintf("%s/%s/extend", basePath, "incident")).
+			WithJSON(map[string]string{"ttl": "1h"}).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Raw(), t)
+		assert.Equal(t, created.Spec.ExpireAt.Add(time.Hour), extended.Spec.ExpireAt)
+		return []api.Entity{project, entity}
+	})
+}
+
+func TestDeleteExpiredEphemeralDashboard(t *testing.T) {
+	e2eframework.WithServer(t, func(expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
+		project := e2eframework.NewProject("perses")
+		e2eframework.CreateAndWaitUntilEntityExists(t, manager, project)
+		expired := newEphemeralDashboard(t, "perses", "expired")
+		expired.Spec.TTL = 0
+		expired.Spec.ExpireAt = time.Now().UTC().Add(-time.Minute)
+		alive := newEphemeralDashboard(t, "perses", "alive")
+		alive.Spec.ExpireAt = time.Now().UTC().Add(time.Hour)
+		for _, entity := range []*modelV1.EphemeralDashboard{expired, alive} {
+			if err := manager.GetEphemeralDashboard().Create(entity); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		svc := ephemeralDashboardImpl.NewService(manager.GetEphemeralDashboard(), nil)
+		assert.NoError(t, svc.DeleteExpired())
+		_, err := manager.GetEphemeralDashboard().Get("perses", "expired")
+		assert.True(t, databaseModel.IsKeyNotFound(err))
+		_, err = manager.GetEphemeralDashboard().Get("perses", "alive")
+		assert.NoError(t, err)
+		return []api.Entity{project, alive}
+	})
+}
+
+func extractEphemeralDashboardFromHTTPBody(body interface{}, t *testing.T) *modelV1.EphemeralDashboard {
+	b := testUtils.JSONMarshalStrict(body)
+	dashboard := &modelV1.EphemeralDashboard{}
+	testUtils.JSONUnmarshal(b, dashboard)
+	return dashboard
+}
@@ -31,6 +31,7 @@ import (
 	"github.com/perses/perses/pkg/model/api/v1/datasource"
 	datasourceHTTP "github.com/perses/perses/pkg/model/api/v1/datasource/http"
 	"github.com/perses/perses/pkg/model/api/v1/variable"
+	"github.com/prometheus/common/model"
 )
 
 type GetFunc func() (api.Entity, error)
@@ -42,7 +43,7 @@ func CreateGetFunc(t *testing.T, persistenceManager dependency.PersistenceManage
 	switch entity := object.(type) {
 	case *v1.Project:
 		getFunc = func() (api.Entity, error) {
-			return persistenceManager.GetProject().Get(entity.Metadata.Name)
+			return persistenceManager.GetProject().Get(entity.Metadata.Organization, entity.Metadata.Name)
 		}
 		upsertFunc = func() error {
 			return persistenceManager.GetProject().Update(entity)
@@ -61,6 +62,13 @@ func CreateGetFunc(t *testing.T, persistenceManager dependency.PersistenceManage
 		upsertFunc = func() error {
 			return persistenceManager.GetGlobalDatasource().Update(entity)
 		}
+	case *v1.GlobalDatasourceOverride:
+		getFunc = func() (api.Entity, error) {
+			return persistenceManager.GetGlobalDatasourceOverride().Get(entity.Metadata.Project, entity.Metadata.Name)
+		}
+		upsertFunc = func() error {
+			return persistenceManager.GetGlobalDatasourceOverride().Update(entity)
+		}
 	case *v1.Dashboard:
 		getFunc = func() (api.Entity, error) {
 			return persistenceManager.GetDashboard().Get(entity.Metadata.Project, entity.Metadata.Name)
@@ -75,6 +83,20 @@ func CreateGetFunc(t *testing.T, persistenceManager dependency.PersistenceManage
 		upsertFunc = func() error {
 			return persistenceManager.GetVariable().Update(entity)
 		}
+	case *v1.GlobalDashboard:
+		getFunc = func() (api.Entity, error) {
+			return persistenceManager.GetGlobalDashboard().Get(entity.Metadata.Name)
+		}
+		upsertFunc = func() error {
+			return persistenceManager.GetGlobalDashboard().Update(entity)
+		}
+	case *v1.GlobalHomeConfig:
+		getFunc = func() (api.Entity, error) {
+			return persistenceManager.GetGlobalHomeConfig().Get(entity.Metadata.Name)
+		}
+		upsertFunc = func() error {
+			return persistenceManager.GetGlobalHomeConfig().Update(entity)
+		}
 	case *v1.GlobalVariable:
 		getFunc = func() (api.Entity, error) {
 			return persistenceManager.GetGlobalVariable().Get(entity.Metadata.Name)
@@ -82,6 +104,48 @@ func CreateGetFunc(t *testing.T, persistenceManager dependency.PersistenceManage
 		upsertFunc = func() error {
 			return persistenceManager.GetGlobalVariable().Update(entity)
 		}
+	case *v1.Team:
+		getFunc = func() (api.Entity, error) {
+			return persistenceManager.GetTeam().Get(entity.Metadata.Name)
+		}
+		upsertFunc = func() error {
+			return persistenceManager.GetTeam().Update(entity)
+		}
+	case *v1.Organization:
+		getFunc = func() (api.Entity, error) {
+			return persistenceManager.GetOrganization().Get(entity.Metadata.Name)
+		}
+		upsertFunc = func() error {
+			return persistenceManager.GetOrganization().Update(entity)
+		}
+	case *v1.OrganizationDatasource:
+		getFunc = func() (api.Entity, error) {
+			return persistenceManager.GetOrganizationDatasource().Get(entity.Metadata.Organization, entity.Metadata.Name)
+		}
+		upsertFunc = func() error {
+			return persistenceManager.GetOrganizationDatasource().Update(entity)
+		}
+	case *v1.Playlist:
+		getFunc = func() (api.Entity, error) {
+			return persistenceManager.GetPlaylist().Get(entity.Metadata.Project, entity.Metadata.Name)
+		}
+		upsertFunc = func() error {
+			return persistenceManager.GetPlaylist().Update(entity)
+		}
+	case *v1.HomeConfig:
+		getFunc = func() (api.Entity, error) {
+			return persistenceManager.GetHomeConfig().Get(entity.Metadata.Project, entity.Metadata.Name)
+		}
+		upsertFunc = func() error {
+			return persistenceManager.GetHomeConfig().Update(entity)
+		}
+	case *v1.SavedView:
+		getFunc = func() (api.Entity, error) {
+			return persistenceManager.GetSavedView().Get(entity.Metadata.Project, entity.Metadata.Name)
+		}
+		upsertFunc = func() error {
+			return persistenceManager.GetSavedView().Update(entity)
+		}
 	default:
 		t.Fatalf("%T is not managed", object)
 	}
@@ -107,9 +171,9 @@ func CreateAndWaitUntilEntityExists(t *testing.T, persistenceManager dependency.
 			t.Fatal(err)
 		}
 		j := 0
-		for _, err = getFunc(); err != nil && j < 30; _, err = getFunc() {
+		for _, err = getFunc(); err != nil && j < 600; _, err = getFunc() {
 			j++
-			time.Sleep(2 * time.Second)
+			time.Sleep(100 * time.Millisecond)
 		}
 	}
 	if err != nil {
@@ -118,11 +182,25 @@ func CreateAndWaitUntilEntityExists(t *testing.T, persistenceManager dependency.
 }
 
 func NewProject(name string) *v1.Project {
+	return NewProjectInOrganization(v1.DefaultOrganization, name)
+}
+
+func NewProjectInOrganization(organizationName string, name string) *v1.Project {
 	entity := &v1.Project{
-		Kind: v1.KindProject,
+		Kind:     v1.KindProject,
+		Metadata: *v1.NewOrganizationMetadata(organizationName, name),
+	}
+	entity.Metadata.CreateNow()
+	return entity
+}
+
+func NewOrganization(name string) *v1.Organization {
+	entity := &v1.Organization{
+		Kind: v1.KindOrganization,
 		Metadata: v1.Metadata{
 			Name: name,
-		}}
+		},
+	}
 	entity.Metadata.CreateNow()
 	return entity
 }
@@ -214,6 +292,38 @@ func NewGlobalDatasource(t *testing.T, name string) *v1.GlobalDatasource {
 	return entity
 }
 
+// NewGlobalDatasourceOverride returns an override of the global datasource created with NewGlobalDatasource, changing
+// its URL.
+func NewGlobalDatasourceOverride(projectName string, name string, url string) *v1.GlobalDatasourceOverride {
+	entity := &v1.GlobalDatasourceOverride{
+		Kind:     v1.KindGlobalDatasourceOverride,
+		Metadata: *v1.NewProjectMetadata(projectName, name),
+		Spec: v1.GlobalDatasourceOverrideSpec{
+			Plugin: &v1.PluginOverride{
+				Spec: map[string]interface{}{
+					"proxy": map[string]interface{}{
+						"spec": map[string]interface{}{
+							"url": url,
+						},
+					},
+				},
+			},
+		},
+	}
+	entity.Metadata.CreateNow()
+	return entity
+}
+
+func NewOrganizationDatasource(t *testing.T, organizationName string, name string) *v1.OrganizationDatasource {
+	entity := &v1.OrganizationDatasource{
+		Kind:     v1.KindOrganizationDatasource,
+		Metadata: *v1.NewOrganizationMetadata(organizationName, name),
+		Spec:     newDatasourceSpec(t),
+	}
+	entity.Metadata.CreateNow()
+	return entity
+}
+
 func NewVariable(projectName string, name string) *v1.Variable {
 	entity := &v1.Variable{
 		Kind: v1.KindVariable,
@@ -265,6 +375,70 @@ func NewGlobalVariable(name string) *v1.GlobalVariable {
 	return entity
 }
 
+func NewTeam(name string) *v1.Team {
+	entity := &v1.Team{
+		Kind: v1.KindTeam,
+		Metadata: v1.Metadata{
+			Name: name,
+		},
+		Spec: v1.TeamSpec{
+			Members: []v1.TeamMember{
+				{
+					Username: "alice",
+					Email:    "alice@perses.dev",
+				},
+			},
+			ExternalGroups: []string{"observability"},
+			Contact: &v1.TeamContact{
+				Chat: "#observability",
+			},
+		},
+	}
+	entity.Metadata.CreateNow()
+	return entity
+}
+
+func NewPlaylist(projectName string, name string) *v1.Playlist {
+	entity := &v1.Playlist{
+		Kind: v1.KindPlaylist,
+		Metadata: v1.ProjectMetadata{
+			Metadata: v1.Metadata{
+				Name: name,
+			},
+			Project: projectName,
+		},
+		Spec: v1.PlaylistSpec{
+			Interval: model.Duration(time.Minute),
+			Items: []v1.PlaylistItem{
+				{Tag: "noc"},
+			},
+		},
+	}
+	entity.Metadata.CreateNow()
+	return entity
+}
+
+func NewSavedView(projectName string, name string, dashboardName string) *v1.SavedView {
+	entity := &v1.SavedView{
+		Kind: v1.KindSavedView,
+		Metadata: v1.ProjectMetadata{
+			Metadata: v1.Metadata{
+				Name: name,
+			},
+			Project: projectName,
+		},
+		Spec: v1.SavedViewSpec{
+			Dashboard: dashboardName,
+			Variables: map[string]*variable.DefaultValue{
+				"interval": {SingleValue: "5m"},
+			},
+			TimeRange: &v1.TimeRange{Duration: model.Duration(15 * time.Minute)},
+		},
+	}
+	entity.Metadata.CreateNow()
+	return entity
+}
+
 func NewDashboard(t *testing.T, projectName string, name string) *v1.Dashboard {
 	// Creating a full dashboard is quite long and to ensure the changes are still matching the dev environment,
 	// it's better to use the dashboard written in the dev/data/dashboard.json
@@ -284,3 +458,46 @@ func NewDashboard(t *testing.T, projectName string, name string) *v1.Dashboard {
 	dashboard.Metadata.Project = projectName
 	return dashboard
 }
+
+func NewGlobalDashboard(t *testing.T, name string) *v1.GlobalDashboard {
+	dashboard := NewDashboard(t, "", name)
+	entity := &v1.GlobalDashboard{
+		Kind:     v1.KindGlobalDashboard,
+		Metadata: dashboard.Metadata.Metadata,
+		Spec:     dashboard.Spec,
+	}
+	entity.Metadata.CreateNow()
+	return entity
+}
+
+func NewGlobalHomeConfig(name string, dashboards ...v1.DashboardSelector) *v1.GlobalHomeConfig {
+	entity := &v1.GlobalHomeConfig{
+		Kind: v1.KindGlobalHomeConfig,
+		Metadata: v1.Metadata{
+			Name: name,
+		},
+		Spec: v1.HomeConfigSpec{
+			ImportantDashboards: dashboards,
+			Information:         "# Hello World",
+		},
+	}
+	entity.Metadata.CreateNow()
+	return entity
+}
+
+func NewHomeConfig(projectName string, name string, dashboards ...v1.DashboardSelector) *v1.HomeConfig {
+	entity := &v1.HomeConfig{
+		Kind: v1.KindHomeConfig,
+		Metadata: v1.ProjectMetadata{
+			Metadata: v1.Metadata{
+				Name: name,
+			},
+			Project: projectName,
+		},
+		Spec: v1.HomeConfigSpec{
+			ImportantDashboards: dashboards,
+		},
+	}
+	entity.Metadata.CreateNow()
+	return entity
+}
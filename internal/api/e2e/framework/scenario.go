@@ -20,7 +20,6 @@ import (
 	"fmt"
 	"net/http"
 	"testing"
-	"time"
 
 	"github.com/gavv/httpexpect/v2"
 	"github.com/perses/perses/internal/api/shared"
@@ -65,8 +64,6 @@ func MainTestScenario(t *testing.T, path string, creator func(name string) model
 		WithServer(t, func(expect *httpexpect.Expect, manager dependency.PersistenceManager) []modelAPI.Entity {
 			entity := creator("myResource")
 			CreateAndWaitUntilEntityExists(t, manager, entity)
-			// For the "get all" requests, we have no choice to wait a bit of time between the creation and the "get all"
-			time.Sleep(3 * time.Second)
 
 			// Check the retrieval of the entity among all the others
 			expect.GET(fmt.Sprintf("%s/%s", shared.APIV1Prefix, path)).
@@ -148,11 +145,6 @@ func MainTestScenario(t *testing.T, path string, creator func(name string) model
 			entity := creator("myResource")
 			CreateAndWaitUntilEntityExists(t, manager, entity)
 
-			// For obscure reason, the check that entity exists can pass and not the deletion. As the deletion fails
-			// silently, the GET right after can then return an unexpected 200. So we do an explicit wait to make sure
-			// that the resource is well saved before delete
-			time.Sleep(3 * time.Second)
-
 			expect.DELETE(fmt.Sprintf("%s/%s/%s", shared.APIV1Prefix, path, entity.GetMetadata().GetName())).
 				Expect().
 				Status(http.StatusNoContent)
@@ -216,9 +208,6 @@ func MainTestScenarioWithProject(t *testing.T, path string, creator func(project
 			parent, entity := creator("myProject", "myResource")
 			CreateAndWaitUntilEntitiesExist(t, manager, parent, entity)
 
-			// For the "get all" requests, we have no choice to wait a bit of time between the creation and the "get all"
-			time.Sleep(3 * time.Second)
-
 			// Check the retrieval of the entity among all the others
 			expect.GET(fmt.Sprintf("%s/%s", shared.APIV1Prefix, path)).
 				Expect().
@@ -248,9 +237,6 @@ func MainTestScenarioWithProject(t *testing.T, path string, creator func(project
 			parent2, entity2 := creator("myProject2", "myResource2")
 			CreateAndWaitUntilEntitiesExist(t, manager, parent1, parent2, entity1, entity2)
 
-			// For the "get all" requests, we have no choice to wait a bit of time between the creation and the "get all"
-			time.Sleep(3 * time.Second)
-
 			expect.GET(fmt.Sprintf("%s/%s", shared.APIV1Prefix, path)).
 				Expect().
 				Status(http.StatusOK).
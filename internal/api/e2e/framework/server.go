@@ -19,6 +19,7 @@ import (
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"sync"
 	"testing"
 
 	"github.com/gavv/httpexpect/v2"
@@ -26,6 +27,7 @@ import (
 	"github.com/perses/perses/internal/api/core"
 	databaseModel "github.com/perses/perses/internal/api/shared/database/model"
 	"github.com/perses/perses/internal/api/shared/dependency"
+	"github.com/perses/perses/internal/api/shared/schemas"
 	test "github.com/perses/perses/internal/test"
 	modelAPI "github.com/perses/perses/pkg/model/api"
 	modelV1 "github.com/perses/perses/pkg/model/api/v1"
@@ -34,6 +36,20 @@ import (
 
 var useSQL = os.Getenv("PERSES_TEST_USE_SQL")
 
+var (
+	// loadedSchemas are shared by all the servers of the tests, as loading them takes several seconds.
+	loadedSchemas   schemas.Schemas
+	loadSchemasErr  error
+	loadSchemasOnce sync.Once
+)
+
+func getSchemas(conf config.Schemas) (schemas.Schemas, error) {
+	loadSchemasOnce.Do(func() {
+		loadedSchemas, loadSchemasErr = schemas.New(conf)
+	})
+	return loadedSchemas, loadSchemasErr
+}
+
 func ClearAllKeys(t *testing.T, dao databaseModel.DAO, entities ...modelAPI.Entity) {
 	for _, entity := range entities {
 		err := dao.Delete(modelV1.Kind(entity.GetKind()), entity.GetMetadata())
@@ -41,6 +57,11 @@ func ClearAllKeys(t *testing.T, dao databaseModel.DAO, entities ...modelAPI.Enti
 			t.Fatal(err)
 		}
 	}
+	// the default organization is created by the server when it starts
+	err := dao.Delete(modelV1.KindOrganization, &modelV1.Metadata{Name: modelV1.DefaultOrganization})
+	if err != nil && !databaseModel.IsKeyNotFound(err) {
+		t.Fatal(err)
+	}
 }
 
 func defaultFileConfig() *config.File {
@@ -51,14 +72,20 @@ func defaultFileConfig() *config.File {
 }
 
 func CreateServer(t *testing.T) (*httptest.Server, *httpexpect.Expect, dependency.PersistenceManager) {
+	return CreateServerWithConfig(t, nil)
+}
+
+// CreateServerWithConfig creates the server with the default test configuration, modified by customize when not nil.
+func CreateServerWithConfig(t *testing.T, customize func(conf *config.Config)) (*httptest.Server, *httpexpect.Expect, dependency.PersistenceManager) {
 	projectPath := test.GetRepositoryPath()
 	conf := config.Config{
 		Schemas: config.Schemas{
-			PanelsPath:      filepath.Join(projectPath, config.DefaultPanelsPath),
-			QueriesPath:     filepath.Join(projectPath, config.DefaultQueriesPath),
-			DatasourcesPath: filepath.Join(projectPath, config.DefaultDatasourcesPath),
-			VariablesPath:   filepath.Join(projectPath, config.DefaultVariablesPath),
-			Interval:        0,
+			PanelsPath:          filepath.Join(projectPath, config.DefaultPanelsPath),
+			QueriesPath:         filepath.Join(projectPath, config.DefaultQueriesPath),
+			DatasourcesPath:     filepath.Join(projectPath, config.DefaultDatasourcesPath),
+			VariablesPath:       filepath.Join(projectPath, config.DefaultVariablesPath),
+			TransformationsPath: filepath.Join(projectPath, config.DefaultTransformationsPath),
+			Interval:            0,
 		},
 	}
 	if useSQL == "true" {
@@ -77,7 +104,15 @@ func CreateServer(t *testing.T) (*httptest.Server, *httpexpect.Expect, dependenc
 			File: defaultFileConfig(),
 		}
 	}
-	runner, persistenceManager, err := core.New(conf, "")
+	if customize != nil {
+		customize(&conf)
+	}
+	loaded, err := getSchemas(conf.Schemas)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the server is not started, so the schemas don't need to be watched
+	runner, persistenceManager, err := core.NewWithOptions(conf, "", core.Options{Schemas: loaded, DisableHotReload: true})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -93,7 +128,11 @@ func CreateServer(t *testing.T) (*httptest.Server, *httpexpect.Expect, dependenc
 }
 
 func WithServer(t *testing.T, testFunc func(*httpexpect.Expect, dependency.PersistenceManager) []modelAPI.Entity) {
-	server, expect, persistenceManager := CreateServer(t)
+	WithServerConfig(t, nil, testFunc)
+}
+
+func WithServerConfig(t *testing.T, customize func(conf *config.Config), testFunc func(*httpexpect.Expect, dependency.PersistenceManager) []modelAPI.Entity) {
+	server, expect, persistenceManager := CreateServerWithConfig(t, customize)
 	defer persistenceManager.GetPersesDAO().Close()
 	defer server.Close()
 	entities := testFunc(expect, persistenceManager)
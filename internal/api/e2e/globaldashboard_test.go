@@ -0,0 +1,56 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build integration
+
+package e2e
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/gavv/httpexpect/v2"
+	e2eframework "github.com/perses/perses/internal/api/e2e/framework"
+	"github.com/perses/perses/internal/api/shared"
+	"github.com/perses/perses/internal/api/shared/dependency"
+	"github.com/perses/perses/pkg/model/api"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+func TestMainScenarioGlobalDashboard(t *testing.T) {
+	e2eframework.MainTestScenario(t, shared.PathGlobalDashboard, func(name string) api.Entity {
+		return e2eframework.NewGlobalDashboard(t, name)
+	})
+}
+
+func TestGetGlobalDashboardInProject(t *testing.T) {
+	e2eframework.WithServer(t, func(expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
+		project := e2eframework.NewProject("perses")
+		entity := e2eframework.NewGlobalDashboard(t, "cluster")
+		e2eframework.CreateAndWaitUntilEntitiesExist(t, manager, project, entity)
+
+		metadata := expect.GET(fmt.Sprintf("%s/%s/%s/%s/%s", shared.APIV1Prefix, shared.PathProject, "perses", shared.PathGlobalDashboard, "cluster")).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object().ValueEqual("kind", v1.KindDashboard).
+			Value("metadata").Object()
+		metadata.ValueEqual("name", "cluster")
+		metadata.ValueEqual("project", "perses")
+
+		expect.GET(fmt.Sprintf("%s/%s/%s/%s/%s", shared.APIV1Prefix, shared.PathProject, "unknown", shared.PathGlobalDashboard, "cluster")).
+			Expect().
+			Status(http.StatusNotFound)
+		return []api.Entity{project, entity}
+	})
+}
@@ -0,0 +1,225 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build integration
+
+package e2e
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gavv/httpexpect/v2"
+	"github.com/perses/perses/internal/api/config"
+	e2eframework "github.com/perses/perses/internal/api/e2e/framework"
+	"github.com/perses/perses/internal/api/shared"
+	"github.com/perses/perses/internal/api/shared/dependency"
+	"github.com/perses/perses/pkg/model/api"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateGlobalDatasourceOverride(t *testing.T) {
+	e2eframework.WithServer(t, func(expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
+		project := e2eframework.NewProject("perses")
+		globalDatasource := e2eframework.NewGlobalDatasource(t, "prometheus")
+		e2eframework.CreateAndWaitUntilEntitiesExist(t, manager, project, globalDatasource)
+		override := e2eframework.NewGlobalDatasourceOverride("perses", "prometheus", "https://prometheus.perses.example.com")
+
+		expect.POST(fmt.Sprintf("%s/%s/%s/%s", shared.APIV1Prefix, shared.PathProject, "perses", shared.PathGlobalDatasourceOverride)).
+			WithJSON(override).
+			Expect().
+			Status(http.StatusOK)
+
+		return []api.Entity{project, globalDatasource, override}
+	})
+}
+
+func TestCreateGlobalDatasourceOverrideWithoutGlobalDatasource(t *testing.T) {
+	e2eframework.WithServer(t, func(expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
+		project := e2eframework.NewProject("perses")
+		e2eframework.CreateAndWaitUntilEntityExists(t, manager, project)
+		override := e2eframework.NewGlobalDatasourceOverride("perses", "prometheus", "https://prometheus.perses.example.com")
+
+		expect.POST(fmt.Sprintf("%s/%s/%s/%s", shared.APIV1Prefix, shared.PathProject, "perses", shared.PathGlobalDatasourceOverride)).
+			WithJSON(override).
+			Expect().
+			Status(http.StatusBadRequest)
+
+		return []api.Entity{project}
+	})
+}
+
+func TestGetGlobalDatasourceInProject(t *testing.T) {
+	e2eframework.WithServer(t, func(expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
+		persesProject := e2eframework.NewProject("perses")
+		demoProject := e2eframework.NewProject("demo")
+		globalDatasource := e2eframework.NewGlobalDatasource(t, "prometheus")
+		override := e2eframework.NewGlobalDatasourceOverride("perses", "prometheus", "https://prometheus.perses.example.com")
+		e2eframework.CreateAndWaitUntilEntitiesExist(t, manager, persesProject, demoProject, globalDatasource, override)
+		urlPath := "$.spec.plugin.spec.proxy.spec.url"
+
+		// the override is merged over the global datasource in the project
+		persesPath := fmt.Sprintf("%s/%s/%s/%s", shared.APIV1Prefix, shared.PathProject, "perses", shared.PathGlobalDatasource)
+		expect.GET(fmt.Sprintf("%s/%s", persesPath, "prometheus")).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Path(urlPath).
+			IsEqual("https://prometheus.perses.example.com")
+		list := expect.GET(persesPath).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Array()
+		list.Length().IsEqual(1)
+		list.Element(0).Path(urlPath).IsEqual("https://prometheus.perses.example.com")
+
+		// but not in the other projects
+		expect.GET(fmt.Sprintf("%s/%s/%s/%s/%s", shared.APIV1Prefix, shared.PathProject, "demo", shared.PathGlobalDatasource, "prometheus")).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Path(urlPath).
+			IsEqual("https://prometheus.demo.do.prometheus.io")
+
+		// and the global datasource itself is unchanged
+		expect.GET(fmt.Sprintf("%s/%s/%s", shared.APIV1Prefix, shared.PathGlobalDatasource, "prometheus")).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Path(urlPath).
+			IsEqual("https://prometheus.demo.do.prometheus.io")
+
+		return []api.Entity{persesProject, demoProject, globalDatasource, override}
+	})
+}
+
+func newBackend(name string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(name))
+	}))
+}
+
+func TestGlobalDatasourceProxyRequiresAGlobalPermission(t *testing.T) {
+	globalBackend := newBackend("global")
+	defer globalBackend.Close()
+	persesBackend := newBackend("perses")
+	defer persesBackend.Close()
+	withGlobalViewers := func(conf *config.Config) {
+		withAuthorization(conf)
+		conf.Authorization.Groups = append(conf.Authorization.Groups, config.GroupPermissions{
+			Group:       "global-viewers",
+			Permissions: []v1.Permission{{Organization: v1.DefaultOrganization, Role: v1.RoleViewer}},
+		})
+	}
+	e2eframework.WithServerConfig(t, withGlobalViewers, func(expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
+		project := e2eframework.NewProject("perses")
+		globalDatasource := e2eframework.NewGlobalDatasource(t, "prometheus")
+		proxy := globalDatasource.Spec.Plugin.Spec.(map[string]interface{})["proxy"].(map[string]interface{})
+		proxy["spec"].(map[string]interface{})["url"] = globalBackend.URL
+		override := e2eframework.NewGlobalDatasourceOverride("perses", "prometheus", persesBackend.URL)
+		e2eframework.CreateAndWaitUntilEntitiesExist(t, manager, project, globalDatasource, override)
+
+		// the users of the project are querying the global datasource with the override of the project
+		asUser(expect.POST("/proxy/projects/perses/globaldatasources/prometheus/api/v1/query"), "viewers").
+			Expect().
+			Status(http.StatusOK).
+			Body().IsEqual("perses")
+		// and can't bypass it with the global proxy
+		asUser(expect.POST("/proxy/globaldatasources/prometheus/api/v1/query"), "viewers").
+			Expect().
+			Status(http.StatusForbidden)
+		// which ignores the overrides, so it is only available with a permission on the whole organization
+		asUser(expect.POST("/proxy/globaldatasources/prometheus/api/v1/query"), "global-viewers").
+			Expect().
+			Status(http.StatusOK).
+			Body().IsEqual("global")
+		return []api.Entity{project, globalDatasource, override}
+	})
+}
+
+func TestGlobalDatasourceOverrideDoesNotLeakTheCredentials(t *testing.T) {
+	var receivedAuthorization []string
+	attackerBackend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedAuthorization = append(receivedAuthorization, r.Header.Get("Authorization"))
+		_, _ = w.Write([]byte("attacker"))
+	}))
+	defer attackerBackend.Close()
+	withProjectAdmins := func(conf *config.Config) {
+		withAuthorization(conf)
+		conf.Authorization.Groups = append(conf.Authorization.Groups, config.GroupPermissions{
+			Group:       "admins",
+			Permissions: []v1.Permission{{Project: "perses", Role: v1.RoleAdmin}},
+		})
+	}
+	e2eframework.WithServerConfig(t, withProjectAdmins, func(expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
+		project := e2eframework.NewProject("perses")
+		globalDatasource := e2eframework.NewGlobalDatasource(t, "prometheus")
+		proxy := globalDatasource.Spec.Plugin.Spec.(map[string]interface{})["proxy"].(map[string]interface{})
+		proxy["spec"].(map[string]interface{})["headers"] = map[string]interface{}{"Authorization": "Bearer secret"}
+		e2eframework.CreateAndWaitUntilEntitiesExist(t, manager, project, globalDatasource)
+		overridesPath := fmt.Sprintf("%s/%s/%s/%s", shared.APIV1Prefix, shared.PathProject, "perses", shared.PathGlobalDatasourceOverride)
+		override := e2eframework.NewGlobalDatasourceOverride("perses", "prometheus", attackerBackend.URL)
+
+		// the editors of the project can't override a datasource shared with the other projects
+		asUser(expect.POST(overridesPath), "editors").
+			WithJSON(override).
+			Expect().
+			Status(http.StatusForbidden)
+		asUser(expect.POST(fmt.Sprintf("%s/%s", shared.APIV1Prefix, shared.PathGlobalDatasourceOverride)), "editors").
+			WithJSON(override).
+			Expect().
+			Status(http.StatusForbidden)
+
+		// the endpoints allowed by the global datasource can't be opened
+		asUser(expect.POST(overridesPath), "admins").
+			WithJSON(map[string]interface{}{
+				"kind":     v1.KindGlobalDatasourceOverride,
+				"metadata": map[string]string{"name": "prometheus", "project": "perses"},
+				"spec": map[string]interface{}{
+					"plugin": map[string]interface{}{
+						"spec": map[string]interface{}{
+							"proxy": map[string]interface{}{
+								"spec": map[string]interface{}{
+									"allowed_endpoints": []map[string]string{{"endpoint_pattern": "/api/v1/admin/.*", "method": http.MethodPost}},
+								},
+							},
+						},
+					},
+				},
+			}).
+			Expect().
+			Status(http.StatusBadRequest)
+
+		// the URL can be changed, but the credentials of the global datasource are not sent to the other host
+		asUser(expect.POST(overridesPath), "admins").
+			WithJSON(override).
+			Expect().
+			Status(http.StatusOK)
+		asUser(expect.GET(fmt.Sprintf("%s/%s/%s/%s/%s", shared.APIV1Prefix, shared.PathProject, "perses", shared.PathGlobalDatasource, "prometheus")), "viewers").
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Path("$.spec.plugin.spec.proxy.spec").Object().
+			NotContainsKey("headers")
+		asUser(expect.POST("/proxy/projects/perses/globaldatasources/prometheus/api/v1/query"), "viewers").
+			Expect().
+			Status(http.StatusOK).
+			Body().IsEqual("attacker")
+		assert.Equal(t, []string{""}, receivedAuthorization)
+		return []api.Entity{project, globalDatasource, override}
+	})
+}
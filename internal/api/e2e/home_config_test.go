@@ -0,0 +1,129 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build integration
+
+package e2e
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/gavv/httpexpect/v2"
+	e2eframework "github.com/perses/perses/internal/api/e2e/framework"
+	"github.com/perses/perses/internal/api/shared"
+	"github.com/perses/perses/internal/api/shared/dependency"
+	"github.com/perses/perses/pkg/model/api"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+func TestMainScenarioGlobalHomeConfig(t *testing.T) {
+	e2eframework.MainTestScenario(t, shared.PathGlobalHomeConfig, func(name string) api.Entity {
+		return e2eframework.NewGlobalHomeConfig(name)
+	})
+}
+
+func TestMainScenarioHomeConfig(t *testing.T) {
+	e2eframework.MainTestScenarioWithProject(t, shared.PathHomeConfig, func(projectName string, name string) (api.Entity, api.Entity) {
+		return e2eframework.NewProject(projectName), e2eframework.NewHomeConfig(projectName, name)
+	})
+}
+
+func TestCreateHomeConfigWithUnknownDashboard(t *testing.T) {
+	e2eframework.WithServer(t, func(expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
+		project := e2eframework.NewProject("perses")
+		e2eframework.CreateAndWaitUntilEntitiesExist(t, manager, project)
+
+		expect.POST(fmt.Sprintf("%s/%s", shared.APIV1Prefix, shared.PathGlobalHomeConfig)).
+			WithJSON(e2eframework.NewGlobalHomeConfig("default", v1.DashboardSelector{Project: "perses", Dashboard: "unknown"})).
+			Expect().
+			Status(http.StatusBadRequest)
+
+		expect.POST(fmt.Sprintf("%s/%s/%s/%s", shared.APIV1Prefix, shared.PathProject, "perses", shared.PathHomeConfig)).
+			WithJSON(e2eframework.NewHomeConfig("perses", "default", v1.DashboardSelector{Dashboard: "unknown"})).
+			Expect().
+			Status(http.StatusBadRequest)
+		return []api.Entity{project}
+	})
+}
+
+func TestCreateSecondHomeConfig(t *testing.T) {
+	e2eframework.WithServer(t, func(expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
+		project := e2eframework.NewProject("perses")
+		globalHomeConfig := e2eframework.NewGlobalHomeConfig("default")
+		homeConfig := e2eframework.NewHomeConfig("perses", "default")
+		e2eframework.CreateAndWaitUntilEntitiesExist(t, manager, project, globalHomeConfig, homeConfig)
+
+		expect.POST(fmt.Sprintf("%s/%s", shared.APIV1Prefix, shared.PathGlobalHomeConfig)).
+			WithJSON(e2eframework.NewGlobalHomeConfig("other")).
+			Expect().
+			Status(http.StatusConflict)
+
+		expect.POST(fmt.Sprintf("%s/%s/%s/%s", shared.APIV1Prefix, shared.PathProject, "perses", shared.PathHomeConfig)).
+			WithJSON(e2eframework.NewHomeConfig("perses", "other")).
+			Expect().
+			Status(http.StatusConflict)
+		return []api.Entity{project, globalHomeConfig, homeConfig}
+	})
+}
+
+func TestGetHome(t *testing.T) {
+	e2eframework.WithServer(t, func(expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
+		project := e2eframework.NewProject("perses")
+		demo := e2eframework.NewDashboard(t, "perses", "Demo")
+		benchmark := e2eframework.NewDashboard(t, "perses", "Benchmark")
+		globalHomeConfig := e2eframework.NewGlobalHomeConfig("default", v1.DashboardSelector{Project: "perses", Dashboard: "Demo"})
+		homeConfig := e2eframework.NewHomeConfig("perses", "default", v1.DashboardSelector{Project: "perses", Dashboard: "Benchmark"})
+		e2eframework.CreateAndWaitUntilEntitiesExist(t, manager, project, demo, benchmark, globalHomeConfig, homeConfig)
+
+		global := expect.GET(fmt.Sprintf("%s/%s", shared.APIV1Prefix, shared.PathHome)).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object()
+		global.ValueEqual("information", "# Hello World")
+		global.Value("important_dashboards").Array().Length().Equal(1)
+		global.Value("important_dashboards").Array().Element(0).Object().ValueEqual("dashboard", "Demo")
+
+		// the dashboards are overridden by the project while the information is inherited
+		inProject := expect.GET(fmt.Sprintf("%s/%s/%s/%s", shared.APIV1Prefix, shared.PathProject, "perses", shared.PathHome)).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object()
+		inProject.ValueEqual("information", "# Hello World")
+		inProject.Value("important_dashboards").Array().Length().Equal(1)
+		inProject.Value("important_dashboards").Array().Element(0).Object().ValueEqual("dashboard", "Benchmark")
+		return []api.Entity{project, demo, benchmark, globalHomeConfig, homeConfig}
+	})
+}
+
+func TestDeleteDashboardRemovesItFromHome(t *testing.T) {
+	e2eframework.WithServer(t, func(expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
+		project := e2eframework.NewProject("perses")
+		demo := e2eframework.NewDashboard(t, "perses", "Demo")
+		globalHomeConfig := e2eframework.NewGlobalHomeConfig("default", v1.DashboardSelector{Project: "perses", Dashboard: "Demo"})
+		e2eframework.CreateAndWaitUntilEntitiesExist(t, manager, project, demo, globalHomeConfig)
+
+		expect.DELETE(fmt.Sprintf("%s/%s/%s/%s/%s", shared.APIV1Prefix, shared.PathProject, "perses", shared.PathDashboard, "Demo")).
+			Expect().
+			Status(http.StatusNoContent)
+
+		expect.GET(fmt.Sprintf("%s/%s/%s", shared.APIV1Prefix, shared.PathGlobalHomeConfig, "default")).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object().
+			Value("spec").Object().
+			NotContainsKey("important_dashboards")
+		return []api.Entity{project, globalHomeConfig}
+	})
+}
@@ -0,0 +1,266 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build integration
+
+package e2e
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/gavv/httpexpect/v2"
+	"github.com/perses/perses/internal/api/config"
+	e2eframework "github.com/perses/perses/internal/api/e2e/framework"
+	organizationImpl "github.com/perses/perses/internal/api/impl/v1/organization"
+	"github.com/perses/perses/internal/api/shared"
+	"github.com/perses/perses/internal/api/shared/dependency"
+	"github.com/perses/perses/pkg/model/api"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMainScenarioOrganization(t *testing.T) {
+	e2eframework.MainTestScenario(t, shared.PathOrganization, func(name string) api.Entity {
+		return e2eframework.NewOrganization(name)
+	})
+}
+
+func TestCreateProjectInOrganization(t *testing.T) {
+	e2eframework.WithServer(t, func(expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
+		organization := e2eframework.NewOrganization("acme")
+		e2eframework.CreateAndWaitUntilEntitiesExist(t, manager, organization)
+		project := e2eframework.NewProjectInOrganization("acme", "perses")
+
+		expect.POST(fmt.Sprintf("%s/%s/%s/%s", shared.APIV1Prefix, shared.PathOrganization, "acme", shared.PathProject)).
+			WithJSON(project).
+			Expect().
+			Status(http.StatusOK)
+
+		// the project is still reachable without its organization
+		expect.GET(fmt.Sprintf("%s/%s/%s", shared.APIV1Prefix, shared.PathProject, "perses")).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object().Path("$.metadata.organization").String().IsEqual("acme")
+
+		// the name of a project is unique across all the organizations
+		expect.POST(fmt.Sprintf("%s/%s", shared.APIV1Prefix, shared.PathProject)).
+			WithJSON(e2eframework.NewProject("perses")).
+			Expect().
+			Status(http.StatusConflict)
+
+		// the organization cannot be deleted while it contains a project
+		expect.DELETE(fmt.Sprintf("%s/%s/%s", shared.APIV1Prefix, shared.PathOrganization, "acme")).
+			Expect().
+			Status(http.StatusConflict)
+		return []api.Entity{project, organization}
+	})
+}
+
+func TestCreateProjectInUnknownOrganization(t *testing.T) {
+	e2eframework.WithServer(t, func(expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
+		expect.POST(fmt.Sprintf("%s/%s", shared.APIV1Prefix, shared.PathProject)).
+			WithJSON(e2eframework.NewProjectInOrganization("unknown", "perses")).
+			Expect().
+			Status(http.StatusBadRequest)
+		return []api.Entity{}
+	})
+}
+
+func TestOrganizationDatasource(t *testing.T) {
+	e2eframework.WithServer(t, func(expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
+		organization := e2eframework.NewOrganization("acme")
+		otherOrganization := e2eframework.NewOrganization("umbrella")
+		e2eframework.CreateAndWaitUntilEntitiesExist(t, manager, organization, otherOrganization)
+		datasource := e2eframework.NewOrganizationDatasource(t, "acme", "prometheus")
+
+		expect.POST(fmt.Sprintf("%s/%s/%s/%s", shared.APIV1Prefix, shared.PathOrganization, "acme", shared.PathDatasource)).
+			WithJSON(datasource).
+			Expect().
+			Status(http.StatusOK)
+
+		expect.GET(fmt.Sprintf("%s/%s/%s/%s/%s", shared.APIV1Prefix, shared.PathOrganization, "acme", shared.PathDatasource, "prometheus")).
+			Expect().
+			Status(http.StatusOK)
+
+		// the datasource is not visible from the other organizations
+		expect.GET(fmt.Sprintf("%s/%s/%s/%s", shared.APIV1Prefix, shared.PathOrganization, "umbrella", shared.PathDatasource)).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Array().IsEmpty()
+
+		// deleting the organization deletes its datasources
+		expect.DELETE(fmt.Sprintf("%s/%s/%s", shared.APIV1Prefix, shared.PathOrganization, "acme")).
+			Expect().
+			Status(http.StatusNoContent)
+		_, err := manager.GetOrganizationDatasource().Get("acme", "prometheus")
+		assert.Error(t, err)
+		return []api.Entity{otherOrganization}
+	})
+}
+
+func TestReadonlyOrganization(t *testing.T) {
+	e2eframework.WithServer(t, func(expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
+		organization := e2eframework.NewOrganization("acme")
+		organization.Spec.Settings.Readonly = true
+		project := e2eframework.NewProjectInOrganization("acme", "perses")
+		e2eframework.CreateAndWaitUntilEntitiesExist(t, manager, organization, project)
+
+		expect.POST(fmt.Sprintf("%s/%s/%s/%s", shared.APIV1Prefix, shared.PathProject, "perses", shared.PathDashboard)).
+			WithJSON(e2eframework.NewDashboard(t, "perses", "Demo")).
+			Expect().
+			Status(http.StatusForbidden)
+
+		expect.POST(fmt.Sprintf("%s/%s", shared.APIV1Prefix, shared.PathDashboard)).
+			WithJSON(e2eframework.NewDashboard(t, "perses", "Demo")).
+			Expect().
+			Status(http.StatusForbidden)
+
+		expect.DELETE(fmt.Sprintf("%s/%s/%s", shared.APIV1Prefix, shared.PathProject, "perses")).
+			Expect().
+			Status(http.StatusForbidden)
+
+		// the read requests are still allowed
+		expect.GET(fmt.Sprintf("%s/%s/%s/%s", shared.APIV1Prefix, shared.PathProject, "perses", shared.PathDashboard)).
+			Expect().
+			Status(http.StatusOK)
+		return []api.Entity{project, organization}
+	})
+}
+
+func TestMigrateProjects(t *testing.T) {
+	e2eframework.WithServer(t, func(expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
+		// a project created before the organizations exist
+		legacyProject := e2eframework.NewProjectInOrganization("", "perses")
+		e2eframework.CreateAndWaitUntilEntitiesExist(t, manager, legacyProject)
+
+		service := organizationImpl.NewService(manager.GetOrganization(), manager.GetProject(), manager.GetOrganizationDatasource(), manager.GetOrganizationVariable())
+		assert.NoError(t, service.MigrateProjects())
+
+		project, err := manager.GetProject().Get(v1.DefaultOrganization, "perses")
+		assert.NoError(t, err)
+		_, err = manager.GetProject().Get("", "perses")
+		assert.Error(t, err)
+
+		expect.GET(fmt.Sprintf("%s/%s/%s/%s", shared.APIV1Prefix, shared.PathOrganization, v1.DefaultOrganization, shared.PathProject)).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Array().Length().IsEqual(1)
+		return []api.Entity{project}
+	})
+}
+
+func withOrganizationAuthorization(conf *config.Config) {
+	withAuthorization(conf)
+	conf.Authorization = config.Authorization{
+		Groups: []config.GroupPermissions{
+			{Group: "acme-admins", Permissions: []v1.Permission{{Organization: "acme", Role: v1.RoleAdmin}}},
+			{Group: "perses-viewers", Permissions: []v1.Permission{{Project: "perses", Role: v1.RoleViewer}}},
+			{Group: "other-viewers", Permissions: []v1.Permission{{Project: "other", Role: v1.RoleViewer}}},
+		},
+	}
+}
+
+func TestOrganizationPermissions(t *testing.T) {
+	e2eframework.WithServerConfig(t, withOrganizationAuthorization, func(expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
+		organization := e2eframework.NewOrganization("acme")
+		project := e2eframework.NewProjectInOrganization("acme", "perses")
+		otherProject := e2eframework.NewProject("other")
+		globalDatasource := e2eframework.NewGlobalDatasource(t, "prometheus")
+		e2eframework.CreateAndWaitUntilEntitiesExist(t, manager, organization, project, otherProject, globalDatasource)
+		datasourcePath := fmt.Sprintf("%s/%s/%s/%s", shared.APIV1Prefix, shared.PathOrganization, "acme", shared.PathDatasource)
+
+		// the datasources of the organization are readable by the users having a permission on one of its projects
+		asUser(expect.GET(datasourcePath), "perses-viewers").
+			Expect().
+			Status(http.StatusOK)
+		asUser(expect.GET(datasourcePath), "other-viewers").
+			Expect().
+			Status(http.StatusForbidden)
+		asUser(expect.POST("/proxy/organizations/acme/datasources/prometheus/api/v1/query"), "other-viewers").
+			Expect().
+			Status(http.StatusForbidden)
+
+		// only the admins of the organization can modify its datasources
+		asUser(expect.POST(datasourcePath), "perses-viewers").
+			WithJSON(e2eframework.NewOrganizationDatasource(t, "acme", "prometheus")).
+			Expect().
+			Status(http.StatusForbidden)
+		organizationDatasource := e2eframework.NewOrganizationDatasource(t, "acme", "prometheus")
+		asUser(expect.POST(datasourcePath), "acme-admins").
+			WithJSON(organizationDatasource).
+			Expect().
+			Status(http.StatusOK)
+
+		// the permission on the organization applies to its projects
+		dashboard := e2eframework.NewDashboard(t, "perses", "demo")
+		asUser(expect.POST(fmt.Sprintf("%s/%s/%s/%s", shared.APIV1Prefix, shared.PathProject, "perses", shared.PathDashboard)), "acme-admins").
+			WithJSON(dashboard).
+			Expect().
+			Status(http.StatusOK)
+		asUser(expect.POST(fmt.Sprintf("%s/%s/%s/%s", shared.APIV1Prefix, shared.PathProject, "other", shared.PathDashboard)), "acme-admins").
+			WithJSON(e2eframework.NewDashboard(t, "other", "demo")).
+			Expect().
+			Status(http.StatusForbidden)
+
+		// the global datasources are only available to the projects of the default organization
+		asUser(expect.GET(fmt.Sprintf("%s/%s", shared.APIV1Prefix, shared.PathGlobalDatasource)), "other-viewers").
+			Expect().
+			Status(http.StatusOK)
+		asUser(expect.GET(fmt.Sprintf("%s/%s", shared.APIV1Prefix, shared.PathGlobalDatasource)), "perses-viewers").
+			Expect().
+			Status(http.StatusForbidden)
+		asUser(expect.GET(fmt.Sprintf("%s/%s/%s/%s", shared.APIV1Prefix, shared.PathProject, "perses", shared.PathGlobalDatasource)), "perses-viewers").
+			Expect().
+			Status(http.StatusOK).
+			JSON().Array().IsEmpty()
+		asUser(expect.GET("/proxy/projects/perses/globaldatasources/prometheus/api/v1/query"), "perses-viewers").
+			Expect().
+			Status(http.StatusNotFound)
+		return []api.Entity{dashboard, organizationDatasource, project, otherProject, globalDatasource, organization}
+	})
+}
+
+func TestProjectResourcesUnderOrganization(t *testing.T) {
+	e2eframework.WithServer(t, func(expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
+		organization := e2eframework.NewOrganization("acme")
+		project := e2eframework.NewProjectInOrganization("acme", "perses")
+		e2eframework.CreateAndWaitUntilEntitiesExist(t, manager, organization, project)
+		dashboard := e2eframework.NewDashboard(t, "perses", "demo")
+		organizationPath := fmt.Sprintf("%s/%s/%s/%s/%s", shared.APIV1Prefix, shared.PathOrganization, "acme", shared.PathProject, "perses")
+
+		expect.POST(fmt.Sprintf("%s/%s", organizationPath, shared.PathDashboard)).
+			WithJSON(dashboard).
+			Expect().
+			Status(http.StatusOK)
+		expect.GET(fmt.Sprintf("%s/%s/%s", organizationPath, shared.PathDashboard, "demo")).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object().Path("$.metadata.project").String().IsEqual("perses")
+
+		// the resources of the project are still available without the organization
+		expect.GET(fmt.Sprintf("%s/%s/%s/%s/%s", shared.APIV1Prefix, shared.PathProject, "perses", shared.PathDashboard, "demo")).
+			Expect().
+			Status(http.StatusOK)
+
+		// the project doesn't belong to the organization default
+		expect.GET(fmt.Sprintf("%s/%s/%s/%s/%s/%s", shared.APIV1Prefix, shared.PathOrganization, v1.DefaultOrganization, shared.PathProject, "perses", shared.PathDashboard)).
+			Expect().
+			Status(http.StatusNotFound)
+		expect.POST(fmt.Sprintf("/proxy/%s/%s/%s/perses/datasources/prometheus/api/v1/query", shared.PathOrganization, v1.DefaultOrganization, shared.PathProject)).
+			Expect().
+			Status(http.StatusNotFound)
+		return []api.Entity{dashboard, project, organization}
+	})
+}
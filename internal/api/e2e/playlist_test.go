@@ -0,0 +1,83 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build integration
+
+package e2e
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gavv/httpexpect/v2"
+	e2eframework "github.com/perses/perses/internal/api/e2e/framework"
+	"github.com/perses/perses/internal/api/shared"
+	"github.com/perses/perses/internal/api/shared/dependency"
+	"github.com/perses/perses/pkg/model/api"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/prometheus/common/model"
+)
+
+func TestMainScenarioPlaylist(t *testing.T) {
+	e2eframework.MainTestScenarioWithProject(t, shared.PathPlaylist, func(projectName string, name string) (api.Entity, api.Entity) {
+		return e2eframework.NewProject(projectName), e2eframework.NewPlaylist(projectName, name)
+	})
+}
+
+func TestPlaylistWithUnknownDashboard(t *testing.T) {
+	e2eframework.WithServer(t, func(expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
+		project := e2eframework.NewProject("perses")
+		e2eframework.CreateAndWaitUntilEntitiesExist(t, manager, project)
+
+		entity := e2eframework.NewPlaylist("perses", "noc")
+		entity.Spec.Items = []v1.PlaylistItem{{Dashboard: "unknown"}}
+		expect.POST(fmt.Sprintf("%s/%s/%s/%s", shared.APIV1Prefix, shared.PathProject, "perses", shared.PathPlaylist)).
+			WithJSON(entity).
+			Expect().
+			Status(http.StatusBadRequest)
+		return []api.Entity{project}
+	})
+}
+
+func TestResolvePlaylist(t *testing.T) {
+	e2eframework.WithServer(t, func(expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
+		project := e2eframework.NewProject("perses")
+		incident := e2eframework.NewDashboard(t, "perses", "incident")
+		nodes := e2eframework.NewDashboard(t, "perses", "nodes")
+		nodes.Spec.Tags = []string{"noc"}
+		network := e2eframework.NewDashboard(t, "perses", "network")
+		network.Spec.Tags = []string{"noc"}
+		network.Metadata.Labels = map[string]string{"team": "network"}
+		entity := e2eframework.NewPlaylist("perses", "noc")
+		entity.Spec.Items = []v1.PlaylistItem{
+			{Dashboard: "incident", Interval: model.Duration(30 * time.Second), Variables: map[string]string{"env": "production"}},
+			{Tag: "noc"},
+			{Labels: map[string]string{"team": "network"}},
+		}
+		e2eframework.CreateAndWaitUntilEntitiesExist(t, manager, project, incident, nodes, network, entity)
+
+		result := expect.GET(fmt.Sprintf("%s/%s/%s/%s/%s/dashboards", shared.APIV1Prefix, shared.PathProject, "perses", shared.PathPlaylist, "noc")).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Array()
+		result.Length().IsEqual(4)
+		result.Element(0).Object().ValueEqual("dashboard", "incident").ValueEqual("interval", "30s").
+			Value("variables").Object().ValueEqual("env", "production")
+		result.Element(1).Object().ValueEqual("dashboard", "network").ValueEqual("interval", "1m")
+		result.Element(2).Object().ValueEqual("dashboard", "nodes")
+		result.Element(3).Object().ValueEqual("dashboard", "network")
+		return []api.Entity{project, incident, nodes, network, entity}
+	})
+}
@@ -0,0 +1,114 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build integration
+
+package e2e
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/gavv/httpexpect/v2"
+	e2eframework "github.com/perses/perses/internal/api/e2e/framework"
+	"github.com/perses/perses/internal/api/shared"
+	"github.com/perses/perses/internal/api/shared/dependency"
+	"github.com/perses/perses/pkg/model/api"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+func TestRenameDashboard(t *testing.T) {
+	e2eframework.WithServer(t, func(expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
+		project := e2eframework.NewProject("perses")
+		dashboard := e2eframework.NewDashboard(t, "perses", "cpu")
+		homeConfig := e2eframework.NewHomeConfig("perses", "default", v1.DashboardSelector{Project: "perses", Dashboard: "cpu"})
+		e2eframework.CreateAndWaitUntilEntitiesExist(t, manager, project, dashboard, homeConfig)
+		dashboardPath := fmt.Sprintf("%s/%s/%s/%s", shared.APIV1Prefix, shared.PathProject, "perses", shared.PathDashboard)
+
+		expect.POST(fmt.Sprintf("%s/%s/rename", dashboardPath, "cpu")).
+			WithJSON(&v1.Rename{Name: "node", KeepAlias: true}).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Path("$.metadata.name").
+			IsEqual("node")
+
+		expect.GET(fmt.Sprintf("%s/%s", dashboardPath, "node")).
+			Expect().
+			Status(http.StatusOK)
+		// the home config is now pointing to the new name
+		expect.GET(fmt.Sprintf("%s/%s/%s/%s/%s", shared.APIV1Prefix, shared.PathProject, "perses", shared.PathHomeConfig, "default")).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Path("$.spec.important_dashboards[0].dashboard").
+			IsEqual("node")
+		// and the old name is redirected to the new one
+		expect.GET(fmt.Sprintf("%s/%s", dashboardPath, "cpu")).
+			WithRedirectPolicy(httpexpect.DontFollowRedirects).
+			Expect().
+			Status(http.StatusMovedPermanently).
+			Header("Location").
+			IsEqual(fmt.Sprintf("%s/%s", dashboardPath, "node"))
+
+		renamedDashboard := e2eframework.NewDashboard(t, "perses", "node")
+		alias, err := manager.GetDashboardAlias().Get("perses", "cpu")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return []api.Entity{project, renamedDashboard, homeConfig, alias}
+	})
+}
+
+func TestRenameDashboardWithExistingName(t *testing.T) {
+	e2eframework.WithServer(t, func(expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
+		project := e2eframework.NewProject("perses")
+		cpuDashboard := e2eframework.NewDashboard(t, "perses", "cpu")
+		nodeDashboard := e2eframework.NewDashboard(t, "perses", "node")
+		e2eframework.CreateAndWaitUntilEntitiesExist(t, manager, project, cpuDashboard, nodeDashboard)
+
+		expect.POST(fmt.Sprintf("%s/%s/%s/%s/%s/rename", shared.APIV1Prefix, shared.PathProject, "perses", shared.PathDashboard, "cpu")).
+			WithJSON(&v1.Rename{Name: "node"}).
+			Expect().
+			Status(http.StatusConflict)
+
+		return []api.Entity{project, cpuDashboard, nodeDashboard}
+	})
+}
+
+func TestRenameDatasource(t *testing.T) {
+	e2eframework.WithServer(t, func(expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
+		project := e2eframework.NewProject("perses")
+		datasource := e2eframework.NewDatasource(t, "perses", "prometheus")
+		dashboard := e2eframework.NewDashboard(t, "perses", "cpu")
+		query := dashboard.Spec.Panels["basicEx"].Spec.Queries[0].Spec.Plugin.Spec.(map[string]interface{})
+		query["datasource"] = map[string]interface{}{"kind": "PrometheusDatasource", "name": "prometheus"}
+		e2eframework.CreateAndWaitUntilEntitiesExist(t, manager, project, datasource, dashboard)
+
+		expect.POST(fmt.Sprintf("%s/%s/%s/%s/%s/rename", shared.APIV1Prefix, shared.PathProject, "perses", shared.PathDatasource, "prometheus")).
+			WithJSON(&v1.Rename{Name: "thanos"}).
+			Expect().
+			Status(http.StatusOK)
+
+		expect.GET(fmt.Sprintf("%s/%s/%s/%s/%s", shared.APIV1Prefix, shared.PathProject, "perses", shared.PathDashboard, "cpu")).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Path("$.spec.panels.basicEx.spec.queries[0].spec.plugin.spec.datasource.name").
+			IsEqual("thanos")
+
+		renamedDatasource := e2eframework.NewDatasource(t, "perses", "thanos")
+		return []api.Entity{project, renamedDatasource, dashboard}
+	})
+}
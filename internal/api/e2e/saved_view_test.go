@@ -0,0 +1,156 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build integration
+
+package e2e
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/gavv/httpexpect/v2"
+	e2eframework "github.com/perses/perses/internal/api/e2e/framework"
+	"github.com/perses/perses/internal/api/shared"
+	"github.com/perses/perses/internal/api/shared/dependency"
+	"github.com/perses/perses/pkg/model/api"
+	"github.com/perses/perses/pkg/model/api/v1/dashboard"
+	"github.com/perses/perses/pkg/model/api/v1/variable"
+)
+
+func TestCreateSavedView(t *testing.T) {
+	e2eframework.WithServer(t, func(expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
+		project := e2eframework.NewProject("perses")
+		incident := e2eframework.NewDashboard(t, "perses", "incident")
+		e2eframework.CreateAndWaitUntilEntitiesExist(t, manager, project, incident)
+		path := fmt.Sprintf("%s/%s/%s/%s", shared.APIV1Prefix, shared.PathProject, "perses", shared.PathSavedView)
+
+		unknownDashboard := e2eframework.NewSavedView("perses", "unknown", "unknown")
+		expect.POST(path).
+			WithJSON(unknownDashboard).
+			Expect().
+			Status(http.StatusBadRequest)
+
+		wrongValue := e2eframework.NewSavedView("perses", "wrong", "incident")
+		wrongValue.Spec.Variables["interval"] = &variable.DefaultValue{SingleValue: "10m"}
+		expect.POST(path).
+			WithJSON(wrongValue).
+			Expect().
+			Status(http.StatusBadRequest)
+
+		entity := e2eframework.NewSavedView("perses", "last15m", "incident")
+		expect.POST(path).
+			WithJSON(entity).
+			Expect().
+			Status(http.StatusOK)
+		expect.GET(fmt.Sprintf("%s/%s", path, "last15m")).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object().Value("status").Object().ValueEqual("broken", false)
+		return []api.Entity{project, incident, entity}
+	})
+}
+
+func TestSavedViewBrokenByDashboardUpdate(t *testing.T) {
+	e2eframework.WithServer(t, func(expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
+		project := e2eframework.NewProject("perses")
+		incident := e2eframework.NewDashboard(t, "perses", "incident")
+		entity := e2eframework.NewSavedView("perses", "last15m", "incident")
+		e2eframework.CreateAndWaitUntilEntitiesExist(t, manager, project, incident, entity)
+
+		// remove the variable used by the view
+		var variables []dashboard.Variable
+		for _, v := range incident.Spec.Variables {
+			if v.Spec.GetName() != "interval" {
+				variables = append(variables, v)
+			}
+		}
+		incident.Spec.Variables = variables
+		if err := manager.GetDashboard().Update(incident); err != nil {
+			t.Fatal(err)
+		}
+
+		path := fmt.Sprintf("%s/%s/%s/%s", shared.APIV1Prefix, shared.PathProject, "perses", shared.PathSavedView)
+		result := expect.GET(path).
+			WithQuery("dashboard", "incident").
+			Expect().
+			Status(http.StatusOK).
+			JSON().Array()
+		result.Length().IsEqual(1)
+		status := result.Element(0).Object().Value("status").Object()
+		status.ValueEqual("broken", true)
+		status.Value("errors").Array().Contains(`variable "interval" doesn't exist in the dashboard`)
+
+		expect.GET(fmt.Sprintf("%s/%s/dashboard", path, "last15m")).
+			Expect().
+			Status(http.StatusBadRequest)
+		return []api.Entity{project, incident, entity}
+	})
+}
+
+func TestApplySavedView(t *testing.T) {
+	e2eframework.WithServer(t, func(expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
+		project := e2eframework.NewProject("perses")
+		incident := e2eframework.NewDashboard(t, "perses", "incident")
+		entity := e2eframework.NewSavedView("perses", "last15m", "incident")
+		e2eframework.CreateAndWaitUntilEntitiesExist(t, manager, project, incident, entity)
+
+		result := expect.GET(fmt.Sprintf("%s/%s/%s/%s/%s/dashboard", shared.APIV1Prefix, shared.PathProject, "perses", shared.PathSavedView, "last15m")).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object()
+		result.Value("time_range").Object().ValueEqual("duration", "15m")
+		spec := result.Value("dashboard").Object().Value("spec").Object()
+		spec.ValueEqual("duration", "15m")
+		for _, v := range spec.Value("variables").Array().Iter() {
+			variableSpec := v.Object().Value("spec").Object()
+			if variableSpec.Value("name").String().Raw() == "interval" {
+				variableSpec.ValueEqual("default_value", "5m")
+			}
+		}
+		return []api.Entity{project, incident, entity}
+	})
+}
+
+func TestListSavedViewsOfSeveralProjects(t *testing.T) {
+	e2eframework.WithServer(t, func(expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
+		persesProject := e2eframework.NewProject("perses")
+		persesIncident := e2eframework.NewDashboard(t, "perses", "incident")
+		persesView := e2eframework.NewSavedView("perses", "last15m", "incident")
+		otherProject := e2eframework.NewProject("other")
+		// the dashboard has the same name in the other project, but not the variable used by the view
+		otherIncident := e2eframework.NewDashboard(t, "other", "incident")
+		var variables []dashboard.Variable
+		for _, v := range otherIncident.Spec.Variables {
+			if v.Spec.GetName() != "interval" {
+				variables = append(variables, v)
+			}
+		}
+		otherIncident.Spec.Variables = variables
+		otherView := e2eframework.NewSavedView("other", "last15m", "incident")
+		e2eframework.CreateAndWaitUntilEntitiesExist(t, manager, persesProject, persesIncident, persesView, otherProject, otherIncident, otherView)
+
+		result := expect.GET(fmt.Sprintf("%s/%s", shared.APIV1Prefix, shared.PathSavedView)).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Array()
+		result.Length().IsEqual(2)
+		for _, v := range result.Iter() {
+			view := v.Object()
+			project := view.Value("metadata").Object().Value("project").String().Raw()
+			view.Value("status").Object().ValueEqual("broken", project == "other")
+		}
+		return []api.Entity{persesProject, persesIncident, persesView, otherProject, otherIncident, otherView}
+	})
+}
@@ -0,0 +1,239 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build integration
+
+package e2e
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gavv/httpexpect/v2"
+	e2eframework "github.com/perses/perses/internal/api/e2e/framework"
+	"github.com/perses/perses/internal/api/shared"
+	databaseModel "github.com/perses/perses/internal/api/shared/database/model"
+	"github.com/perses/perses/internal/api/shared/dependency"
+	"github.com/perses/perses/pkg/model/api"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShareToken(t *testing.T) {
+	e2eframework.WithServer(t, func(expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
+		project := e2eframework.NewProject("perses")
+		dashboard := e2eframework.NewDashboard(t, "perses", "incident")
+		e2eframework.CreateAndWaitUntilEntitiesExist(t, manager, project, dashboard)
+
+		dashboardPath := fmt.Sprintf("%s/%s/%s/%s/%s", shared.APIV1Prefix, shared.PathProject, "perses", shared.PathDashboard, "incident")
+		tokensPath := fmt.Sprintf("%s/%s/%s/%s", shared.APIV1Prefix, shared.PathProject, "perses", shared.PathShareToken)
+
+		expect.POST(fmt.Sprintf("%s/%s", dashboardPath, shared.PathShareToken)).
+			WithJSON(map[string]interface{}{}).
+			Expect().
+			Status(http.StatusBadRequest)
+
+		created := expect.POST(fmt.Sprintf("%s/%s", dashboardPath, shared.PathShareToken)).
+			WithJSON(map[string]interface{}{
+				"ttl":        "1d",
+				"variables":  map[string]string{"instance": "localhost:9090"},
+				"time_range": map[string]string{"duration": "6h"},
+			}).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object()
+		token := created.Value("token").String().Raw()
+		shareToken := created.Value("share_token").Object()
+		shareToken.Value("spec").Object().
+			ValueEqual("dashboard", "incident").
+			ValueEqual("rate_limit", 300).
+			NotContainsKey("secret_hash")
+		name := shareToken.Value("metadata").Object().Value("name").String().Raw()
+
+		expect.GET(tokensPath).
+			WithQuery("dashboard", "incident").
+			Expect().
+			Status(http.StatusOK).
+			JSON().Array().Length().IsEqual(1)
+
+		result := expect.GET("/api/public/dashboard").
+			WithHeader(shared.ShareTokenHeader, token).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object()
+		result.Value("dashboard").Object().Value("metadata").Object().ValueEqual("name", "incident")
+		result.Value("variables").Object().ValueEqual("instance", "localhost:9090")
+		result.Value("time_range").Object().ValueEqual("duration", "6h")
+
+		expect.GET("/api/public/dashboard").
+			WithHeader(shared.ShareTokenHeader, token+"x").
+			Expect().
+			Status(http.StatusUnauthorized)
+
+		// revoking the token
+		expect.DELETE(fmt.Sprintf("%s/%s", tokensPath, name)).
+			Expect().
+			Status(http.StatusNoContent)
+		expect.GET("/api/public/dashboard").
+			WithHeader(shared.ShareTokenHeader, token).
+			Expect().
+			Status(http.StatusUnauthorized)
+		return []api.Entity{project, dashboard}
+	})
+}
+
+func TestShareTokenRateLimit(t *testing.T) {
+	e2eframework.WithServer(t, func(expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
+		project := e2eframework.NewProject("perses")
+		dashboard := e2eframework.NewDashboard(t, "perses", "incident")
+		e2eframework.CreateAndWaitUntilEntitiesExist(t, manager, project, dashboard)
+
+		dashboardPath := fmt.Sprintf("%s/%s/%s/%s/%s", shared.APIV1Prefix, shared.PathProject, "perses", shared.PathDashboard, "incident")
+		created := expect.POST(fmt.Sprintf("%s/%s", dashboardPath, shared.PathShareToken)).
+			WithJSON(map[string]interface{}{"ttl": "1h", "rate_limit": 1}).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object()
+		token := created.Value("token").String().Raw()
+		name := created.Value("share_token").Object().Value("metadata").Object().Value("name").String().Raw()
+
+		expect.GET("/api/public/dashboard").
+			WithHeader(shared.ShareTokenHeader, token).
+			Expect().
+			Status(http.StatusOK)
+		expect.GET("/api/public/dashboard").
+			WithHeader(shared.ShareTokenHeader, token).
+			Expect().
+			Status(http.StatusTooManyRequests)
+
+		// deleting the dashboard removes its tokens
+		expect.DELETE(dashboardPath).
+			Expect().
+			Status(http.StatusNoContent)
+		_, err := manager.GetShareToken().Get("perses", name)
+		assert.True(t, databaseModel.IsKeyNotFound(err))
+		return []api.Entity{project}
+	})
+}
+
+// newDefaultDatasourceSpec returns the spec of a default datasource sending the requests to the URL.
+func newDefaultDatasourceSpec(spec v1.DatasourceSpec, url string) v1.DatasourceSpec {
+	spec.Default = true
+	proxy := spec.Plugin.Spec.(map[string]interface{})["proxy"].(map[string]interface{})
+	proxy["spec"].(map[string]interface{})["url"] = url
+	return spec
+}
+
+func TestShareTokenProxy(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[]}}`))
+	}))
+	defer backend.Close()
+	e2eframework.WithServerConfig(t, withAuthorization, func(expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
+		project := e2eframework.NewProject("perses")
+		dashboard := e2eframework.NewDashboard(t, "perses", "incident")
+		datasource := e2eframework.NewDatasource(t, "perses", "prometheus")
+		datasource.Spec = newDefaultDatasourceSpec(datasource.Spec, backend.URL)
+		globalDatasource := e2eframework.NewGlobalDatasource(t, "thanos")
+		globalDatasource.Spec = newDefaultDatasourceSpec(globalDatasource.Spec, backend.URL)
+		otherGlobalDatasource := e2eframework.NewGlobalDatasource(t, "other")
+		e2eframework.CreateAndWaitUntilEntitiesExist(t, manager, project, dashboard, datasource, globalDatasource, otherGlobalDatasource)
+
+		// the dashboard is using the variables job and instance
+		created := asUser(expect.POST(fmt.Sprintf("%s/%s/%s/%s/%s/%s", shared.APIV1Prefix, shared.PathProject, "perses", shared.PathDashboard, "incident", shared.PathShareToken)), "editors").
+			WithJSON(map[string]interface{}{
+				"ttl":       "1h",
+				"variables": map[string]string{"job": "node"},
+			}).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Object()
+		token := created.Value("token").String().Raw()
+		shareToken, err := manager.GetShareToken().Get("perses", created.Value("share_token").Object().Value("metadata").Object().Value("name").String().Raw())
+		if err != nil {
+			t.Fatal(err)
+		}
+		now := strconv.FormatInt(time.Now().Unix(), 10)
+		query := func(path string, form map[string]string) *httpexpect.Request {
+			request := expect.POST(path).WithHeader(shared.ShareTokenHeader, token)
+			for key, value := range form {
+				request = request.WithFormField(key, value)
+			}
+			return request
+		}
+		queryPath := "/proxy/projects/perses/datasources/prometheus/api/v1/query"
+
+		// the queries of the dashboard, with the variables fixed by the token
+		query(queryPath, map[string]string{"query": "up", "time": now}).
+			Expect().
+			Status(http.StatusOK)
+		query(queryPath, map[string]string{"query": "node_memory_Buffers_bytes{job='node',instance=~'localhost:9100'}"}).
+			Expect().
+			Status(http.StatusOK)
+		query(queryPath, map[string]string{"query": "node_memory_Buffers_bytes{job='other',instance=~'localhost:9100'}"}).
+			Expect().
+			Status(http.StatusForbidden)
+		query(queryPath, map[string]string{"query": "node_memory_Buffers_bytes{job='node',instance=~'.*'} or secret"}).
+			Expect().
+			Status(http.StatusForbidden)
+		query(queryPath, map[string]string{"query": "secret"}).
+			Expect().
+			Status(http.StatusForbidden)
+		query("/proxy/projects/perses/datasources/prometheus/api/v1/series", map[string]string{"match[]": `up{job=~"node"}`}).
+			Expect().
+			Status(http.StatusOK)
+		expect.GET("/proxy/projects/perses/datasources/prometheus/api/v1/metadata").
+			WithHeader(shared.ShareTokenHeader, token).
+			Expect().
+			Status(http.StatusForbidden)
+
+		// the time range of the dashboard is the last 5 minutes
+		query("/proxy/projects/perses/datasources/prometheus/api/v1/query_range", map[string]string{
+			"query": "up",
+			"start": strconv.FormatInt(time.Now().Add(-5*time.Minute).Unix(), 10),
+			"end":   now,
+			"step":  "15",
+		}).
+			Expect().
+			Status(http.StatusOK)
+		query("/proxy/projects/perses/datasources/prometheus/api/v1/query_range", map[string]string{
+			"query": "up",
+			"start": strconv.FormatInt(time.Now().Add(-24*time.Hour).Unix(), 10),
+			"end":   now,
+			"step":  "15",
+		}).
+			Expect().
+			Status(http.StatusForbidden)
+
+		// the default global datasource is used by the dashboard, but not the other ones
+		query("/proxy/projects/perses/globaldatasources/thanos/api/v1/query", map[string]string{"query": "up"}).
+			Expect().
+			Status(http.StatusOK)
+		query("/proxy/projects/perses/globaldatasources/other/api/v1/query", map[string]string{"query": "up"}).
+			Expect().
+			Status(http.StatusForbidden)
+		// the global datasources are only queried with the overrides of the project
+		query("/proxy/globaldatasources/thanos/api/v1/query", map[string]string{"query": "up"}).
+			Expect().
+			Status(http.StatusForbidden)
+		query(fmt.Sprintf("/proxy/organizations/%s/datasources/prometheus/api/v1/query", "acme"), map[string]string{"query": "up"}).
+			Expect().
+			Status(http.StatusForbidden)
+		return []api.Entity{project, dashboard, datasource, globalDatasource, otherGlobalDatasource, shareToken}
+	})
+}
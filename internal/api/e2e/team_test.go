@@ -0,0 +1,112 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build integration
+
+package e2e
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/gavv/httpexpect/v2"
+	e2eframework "github.com/perses/perses/internal/api/e2e/framework"
+	"github.com/perses/perses/internal/api/shared"
+	"github.com/perses/perses/internal/api/shared/dependency"
+	"github.com/perses/perses/pkg/model/api"
+)
+
+func TestMainScenarioTeam(t *testing.T) {
+	e2eframework.MainTestScenario(t, shared.PathTeam, func(name string) api.Entity {
+		return e2eframework.NewTeam(name)
+	})
+}
+
+func TestListTeamsByMember(t *testing.T) {
+	e2eframework.WithServer(t, func(expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
+		team := e2eframework.NewTeam("observability")
+		e2eframework.CreateAndWaitUntilEntityExists(t, manager, team)
+
+		expect.GET(fmt.Sprintf("%s/%s", shared.APIV1Prefix, shared.PathTeam)).
+			WithQuery("member", "alice").
+			Expect().
+			Status(http.StatusOK).
+			JSON().Array().Length().Equal(1)
+
+		expect.GET(fmt.Sprintf("%s/%s", shared.APIV1Prefix, shared.PathTeam)).
+			WithQuery("member", "bob").
+			Expect().
+			Status(http.StatusOK).
+			JSON().Array().Length().Equal(0)
+		return []api.Entity{team}
+	})
+}
+
+func TestListFilteredByOwningTeam(t *testing.T) {
+	e2eframework.WithServer(t, func(expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
+		owned := e2eframework.NewProject("owned")
+		owned.Metadata.Owners = []string{"observability"}
+		notOwned := e2eframework.NewProject("notowned")
+		e2eframework.CreateAndWaitUntilEntitiesExist(t, manager, owned, notOwned)
+
+		result := expect.GET(fmt.Sprintf("%s/%s", shared.APIV1Prefix, shared.PathProject)).
+			WithQuery("team", "observability").
+			Expect().
+			Status(http.StatusOK).
+			JSON().Array()
+		result.Length().Equal(1)
+		result.First().Object().Value("metadata").Object().Value("name").Equal("owned")
+
+		expect.GET(fmt.Sprintf("%s/%s", shared.APIV1Prefix, shared.PathProject)).
+			Expect().
+			Status(http.StatusOK).
+			JSON().Array().Length().Equal(2)
+		return []api.Entity{owned, notOwned}
+	})
+}
+
+func TestCreateResourceWithInvalidOwner(t *testing.T) {
+	e2eframework.WithServer(t, func(expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
+		entity := e2eframework.NewProject("perses")
+		entity.Metadata.Owners = []string{"not a valid name"}
+		expect.POST(fmt.Sprintf("%s/%s", shared.APIV1Prefix, shared.PathProject)).
+			WithJSON(entity).
+			Expect().
+			Status(http.StatusBadRequest)
+		return []api.Entity{}
+	})
+}
+
+func TestCreateResourceWithUnknownOwner(t *testing.T) {
+	e2eframework.WithServer(t, func(expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
+		team := e2eframework.NewTeam("observability")
+		e2eframework.CreateAndWaitUntilEntityExists(t, manager, team)
+
+		unknown := e2eframework.NewProject("unknown")
+		unknown.Metadata.Owners = []string{"observability", "sre"}
+		expect.POST(fmt.Sprintf("%s/%s", shared.APIV1Prefix, shared.PathProject)).
+			WithJSON(unknown).
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object().Value("message").String().Contains(`"sre"`)
+
+		owned := e2eframework.NewProject("perses")
+		owned.Metadata.Owners = []string{"observability"}
+		expect.POST(fmt.Sprintf("%s/%s", shared.APIV1Prefix, shared.PathProject)).
+			WithJSON(owned).
+			Expect().
+			Status(http.StatusOK)
+		return []api.Entity{team, owned}
+	})
+}
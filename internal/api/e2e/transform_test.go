@@ -0,0 +1,96 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build integration
+
+package e2e
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/gavv/httpexpect/v2"
+	e2eframework "github.com/perses/perses/internal/api/e2e/framework"
+	"github.com/perses/perses/internal/api/shared"
+	"github.com/perses/perses/internal/api/shared/dependency"
+	"github.com/perses/perses/pkg/model/api"
+	"github.com/perses/perses/pkg/model/api/transform"
+	"github.com/perses/perses/pkg/model/api/v1/common"
+)
+
+func TestApplyTransformations(t *testing.T) {
+	e2eframework.WithServer(t, func(expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
+		body := transform.Apply{
+			Transformations: []common.Plugin{
+				{
+					Kind: transform.KindBinaryOperation,
+					Spec: map[string]interface{}{"left": 0, "right": 1, "operator": "/", "name": "ratio"},
+				},
+			},
+			Data: transform.Data{
+				Results: []transform.Result{
+					{Series: []transform.Series{{Name: "errors", Values: []transform.Point{transform.NewPoint(1, 1), transform.NewPoint(2, 3)}}}},
+					{Series: []transform.Series{{Name: "requests", Values: []transform.Point{transform.NewPoint(1, 4), transform.NewPoint(2, 6)}}}},
+				},
+			},
+		}
+		expect.POST("/api/transformations/apply").
+			WithJSON(body).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Equal(json.RawMessage(`{"results":[{"series":[{"name":"ratio","values":[[1,0.25],[2,0.5]]}]}]}`))
+
+		body.Transformations[0].Kind = "Unknown"
+		expect.POST("/api/transformations/apply").
+			WithJSON(body).
+			Expect().
+			Status(http.StatusBadRequest)
+		return []api.Entity{}
+	})
+}
+
+func TestCreateDashboardWithTransformations(t *testing.T) {
+	e2eframework.WithServer(t, func(expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
+		project := e2eframework.NewProject("perses")
+		e2eframework.CreateAndWaitUntilEntityExists(t, manager, project)
+		path := fmt.Sprintf("%s/%s/%s/%s", shared.APIV1Prefix, shared.PathProject, "perses", shared.PathDashboard)
+
+		invalid := e2eframework.NewDashboard(t, "perses", "invalid")
+		invalid.Spec.Panels["basicEx"].Spec.Transformations = []common.Plugin{
+			{Kind: transform.KindBinaryOperation, Spec: map[string]interface{}{"left": 0, "right": 1, "operator": "%"}},
+		}
+		expect.POST(path).
+			WithJSON(invalid).
+			Expect().
+			Status(http.StatusBadRequest)
+
+		entity := e2eframework.NewDashboard(t, "perses", "transformed")
+		entity.Spec.Panels["basicEx"].Spec.Transformations = []common.Plugin{
+			{Kind: transform.KindMergeSeries, Spec: map[string]interface{}{}},
+			{Kind: transform.KindReduceToTable, Spec: map[string]interface{}{"calculations": []string{"Last", "Mean"}}},
+		}
+		expect.POST(path).
+			WithJSON(entity).
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Path("$.spec.panels.basicEx.spec.transformations").
+			Array().
+			Length().
+			Equal(2)
+		return []api.Entity{project, entity}
+	})
+}
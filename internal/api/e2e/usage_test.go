@@ -0,0 +1,59 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build integration
+
+package e2e
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/gavv/httpexpect/v2"
+	e2eframework "github.com/perses/perses/internal/api/e2e/framework"
+	"github.com/perses/perses/internal/api/shared"
+	"github.com/perses/perses/internal/api/shared/dependency"
+	"github.com/perses/perses/pkg/model/api"
+)
+
+func TestDashboardUsage(t *testing.T) {
+	e2eframework.WithServer(t, func(expect *httpexpect.Expect, manager dependency.PersistenceManager) []api.Entity {
+		project := e2eframework.NewProject("perses")
+		viewed := e2eframework.NewDashboard(t, "perses", "viewed")
+		unused := e2eframework.NewDashboard(t, "perses", "unused")
+		e2eframework.CreateAndWaitUntilEntitiesExist(t, manager, project, viewed, unused)
+
+		dashboardPath := fmt.Sprintf("%s/%s/%s/%s/%s", shared.APIV1Prefix, shared.PathProject, "perses", shared.PathDashboard, "viewed")
+		expect.GET(dashboardPath).
+			Expect().
+			Status(http.StatusOK)
+		expect.POST(fmt.Sprintf("%s/view", dashboardPath)).
+			Expect().
+			Status(http.StatusNoContent)
+		expect.POST(fmt.Sprintf("%s/%s/%s/%s/%s/view", shared.APIV1Prefix, shared.PathProject, "perses", shared.PathDashboard, "unknown")).
+			Expect().
+			Status(http.StatusNotFound)
+
+		result := expect.GET(fmt.Sprintf("%s/%s/%s", shared.APIV1Prefix, shared.PathUsage, shared.PathDashboard)).
+			WithQuery("project", "perses").
+			Expect().
+			Status(http.StatusOK).
+			JSON().
+			Array()
+		result.Length().IsEqual(2)
+		result.Element(0).Object().ValueEqual("dashboard", "unused").ValueEqual("views", 0).NotContainsKey("last_viewed_at")
+		result.Element(1).Object().ValueEqual("dashboard", "viewed").ValueEqual("views", 2).ContainsKey("last_viewed_at")
+		return []api.Entity{project, viewed, unused}
+	})
+}
@@ -0,0 +1,51 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alert
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/shared"
+	"github.com/perses/perses/internal/api/shared/alert"
+	modelAlert "github.com/perses/perses/pkg/model/api/alert"
+)
+
+// Endpoint is the struct that define all endpoint delivered by the path /alerts
+type Endpoint struct {
+}
+
+// New create an instance of the object Endpoint.
+// You should have at most one instance of this object as it is only used by the struct api in the method api.registerRoute
+func New() *Endpoint {
+	return &Endpoint{}
+}
+
+// RegisterRoutes is the method to use to register the routes prefixed by /api
+func (e *Endpoint) RegisterRoutes(g *echo.Group) {
+	g.POST("/alerts/generate", e.Generate)
+}
+
+// Generate is the endpoint that provides the Prometheus alert rules corresponding to the thresholds of the provided dashboard.
+func (e *Endpoint) Generate(ctx echo.Context) error {
+	body := &modelAlert.Generate{}
+	if err := ctx.Bind(body); err != nil {
+		return shared.HandleBadRequestError(err.Error())
+	}
+	result, err := alert.Generate(body)
+	if err != nil {
+		return shared.HandleBadRequestError(err.Error())
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,69 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analysis
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/shared"
+	"github.com/perses/perses/internal/api/shared/analysis"
+	modelAnalysis "github.com/perses/perses/pkg/model/api/analysis"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+// Endpoint is the struct that define all endpoint delivered by the path /analysis
+type Endpoint struct {
+	analysisService analysis.Analysis
+}
+
+// New create an instance of the object Endpoint.
+// You should have at most one instance of this object as it is only used by the struct api in the method api.registerRoute
+func New(analysisService analysis.Analysis) *Endpoint {
+	return &Endpoint{
+		analysisService: analysisService,
+	}
+}
+
+// RegisterRoutes is the method to use to register the routes prefixed by /api
+func (e *Endpoint) RegisterRoutes(g *echo.Group) {
+	g.GET("/analysis/queries", e.AnalyzeQueries)
+}
+
+// AnalyzeQueries is the endpoint that provides the Prometheus expressions used several times across the dashboards and the variables,
+// with the recording rules that could replace them.
+func (e *Endpoint) AnalyzeQueries(ctx echo.Context) error {
+	query := &modelAnalysis.Query{}
+	if err := ctx.Bind(query); err != nil {
+		return shared.HandleBadRequestError(err.Error())
+	}
+	// The path is not scoped to a project, so the permissions are checked here: the project requested must be
+	// readable, and without project only the readable projects are analyzed.
+	if len(query.Project) > 0 && !shared.HasProjectPermission(ctx, query.Project, v1.RoleViewer) {
+		return echo.NewHTTPError(http.StatusForbidden, fmt.Sprintf("the role %s on the project %q is required", v1.RoleViewer, query.Project))
+	}
+	filter := analysis.Filter{
+		IsProjectReadable: func(project string) bool {
+			return shared.HasProjectPermission(ctx, project, v1.RoleViewer)
+		},
+		// The global variables are only available to the projects of the default organization.
+		GlobalVariables: shared.HasOrganizationPermission(ctx, v1.DefaultOrganization, v1.RoleViewer),
+	}
+	result, err := e.analysisService.Analyze(query, filter)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
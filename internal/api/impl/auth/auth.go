@@ -0,0 +1,98 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authendpoint
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/shared"
+	"github.com/perses/perses/internal/api/shared/auth"
+	"github.com/perses/perses/internal/api/shared/auth/ldap"
+	"github.com/perses/perses/pkg/model/api"
+	"github.com/sirupsen/logrus"
+)
+
+// Endpoint is the struct that define all endpoint delivered by the path /auth
+type Endpoint struct {
+	ldap  ldap.Provider
+	token auth.Token
+}
+
+// New create an instance of the object Endpoint.
+// You should have at most one instance of this object as it is only used by the struct api in the method api.registerRoute
+func New(ldapProvider ldap.Provider, token auth.Token) *Endpoint {
+	return &Endpoint{
+		ldap:  ldapProvider,
+		token: token,
+	}
+}
+
+// RegisterRoutes is the method to use to register the routes prefixed by /api
+func (e *Endpoint) RegisterRoutes(g *echo.Group) {
+	if e.token == nil {
+		return
+	}
+	if e.ldap != nil {
+		g.POST("/auth/providers/ldap/login", e.LDAPLogin)
+	}
+	g.POST("/auth/logout", e.Logout)
+}
+
+// LDAPLogin checks the credentials against the LDAP directory and delivers an access token.
+// The token is also set in a cookie for the frontend.
+func (e *Endpoint) LDAPLogin(ctx echo.Context) error {
+	body := &api.Auth{}
+	if err := ctx.Bind(body); err != nil {
+		return shared.HandleBadRequestError(err.Error())
+	}
+	identity, err := e.ldap.Authenticate(body.Login, body.Password)
+	if err != nil {
+		if errors.Is(err, ldap.ErrInvalidCredentials) {
+			return echo.NewHTTPError(http.StatusUnauthorized, "invalid login or password")
+		}
+		logrus.WithError(err).Error("unable to authenticate the user with LDAP")
+		return echo.NewHTTPError(http.StatusBadGateway, "unable to reach the LDAP directory")
+	}
+	accessToken, expiresAt, err := e.token.Sign(identity)
+	if err != nil {
+		logrus.WithError(err).Error("unable to sign the access token")
+		return echo.NewHTTPError(http.StatusInternalServerError, "internal server error")
+	}
+	ctx.SetCookie(e.newCookie(ctx, accessToken, expiresAt))
+	return ctx.JSON(http.StatusOK, &api.AuthResponse{
+		AccessToken: accessToken,
+		ExpiresAt:   expiresAt,
+	})
+}
+
+// Logout removes the cookie containing the access token.
+func (e *Endpoint) Logout(ctx echo.Context) error {
+	ctx.SetCookie(e.newCookie(ctx, "", time.Unix(0, 0)))
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func (e *Endpoint) newCookie(ctx echo.Context, value string, expiresAt time.Time) *http.Cookie {
+	return &http.Cookie{
+		Name:     auth.CookieName,
+		Value:    value,
+		Path:     "/",
+		Expires:  expiresAt,
+		HttpOnly: true,
+		Secure:   ctx.Scheme() == "https",
+		SameSite: http.SameSiteLaxMode,
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rename
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/shared"
+	"github.com/perses/perses/internal/api/shared/rename"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+// Endpoint is the struct that define all endpoint delivered by the path <resource>/:name/rename
+type Endpoint struct {
+	renameService rename.Rename
+	readonly      bool
+}
+
+// New create an instance of the object Endpoint.
+// You should have at most one instance of this object as it is only used by the struct api in the method api.registerRoute
+func New(renameService rename.Rename, readonly bool) *Endpoint {
+	return &Endpoint{
+		renameService: renameService,
+		readonly:      readonly,
+	}
+}
+
+// RegisterRoutes is the method to use to register the routes prefixed by /api/v1
+func (e *Endpoint) RegisterRoutes(g *echo.Group) {
+	if e.readonly {
+		return
+	}
+	projectPath := fmt.Sprintf("/%s/:%s", shared.PathProject, shared.ParamProject)
+	g.POST(fmt.Sprintf("%s/%s/:%s/rename", projectPath, shared.PathDashboard, shared.ParamName), e.RenameDashboard)
+	g.POST(fmt.Sprintf("%s/%s/:%s/rename", projectPath, shared.PathDatasource, shared.ParamName), e.RenameDatasource)
+	g.POST(fmt.Sprintf("%s/%s/:%s/rename", projectPath, shared.PathVariable, shared.ParamName), e.RenameVariable)
+	g.POST(fmt.Sprintf("/%s/:%s/rename", shared.PathGlobalDatasource, shared.ParamName), e.RenameGlobalDatasource)
+	g.POST(fmt.Sprintf("/%s/:%s/rename", shared.PathGlobalVariable, shared.ParamName), e.RenameGlobalVariable)
+}
+
+func (e *Endpoint) RenameDashboard(ctx echo.Context) error {
+	return handle(ctx, func(request *v1.Rename) (interface{}, error) {
+		return e.renameService.Dashboard(shared.NewAdmitter(ctx), shared.GetProjectParameter(ctx), ctx.Param(shared.ParamName), request)
+	})
+}
+
+func (e *Endpoint) RenameDatasource(ctx echo.Context) error {
+	return handle(ctx, func(request *v1.Rename) (interface{}, error) {
+		return e.renameService.Datasource(shared.NewAdmitter(ctx), shared.GetProjectParameter(ctx), ctx.Param(shared.ParamName), request)
+	})
+}
+
+func (e *Endpoint) RenameVariable(ctx echo.Context) error {
+	return handle(ctx, func(request *v1.Rename) (interface{}, error) {
+		return e.renameService.Variable(shared.NewAdmitter(ctx), shared.GetProjectParameter(ctx), ctx.Param(shared.ParamName), request)
+	})
+}
+
+func (e *Endpoint) RenameGlobalDatasource(ctx echo.Context) error {
+	return handle(ctx, func(request *v1.Rename) (interface{}, error) {
+		return e.renameService.GlobalDatasource(shared.NewAdmitter(ctx), ctx.Param(shared.ParamName), request)
+	})
+}
+
+func (e *Endpoint) RenameGlobalVariable(ctx echo.Context) error {
+	return handle(ctx, func(request *v1.Rename) (interface{}, error) {
+		return e.renameService.GlobalVariable(shared.NewAdmitter(ctx), ctx.Param(shared.ParamName), request)
+	})
+}
+
+func handle(ctx echo.Context, apply func(request *v1.Rename) (interface{}, error)) error {
+	request := &v1.Rename{}
+	if err := ctx.Bind(request); err != nil {
+		return shared.HandleBadRequestError(err.Error())
+	}
+	result, err := apply(request)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
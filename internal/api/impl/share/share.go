@@ -0,0 +1,54 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shareendpoint
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/interface/v1/sharetoken"
+	"github.com/perses/perses/internal/api/shared"
+)
+
+// Endpoint is the struct that define all endpoint delivered by the path /public.
+// These endpoints are reached anonymously with a share token.
+type Endpoint struct {
+	service sharetoken.Service
+}
+
+// New create an instance of the object Endpoint.
+// You should have at most one instance of this object as it is only used by the struct api in the method api.registerRoute
+func New(service sharetoken.Service) *Endpoint {
+	return &Endpoint{
+		service: service,
+	}
+}
+
+// RegisterRoutes is the method to use to register the routes prefixed by /api
+func (e *Endpoint) RegisterRoutes(g *echo.Group) {
+	g.GET("/public/dashboard", e.GetDashboard)
+}
+
+// GetDashboard returns the dashboard shared with the token given in the header shared.ShareTokenHeader.
+func (e *Endpoint) GetDashboard(ctx echo.Context) error {
+	token := ctx.Request().Header.Get(shared.ShareTokenHeader)
+	if len(token) == 0 {
+		return sharetoken.ErrInvalidToken
+	}
+	result, err := e.service.GetDashboard(token)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
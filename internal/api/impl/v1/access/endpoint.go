@@ -0,0 +1,93 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package access
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/interface/v1/access"
+	"github.com/perses/perses/internal/api/shared"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+// Endpoint is the struct that define all endpoint used to manage the grants of the dashboards.
+type Endpoint struct {
+	service  access.Service
+	readonly bool
+}
+
+func NewEndpoint(service access.Service, readonly bool) *Endpoint {
+	return &Endpoint{
+		service:  service,
+		readonly: readonly,
+	}
+}
+
+// RegisterRoutes is the method to use to register the routes prefixed by /api/v1
+func (e *Endpoint) RegisterRoutes(g *echo.Group) {
+	group := g.Group(fmt.Sprintf("/%s/:%s/%s/:%s", shared.PathProject, shared.ParamProject, shared.PathDashboard, shared.ParamName))
+	group.GET("/access", e.List)
+	group.GET("/grants", e.Get)
+	if !e.readonly {
+		group.PUT("/grants", e.Set)
+		group.DELETE("/grants", e.Delete)
+	}
+}
+
+// List returns who has access to the dashboard.
+func (e *Endpoint) List(ctx echo.Context) error {
+	project, name := extractParameters(ctx)
+	result, err := e.service.List(project, name)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func (e *Endpoint) Get(ctx echo.Context) error {
+	project, name := extractParameters(ctx)
+	result, err := e.service.Get(project, name)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+// Set replaces the grants of the dashboard with the ones contained in the body.
+func (e *Endpoint) Set(ctx echo.Context) error {
+	spec := v1.DashboardAccessSpec{}
+	if err := ctx.Bind(&spec); err != nil {
+		return shared.HandleBadRequestError(err.Error())
+	}
+	project, name := extractParameters(ctx)
+	result, err := e.service.Set(project, name, spec)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func (e *Endpoint) Delete(ctx echo.Context) error {
+	project, name := extractParameters(ctx)
+	if err := e.service.Delete(project, name); err != nil {
+		return err
+	}
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func extractParameters(ctx echo.Context) (string, string) {
+	return shared.GetProjectParameter(ctx), ctx.Param(shared.ParamName)
+}
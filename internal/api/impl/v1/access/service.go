@@ -0,0 +1,189 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package access
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/perses/perses/internal/api/interface/v1/access"
+	"github.com/perses/perses/internal/api/interface/v1/dashboard"
+	"github.com/perses/perses/internal/api/interface/v1/datasource"
+	"github.com/perses/perses/internal/api/shared"
+	"github.com/perses/perses/internal/api/shared/auth"
+	databaseModel "github.com/perses/perses/internal/api/shared/database/model"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/sirupsen/logrus"
+)
+
+type service struct {
+	access.Service
+	dao           access.DAO
+	dashboardDAO  dashboard.DAO
+	datasourceDAO datasource.DAO
+	authorization auth.Authorization
+}
+
+func NewService(dao access.DAO, dashboardDAO dashboard.DAO, datasourceDAO datasource.DAO, authorization auth.Authorization) access.Service {
+	return &service{
+		dao:           dao,
+		dashboardDAO:  dashboardDAO,
+		datasourceDAO: datasourceDAO,
+		authorization: authorization,
+	}
+}
+
+func (s *service) Get(project string, dashboard string) (*v1.DashboardAccess, error) {
+	if _, err := s.dashboardDAO.Get(project, dashboard); err != nil {
+		return nil, err
+	}
+	return s.get(project, dashboard)
+}
+
+func (s *service) get(project string, dashboard string) (*v1.DashboardAccess, error) {
+	entity, err := s.dao.Get(project, dashboard)
+	if err == nil {
+		return entity, nil
+	}
+	if !databaseModel.IsKeyNotFound(err) {
+		return nil, err
+	}
+	return &v1.DashboardAccess{
+		Kind:     v1.KindDashboardAccess,
+		Metadata: *v1.NewProjectMetadata(project, dashboard),
+		Spec:     v1.DashboardAccessSpec{Grants: []v1.DashboardGrant{}},
+	}, nil
+}
+
+func (s *service) Set(project string, dashboard string, spec v1.DashboardAccessSpec) (*v1.DashboardAccess, error) {
+	entity, err := s.Get(project, dashboard)
+	if err != nil {
+		return nil, err
+	}
+	if entity.Metadata.CreatedAt.IsZero() {
+		entity.Metadata.CreateNow()
+	} else {
+		entity.Metadata.Update(entity.Metadata)
+	}
+	if spec.Grants == nil {
+		spec.Grants = []v1.DashboardGrant{}
+	}
+	entity.Spec = spec
+	if updateErr := s.dao.Update(entity); updateErr != nil {
+		logrus.WithError(updateErr).Errorf("unable to save the grants of the dashboard %q in the project %q", dashboard, project)
+		return nil, updateErr
+	}
+	return entity, nil
+}
+
+func (s *service) Delete(project string, dashboard string) error {
+	return s.dao.Delete(project, dashboard)
+}
+
+func (s *service) List(project string, dashboard string) ([]v1.DashboardAccessEntry, error) {
+	entity, err := s.Get(project, dashboard)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]v1.DashboardAccessEntry, 0)
+	groupRoles := s.authorization.GetGroupRoles(project)
+	groups := make([]string, 0, len(groupRoles))
+	for group := range groupRoles {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+	for _, group := range groups {
+		result = append(result, v1.DashboardAccessEntry{
+			Group:  group,
+			Role:   groupRoles[group],
+			Source: v1.AccessSourceProject,
+		})
+	}
+	for _, grant := range entity.Spec.Grants {
+		result = append(result, v1.DashboardAccessEntry{
+			User:   grant.User,
+			Group:  grant.Group,
+			Role:   grant.Role,
+			Source: v1.AccessSourceDashboard,
+		})
+	}
+	return result, nil
+}
+
+func (s *service) GetRole(identity *shared.Identity, project string, dashboard string) v1.Role {
+	if identity == nil {
+		return ""
+	}
+	entity, err := s.dao.Get(project, dashboard)
+	if err != nil {
+		if !databaseModel.IsKeyNotFound(err) {
+			logrus.WithError(err).Errorf("unable to get the grants of the dashboard %q in the project %q", dashboard, project)
+		}
+		return ""
+	}
+	return entity.Spec.GetRole(identity.Username, identity.Groups)
+}
+
+func (s *service) UsesDatasource(project string, dashboard string, datasource string) bool {
+	entity, err := s.dashboardDAO.Get(project, dashboard)
+	if err != nil {
+		return false
+	}
+	names, err := findDatasourceNames(entity.Spec)
+	if err != nil {
+		logrus.WithError(err).Errorf("unable to find the datasources used by the dashboard %q in the project %q", dashboard, project)
+		return false
+	}
+	if names[datasource] {
+		return true
+	}
+	// The queries and the variables without an explicit datasource are using the default datasources.
+	dts, err := s.datasourceDAO.Get(project, datasource)
+	return err == nil && dts.Spec.Default
+}
+
+// findDatasourceNames returns the name of every datasource explicitly selected ({"kind": ..., "name": ...}) in the panels and in the variables.
+// As the spec of the plugins is only known by the CUE schemas, the dashboard is walked generically.
+func findDatasourceNames(spec v1.DashboardSpec) (map[string]bool, error) {
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return nil, err
+	}
+	var raw interface{}
+	if unmarshalErr := json.Unmarshal(data, &raw); unmarshalErr != nil {
+		return nil, unmarshalErr
+	}
+	result := make(map[string]bool)
+	walk(raw, result)
+	return result, nil
+}
+
+func walk(node interface{}, result map[string]bool) {
+	switch value := node.(type) {
+	case map[string]interface{}:
+		for key, child := range value {
+			if selector, ok := child.(map[string]interface{}); ok && key == "datasource" {
+				if name, isString := selector["name"].(string); isString && len(name) > 0 {
+					result[name] = true
+					continue
+				}
+			}
+			walk(child, result)
+		}
+	case []interface{}:
+		for _, child := range value {
+			walk(child, result)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright 2021 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dashboard
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/interface/v1/dashboard"
+	"github.com/perses/perses/internal/api/shared"
+	databaseModel "github.com/perses/perses/internal/api/shared/database/model"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+// reviewRequiredError is returned when a dashboard is modified directly while the drafts must be reviewed before being
+// published.
+func reviewRequiredError() error {
+	return echo.NewHTTPError(http.StatusConflict, "the dashboards must be modified through a draft approved before being published: save the draft with PUT .../dashboards/<name>/draft, request a review and publish it once approved")
+}
+
+type Endpoint struct {
+	toolbox       shared.Toolbox
+	service       dashboard.Service
+	readonly      bool
+	requireReview bool
+}
+
+func NewEndpoint(service dashboard.Service, readonly bool, requireReview bool) *Endpoint {
+	return &Endpoint{
+		toolbox:       shared.NewToolBox(service),
+		service:       service,
+		readonly:      readonly,
+		requireReview: requireReview,
+	}
+}
+
+func (e *Endpoint) RegisterRoutes(g *echo.Group) {
+	group := g.Group(fmt.Sprintf("/%s", shared.PathDashboard))
+	subGroup := g.Group(fmt.Sprintf("/%s/:%s/%s", shared.PathProject, shared.ParamProject, shared.PathDashboard))
+	if !e.readonly {
+		group.POST("", e.Create)
+		subGroup.POST("", e.Create)
+		subGroup.PUT(fmt.Sprintf("/:%s", shared.ParamName), e.Update)
+		subGroup.PUT(fmt.Sprintf("/:%s/merge", shared.ParamName), e.Merge)
+		subGroup.DELETE(fmt.Sprintf("/:%s", shared.ParamName), e.Delete)
+	}
+	group.GET("", e.List)
+	subGroup.GET("", e.List)
+	subGroup.GET(fmt.Sprintf("/:%s", shared.ParamName), e.Get)
+}
+
+func (e *Endpoint) Create(ctx echo.Context) error {
+	entity := &v1.Dashboard{}
+	return e.toolbox.Create(ctx, entity)
+}
+
+func (e *Endpoint) Update(ctx echo.Context) error {
+	if e.requireReview {
+		return reviewRequiredError()
+	}
+	entity := &v1.Dashboard{}
+	return e.toolbox.Update(ctx, entity)
+}
+
+// Merge updates the dashboard with a three-way merge between the version the client started from, the version it
+// modified and the version currently stored.
+func (e *Endpoint) Merge(ctx echo.Context) error {
+	if e.requireReview {
+		return reviewRequiredError()
+	}
+	request := &v1.DashboardMerge{}
+	if err := ctx.Bind(request); err != nil {
+		return shared.HandleBadRequestError(err.Error())
+	}
+	parameters := shared.Parameters{
+		Project: shared.GetProjectParameter(ctx),
+		Name:    ctx.Param(shared.ParamName),
+	}
+	if len(request.Dashboard.Metadata.Project) == 0 {
+		request.Dashboard.Metadata.Project = parameters.Project
+	}
+	result, err := e.service.Merge(shared.NewAdmitter(ctx), request, parameters)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func (e *Endpoint) Delete(ctx echo.Context) error {
+	return e.toolbox.Delete(ctx)
+}
+
+// Get returns the dashboard. When the dashboard doesn't exist, but a dashboard has been renamed while keeping this
+// name as an alias, the client is redirected to the renamed dashboard.
+func (e *Endpoint) Get(ctx echo.Context) error {
+	err := e.toolbox.Get(ctx)
+	if !databaseModel.IsKeyNotFound(err) {
+		return err
+	}
+	project := shared.GetProjectParameter(ctx)
+	name, aliasErr := e.service.ResolveAlias(project, ctx.Param(shared.ParamName))
+	if aliasErr != nil {
+		return err
+	}
+	return ctx.Redirect(http.StatusMovedPermanently, fmt.Sprintf("%s/%s/%s/%s/%s", shared.APIV1Prefix, shared.PathProject, project, shared.PathDashboard, name))
+}
+
+func (e *Endpoint) List(ctx echo.Context) error {
+	q := &dashboard.Query{}
+	return e.toolbox.List(ctx, q)
+}
@@ -0,0 +1,285 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dashboard
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	dashboardModel "github.com/perses/perses/pkg/model/api/v1/dashboard"
+)
+
+const (
+	conflictReason = "the value has been modified in both versions"
+	panelRefPrefix = "#/spec/panels/"
+)
+
+var (
+	variablesPath   = regexp.MustCompile(`^spec\.variables$`)
+	layoutsPath     = regexp.MustCompile(`^spec\.layouts$`)
+	layoutItemsPath = regexp.MustCompile(`^spec\.layouts\[\d+]\.spec\.items$`)
+)
+
+// absentValue is the value of a key that doesn't exist in a version.
+type absentValue struct{}
+
+var absent interface{} = absentValue{}
+
+// listKey returns the key identifying an element of a list. The key is empty when the element cannot be identified.
+type listKey func(element interface{}) (name string, key string)
+
+func variableKey(element interface{}) (string, string) {
+	return "name", getString(element, "spec", "name")
+}
+
+func layoutItemKey(element interface{}) (string, string) {
+	return "ref", getString(element, "content", "$ref")
+}
+
+func getString(element interface{}, path ...string) string {
+	current := element
+	for _, key := range path {
+		object, ok := current.(map[string]interface{})
+		if !ok {
+			return ""
+		}
+		current = object[key]
+	}
+	value, _ := current.(string)
+	return value
+}
+
+type merger struct {
+	conflicts []v1.MergeConflict
+}
+
+// mergeSpec applies on the spec of the stored dashboard the changes done between the base and the local version.
+// The objects are merged key by key, the variables by name, the layouts by position and their items by the panel
+// they reference. The other lists are considered as a single value.
+func mergeSpec(base, local, stored *v1.Dashboard) (*v1.DashboardSpec, []v1.MergeConflict, error) {
+	var values [3]interface{}
+	for i, dashboard := range []*v1.Dashboard{base, local, stored} {
+		data, err := json.Marshal(dashboard.Spec)
+		if err != nil {
+			return nil, nil, err
+		}
+		if unmarshalErr := json.Unmarshal(data, &values[i]); unmarshalErr != nil {
+			return nil, nil, unmarshalErr
+		}
+	}
+	m := &merger{}
+	result := m.merge("spec", values[0], values[1], values[2])
+	if len(m.conflicts) > 0 {
+		return nil, m.conflicts, nil
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		return nil, nil, err
+	}
+	spec := &v1.DashboardSpec{}
+	if unmarshalErr := json.Unmarshal(data, spec); unmarshalErr != nil {
+		// the changes are compatible one by one, but not together
+		return nil, []v1.MergeConflict{{
+			Path:   "spec",
+			Reason: fmt.Sprintf("the merged dashboard is invalid: %s", unmarshalErr),
+		}}, nil
+	}
+	if refConflicts := checkPanelReferences(spec); len(refConflicts) > 0 {
+		return nil, refConflicts, nil
+	}
+	return spec, nil, nil
+}
+
+// checkPanelReferences returns a conflict for each item of the layouts referencing a panel that doesn't exist in the
+// merged dashboard. It happens when a panel is removed in one version and added to a layout in the other one.
+func checkPanelReferences(spec *v1.DashboardSpec) []v1.MergeConflict {
+	var conflicts []v1.MergeConflict
+	for i, layout := range spec.Layouts {
+		grid, ok := layout.Spec.(*dashboardModel.GridLayoutSpec)
+		if !ok {
+			continue
+		}
+		for _, item := range grid.Items {
+			if item.Content == nil || !strings.HasPrefix(item.Content.Ref, panelRefPrefix) {
+				continue
+			}
+			if _, exists := spec.Panels[strings.TrimPrefix(item.Content.Ref, panelRefPrefix)]; !exists {
+				conflicts = append(conflicts, v1.MergeConflict{
+					Path:   fmt.Sprintf("spec.layouts[%d].spec.items[ref=%s]", i, item.Content.Ref),
+					Reason: "the panel referenced doesn't exist in the merged dashboard",
+				})
+			}
+		}
+	}
+	return conflicts
+}
+
+func (m *merger) merge(path string, base, local, stored interface{}) interface{} {
+	if reflect.DeepEqual(local, stored) || reflect.DeepEqual(base, stored) {
+		return local
+	}
+	if reflect.DeepEqual(base, local) {
+		return stored
+	}
+	// both versions have modified the value, differently
+	switch localValue := local.(type) {
+	case map[string]interface{}:
+		baseObject, isBaseObject := base.(map[string]interface{})
+		storedObject, isStoredObject := stored.(map[string]interface{})
+		if isBaseObject && isStoredObject {
+			return m.mergeObject(path, baseObject, localValue, storedObject)
+		}
+	case []interface{}:
+		baseList, isBaseList := base.([]interface{})
+		storedList, isStoredList := stored.([]interface{})
+		if isBaseList && isStoredList {
+			if result, ok := m.mergeList(path, baseList, localValue, storedList); ok {
+				return result
+			}
+		}
+	}
+	m.addConflict(path, base, local, stored)
+	return local
+}
+
+func (m *merger) mergeObject(path string, base, local, stored map[string]interface{}) interface{} {
+	keys := make(map[string]bool)
+	for _, object := range []map[string]interface{}{base, local, stored} {
+		for key := range object {
+			keys[key] = true
+		}
+	}
+	sortedKeys := make([]string, 0, len(keys))
+	for key := range keys {
+		sortedKeys = append(sortedKeys, key)
+	}
+	sort.Strings(sortedKeys)
+	result := make(map[string]interface{})
+	for _, key := range sortedKeys {
+		value := m.merge(fmt.Sprintf("%s.%s", path, key), getKey(base, key), getKey(local, key), getKey(stored, key))
+		if value != absent {
+			result[key] = value
+		}
+	}
+	return result
+}
+
+func getKey(object map[string]interface{}, key string) interface{} {
+	if value, ok := object[key]; ok {
+		return value
+	}
+	return absent
+}
+
+func (m *merger) mergeList(path string, base, local, stored []interface{}) ([]interface{}, bool) {
+	switch {
+	case variablesPath.MatchString(path):
+		return m.mergeKeyedList(path, variableKey, base, local, stored)
+	case layoutItemsPath.MatchString(path):
+		return m.mergeKeyedList(path, layoutItemKey, base, local, stored)
+	case layoutsPath.MatchString(path):
+		return m.mergePositionalList(path, base, local, stored), true
+	default:
+		return nil, false
+	}
+}
+
+// mergePositionalList merges the elements having the same position. An element added at the end of the list in
+// only one version is kept.
+func (m *merger) mergePositionalList(path string, base, local, stored []interface{}) []interface{} {
+	length := len(base)
+	if len(local) > length {
+		length = len(local)
+	}
+	if len(stored) > length {
+		length = len(stored)
+	}
+	result := make([]interface{}, 0, length)
+	for i := 0; i < length; i++ {
+		value := m.merge(fmt.Sprintf("%s[%d]", path, i), getIndex(base, i), getIndex(local, i), getIndex(stored, i))
+		if value != absent {
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+func getIndex(list []interface{}, i int) interface{} {
+	if i < len(list) {
+		return list[i]
+	}
+	return absent
+}
+
+// mergeKeyedList merges the elements having the same key. The order of the local version is kept, the elements only
+// added in the stored version are placed at the end. It returns false when an element cannot be identified.
+func (m *merger) mergeKeyedList(path string, key listKey, base, local, stored []interface{}) ([]interface{}, bool) {
+	var keyName string
+	var order []string
+	elements := make([]map[string]interface{}, 3)
+	for i, list := range [][]interface{}{base, local, stored} {
+		elements[i] = make(map[string]interface{}, len(list))
+		for _, element := range list {
+			name, k := key(element)
+			if _, exists := elements[i][k]; len(k) == 0 || exists {
+				return nil, false
+			}
+			keyName = name
+			elements[i][k] = element
+		}
+	}
+	seen := make(map[string]bool)
+	for _, list := range [][]interface{}{local, stored, base} {
+		for _, element := range list {
+			if _, k := key(element); !seen[k] {
+				seen[k] = true
+				order = append(order, k)
+			}
+		}
+	}
+	result := make([]interface{}, 0, len(order))
+	for _, k := range order {
+		value := m.merge(fmt.Sprintf("%s[%s=%s]", path, keyName, k), getKey(elements[0], k), getKey(elements[1], k), getKey(elements[2], k))
+		if value != absent {
+			result = append(result, value)
+		}
+	}
+	return result, true
+}
+
+func (m *merger) addConflict(path string, base, local, stored interface{}) {
+	m.conflicts = append(m.conflicts, v1.MergeConflict{
+		Path:   path,
+		Reason: conflictReason,
+		Base:   marshalValue(base),
+		Local:  marshalValue(local),
+		Stored: marshalValue(stored),
+	})
+}
+
+func marshalValue(value interface{}) json.RawMessage {
+	if value == absent {
+		return nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+	return data
+}
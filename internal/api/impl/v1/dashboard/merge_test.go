@@ -0,0 +1,259 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dashboard
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+// testSpec describes a dashboard spec with the JSON of its parts, so each version of a test only changes what it needs.
+type testSpec struct {
+	duration  string
+	variables []string
+	panels    map[string]string
+	layouts   []string
+}
+
+func textVariable(name string, value string) string {
+	return fmt.Sprintf(`{"kind":"TextVariable","spec":{"name":%q,"value":%q}}`, name, value)
+}
+
+func panel(title string) string {
+	return fmt.Sprintf(`{"kind":"Panel","spec":{"display":{"name":%q},"plugin":{"kind":"TimeSeriesChart","spec":{}}}}`, title)
+}
+
+func gridItem(panel string, x int) string {
+	return fmt.Sprintf(`{"x":%d,"y":0,"width":6,"height":4,"content":{"$ref":"#/spec/panels/%s"}}`, x, panel)
+}
+
+func grid(title string, items ...string) string {
+	return fmt.Sprintf(`{"kind":"Grid","spec":{"display":{"title":%q},"items":[%s]}}`, title, strings.Join(items, ","))
+}
+
+func baseSpec() testSpec {
+	return testSpec{
+		duration:  "1h",
+		variables: []string{textVariable("job", "api"), textVariable("instance", "a")},
+		panels:    map[string]string{"cpu": panel("CPU"), "mem": panel("Memory")},
+		layouts:   []string{grid("Resources", gridItem("cpu", 0), gridItem("mem", 6))},
+	}
+}
+
+func (s testSpec) dashboard(t *testing.T) *v1.Dashboard {
+	names := make([]string, 0, len(s.panels))
+	for name := range s.panels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	panels := make([]string, 0, len(names))
+	for _, name := range names {
+		panels = append(panels, fmt.Sprintf("%q:%s", name, s.panels[name]))
+	}
+	data := fmt.Sprintf(
+		`{"kind":"Dashboard","metadata":{"name":"test","project":"perses"},"spec":{"duration":%q,"variables":[%s],"panels":{%s},"layouts":[%s]}}`,
+		s.duration, strings.Join(s.variables, ","), strings.Join(panels, ","), strings.Join(s.layouts, ","),
+	)
+	result := &v1.Dashboard{}
+	if err := json.Unmarshal([]byte(data), result); err != nil {
+		t.Fatal(err)
+	}
+	return result
+}
+
+func TestMergeSpec(t *testing.T) {
+	testSuite := []struct {
+		title  string
+		local  func(s *testSpec)
+		stored func(s *testSpec)
+		// expected is applied on the base version to get the merged one. It is ignored when conflicts is set.
+		expected  func(s *testSpec)
+		conflicts []string
+	}{
+		{
+			title: "variables merged by name",
+			local: func(s *testSpec) {
+				s.variables = []string{textVariable("instance", "a"), textVariable("job", "api")}
+			},
+			stored: func(s *testSpec) {
+				s.variables = []string{textVariable("job", "web"), textVariable("instance", "a")}
+			},
+			expected: func(s *testSpec) {
+				s.variables = []string{textVariable("instance", "a"), textVariable("job", "web")}
+			},
+		},
+		{
+			title: "variables added in both versions",
+			local: func(s *testSpec) {
+				s.variables = append(s.variables, textVariable("env", "prod"))
+			},
+			stored: func(s *testSpec) {
+				s.variables = append(s.variables, textVariable("region", "eu"))
+			},
+			expected: func(s *testSpec) {
+				s.variables = append(s.variables, textVariable("env", "prod"), textVariable("region", "eu"))
+			},
+		},
+		{
+			title: "layout items merged by the panel they reference",
+			local: func(s *testSpec) {
+				s.layouts = []string{grid("Resources", gridItem("mem", 6), gridItem("cpu", 0))}
+			},
+			stored: func(s *testSpec) {
+				s.layouts = []string{grid("Resources", gridItem("cpu", 3), gridItem("mem", 6))}
+			},
+			expected: func(s *testSpec) {
+				s.layouts = []string{grid("Resources", gridItem("mem", 6), gridItem("cpu", 3))}
+			},
+		},
+		{
+			title: "layouts merged by position",
+			local: func(s *testSpec) {
+				s.layouts = []string{grid("Usage", gridItem("cpu", 0), gridItem("mem", 6))}
+			},
+			stored: func(s *testSpec) {
+				s.layouts = append(s.layouts, grid("Network"))
+			},
+			expected: func(s *testSpec) {
+				s.layouts = []string{grid("Usage", gridItem("cpu", 0), gridItem("mem", 6)), grid("Network")}
+			},
+		},
+		{
+			title: "layouts added at the same position",
+			local: func(s *testSpec) {
+				s.layouts = append(s.layouts, grid("Network"))
+			},
+			stored: func(s *testSpec) {
+				s.layouts = append(s.layouts, grid("Disk"))
+			},
+			conflicts: []string{"spec.layouts[1]"},
+		},
+		{
+			title: "panel added in a version and another one deleted in the other",
+			local: func(s *testSpec) {
+				s.panels["disk"] = panel("Disk")
+				s.layouts = []string{grid("Resources", gridItem("cpu", 0), gridItem("mem", 6), gridItem("disk", 12))}
+			},
+			stored: func(s *testSpec) {
+				delete(s.panels, "mem")
+				s.layouts = []string{grid("Resources", gridItem("cpu", 0))}
+			},
+			expected: func(s *testSpec) {
+				s.panels = map[string]string{"cpu": panel("CPU"), "disk": panel("Disk")}
+				s.layouts = []string{grid("Resources", gridItem("cpu", 0), gridItem("disk", 12))}
+			},
+		},
+		{
+			title: "panel modified in a version and deleted in the other",
+			local: func(s *testSpec) {
+				s.panels["mem"] = panel("RAM")
+			},
+			stored: func(s *testSpec) {
+				delete(s.panels, "mem")
+				s.layouts = []string{grid("Resources", gridItem("cpu", 0))}
+			},
+			conflicts: []string{"spec.panels.mem"},
+		},
+		{
+			title: "panel deleted in a version and added to a layout in the other",
+			local: func(s *testSpec) {
+				delete(s.panels, "mem")
+				s.layouts = []string{grid("Resources", gridItem("cpu", 0))}
+			},
+			stored: func(s *testSpec) {
+				s.layouts = append(s.layouts, grid("Memory", gridItem("mem", 0)))
+			},
+			conflicts: []string{"spec.layouts[1].spec.items[ref=#/spec/panels/mem]"},
+		},
+		{
+			title: "same values modified differently in both versions",
+			local: func(s *testSpec) {
+				s.duration = "2h"
+				s.variables = []string{textVariable("job", "web"), textVariable("instance", "a")}
+				s.layouts = []string{grid("Resources", gridItem("cpu", 3), gridItem("mem", 6))}
+			},
+			stored: func(s *testSpec) {
+				s.duration = "3h"
+				s.variables = []string{textVariable("job", "db"), textVariable("instance", "a")}
+				s.layouts = []string{grid("Resources", gridItem("cpu", 9), gridItem("mem", 6))}
+			},
+			conflicts: []string{
+				"spec.duration",
+				"spec.layouts[0].spec.items[ref=#/spec/panels/cpu].x",
+				"spec.variables[name=job].spec.value",
+			},
+		},
+		{
+			title: "same value modified identically in both versions",
+			local: func(s *testSpec) {
+				s.duration = "2h"
+			},
+			stored: func(s *testSpec) {
+				s.duration = "2h"
+			},
+			expected: func(s *testSpec) {
+				s.duration = "2h"
+			},
+		},
+	}
+	for _, test := range testSuite {
+		t.Run(test.title, func(t *testing.T) {
+			local := baseSpec()
+			test.local(&local)
+			stored := baseSpec()
+			test.stored(&stored)
+			spec, conflicts, err := mergeSpec(baseSpec().dashboard(t), local.dashboard(t), stored.dashboard(t))
+			assert.NoError(t, err)
+			if len(test.conflicts) > 0 {
+				paths := make([]string, 0, len(conflicts))
+				for _, conflict := range conflicts {
+					paths = append(paths, conflict.Path)
+				}
+				assert.Equal(t, test.conflicts, paths)
+				assert.Nil(t, spec)
+				return
+			}
+			assert.Empty(t, conflicts)
+			expected := baseSpec()
+			test.expected(&expected)
+			expectedData, err := json.Marshal(expected.dashboard(t).Spec)
+			assert.NoError(t, err)
+			data, err := json.Marshal(spec)
+			assert.NoError(t, err)
+			assert.JSONEq(t, string(expectedData), string(data))
+		})
+	}
+}
+
+func TestMergeSpecConflictValues(t *testing.T) {
+	local := baseSpec()
+	local.duration = "2h"
+	stored := baseSpec()
+	stored.duration = "3h"
+	_, conflicts, err := mergeSpec(baseSpec().dashboard(t), local.dashboard(t), stored.dashboard(t))
+	assert.NoError(t, err)
+	assert.Equal(t, []v1.MergeConflict{{
+		Path:   "spec.duration",
+		Reason: conflictReason,
+		Base:   json.RawMessage(`"1h"`),
+		Local:  json.RawMessage(`"2h"`),
+		Stored: json.RawMessage(`"3h"`),
+	}}, conflicts)
+}
@@ -15,59 +15,84 @@ package dashboard
 
 import (
 	"fmt"
+	"net/http"
 
+	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/interface/v1/access"
 	"github.com/perses/perses/internal/api/interface/v1/dashboard"
+	"github.com/perses/perses/internal/api/interface/v1/dashboardalias"
+	"github.com/perses/perses/internal/api/interface/v1/draft"
+	"github.com/perses/perses/internal/api/interface/v1/homeconfig"
+	"github.com/perses/perses/internal/api/interface/v1/sharetoken"
 	"github.com/perses/perses/internal/api/shared"
 	databaseModel "github.com/perses/perses/internal/api/shared/database/model"
 	"github.com/perses/perses/internal/api/shared/schemas"
 	"github.com/perses/perses/internal/api/shared/validate"
 	"github.com/perses/perses/pkg/model/api"
+	"github.com/perses/perses/pkg/model/api/admission"
 	v1 "github.com/perses/perses/pkg/model/api/v1"
 	"github.com/sirupsen/logrus"
 )
 
 type service struct {
 	dashboard.Service
-	dao dashboard.DAO
-	sch schemas.Schemas
+	dao               dashboard.DAO
+	draftDAO          draft.DAO
+	accessDAO         access.DAO
+	shareTokenDAO     sharetoken.DAO
+	aliasDAO          dashboardalias.DAO
+	homeConfigService homeconfig.Service
+	sch               schemas.Schemas
 }
 
-func NewService(dao dashboard.DAO, sch schemas.Schemas) dashboard.Service {
+func NewService(dao dashboard.DAO, draftDAO draft.DAO, accessDAO access.DAO, shareTokenDAO sharetoken.DAO, aliasDAO dashboardalias.DAO, homeConfigService homeconfig.Service, sch schemas.Schemas) dashboard.Service {
 	return &service{
-		dao: dao,
-		sch: sch,
+		dao:               dao,
+		draftDAO:          draftDAO,
+		accessDAO:         accessDAO,
+		shareTokenDAO:     shareTokenDAO,
+		aliasDAO:          aliasDAO,
+		homeConfigService: homeConfigService,
+		sch:               sch,
 	}
 }
 
-func (s *service) Create(entity api.Entity) (interface{}, error) {
+func (s *service) Create(admitter shared.Admitter, entity api.Entity) (interface{}, error) {
 	if dashboardObject, ok := entity.(*v1.Dashboard); ok {
-		return s.create(dashboardObject)
+		return s.create(admitter, dashboardObject)
 	}
 	return nil, shared.HandleBadRequestError(fmt.Sprintf("wrong entity format, attempting dashboard format, received '%T'", entity))
 }
 
-func (s *service) create(entity *v1.Dashboard) (*v1.Dashboard, error) {
+func (s *service) create(admitter shared.Admitter, entity *v1.Dashboard) (*v1.Dashboard, error) {
 	// verify this new dashboard passes the validation
-	if err := validate.Dashboard(entity, s.sch); err != nil {
-		return nil, shared.HandleBadRequestError(err.Error())
+	if err := s.validate(entity); err != nil {
+		return nil, err
+	}
+	if err := admitter.Admit(admission.OperationCreate, entity, nil, func() error { return s.validate(entity) }); err != nil {
+		return nil, err
 	}
-
 	// Update the time contains in the entity
 	entity.Metadata.CreateNow()
 	if err := s.dao.Create(entity); err != nil {
 		return nil, err
 	}
+	// an alias having the same name is superseded by the new dashboard
+	if err := s.aliasDAO.Delete(entity.Metadata.Project, entity.Metadata.Name); err != nil && !databaseModel.IsKeyNotFound(err) {
+		logrus.WithError(err).Errorf("unable to delete the alias %q superseded by the new dashboard", entity.Metadata.Name)
+		return nil, err
+	}
 	return entity, nil
 }
 
-func (s *service) Update(entity api.Entity, parameters shared.Parameters) (interface{}, error) {
+func (s *service) Update(admitter shared.Admitter, entity api.Entity, parameters shared.Parameters) (interface{}, error) {
 	if dashboardObject, ok := entity.(*v1.Dashboard); ok {
-		return s.update(dashboardObject, parameters)
+		return s.update(admitter, dashboardObject, parameters)
 	}
 	return nil, shared.HandleBadRequestError(fmt.Sprintf("wrong entity format, attempting dashboard format, received '%T'", entity))
 }
 
-func (s *service) update(entity *v1.Dashboard, parameters shared.Parameters) (*v1.Dashboard, error) {
+func (s *service) update(admitter shared.Admitter, entity *v1.Dashboard, parameters shared.Parameters) (*v1.Dashboard, error) {
 	if entity.Metadata.Name != parameters.Name {
 		logrus.Debugf("name in dashboard %q and name from the http request %q don't match", entity.Metadata.Name, parameters.Name)
 		return nil, shared.HandleBadRequestError("metadata.name and the name in the http path request don't match")
@@ -80,8 +105,8 @@ func (s *service) update(entity *v1.Dashboard, parameters shared.Parameters) (*v
 	}
 
 	// verify this new dashboard passes the validation
-	if err := validate.Dashboard(entity, s.sch); err != nil {
-		return nil, shared.HandleBadRequestError(err.Error())
+	if err := s.validate(entity); err != nil {
+		return nil, err
 	}
 
 	// find the previous version of the dashboard
@@ -89,6 +114,9 @@ func (s *service) update(entity *v1.Dashboard, parameters shared.Parameters) (*v
 	if err != nil {
 		return nil, err
 	}
+	if admissionErr := admitter.Admit(admission.OperationUpdate, entity, oldEntity, func() error { return s.validate(entity) }); admissionErr != nil {
+		return nil, admissionErr
+	}
 	entity.Metadata.Update(oldEntity.Metadata)
 	if updateErr := s.dao.Update(entity); updateErr != nil {
 		logrus.WithError(updateErr).Errorf("unable to perform the update of the dashboard %q, something wrong with the database", entity.Metadata.Name)
@@ -97,8 +125,112 @@ func (s *service) update(entity *v1.Dashboard, parameters shared.Parameters) (*v
 	return entity, nil
 }
 
-func (s *service) Delete(parameters shared.Parameters) error {
-	return s.dao.Delete(parameters.Project, parameters.Name)
+func (s *service) Merge(admitter shared.Admitter, request *v1.DashboardMerge, parameters shared.Parameters) (*v1.Dashboard, error) {
+	stored, err := s.dao.Get(parameters.Project, parameters.Name)
+	if err != nil {
+		return nil, err
+	}
+	spec, conflicts, err := mergeSpec(request.Base, request.Dashboard, stored)
+	if err != nil {
+		logrus.WithError(err).Errorf("unable to merge the dashboard %q", parameters.Name)
+		return nil, shared.InternalError
+	}
+	if len(conflicts) > 0 {
+		return nil, echo.NewHTTPError(http.StatusConflict, &v1.MergeConflicts{
+			Message:   fmt.Sprintf("the dashboard %q has been modified in the meantime and the changes are conflicting", parameters.Name),
+			Conflicts: conflicts,
+		})
+	}
+	merged := &v1.Dashboard{
+		Kind:     request.Dashboard.Kind,
+		Metadata: request.Dashboard.Metadata,
+		Spec:     *spec,
+	}
+	// the merged dashboard is the one stored, so it is the one validated and sent to the admission webhooks
+	return s.update(admitter, merged, parameters)
+}
+
+func (s *service) validate(entity *v1.Dashboard) error {
+	if err := validate.Dashboard(entity, s.sch); err != nil {
+		return shared.HandleBadRequestError(err.Error())
+	}
+	return nil
+}
+
+func (s *service) Delete(admitter shared.Admitter, parameters shared.Parameters) error {
+	getOld := func() (api.Entity, error) { return s.dao.Get(parameters.Project, parameters.Name) }
+	if err := admitter.AdmitDeletion(v1.KindDashboard, getOld); err != nil {
+		return err
+	}
+	if err := s.dao.Delete(parameters.Project, parameters.Name); err != nil {
+		return err
+	}
+	// the grants must not be inherited by a future dashboard with the same name
+	if err := s.accessDAO.Delete(parameters.Project, parameters.Name); err != nil && !databaseModel.IsKeyNotFound(err) {
+		logrus.WithError(err).Errorf("unable to delete the grants of the dashboard %q", parameters.Name)
+		return err
+	}
+	// a draft would publish the dashboard again
+	if err := s.draftDAO.Delete(parameters.Project, parameters.Name); err != nil && !databaseModel.IsKeyNotFound(err) {
+		logrus.WithError(err).Errorf("unable to delete the draft of the dashboard %q", parameters.Name)
+		return err
+	}
+	// the same for the share tokens
+	if err := s.deleteShareTokens(parameters.Project, parameters.Name); err != nil {
+		logrus.WithError(err).Errorf("unable to delete the share tokens of the dashboard %q", parameters.Name)
+		return err
+	}
+	// the aliases would redirect to a dashboard that doesn't exist anymore
+	if err := s.deleteAliases(parameters.Project, parameters.Name); err != nil {
+		logrus.WithError(err).Errorf("unable to delete the aliases of the dashboard %q", parameters.Name)
+		return err
+	}
+	// and the home page must not point to a dashboard that doesn't exist anymore
+	if err := s.homeConfigService.RemoveDashboard(parameters.Project, parameters.Name); err != nil {
+		logrus.WithError(err).Errorf("unable to remove the dashboard %q from the home page", parameters.Name)
+		return err
+	}
+	return nil
+}
+
+func (s *service) deleteShareTokens(project string, name string) error {
+	list, err := s.shareTokenDAO.List(&sharetoken.Query{Project: project})
+	if err != nil {
+		return err
+	}
+	for _, shareToken := range list {
+		if shareToken.Spec.Dashboard != name {
+			continue
+		}
+		if deleteErr := s.shareTokenDAO.Delete(project, shareToken.Metadata.Name); deleteErr != nil && !databaseModel.IsKeyNotFound(deleteErr) {
+			return deleteErr
+		}
+	}
+	return nil
+}
+
+func (s *service) deleteAliases(project string, name string) error {
+	list, err := s.aliasDAO.List(&dashboardalias.Query{Project: project})
+	if err != nil {
+		return err
+	}
+	for _, alias := range list {
+		if alias.Spec.Dashboard != name {
+			continue
+		}
+		if deleteErr := s.aliasDAO.Delete(project, alias.Metadata.Name); deleteErr != nil && !databaseModel.IsKeyNotFound(deleteErr) {
+			return deleteErr
+		}
+	}
+	return nil
+}
+
+func (s *service) ResolveAlias(project string, name string) (string, error) {
+	alias, err := s.aliasDAO.Get(project, name)
+	if err != nil {
+		return "", err
+	}
+	return alias.Spec.Dashboard, nil
 }
 
 func (s *service) Get(parameters shared.Parameters) (interface{}, error) {
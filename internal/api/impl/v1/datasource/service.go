@@ -22,6 +22,7 @@ import (
 	"github.com/perses/perses/internal/api/shared/schemas"
 	"github.com/perses/perses/internal/api/shared/validate"
 	"github.com/perses/perses/pkg/model/api"
+	"github.com/perses/perses/pkg/model/api/admission"
 	v1 "github.com/perses/perses/pkg/model/api/v1"
 	"github.com/sirupsen/logrus"
 )
@@ -39,16 +40,19 @@ func NewService(dao datasource.DAO, sch schemas.Schemas) datasource.Service {
 	}
 }
 
-func (s *service) Create(entity api.Entity) (interface{}, error) {
+func (s *service) Create(admitter shared.Admitter, entity api.Entity) (interface{}, error) {
 	if datasourceObject, ok := entity.(*v1.Datasource); ok {
-		return s.create(datasourceObject)
+		return s.create(admitter, datasourceObject)
 	}
 	return nil, shared.HandleBadRequestError(fmt.Sprintf("wrong entity format, attempting Datasource format, received '%T'", entity))
 }
 
-func (s *service) create(entity *v1.Datasource) (*v1.Datasource, error) {
+func (s *service) create(admitter shared.Admitter, entity *v1.Datasource) (*v1.Datasource, error) {
 	if err := s.validate(entity); err != nil {
-		return nil, shared.HandleBadRequestError(err.Error())
+		return nil, err
+	}
+	if err := admitter.Admit(admission.OperationCreate, entity, nil, func() error { return s.validate(entity) }); err != nil {
+		return nil, err
 	}
 	// Update the time contains in the entity
 	entity.Metadata.CreateNow()
@@ -58,16 +62,16 @@ func (s *service) create(entity *v1.Datasource) (*v1.Datasource, error) {
 	return entity, nil
 }
 
-func (s *service) Update(entity api.Entity, parameters shared.Parameters) (interface{}, error) {
+func (s *service) Update(admitter shared.Admitter, entity api.Entity, parameters shared.Parameters) (interface{}, error) {
 	if DatasourceObject, ok := entity.(*v1.Datasource); ok {
-		return s.update(DatasourceObject, parameters)
+		return s.update(admitter, DatasourceObject, parameters)
 	}
 	return nil, shared.HandleBadRequestError(fmt.Sprintf("wrong entity format, attempting Datasource format, received '%T'", entity))
 }
 
-func (s *service) update(entity *v1.Datasource, parameters shared.Parameters) (*v1.Datasource, error) {
+func (s *service) update(admitter shared.Admitter, entity *v1.Datasource, parameters shared.Parameters) (*v1.Datasource, error) {
 	if err := s.validate(entity); err != nil {
-		return nil, shared.HandleBadRequestError(err.Error())
+		return nil, err
 	}
 	if entity.Metadata.Name != parameters.Name {
 		logrus.Debugf("name in Datasource %q and name from the http request %q don't match", entity.Metadata.Name, parameters.Name)
@@ -84,6 +88,9 @@ func (s *service) update(entity *v1.Datasource, parameters shared.Parameters) (*
 	if err != nil {
 		return nil, err
 	}
+	if admissionErr := admitter.Admit(admission.OperationUpdate, entity, oldEntity, func() error { return s.validate(entity) }); admissionErr != nil {
+		return nil, admissionErr
+	}
 	entity.Metadata.Update(oldEntity.Metadata)
 	if updateErr := s.dao.Update(entity); updateErr != nil {
 		logrus.WithError(updateErr).Errorf("unable to perform the update of the Datasource %q, something wrong with the database", entity.Metadata.Name)
@@ -92,7 +99,11 @@ func (s *service) update(entity *v1.Datasource, parameters shared.Parameters) (*
 	return entity, nil
 }
 
-func (s *service) Delete(parameters shared.Parameters) error {
+func (s *service) Delete(admitter shared.Admitter, parameters shared.Parameters) error {
+	getOld := func() (api.Entity, error) { return s.dao.Get(parameters.Project, parameters.Name) }
+	if err := admitter.AdmitDeletion(v1.KindDatasource, getOld); err != nil {
+		return err
+	}
 	return s.dao.Delete(parameters.Project, parameters.Name)
 }
 
@@ -120,5 +131,8 @@ func (s *service) validate(entity *v1.Datasource) error {
 			return err
 		}
 	}
-	return validate.Datasource(entity, list, s.sch)
+	if err := validate.Datasource(entity, list, s.sch); err != nil {
+		return shared.HandleBadRequestError(err.Error())
+	}
+	return nil
 }
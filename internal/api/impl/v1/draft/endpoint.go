@@ -0,0 +1,165 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package draft
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/interface/v1/draft"
+	"github.com/perses/perses/internal/api/shared"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+// Endpoint is the struct that define all endpoint used to manage the drafts of the dashboards.
+type Endpoint struct {
+	service  draft.Service
+	readonly bool
+}
+
+func NewEndpoint(service draft.Service, readonly bool) *Endpoint {
+	return &Endpoint{
+		service:  service,
+		readonly: readonly,
+	}
+}
+
+// RegisterRoutes is the method to use to register the routes prefixed by /api/v1
+func (e *Endpoint) RegisterRoutes(g *echo.Group) {
+	if e.readonly {
+		// The drafts are only visible to the ones allowed to modify the dashboards.
+		return
+	}
+	g.GET(fmt.Sprintf("/%s", shared.PathDraft), e.List)
+	g.GET(fmt.Sprintf("/%s/:%s/%s", shared.PathProject, shared.ParamProject, shared.PathDraft), e.List)
+	group := g.Group(fmt.Sprintf("/%s/:%s/%s/:%s/draft", shared.PathProject, shared.ParamProject, shared.PathDashboard, shared.ParamName))
+	group.GET("", e.Get)
+	group.PUT("", e.Save)
+	group.DELETE("", e.Discard)
+	group.GET("/preview", e.Preview)
+	group.GET("/diff", e.Diff)
+	group.POST("/review", e.RequestReview)
+	group.POST("/approve", e.Approve)
+	group.POST("/publish", e.Publish)
+}
+
+func (e *Endpoint) List(ctx echo.Context) error {
+	q := &draft.Query{}
+	if err := ctx.Bind(q); err != nil {
+		return shared.HandleBadRequestError(err.Error())
+	}
+	result, err := e.service.List(q)
+	if err != nil {
+		return err
+	}
+	if len(shared.GetProjectParameter(ctx)) == 0 {
+		// like in a project, the drafts are only visible to the ones allowed to modify the dashboards
+		result = shared.FilterByProjectPermission(ctx, result, v1.RoleEditor, func(d *v1.DashboardDraft) string { return d.Metadata.Project })
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func (e *Endpoint) Get(ctx echo.Context) error {
+	project, name := extractParameters(ctx)
+	result, err := e.service.Get(project, name)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+// Save creates or updates the draft with the dashboard contained in the body.
+func (e *Endpoint) Save(ctx echo.Context) error {
+	entity := &v1.Dashboard{}
+	if err := ctx.Bind(entity); err != nil {
+		return shared.HandleBadRequestError(err.Error())
+	}
+	project, name := extractParameters(ctx)
+	result, err := e.service.Save(shared.NewAdmitter(ctx), entity, project, name, getUsername(ctx))
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func (e *Endpoint) Discard(ctx echo.Context) error {
+	project, name := extractParameters(ctx)
+	if err := e.service.Discard(project, name); err != nil {
+		return err
+	}
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func (e *Endpoint) Preview(ctx echo.Context) error {
+	project, name := extractParameters(ctx)
+	result, err := e.service.Preview(project, name)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func (e *Endpoint) Diff(ctx echo.Context) error {
+	project, name := extractParameters(ctx)
+	result, err := e.service.Diff(project, name)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func (e *Endpoint) RequestReview(ctx echo.Context) error {
+	project, name := extractParameters(ctx)
+	result, err := e.service.RequestReview(project, name)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func (e *Endpoint) Approve(ctx echo.Context) error {
+	project, name := extractParameters(ctx)
+	result, err := e.service.Approve(project, name, getUsername(ctx))
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func (e *Endpoint) Publish(ctx echo.Context) error {
+	q := &draft.PublishQuery{}
+	// Bind only reads the query parameters of the GET and DELETE requests
+	if err := (&echo.DefaultBinder{}).BindQueryParams(ctx, q); err != nil {
+		return shared.HandleBadRequestError(err.Error())
+	}
+	project, name := extractParameters(ctx)
+	result, err := e.service.Publish(shared.NewAdmitter(ctx), project, name, q)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func extractParameters(ctx echo.Context) (string, string) {
+	return shared.GetProjectParameter(ctx), ctx.Param(shared.ParamName)
+}
+
+// getUsername returns the name of the user sending the request, empty when the authentication is not enabled.
+func getUsername(ctx echo.Context) string {
+	if identity := shared.GetIdentity(ctx); identity != nil {
+		return identity.Username
+	}
+	return ""
+}
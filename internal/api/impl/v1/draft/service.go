@@ -0,0 +1,314 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package draft
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/interface/v1/dashboard"
+	"github.com/perses/perses/internal/api/interface/v1/draft"
+	"github.com/perses/perses/internal/api/interface/v1/project"
+	"github.com/perses/perses/internal/api/shared"
+	databaseModel "github.com/perses/perses/internal/api/shared/database/model"
+	"github.com/perses/perses/internal/api/shared/diff"
+	"github.com/perses/perses/internal/api/shared/schemas"
+	"github.com/perses/perses/internal/api/shared/validate"
+	"github.com/perses/perses/pkg/model/api/admission"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/common"
+	"github.com/sirupsen/logrus"
+)
+
+type service struct {
+	draft.Service
+	dao              draft.DAO
+	dashboardDAO     dashboard.DAO
+	dashboardService dashboard.Service
+	projectDAO       project.DAO
+	sch              schemas.Schemas
+	requireReview    bool
+}
+
+func NewService(dao draft.DAO, dashboardDAO dashboard.DAO, dashboardService dashboard.Service, projectDAO project.DAO, sch schemas.Schemas, requireReview bool) draft.Service {
+	return &service{
+		dao:              dao,
+		dashboardDAO:     dashboardDAO,
+		dashboardService: dashboardService,
+		projectDAO:       projectDAO,
+		sch:              sch,
+		requireReview:    requireReview,
+	}
+}
+
+func (s *service) Save(admitter shared.Admitter, entity *v1.Dashboard, project string, name string, author string) (*v1.DashboardDraft, error) {
+	if entity.Metadata.Name != name {
+		logrus.Debugf("name in dashboard %q and name from the http request %q don't match", entity.Metadata.Name, name)
+		return nil, shared.HandleBadRequestError("metadata.name and the name in the http path request don't match")
+	}
+	if len(entity.Metadata.Project) == 0 {
+		entity.Metadata.Project = project
+	} else if entity.Metadata.Project != project {
+		logrus.Debugf("project in dashboard %q and project from the http request %q don't match", entity.Metadata.Project, project)
+		return nil, shared.HandleBadRequestError("metadata.project and the project name in the http path request don't match")
+	}
+	if err := common.ValidateID(name); err != nil {
+		return nil, shared.HandleBadRequestError(err.Error())
+	}
+	// the draft must be publishable, so it has to pass the same validation as the dashboard.
+	if err := s.validate(entity); err != nil {
+		return nil, err
+	}
+	// the draft is sent to the admission webhooks as the dashboard it will publish, so the policies can't be bypassed.
+	if err := s.admit(admitter, entity); err != nil {
+		return nil, err
+	}
+	oldDraft, err := s.dao.Get(project, name)
+	if err == nil {
+		oldDraft.Metadata.Update(oldDraft.Metadata)
+		// any modification requires a new review
+		oldDraft.Spec.Status = v1.DraftStatusDraft
+		oldDraft.Spec.Reviewer = ""
+		oldDraft.Spec.Author = author
+		oldDraft.Spec.Dashboard = entity.Spec
+		if updateErr := s.dao.Update(oldDraft); updateErr != nil {
+			logrus.WithError(updateErr).Errorf("unable to perform the update of the draft %q, something wrong with the database", name)
+			return nil, updateErr
+		}
+		return oldDraft, nil
+	}
+	if !databaseModel.IsKeyNotFound(err) {
+		return nil, err
+	}
+	newDraft := &v1.DashboardDraft{
+		Kind:     v1.KindDashboardDraft,
+		Metadata: *v1.NewProjectMetadata(project, name),
+		Spec: v1.DashboardDraftSpec{
+			Status:    v1.DraftStatusDraft,
+			Dashboard: entity.Spec,
+			Author:    author,
+		},
+	}
+	published, isPublished, err := s.getPublished(project, name)
+	if err != nil {
+		return nil, err
+	}
+	if isPublished {
+		version := published.Metadata.Version
+		newDraft.Spec.BaseVersion = &version
+		newDraft.Spec.Base = &published.Spec
+	} else if _, projectErr := s.projectDAO.Find(project); projectErr != nil {
+		// The dashboard has never been published, so the project must be verified.
+		if databaseModel.IsKeyNotFound(projectErr) {
+			return nil, shared.HandleBadRequestError(fmt.Sprintf("metadata.project %q doesn't exist", project))
+		}
+		return nil, projectErr
+	}
+	newDraft.Metadata.CreateNow()
+	if createErr := s.dao.Create(newDraft); createErr != nil {
+		return nil, createErr
+	}
+	return newDraft, nil
+}
+
+func (s *service) validate(entity *v1.Dashboard) error {
+	if err := validate.Dashboard(entity, s.sch); err != nil {
+		return shared.HandleBadRequestError(err.Error())
+	}
+	return nil
+}
+
+// admit sends the draft to the admission webhooks as the creation of the dashboard, or as its update when it is
+// already published.
+func (s *service) admit(admitter shared.Admitter, entity *v1.Dashboard) error {
+	published, isPublished, err := s.getPublished(entity.Metadata.Project, entity.Metadata.Name)
+	if err != nil {
+		return err
+	}
+	validateDraft := func() error { return s.validate(entity) }
+	if !isPublished {
+		return admitter.Admit(admission.OperationCreate, entity, nil, validateDraft)
+	}
+	return admitter.Admit(admission.OperationUpdate, entity, published, validateDraft)
+}
+
+func (s *service) Discard(project string, name string) error {
+	return s.dao.Delete(project, name)
+}
+
+func (s *service) Get(project string, name string) (*v1.DashboardDraft, error) {
+	return s.dao.Get(project, name)
+}
+
+func (s *service) List(q *draft.Query) ([]*v1.DashboardDraft, error) {
+	return s.dao.List(q)
+}
+
+func (s *service) Preview(project string, name string) (*v1.Dashboard, error) {
+	entity, err := s.dao.Get(project, name)
+	if err != nil {
+		return nil, err
+	}
+	result, _, err := s.buildDashboard(entity)
+	return result, err
+}
+
+func (s *service) Diff(project string, name string) (*v1.DashboardDraftDiff, error) {
+	entity, err := s.dao.Get(project, name)
+	if err != nil {
+		return nil, err
+	}
+	published, isPublished, err := s.getPublished(project, name)
+	if err != nil {
+		return nil, err
+	}
+	result := &v1.DashboardDraftDiff{
+		Outdated: isOutdated(entity, published, isPublished),
+	}
+	// when the dashboard has never been published, everything in the draft is new.
+	var publishedSpec interface{} = map[string]interface{}{}
+	if isPublished {
+		publishedSpec = published.Spec
+	}
+	changes, err := diff.Compute("spec", publishedSpec, entity.Spec.Dashboard)
+	if err != nil {
+		return nil, err
+	}
+	result.Changes = changes
+	return result, nil
+}
+
+func (s *service) RequestReview(project string, name string) (*v1.DashboardDraft, error) {
+	return s.updateStatus(project, name, v1.DraftStatusDraft, v1.DraftStatusInReview, "")
+}
+
+func (s *service) Approve(project string, name string, reviewer string) (*v1.DashboardDraft, error) {
+	return s.updateStatus(project, name, v1.DraftStatusInReview, v1.DraftStatusApproved, reviewer)
+}
+
+func (s *service) updateStatus(project string, name string, from v1.DraftStatus, to v1.DraftStatus, reviewer string) (*v1.DashboardDraft, error) {
+	entity, err := s.dao.Get(project, name)
+	if err != nil {
+		return nil, err
+	}
+	if entity.Spec.Status != from {
+		return nil, shared.HandleBadRequestError(fmt.Sprintf("the draft is in the status %q, it must be %q to become %q", entity.Spec.Status, from, to))
+	}
+	if to == v1.DraftStatusApproved {
+		if len(entity.Spec.Author) > 0 && entity.Spec.Author == reviewer {
+			return nil, echo.NewHTTPError(http.StatusForbidden, "the author of the draft cannot approve it")
+		}
+		entity.Spec.Reviewer = reviewer
+	}
+	entity.Metadata.Update(entity.Metadata)
+	entity.Spec.Status = to
+	if updateErr := s.dao.Update(entity); updateErr != nil {
+		return nil, updateErr
+	}
+	return entity, nil
+}
+
+func (s *service) Publish(admitter shared.Admitter, project string, name string, q *draft.PublishQuery) (*v1.Dashboard, error) {
+	entity, err := s.dao.Get(project, name)
+	if err != nil {
+		return nil, err
+	}
+	if s.requireReview && entity.Spec.Status != v1.DraftStatusApproved {
+		return nil, shared.HandleBadRequestError("the draft must be approved before being published")
+	}
+	dashboardEntity, err := s.publish(admitter, entity, q)
+	if err != nil {
+		logrus.WithError(err).Errorf("unable to publish the draft of the dashboard %q", name)
+		return nil, err
+	}
+	if deleteErr := s.dao.Delete(project, name); deleteErr != nil {
+		logrus.WithError(deleteErr).Errorf("dashboard %q published, but unable to delete the draft", name)
+		return nil, deleteErr
+	}
+	return dashboardEntity, nil
+}
+
+// publish goes through the dashboard service, so the draft is validated, admitted and published like any other dashboard.
+func (s *service) publish(admitter shared.Admitter, entity *v1.DashboardDraft, q *draft.PublishQuery) (*v1.Dashboard, error) {
+	project, name := entity.Metadata.Project, entity.Metadata.Name
+	published, isPublished, err := s.getPublished(project, name)
+	if err != nil {
+		return nil, err
+	}
+	dashboardEntity := &v1.Dashboard{
+		Kind:     v1.KindDashboard,
+		Metadata: *v1.NewProjectMetadata(project, name),
+		Spec:     entity.Spec.Dashboard,
+	}
+	parameters := shared.Parameters{Project: project, Name: name}
+	if isOutdated(entity, published, isPublished) {
+		switch {
+		case q.Merge && isPublished && entity.Spec.Base != nil:
+			base := &v1.Dashboard{Kind: v1.KindDashboard, Metadata: dashboardEntity.Metadata, Spec: *entity.Spec.Base}
+			return s.dashboardService.Merge(admitter, &v1.DashboardMerge{Base: base, Dashboard: dashboardEntity}, parameters)
+		case q.Merge:
+			return nil, echo.NewHTTPError(http.StatusConflict, fmt.Sprintf("the draft of the dashboard %q cannot be merged, the dashboard it has been created from is unknown", name))
+		case !q.Force:
+			return nil, echo.NewHTTPError(http.StatusConflict, fmt.Sprintf("the dashboard %q has been published since the draft has been created, the draft must be forced or merged", name))
+		}
+	}
+	var result interface{}
+	if isPublished {
+		result, err = s.dashboardService.Update(admitter, dashboardEntity, parameters)
+	} else {
+		result, err = s.dashboardService.Create(admitter, dashboardEntity)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return result.(*v1.Dashboard), nil
+}
+
+// isOutdated returns true when the dashboard has been published since the draft has been created.
+func isOutdated(entity *v1.DashboardDraft, published *v1.Dashboard, isPublished bool) bool {
+	if entity.Spec.BaseVersion == nil {
+		return isPublished
+	}
+	return !isPublished || published.Metadata.Version != *entity.Spec.BaseVersion
+}
+
+// buildDashboard returns the published dashboard with the spec of the draft.
+func (s *service) buildDashboard(entity *v1.DashboardDraft) (*v1.Dashboard, bool, error) {
+	published, isPublished, err := s.getPublished(entity.Metadata.Project, entity.Metadata.Name)
+	if err != nil {
+		return nil, false, err
+	}
+	result := &v1.Dashboard{
+		Kind:     v1.KindDashboard,
+		Metadata: *v1.NewProjectMetadata(entity.Metadata.Project, entity.Metadata.Name),
+		Spec:     entity.Spec.Dashboard,
+	}
+	if isPublished {
+		result.Metadata = published.Metadata
+	}
+	return result, isPublished, nil
+}
+
+// getPublished returns the published dashboard and false if it doesn't exist.
+func (s *service) getPublished(project string, name string) (*v1.Dashboard, bool, error) {
+	published, err := s.dashboardDAO.Get(project, name)
+	if err != nil {
+		if databaseModel.IsKeyNotFound(err) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	return published, true, nil
+}
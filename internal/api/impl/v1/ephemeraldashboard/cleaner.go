@@ -0,0 +1,52 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ephemeraldashboard
+
+import (
+	"context"
+	"time"
+
+	"github.com/perses/common/async"
+	"github.com/perses/perses/internal/api/interface/v1/ephemeraldashboard"
+	"github.com/sirupsen/logrus"
+)
+
+// CleanupInterval is the interval used to look for the expired dashboards.
+const CleanupInterval = time.Minute
+
+// NewCleaner returns the task deleting periodically the expired dashboards.
+func NewCleaner(svc ephemeraldashboard.Service) async.SimpleTask {
+	return &cleaner{svc: svc}
+}
+
+type cleaner struct {
+	async.SimpleTask
+	svc ephemeraldashboard.Service
+}
+
+func (c *cleaner) String() string {
+	return "ephemeral dashboard cleaner"
+}
+
+func (c *cleaner) Execute(ctx context.Context, _ context.CancelFunc) error {
+	select {
+	case <-ctx.Done():
+		logrus.Infof("canceled %s", c.String())
+	default:
+		if err := c.svc.DeleteExpired(); err != nil {
+			logrus.WithError(err).Error("unable to delete the expired ephemeral dashboards")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ephemeraldashboard
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/perses/perses/internal/api/interface/v1/ephemeraldashboard"
+	"github.com/perses/perses/internal/api/shared"
+	databaseModel "github.com/perses/perses/internal/api/shared/database/model"
+	"github.com/perses/perses/internal/api/shared/schemas"
+	"github.com/perses/perses/internal/api/shared/validate"
+	"github.com/perses/perses/pkg/model/api"
+	"github.com/perses/perses/pkg/model/api/admission"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/prometheus/common/model"
+	"github.com/sirupsen/logrus"
+)
+
+type service struct {
+	ephemeraldashboard.Service
+	dao ephemeraldashboard.DAO
+	sch schemas.Schemas
+}
+
+func NewService(dao ephemeraldashboard.DAO, sch schemas.Schemas) ephemeraldashboard.Service {
+	return &service{
+		dao: dao,
+		sch: sch,
+	}
+}
+
+func (s *service) Create(admitter shared.Admitter, entity api.Entity) (interface{}, error) {
+	if dashboardObject, ok := entity.(*v1.EphemeralDashboard); ok {
+		return s.create(admitter, dashboardObject)
+	}
+	return nil, shared.HandleBadRequestError(fmt.Sprintf("wrong entity format, attempting ephemeral dashboard format, received '%T'", entity))
+}
+
+func (s *service) create(admitter shared.Admitter, entity *v1.EphemeralDashboard) (*v1.EphemeralDashboard, error) {
+	// verify this new dashboard passes the validation
+	if err := s.validate(entity); err != nil {
+		return nil, err
+	}
+	if err := admitter.Admit(admission.OperationCreate, entity, nil, func() error { return s.validate(entity) }); err != nil {
+		return nil, err
+	}
+	// Update the time contains in the entity
+	entity.Metadata.CreateNow()
+	setExpiry(entity, entity.Metadata.CreatedAt)
+	if err := s.dao.Create(entity); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
+
+func (s *service) Update(admitter shared.Admitter, entity api.Entity, parameters shared.Parameters) (interface{}, error) {
+	if dashboardObject, ok := entity.(*v1.EphemeralDashboard); ok {
+		return s.update(admitter, dashboardObject, parameters)
+	}
+	return nil, shared.HandleBadRequestError(fmt.Sprintf("wrong entity format, attempting ephemeral dashboard format, received '%T'", entity))
+}
+
+func (s *service) update(admitter shared.Admitter, entity *v1.EphemeralDashboard, parameters shared.Parameters) (*v1.EphemeralDashboard, error) {
+	if entity.Metadata.Name != parameters.Name {
+		logrus.Debugf("name in ephemeral dashboard %q and name from the http request %q don't match", entity.Metadata.Name, parameters.Name)
+		return nil, shared.HandleBadRequestError("metadata.name and the name in the http path request don't match")
+	}
+	if len(entity.Metadata.Project) == 0 {
+		entity.Metadata.Project = parameters.Project
+	} else if entity.Metadata.Project != parameters.Project {
+		logrus.Debugf("project in ephemeral dashboard %q and project from the http request %q don't match", entity.Metadata.Project, parameters.Project)
+		return nil, shared.HandleBadRequestError("metadata.project and the project name in the http path request don't match")
+	}
+	// verify this new dashboard passes the validation
+	if err := s.validate(entity); err != nil {
+		return nil, err
+	}
+	// find the previous version of the dashboard
+	oldEntity, err := s.dao.Get(parameters.Project, parameters.Name)
+	if err != nil {
+		return nil, err
+	}
+	if admissionErr := admitter.Admit(admission.OperationUpdate, entity, oldEntity, func() error { return s.validate(entity) }); admissionErr != nil {
+		return nil, admissionErr
+	}
+	entity.Metadata.Update(oldEntity.Metadata)
+	setExpiry(entity, entity.Metadata.UpdatedAt)
+	if updateErr := s.dao.Update(entity); updateErr != nil {
+		logrus.WithError(updateErr).Errorf("unable to perform the update of the ephemeral dashboard %q, something wrong with the database", entity.Metadata.Name)
+		return nil, updateErr
+	}
+	return entity, nil
+}
+
+func (s *service) Extend(admitter shared.Admitter, extension *v1.EphemeralDashboardExtension, parameters shared.Parameters) (*v1.EphemeralDashboard, error) {
+	oldEntity, err := s.dao.Get(parameters.Project, parameters.Name)
+	if err != nil {
+		return nil, err
+	}
+	// only the expiry changes, the admission webhooks receive the version stored as the old object
+	entity := *oldEntity
+	entity.Metadata.Update(oldEntity.Metadata)
+	expireAt := entity.Spec.ExpireAt
+	if expireAt.Before(entity.Metadata.UpdatedAt) {
+		expireAt = entity.Metadata.UpdatedAt
+	}
+	entity.Spec.ExpireAt = expireAt.Add(time.Duration(extension.TTL))
+	// The TTL is not relevant anymore as the expiry has been explicitly set.
+	entity.Spec.TTL = 0
+	if admissionErr := admitter.Admit(admission.OperationUpdate, &entity, oldEntity, func() error { return s.validate(&entity) }); admissionErr != nil {
+		return nil, admissionErr
+	}
+	if updateErr := s.dao.Update(&entity); updateErr != nil {
+		logrus.WithError(updateErr).Errorf("unable to extend the ephemeral dashboard %q, something wrong with the database", entity.Metadata.Name)
+		return nil, updateErr
+	}
+	return &entity, nil
+}
+
+func (s *service) validate(entity *v1.EphemeralDashboard) error {
+	if err := validate.Dashboard(entity.ToDashboard(), s.sch); err != nil {
+		return shared.HandleBadRequestError(err.Error())
+	}
+	return nil
+}
+
+func (s *service) Delete(admitter shared.Admitter, parameters shared.Parameters) error {
+	getOld := func() (api.Entity, error) { return s.dao.Get(parameters.Project, parameters.Name) }
+	if err := admitter.AdmitDeletion(v1.KindEphemeralDashboard, getOld); err != nil {
+		return err
+	}
+	return s.dao.Delete(parameters.Project, parameters.Name)
+}
+
+func (s *service) DeleteExpired() error {
+	list, err := s.dao.List(&ephemeraldashboard.Query{})
+	if err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	for _, entity := range list {
+		if entity.Spec.ExpireAt.After(now) {
+			continue
+		}
+		if deleteErr := s.dao.Delete(entity.Metadata.Project, entity.Metadata.Name); deleteErr != nil && !databaseModel.IsKeyNotFound(deleteErr) {
+			return deleteErr
+		}
+		logrus.Debugf("ephemeral dashboard %q in the project %q has expired and has been deleted", entity.Metadata.Name, entity.Metadata.Project)
+	}
+	return nil
+}
+
+func (s *service) Get(parameters shared.Parameters) (interface{}, error) {
+	return s.dao.Get(parameters.Project, parameters.Name)
+}
+
+func (s *service) List(q databaseModel.Query, _ shared.Parameters) (interface{}, error) {
+	query, ok := q.(*ephemeraldashboard.Query)
+	if !ok || len(query.ExpireWithin) == 0 {
+		return s.dao.List(q)
+	}
+	within, err := model.ParseDuration(query.ExpireWithin)
+	if err != nil {
+		return nil, shared.HandleBadRequestError(fmt.Sprintf("invalid expire_within: %s", err))
+	}
+	list, err := s.dao.List(q)
+	if err != nil {
+		return nil, err
+	}
+	limit := time.Now().UTC().Add(time.Duration(within))
+	result := make([]*v1.EphemeralDashboard, 0, len(list))
+	for _, entity := range list {
+		if !entity.Spec.ExpireAt.After(limit) {
+			result = append(result, entity)
+		}
+	}
+	return result, nil
+}
+
+// setExpiry computes the expiry of the dashboard from its TTL when it is set.
+func setExpiry(entity *v1.EphemeralDashboard, from time.Time) {
+	if entity.Spec.TTL > 0 {
+		entity.Spec.ExpireAt = from.Add(time.Duration(entity.Spec.TTL))
+	}
+}
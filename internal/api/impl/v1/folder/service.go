@@ -20,6 +20,7 @@ import (
 	"github.com/perses/perses/internal/api/shared"
 	databaseModel "github.com/perses/perses/internal/api/shared/database/model"
 	"github.com/perses/perses/pkg/model/api"
+	"github.com/perses/perses/pkg/model/api/admission"
 	v1 "github.com/perses/perses/pkg/model/api/v1"
 	"github.com/sirupsen/logrus"
 )
@@ -35,14 +36,17 @@ func NewService(dao folder.DAO) folder.Service {
 	}
 }
 
-func (s *service) Create(entity api.Entity) (interface{}, error) {
+func (s *service) Create(admitter shared.Admitter, entity api.Entity) (interface{}, error) {
 	if datasourceObject, ok := entity.(*v1.Folder); ok {
-		return s.create(datasourceObject)
+		return s.create(admitter, datasourceObject)
 	}
 	return nil, shared.HandleBadRequestError(fmt.Sprintf("wrong entity format, attempting Folder format, received '%T'", entity))
 }
 
-func (s *service) create(entity *v1.Folder) (*v1.Folder, error) {
+func (s *service) create(admitter shared.Admitter, entity *v1.Folder) (*v1.Folder, error) {
+	if err := admitter.Admit(admission.OperationCreate, entity, nil, nil); err != nil {
+		return nil, err
+	}
 	// Update the time contains in the entity
 	entity.Metadata.CreateNow()
 	if err := s.dao.Create(entity); err != nil {
@@ -51,14 +55,14 @@ func (s *service) create(entity *v1.Folder) (*v1.Folder, error) {
 	return entity, nil
 }
 
-func (s *service) Update(entity api.Entity, parameters shared.Parameters) (interface{}, error) {
+func (s *service) Update(admitter shared.Admitter, entity api.Entity, parameters shared.Parameters) (interface{}, error) {
 	if FolderObject, ok := entity.(*v1.Folder); ok {
-		return s.update(FolderObject, parameters)
+		return s.update(admitter, FolderObject, parameters)
 	}
 	return nil, shared.HandleBadRequestError(fmt.Sprintf("wrong entity format, attempting Folder format, received '%T'", entity))
 }
 
-func (s *service) update(entity *v1.Folder, parameters shared.Parameters) (*v1.Folder, error) {
+func (s *service) update(admitter shared.Admitter, entity *v1.Folder, parameters shared.Parameters) (*v1.Folder, error) {
 	if entity.Metadata.Name != parameters.Name {
 		logrus.Debugf("name in Folder %q and name from the http request: %q don't match", entity.Metadata.Name, parameters.Name)
 		return nil, shared.HandleBadRequestError("metadata.name and the name in the http path request don't match")
@@ -74,6 +78,9 @@ func (s *service) update(entity *v1.Folder, parameters shared.Parameters) (*v1.F
 	if err != nil {
 		return nil, err
 	}
+	if admissionErr := admitter.Admit(admission.OperationUpdate, entity, oldEntity, nil); admissionErr != nil {
+		return nil, admissionErr
+	}
 	entity.Metadata.Update(oldEntity.Metadata)
 	if updateErr := s.dao.Update(entity); updateErr != nil {
 		logrus.WithError(updateErr).Errorf("unable to perform the update of the Folder %q, something wrong with the database", entity.Metadata.Name)
@@ -82,7 +89,11 @@ func (s *service) update(entity *v1.Folder, parameters shared.Parameters) (*v1.F
 	return entity, nil
 }
 
-func (s *service) Delete(parameters shared.Parameters) error {
+func (s *service) Delete(admitter shared.Admitter, parameters shared.Parameters) error {
+	getOld := func() (api.Entity, error) { return s.dao.Get(parameters.Project, parameters.Name) }
+	if err := admitter.AdmitDeletion(v1.KindFolder, getOld); err != nil {
+		return err
+	}
 	return s.dao.Delete(parameters.Project, parameters.Name)
 }
 
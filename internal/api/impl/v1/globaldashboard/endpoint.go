@@ -0,0 +1,84 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package globaldashboard
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/interface/v1/globaldashboard"
+	"github.com/perses/perses/internal/api/shared"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+type Endpoint struct {
+	toolbox  shared.Toolbox
+	service  globaldashboard.Service
+	readonly bool
+}
+
+func NewEndpoint(service globaldashboard.Service, readonly bool) *Endpoint {
+	return &Endpoint{
+		toolbox:  shared.NewToolBox(service),
+		service:  service,
+		readonly: readonly,
+	}
+}
+
+func (e *Endpoint) RegisterRoutes(g *echo.Group) {
+	group := g.Group(fmt.Sprintf("/%s", shared.PathGlobalDashboard))
+	projectGroup := g.Group(fmt.Sprintf("/%s/:%s/%s", shared.PathProject, shared.ParamProject, shared.PathGlobalDashboard))
+
+	if !e.readonly {
+		group.POST("", e.Create)
+		group.PUT(fmt.Sprintf("/:%s", shared.ParamName), e.Update)
+		group.DELETE(fmt.Sprintf("/:%s", shared.ParamName), e.Delete)
+	}
+	group.GET("", e.List)
+	group.GET(fmt.Sprintf("/:%s", shared.ParamName), e.Get)
+	projectGroup.GET(fmt.Sprintf("/:%s", shared.ParamName), e.GetInProject)
+}
+
+func (e *Endpoint) Create(ctx echo.Context) error {
+	entity := &v1.GlobalDashboard{}
+	return e.toolbox.Create(ctx, entity)
+}
+
+func (e *Endpoint) Update(ctx echo.Context) error {
+	entity := &v1.GlobalDashboard{}
+	return e.toolbox.Update(ctx, entity)
+}
+
+func (e *Endpoint) Delete(ctx echo.Context) error {
+	return e.toolbox.Delete(ctx)
+}
+
+func (e *Endpoint) Get(ctx echo.Context) error {
+	return e.toolbox.Get(ctx)
+}
+
+func (e *Endpoint) List(ctx echo.Context) error {
+	q := &globaldashboard.Query{}
+	return e.toolbox.List(ctx, q)
+}
+
+// GetInProject returns the global dashboard as a dashboard of the project, so it is displayed with the datasources of the project.
+func (e *Endpoint) GetInProject(ctx echo.Context) error {
+	result, err := e.service.GetInProject(shared.GetProjectParameter(ctx), ctx.Param(shared.ParamName))
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,131 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package globaldashboard
+
+import (
+	"fmt"
+
+	"github.com/perses/perses/internal/api/interface/v1/globaldashboard"
+	"github.com/perses/perses/internal/api/interface/v1/project"
+	"github.com/perses/perses/internal/api/shared"
+	databaseModel "github.com/perses/perses/internal/api/shared/database/model"
+	"github.com/perses/perses/internal/api/shared/schemas"
+	"github.com/perses/perses/internal/api/shared/validate"
+	"github.com/perses/perses/pkg/model/api"
+	"github.com/perses/perses/pkg/model/api/admission"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/sirupsen/logrus"
+)
+
+type service struct {
+	globaldashboard.Service
+	dao        globaldashboard.DAO
+	projectDAO project.DAO
+	sch        schemas.Schemas
+}
+
+func NewService(dao globaldashboard.DAO, projectDAO project.DAO, sch schemas.Schemas) globaldashboard.Service {
+	return &service{
+		dao:        dao,
+		projectDAO: projectDAO,
+		sch:        sch,
+	}
+}
+
+func (s *service) Create(admitter shared.Admitter, entity api.Entity) (interface{}, error) {
+	if dashboardObject, ok := entity.(*v1.GlobalDashboard); ok {
+		return s.create(admitter, dashboardObject)
+	}
+	return nil, shared.HandleBadRequestError(fmt.Sprintf("wrong entity format, attempting GlobalDashboard format, received '%T'", entity))
+}
+
+func (s *service) create(admitter shared.Admitter, entity *v1.GlobalDashboard) (*v1.GlobalDashboard, error) {
+	if err := s.validate(entity); err != nil {
+		return nil, err
+	}
+	if err := admitter.Admit(admission.OperationCreate, entity, nil, func() error { return s.validate(entity) }); err != nil {
+		return nil, err
+	}
+	// Update the time contains in the entity
+	entity.Metadata.CreateNow()
+	if err := s.dao.Create(entity); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
+
+func (s *service) Update(admitter shared.Admitter, entity api.Entity, parameters shared.Parameters) (interface{}, error) {
+	if dashboardObject, ok := entity.(*v1.GlobalDashboard); ok {
+		return s.update(admitter, dashboardObject, parameters)
+	}
+	return nil, shared.HandleBadRequestError(fmt.Sprintf("wrong entity format, attempting GlobalDashboard format, received '%T'", entity))
+}
+
+func (s *service) update(admitter shared.Admitter, entity *v1.GlobalDashboard, parameters shared.Parameters) (*v1.GlobalDashboard, error) {
+	if entity.Metadata.Name != parameters.Name {
+		logrus.Debugf("name in GlobalDashboard %q and name from the http request %q don't match", entity.Metadata.Name, parameters.Name)
+		return nil, shared.HandleBadRequestError("metadata.name and the name in the http path request don't match")
+	}
+	if err := s.validate(entity); err != nil {
+		return nil, err
+	}
+	// find the previous version of the GlobalDashboard
+	oldEntity, err := s.dao.Get(parameters.Name)
+	if err != nil {
+		return nil, err
+	}
+	if admissionErr := admitter.Admit(admission.OperationUpdate, entity, oldEntity, func() error { return s.validate(entity) }); admissionErr != nil {
+		return nil, admissionErr
+	}
+	entity.Metadata.Update(oldEntity.Metadata)
+	if updateErr := s.dao.Update(entity); updateErr != nil {
+		logrus.WithError(updateErr).Errorf("unable to perform the update of the GlobalDashboard %q, something wrong with the database", entity.Metadata.Name)
+		return nil, updateErr
+	}
+	return entity, nil
+}
+
+func (s *service) validate(entity *v1.GlobalDashboard) error {
+	if err := validate.GlobalDashboard(entity, s.sch); err != nil {
+		return shared.HandleBadRequestError(err.Error())
+	}
+	return nil
+}
+
+func (s *service) Delete(admitter shared.Admitter, parameters shared.Parameters) error {
+	getOld := func() (api.Entity, error) { return s.dao.Get(parameters.Name) }
+	if err := admitter.AdmitDeletion(v1.KindGlobalDashboard, getOld); err != nil {
+		return err
+	}
+	return s.dao.Delete(parameters.Name)
+}
+
+func (s *service) Get(parameters shared.Parameters) (interface{}, error) {
+	return s.dao.Get(parameters.Name)
+}
+
+func (s *service) List(q databaseModel.Query, _ shared.Parameters) (interface{}, error) {
+	return s.dao.List(q)
+}
+
+func (s *service) GetInProject(projectName string, name string) (*v1.Dashboard, error) {
+	if _, err := s.projectDAO.Find(projectName); err != nil {
+		return nil, err
+	}
+	entity, err := s.dao.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	return entity.InProject(projectName), nil
+}
@@ -17,38 +17,48 @@ import (
 	"fmt"
 
 	"github.com/perses/perses/internal/api/interface/v1/globaldatasource"
+	"github.com/perses/perses/internal/api/interface/v1/globaldatasourceoverride"
+	"github.com/perses/perses/internal/api/interface/v1/project"
 	"github.com/perses/perses/internal/api/shared"
 	databaseModel "github.com/perses/perses/internal/api/shared/database/model"
 	"github.com/perses/perses/internal/api/shared/schemas"
 	"github.com/perses/perses/internal/api/shared/validate"
 	"github.com/perses/perses/pkg/model/api"
+	"github.com/perses/perses/pkg/model/api/admission"
 	v1 "github.com/perses/perses/pkg/model/api/v1"
 	"github.com/sirupsen/logrus"
 )
 
 type service struct {
 	globaldatasource.Service
-	dao globaldatasource.DAO
-	sch schemas.Schemas
+	dao         globaldatasource.DAO
+	overrideDAO globaldatasourceoverride.DAO
+	projectDAO  project.DAO
+	sch         schemas.Schemas
 }
 
-func NewService(dao globaldatasource.DAO, sch schemas.Schemas) globaldatasource.Service {
+func NewService(dao globaldatasource.DAO, overrideDAO globaldatasourceoverride.DAO, projectDAO project.DAO, sch schemas.Schemas) globaldatasource.Service {
 	return &service{
-		dao: dao,
-		sch: sch,
+		dao:         dao,
+		overrideDAO: overrideDAO,
+		projectDAO:  projectDAO,
+		sch:         sch,
 	}
 }
 
-func (s *service) Create(entity api.Entity) (interface{}, error) {
+func (s *service) Create(admitter shared.Admitter, entity api.Entity) (interface{}, error) {
 	if datasourceObject, ok := entity.(*v1.GlobalDatasource); ok {
-		return s.create(datasourceObject)
+		return s.create(admitter, datasourceObject)
 	}
 	return nil, shared.HandleBadRequestError(fmt.Sprintf("wrong entity format, attempting GlobalDatasource format, received '%T'", entity))
 }
 
-func (s *service) create(entity *v1.GlobalDatasource) (*v1.GlobalDatasource, error) {
+func (s *service) create(admitter shared.Admitter, entity *v1.GlobalDatasource) (*v1.GlobalDatasource, error) {
 	if err := s.validate(entity); err != nil {
-		return nil, shared.HandleBadRequestError(err.Error())
+		return nil, err
+	}
+	if err := admitter.Admit(admission.OperationCreate, entity, nil, func() error { return s.validate(entity) }); err != nil {
+		return nil, err
 	}
 	// Update the time contains in the entity
 	entity.Metadata.CreateNow()
@@ -58,16 +68,16 @@ func (s *service) create(entity *v1.GlobalDatasource) (*v1.GlobalDatasource, err
 	return entity, nil
 }
 
-func (s *service) Update(entity api.Entity, parameters shared.Parameters) (interface{}, error) {
+func (s *service) Update(admitter shared.Admitter, entity api.Entity, parameters shared.Parameters) (interface{}, error) {
 	if DatasourceObject, ok := entity.(*v1.GlobalDatasource); ok {
-		return s.update(DatasourceObject, parameters)
+		return s.update(admitter, DatasourceObject, parameters)
 	}
 	return nil, shared.HandleBadRequestError(fmt.Sprintf("wrong entity format, attempting GlobalDatasource format, received '%T'", entity))
 }
 
-func (s *service) update(entity *v1.GlobalDatasource, parameters shared.Parameters) (*v1.GlobalDatasource, error) {
+func (s *service) update(admitter shared.Admitter, entity *v1.GlobalDatasource, parameters shared.Parameters) (*v1.GlobalDatasource, error) {
 	if err := s.validate(entity); err != nil {
-		return nil, shared.HandleBadRequestError(err.Error())
+		return nil, err
 	}
 	if entity.Metadata.Name != parameters.Name {
 		logrus.Debugf("name in Datasource %q and name from the http request %q don't match", entity.Metadata.Name, parameters.Name)
@@ -78,6 +88,9 @@ func (s *service) update(entity *v1.GlobalDatasource, parameters shared.Paramete
 	if err != nil {
 		return nil, err
 	}
+	if admissionErr := admitter.Admit(admission.OperationUpdate, entity, oldEntity, func() error { return s.validate(entity) }); admissionErr != nil {
+		return nil, admissionErr
+	}
 	entity.Metadata.Update(oldEntity.Metadata)
 	if updateErr := s.dao.Update(entity); updateErr != nil {
 		logrus.WithError(updateErr).Errorf("unable to perform the update of the GlobalDatasource %q, something wrong with the database", entity.Metadata.Name)
@@ -86,7 +99,11 @@ func (s *service) update(entity *v1.GlobalDatasource, parameters shared.Paramete
 	return entity, nil
 }
 
-func (s *service) Delete(parameters shared.Parameters) error {
+func (s *service) Delete(admitter shared.Admitter, parameters shared.Parameters) error {
+	getOld := func() (api.Entity, error) { return s.dao.Get(parameters.Name) }
+	if err := admitter.AdmitDeletion(v1.KindGlobalDatasource, getOld); err != nil {
+		return err
+	}
 	return s.dao.Delete(parameters.Name)
 }
 
@@ -103,6 +120,70 @@ func (s *service) List(q databaseModel.Query, _ shared.Parameters) (interface{},
 	return v1.FilterDatasource(dtsQuery.Kind, dtsQuery.Default, dtsList), nil
 }
 
+func (s *service) GetInProject(projectName string, name string) (*v1.GlobalDatasource, error) {
+	projectEntity, err := s.projectDAO.Find(projectName)
+	if err != nil {
+		return nil, err
+	}
+	// the global datasources are only available to the projects of the default organization
+	if projectEntity.GetOrganization() != v1.DefaultOrganization {
+		return nil, &databaseModel.Error{Key: name, Code: databaseModel.ErrorCodeNotFound}
+	}
+	entity, err := s.dao.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	override, err := s.overrideDAO.Get(projectName, name)
+	if err != nil {
+		if databaseModel.IsKeyNotFound(err) {
+			return entity, nil
+		}
+		return nil, err
+	}
+	return s.override(entity, override)
+}
+
+func (s *service) ListInProject(projectName string, q *globaldatasource.Query) ([]*v1.GlobalDatasource, error) {
+	projectEntity, err := s.projectDAO.Find(projectName)
+	if err != nil {
+		return nil, err
+	}
+	if projectEntity.GetOrganization() != v1.DefaultOrganization {
+		return []*v1.GlobalDatasource{}, nil
+	}
+	list, err := s.dao.List(q)
+	if err != nil {
+		return nil, err
+	}
+	overrides, err := s.overrideDAO.List(&globaldatasourceoverride.Query{Project: projectName})
+	if err != nil {
+		return nil, err
+	}
+	overrideMap := make(map[string]*v1.GlobalDatasourceOverride, len(overrides))
+	for _, override := range overrides {
+		overrideMap[override.Metadata.Name] = override
+	}
+	result := make([]*v1.GlobalDatasource, 0, len(list))
+	for _, entity := range list {
+		if override, ok := overrideMap[entity.Metadata.Name]; ok {
+			if entity, err = s.override(entity, override); err != nil {
+				return nil, err
+			}
+		}
+		result = append(result, entity)
+	}
+	return v1.FilterDatasource(q.Kind, q.Default, result), nil
+}
+
+func (s *service) override(entity *v1.GlobalDatasource, override *v1.GlobalDatasourceOverride) (*v1.GlobalDatasource, error) {
+	result, err := entity.Override(override.Spec)
+	if err != nil {
+		logrus.WithError(err).Errorf("unable to apply the override of the project %q on the GlobalDatasource %q", override.Metadata.Project, entity.Metadata.Name)
+		return nil, shared.InternalError
+	}
+	return result, nil
+}
+
 func (s *service) validate(entity *v1.GlobalDatasource) error {
 	var list []*v1.GlobalDatasource
 	if entity.Spec.Default {
@@ -113,5 +194,8 @@ func (s *service) validate(entity *v1.GlobalDatasource) error {
 			return err
 		}
 	}
-	return validate.Datasource(entity, list, s.sch)
+	if err := validate.Datasource(entity, list, s.sch); err != nil {
+		return shared.HandleBadRequestError(err.Error())
+	}
+	return nil
 }
@@ -0,0 +1,139 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package globaldatasourceoverride
+
+import (
+	"fmt"
+
+	"github.com/perses/perses/internal/api/interface/v1/globaldatasource"
+	"github.com/perses/perses/internal/api/interface/v1/globaldatasourceoverride"
+	"github.com/perses/perses/internal/api/shared"
+	databaseModel "github.com/perses/perses/internal/api/shared/database/model"
+	"github.com/perses/perses/internal/api/shared/schemas"
+	"github.com/perses/perses/internal/api/shared/validate"
+	"github.com/perses/perses/pkg/model/api"
+	"github.com/perses/perses/pkg/model/api/admission"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/sirupsen/logrus"
+)
+
+type service struct {
+	globaldatasourceoverride.Service
+	dao                 globaldatasourceoverride.DAO
+	globalDatasourceDAO globaldatasource.DAO
+	sch                 schemas.Schemas
+}
+
+func NewService(dao globaldatasourceoverride.DAO, globalDatasourceDAO globaldatasource.DAO, sch schemas.Schemas) globaldatasourceoverride.Service {
+	return &service{
+		dao:                 dao,
+		globalDatasourceDAO: globalDatasourceDAO,
+		sch:                 sch,
+	}
+}
+
+func (s *service) Create(admitter shared.Admitter, entity api.Entity) (interface{}, error) {
+	if overrideObject, ok := entity.(*v1.GlobalDatasourceOverride); ok {
+		return s.create(admitter, overrideObject)
+	}
+	return nil, shared.HandleBadRequestError(fmt.Sprintf("wrong entity format, attempting GlobalDatasourceOverride format, received '%T'", entity))
+}
+
+func (s *service) create(admitter shared.Admitter, entity *v1.GlobalDatasourceOverride) (*v1.GlobalDatasourceOverride, error) {
+	if err := s.validate(entity); err != nil {
+		return nil, err
+	}
+	if err := admitter.Admit(admission.OperationCreate, entity, nil, func() error { return s.validate(entity) }); err != nil {
+		return nil, err
+	}
+	// Update the time contains in the entity
+	entity.Metadata.CreateNow()
+	if err := s.dao.Create(entity); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
+
+func (s *service) Update(admitter shared.Admitter, entity api.Entity, parameters shared.Parameters) (interface{}, error) {
+	if overrideObject, ok := entity.(*v1.GlobalDatasourceOverride); ok {
+		return s.update(admitter, overrideObject, parameters)
+	}
+	return nil, shared.HandleBadRequestError(fmt.Sprintf("wrong entity format, attempting GlobalDatasourceOverride format, received '%T'", entity))
+}
+
+func (s *service) update(admitter shared.Admitter, entity *v1.GlobalDatasourceOverride, parameters shared.Parameters) (*v1.GlobalDatasourceOverride, error) {
+	if entity.Metadata.Name != parameters.Name {
+		logrus.Debugf("name in GlobalDatasourceOverride %q and name from the http request %q don't match", entity.Metadata.Name, parameters.Name)
+		return nil, shared.HandleBadRequestError("metadata.name and the name in the http path request don't match")
+	}
+	if len(entity.Metadata.Project) == 0 {
+		entity.Metadata.Project = parameters.Project
+	} else if entity.Metadata.Project != parameters.Project {
+		logrus.Debugf("project in GlobalDatasourceOverride %q and project from the http request %q don't match", entity.Metadata.Project, parameters.Project)
+		return nil, shared.HandleBadRequestError("metadata.project and the project name in the http path request don't match")
+	}
+	if err := s.validate(entity); err != nil {
+		return nil, err
+	}
+	// find the previous version of the GlobalDatasourceOverride
+	oldEntity, err := s.dao.Get(parameters.Project, parameters.Name)
+	if err != nil {
+		return nil, err
+	}
+	if admissionErr := admitter.Admit(admission.OperationUpdate, entity, oldEntity, func() error { return s.validate(entity) }); admissionErr != nil {
+		return nil, admissionErr
+	}
+	entity.Metadata.Update(oldEntity.Metadata)
+	if updateErr := s.dao.Update(entity); updateErr != nil {
+		logrus.WithError(updateErr).Errorf("unable to perform the update of the GlobalDatasourceOverride %q, something wrong with the database", entity.Metadata.Name)
+		return nil, updateErr
+	}
+	return entity, nil
+}
+
+func (s *service) Delete(admitter shared.Admitter, parameters shared.Parameters) error {
+	getOld := func() (api.Entity, error) { return s.dao.Get(parameters.Project, parameters.Name) }
+	if err := admitter.AdmitDeletion(v1.KindGlobalDatasourceOverride, getOld); err != nil {
+		return err
+	}
+	return s.dao.Delete(parameters.Project, parameters.Name)
+}
+
+func (s *service) Get(parameters shared.Parameters) (interface{}, error) {
+	return s.dao.Get(parameters.Project, parameters.Name)
+}
+
+func (s *service) List(q databaseModel.Query, _ shared.Parameters) (interface{}, error) {
+	return s.dao.List(q)
+}
+
+// validate checks the override is named after an existing GlobalDatasource, and that the datasource stays valid once
+// the override is merged over it.
+func (s *service) validate(entity *v1.GlobalDatasourceOverride) error {
+	globalDatasource, err := s.globalDatasourceDAO.Get(entity.Metadata.Name)
+	if err != nil {
+		if databaseModel.IsKeyNotFound(err) {
+			return shared.HandleBadRequestError(fmt.Sprintf("the GlobalDatasource %q doesn't exist", entity.Metadata.Name))
+		}
+		return err
+	}
+	merged, err := globalDatasource.Override(entity.Spec)
+	if err != nil {
+		return shared.HandleBadRequestError(err.Error())
+	}
+	if validateErr := validate.Datasource(merged, nil, s.sch); validateErr != nil {
+		return shared.HandleBadRequestError(validateErr.Error())
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package globalhomeconfig
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/interface/v1/dashboard"
+	"github.com/perses/perses/internal/api/interface/v1/globalhomeconfig"
+	"github.com/perses/perses/internal/api/shared"
+	databaseModel "github.com/perses/perses/internal/api/shared/database/model"
+	"github.com/perses/perses/pkg/model/api"
+	"github.com/perses/perses/pkg/model/api/admission"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/sirupsen/logrus"
+)
+
+type service struct {
+	globalhomeconfig.Service
+	dao          globalhomeconfig.DAO
+	dashboardDAO dashboard.DAO
+}
+
+func NewService(dao globalhomeconfig.DAO, dashboardDAO dashboard.DAO) globalhomeconfig.Service {
+	return &service{
+		dao:          dao,
+		dashboardDAO: dashboardDAO,
+	}
+}
+
+func (s *service) Create(admitter shared.Admitter, entity api.Entity) (interface{}, error) {
+	if homeConfigObject, ok := entity.(*v1.GlobalHomeConfig); ok {
+		return s.create(admitter, homeConfigObject)
+	}
+	return nil, shared.HandleBadRequestError(fmt.Sprintf("wrong entity format, attempting GlobalHomeConfig format, received '%T'", entity))
+}
+
+func (s *service) create(admitter shared.Admitter, entity *v1.GlobalHomeConfig) (*v1.GlobalHomeConfig, error) {
+	if err := s.validate(entity); err != nil {
+		return nil, err
+	}
+	if err := admitter.Admit(admission.OperationCreate, entity, nil, func() error { return s.validate(entity) }); err != nil {
+		return nil, err
+	}
+	// Update the time contains in the entity
+	entity.Metadata.CreateNow()
+	if err := s.dao.Create(entity); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
+
+func (s *service) Update(admitter shared.Admitter, entity api.Entity, parameters shared.Parameters) (interface{}, error) {
+	if homeConfigObject, ok := entity.(*v1.GlobalHomeConfig); ok {
+		return s.update(admitter, homeConfigObject, parameters)
+	}
+	return nil, shared.HandleBadRequestError(fmt.Sprintf("wrong entity format, attempting GlobalHomeConfig format, received '%T'", entity))
+}
+
+func (s *service) update(admitter shared.Admitter, entity *v1.GlobalHomeConfig, parameters shared.Parameters) (*v1.GlobalHomeConfig, error) {
+	if entity.Metadata.Name != parameters.Name {
+		logrus.Debugf("name in GlobalHomeConfig %q and name from the http request %q don't match", entity.Metadata.Name, parameters.Name)
+		return nil, shared.HandleBadRequestError("metadata.name and the name in the http path request don't match")
+	}
+	if err := s.validate(entity); err != nil {
+		return nil, err
+	}
+	// find the previous version of the GlobalHomeConfig
+	oldEntity, err := s.dao.Get(parameters.Name)
+	if err != nil {
+		return nil, err
+	}
+	if admissionErr := admitter.Admit(admission.OperationUpdate, entity, oldEntity, func() error { return s.validate(entity) }); admissionErr != nil {
+		return nil, admissionErr
+	}
+	entity.Metadata.Update(oldEntity.Metadata)
+	if updateErr := s.dao.Update(entity); updateErr != nil {
+		logrus.WithError(updateErr).Errorf("unable to perform the update of the GlobalHomeConfig %q, something wrong with the database", entity.Metadata.Name)
+		return nil, updateErr
+	}
+	return entity, nil
+}
+
+// validate verifies there is no other GlobalHomeConfig and that the important dashboards exist.
+func (s *service) validate(entity *v1.GlobalHomeConfig) error {
+	list, err := s.dao.List(&globalhomeconfig.Query{})
+	if err != nil {
+		return err
+	}
+	for _, existing := range list {
+		if existing.Metadata.Name != entity.Metadata.Name {
+			return echo.NewHTTPError(http.StatusConflict, fmt.Sprintf("there is already a GlobalHomeConfig named %q", existing.Metadata.Name))
+		}
+	}
+	for _, selector := range entity.Spec.ImportantDashboards {
+		if _, getErr := s.dashboardDAO.Get(selector.Project, selector.Dashboard); getErr != nil {
+			if databaseModel.IsKeyNotFound(getErr) {
+				return shared.HandleBadRequestError(fmt.Sprintf("dashboard %q doesn't exist in the project %q", selector.Dashboard, selector.Project))
+			}
+			return getErr
+		}
+	}
+	return nil
+}
+
+func (s *service) Delete(admitter shared.Admitter, parameters shared.Parameters) error {
+	getOld := func() (api.Entity, error) { return s.dao.Get(parameters.Name) }
+	if err := admitter.AdmitDeletion(v1.KindGlobalHomeConfig, getOld); err != nil {
+		return err
+	}
+	return s.dao.Delete(parameters.Name)
+}
+
+func (s *service) Get(parameters shared.Parameters) (interface{}, error) {
+	return s.dao.Get(parameters.Name)
+}
+
+func (s *service) List(q databaseModel.Query, _ shared.Parameters) (interface{}, error) {
+	return s.dao.List(q)
+}
@@ -21,6 +21,7 @@ import (
 	databaseModel "github.com/perses/perses/internal/api/shared/database/model"
 	"github.com/perses/perses/internal/api/shared/schemas"
 	"github.com/perses/perses/pkg/model/api"
+	"github.com/perses/perses/pkg/model/api/admission"
 	v1 "github.com/perses/perses/pkg/model/api/v1"
 	"github.com/sirupsen/logrus"
 )
@@ -38,18 +39,20 @@ func NewService(dao globalvariable.DAO, sch schemas.Schemas) globalvariable.Serv
 	}
 }
 
-func (s *service) Create(entity api.Entity) (interface{}, error) {
+func (s *service) Create(admitter shared.Admitter, entity api.Entity) (interface{}, error) {
 	if datasourceObject, ok := entity.(*v1.GlobalVariable); ok {
-		return s.create(datasourceObject)
+		return s.create(admitter, datasourceObject)
 	}
 	return nil, shared.HandleBadRequestError(fmt.Sprintf("wrong entity format, attempting Globalvariable format, received '%T'", entity))
 }
 
-func (s *service) create(entity *v1.GlobalVariable) (*v1.GlobalVariable, error) {
-	if err := s.sch.ValidateGlobalVariable(entity.Spec); err != nil {
-		return nil, shared.HandleBadRequestError(err.Error())
+func (s *service) create(admitter shared.Admitter, entity *v1.GlobalVariable) (*v1.GlobalVariable, error) {
+	if err := s.validate(entity); err != nil {
+		return nil, err
+	}
+	if err := admitter.Admit(admission.OperationCreate, entity, nil, func() error { return s.validate(entity) }); err != nil {
+		return nil, err
 	}
-
 	// Update the time contains in the entity
 	entity.Metadata.CreateNow()
 	if err := s.dao.Create(entity); err != nil {
@@ -58,26 +61,29 @@ func (s *service) create(entity *v1.GlobalVariable) (*v1.GlobalVariable, error)
 	return entity, nil
 }
 
-func (s *service) Update(entity api.Entity, parameters shared.Parameters) (interface{}, error) {
+func (s *service) Update(admitter shared.Admitter, entity api.Entity, parameters shared.Parameters) (interface{}, error) {
 	if DatasourceObject, ok := entity.(*v1.GlobalVariable); ok {
-		return s.update(DatasourceObject, parameters)
+		return s.update(admitter, DatasourceObject, parameters)
 	}
 	return nil, shared.HandleBadRequestError(fmt.Sprintf("wrong entity format, attempting Globalvariable format, received '%T'", entity))
 }
 
-func (s *service) update(entity *v1.GlobalVariable, parameters shared.Parameters) (*v1.GlobalVariable, error) {
+func (s *service) update(admitter shared.Admitter, entity *v1.GlobalVariable, parameters shared.Parameters) (*v1.GlobalVariable, error) {
 	if entity.Metadata.Name != parameters.Name {
 		logrus.Debugf("name in Datasource %q and name from the http request %q don't match", entity.Metadata.Name, parameters.Name)
 		return nil, shared.HandleBadRequestError("metadata.name and the name in the http path request don't match")
 	}
-	if err := s.sch.ValidateGlobalVariable(entity.Spec); err != nil {
-		return nil, shared.HandleBadRequestError(err.Error())
+	if err := s.validate(entity); err != nil {
+		return nil, err
 	}
 	// find the previous version of the Datasource
 	oldEntity, err := s.dao.Get(parameters.Name)
 	if err != nil {
 		return nil, err
 	}
+	if admissionErr := admitter.Admit(admission.OperationUpdate, entity, oldEntity, func() error { return s.validate(entity) }); admissionErr != nil {
+		return nil, admissionErr
+	}
 	entity.Metadata.Update(oldEntity.Metadata)
 	if updateErr := s.dao.Update(entity); updateErr != nil {
 		logrus.WithError(updateErr).Errorf("unable to perform the update of the Globalvariable %q, something wrong with the database", entity.Metadata.Name)
@@ -86,7 +92,18 @@ func (s *service) update(entity *v1.GlobalVariable, parameters shared.Parameters
 	return entity, nil
 }
 
-func (s *service) Delete(parameters shared.Parameters) error {
+func (s *service) validate(entity *v1.GlobalVariable) error {
+	if err := s.sch.ValidateGlobalVariable(entity.Spec); err != nil {
+		return shared.HandleBadRequestError(err.Error())
+	}
+	return nil
+}
+
+func (s *service) Delete(admitter shared.Admitter, parameters shared.Parameters) error {
+	getOld := func() (api.Entity, error) { return s.dao.Get(parameters.Name) }
+	if err := admitter.AdmitDeletion(v1.KindGlobalVariable, getOld); err != nil {
+		return err
+	}
 	return s.dao.Delete(parameters.Name)
 }
 
@@ -0,0 +1,197 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package homeconfig
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/interface/v1/dashboard"
+	"github.com/perses/perses/internal/api/interface/v1/globalhomeconfig"
+	"github.com/perses/perses/internal/api/interface/v1/homeconfig"
+	"github.com/perses/perses/internal/api/shared"
+	databaseModel "github.com/perses/perses/internal/api/shared/database/model"
+	"github.com/perses/perses/pkg/model/api"
+	"github.com/perses/perses/pkg/model/api/admission"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/sirupsen/logrus"
+)
+
+type service struct {
+	homeconfig.Service
+	dao          homeconfig.DAO
+	globalDAO    globalhomeconfig.DAO
+	dashboardDAO dashboard.DAO
+}
+
+func NewService(dao homeconfig.DAO, globalDAO globalhomeconfig.DAO, dashboardDAO dashboard.DAO) homeconfig.Service {
+	return &service{
+		dao:          dao,
+		globalDAO:    globalDAO,
+		dashboardDAO: dashboardDAO,
+	}
+}
+
+func (s *service) Create(admitter shared.Admitter, entity api.Entity) (interface{}, error) {
+	if homeConfigObject, ok := entity.(*v1.HomeConfig); ok {
+		return s.create(admitter, homeConfigObject)
+	}
+	return nil, shared.HandleBadRequestError(fmt.Sprintf("wrong entity format, attempting HomeConfig format, received '%T'", entity))
+}
+
+func (s *service) create(admitter shared.Admitter, entity *v1.HomeConfig) (*v1.HomeConfig, error) {
+	if err := s.validate(entity); err != nil {
+		return nil, err
+	}
+	if err := admitter.Admit(admission.OperationCreate, entity, nil, func() error { return s.validate(entity) }); err != nil {
+		return nil, err
+	}
+	// Update the time contains in the entity
+	entity.Metadata.CreateNow()
+	if err := s.dao.Create(entity); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
+
+func (s *service) Update(admitter shared.Admitter, entity api.Entity, parameters shared.Parameters) (interface{}, error) {
+	if homeConfigObject, ok := entity.(*v1.HomeConfig); ok {
+		return s.update(admitter, homeConfigObject, parameters)
+	}
+	return nil, shared.HandleBadRequestError(fmt.Sprintf("wrong entity format, attempting HomeConfig format, received '%T'", entity))
+}
+
+func (s *service) update(admitter shared.Admitter, entity *v1.HomeConfig, parameters shared.Parameters) (*v1.HomeConfig, error) {
+	if entity.Metadata.Name != parameters.Name {
+		logrus.Debugf("name in HomeConfig %q and name from the http request %q don't match", entity.Metadata.Name, parameters.Name)
+		return nil, shared.HandleBadRequestError("metadata.name and the name in the http path request don't match")
+	}
+	if len(entity.Metadata.Project) == 0 {
+		entity.Metadata.Project = parameters.Project
+	} else if entity.Metadata.Project != parameters.Project {
+		logrus.Debugf("project in HomeConfig %q and project from the http request %q don't match", entity.Metadata.Project, parameters.Project)
+		return nil, shared.HandleBadRequestError("metadata.project and the project name in the http path request don't match")
+	}
+	if err := s.validate(entity); err != nil {
+		return nil, err
+	}
+	// find the previous version of the HomeConfig
+	oldEntity, err := s.dao.Get(parameters.Project, parameters.Name)
+	if err != nil {
+		return nil, err
+	}
+	if admissionErr := admitter.Admit(admission.OperationUpdate, entity, oldEntity, func() error { return s.validate(entity) }); admissionErr != nil {
+		return nil, admissionErr
+	}
+	entity.Metadata.Update(oldEntity.Metadata)
+	if updateErr := s.dao.Update(entity); updateErr != nil {
+		logrus.WithError(updateErr).Errorf("unable to perform the update of the HomeConfig %q, something wrong with the database", entity.Metadata.Name)
+		return nil, updateErr
+	}
+	return entity, nil
+}
+
+// validate verifies there is no other HomeConfig in the project and that the important dashboards exist.
+// The important dashboards without project are set with the project of the HomeConfig.
+func (s *service) validate(entity *v1.HomeConfig) error {
+	list, err := s.dao.List(&homeconfig.Query{Project: entity.Metadata.Project})
+	if err != nil {
+		return err
+	}
+	for _, existing := range list {
+		if existing.Metadata.Name != entity.Metadata.Name {
+			return echo.NewHTTPError(http.StatusConflict, fmt.Sprintf("there is already a HomeConfig named %q in the project %q", existing.Metadata.Name, entity.Metadata.Project))
+		}
+	}
+	for i, selector := range entity.Spec.ImportantDashboards {
+		if len(selector.Project) == 0 {
+			selector.Project = entity.Metadata.Project
+			entity.Spec.ImportantDashboards[i] = selector
+		}
+		if _, getErr := s.dashboardDAO.Get(selector.Project, selector.Dashboard); getErr != nil {
+			if databaseModel.IsKeyNotFound(getErr) {
+				return shared.HandleBadRequestError(fmt.Sprintf("dashboard %q doesn't exist in the project %q", selector.Dashboard, selector.Project))
+			}
+			return getErr
+		}
+	}
+	return nil
+}
+
+func (s *service) Delete(admitter shared.Admitter, parameters shared.Parameters) error {
+	getOld := func() (api.Entity, error) { return s.dao.Get(parameters.Project, parameters.Name) }
+	if err := admitter.AdmitDeletion(v1.KindHomeConfig, getOld); err != nil {
+		return err
+	}
+	return s.dao.Delete(parameters.Project, parameters.Name)
+}
+
+func (s *service) Get(parameters shared.Parameters) (interface{}, error) {
+	return s.dao.Get(parameters.Project, parameters.Name)
+}
+
+func (s *service) List(q databaseModel.Query, _ shared.Parameters) (interface{}, error) {
+	return s.dao.List(q)
+}
+
+func (s *service) GetHome(project string) (*v1.HomeConfigSpec, error) {
+	result := v1.HomeConfigSpec{}
+	globalList, err := s.globalDAO.List(&globalhomeconfig.Query{})
+	if err != nil {
+		return nil, err
+	}
+	if len(globalList) > 0 {
+		result = globalList[0].Spec
+	}
+	if len(project) == 0 {
+		return &result, nil
+	}
+	list, err := s.dao.List(&homeconfig.Query{Project: project})
+	if err != nil {
+		return nil, err
+	}
+	if len(list) > 0 {
+		result = result.Override(list[0].Spec)
+	}
+	return &result, nil
+}
+
+func (s *service) RemoveDashboard(project string, dashboardName string) error {
+	globalList, err := s.globalDAO.List(&globalhomeconfig.Query{})
+	if err != nil {
+		return err
+	}
+	for _, entity := range globalList {
+		if !entity.Spec.RemoveDashboard(project, dashboardName) {
+			continue
+		}
+		if updateErr := s.globalDAO.Update(entity); updateErr != nil {
+			return updateErr
+		}
+	}
+	list, err := s.dao.List(&homeconfig.Query{})
+	if err != nil {
+		return err
+	}
+	for _, entity := range list {
+		if !entity.Spec.RemoveDashboard(project, dashboardName) {
+			continue
+		}
+		if updateErr := s.dao.Update(entity); updateErr != nil {
+			return updateErr
+		}
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package organization
+
+import (
+	"fmt"
+
+	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/interface/v1/organization"
+	"github.com/perses/perses/internal/api/interface/v1/organizationdatasource"
+	"github.com/perses/perses/internal/api/interface/v1/organizationvariable"
+	"github.com/perses/perses/internal/api/interface/v1/project"
+	"github.com/perses/perses/internal/api/shared"
+	databaseModel "github.com/perses/perses/internal/api/shared/database/model"
+	"github.com/perses/perses/pkg/model/api"
+	"github.com/perses/perses/pkg/model/api/admission"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/sirupsen/logrus"
+)
+
+type service struct {
+	organization.Service
+	dao           organization.DAO
+	projectDAO    project.DAO
+	datasourceDAO organizationdatasource.DAO
+	variableDAO   organizationvariable.DAO
+}
+
+func NewService(dao organization.DAO, projectDAO project.DAO, datasourceDAO organizationdatasource.DAO, variableDAO organizationvariable.DAO) organization.Service {
+	return &service{
+		dao:           dao,
+		projectDAO:    projectDAO,
+		datasourceDAO: datasourceDAO,
+		variableDAO:   variableDAO,
+	}
+}
+
+func (s *service) Create(admitter shared.Admitter, entity api.Entity) (interface{}, error) {
+	if object, ok := entity.(*v1.Organization); ok {
+		return s.create(admitter, object)
+	}
+	return nil, shared.HandleBadRequestError(fmt.Sprintf("wrong entity format, attempting Organization format, received '%T'", entity))
+}
+
+func (s *service) create(admitter shared.Admitter, entity *v1.Organization) (*v1.Organization, error) {
+	if err := admitter.Admit(admission.OperationCreate, entity, nil, nil); err != nil {
+		return nil, err
+	}
+	// Update the time contains in the entity
+	entity.Metadata.CreateNow()
+	if err := s.dao.Create(entity); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
+
+func (s *service) Update(admitter shared.Admitter, entity api.Entity, parameters shared.Parameters) (interface{}, error) {
+	if object, ok := entity.(*v1.Organization); ok {
+		return s.update(admitter, object, parameters)
+	}
+	return nil, shared.HandleBadRequestError(fmt.Sprintf("wrong entity format, attempting Organization format, received '%T'", entity))
+}
+
+func (s *service) update(admitter shared.Admitter, entity *v1.Organization, parameters shared.Parameters) (*v1.Organization, error) {
+	if entity.Metadata.Name != parameters.Name {
+		logrus.Debugf("name in Organization %q and name from the http request %q don't match", entity.Metadata.Name, parameters.Name)
+		return nil, shared.HandleBadRequestError("metadata.name and the name in the http path request don't match")
+	}
+	// find the previous version of the Organization
+	oldEntity, err := s.dao.Get(parameters.Name)
+	if err != nil {
+		return nil, err
+	}
+	if admissionErr := admitter.Admit(admission.OperationUpdate, entity, oldEntity, nil); admissionErr != nil {
+		return nil, admissionErr
+	}
+	entity.Metadata.Update(oldEntity.Metadata)
+	if updateErr := s.dao.Update(entity); updateErr != nil {
+		logrus.WithError(updateErr).Errorf("unable to perform the update of the Organization %q, something wrong with the database", entity.Metadata.Name)
+		return nil, updateErr
+	}
+	return entity, nil
+}
+
+func (s *service) Delete(admitter shared.Admitter, parameters shared.Parameters) error {
+	oldEntity, err := s.dao.Get(parameters.Name)
+	if err != nil {
+		return err
+	}
+	if admissionErr := admitter.AdmitDeletion(v1.KindOrganization, func() (api.Entity, error) { return oldEntity, nil }); admissionErr != nil {
+		return admissionErr
+	}
+	projects, err := s.projectDAO.List(&project.Query{Organization: parameters.Name})
+	if err != nil {
+		return err
+	}
+	if len(projects) > 0 {
+		return echo.NewHTTPError(409, fmt.Sprintf("organization %q still contains %d project(s), they must be deleted first", parameters.Name, len(projects)))
+	}
+	if err := s.datasourceDAO.DeleteAll(parameters.Name); err != nil {
+		logrus.WithError(err).Error("unable to delete all datasources of the organization")
+		return err
+	}
+	if err := s.variableDAO.DeleteAll(parameters.Name); err != nil {
+		logrus.WithError(err).Error("unable to delete all variables of the organization")
+		return err
+	}
+	return s.dao.Delete(parameters.Name)
+}
+
+func (s *service) Get(parameters shared.Parameters) (interface{}, error) {
+	return s.dao.Get(parameters.Name)
+}
+
+func (s *service) List(q databaseModel.Query, _ shared.Parameters) (interface{}, error) {
+	return s.dao.List(q)
+}
+
+func (s *service) MigrateProjects() error {
+	if _, err := s.dao.Get(v1.DefaultOrganization); err != nil {
+		if !databaseModel.IsKeyNotFound(err) {
+			return err
+		}
+		defaultOrganization := &v1.Organization{
+			Kind:     v1.KindOrganization,
+			Metadata: v1.Metadata{Name: v1.DefaultOrganization},
+		}
+		if _, createErr := s.create(shared.Admitter{}, defaultOrganization); createErr != nil {
+			return createErr
+		}
+		logrus.Infof("organization %q created", v1.DefaultOrganization)
+	}
+	projects, err := s.projectDAO.List(&project.Query{})
+	if err != nil {
+		return err
+	}
+	for _, entity := range projects {
+		if len(entity.Metadata.Organization) > 0 {
+			continue
+		}
+		entity.Metadata.Organization = v1.DefaultOrganization
+		// the project is written next to the one stored without organization, which doesn't count as another project
+		if updateErr := s.projectDAO.Update(entity); updateErr != nil {
+			return updateErr
+		}
+		// remove the project stored without organization
+		if deleteErr := s.projectDAO.Delete("", entity.Metadata.Name); deleteErr != nil && !databaseModel.IsKeyNotFound(deleteErr) {
+			return deleteErr
+		}
+		logrus.Infof("project %q moved to the organization %q", entity.Metadata.Name, v1.DefaultOrganization)
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package organizationdatasource
+
+import (
+	"fmt"
+
+	"github.com/perses/perses/internal/api/interface/v1/organization"
+	"github.com/perses/perses/internal/api/interface/v1/organizationdatasource"
+	"github.com/perses/perses/internal/api/shared"
+	databaseModel "github.com/perses/perses/internal/api/shared/database/model"
+	"github.com/perses/perses/internal/api/shared/schemas"
+	"github.com/perses/perses/internal/api/shared/validate"
+	"github.com/perses/perses/pkg/model/api"
+	"github.com/perses/perses/pkg/model/api/admission"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/sirupsen/logrus"
+)
+
+type service struct {
+	organizationdatasource.Service
+	dao             organizationdatasource.DAO
+	organizationDAO organization.DAO
+	sch             schemas.Schemas
+}
+
+func NewService(dao organizationdatasource.DAO, organizationDAO organization.DAO, sch schemas.Schemas) organizationdatasource.Service {
+	return &service{
+		dao:             dao,
+		organizationDAO: organizationDAO,
+		sch:             sch,
+	}
+}
+
+func (s *service) Create(admitter shared.Admitter, entity api.Entity) (interface{}, error) {
+	if datasourceObject, ok := entity.(*v1.OrganizationDatasource); ok {
+		return s.create(admitter, datasourceObject)
+	}
+	return nil, shared.HandleBadRequestError(fmt.Sprintf("wrong entity format, attempting OrganizationDatasource format, received '%T'", entity))
+}
+
+func (s *service) create(admitter shared.Admitter, entity *v1.OrganizationDatasource) (*v1.OrganizationDatasource, error) {
+	if _, err := s.organizationDAO.Get(entity.Metadata.Organization); err != nil {
+		if databaseModel.IsKeyNotFound(err) {
+			return nil, shared.HandleBadRequestError(fmt.Sprintf("metadata.organization %q doesn't exist", entity.Metadata.Organization))
+		}
+		return nil, err
+	}
+	if err := s.validate(entity); err != nil {
+		return nil, err
+	}
+	if err := admitter.Admit(admission.OperationCreate, entity, nil, func() error { return s.validate(entity) }); err != nil {
+		return nil, err
+	}
+	// Update the time contains in the entity
+	entity.Metadata.CreateNow()
+	if err := s.dao.Create(entity); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
+
+func (s *service) Update(admitter shared.Admitter, entity api.Entity, parameters shared.Parameters) (interface{}, error) {
+	if datasourceObject, ok := entity.(*v1.OrganizationDatasource); ok {
+		return s.update(admitter, datasourceObject, parameters)
+	}
+	return nil, shared.HandleBadRequestError(fmt.Sprintf("wrong entity format, attempting OrganizationDatasource format, received '%T'", entity))
+}
+
+func (s *service) update(admitter shared.Admitter, entity *v1.OrganizationDatasource, parameters shared.Parameters) (*v1.OrganizationDatasource, error) {
+	if entity.Metadata.Name != parameters.Name {
+		logrus.Debugf("name in OrganizationDatasource %q and name from the http request %q don't match", entity.Metadata.Name, parameters.Name)
+		return nil, shared.HandleBadRequestError("metadata.name and the name in the http path request don't match")
+	}
+	if len(entity.Metadata.Organization) == 0 {
+		entity.Metadata.Organization = parameters.Organization
+	} else if entity.Metadata.Organization != parameters.Organization {
+		logrus.Debugf("organization in datasource %q and organization from the http request %q don't match", entity.Metadata.Organization, parameters.Organization)
+		return nil, shared.HandleBadRequestError("metadata.organization and the organization name in the http path request don't match")
+	}
+	if err := s.validate(entity); err != nil {
+		return nil, err
+	}
+	// find the previous version of the Datasource
+	oldEntity, err := s.dao.Get(parameters.Organization, parameters.Name)
+	if err != nil {
+		return nil, err
+	}
+	if admissionErr := admitter.Admit(admission.OperationUpdate, entity, oldEntity, func() error { return s.validate(entity) }); admissionErr != nil {
+		return nil, admissionErr
+	}
+	entity.Metadata.Update(oldEntity.Metadata)
+	if updateErr := s.dao.Update(entity); updateErr != nil {
+		logrus.WithError(updateErr).Errorf("unable to perform the update of the OrganizationDatasource %q, something wrong with the database", entity.Metadata.Name)
+		return nil, updateErr
+	}
+	return entity, nil
+}
+
+func (s *service) Delete(admitter shared.Admitter, parameters shared.Parameters) error {
+	getOld := func() (api.Entity, error) { return s.dao.Get(parameters.Organization, parameters.Name) }
+	if err := admitter.AdmitDeletion(v1.KindOrganizationDatasource, getOld); err != nil {
+		return err
+	}
+	return s.dao.Delete(parameters.Organization, parameters.Name)
+}
+
+func (s *service) Get(parameters shared.Parameters) (interface{}, error) {
+	return s.dao.Get(parameters.Organization, parameters.Name)
+}
+
+func (s *service) List(q databaseModel.Query, _ shared.Parameters) (interface{}, error) {
+	dtsList, err := s.dao.List(q)
+	if err != nil {
+		return nil, err
+	}
+	dtsQuery := q.(*organizationdatasource.Query)
+	return v1.FilterDatasource(dtsQuery.Kind, dtsQuery.Default, dtsList), nil
+}
+
+func (s *service) validate(entity *v1.OrganizationDatasource) error {
+	var list []*v1.OrganizationDatasource
+	if entity.Spec.Default {
+		var err error
+		list, err = s.dao.List(&organizationdatasource.Query{Organization: entity.Metadata.Organization})
+		if err != nil {
+			logrus.WithError(err).Errorf("unable to get the list of the datasources of the organization %q", entity.Metadata.Organization)
+			return err
+		}
+	}
+	if err := validate.Datasource(entity, list, s.sch); err != nil {
+		return shared.HandleBadRequestError(err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package playlist
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/interface/v1/playlist"
+	"github.com/perses/perses/internal/api/shared"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+type Endpoint struct {
+	toolbox  shared.Toolbox
+	service  playlist.Service
+	readonly bool
+}
+
+func NewEndpoint(service playlist.Service, readonly bool) *Endpoint {
+	return &Endpoint{
+		toolbox:  shared.NewToolBox(service),
+		service:  service,
+		readonly: readonly,
+	}
+}
+
+func (e *Endpoint) RegisterRoutes(g *echo.Group) {
+	group := g.Group(fmt.Sprintf("/%s", shared.PathPlaylist))
+	subGroup := g.Group(fmt.Sprintf("/%s/:%s/%s", shared.PathProject, shared.ParamProject, shared.PathPlaylist))
+	if !e.readonly {
+		group.POST("", e.Create)
+		subGroup.POST("", e.Create)
+		subGroup.PUT(fmt.Sprintf("/:%s", shared.ParamName), e.Update)
+		subGroup.DELETE(fmt.Sprintf("/:%s", shared.ParamName), e.Delete)
+	}
+	group.GET("", e.List)
+	subGroup.GET("", e.List)
+	subGroup.GET(fmt.Sprintf("/:%s", shared.ParamName), e.Get)
+	subGroup.GET(fmt.Sprintf("/:%s/dashboards", shared.ParamName), e.Resolve)
+}
+
+func (e *Endpoint) Create(ctx echo.Context) error {
+	entity := &v1.Playlist{}
+	return e.toolbox.Create(ctx, entity)
+}
+
+func (e *Endpoint) Update(ctx echo.Context) error {
+	entity := &v1.Playlist{}
+	return e.toolbox.Update(ctx, entity)
+}
+
+func (e *Endpoint) Delete(ctx echo.Context) error {
+	return e.toolbox.Delete(ctx)
+}
+
+func (e *Endpoint) Get(ctx echo.Context) error {
+	return e.toolbox.Get(ctx)
+}
+
+func (e *Endpoint) List(ctx echo.Context) error {
+	q := &playlist.Query{}
+	return e.toolbox.List(ctx, q)
+}
+
+// Resolve returns the dashboards to display in order.
+func (e *Endpoint) Resolve(ctx echo.Context) error {
+	result, err := e.service.Resolve(shared.GetProjectParameter(ctx), ctx.Param(shared.ParamName))
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,174 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package playlist
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/perses/perses/internal/api/interface/v1/dashboard"
+	"github.com/perses/perses/internal/api/interface/v1/playlist"
+	"github.com/perses/perses/internal/api/shared"
+	databaseModel "github.com/perses/perses/internal/api/shared/database/model"
+	"github.com/perses/perses/pkg/model/api"
+	"github.com/perses/perses/pkg/model/api/admission"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/sirupsen/logrus"
+)
+
+type service struct {
+	playlist.Service
+	dao          playlist.DAO
+	dashboardDAO dashboard.DAO
+}
+
+func NewService(dao playlist.DAO, dashboardDAO dashboard.DAO) playlist.Service {
+	return &service{
+		dao:          dao,
+		dashboardDAO: dashboardDAO,
+	}
+}
+
+func (s *service) Create(admitter shared.Admitter, entity api.Entity) (interface{}, error) {
+	if playlistObject, ok := entity.(*v1.Playlist); ok {
+		return s.create(admitter, playlistObject)
+	}
+	return nil, shared.HandleBadRequestError(fmt.Sprintf("wrong entity format, attempting playlist format, received '%T'", entity))
+}
+
+func (s *service) create(admitter shared.Admitter, entity *v1.Playlist) (*v1.Playlist, error) {
+	if err := s.validate(entity); err != nil {
+		return nil, err
+	}
+	if err := admitter.Admit(admission.OperationCreate, entity, nil, func() error { return s.validate(entity) }); err != nil {
+		return nil, err
+	}
+	// Update the time contains in the entity
+	entity.Metadata.CreateNow()
+	if err := s.dao.Create(entity); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
+
+func (s *service) Update(admitter shared.Admitter, entity api.Entity, parameters shared.Parameters) (interface{}, error) {
+	if playlistObject, ok := entity.(*v1.Playlist); ok {
+		return s.update(admitter, playlistObject, parameters)
+	}
+	return nil, shared.HandleBadRequestError(fmt.Sprintf("wrong entity format, attempting playlist format, received '%T'", entity))
+}
+
+func (s *service) update(admitter shared.Admitter, entity *v1.Playlist, parameters shared.Parameters) (*v1.Playlist, error) {
+	if entity.Metadata.Name != parameters.Name {
+		logrus.Debugf("name in playlist %q and name from the http request %q don't match", entity.Metadata.Name, parameters.Name)
+		return nil, shared.HandleBadRequestError("metadata.name and the name in the http path request don't match")
+	}
+	if len(entity.Metadata.Project) == 0 {
+		entity.Metadata.Project = parameters.Project
+	} else if entity.Metadata.Project != parameters.Project {
+		logrus.Debugf("project in playlist %q and project from the http request %q don't match", entity.Metadata.Project, parameters.Project)
+		return nil, shared.HandleBadRequestError("metadata.project and the project name in the http path request don't match")
+	}
+	if err := s.validate(entity); err != nil {
+		return nil, err
+	}
+	// find the previous version of the playlist
+	oldEntity, err := s.dao.Get(parameters.Project, parameters.Name)
+	if err != nil {
+		return nil, err
+	}
+	if admissionErr := admitter.Admit(admission.OperationUpdate, entity, oldEntity, func() error { return s.validate(entity) }); admissionErr != nil {
+		return nil, admissionErr
+	}
+	entity.Metadata.Update(oldEntity.Metadata)
+	if updateErr := s.dao.Update(entity); updateErr != nil {
+		logrus.WithError(updateErr).Errorf("unable to perform the update of the playlist %q, something wrong with the database", entity.Metadata.Name)
+		return nil, updateErr
+	}
+	return entity, nil
+}
+
+// validate verifies the dashboards referenced by name exist in the project.
+func (s *service) validate(entity *v1.Playlist) error {
+	for _, item := range entity.Spec.Items {
+		if len(item.Dashboard) == 0 {
+			continue
+		}
+		if _, err := s.dashboardDAO.Get(entity.Metadata.Project, item.Dashboard); err != nil {
+			if databaseModel.IsKeyNotFound(err) {
+				return shared.HandleBadRequestError(fmt.Sprintf("dashboard %q doesn't exist in the project %q", item.Dashboard, entity.Metadata.Project))
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *service) Delete(admitter shared.Admitter, parameters shared.Parameters) error {
+	getOld := func() (api.Entity, error) { return s.dao.Get(parameters.Project, parameters.Name) }
+	if err := admitter.AdmitDeletion(v1.KindPlaylist, getOld); err != nil {
+		return err
+	}
+	return s.dao.Delete(parameters.Project, parameters.Name)
+}
+
+func (s *service) Get(parameters shared.Parameters) (interface{}, error) {
+	return s.dao.Get(parameters.Project, parameters.Name)
+}
+
+func (s *service) List(q databaseModel.Query, _ shared.Parameters) (interface{}, error) {
+	return s.dao.List(q)
+}
+
+func (s *service) Resolve(project string, name string) ([]v1.PlaylistEntry, error) {
+	entity, err := s.dao.Get(project, name)
+	if err != nil {
+		return nil, err
+	}
+	dashboards, err := s.dashboardDAO.List(&dashboard.Query{Project: project})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(dashboards, func(i, j int) bool {
+		return dashboards[i].Metadata.Name < dashboards[j].Metadata.Name
+	})
+	result := make([]v1.PlaylistEntry, 0, len(entity.Spec.Items))
+	for _, item := range entity.Spec.Items {
+		interval := entity.Spec.GetInterval(item)
+		for _, dash := range dashboards {
+			if !matchItem(item, dash) {
+				continue
+			}
+			result = append(result, v1.PlaylistEntry{
+				Dashboard: dash.Metadata.Name,
+				Interval:  interval,
+				Variables: item.Variables,
+			})
+		}
+	}
+	return result, nil
+}
+
+// matchItem returns true when the dashboard is selected by the item. A dashboard deleted since the playlist has been
+// saved is simply skipped.
+func matchItem(item v1.PlaylistItem, dash *v1.Dashboard) bool {
+	switch {
+	case len(item.Dashboard) > 0:
+		return dash.Metadata.Name == item.Dashboard
+	case len(item.Tag) > 0:
+		return dash.Spec.HasTag(item.Tag)
+	default:
+		return dash.Metadata.MatchLabels(item.Labels)
+	}
+}
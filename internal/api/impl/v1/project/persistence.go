@@ -40,13 +40,26 @@ func (d *dao) Update(entity *v1.Project) error {
 	return d.client.Upsert(entity)
 }
 
-func (d *dao) Get(name string) (*v1.Project, error) {
+func (d *dao) Get(organization string, name string) (*v1.Project, error) {
 	entity := &v1.Project{}
-	return entity, d.client.Get(d.kind, v1.NewMetadata(name), entity)
+	return entity, d.client.Get(d.kind, v1.NewOrganizationMetadata(organization, name), entity)
 }
 
-func (d *dao) Delete(name string) error {
-	return d.client.Delete(d.kind, v1.NewMetadata(name))
+func (d *dao) Find(name string) (*v1.Project, error) {
+	list, err := d.List(&project.Query{NamePrefix: name})
+	if err != nil {
+		return nil, err
+	}
+	for _, entity := range list {
+		if entity.Metadata.Name == name {
+			return entity, nil
+		}
+	}
+	return nil, &databaseModel.Error{Key: name, Code: databaseModel.ErrorCodeNotFound}
+}
+
+func (d *dao) Delete(organization string, name string) error {
+	return d.client.Delete(d.kind, v1.NewOrganizationMetadata(organization, name))
 }
 
 func (d *dao) List(q databaseModel.Query) ([]*v1.Project, error) {
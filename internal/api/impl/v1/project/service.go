@@ -15,71 +15,133 @@ package project
 
 import (
 	"fmt"
+	"net/http"
 
+	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/interface/v1/access"
 	"github.com/perses/perses/internal/api/interface/v1/dashboard"
+	"github.com/perses/perses/internal/api/interface/v1/dashboardalias"
 	"github.com/perses/perses/internal/api/interface/v1/datasource"
+	"github.com/perses/perses/internal/api/interface/v1/draft"
+	"github.com/perses/perses/internal/api/interface/v1/ephemeraldashboard"
 	"github.com/perses/perses/internal/api/interface/v1/folder"
+	"github.com/perses/perses/internal/api/interface/v1/globaldatasourceoverride"
+	"github.com/perses/perses/internal/api/interface/v1/homeconfig"
+	"github.com/perses/perses/internal/api/interface/v1/organization"
+	"github.com/perses/perses/internal/api/interface/v1/playlist"
 	"github.com/perses/perses/internal/api/interface/v1/project"
+	"github.com/perses/perses/internal/api/interface/v1/savedview"
+	"github.com/perses/perses/internal/api/interface/v1/sharetoken"
+	"github.com/perses/perses/internal/api/interface/v1/usage"
 	"github.com/perses/perses/internal/api/interface/v1/variable"
 	"github.com/perses/perses/internal/api/shared"
 	databaseModel "github.com/perses/perses/internal/api/shared/database/model"
 	"github.com/perses/perses/pkg/model/api"
+	"github.com/perses/perses/pkg/model/api/admission"
 	v1 "github.com/perses/perses/pkg/model/api/v1"
 	"github.com/sirupsen/logrus"
 )
 
 type service struct {
 	project.Service
-	dao           project.DAO
-	folderDAO     folder.DAO
-	datasourceDAO datasource.DAO
-	dashboardDAO  dashboard.DAO
-	variableDAO   variable.DAO
+	dao               project.DAO
+	organizationDAO   organization.DAO
+	folderDAO         folder.DAO
+	datasourceDAO     datasource.DAO
+	dashboardDAO      dashboard.DAO
+	aliasDAO          dashboardalias.DAO
+	variableDAO       variable.DAO
+	draftDAO          draft.DAO
+	ephemeralDAO      ephemeraldashboard.DAO
+	usageDAO          usage.DAO
+	accessDAO         access.DAO
+	shareTokenDAO     sharetoken.DAO
+	playlistDAO       playlist.DAO
+	savedViewDAO      savedview.DAO
+	homeConfigDAO     homeconfig.DAO
+	overrideDAO       globaldatasourceoverride.DAO
+	homeConfigService homeconfig.Service
 }
 
-func NewService(dao project.DAO, folderDAO folder.DAO, datasourceDAO datasource.DAO, dashboardDAO dashboard.DAO, variableDAO variable.DAO) project.Service {
+func NewService(dao project.DAO, organizationDAO organization.DAO, folderDAO folder.DAO, datasourceDAO datasource.DAO, dashboardDAO dashboard.DAO, aliasDAO dashboardalias.DAO, variableDAO variable.DAO, draftDAO draft.DAO, ephemeralDAO ephemeraldashboard.DAO, usageDAO usage.DAO, accessDAO access.DAO, shareTokenDAO sharetoken.DAO, playlistDAO playlist.DAO, savedViewDAO savedview.DAO, homeConfigDAO homeconfig.DAO, overrideDAO globaldatasourceoverride.DAO, homeConfigService homeconfig.Service) project.Service {
 	return &service{
-		dao:           dao,
-		folderDAO:     folderDAO,
-		datasourceDAO: datasourceDAO,
-		dashboardDAO:  dashboardDAO,
-		variableDAO:   variableDAO,
+		dao:               dao,
+		organizationDAO:   organizationDAO,
+		folderDAO:         folderDAO,
+		datasourceDAO:     datasourceDAO,
+		dashboardDAO:      dashboardDAO,
+		aliasDAO:          aliasDAO,
+		variableDAO:       variableDAO,
+		draftDAO:          draftDAO,
+		ephemeralDAO:      ephemeralDAO,
+		usageDAO:          usageDAO,
+		accessDAO:         accessDAO,
+		shareTokenDAO:     shareTokenDAO,
+		playlistDAO:       playlistDAO,
+		savedViewDAO:      savedViewDAO,
+		homeConfigDAO:     homeConfigDAO,
+		overrideDAO:       overrideDAO,
+		homeConfigService: homeConfigService,
 	}
 }
 
-func (s *service) Create(entity api.Entity) (interface{}, error) {
+func (s *service) Create(admitter shared.Admitter, entity api.Entity) (interface{}, error) {
 	if projectObject, ok := entity.(*v1.Project); ok {
-		return s.create(projectObject)
+		return s.create(admitter, projectObject)
 	}
 	return nil, shared.HandleBadRequestError(fmt.Sprintf("wrong entity format, attempting project format, received '%T'", entity))
 }
 
-func (s *service) create(entity *v1.Project) (*v1.Project, error) {
+func (s *service) create(admitter shared.Admitter, entity *v1.Project) (*v1.Project, error) {
+	if len(entity.Metadata.Organization) == 0 {
+		entity.Metadata.Organization = v1.DefaultOrganization
+	}
+	if _, err := s.organizationDAO.Get(entity.Metadata.Organization); err != nil {
+		if databaseModel.IsKeyNotFound(err) {
+			return nil, shared.HandleBadRequestError(fmt.Sprintf("metadata.organization %q doesn't exist", entity.Metadata.Organization))
+		}
+		return nil, err
+	}
+	if err := admitter.Admit(admission.OperationCreate, entity, nil, nil); err != nil {
+		return nil, err
+	}
 	// Update the time contains in the entity
 	entity.Metadata.CreateNow()
+	// The database rejects a project having the same name as a project of another organization.
 	if err := s.dao.Create(entity); err != nil {
+		if databaseModel.IsKeyConflict(err) {
+			return nil, echo.NewHTTPError(http.StatusConflict, fmt.Sprintf("project %q already exists", entity.Metadata.Name))
+		}
 		return nil, err
 	}
 	return entity, nil
 }
 
-func (s *service) Update(entity api.Entity, parameters shared.Parameters) (interface{}, error) {
+func (s *service) Update(admitter shared.Admitter, entity api.Entity, parameters shared.Parameters) (interface{}, error) {
 	if projectObject, ok := entity.(*v1.Project); ok {
-		return s.update(projectObject, parameters)
+		return s.update(admitter, projectObject, parameters)
 	}
 	return nil, shared.HandleBadRequestError(fmt.Sprintf("wrong entity format, attempting project format, received '%T'", entity))
 }
 
-func (s *service) update(entity *v1.Project, parameters shared.Parameters) (*v1.Project, error) {
+func (s *service) update(admitter shared.Admitter, entity *v1.Project, parameters shared.Parameters) (*v1.Project, error) {
 	if entity.Metadata.Name != parameters.Name {
 		logrus.Debugf("name in project %q and name from the http request: %q don't match", entity.Metadata.Name, parameters.Name)
 		return nil, shared.HandleBadRequestError("metadata.name and the name in the http path request don't match")
 	}
 	// find the previous version of the project
-	oldEntity, err := s.dao.Get(parameters.Name)
+	oldEntity, err := s.get(parameters)
 	if err != nil {
 		return nil, err
 	}
+	if len(entity.Metadata.Organization) == 0 {
+		entity.Metadata.Organization = oldEntity.Metadata.Organization
+	} else if entity.Metadata.Organization != oldEntity.Metadata.Organization {
+		return nil, shared.HandleBadRequestError("a project cannot be moved to another organization")
+	}
+	if admissionErr := admitter.Admit(admission.OperationUpdate, entity, oldEntity, nil); admissionErr != nil {
+		return nil, admissionErr
+	}
 	entity.Metadata.Update(oldEntity.Metadata)
 	if updateErr := s.dao.Update(entity); updateErr != nil {
 		logrus.WithError(updateErr).Errorf("unable to perform the update of the project %q, something wrong with the database", entity.Metadata.Name)
@@ -88,8 +150,15 @@ func (s *service) update(entity *v1.Project, parameters shared.Parameters) (*v1.
 	return entity, nil
 }
 
-func (s *service) Delete(parameters shared.Parameters) error {
-	projectName := parameters.Name
+func (s *service) Delete(admitter shared.Admitter, parameters shared.Parameters) error {
+	entity, err := s.get(parameters)
+	if err != nil {
+		return err
+	}
+	if admissionErr := admitter.AdmitDeletion(v1.KindProject, func() (api.Entity, error) { return entity, nil }); admissionErr != nil {
+		return admissionErr
+	}
+	projectName := entity.Metadata.Name
 	if err := s.folderDAO.DeleteAll(projectName); err != nil {
 		logrus.WithError(err).Error("unable to delete all folders")
 		return err
@@ -106,11 +175,65 @@ func (s *service) Delete(parameters shared.Parameters) error {
 		logrus.WithError(err).Error("unable to delete all variables")
 		return err
 	}
-	return s.dao.Delete(parameters.Name)
+	if err := s.draftDAO.DeleteAll(projectName); err != nil {
+		logrus.WithError(err).Error("unable to delete all drafts")
+		return err
+	}
+	if err := s.ephemeralDAO.DeleteAll(projectName); err != nil {
+		logrus.WithError(err).Error("unable to delete all ephemeral dashboards")
+		return err
+	}
+	if err := s.aliasDAO.DeleteAll(projectName); err != nil {
+		logrus.WithError(err).Error("unable to delete the aliases of the dashboards")
+		return err
+	}
+	if err := s.usageDAO.DeleteAll(projectName); err != nil {
+		logrus.WithError(err).Error("unable to delete the usage of the dashboards")
+		return err
+	}
+	if err := s.accessDAO.DeleteAll(projectName); err != nil {
+		logrus.WithError(err).Error("unable to delete the grants of the dashboards")
+		return err
+	}
+	if err := s.shareTokenDAO.DeleteAll(projectName); err != nil {
+		logrus.WithError(err).Error("unable to delete the share tokens of the dashboards")
+		return err
+	}
+	if err := s.playlistDAO.DeleteAll(projectName); err != nil {
+		logrus.WithError(err).Error("unable to delete all playlists")
+		return err
+	}
+	if err := s.savedViewDAO.DeleteAll(projectName); err != nil {
+		logrus.WithError(err).Error("unable to delete all saved views")
+		return err
+	}
+	if err := s.homeConfigDAO.DeleteAll(projectName); err != nil {
+		logrus.WithError(err).Error("unable to delete the home config")
+		return err
+	}
+	if err := s.overrideDAO.DeleteAll(projectName); err != nil {
+		logrus.WithError(err).Error("unable to delete the overrides of the global datasources")
+		return err
+	}
+	// the global home config can reference the dashboards of the project
+	if err := s.homeConfigService.RemoveDashboard(projectName, ""); err != nil {
+		logrus.WithError(err).Error("unable to remove the dashboards of the project from the home page")
+		return err
+	}
+	return s.dao.Delete(entity.Metadata.Organization, projectName)
 }
 
 func (s *service) Get(parameters shared.Parameters) (interface{}, error) {
-	return s.dao.Get(parameters.Name)
+	return s.get(parameters)
+}
+
+// get returns the project from the given organization.
+// When the organization is not in the parameters, the project is searched in every organization.
+func (s *service) get(parameters shared.Parameters) (*v1.Project, error) {
+	if len(parameters.Organization) == 0 {
+		return s.dao.Find(parameters.Name)
+	}
+	return s.dao.Get(parameters.Organization, parameters.Name)
 }
 
 func (s *service) List(q databaseModel.Query, _ shared.Parameters) (interface{}, error) {
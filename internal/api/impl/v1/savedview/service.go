@@ -0,0 +1,212 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package savedview
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/perses/perses/internal/api/interface/v1/dashboard"
+	"github.com/perses/perses/internal/api/interface/v1/savedview"
+	"github.com/perses/perses/internal/api/shared"
+	databaseModel "github.com/perses/perses/internal/api/shared/database/model"
+	"github.com/perses/perses/pkg/model/api"
+	"github.com/perses/perses/pkg/model/api/admission"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/sirupsen/logrus"
+)
+
+type service struct {
+	savedview.Service
+	dao          savedview.DAO
+	dashboardDAO dashboard.DAO
+}
+
+func NewService(dao savedview.DAO, dashboardDAO dashboard.DAO) savedview.Service {
+	return &service{
+		dao:          dao,
+		dashboardDAO: dashboardDAO,
+	}
+}
+
+func (s *service) Create(admitter shared.Admitter, entity api.Entity) (interface{}, error) {
+	if savedViewObject, ok := entity.(*v1.SavedView); ok {
+		return s.create(admitter, savedViewObject)
+	}
+	return nil, shared.HandleBadRequestError(fmt.Sprintf("wrong entity format, attempting saved view format, received '%T'", entity))
+}
+
+func (s *service) create(admitter shared.Admitter, entity *v1.SavedView) (*v1.SavedView, error) {
+	if err := s.validate(entity); err != nil {
+		return nil, err
+	}
+	// The status is computed each time the view is read, it is never stored.
+	entity.Status = nil
+	if err := admitter.Admit(admission.OperationCreate, entity, nil, func() error { return s.validate(entity) }); err != nil {
+		return nil, err
+	}
+	// Update the time contains in the entity
+	entity.Metadata.CreateNow()
+	if err := s.dao.Create(entity); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
+
+func (s *service) Update(admitter shared.Admitter, entity api.Entity, parameters shared.Parameters) (interface{}, error) {
+	if savedViewObject, ok := entity.(*v1.SavedView); ok {
+		return s.update(admitter, savedViewObject, parameters)
+	}
+	return nil, shared.HandleBadRequestError(fmt.Sprintf("wrong entity format, attempting saved view format, received '%T'", entity))
+}
+
+func (s *service) update(admitter shared.Admitter, entity *v1.SavedView, parameters shared.Parameters) (*v1.SavedView, error) {
+	if entity.Metadata.Name != parameters.Name {
+		logrus.Debugf("name in saved view %q and name from the http request %q don't match", entity.Metadata.Name, parameters.Name)
+		return nil, shared.HandleBadRequestError("metadata.name and the name in the http path request don't match")
+	}
+	if len(entity.Metadata.Project) == 0 {
+		entity.Metadata.Project = parameters.Project
+	} else if entity.Metadata.Project != parameters.Project {
+		logrus.Debugf("project in saved view %q and project from the http request %q don't match", entity.Metadata.Project, parameters.Project)
+		return nil, shared.HandleBadRequestError("metadata.project and the project name in the http path request don't match")
+	}
+	if err := s.validate(entity); err != nil {
+		return nil, err
+	}
+	// find the previous version of the saved view
+	oldEntity, err := s.dao.Get(parameters.Project, parameters.Name)
+	if err != nil {
+		return nil, err
+	}
+	if admissionErr := admitter.Admit(admission.OperationUpdate, entity, oldEntity, func() error { return s.validate(entity) }); admissionErr != nil {
+		return nil, admissionErr
+	}
+	entity.Status = nil
+	entity.Metadata.Update(oldEntity.Metadata)
+	if updateErr := s.dao.Update(entity); updateErr != nil {
+		logrus.WithError(updateErr).Errorf("unable to perform the update of the saved view %q, something wrong with the database", entity.Metadata.Name)
+		return nil, updateErr
+	}
+	return entity, nil
+}
+
+// validate verifies the dashboard exists and accepts the values of the view.
+func (s *service) validate(entity *v1.SavedView) error {
+	dash, err := s.dashboardDAO.Get(entity.Metadata.Project, entity.Spec.Dashboard)
+	if err != nil {
+		if databaseModel.IsKeyNotFound(err) {
+			return shared.HandleBadRequestError(fmt.Sprintf("dashboard %q doesn't exist in the project %q", entity.Spec.Dashboard, entity.Metadata.Project))
+		}
+		return err
+	}
+	if problems := entity.Spec.CheckDashboard(dash); len(problems) > 0 {
+		return shared.HandleBadRequestError(fmt.Sprintf("the view doesn't match the dashboard %q: %s", entity.Spec.Dashboard, strings.Join(problems, ", ")))
+	}
+	return nil
+}
+
+func (s *service) Delete(admitter shared.Admitter, parameters shared.Parameters) error {
+	getOld := func() (api.Entity, error) { return s.dao.Get(parameters.Project, parameters.Name) }
+	if err := admitter.AdmitDeletion(v1.KindSavedView, getOld); err != nil {
+		return err
+	}
+	return s.dao.Delete(parameters.Project, parameters.Name)
+}
+
+func (s *service) Get(parameters shared.Parameters) (interface{}, error) {
+	entity, err := s.dao.Get(parameters.Project, parameters.Name)
+	if err != nil {
+		return nil, err
+	}
+	if _, statusErr := s.setStatus(entity, make(map[string]*v1.Dashboard)); statusErr != nil {
+		return nil, statusErr
+	}
+	return entity, nil
+}
+
+func (s *service) List(q databaseModel.Query, _ shared.Parameters) (interface{}, error) {
+	list, err := s.dao.List(q)
+	if err != nil {
+		return nil, err
+	}
+	var dashboardName string
+	if query, ok := q.(*savedview.Query); ok {
+		dashboardName = query.Dashboard
+	}
+	result := make([]*v1.SavedView, 0, len(list))
+	// Several views usually point to the same dashboard, so each dashboard is only retrieved once.
+	// They are identified by <project>/<name>, as the list can contain the views of several projects.
+	dashboards := make(map[string]*v1.Dashboard)
+	for _, entity := range list {
+		if len(dashboardName) > 0 && entity.Spec.Dashboard != dashboardName {
+			continue
+		}
+		if _, statusErr := s.setStatus(entity, dashboards); statusErr != nil {
+			return nil, statusErr
+		}
+		result = append(result, entity)
+	}
+	return result, nil
+}
+
+func (s *service) Apply(project string, name string) (*v1.SavedViewDashboard, error) {
+	entity, err := s.dao.Get(project, name)
+	if err != nil {
+		return nil, err
+	}
+	dash, err := s.setStatus(entity, make(map[string]*v1.Dashboard))
+	if err != nil {
+		return nil, err
+	}
+	if entity.Status.Broken {
+		return nil, shared.HandleBadRequestError(fmt.Sprintf("the saved view %q is broken: %s", name, strings.Join(entity.Status.Errors, ", ")))
+	}
+	entity.Spec.ApplyOn(dash)
+	return &v1.SavedViewDashboard{
+		Dashboard: dash,
+		TimeRange: entity.Spec.TimeRange,
+	}, nil
+}
+
+// setStatus checks the view against the current version of its dashboard and returns the dashboard.
+// The dashboard is nil when it has been deleted.
+func (s *service) setStatus(entity *v1.SavedView, dashboards map[string]*v1.Dashboard) (*v1.Dashboard, error) {
+	key := fmt.Sprintf("%s/%s", entity.Metadata.Project, entity.Spec.Dashboard)
+	dash, ok := dashboards[key]
+	if !ok {
+		var err error
+		dash, err = s.dashboardDAO.Get(entity.Metadata.Project, entity.Spec.Dashboard)
+		if err != nil {
+			if !databaseModel.IsKeyNotFound(err) {
+				return nil, err
+			}
+			dash = nil
+		}
+		dashboards[key] = dash
+	}
+	if dash == nil {
+		entity.Status = &v1.SavedViewStatus{
+			Broken: true,
+			Errors: []string{fmt.Sprintf("dashboard %q doesn't exist anymore", entity.Spec.Dashboard)},
+		}
+		return nil, nil
+	}
+	problems := entity.Spec.CheckDashboard(dash)
+	entity.Status = &v1.SavedViewStatus{
+		Broken: len(problems) > 0,
+		Errors: problems,
+	}
+	return dash, nil
+}
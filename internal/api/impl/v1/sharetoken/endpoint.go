@@ -0,0 +1,98 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sharetoken
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/interface/v1/sharetoken"
+	"github.com/perses/perses/internal/api/shared"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+// Endpoint is the struct that define all endpoint used to manage the share tokens of the dashboards.
+type Endpoint struct {
+	service  sharetoken.Service
+	readonly bool
+}
+
+func NewEndpoint(service sharetoken.Service, readonly bool) *Endpoint {
+	return &Endpoint{
+		service:  service,
+		readonly: readonly,
+	}
+}
+
+// RegisterRoutes is the method to use to register the routes prefixed by /api/v1
+func (e *Endpoint) RegisterRoutes(g *echo.Group) {
+	projectGroup := g.Group(fmt.Sprintf("/%s/:%s", shared.PathProject, shared.ParamProject))
+	group := projectGroup.Group(fmt.Sprintf("/%s", shared.PathShareToken))
+	group.GET("", e.List)
+	group.GET(fmt.Sprintf("/:%s", shared.ParamName), e.Get)
+	if !e.readonly {
+		projectGroup.POST(fmt.Sprintf("/%s/:%s/%s", shared.PathDashboard, shared.ParamName, shared.PathShareToken), e.Create)
+		group.DELETE(fmt.Sprintf("/:%s", shared.ParamName), e.Delete)
+	}
+}
+
+// Create generates a new token sharing the dashboard. The token is only returned in the response of this request.
+func (e *Endpoint) Create(ctx echo.Context) error {
+	spec := v1.ShareTokenSpec{}
+	if err := ctx.Bind(&spec); err != nil {
+		return shared.HandleBadRequestError(err.Error())
+	}
+	project, name := extractParameters(ctx)
+	result, err := e.service.Create(project, name, spec)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+// Delete revokes the token.
+func (e *Endpoint) Delete(ctx echo.Context) error {
+	project, name := extractParameters(ctx)
+	if err := e.service.Delete(project, name); err != nil {
+		return err
+	}
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func (e *Endpoint) Get(ctx echo.Context) error {
+	project, name := extractParameters(ctx)
+	result, err := e.service.Get(project, name)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func (e *Endpoint) List(ctx echo.Context) error {
+	query := &sharetoken.Query{}
+	if err := ctx.Bind(query); err != nil {
+		return shared.HandleBadRequestError(err.Error())
+	}
+	query.Project = shared.GetProjectParameter(ctx)
+	result, err := e.service.List(query)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+func extractParameters(ctx echo.Context) (string, string) {
+	return shared.GetProjectParameter(ctx), ctx.Param(shared.ParamName)
+}
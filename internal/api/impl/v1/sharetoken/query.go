@@ -0,0 +1,314 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sharetoken
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/interface/v1/sharetoken"
+	databaseModel "github.com/perses/perses/internal/api/shared/database/model"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/prometheus/common/model"
+	"github.com/sirupsen/logrus"
+)
+
+// timeRangeTolerance is added around the time range of the token, as the clocks differ and the start of a range
+// query is aligned on its step.
+const timeRangeTolerance = time.Minute
+
+// freeValuePattern is what a variable that is not fixed by the token can be replaced by. It can't contain a space, a
+// quote or a bracket, so it can't end the expression it is part of and add another one.
+const freeValuePattern = "[^\\s\"'`{}()\\[\\],;]*"
+
+var (
+	variableReferenceRegexp = regexp.MustCompile(`\$\{([a-zA-Z0-9_-]+)(?::([a-zA-Z]+))?}|\$([a-zA-Z0-9_-]+)`)
+	// the read APIs of Prometheus and Loki. Nothing else can be reached with a share token.
+	queryEndpointRegexp       = regexp.MustCompile(`^(/loki)?/api/v1/(query|query_range)$`)
+	seriesEndpointRegexp      = regexp.MustCompile(`^(/loki)?/api/v1/(series|labels|label)$`)
+	labelValuesEndpointRegexp = regexp.MustCompile(`^(/loki)?/api/v1/label/([^/]+)/values$`)
+)
+
+// templates are the expressions of the dashboard that can be sent to the datasources, with their variables.
+type templates struct {
+	// queries are the queries of the panels and of the variables.
+	queries []*regexp.Regexp
+	// matchers are the series selectors of the variables.
+	matchers []*regexp.Regexp
+	// labels are the labels whose values are listed by the variables.
+	labels []*regexp.Regexp
+}
+
+func (s *service) AuthorizeProxyRequest(shareToken *v1.ShareToken, request *sharetoken.ProxyRequest) error {
+	if err := s.authorizeDatasource(shareToken, request); err != nil {
+		return err
+	}
+	dashboardEntity, err := s.dashboardDAO.Get(shareToken.Metadata.Project, shareToken.Spec.Dashboard)
+	if err != nil {
+		if databaseModel.IsKeyNotFound(err) {
+			return sharetoken.ErrInvalidToken
+		}
+		return err
+	}
+	t, err := newTemplates(dashboardEntity.Spec, shareToken.Spec.Variables)
+	if err != nil {
+		logrus.WithError(err).Errorf("unable to find the queries of the dashboard %q in the project %q", shareToken.Spec.Dashboard, shareToken.Metadata.Project)
+		return echo.NewHTTPError(http.StatusInternalServerError, "internal server error")
+	}
+	if queryErr := t.authorize(request); queryErr != nil {
+		return queryErr
+	}
+	timeRange := shareToken.Spec.TimeRange
+	if timeRange == nil {
+		// the token doesn't change the time range of the dashboard
+		timeRange = &v1.TimeRange{Duration: dashboardEntity.Spec.Duration}
+	}
+	return authorizeTimeRange(timeRange, request)
+}
+
+// authorizeDatasource verifies the datasource is used by the dashboard and is visible from its project.
+func (s *service) authorizeDatasource(shareToken *v1.ShareToken, request *sharetoken.ProxyRequest) error {
+	forbidden := echo.NewHTTPError(http.StatusForbidden, "the share token doesn't give access to this datasource")
+	if len(request.Project) > 0 && request.Project != shareToken.Metadata.Project {
+		return forbidden
+	}
+	if request.Global && len(request.Project) == 0 {
+		// the token is scoped to a project, the overrides of the project must apply
+		return echo.NewHTTPError(http.StatusForbidden, "the global datasources must be queried through the proxy of the project, so its overrides apply")
+	}
+	if request.Global || len(request.Organization) > 0 {
+		projectEntity, err := s.projectDAO.Find(shareToken.Metadata.Project)
+		if err != nil {
+			if databaseModel.IsKeyNotFound(err) {
+				return forbidden
+			}
+			return err
+		}
+		// the global datasources are only available to the projects of the default organization
+		if request.Global && projectEntity.GetOrganization() != v1.DefaultOrganization {
+			return forbidden
+		}
+		if len(request.Organization) > 0 && projectEntity.GetOrganization() != request.Organization {
+			return forbidden
+		}
+	}
+	if !s.usesDatasource(shareToken, request) {
+		return forbidden
+	}
+	return nil
+}
+
+// usesDatasource verifies the datasource is explicitly selected by the dashboard, or is a default datasource of the
+// scope queried, used by the panels and the variables without an explicit datasource.
+func (s *service) usesDatasource(shareToken *v1.ShareToken, request *sharetoken.ProxyRequest) bool {
+	if s.UsesDatasource(shareToken, request.Datasource) {
+		return true
+	}
+	if request.Global {
+		dts, err := s.globalDTSDAO.Get(request.Datasource)
+		return err == nil && dts.Spec.Default
+	}
+	if len(request.Organization) > 0 {
+		dts, err := s.orgDTSDAO.Get(request.Organization, request.Datasource)
+		return err == nil && dts.Spec.Default
+	}
+	return false
+}
+
+func newTemplates(spec v1.DashboardSpec, variables map[string]string) (*templates, error) {
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return nil, err
+	}
+	var raw interface{}
+	if unmarshalErr := json.Unmarshal(data, &raw); unmarshalErr != nil {
+		return nil, unmarshalErr
+	}
+	t := &templates{}
+	return t, t.collect(raw, variables)
+}
+
+// collect walks the dashboard generically, as the spec of the plugins is only known by the CUE schemas.
+func (t *templates) collect(node interface{}, variables map[string]string) error {
+	switch value := node.(type) {
+	case map[string]interface{}:
+		for key, child := range value {
+			var err error
+			switch text, isString := child.(string); {
+			case isString && (key == "query" || key == "expr"):
+				t.queries, err = appendTemplate(t.queries, text, variables)
+			case isString && key == "label_name":
+				t.labels, err = appendTemplate(t.labels, text, variables)
+			case key == "matchers":
+				list, _ := child.([]interface{})
+				for _, item := range list {
+					if matcher, ok := item.(string); ok {
+						if t.matchers, err = appendTemplate(t.matchers, matcher, variables); err != nil {
+							break
+						}
+					}
+				}
+			default:
+				err = t.collect(child, variables)
+			}
+			if err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for _, child := range value {
+			if err := t.collect(child, variables); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// appendTemplate compiles the expression into a regexp where the variables fixed by the token are replaced by their
+// value, and the other ones by freeValuePattern.
+func appendTemplate(list []*regexp.Regexp, expression string, variables map[string]string) ([]*regexp.Regexp, error) {
+	var builder strings.Builder
+	builder.WriteString("^")
+	last := 0
+	for _, loc := range variableReferenceRegexp.FindAllStringSubmatchIndex(expression, -1) {
+		builder.WriteString(regexp.QuoteMeta(expression[last:loc[0]]))
+		name := ""
+		if loc[2] >= 0 {
+			name = expression[loc[2]:loc[3]]
+		} else {
+			name = expression[loc[6]:loc[7]]
+		}
+		if value, ok := variables[name]; ok {
+			builder.WriteString(regexp.QuoteMeta(value))
+		} else {
+			builder.WriteString(freeValuePattern)
+		}
+		last = loc[1]
+	}
+	builder.WriteString(regexp.QuoteMeta(expression[last:]))
+	builder.WriteString("$")
+	result, err := regexp.Compile(builder.String())
+	if err != nil {
+		return nil, err
+	}
+	return append(list, result), nil
+}
+
+func (t *templates) authorize(request *sharetoken.ProxyRequest) error {
+	params := request.Parameters
+	switch {
+	case queryEndpointRegexp.MatchString(request.Path):
+		queries := params["query"]
+		if len(queries) == 0 {
+			return echo.NewHTTPError(http.StatusForbidden, "the query is missing")
+		}
+		return matchAll(queries, t.queries, "the query is not one of the dashboard shared with the token")
+	case seriesEndpointRegexp.MatchString(request.Path):
+		return t.authorizeSelectors(params)
+	case labelValuesEndpointRegexp.MatchString(request.Path):
+		label := labelValuesEndpointRegexp.FindStringSubmatch(request.Path)[2]
+		if err := matchAll([]string{label}, t.labels, "the values of this label are not listed by the dashboard shared with the token"); err != nil {
+			return err
+		}
+		return t.authorizeSelectors(params)
+	}
+	return echo.NewHTTPError(http.StatusForbidden, "the share token doesn't give access to this endpoint of the datasource")
+}
+
+// authorizeSelectors verifies the series selectors given by Prometheus (match[]) or Loki (query).
+func (t *templates) authorizeSelectors(params map[string][]string) error {
+	selectors := append(append([]string{}, params["match[]"]...), params["query"]...)
+	return matchAll(selectors, append(append([]*regexp.Regexp{}, t.matchers...), t.queries...), "the series selector is not one of the dashboard shared with the token")
+}
+
+func matchAll(values []string, list []*regexp.Regexp, message string) error {
+	for _, value := range values {
+		matched := false
+		for _, r := range list {
+			if r.MatchString(value) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return echo.NewHTTPError(http.StatusForbidden, message)
+		}
+	}
+	return nil
+}
+
+// authorizeTimeRange verifies the timestamps of the request are in the time range.
+func authorizeTimeRange(timeRange *v1.TimeRange, request *sharetoken.ProxyRequest) error {
+	tolerance := timeRangeTolerance
+	if step, err := parseDuration(request.Parameters.Get("step")); err == nil && step > tolerance {
+		tolerance = step
+	}
+	now := time.Now()
+	var start, end time.Time
+	if timeRange.Duration > 0 {
+		start, end = now.Add(-time.Duration(timeRange.Duration)), now
+	} else if timeRange.Start != nil && timeRange.End != nil {
+		start, end = *timeRange.Start, *timeRange.End
+	} else {
+		return nil
+	}
+	for _, param := range []string{"start", "end", "time"} {
+		value := request.Parameters.Get(param)
+		if len(value) == 0 {
+			continue
+		}
+		timestamp, err := parseTimestamp(value)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("invalid parameter %s: %s", param, err))
+		}
+		if timestamp.Before(start.Add(-tolerance)) || timestamp.After(end.Add(tolerance)) {
+			return echo.NewHTTPError(http.StatusForbidden, "the time range is not the one of the dashboard shared with the token")
+		}
+	}
+	return nil
+}
+
+// parseTimestamp accepts the formats of Prometheus and Loki: RFC 3339, or a Unix timestamp in seconds, or in
+// nanoseconds for Loki.
+func parseTimestamp(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return t, nil
+	}
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("cannot parse %q as a timestamp", value)
+	}
+	if f > 1e15 {
+		return time.Unix(0, int64(f)), nil
+	}
+	seconds, fraction := math.Modf(f)
+	return time.Unix(int64(seconds), int64(fraction*1e9)), nil
+}
+
+// parseDuration accepts a duration like 15s or a number of seconds.
+func parseDuration(value string) (time.Duration, error) {
+	if f, err := strconv.ParseFloat(value, 64); err == nil {
+		return time.Duration(f * float64(time.Second)), nil
+	}
+	d, err := model.ParseDuration(value)
+	return time.Duration(d), err
+}
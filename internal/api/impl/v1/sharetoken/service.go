@@ -0,0 +1,259 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sharetoken
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/perses/perses/internal/api/interface/v1/access"
+	"github.com/perses/perses/internal/api/interface/v1/dashboard"
+	"github.com/perses/perses/internal/api/interface/v1/globaldatasource"
+	"github.com/perses/perses/internal/api/interface/v1/organizationdatasource"
+	"github.com/perses/perses/internal/api/interface/v1/project"
+	"github.com/perses/perses/internal/api/interface/v1/sharetoken"
+	"github.com/perses/perses/internal/api/shared"
+	databaseModel "github.com/perses/perses/internal/api/shared/database/model"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/time/rate"
+)
+
+const (
+	nameLength   = 8
+	secretLength = 32
+)
+
+type service struct {
+	sharetoken.Service
+	dao           sharetoken.DAO
+	dashboardDAO  dashboard.DAO
+	projectDAO    project.DAO
+	globalDTSDAO  globaldatasource.DAO
+	orgDTSDAO     organizationdatasource.DAO
+	accessService access.Service
+	// limiters contains the rate limiter of each token, indexed by project and name.
+	limiters map[string]*rate.Limiter
+	mutex    sync.Mutex
+}
+
+func NewService(dao sharetoken.DAO, dashboardDAO dashboard.DAO, projectDAO project.DAO, globalDTSDAO globaldatasource.DAO, orgDTSDAO organizationdatasource.DAO, accessService access.Service) sharetoken.Service {
+	return &service{
+		dao:           dao,
+		dashboardDAO:  dashboardDAO,
+		projectDAO:    projectDAO,
+		globalDTSDAO:  globalDTSDAO,
+		orgDTSDAO:     orgDTSDAO,
+		accessService: accessService,
+		limiters:      make(map[string]*rate.Limiter),
+	}
+}
+
+func (s *service) Create(project string, dashboard string, spec v1.ShareTokenSpec) (*v1.ShareTokenCreation, error) {
+	if _, err := s.dashboardDAO.Get(project, dashboard); err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	if spec.TTL > 0 {
+		spec.ExpireAt = now.Add(time.Duration(spec.TTL))
+	}
+	if spec.IsExpired(now) {
+		return nil, shared.HandleBadRequestError("expire_at must be in the future")
+	}
+	if spec.RateLimit == 0 {
+		spec.RateLimit = v1.DefaultShareRateLimit
+	}
+	name, err := generateRandomString(nameLength, hex.EncodeToString)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := generateRandomString(secretLength, base64.RawURLEncoding.EncodeToString)
+	if err != nil {
+		return nil, err
+	}
+	spec.Dashboard = dashboard
+	spec.SecretHash = hash(secret)
+	entity := &v1.ShareToken{
+		Kind:     v1.KindShareToken,
+		Metadata: *v1.NewProjectMetadata(project, name),
+		Spec:     spec,
+	}
+	entity.Metadata.CreateNow()
+	if createErr := s.dao.Create(entity); createErr != nil {
+		logrus.WithError(createErr).Errorf("unable to save the share token of the dashboard %q in the project %q", dashboard, project)
+		return nil, createErr
+	}
+	return &v1.ShareTokenCreation{
+		// The secret is the last part, so the project and the name (that can't contain a dot) are easy to extract.
+		Token:      fmt.Sprintf("%s.%s.%s", project, name, secret),
+		ShareToken: hideSecret(entity),
+	}, nil
+}
+
+func (s *service) Delete(project string, name string) error {
+	if err := s.dao.Delete(project, name); err != nil {
+		return err
+	}
+	s.removeLimiter(project, name)
+	return nil
+}
+
+func (s *service) Get(project string, name string) (*v1.ShareToken, error) {
+	entity, err := s.dao.Get(project, name)
+	if err != nil {
+		return nil, err
+	}
+	return hideSecret(entity), nil
+}
+
+func (s *service) List(q *sharetoken.Query) ([]*v1.ShareToken, error) {
+	list, err := s.dao.List(q)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*v1.ShareToken, 0, len(list))
+	for _, entity := range list {
+		if len(q.Dashboard) > 0 && entity.Spec.Dashboard != q.Dashboard {
+			continue
+		}
+		result = append(result, hideSecret(entity))
+	}
+	return result, nil
+}
+
+func (s *service) DeleteExpired() error {
+	list, err := s.dao.List(&sharetoken.Query{})
+	if err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	for _, entity := range list {
+		if !entity.Spec.IsExpired(now) {
+			continue
+		}
+		if deleteErr := s.Delete(entity.Metadata.Project, entity.Metadata.Name); deleteErr != nil && !databaseModel.IsKeyNotFound(deleteErr) {
+			return deleteErr
+		}
+		logrus.Debugf("share token %q in the project %q has expired and has been deleted", entity.Metadata.Name, entity.Metadata.Project)
+	}
+	return nil
+}
+
+func (s *service) Verify(token string) (*v1.ShareToken, error) {
+	project, name, secret, ok := parseToken(token)
+	if !ok {
+		return nil, sharetoken.ErrInvalidToken
+	}
+	entity, err := s.dao.Get(project, name)
+	if err != nil {
+		if databaseModel.IsKeyNotFound(err) {
+			return nil, sharetoken.ErrInvalidToken
+		}
+		return nil, err
+	}
+	if subtle.ConstantTimeCompare([]byte(hash(secret)), []byte(entity.Spec.SecretHash)) != 1 || entity.Spec.IsExpired(time.Now().UTC()) {
+		return nil, sharetoken.ErrInvalidToken
+	}
+	if !s.getLimiter(entity).Allow() {
+		return nil, sharetoken.ErrRateLimited
+	}
+	return hideSecret(entity), nil
+}
+
+func (s *service) GetDashboard(token string) (*v1.SharedDashboard, error) {
+	entity, err := s.Verify(token)
+	if err != nil {
+		return nil, err
+	}
+	dashboardEntity, err := s.dashboardDAO.Get(entity.Metadata.Project, entity.Spec.Dashboard)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.SharedDashboard{
+		Dashboard: dashboardEntity,
+		Variables: entity.Spec.Variables,
+		TimeRange: entity.Spec.TimeRange,
+		ExpireAt:  entity.Spec.ExpireAt,
+	}, nil
+}
+
+func (s *service) UsesDatasource(shareToken *v1.ShareToken, datasource string) bool {
+	return s.accessService.UsesDatasource(shareToken.Metadata.Project, shareToken.Spec.Dashboard, datasource)
+}
+
+func (s *service) getLimiter(entity *v1.ShareToken) *rate.Limiter {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	key := limiterKey(entity.Metadata.Project, entity.Metadata.Name)
+	limiter, ok := s.limiters[key]
+	if !ok {
+		limiter = rate.NewLimiter(rate.Limit(float64(entity.Spec.RateLimit)/time.Minute.Seconds()), entity.Spec.RateLimit)
+		s.limiters[key] = limiter
+	}
+	return limiter
+}
+
+func (s *service) removeLimiter(project string, name string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	delete(s.limiters, limiterKey(project, name))
+}
+
+func limiterKey(project string, name string) string {
+	return fmt.Sprintf("%s/%s", project, name)
+}
+
+// parseToken splits the token <project>.<name>.<secret>. The project is the only part that can contain a dot.
+func parseToken(token string) (string, string, string, bool) {
+	secretIndex := strings.LastIndex(token, ".")
+	if secretIndex < 0 {
+		return "", "", "", false
+	}
+	secret := token[secretIndex+1:]
+	nameIndex := strings.LastIndex(token[:secretIndex], ".")
+	if nameIndex < 0 {
+		return "", "", "", false
+	}
+	project, name := token[:nameIndex], token[nameIndex+1:secretIndex]
+	if len(project) == 0 || len(name) == 0 || len(secret) == 0 {
+		return "", "", "", false
+	}
+	return project, name, secret, true
+}
+
+func generateRandomString(length int, encode func([]byte) string) (string, error) {
+	data := make([]byte, length)
+	if _, err := rand.Read(data); err != nil {
+		return "", err
+	}
+	return encode(data), nil
+}
+
+func hash(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// hideSecret removes the hash of the secret, so it is never returned by the API.
+func hideSecret(entity *v1.ShareToken) *v1.ShareToken {
+	entity.Spec.SecretHash = ""
+	return entity
+}
@@ -0,0 +1,114 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package team
+
+import (
+	"fmt"
+
+	"github.com/perses/perses/internal/api/interface/v1/team"
+	"github.com/perses/perses/internal/api/shared"
+	databaseModel "github.com/perses/perses/internal/api/shared/database/model"
+	"github.com/perses/perses/pkg/model/api"
+	"github.com/perses/perses/pkg/model/api/admission"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/sirupsen/logrus"
+)
+
+type service struct {
+	team.Service
+	dao team.DAO
+}
+
+func NewService(dao team.DAO) team.Service {
+	return &service{
+		dao: dao,
+	}
+}
+
+func (s *service) Create(admitter shared.Admitter, entity api.Entity) (interface{}, error) {
+	if teamObject, ok := entity.(*v1.Team); ok {
+		return s.create(admitter, teamObject)
+	}
+	return nil, shared.HandleBadRequestError(fmt.Sprintf("wrong entity format, attempting Team format, received '%T'", entity))
+}
+
+func (s *service) create(admitter shared.Admitter, entity *v1.Team) (*v1.Team, error) {
+	if err := admitter.Admit(admission.OperationCreate, entity, nil, nil); err != nil {
+		return nil, err
+	}
+	// Update the time contains in the entity
+	entity.Metadata.CreateNow()
+	if err := s.dao.Create(entity); err != nil {
+		return nil, err
+	}
+	return entity, nil
+}
+
+func (s *service) Update(admitter shared.Admitter, entity api.Entity, parameters shared.Parameters) (interface{}, error) {
+	if teamObject, ok := entity.(*v1.Team); ok {
+		return s.update(admitter, teamObject, parameters)
+	}
+	return nil, shared.HandleBadRequestError(fmt.Sprintf("wrong entity format, attempting Team format, received '%T'", entity))
+}
+
+func (s *service) update(admitter shared.Admitter, entity *v1.Team, parameters shared.Parameters) (*v1.Team, error) {
+	if entity.Metadata.Name != parameters.Name {
+		logrus.Debugf("name in Team %q and name from the http request %q don't match", entity.Metadata.Name, parameters.Name)
+		return nil, shared.HandleBadRequestError("metadata.name and the name in the http path request don't match")
+	}
+	// find the previous version of the Team
+	oldEntity, err := s.dao.Get(parameters.Name)
+	if err != nil {
+		return nil, err
+	}
+	if admissionErr := admitter.Admit(admission.OperationUpdate, entity, oldEntity, nil); admissionErr != nil {
+		return nil, admissionErr
+	}
+	entity.Metadata.Update(oldEntity.Metadata)
+	if updateErr := s.dao.Update(entity); updateErr != nil {
+		logrus.WithError(updateErr).Errorf("unable to perform the update of the Team %q, something wrong with the database", entity.Metadata.Name)
+		return nil, updateErr
+	}
+	return entity, nil
+}
+
+func (s *service) Delete(admitter shared.Admitter, parameters shared.Parameters) error {
+	getOld := func() (api.Entity, error) { return s.dao.Get(parameters.Name) }
+	if err := admitter.AdmitDeletion(v1.KindTeam, getOld); err != nil {
+		return err
+	}
+	return s.dao.Delete(parameters.Name)
+}
+
+func (s *service) Get(parameters shared.Parameters) (interface{}, error) {
+	return s.dao.Get(parameters.Name)
+}
+
+func (s *service) List(q databaseModel.Query, _ shared.Parameters) (interface{}, error) {
+	teams, err := s.dao.List(q)
+	if err != nil {
+		return nil, err
+	}
+	query, ok := q.(*team.Query)
+	if !ok || len(query.Member) == 0 {
+		return teams, nil
+	}
+	result := make([]*v1.Team, 0, len(teams))
+	for _, t := range teams {
+		if t.Spec.IsMember(query.Member, nil) {
+			result = append(result, t)
+		}
+	}
+	return result, nil
+}
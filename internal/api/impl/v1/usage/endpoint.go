@@ -0,0 +1,68 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usage
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/interface/v1/usage"
+	"github.com/perses/perses/internal/api/shared"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+// Endpoint is the struct that define all endpoint delivered by the path /usage
+// and the endpoint used to record a view of a dashboard.
+type Endpoint struct {
+	service usage.Service
+}
+
+func NewEndpoint(service usage.Service) *Endpoint {
+	return &Endpoint{
+		service: service,
+	}
+}
+
+// RegisterRoutes is the method to use to register the routes prefixed by /api/v1
+func (e *Endpoint) RegisterRoutes(g *echo.Group) {
+	// Recording a view is not considered as a modification of the dashboard, that's why it is available even in readonly mode.
+	g.POST(fmt.Sprintf("/%s/:%s/%s/:%s/view", shared.PathProject, shared.ParamProject, shared.PathDashboard, shared.ParamName), e.View)
+	g.GET(fmt.Sprintf("/%s/%s", shared.PathUsage, shared.PathDashboard), e.ListDashboards)
+}
+
+// View records a view of the dashboard.
+func (e *Endpoint) View(ctx echo.Context) error {
+	if err := e.service.View(shared.GetProjectParameter(ctx), ctx.Param(shared.ParamName)); err != nil {
+		return err
+	}
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+// ListDashboards returns the usage statistics of every dashboard, including the ones never viewed.
+func (e *Endpoint) ListDashboards(ctx echo.Context) error {
+	q := &v1.DashboardUsageQuery{}
+	if err := ctx.Bind(q); err != nil {
+		return shared.HandleBadRequestError(err.Error())
+	}
+	if q.Days < 0 {
+		return shared.HandleBadRequestError("days cannot be negative")
+	}
+	result, err := e.service.ListDashboards(q)
+	if err != nil {
+		return err
+	}
+	result = shared.FilterByProjectPermission(ctx, result, v1.RoleViewer, func(d *v1.DashboardUsageSummary) string { return d.Project })
+	return ctx.JSON(http.StatusOK, result)
+}
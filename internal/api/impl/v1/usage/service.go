@@ -0,0 +1,255 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usage
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/perses/common/async"
+	"github.com/perses/perses/internal/api/interface/v1/dashboard"
+	"github.com/perses/perses/internal/api/interface/v1/usage"
+	databaseModel "github.com/perses/perses/internal/api/shared/database/model"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// FlushInterval is the interval used to persist the views kept in memory.
+	FlushInterval = time.Minute
+	// retentionDays is the number of days the daily views are kept in the database.
+	// The time of the last view is kept no matter how old it is.
+	retentionDays = 400
+)
+
+type key struct {
+	project   string
+	dashboard string
+}
+
+type views struct {
+	lastViewedAt time.Time
+	perDay       map[string]uint64
+}
+
+func newViews() *views {
+	return &views{perDay: make(map[string]uint64)}
+}
+
+func (v *views) merge(other *views) {
+	if other.lastViewedAt.After(v.lastViewedAt) {
+		v.lastViewedAt = other.lastViewedAt
+	}
+	for day, count := range other.perDay {
+		v.perDay[day] += count
+	}
+}
+
+type service struct {
+	usage.Service
+	dao          usage.DAO
+	dashboardDAO dashboard.DAO
+	mutex        sync.Mutex
+	// pending contains the views recorded since the last flush.
+	pending map[key]*views
+}
+
+func NewService(dao usage.DAO, dashboardDAO dashboard.DAO) usage.Service {
+	return &service{
+		dao:          dao,
+		dashboardDAO: dashboardDAO,
+		pending:      make(map[key]*views),
+	}
+}
+
+func (s *service) Record(project string, dashboard string) {
+	now := time.Now().UTC()
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	k := key{project: project, dashboard: dashboard}
+	v, ok := s.pending[k]
+	if !ok {
+		v = newViews()
+		s.pending[k] = v
+	}
+	v.lastViewedAt = now
+	v.perDay[now.Format(v1.UsageDayLayout)]++
+}
+
+func (s *service) View(project string, dashboard string) error {
+	if _, err := s.dashboardDAO.Get(project, dashboard); err != nil {
+		return err
+	}
+	s.Record(project, dashboard)
+	return nil
+}
+
+func (s *service) Flush() error {
+	s.mutex.Lock()
+	pending := s.pending
+	s.pending = make(map[key]*views)
+	s.mutex.Unlock()
+
+	var lastErr error
+	for k, v := range pending {
+		if err := s.save(k, v); err != nil {
+			logrus.WithError(err).Errorf("unable to save the usage of the dashboard %q in the project %q", k.dashboard, k.project)
+			// put back the views in memory, so they are not lost and saved at the next flush.
+			s.restore(k, v)
+			lastErr = err
+		}
+	}
+	return lastErr
+}
+
+func (s *service) save(k key, v *views) error {
+	entity, err := s.dao.Get(k.project, k.dashboard)
+	if err != nil {
+		if !databaseModel.IsKeyNotFound(err) {
+			return err
+		}
+		entity = &v1.DashboardUsage{
+			Kind:     v1.KindDashboardUsage,
+			Metadata: *v1.NewProjectMetadata(k.project, k.dashboard),
+		}
+		entity.Metadata.CreateNow()
+	} else {
+		entity.Metadata.Update(entity.Metadata)
+	}
+	stored := toViews(entity)
+	stored.merge(v)
+	// remove the days that are too old to be kept
+	oldestDay := time.Now().UTC().AddDate(0, 0, -retentionDays).Format(v1.UsageDayLayout)
+	for day := range stored.perDay {
+		if day < oldestDay {
+			delete(stored.perDay, day)
+		}
+	}
+	entity.Spec = v1.DashboardUsageSpec{
+		LastViewedAt: stored.lastViewedAt,
+		Views:        stored.perDay,
+	}
+	return s.dao.Update(entity)
+}
+
+func (s *service) restore(k key, v *views) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if current, ok := s.pending[k]; ok {
+		current.merge(v)
+	} else {
+		s.pending[k] = v
+	}
+}
+
+func (s *service) ListDashboards(q *v1.DashboardUsageQuery) ([]*v1.DashboardUsageSummary, error) {
+	days := q.Days
+	if days <= 0 {
+		days = v1.DefaultUsageDays
+	}
+	dashboards, err := s.dashboardDAO.List(&dashboard.Query{Project: q.Project})
+	if err != nil {
+		return nil, err
+	}
+	usages, err := s.dao.List(&usage.Query{Project: q.Project})
+	if err != nil {
+		return nil, err
+	}
+	allViews := make(map[key]*views, len(usages))
+	for _, u := range usages {
+		allViews[key{project: u.Metadata.Project, dashboard: u.Metadata.Name}] = toViews(u)
+	}
+	s.mutex.Lock()
+	for k, v := range s.pending {
+		if current, ok := allViews[k]; ok {
+			current.merge(v)
+		} else {
+			allViews[k] = newViews()
+			allViews[k].merge(v)
+		}
+	}
+	s.mutex.Unlock()
+
+	firstDay := time.Now().UTC().AddDate(0, 0, 1-days).Format(v1.UsageDayLayout)
+	result := make([]*v1.DashboardUsageSummary, 0, len(dashboards))
+	for _, d := range dashboards {
+		summary := &v1.DashboardUsageSummary{
+			Project:   d.Metadata.Project,
+			Dashboard: d.Metadata.Name,
+		}
+		if v, ok := allViews[key{project: d.Metadata.Project, dashboard: d.Metadata.Name}]; ok {
+			lastViewedAt := v.lastViewedAt
+			summary.LastViewedAt = &lastViewedAt
+			for day, count := range v.perDay {
+				if day >= firstDay {
+					summary.Views += count
+				}
+			}
+		}
+		result = append(result, summary)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Project != result[j].Project {
+			return result[i].Project < result[j].Project
+		}
+		return result[i].Dashboard < result[j].Dashboard
+	})
+	return result, nil
+}
+
+func toViews(entity *v1.DashboardUsage) *views {
+	v := newViews()
+	v.lastViewedAt = entity.Spec.LastViewedAt
+	for day, count := range entity.Spec.Views {
+		v.perDay[day] = count
+	}
+	return v
+}
+
+// NewFlusher returns the task persisting periodically the views recorded by the service.
+// The remaining views are persisted when the task is stopped.
+func NewFlusher(svc usage.Service) async.Task {
+	return &flusher{svc: svc}
+}
+
+type flusher struct {
+	async.Task
+	svc usage.Service
+}
+
+func (f *flusher) String() string {
+	return "dashboard usage flusher"
+}
+
+func (f *flusher) Initialize() error {
+	return nil
+}
+
+func (f *flusher) Execute(ctx context.Context, _ context.CancelFunc) error {
+	select {
+	case <-ctx.Done():
+		logrus.Infof("canceled %s", f.String())
+	default:
+		if err := f.svc.Flush(); err != nil {
+			logrus.WithError(err).Error("unable to flush the usage of the dashboards")
+		}
+	}
+	return nil
+}
+
+func (f *flusher) Finalize() error {
+	return f.svc.Flush()
+}
@@ -21,6 +21,7 @@ import (
 	databaseModel "github.com/perses/perses/internal/api/shared/database/model"
 	"github.com/perses/perses/internal/api/shared/schemas"
 	"github.com/perses/perses/pkg/model/api"
+	"github.com/perses/perses/pkg/model/api/admission"
 	v1 "github.com/perses/perses/pkg/model/api/v1"
 	"github.com/sirupsen/logrus"
 )
@@ -38,16 +39,19 @@ func NewService(dao variable.DAO, sch schemas.Schemas) variable.Service {
 	}
 }
 
-func (s *service) Create(entity api.Entity) (interface{}, error) {
+func (s *service) Create(admitter shared.Admitter, entity api.Entity) (interface{}, error) {
 	if variableObject, ok := entity.(*v1.Variable); ok {
-		return s.create(variableObject)
+		return s.create(admitter, variableObject)
 	}
 	return nil, shared.HandleBadRequestError(fmt.Sprintf("wrong entity format, attempting Variable format, received '%T'", entity))
 }
 
-func (s *service) create(entity *v1.Variable) (*v1.Variable, error) {
-	if err := s.sch.ValidateGlobalVariable(entity.Spec); err != nil {
-		return nil, shared.HandleBadRequestError(err.Error())
+func (s *service) create(admitter shared.Admitter, entity *v1.Variable) (*v1.Variable, error) {
+	if err := s.validate(entity); err != nil {
+		return nil, err
+	}
+	if err := admitter.Admit(admission.OperationCreate, entity, nil, func() error { return s.validate(entity) }); err != nil {
+		return nil, err
 	}
 	// Update the time contains in the entity
 	entity.Metadata.CreateNow()
@@ -57,14 +61,14 @@ func (s *service) create(entity *v1.Variable) (*v1.Variable, error) {
 	return entity, nil
 }
 
-func (s *service) Update(entity api.Entity, parameters shared.Parameters) (interface{}, error) {
+func (s *service) Update(admitter shared.Admitter, entity api.Entity, parameters shared.Parameters) (interface{}, error) {
 	if VariableObject, ok := entity.(*v1.Variable); ok {
-		return s.update(VariableObject, parameters)
+		return s.update(admitter, VariableObject, parameters)
 	}
 	return nil, shared.HandleBadRequestError(fmt.Sprintf("wrong entity format, attempting Variable format, received '%T'", entity))
 }
 
-func (s *service) update(entity *v1.Variable, parameters shared.Parameters) (*v1.Variable, error) {
+func (s *service) update(admitter shared.Admitter, entity *v1.Variable, parameters shared.Parameters) (*v1.Variable, error) {
 	if entity.Metadata.Name != parameters.Name {
 		logrus.Debugf("name in Variable %q and name from the http request %q don't match", entity.Metadata.Name, parameters.Name)
 		return nil, shared.HandleBadRequestError("metadata.name and the name in the http path request don't match")
@@ -76,8 +80,8 @@ func (s *service) update(entity *v1.Variable, parameters shared.Parameters) (*v1
 		return nil, shared.HandleBadRequestError("metadata.project and the project name in the http path request don't match")
 	}
 
-	if err := s.sch.ValidateGlobalVariable(entity.Spec); err != nil {
-		return nil, shared.HandleBadRequestError(err.Error())
+	if err := s.validate(entity); err != nil {
+		return nil, err
 	}
 
 	// find the previous version of the Variable
@@ -85,6 +89,9 @@ func (s *service) update(entity *v1.Variable, parameters shared.Parameters) (*v1
 	if err != nil {
 		return nil, err
 	}
+	if admissionErr := admitter.Admit(admission.OperationUpdate, entity, oldEntity, func() error { return s.validate(entity) }); admissionErr != nil {
+		return nil, admissionErr
+	}
 	entity.Metadata.Update(oldEntity.Metadata)
 	if updateErr := s.dao.Update(entity); updateErr != nil {
 		logrus.WithError(updateErr).Errorf("unable to perform the update of the Variable %q, something wrong with the database", entity.Metadata.Name)
@@ -93,7 +100,18 @@ func (s *service) update(entity *v1.Variable, parameters shared.Parameters) (*v1
 	return entity, nil
 }
 
-func (s *service) Delete(parameters shared.Parameters) error {
+func (s *service) validate(entity *v1.Variable) error {
+	if err := s.sch.ValidateGlobalVariable(entity.Spec); err != nil {
+		return shared.HandleBadRequestError(err.Error())
+	}
+	return nil
+}
+
+func (s *service) Delete(admitter shared.Admitter, parameters shared.Parameters) error {
+	getOld := func() (api.Entity, error) { return s.dao.Get(parameters.Project, parameters.Name) }
+	if err := admitter.AdmitDeletion(v1.KindVariable, getOld); err != nil {
+		return err
+	}
 	return s.dao.Delete(parameters.Project, parameters.Name)
 }
 
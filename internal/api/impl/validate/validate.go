@@ -35,6 +35,7 @@ func New(sch schemas.Schemas) *Endpoint {
 func (e *Endpoint) RegisterRoutes(g *echo.Group) {
 	path := "/validate"
 	g.POST(fmt.Sprintf("%s/%s", path, shared.PathDashboard), e.ValidateDashboard)
+	g.POST(fmt.Sprintf("%s/%s", path, shared.PathGlobalDashboard), e.ValidateGlobalDashboard)
 	g.POST(fmt.Sprintf("%s/%s", path, shared.PathDatasource), e.ValidateDatasource)
 	g.POST(fmt.Sprintf("%s/%s", path, shared.PathGlobalDatasource), e.ValidateGlobalDatasource)
 	g.POST(fmt.Sprintf("%s/%s", path, shared.PathVariable), e.ValidateVariable)
@@ -52,6 +53,17 @@ func (e *Endpoint) ValidateDashboard(ctx echo.Context) error {
 	return ctx.NoContent(http.StatusOK)
 }
 
+func (e *Endpoint) ValidateGlobalDashboard(ctx echo.Context) error {
+	entity := &v1.GlobalDashboard{}
+	if err := ctx.Bind(entity); err != nil {
+		return shared.HandleBadRequestError(err.Error())
+	}
+	if err := validate.GlobalDashboard(entity, e.sch); err != nil {
+		return shared.HandleBadRequestError(err.Error())
+	}
+	return ctx.NoContent(http.StatusOK)
+}
+
 func (e *Endpoint) ValidateDatasource(ctx echo.Context) error {
 	return validateDatasource(&v1.Datasource{}, e.sch, ctx)
 }
@@ -0,0 +1,50 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package access
+
+import (
+	"github.com/perses/perses/internal/api/shared"
+	databaseModel "github.com/perses/perses/internal/api/shared/database/model"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+type Query struct {
+	databaseModel.Query
+	// NamePrefix is a prefix of the DashboardAccess.metadata.name (so the name of the dashboard) that is used to filter the list.
+	NamePrefix string `query:"name"`
+	// Project is the exact name of the project.
+	Project string `query:"project"`
+}
+
+type DAO interface {
+	Update(entity *v1.DashboardAccess) error
+	Delete(project string, name string) error
+	DeleteAll(project string) error
+	Get(project string, name string) (*v1.DashboardAccess, error)
+}
+
+type Service interface {
+	// Get returns the grants of the dashboard. The list of grants is empty when nothing has been granted.
+	Get(project string, dashboard string) (*v1.DashboardAccess, error)
+	// Set replaces the grants of the dashboard.
+	Set(project string, dashboard string, spec v1.DashboardAccessSpec) (*v1.DashboardAccess, error)
+	// Delete removes every grant of the dashboard.
+	Delete(project string, dashboard string) error
+	// List returns who has access to the dashboard, through the project permissions or through the grants of the dashboard.
+	List(project string, dashboard string) ([]v1.DashboardAccessEntry, error)
+	// GetRole returns the role granted on the dashboard to the user, or an empty role if nothing is granted.
+	GetRole(identity *shared.Identity, project string, dashboard string) v1.Role
+	// UsesDatasource returns true when the dashboard is querying the datasource of the project.
+	UsesDatasource(project string, dashboard string, datasource string) bool
+}
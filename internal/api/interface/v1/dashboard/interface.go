@@ -24,11 +24,17 @@ type Query struct {
 	// NamePrefix is a prefix of the Dashboard.metadata.name that is used to filter the list of the Dashboard.
 	// NamePrefix can be empty in case you want to return the full list of Dashboard available.
 	NamePrefix string `query:"name"`
+	// Team is the name of a team. When set, only the resources owned by this team are returned.
+	Team string `query:"team"`
 	// Project is the exact name of the project.
 	// The value can come from the path of the URL or from the query parameter
 	Project string `param:"project" query:"project"`
 }
 
+func (q *Query) GetTeam() string {
+	return q.Team
+}
+
 type DAO interface {
 	Create(entity *v1.Dashboard) error
 	Update(entity *v1.Dashboard) error
@@ -40,4 +46,10 @@ type DAO interface {
 
 type Service interface {
 	shared.ToolboxService
+	// Merge applies on the dashboard currently stored the changes done between request.Base and request.Dashboard.
+	// When the changes cannot be merged automatically, the error returned contains the list of the conflicts.
+	// The dashboard merged is sent to the admission webhooks.
+	Merge(admitter shared.Admitter, request *v1.DashboardMerge, parameters shared.Parameters) (*v1.Dashboard, error)
+	// ResolveAlias returns the name of the dashboard that was previously named name.
+	ResolveAlias(project string, name string) (string, error)
 }
@@ -0,0 +1,36 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dashboardalias
+
+import (
+	databaseModel "github.com/perses/perses/internal/api/shared/database/model"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+type Query struct {
+	databaseModel.Query
+	// NamePrefix is a prefix of the DashboardAlias.metadata.name (so the previous name of the dashboard) that is used to filter the list.
+	NamePrefix string `query:"name"`
+	// Project is the exact name of the project. It can be empty to get the aliases of every project.
+	Project string `query:"project"`
+}
+
+type DAO interface {
+	Create(entity *v1.DashboardAlias) error
+	Update(entity *v1.DashboardAlias) error
+	Delete(project string, name string) error
+	DeleteAll(project string) error
+	Get(project string, name string) (*v1.DashboardAlias, error)
+	List(q databaseModel.Query) ([]*v1.DashboardAlias, error)
+}
@@ -24,6 +24,8 @@ type Query struct {
 	// NamePrefix is a prefix of the Datasource.metadata.name that is used to filter the list of the Datasource.
 	// NamePrefix can be empty in case you want to return the full list of Datasource available.
 	NamePrefix string `query:"name"`
+	// Team is the name of a team. When set, only the resources owned by this team are returned.
+	Team string `query:"team"`
 	// Project is the exact name of the project.
 	// The value can come from the path of the URL or from the query parameter
 	Project string `param:"project" query:"project"`
@@ -33,6 +35,10 @@ type Query struct {
 	Default *bool `query:"default"`
 }
 
+func (q *Query) GetTeam() string {
+	return q.Team
+}
+
 type DAO interface {
 	Create(entity *v1.Datasource) error
 	Update(entity *v1.Datasource) error
@@ -0,0 +1,67 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package draft
+
+import (
+	"github.com/perses/perses/internal/api/shared"
+	databaseModel "github.com/perses/perses/internal/api/shared/database/model"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+type Query struct {
+	databaseModel.Query
+	// NamePrefix is a prefix of the DashboardDraft.metadata.name (so the name of the dashboard) that is used to filter the list of the drafts.
+	NamePrefix string `query:"name"`
+	// Project is the exact name of the project.
+	// The value can come from the path of the URL or from the query parameter
+	Project string `param:"project" query:"project"`
+}
+
+// PublishQuery tells how to publish a draft that is outdated, because the dashboard has been published again since the
+// draft has been created.
+type PublishQuery struct {
+	// Force replaces the published dashboard with the draft, losing the changes published in the meantime.
+	Force bool `query:"force"`
+	// Merge applies the changes of the draft on the published dashboard.
+	Merge bool `query:"merge"`
+}
+
+type DAO interface {
+	Create(entity *v1.DashboardDraft) error
+	Update(entity *v1.DashboardDraft) error
+	Delete(project string, name string) error
+	DeleteAll(project string) error
+	Get(project string, name string) (*v1.DashboardDraft, error)
+	List(q databaseModel.Query) ([]*v1.DashboardDraft, error)
+}
+
+type Service interface {
+	// Save creates or updates the draft of the dashboard. The published dashboard is not modified.
+	// author is the user saving the draft, empty when the authentication is not enabled.
+	Save(admitter shared.Admitter, entity *v1.Dashboard, project string, name string, author string) (*v1.DashboardDraft, error)
+	// Discard deletes the draft of the dashboard.
+	Discard(project string, name string) error
+	Get(project string, name string) (*v1.DashboardDraft, error)
+	List(q *Query) ([]*v1.DashboardDraft, error)
+	// Preview returns the dashboard as it will be once the draft is published.
+	Preview(project string, name string) (*v1.Dashboard, error)
+	// Diff returns the differences between the published dashboard and the draft.
+	Diff(project string, name string) (*v1.DashboardDraftDiff, error)
+	RequestReview(project string, name string) (*v1.DashboardDraft, error)
+	// Approve records the reviewer approving the draft. The author of the draft cannot approve it.
+	Approve(project string, name string, reviewer string) (*v1.DashboardDraft, error)
+	// Publish replaces the published dashboard with the draft, then deletes the draft.
+	// An outdated draft is rejected, unless the query asks to force or to merge it.
+	Publish(admitter shared.Admitter, project string, name string, q *PublishQuery) (*v1.Dashboard, error)
+}
@@ -0,0 +1,55 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ephemeraldashboard
+
+import (
+	"github.com/perses/perses/internal/api/shared"
+	databaseModel "github.com/perses/perses/internal/api/shared/database/model"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+type Query struct {
+	databaseModel.Query
+	// NamePrefix is a prefix of the EphemeralDashboard.metadata.name that is used to filter the list of the EphemeralDashboard.
+	// NamePrefix can be empty in case you want to return the full list of EphemeralDashboard available.
+	NamePrefix string `query:"name"`
+	// Team is the name of a team. When set, only the resources owned by this team are returned.
+	Team string `query:"team"`
+	// Project is the exact name of the project.
+	// The value can come from the path of the URL or from the query parameter
+	Project string `param:"project" query:"project"`
+	// ExpireWithin is a duration (like 1h or 2d). When set, only the dashboards expiring before now + ExpireWithin are returned.
+	ExpireWithin string `query:"expire_within"`
+}
+
+func (q *Query) GetTeam() string {
+	return q.Team
+}
+
+type DAO interface {
+	Create(entity *v1.EphemeralDashboard) error
+	Update(entity *v1.EphemeralDashboard) error
+	Delete(project string, name string) error
+	DeleteAll(project string) error
+	Get(project string, name string) (*v1.EphemeralDashboard, error)
+	List(q databaseModel.Query) ([]*v1.EphemeralDashboard, error)
+}
+
+type Service interface {
+	shared.ToolboxService
+	// Extend postpones the expiry of the dashboard.
+	Extend(admitter shared.Admitter, extension *v1.EphemeralDashboardExtension, parameters shared.Parameters) (*v1.EphemeralDashboard, error)
+	// DeleteExpired deletes all dashboards that have expired.
+	DeleteExpired() error
+}
@@ -24,11 +24,17 @@ type Query struct {
 	// NamePrefix is a prefix of the Folders.metadata.name that is used to filter the list of the Folders.
 	// NamePrefix can be empty in case you want to return the full list of Folders available.
 	NamePrefix string `query:"name"`
+	// Team is the name of a team. When set, only the resources owned by this team are returned.
+	Team string `query:"team"`
 	// Project is the exact name of the project.
 	// The value can come from the path of the URL or from the query parameter
 	Project string `param:"project" query:"project"`
 }
 
+func (q *Query) GetTeam() string {
+	return q.Team
+}
+
 type DAO interface {
 	Create(entity *v1.Folder) error
 	Update(entity *v1.Folder) error
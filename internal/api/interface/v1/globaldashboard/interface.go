@@ -0,0 +1,47 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package globaldashboard
+
+import (
+	"github.com/perses/perses/internal/api/shared"
+	databaseModel "github.com/perses/perses/internal/api/shared/database/model"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+type Query struct {
+	databaseModel.Query
+	// NamePrefix is a prefix of the GlobalDashboard.metadata.name that is used to filter the list of the GlobalDashboard.
+	// NamePrefix can be empty in case you want to return the full list of GlobalDashboard available.
+	NamePrefix string `query:"name"`
+	// Team is the name of a team. When set, only the resources owned by this team are returned.
+	Team string `query:"team"`
+}
+
+func (q *Query) GetTeam() string {
+	return q.Team
+}
+
+type DAO interface {
+	Create(entity *v1.GlobalDashboard) error
+	Update(entity *v1.GlobalDashboard) error
+	Delete(name string) error
+	Get(name string) (*v1.GlobalDashboard, error)
+	List(q databaseModel.Query) ([]*v1.GlobalDashboard, error)
+}
+
+type Service interface {
+	shared.ToolboxService
+	// GetInProject returns the global dashboard as a dashboard of the project, so it uses the datasources of the project.
+	GetInProject(project string, name string) (*v1.Dashboard, error)
+}
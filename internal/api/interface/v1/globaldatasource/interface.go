@@ -24,12 +24,18 @@ type Query struct {
 	// NamePrefix is a prefix of the GlobalDatasource.metadata.name that is used to filter the list of the GlobalDatasource.
 	// NamePrefix can be empty in case you want to return the full list of GlobalDatasource available.
 	NamePrefix string `query:"name"`
+	// Team is the name of a team. When set, only the resources owned by this team are returned.
+	Team string `query:"team"`
 	// Kind is the type of the datasource.
 	Kind string `query:"kind"`
 	// Default will filter the list of datasource and return only the default datasource, whatever the kind of the datasource is.
 	Default *bool `query:"default"`
 }
 
+func (q *Query) GetTeam() string {
+	return q.Team
+}
+
 type DAO interface {
 	Create(entity *v1.GlobalDatasource) error
 	Update(entity *v1.GlobalDatasource) error
@@ -40,4 +46,8 @@ type DAO interface {
 
 type Service interface {
 	shared.ToolboxService
+	// GetInProject returns the global datasource as it is used by the project, once the override of the project is merged over it.
+	GetInProject(project string, name string) (*v1.GlobalDatasource, error)
+	// ListInProject returns the global datasources as they are used by the project, once the overrides of the project are merged over them.
+	ListInProject(project string, q *Query) ([]*v1.GlobalDatasource, error)
 }
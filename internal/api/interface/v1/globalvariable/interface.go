@@ -24,7 +24,14 @@ type Query struct {
 	// NamePrefix is a prefix of the GlobalVariable.metadata.name that is used to filter the list of the GlobalVariable.
 	// NamePrefix can be empty in case you want to return the full list of GlobalVariable available.
 	NamePrefix string `query:"name"`
+	// Team is the name of a team. When set, only the resources owned by this team are returned.
+	Team string `query:"team"`
 }
+
+func (q *Query) GetTeam() string {
+	return q.Team
+}
+
 type DAO interface {
 	Create(entity *v1.GlobalVariable) error
 	Update(entity *v1.GlobalVariable) error
@@ -0,0 +1,49 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package homeconfig
+
+import (
+	"github.com/perses/perses/internal/api/shared"
+	databaseModel "github.com/perses/perses/internal/api/shared/database/model"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+type Query struct {
+	databaseModel.Query
+	// NamePrefix is a prefix of the HomeConfig.metadata.name that is used to filter the list of the HomeConfig.
+	// NamePrefix can be empty in case you want to return the full list of HomeConfig available.
+	NamePrefix string `query:"name"`
+	// Project is the exact name of the project.
+	// The value can come from the path of the URL or from the query parameter
+	Project string `param:"project" query:"project"`
+}
+
+type DAO interface {
+	Create(entity *v1.HomeConfig) error
+	Update(entity *v1.HomeConfig) error
+	Delete(project string, name string) error
+	DeleteAll(project string) error
+	Get(project string, name string) (*v1.HomeConfig, error)
+	List(q databaseModel.Query) ([]*v1.HomeConfig, error)
+}
+
+type Service interface {
+	shared.ToolboxService
+	// GetHome returns the configuration of the home page of the project: the GlobalHomeConfig overridden by the
+	// HomeConfig of the project. When project is empty, only the GlobalHomeConfig is considered.
+	GetHome(project string) (*v1.HomeConfigSpec, error)
+	// RemoveDashboard removes the references to the dashboard from every home config.
+	// When dashboard is empty, the references to every dashboard of the project are removed.
+	RemoveDashboard(project string, dashboard string) error
+}
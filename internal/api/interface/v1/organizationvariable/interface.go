@@ -0,0 +1,49 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package organizationvariable
+
+import (
+	"github.com/perses/perses/internal/api/shared"
+	databaseModel "github.com/perses/perses/internal/api/shared/database/model"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+type Query struct {
+	databaseModel.Query
+	// NamePrefix is a prefix of the OrganizationVariable.metadata.name that is used to filter the list of the OrganizationVariable.
+	// NamePrefix can be empty in case you want to return the full list of OrganizationVariable available.
+	NamePrefix string `query:"name"`
+	// Team is the name of a team. When set, only the resources owned by this team are returned.
+	Team string `query:"team"`
+	// Organization is the exact name of the organization.
+	// The value can come from the path of the URL or from the query parameter
+	Organization string `param:"organization" query:"organization"`
+}
+
+func (q *Query) GetTeam() string {
+	return q.Team
+}
+
+type DAO interface {
+	Create(entity *v1.OrganizationVariable) error
+	Update(entity *v1.OrganizationVariable) error
+	Delete(organization string, name string) error
+	DeleteAll(organization string) error
+	Get(organization string, name string) (*v1.OrganizationVariable, error)
+	List(q databaseModel.Query) ([]*v1.OrganizationVariable, error)
+}
+
+type Service interface {
+	shared.ToolboxService
+}
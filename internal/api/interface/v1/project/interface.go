@@ -24,13 +24,24 @@ type Query struct {
 	// NamePrefix is a prefix of the project.metadata.name that is used to filter the list of the project.
 	// NamePrefix can be empty in case you want to return the full list of project available.
 	NamePrefix string `query:"name"`
+	// Team is the name of a team. When set, only the resources owned by this team are returned.
+	Team string `query:"team"`
+	// Organization is the exact name of the organization.
+	// The value can come from the path of the URL or from the query parameter
+	Organization string `param:"organization" query:"organization"`
+}
+
+func (q *Query) GetTeam() string {
+	return q.Team
 }
 
 type DAO interface {
 	Create(entity *v1.Project) error
 	Update(entity *v1.Project) error
-	Delete(name string) error
-	Get(name string) (*v1.Project, error)
+	Delete(organization string, name string) error
+	Get(organization string, name string) (*v1.Project, error)
+	// Find returns the project whatever its organization is. The name of a project is unique across the organizations.
+	Find(name string) (*v1.Project, error)
 	List(q databaseModel.Query) ([]*v1.Project, error)
 }
 
@@ -0,0 +1,85 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sharetoken
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/labstack/echo/v4"
+	databaseModel "github.com/perses/perses/internal/api/shared/database/model"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+var (
+	// ErrInvalidToken is returned when the token is malformed, unknown, revoked or expired.
+	ErrInvalidToken = echo.NewHTTPError(http.StatusUnauthorized, "invalid or expired share token")
+	// ErrRateLimited is returned when the token has been used too many times in the last minute.
+	ErrRateLimited = echo.NewHTTPError(http.StatusTooManyRequests, "too many requests made with the share token")
+)
+
+type Query struct {
+	databaseModel.Query
+	// NamePrefix is a prefix of the ShareToken.metadata.name that is used to filter the list of the ShareToken.
+	NamePrefix string `query:"name"`
+	// Project is the exact name of the project.
+	// The value can come from the path of the URL or from the query parameter
+	Project string `param:"project" query:"project"`
+	// Dashboard is the exact name of the dashboard. When set, only the tokens sharing this dashboard are returned.
+	Dashboard string `query:"dashboard"`
+}
+
+// ProxyRequest describes a request made with a share token to the proxy of the datasources.
+type ProxyRequest struct {
+	// Project is set when the datasource is queried through the path of a project.
+	Project string
+	// Organization is set when a datasource of an organization is queried.
+	Organization string
+	// Global is true when a global datasource is queried without the path of a project.
+	Global     bool
+	Datasource string
+	// Path is the path forwarded to the datasource.
+	Path string
+	// Parameters are the parameters of the URL and of the form sent in the body.
+	Parameters url.Values
+}
+
+type DAO interface {
+	Create(entity *v1.ShareToken) error
+	Delete(project string, name string) error
+	DeleteAll(project string) error
+	Get(project string, name string) (*v1.ShareToken, error)
+	List(q databaseModel.Query) ([]*v1.ShareToken, error)
+}
+
+type Service interface {
+	// Create generates a new token sharing the dashboard. The token is only returned by this method.
+	Create(project string, dashboard string, spec v1.ShareTokenSpec) (*v1.ShareTokenCreation, error)
+	// Delete revokes the token.
+	Delete(project string, name string) error
+	Get(project string, name string) (*v1.ShareToken, error)
+	List(q *Query) ([]*v1.ShareToken, error)
+	// DeleteExpired deletes all tokens that have expired.
+	DeleteExpired() error
+	// Verify returns the ShareToken matching the token if it is valid and not expired.
+	// It returns ErrRateLimited when too many requests have been made with the token.
+	Verify(token string) (*v1.ShareToken, error)
+	// GetDashboard returns the dashboard shared with the token, with the variables and the time range it imposes.
+	GetDashboard(token string) (*v1.SharedDashboard, error)
+	// UsesDatasource returns true when the dashboard shared with the token is querying the datasource of the project.
+	UsesDatasource(shareToken *v1.ShareToken, datasource string) bool
+	// AuthorizeProxyRequest returns an error when the request is not one of the queries of the dashboard shared with
+	// the token, with the variables and the time range it imposes.
+	AuthorizeProxyRequest(shareToken *v1.ShareToken, request *ProxyRequest) error
+}
@@ -0,0 +1,42 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package team
+
+import (
+	"github.com/perses/perses/internal/api/shared"
+	databaseModel "github.com/perses/perses/internal/api/shared/database/model"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+type Query struct {
+	databaseModel.Query
+	// NamePrefix is a prefix of the Team.metadata.name that is used to filter the list of the Team.
+	// NamePrefix can be empty in case you want to return the full list of Team available.
+	NamePrefix string `query:"name"`
+	// Member is the username of a user. When set, only the teams the user is a member of are returned.
+	// The membership through the external groups is not considered since the groups are only known at the login.
+	Member string `query:"member"`
+}
+
+type DAO interface {
+	Create(entity *v1.Team) error
+	Update(entity *v1.Team) error
+	Delete(name string) error
+	Get(name string) (*v1.Team, error)
+	List(q databaseModel.Query) ([]*v1.Team, error)
+}
+
+type Service interface {
+	shared.ToolboxService
+}
@@ -0,0 +1,44 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usage
+
+import (
+	databaseModel "github.com/perses/perses/internal/api/shared/database/model"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+type Query struct {
+	databaseModel.Query
+	// NamePrefix is a prefix of the DashboardUsage.metadata.name (so the name of the dashboard) that is used to filter the list.
+	NamePrefix string `query:"name"`
+	// Project is the exact name of the project. It can be empty to get the usage of every project.
+	Project string `query:"project"`
+}
+
+type DAO interface {
+	Update(entity *v1.DashboardUsage) error
+	DeleteAll(project string) error
+	Get(project string, name string) (*v1.DashboardUsage, error)
+	List(q databaseModel.Query) ([]*v1.DashboardUsage, error)
+}
+
+type Service interface {
+	// Record counts a view of the dashboard. The views are kept in memory until the next call of Flush.
+	Record(project string, dashboard string)
+	// View verifies the dashboard exists before recording a view.
+	View(project string, dashboard string) error
+	// Flush aggregates the views recorded in memory with the ones stored in the database.
+	Flush() error
+	ListDashboards(q *v1.DashboardUsageQuery) ([]*v1.DashboardUsageSummary, error)
+}
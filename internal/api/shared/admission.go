@@ -0,0 +1,89 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shared
+
+import (
+	"github.com/labstack/echo/v4"
+	databaseModel "github.com/perses/perses/internal/api/shared/database/model"
+	"github.com/perses/perses/pkg/model/api"
+	"github.com/perses/perses/pkg/model/api/admission"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+const admissionControllerContextKey = "perses.admission_controller"
+
+// AdmissionController calls the admission webhooks on the resources written by the users.
+type AdmissionController interface {
+	// Match returns true when at least one webhook is interested in the operation on the kind.
+	Match(kind v1.Kind, operation admission.Operation) bool
+	// Admit calls the webhooks interested in the operation. entity is nil when the resource is deleted, oldEntity is nil
+	// when the resource is created. The patches returned by the mutating webhooks are applied on entity.
+	Admit(identity *Identity, operation admission.Operation, entity api.Entity, oldEntity api.Entity) error
+}
+
+// SetAdmissionController attaches to the request the controller calling the admission webhooks.
+// It is only set when at least one webhook is configured.
+func SetAdmissionController(ctx echo.Context, controller AdmissionController) {
+	ctx.Set(admissionControllerContextKey, controller)
+}
+
+// Admitter sends the resources written by a user to the admission webhooks. The endpoints build it from the request
+// with NewAdmitter and give it to the services, that call it once the resource has passed their validation. The zero
+// value admits everything, it is used for the writes that are not done on behalf of a user.
+type Admitter struct {
+	controller AdmissionController
+	identity   *Identity
+}
+
+// NewAdmitter returns the admitter of the user sending the request.
+func NewAdmitter(ctx echo.Context) Admitter {
+	controller, _ := ctx.Get(admissionControllerContextKey).(AdmissionController)
+	return Admitter{controller: controller, identity: GetIdentity(ctx)}
+}
+
+// Admit sends the resource to the admission webhooks. oldEntity is the resource currently stored, it is nil for a
+// creation. As a mutating webhook can modify the resource, validate is called again on the resource admitted. It is nil
+// when the service has no validation of its own.
+func (a Admitter) Admit(operation admission.Operation, entity api.Entity, oldEntity api.Entity, validate func() error) error {
+	if a.controller == nil || !a.controller.Match(v1.Kind(entity.GetKind()), operation) {
+		return nil
+	}
+	if err := a.controller.Admit(a.identity, operation, entity, oldEntity); err != nil {
+		return err
+	}
+	if err := validateMetadataContent(entity.GetMetadata()); err != nil {
+		return HandleBadRequestError(err.Error())
+	}
+	if validate == nil {
+		return nil
+	}
+	return validate()
+}
+
+// AdmitDeletion sends the deletion of the resource to the admission webhooks. getOld returns the resource currently
+// stored, it is only called when a webhook is interested in the deletion.
+func (a Admitter) AdmitDeletion(kind v1.Kind, getOld func() (api.Entity, error)) error {
+	if a.controller == nil || !a.controller.Match(kind, admission.OperationDelete) {
+		return nil
+	}
+	oldEntity, err := getOld()
+	if err != nil {
+		if databaseModel.IsKeyNotFound(err) {
+			// the service returns the error
+			return nil
+		}
+		return err
+	}
+	return a.controller.Admit(a.identity, admission.OperationDelete, nil, oldEntity)
+}
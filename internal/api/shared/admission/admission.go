@@ -0,0 +1,282 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package admission calls the admission webhooks configured before a resource is created, updated or deleted by a user.
+// The resource modified by the mutating webhooks goes through the validation of the service afterward.
+package admission
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/config"
+	"github.com/perses/perses/internal/api/shared"
+	modelAPI "github.com/perses/perses/pkg/model/api"
+	"github.com/perses/perses/pkg/model/api/admission"
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+	promConfig "github.com/prometheus/common/config"
+	"github.com/sirupsen/logrus"
+)
+
+// maxResponseSize is the maximum size of the body returned by a webhook.
+const maxResponseSize = 1 << 20
+
+type webhook struct {
+	config.AdmissionWebhook
+	client *http.Client
+}
+
+func newWebhook(conf config.AdmissionWebhook) (*webhook, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if conf.TLSConfig != nil {
+		tlsConfig, err := promConfig.NewTLSConfig(conf.TLSConfig)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tls_config for the admission webhook %q: %w", conf.Name, err)
+		}
+		transport.TLSClientConfig = tlsConfig
+	}
+	return &webhook{
+		AdmissionWebhook: conf,
+		client: &http.Client{
+			Transport: transport,
+			Timeout:   time.Duration(conf.Timeout),
+		},
+	}, nil
+}
+
+func (w *webhook) match(kind modelV1.Kind, operation admission.Operation) bool {
+	return matchKind(w.Kinds, kind) && matchOperation(w.Operations, operation)
+}
+
+func matchKind(kinds []string, kind modelV1.Kind) bool {
+	if len(kinds) == 0 {
+		return true
+	}
+	for _, k := range kinds {
+		if modelV1.Kind(k) == kind {
+			return true
+		}
+	}
+	return false
+}
+
+func matchOperation(operations []admission.Operation, operation admission.Operation) bool {
+	if len(operations) == 0 {
+		return true
+	}
+	for _, op := range operations {
+		if op == operation {
+			return true
+		}
+	}
+	return false
+}
+
+// call sends the request to the webhook and decodes its response.
+func (w *webhook) call(request *admission.Request) (*admission.Response, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := w.client.Post(w.URL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return nil, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, err
+	}
+	response := &admission.Response{}
+	if unmarshalErr := json.Unmarshal(data, response); unmarshalErr != nil {
+		return nil, fmt.Errorf("invalid response: %w", unmarshalErr)
+	}
+	return response, nil
+}
+
+// Controller calls the admission webhooks configured. It is used by the services when they write the resources of the
+// users, so the writes done by Perses itself, like the update of the usage or the deletions in cascade, are not sent to
+// them.
+type Controller struct {
+	mutating   []*webhook
+	validating []*webhook
+}
+
+// New returns the controller calling the webhooks. It returns nil when there is no webhook.
+func New(webhooks []config.AdmissionWebhook) (*Controller, error) {
+	if len(webhooks) == 0 {
+		return nil, nil
+	}
+	c := &Controller{}
+	for _, conf := range webhooks {
+		w, err := newWebhook(conf)
+		if err != nil {
+			return nil, err
+		}
+		if w.Type == config.AdmissionWebhookMutating {
+			c.mutating = append(c.mutating, w)
+		} else {
+			c.validating = append(c.validating, w)
+		}
+	}
+	return c, nil
+}
+
+func (c *Controller) Match(kind modelV1.Kind, operation admission.Operation) bool {
+	for _, w := range c.mutating {
+		if w.match(kind, operation) {
+			return true
+		}
+	}
+	for _, w := range c.validating {
+		if w.match(kind, operation) {
+			return true
+		}
+	}
+	return false
+}
+
+// Admit calls the mutating webhooks, then the validating webhooks on the final version of the entity.
+// entity is nil when the resource is deleted, oldEntity is nil when the resource is created.
+func (c *Controller) Admit(identity *shared.Identity, operation admission.Operation, entity modelAPI.Entity, oldEntity modelAPI.Entity) error {
+	var kind modelV1.Kind
+	request := &admission.Request{Operation: operation}
+	if entity != nil {
+		kind = modelV1.Kind(entity.GetKind())
+	} else {
+		kind = modelV1.Kind(oldEntity.GetKind())
+	}
+	request.Kind = kind
+	if identity != nil {
+		request.User = &admission.UserInfo{
+			Username: identity.Username,
+			Email:    identity.Email,
+			Groups:   identity.Groups,
+		}
+	}
+	if oldEntity != nil {
+		oldObject, err := json.Marshal(oldEntity)
+		if err != nil {
+			return err
+		}
+		request.OldObject = oldObject
+	}
+	for _, w := range c.mutating {
+		if !w.match(kind, operation) {
+			continue
+		}
+		if err := marshalObject(request, entity); err != nil {
+			return err
+		}
+		response, err := w.call(request)
+		if err == nil && response.Allowed && len(response.Patch) > 0 && entity != nil {
+			err = applyPatch(entity, request.Object, response.Patch)
+		}
+		if handleErr := handleResponse(w, response, err); handleErr != nil {
+			return handleErr
+		}
+	}
+	if err := marshalObject(request, entity); err != nil {
+		return err
+	}
+	for _, w := range c.validating {
+		if !w.match(kind, operation) {
+			continue
+		}
+		response, err := w.call(request)
+		if handleErr := handleResponse(w, response, err); handleErr != nil {
+			return handleErr
+		}
+	}
+	return nil
+}
+
+func marshalObject(request *admission.Request, entity modelAPI.Entity) error {
+	if entity == nil {
+		return nil
+	}
+	object, err := json.Marshal(entity)
+	if err != nil {
+		return err
+	}
+	request.Object = object
+	return nil
+}
+
+// handleResponse turns the response of the webhook, or the error that occurred while calling it, into the error
+// returned to the user. The failure policy decides if an error must reject the request.
+func handleResponse(w *webhook, response *admission.Response, err error) error {
+	if err != nil {
+		if w.FailurePolicy == config.FailurePolicyOpen {
+			logrus.WithError(err).Warningf("admission webhook %q failed, the request is admitted as its failure policy is open", w.Name)
+			return nil
+		}
+		logrus.WithError(err).Errorf("admission webhook %q failed", w.Name)
+		return echo.NewHTTPError(http.StatusBadGateway, fmt.Sprintf("admission webhook %q failed: %s", w.Name, err))
+	}
+	if !response.Allowed {
+		msg := fmt.Sprintf("admission webhook %q denied the request", w.Name)
+		if len(response.Message) > 0 {
+			msg = fmt.Sprintf("%s: %s", msg, response.Message)
+		}
+		return echo.NewHTTPError(http.StatusForbidden, msg)
+	}
+	return nil
+}
+
+// applyPatch applies the patch on the JSON representation of the entity and decodes the result into the entity.
+// The patch is not allowed to change the kind or the identity of the resource.
+func applyPatch(entity modelAPI.Entity, object []byte, patch []admission.PatchOperation) error {
+	patched, err := ApplyPatch(object, patch)
+	if err != nil {
+		return err
+	}
+	value := reflect.New(reflect.TypeOf(entity).Elem())
+	patchedEntity := value.Interface().(modelAPI.Entity)
+	if unmarshalErr := json.Unmarshal(patched, patchedEntity); unmarshalErr != nil {
+		return fmt.Errorf("the patched resource is invalid: %w", unmarshalErr)
+	}
+	if patchedEntity.GetKind() != entity.GetKind() || !sameIdentity(entity.GetMetadata(), patchedEntity.GetMetadata()) {
+		return fmt.Errorf("the patch is not allowed to change the kind, the name, the project or the organization of the resource")
+	}
+	reflect.ValueOf(entity).Elem().Set(value.Elem())
+	return nil
+}
+
+type identity struct {
+	Name         string `json:"name"`
+	Project      string `json:"project"`
+	Organization string `json:"organization"`
+}
+
+func sameIdentity(a, b modelAPI.Metadata) bool {
+	return getIdentity(a) == getIdentity(b)
+}
+
+func getIdentity(metadata modelAPI.Metadata) identity {
+	result := identity{}
+	data, err := json.Marshal(metadata)
+	if err == nil {
+		_ = json.Unmarshal(data, &result)
+	}
+	return result
+}
@@ -0,0 +1,235 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admission
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/config"
+	"github.com/perses/perses/internal/api/shared"
+	"github.com/perses/perses/pkg/model/api/admission"
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/common"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+)
+
+// newReceiver starts a local webhook answering with the given response and recording the requests received.
+func newReceiver(t *testing.T, response admission.Response, requests *[]admission.Request) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request := admission.Request{}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if requests != nil {
+			*requests = append(*requests, request)
+		}
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newController(t *testing.T, webhooks ...config.AdmissionWebhook) *Controller {
+	for i := range webhooks {
+		assert.NoError(t, webhooks[i].Verify())
+	}
+	c, err := New(webhooks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+var user = &shared.Identity{Username: "alice", Email: "alice@example.com", Groups: []string{"admins"}}
+
+func newOrganization(name string) *modelV1.Organization {
+	return &modelV1.Organization{
+		Kind:     modelV1.KindOrganization,
+		Metadata: modelV1.Metadata{Name: name},
+	}
+}
+
+func assertHTTPError(t *testing.T, err error, code int) {
+	httpErr, ok := err.(*echo.HTTPError)
+	if assert.True(t, ok, "unexpected error %v", err) {
+		assert.Equal(t, code, httpErr.Code)
+	}
+}
+
+func TestNewNoWebhook(t *testing.T) {
+	c, err := New(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, c)
+}
+
+func TestValidatingWebhook(t *testing.T) {
+	var requests []admission.Request
+	receiver := newReceiver(t, admission.Response{Allowed: false, Message: "the display name is mandatory"}, &requests)
+	c := newController(t, config.AdmissionWebhook{
+		Name:       "policy",
+		Type:       config.AdmissionWebhookValidating,
+		URL:        receiver.URL,
+		Operations: []admission.Operation{admission.OperationCreate},
+	})
+	err := c.Admit(user, admission.OperationCreate, newOrganization("acme"), nil)
+	assertHTTPError(t, err, http.StatusForbidden)
+	assert.Contains(t, err.(*echo.HTTPError).Message, "the display name is mandatory")
+	if assert.Len(t, requests, 1) {
+		assert.Equal(t, modelV1.KindOrganization, requests[0].Kind)
+		assert.Equal(t, admission.OperationCreate, requests[0].Operation)
+		assert.Empty(t, requests[0].OldObject)
+		assert.Equal(t, &admission.UserInfo{Username: "alice", Email: "alice@example.com", Groups: []string{"admins"}}, requests[0].User)
+	}
+}
+
+func TestValidatingWebhookFilter(t *testing.T) {
+	var requests []admission.Request
+	receiver := newReceiver(t, admission.Response{Allowed: false}, &requests)
+	c := newController(t, config.AdmissionWebhook{
+		Name:  "dashboards",
+		Type:  config.AdmissionWebhookValidating,
+		URL:   receiver.URL,
+		Kinds: []string{string(modelV1.KindDashboard)},
+	})
+	assert.False(t, c.Match(modelV1.KindOrganization, admission.OperationCreate))
+	assert.True(t, c.Match(modelV1.KindDashboard, admission.OperationDelete))
+	assert.NoError(t, c.Admit(user, admission.OperationCreate, newOrganization("acme"), nil))
+	assert.NoError(t, c.Admit(nil, admission.OperationDelete, nil, newOrganization("acme")))
+	assert.Empty(t, requests)
+}
+
+func TestMutatingWebhook(t *testing.T) {
+	var requests []admission.Request
+	mutating := newReceiver(t, admission.Response{
+		Allowed: true,
+		Patch: []admission.PatchOperation{
+			{Op: "add", Path: "/spec/display", Value: []byte(`{"name":"ACME"}`)},
+		},
+	}, nil)
+	validating := newReceiver(t, admission.Response{Allowed: true}, &requests)
+	c := newController(t,
+		config.AdmissionWebhook{Name: "validate", Type: config.AdmissionWebhookValidating, URL: validating.URL},
+		config.AdmissionWebhook{Name: "display", Type: config.AdmissionWebhookMutating, URL: mutating.URL},
+	)
+	entity := newOrganization("acme")
+	assert.NoError(t, c.Admit(user, admission.OperationCreate, entity, nil))
+	assert.Equal(t, &common.Display{Name: "ACME"}, entity.Spec.Display)
+
+	// the validating webhook receives the patched resource
+	if assert.Len(t, requests, 1) {
+		result := &modelV1.Organization{}
+		assert.NoError(t, json.Unmarshal(requests[0].Object, result))
+		assert.Equal(t, "ACME", result.Spec.Display.Name)
+	}
+
+	// an update comes with the resource currently stored
+	assert.NoError(t, c.Admit(user, admission.OperationUpdate, newOrganization("acme"), entity))
+	if assert.Len(t, requests, 2) {
+		assert.Equal(t, admission.OperationUpdate, requests[1].Operation)
+		assert.NotEmpty(t, requests[1].OldObject)
+	}
+}
+
+func TestMutatingWebhookChangeIdentity(t *testing.T) {
+	receiver := newReceiver(t, admission.Response{
+		Allowed: true,
+		Patch:   []admission.PatchOperation{{Op: "replace", Path: "/metadata/name", Value: []byte(`"other"`)}},
+	}, nil)
+	c := newController(t, config.AdmissionWebhook{Name: "rename", Type: config.AdmissionWebhookMutating, URL: receiver.URL})
+	assertHTTPError(t, c.Admit(user, admission.OperationCreate, newOrganization("acme"), nil), http.StatusBadGateway)
+}
+
+func TestWebhookFailurePolicy(t *testing.T) {
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		_ = json.NewEncoder(w).Encode(admission.Response{Allowed: true})
+	}))
+	t.Cleanup(slow.Close)
+	broken := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	t.Cleanup(broken.Close)
+
+	testSuite := []struct {
+		title  string
+		url    string
+		policy config.FailurePolicy
+		code   int
+	}{
+		{
+			title:  "timeout with fail closed",
+			url:    slow.URL,
+			policy: config.FailurePolicyClosed,
+			code:   http.StatusBadGateway,
+		},
+		{
+			title:  "timeout with fail open",
+			url:    slow.URL,
+			policy: config.FailurePolicyOpen,
+		},
+		{
+			title:  "server error with fail closed",
+			url:    broken.URL,
+			policy: config.FailurePolicyClosed,
+			code:   http.StatusBadGateway,
+		},
+		{
+			title:  "server error with fail open",
+			url:    broken.URL,
+			policy: config.FailurePolicyOpen,
+		},
+	}
+	for _, test := range testSuite {
+		t.Run(test.title, func(t *testing.T) {
+			c := newController(t, config.AdmissionWebhook{
+				Name:          "policy",
+				Type:          config.AdmissionWebhookValidating,
+				URL:           test.url,
+				Timeout:       model.Duration(50 * time.Millisecond),
+				FailurePolicy: test.policy,
+			})
+			err := c.Admit(user, admission.OperationCreate, newOrganization("acme"), nil)
+			if test.code == 0 {
+				assert.NoError(t, err)
+			} else {
+				assertHTTPError(t, err, test.code)
+			}
+		})
+	}
+}
+
+func TestDeleteWebhook(t *testing.T) {
+	var requests []admission.Request
+	receiver := newReceiver(t, admission.Response{Allowed: false, Message: "protected"}, &requests)
+	c := newController(t, config.AdmissionWebhook{
+		Name:       "protect",
+		Type:       config.AdmissionWebhookValidating,
+		URL:        receiver.URL,
+		Operations: []admission.Operation{admission.OperationDelete},
+	})
+	assert.NoError(t, c.Admit(user, admission.OperationCreate, newOrganization("acme"), nil))
+	assertHTTPError(t, c.Admit(user, admission.OperationDelete, nil, newOrganization("acme")), http.StatusForbidden)
+	if assert.Len(t, requests, 1) {
+		assert.Equal(t, admission.OperationDelete, requests[0].Operation)
+		assert.Empty(t, requests[0].Object)
+		assert.NotEmpty(t, requests[0].OldObject)
+	}
+}
@@ -0,0 +1,238 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admission
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/perses/perses/pkg/model/api/admission"
+)
+
+// ApplyPatch applies the JSON patch (RFC 6902) to the JSON document.
+func ApplyPatch(document []byte, patch []admission.PatchOperation) ([]byte, error) {
+	var doc interface{}
+	if err := json.Unmarshal(document, &doc); err != nil {
+		return nil, err
+	}
+	for i, operation := range patch {
+		var err error
+		if doc, err = applyOperation(doc, operation); err != nil {
+			return nil, fmt.Errorf("unable to apply the operation %d (%s %q) of the patch: %w", i, operation.Op, operation.Path, err)
+		}
+	}
+	return json.Marshal(doc)
+}
+
+func applyOperation(doc interface{}, operation admission.PatchOperation) (interface{}, error) {
+	path, err := parsePointer(operation.Path)
+	if err != nil {
+		return nil, err
+	}
+	switch operation.Op {
+	case "add":
+		value, valueErr := decodeValue(operation)
+		if valueErr != nil {
+			return nil, valueErr
+		}
+		return add(doc, path, value)
+	case "remove":
+		result, _, removeErr := remove(doc, path)
+		return result, removeErr
+	case "replace":
+		value, valueErr := decodeValue(operation)
+		if valueErr != nil {
+			return nil, valueErr
+		}
+		result, _, removeErr := remove(doc, path)
+		if removeErr != nil {
+			return nil, removeErr
+		}
+		return add(result, path, value)
+	case "move":
+		from, fromErr := parsePointer(operation.From)
+		if fromErr != nil {
+			return nil, fromErr
+		}
+		result, value, removeErr := remove(doc, from)
+		if removeErr != nil {
+			return nil, removeErr
+		}
+		return add(result, path, value)
+	case "copy":
+		from, fromErr := parsePointer(operation.From)
+		if fromErr != nil {
+			return nil, fromErr
+		}
+		value, getErr := get(doc, from)
+		if getErr != nil {
+			return nil, getErr
+		}
+		return add(doc, path, deepCopy(value))
+	case "test":
+		expected, valueErr := decodeValue(operation)
+		if valueErr != nil {
+			return nil, valueErr
+		}
+		value, getErr := get(doc, path)
+		if getErr != nil {
+			return nil, getErr
+		}
+		if !reflect.DeepEqual(expected, value) {
+			return nil, fmt.Errorf("the value is not the one expected")
+		}
+		return doc, nil
+	default:
+		return nil, fmt.Errorf("unknown operation %q", operation.Op)
+	}
+}
+
+func decodeValue(operation admission.PatchOperation) (interface{}, error) {
+	if operation.Value == nil {
+		return nil, fmt.Errorf("the value is missing")
+	}
+	var value interface{}
+	return value, json.Unmarshal(operation.Value, &value)
+}
+
+// parsePointer splits the JSON pointer (RFC 6901) in tokens.
+func parsePointer(pointer string) ([]string, error) {
+	if len(pointer) == 0 {
+		return []string{}, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("invalid path %q, it must start with /", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+func get(doc interface{}, path []string) (interface{}, error) {
+	current := doc
+	for _, token := range path {
+		switch node := current.(type) {
+		case map[string]interface{}:
+			value, ok := node[token]
+			if !ok {
+				return nil, fmt.Errorf("the key %q doesn't exist", token)
+			}
+			current = value
+		case []interface{}:
+			index, err := arrayIndex(token, len(node)-1)
+			if err != nil {
+				return nil, err
+			}
+			current = node[index]
+		default:
+			return nil, fmt.Errorf("the path doesn't exist")
+		}
+	}
+	return current, nil
+}
+
+// add sets the value at the end of the path and returns the new document.
+func add(doc interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	parent, err := get(doc, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	token := path[len(path)-1]
+	switch node := parent.(type) {
+	case map[string]interface{}:
+		node[token] = value
+		return doc, nil
+	case []interface{}:
+		index := len(node)
+		if token != "-" {
+			if index, err = arrayIndex(token, len(node)); err != nil {
+				return nil, err
+			}
+		}
+		array := append(node[:index:index], append([]interface{}{value}, node[index:]...)...)
+		return add(doc, path[:len(path)-1], array)
+	default:
+		return nil, fmt.Errorf("the parent of the path is neither an object nor an array")
+	}
+}
+
+// remove deletes the value at the end of the path and returns the new document and the value removed.
+func remove(doc interface{}, path []string) (interface{}, interface{}, error) {
+	if len(path) == 0 {
+		return nil, doc, nil
+	}
+	parent, err := get(doc, path[:len(path)-1])
+	if err != nil {
+		return nil, nil, err
+	}
+	token := path[len(path)-1]
+	switch node := parent.(type) {
+	case map[string]interface{}:
+		value, ok := node[token]
+		if !ok {
+			return nil, nil, fmt.Errorf("the key %q doesn't exist", token)
+		}
+		delete(node, token)
+		return doc, value, nil
+	case []interface{}:
+		index, indexErr := arrayIndex(token, len(node)-1)
+		if indexErr != nil {
+			return nil, nil, indexErr
+		}
+		value := node[index]
+		array := append(node[:index:index], node[index+1:]...)
+		result, addErr := add(doc, path[:len(path)-1], array)
+		return result, value, addErr
+	default:
+		return nil, nil, fmt.Errorf("the parent of the path is neither an object nor an array")
+	}
+}
+
+func arrayIndex(token string, max int) (int, error) {
+	index, err := strconv.Atoi(token)
+	if err != nil || index < 0 || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+	if index > max {
+		return 0, fmt.Errorf("the array index %d is out of bounds", index)
+	}
+	return index, nil
+}
+
+func deepCopy(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			result[key] = deepCopy(item)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, item := range v {
+			result[i] = deepCopy(item)
+		}
+		return result
+	default:
+		return v
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admission
+
+import (
+	"testing"
+
+	"github.com/perses/perses/pkg/model/api/admission"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyPatch(t *testing.T) {
+	testSuite := []struct {
+		title    string
+		document string
+		patch    []admission.PatchOperation
+		result   string
+	}{
+		{
+			title:    "add a key",
+			document: `{"a":1}`,
+			patch:    []admission.PatchOperation{{Op: "add", Path: "/b", Value: []byte(`{"c":2}`)}},
+			result:   `{"a":1,"b":{"c":2}}`,
+		},
+		{
+			title:    "add in an array",
+			document: `{"a":[1,3]}`,
+			patch: []admission.PatchOperation{
+				{Op: "add", Path: "/a/1", Value: []byte(`2`)},
+				{Op: "add", Path: "/a/-", Value: []byte(`4`)},
+			},
+			result: `{"a":[1,2,3,4]}`,
+		},
+		{
+			title:    "remove and replace",
+			document: `{"a":[1,2,3],"b":"x","c":true}`,
+			patch: []admission.PatchOperation{
+				{Op: "remove", Path: "/a/0"},
+				{Op: "remove", Path: "/c"},
+				{Op: "replace", Path: "/b", Value: []byte(`"y"`)},
+			},
+			result: `{"a":[2,3],"b":"y"}`,
+		},
+		{
+			title:    "move, copy and test",
+			document: `{"a":{"b":1},"c":{}}`,
+			patch: []admission.PatchOperation{
+				{Op: "test", Path: "/a/b", Value: []byte(`1`)},
+				{Op: "copy", From: "/a", Path: "/c/copy"},
+				{Op: "move", From: "/a/b", Path: "/d"},
+			},
+			result: `{"a":{},"c":{"copy":{"b":1}},"d":1}`,
+		},
+		{
+			title:    "escaped pointer",
+			document: `{"a/b":1,"m~n":2}`,
+			patch: []admission.PatchOperation{
+				{Op: "replace", Path: "/a~1b", Value: []byte(`3`)},
+				{Op: "remove", Path: "/m~0n"},
+			},
+			result: `{"a/b":3}`,
+		},
+	}
+	for _, test := range testSuite {
+		t.Run(test.title, func(t *testing.T) {
+			result, err := ApplyPatch([]byte(test.document), test.patch)
+			assert.NoError(t, err)
+			assert.JSONEq(t, test.result, string(result))
+		})
+	}
+}
+
+func TestApplyPatchError(t *testing.T) {
+	testSuite := []struct {
+		title string
+		patch []admission.PatchOperation
+	}{
+		{
+			title: "unknown operation",
+			patch: []admission.PatchOperation{{Op: "merge", Path: "/a"}},
+		},
+		{
+			title: "missing key",
+			patch: []admission.PatchOperation{{Op: "remove", Path: "/b"}},
+		},
+		{
+			title: "index out of bounds",
+			patch: []admission.PatchOperation{{Op: "add", Path: "/c/3", Value: []byte(`1`)}},
+		},
+		{
+			title: "failed test",
+			patch: []admission.PatchOperation{{Op: "test", Path: "/a", Value: []byte(`2`)}},
+		},
+		{
+			title: "missing value",
+			patch: []admission.PatchOperation{{Op: "add", Path: "/b"}},
+		},
+		{
+			title: "invalid path",
+			patch: []admission.PatchOperation{{Op: "add", Path: "b", Value: []byte(`1`)}},
+		},
+	}
+	for _, test := range testSuite {
+		t.Run(test.title, func(t *testing.T) {
+			_, err := ApplyPatch([]byte(`{"a":1,"c":[1,2]}`), test.patch)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,444 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alert
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/perses/perses/pkg/model/api/alert"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/common"
+	"github.com/perses/perses/pkg/model/api/v1/dashboard"
+	"github.com/prometheus/common/model"
+	"gopkg.in/yaml.v2"
+)
+
+const prometheusQueryKind = "PrometheusTimeSeriesQuery"
+
+var (
+	// variableTemplateSyntaxRegexp matches the three syntaxes of a variable: `$var`, `${var}` and `${var:format}`.
+	variableTemplateSyntaxRegexp = regexp.MustCompile(`\$\{([a-zA-Z0-9_-]+)(?::([a-zA-Z]+))?}|\$([a-zA-Z0-9_-]+)`)
+	// builtinVariables are the variables provided by the frontend. As there is no time range in an alert rule,
+	// we are using a reasonable default value.
+	builtinVariables = map[string]string{
+		"__interval":      "5m",
+		"__rate_interval": "5m",
+		"__range":         "5m",
+	}
+	supportedOperators = map[string]bool{
+		">":  true,
+		">=": true,
+		"<":  true,
+		"<=": true,
+		"==": true,
+		"!=": true,
+	}
+)
+
+type thresholdStep struct {
+	Value float64 `yaml:"value"`
+	Color string  `yaml:"color"`
+	Name  string  `yaml:"name"`
+}
+
+type thresholds struct {
+	Mode  string          `yaml:"mode"`
+	Steps []thresholdStep `yaml:"steps"`
+}
+
+// thresholdPluginSpec is the part of the panel plugin spec (StatChart, GaugeChart, ...) we are interested in.
+type thresholdPluginSpec struct {
+	Thresholds *thresholds `yaml:"thresholds"`
+	Max        *float64    `yaml:"max"`
+}
+
+type prometheusQuerySpec struct {
+	Query string `yaml:"query"`
+}
+
+// Generate builds the Prometheus rule group corresponding to the panels of the dashboard that have thresholds and that are opted in thanks to the annotation alert.AnnotationEnabled.
+// When rules are provided, the drift between them and the generated rules is computed as well.
+func Generate(request *alert.Generate) (*alert.GenerateResult, error) {
+	if request == nil || request.Dashboard == nil {
+		return nil, fmt.Errorf("dashboard cannot be empty")
+	}
+	g := &generator{
+		dashboard: request.Dashboard,
+		values:    resolveVariables(request.Dashboard.Spec.Variables, request.Variables),
+	}
+	group, err := g.generate()
+	if err != nil {
+		return nil, err
+	}
+	result := &alert.GenerateResult{Warnings: g.warnings}
+	if len(group.Rules) > 0 {
+		result.Groups = []alert.RuleGroup{*group}
+	}
+	if request.Rules != nil {
+		result.Drift = Drift(*group, request.Rules)
+	}
+	return result, nil
+}
+
+// GroupName returns the name of the rule group generated for the given dashboard.
+func GroupName(project string, dashboardName string) string {
+	return fmt.Sprintf("perses-%s-%s", project, dashboardName)
+}
+
+type generator struct {
+	dashboard *v1.Dashboard
+	values    map[string][]string
+	warnings  []string
+}
+
+func (g *generator) warn(format string, args ...interface{}) {
+	g.warnings = append(g.warnings, fmt.Sprintf(format, args...))
+}
+
+func (g *generator) generate() (*alert.RuleGroup, error) {
+	group := &alert.RuleGroup{
+		Name:  GroupName(g.dashboard.Metadata.Project, g.dashboard.Metadata.Name),
+		Rules: []alert.Rule{},
+	}
+	// sorting the panel keys so the result is stable
+	panelKeys := make([]string, 0, len(g.dashboard.Spec.Panels))
+	for key := range g.dashboard.Spec.Panels {
+		panelKeys = append(panelKeys, key)
+	}
+	sort.Strings(panelKeys)
+	for _, key := range panelKeys {
+		panel := g.dashboard.Spec.Panels[key]
+		if panel == nil || panel.Spec.Annotations[alert.AnnotationEnabled] != "true" {
+			continue
+		}
+		rules, err := g.generatePanelRules(key, panel)
+		if err != nil {
+			return nil, fmt.Errorf("unable to generate the alert rules for the panel %q: %w", key, err)
+		}
+		group.Rules = append(group.Rules, rules...)
+	}
+	return group, nil
+}
+
+func (g *generator) generatePanelRules(panelKey string, panel *v1.Panel) ([]alert.Rule, error) {
+	annotations := panel.Spec.Annotations
+	forDuration := defaultString(annotations[alert.AnnotationFor], alert.DefaultFor)
+	if _, err := model.ParseDuration(forDuration); err != nil {
+		return nil, fmt.Errorf("invalid annotation %q: %w", alert.AnnotationFor, err)
+	}
+	operator := defaultString(annotations[alert.AnnotationOperator], alert.DefaultOperator)
+	if !supportedOperators[operator] {
+		return nil, fmt.Errorf("invalid annotation %q: operator %q is not supported", alert.AnnotationOperator, operator)
+	}
+	extraLabels, err := parseLabels(annotations[alert.AnnotationLabels])
+	if err != nil {
+		return nil, fmt.Errorf("invalid annotation %q: %w", alert.AnnotationLabels, err)
+	}
+	pluginSpec := &thresholdPluginSpec{}
+	if decodeErr := decodeSpec(panel.Spec.Plugin.Spec, pluginSpec); decodeErr != nil {
+		return nil, decodeErr
+	}
+	if pluginSpec.Thresholds == nil || len(pluginSpec.Thresholds.Steps) == 0 {
+		g.warn("panel %q: no thresholds defined, no rule generated", panelKey)
+		return nil, nil
+	}
+	if pluginSpec.Thresholds.Mode == "Percent" && pluginSpec.Max == nil {
+		g.warn("panel %q: thresholds in percent mode require the field 'max' to be converted to an absolute value, no rule generated", panelKey)
+		return nil, nil
+	}
+	var exprs []string
+	for i, query := range panel.Spec.GetQueries() {
+		if query.Spec.Plugin.Kind != prometheusQueryKind {
+			g.warn("panel %q: query %d of kind %q is not supported, only %q can be used", panelKey, i, query.Spec.Plugin.Kind, prometheusQueryKind)
+			continue
+		}
+		if options := panel.Spec.GetQueryOptions(i); options != nil && options.TimeShift > 0 {
+			g.warn("panel %q: query %d is shifted in time, no rule generated for this query", panelKey, i)
+			continue
+		}
+		querySpec := &prometheusQuerySpec{}
+		if decodeErr := decodeSpec(query.Spec.Plugin.Spec, querySpec); decodeErr != nil {
+			return nil, decodeErr
+		}
+		expr, unresolved := g.expandVariables(querySpec.Query)
+		if len(unresolved) > 0 {
+			g.warn("panel %q: query %d is using the variables %s that cannot be resolved, no rule generated for this query", panelKey, i, strings.Join(unresolved, ", "))
+			continue
+		}
+		exprs = append(exprs, expr)
+	}
+	alertName := defaultString(annotations[alert.AnnotationName], panelKey)
+	var rules []alert.Rule
+	for i, expr := range exprs {
+		for j, step := range pluginSpec.Thresholds.Steps {
+			value := step.Value
+			if pluginSpec.Thresholds.Mode == "Percent" {
+				value = value * *pluginSpec.Max / 100
+			}
+			labels := map[string]string{
+				alert.LabelProject:   g.dashboard.Metadata.Project,
+				alert.LabelDashboard: g.dashboard.Metadata.Name,
+				alert.LabelPanel:     panelKey,
+				alert.LabelSeverity:  defaultString(step.Name, fmt.Sprintf("step-%d", j)),
+			}
+			if len(exprs) > 1 {
+				labels[alert.LabelQuery] = strconv.Itoa(i)
+			}
+			for k, v := range extraLabels {
+				labels[k] = v
+			}
+			rule := alert.Rule{
+				Alert:  alertName,
+				Expr:   fmt.Sprintf("(%s) %s %s", expr, operator, strconv.FormatFloat(value, 'f', -1, 64)),
+				For:    forDuration,
+				Labels: labels,
+			}
+			if ruleAnnotations := buildRuleAnnotations(panel.Spec.Display); len(ruleAnnotations) > 0 {
+				rule.Annotations = ruleAnnotations
+			}
+			rules = append(rules, rule)
+		}
+	}
+	return rules, nil
+}
+
+// expandVariables replaces the variables used in the expression by their value.
+// It returns the list of the variables that cannot be resolved.
+func (g *generator) expandVariables(expr string) (string, []string) {
+	var unresolved []string
+	result := variableTemplateSyntaxRegexp.ReplaceAllStringFunc(expr, func(match string) string {
+		groups := variableTemplateSyntaxRegexp.FindStringSubmatch(match)
+		name, format := groups[1], groups[2]
+		if len(name) == 0 {
+			name = groups[3]
+		}
+		values, ok := g.values[name]
+		if !ok {
+			unresolved = append(unresolved, name)
+			return match
+		}
+		value, ok := formatVariable(values, format)
+		if !ok {
+			unresolved = append(unresolved, fmt.Sprintf("%s (unknown format %q)", name, format))
+			return match
+		}
+		return value
+	})
+	return result, unresolved
+}
+
+// formatVariable formats the values of a variable like the format given in `${var:format}` asks for.
+// Without format, the multiple values are expanded in a regexp, the same way it is done when the dashboard is displayed.
+func formatVariable(values []string, format string) (string, bool) {
+	switch format {
+	case "", "pipe":
+		return strings.Join(values, "|"), true
+	case "regex":
+		escaped := make([]string, 0, len(values))
+		for _, value := range values {
+			escaped = append(escaped, regexp.QuoteMeta(value))
+		}
+		if len(escaped) == 1 {
+			return escaped[0], true
+		}
+		return "(" + strings.Join(escaped, "|") + ")", true
+	case "csv", "raw":
+		return strings.Join(values, ","), true
+	case "singlequote":
+		return joinQuoted(values, "'"), true
+	case "doublequote":
+		return joinQuoted(values, `"`), true
+	case "json":
+		data, err := json.Marshal(values)
+		if err != nil {
+			return "", false
+		}
+		return string(data), true
+	}
+	return "", false
+}
+
+func joinQuoted(values []string, quote string) string {
+	quoted := make([]string, 0, len(values))
+	for _, value := range values {
+		quoted = append(quoted, quote+strings.ReplaceAll(value, quote, "\\"+quote)+quote)
+	}
+	return strings.Join(quoted, ",")
+}
+
+// resolveVariables returns the value of each variable that can be used in an alert rule.
+// The values provided by the user take precedence over the values defined in the dashboard.
+func resolveVariables(variables []dashboard.Variable, overrides map[string]string) map[string][]string {
+	result := make(map[string][]string, len(builtinVariables)+len(variables)+len(overrides))
+	for k, v := range builtinVariables {
+		result[k] = []string{v}
+	}
+	for _, variable := range variables {
+		switch spec := variable.Spec.(type) {
+		case *dashboard.TextVariableSpec:
+			result[spec.Name] = []string{spec.Value}
+		case *dashboard.ListVariableSpec:
+			if spec.DefaultValue != nil && len(spec.DefaultValue.SingleValue) > 0 {
+				result[spec.Name] = []string{spec.DefaultValue.SingleValue}
+			} else if spec.DefaultValue != nil && len(spec.DefaultValue.SliceValues) > 0 {
+				result[spec.Name] = spec.DefaultValue.SliceValues
+			} else if spec.AllowAllValue {
+				result[spec.Name] = []string{defaultString(spec.CustomAllValue, ".*")}
+			}
+		}
+	}
+	for k, v := range overrides {
+		result[k] = []string{v}
+	}
+	return result
+}
+
+func buildRuleAnnotations(display common.Display) map[string]string {
+	result := make(map[string]string)
+	if len(display.Name) > 0 {
+		result["summary"] = display.Name
+	}
+	if len(display.Description) > 0 {
+		result["description"] = display.Description
+	}
+	return result
+}
+
+func parseLabels(raw string) (map[string]string, error) {
+	result := make(map[string]string)
+	if len(strings.TrimSpace(raw)) == 0 {
+		return result, nil
+	}
+	for _, pair := range strings.Split(raw, ",") {
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("%q is not a valid label, expected syntax is key=value", pair)
+		}
+		key := strings.TrimSpace(kv[0])
+		if !model.LabelName(key).IsValid() {
+			return nil, fmt.Errorf("%q is not a valid label name", key)
+		}
+		result[key] = strings.TrimSpace(kv[1])
+	}
+	return result, nil
+}
+
+// decodeSpec converts a plugin spec to the given struct.
+// The spec can come from a JSON or a YAML document, so it goes through a YAML serialization that supports both kind of map.
+func decodeSpec(spec interface{}, obj interface{}) error {
+	data, err := yaml.Marshal(spec)
+	if err != nil {
+		return err
+	}
+	return yaml.Unmarshal(data, obj)
+}
+
+// Drift returns the differences between the generated group and the existing rules.
+// Only the group with the same name as the generated one is considered.
+func Drift(generated alert.RuleGroup, existing *alert.RuleFile) []alert.Drift {
+	var current []alert.Rule
+	if existing != nil {
+		for _, group := range existing.Groups {
+			if group.Name == generated.Name {
+				current = append(current, group.Rules...)
+			}
+		}
+	}
+	currentRules := make(map[string]alert.Rule, len(current))
+	for _, rule := range current {
+		currentRules[ruleKey(rule)] = rule
+	}
+	var result []alert.Drift
+	generatedKeys := make(map[string]bool, len(generated.Rules))
+	for _, rule := range generated.Rules {
+		key := ruleKey(rule)
+		generatedKeys[key] = true
+		currentRule, ok := currentRules[key]
+		if !ok {
+			result = append(result, newDrift(alert.DriftMissing, generated.Name, rule, nil))
+			continue
+		}
+		if fields := diffRule(rule, currentRule); len(fields) > 0 {
+			result = append(result, newDrift(alert.DriftChanged, generated.Name, rule, fields))
+		}
+	}
+	for _, rule := range current {
+		if !generatedKeys[ruleKey(rule)] {
+			result = append(result, newDrift(alert.DriftOrphaned, generated.Name, rule, nil))
+		}
+	}
+	return result
+}
+
+func newDrift(kind alert.DriftKind, group string, rule alert.Rule, fields []string) alert.Drift {
+	return alert.Drift{
+		Kind:     kind,
+		Group:    group,
+		Alert:    rule.Alert,
+		Severity: rule.Labels[alert.LabelSeverity],
+		Fields:   fields,
+	}
+}
+
+// ruleKey identifies a generated rule. The same alert is generated once per threshold step and per query.
+func ruleKey(rule alert.Rule) string {
+	return strings.Join([]string{rule.Alert, rule.Labels[alert.LabelPanel], rule.Labels[alert.LabelQuery], rule.Labels[alert.LabelSeverity]}, "/")
+}
+
+func diffRule(generated alert.Rule, current alert.Rule) []string {
+	var fields []string
+	if generated.Expr != current.Expr {
+		fields = append(fields, "expr")
+	}
+	if !sameDuration(generated.For, current.For) {
+		fields = append(fields, "for")
+	}
+	if !reflect.DeepEqual(emptyIfNil(generated.Labels), emptyIfNil(current.Labels)) {
+		fields = append(fields, "labels")
+	}
+	if !reflect.DeepEqual(emptyIfNil(generated.Annotations), emptyIfNil(current.Annotations)) {
+		fields = append(fields, "annotations")
+	}
+	return fields
+}
+
+func sameDuration(a string, b string) bool {
+	if a == b {
+		return true
+	}
+	da, errA := model.ParseDuration(defaultString(a, "0s"))
+	db, errB := model.ParseDuration(defaultString(b, "0s"))
+	return errA == nil && errB == nil && da == db
+}
+
+func emptyIfNil(m map[string]string) map[string]string {
+	if m == nil {
+		return map[string]string{}
+	}
+	return m
+}
+
+// defaultString returns value if it's not empty, otherwise it returns defaultValue.
+func defaultString(value string, defaultValue string) string {
+	if len(value) > 0 {
+		return value
+	}
+	return defaultValue
+}
@@ -0,0 +1,229 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alert
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	testUtils "github.com/perses/perses/internal/test"
+	"github.com/perses/perses/pkg/model/api/alert"
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func loadDashboard(t *testing.T) *modelV1.Dashboard {
+	dashboard := &modelV1.Dashboard{}
+	testUtils.JSONUnmarshal(testUtils.ReadFile(filepath.Join("testdata", "dashboard.json")), dashboard)
+	return dashboard
+}
+
+func expectedCPURules() []alert.Rule {
+	labels := func(severity string) map[string]string {
+		return map[string]string{
+			alert.LabelProject:   "perses",
+			alert.LabelDashboard: "node",
+			alert.LabelPanel:     "cpu",
+			alert.LabelSeverity:  severity,
+			"team":               "infra",
+		}
+	}
+	annotations := map[string]string{
+		"summary":     "CPU usage",
+		"description": "CPU usage of the instance",
+	}
+	return []alert.Rule{
+		{
+			Alert:       "HighCPUUsage",
+			Expr:        `(sum(rate(node_cpu_seconds_total{instance="demo:9100",mode!="idle"}[5m]))) > 160`,
+			For:         "10m",
+			Labels:      labels("warning"),
+			Annotations: annotations,
+		},
+		{
+			Alert:       "HighCPUUsage",
+			Expr:        `(sum(rate(node_cpu_seconds_total{instance="demo:9100",mode!="idle"}[5m]))) > 180`,
+			For:         "10m",
+			Labels:      labels("critical"),
+			Annotations: annotations,
+		},
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	testSuite := []struct {
+		title            string
+		variables        map[string]string
+		expectedRules    []alert.Rule
+		expectedWarnings []string
+	}{
+		{
+			title:         "unresolved variable",
+			expectedRules: expectedCPURules(),
+			expectedWarnings: []string{
+				`panel "idle": query 0 is using the variables mode that cannot be resolved, no rule generated for this query`,
+			},
+		},
+		{
+			title:     "variable provided by the user",
+			variables: map[string]string{"mode": "idle", "instance": "prod:9100"},
+			expectedRules: []alert.Rule{
+				{
+					Alert: "HighCPUUsage",
+					Expr:  `(sum(rate(node_cpu_seconds_total{instance="prod:9100",mode!="idle"}[5m]))) > 160`,
+					For:   "10m",
+					Labels: map[string]string{
+						alert.LabelProject:   "perses",
+						alert.LabelDashboard: "node",
+						alert.LabelPanel:     "cpu",
+						alert.LabelSeverity:  "warning",
+						"team":               "infra",
+					},
+					Annotations: expectedCPURules()[0].Annotations,
+				},
+				{
+					Alert: "HighCPUUsage",
+					Expr:  `(sum(rate(node_cpu_seconds_total{instance="prod:9100",mode!="idle"}[5m]))) > 180`,
+					For:   "10m",
+					Labels: map[string]string{
+						alert.LabelProject:   "perses",
+						alert.LabelDashboard: "node",
+						alert.LabelPanel:     "cpu",
+						alert.LabelSeverity:  "critical",
+						"team":               "infra",
+					},
+					Annotations: expectedCPURules()[0].Annotations,
+				},
+				{
+					Alert: "idle",
+					Expr:  `(node_cpu_seconds_total{mode="idle"}) > 1`,
+					For:   alert.DefaultFor,
+					Labels: map[string]string{
+						alert.LabelProject:   "perses",
+						alert.LabelDashboard: "node",
+						alert.LabelPanel:     "idle",
+						alert.LabelSeverity:  "step-0",
+					},
+					Annotations: map[string]string{"summary": "Idle"},
+				},
+			},
+		},
+	}
+	for _, test := range testSuite {
+		t.Run(test.title, func(t *testing.T) {
+			result, err := Generate(&alert.Generate{Dashboard: loadDashboard(t), Variables: test.variables})
+			assert.NoError(t, err)
+			assert.Equal(t, []alert.RuleGroup{{Name: "perses-perses-node", Rules: test.expectedRules}}, result.Groups)
+			assert.Equal(t, test.expectedWarnings, result.Warnings)
+			assert.Empty(t, result.Drift)
+		})
+	}
+}
+
+func TestDrift(t *testing.T) {
+	cpuRules := expectedCPURules()
+	changedRule := cpuRules[1]
+	changedRule.Expr = `(sum(rate(node_cpu_seconds_total[5m]))) > 180`
+	changedRule.For = "600s"
+	orphanedRule := alert.Rule{Alert: "OldAlert", Expr: "up == 0", Labels: map[string]string{alert.LabelPanel: "old"}}
+	existing := &alert.RuleFile{
+		Groups: []alert.RuleGroup{
+			{
+				Name:  "perses-perses-node",
+				Rules: []alert.Rule{changedRule, orphanedRule},
+			},
+			{
+				// a group not related to the dashboard must be ignored
+				Name:  "another-group",
+				Rules: []alert.Rule{{Alert: "Whatever", Expr: "up == 0"}},
+			},
+		},
+	}
+	result, err := Generate(&alert.Generate{Dashboard: loadDashboard(t), Rules: existing})
+	assert.NoError(t, err)
+	assert.Equal(t, []alert.Drift{
+		{Kind: alert.DriftMissing, Group: "perses-perses-node", Alert: "HighCPUUsage", Severity: "warning"},
+		{Kind: alert.DriftChanged, Group: "perses-perses-node", Alert: "HighCPUUsage", Severity: "critical", Fields: []string{"expr"}},
+		{Kind: alert.DriftOrphaned, Group: "perses-perses-node", Alert: "OldAlert"},
+	}, result.Drift)
+}
+
+func TestGenerateInvalidAnnotation(t *testing.T) {
+	dashboard := loadDashboard(t)
+	dashboard.Spec.Panels["cpu"].Spec.Annotations[alert.AnnotationOperator] = "=~"
+	_, err := Generate(&alert.Generate{Dashboard: dashboard})
+	assert.EqualError(t, err, `unable to generate the alert rules for the panel "cpu": invalid annotation "perses.dev/alert-operator": operator "=~" is not supported`)
+}
+
+func TestGenerateWithTimeShift(t *testing.T) {
+	dashboard := loadDashboard(t)
+	dashboard.Spec.Panels["cpu"].Spec.Options = &modelV1.QueryOptions{TimeShift: model.Duration(7 * 24 * time.Hour)}
+	result, err := Generate(&alert.Generate{Dashboard: dashboard, Variables: map[string]string{"mode": "idle"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{`panel "cpu": query 0 is shifted in time, no rule generated for this query`}, result.Warnings)
+	for _, group := range result.Groups {
+		for _, rule := range group.Rules {
+			assert.NotEqual(t, "cpu", rule.Labels[alert.LabelPanel])
+		}
+	}
+}
+
+func TestExpandVariables(t *testing.T) {
+	g := &generator{values: map[string][]string{
+		"job":      {"api"},
+		"instance": {"a:9100", "b.prod:9100"},
+	}}
+	testSuite := []struct {
+		title              string
+		expr               string
+		expectedExpr       string
+		expectedUnresolved []string
+	}{
+		{
+			title:        "dollar syntax",
+			expr:         `up{job="$job",instance=~"$instance"}`,
+			expectedExpr: `up{job="api",instance=~"a:9100|b.prod:9100"}`,
+		},
+		{
+			title:        "braces syntax",
+			expr:         `up{job="${job}",instance=~"${instance}"}`,
+			expectedExpr: `up{job="api",instance=~"a:9100|b.prod:9100"}`,
+		},
+		{
+			title:        "braces syntax with format",
+			expr:         `up{instance=~"${instance:regex}"} or label_replace(vector(1), "list", "${instance:csv}", "", "")`,
+			expectedExpr: `up{instance=~"(a:9100|b\.prod:9100)"} or label_replace(vector(1), "list", "a:9100,b.prod:9100", "", "")`,
+		},
+		{
+			title:        "quoted values",
+			expr:         `${instance:singlequote} ${instance:doublequote} ${instance:json}`,
+			expectedExpr: `'a:9100','b.prod:9100' "a:9100","b.prod:9100" ["a:9100","b.prod:9100"]`,
+		},
+		{
+			title:              "unknown variable and unknown format",
+			expr:               `up{job="${unknown}",instance="${instance:lucene}"}`,
+			expectedExpr:       `up{job="${unknown}",instance="${instance:lucene}"}`,
+			expectedUnresolved: []string{"unknown", `instance (unknown format "lucene")`},
+		},
+	}
+	for _, test := range testSuite {
+		t.Run(test.title, func(t *testing.T) {
+			expr, unresolved := g.expandVariables(test.expr)
+			assert.Equal(t, test.expectedExpr, expr)
+			assert.Equal(t, test.expectedUnresolved, unresolved)
+		})
+	}
+}
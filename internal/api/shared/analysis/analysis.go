@@ -0,0 +1,407 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analysis
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/perses/perses/internal/api/interface/v1/dashboard"
+	"github.com/perses/perses/internal/api/interface/v1/globalvariable"
+	"github.com/perses/perses/internal/api/interface/v1/variable"
+	modelAPI "github.com/perses/perses/pkg/model/api"
+	"github.com/perses/perses/pkg/model/api/analysis"
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/common"
+	dashboardModel "github.com/perses/perses/pkg/model/api/v1/dashboard"
+	variableModel "github.com/perses/perses/pkg/model/api/v1/variable"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/promql/parser"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	prometheusQueryKind    = "PrometheusTimeSeriesQuery"
+	prometheusVariableKind = "PrometheusPromQLVariable"
+)
+
+var (
+	variableRegexp          = regexp.MustCompile(`\$\{?[a-zA-Z0-9_-]+`)
+	nonAlphanumericalRegexp = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+)
+
+// Filter tells which resources the user sending the request can read.
+type Filter struct {
+	// IsProjectReadable is called for the project of each dashboard and variable.
+	IsProjectReadable func(project string) bool
+	// GlobalVariables is true when the global variables can be read.
+	GlobalVariables bool
+}
+
+// Analysis is looking for the Prometheus expressions used several times across the dashboards and the variables.
+type Analysis interface {
+	// Analyze only considers the resources accepted by the filter, so the result doesn't reveal the queries of the
+	// projects the user cannot read.
+	Analyze(query *analysis.Query, filter Filter) (*analysis.Result, error)
+}
+
+type analyzer struct {
+	Analysis
+	dashboardDAO      dashboard.DAO
+	variableDAO       variable.DAO
+	globalVariableDAO globalvariable.DAO
+}
+
+func New(dashboardDAO dashboard.DAO, variableDAO variable.DAO, globalVariableDAO globalvariable.DAO) Analysis {
+	return &analyzer{
+		dashboardDAO:      dashboardDAO,
+		variableDAO:       variableDAO,
+		globalVariableDAO: globalVariableDAO,
+	}
+}
+
+func (a *analyzer) Analyze(query *analysis.Query, filter Filter) (*analysis.Result, error) {
+	var entities []modelAPI.Entity
+	dashboards, err := a.dashboardDAO.List(&dashboard.Query{Project: query.Project})
+	if err != nil {
+		return nil, err
+	}
+	for _, entity := range dashboards {
+		if filter.IsProjectReadable(entity.Metadata.Project) {
+			entities = append(entities, entity)
+		}
+	}
+	variables, err := a.variableDAO.List(&variable.Query{Project: query.Project})
+	if err != nil {
+		return nil, err
+	}
+	for _, entity := range variables {
+		if filter.IsProjectReadable(entity.Metadata.Project) {
+			entities = append(entities, entity)
+		}
+	}
+	if filter.GlobalVariables {
+		globalVariables, globalErr := a.globalVariableDAO.List(&globalvariable.Query{})
+		if globalErr != nil {
+			return nil, globalErr
+		}
+		for _, entity := range globalVariables {
+			entities = append(entities, entity)
+		}
+	}
+	return AnalyzeEntities(entities, *query), nil
+}
+
+type collectedQuery struct {
+	source analysis.Source
+	query  string
+	root   *expression
+}
+
+type occurrence struct {
+	sourceIndex int
+	// parent is the canonical form of the closest candidate containing this occurrence. Empty if there is none.
+	parent string
+}
+
+type candidate struct {
+	node        parser.Node
+	occurrences []occurrence
+}
+
+// AnalyzeEntities parses all Prometheus queries used by the given entities, groups the expressions that are identical
+// once normalized and suggests a recording rule for each of them.
+func AnalyzeEntities(entities []modelAPI.Entity, query analysis.Query) *analysis.Result {
+	minUsage := query.MinUsage
+	if minUsage <= 0 {
+		minUsage = analysis.DefaultMinUsage
+	}
+	result := &analysis.Result{Duplicates: []analysis.DuplicatedExpression{}}
+	var queries []collectedQuery
+	for _, q := range collectQueries(entities, query.Project) {
+		root, err := parse(q.query)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: unable to parse the query %q: %s", formatSource(q.source), q.query, err))
+			continue
+		}
+		q.root = root
+		queries = append(queries, q)
+	}
+
+	candidates := make(map[string]*candidate)
+	for i, q := range queries {
+		collectCandidates(q.root, q.root.root, "", i, candidates)
+	}
+
+	reported := make(map[string]bool)
+	for key, c := range candidates {
+		if len(c.occurrences) >= minUsage {
+			reported[key] = true
+		}
+	}
+	// An expression that is only used inside a bigger expression that is itself reported doesn't bring anything.
+	var dominated []string
+	for key, c := range candidates {
+		if reported[key] && isDominated(c, reported) {
+			dominated = append(dominated, key)
+		}
+	}
+	for _, key := range dominated {
+		delete(reported, key)
+	}
+
+	for key := range reported {
+		c := candidates[key]
+		duplicate := analysis.DuplicatedExpression{
+			Expr:    key,
+			Usage:   len(c.occurrences),
+			Sources: buildSources(c, queries),
+		}
+		if variableRegexp.MatchString(key) {
+			duplicate.Reason = "the expression is using dashboard variables, it cannot be recorded"
+		} else {
+			duplicate.RecordingRule = &analysis.RecordingRule{Expr: key}
+		}
+		result.Duplicates = append(result.Duplicates, duplicate)
+	}
+	sort.Slice(result.Duplicates, func(i, j int) bool {
+		a, b := result.Duplicates[i], result.Duplicates[j]
+		if a.Usage != b.Usage {
+			return a.Usage > b.Usage
+		}
+		if len(a.Expr) != len(b.Expr) {
+			return len(a.Expr) > len(b.Expr)
+		}
+		return a.Expr < b.Expr
+	})
+	// The record names are chosen once the list is sorted, so the names are stable in case of conflict.
+	recordNames := make(map[string]bool)
+	replacements := make(map[string]string)
+	for i := range result.Duplicates {
+		if rule := result.Duplicates[i].RecordingRule; rule != nil {
+			rule.Record = uniqueRecordName(buildRecordName(candidates[rule.Expr].node), recordNames)
+			replacements[rule.Expr] = rule.Record
+		}
+	}
+
+	if query.Rewrite && len(replacements) > 0 {
+		for _, q := range queries {
+			rewritten := q.root.rewrite(replacements)
+			if rewritten != q.root.String() {
+				result.Rewrites = append(result.Rewrites, analysis.Rewrite{
+					Source:    q.source,
+					Query:     q.query,
+					Rewritten: rewritten,
+				})
+			}
+		}
+	}
+	return result
+}
+
+// collectCandidates registers every expression of the query that is worth being recorded.
+func collectCandidates(e *expression, n parser.Node, parent string, sourceIndex int, candidates map[string]*candidate) {
+	childParent := parent
+	if isRecordable(n) {
+		key := e.format(n)
+		c, ok := candidates[key]
+		if !ok {
+			c = &candidate{node: n}
+			candidates[key] = c
+		}
+		c.occurrences = append(c.occurrences, occurrence{sourceIndex: sourceIndex, parent: parent})
+		childParent = key
+	}
+	for _, child := range parser.Children(n) {
+		collectCandidates(e, child, childParent, sourceIndex, candidates)
+	}
+}
+
+func isDominated(c *candidate, reported map[string]bool) bool {
+	parent := c.occurrences[0].parent
+	if len(parent) == 0 || !reported[parent] {
+		return false
+	}
+	for _, o := range c.occurrences {
+		if o.parent != parent {
+			return false
+		}
+	}
+	return true
+}
+
+func buildSources(c *candidate, queries []collectedQuery) []analysis.Source {
+	var result []analysis.Source
+	seen := make(map[int]bool)
+	for _, o := range c.occurrences {
+		if seen[o.sourceIndex] {
+			continue
+		}
+		seen[o.sourceIndex] = true
+		result = append(result, queries[o.sourceIndex].source)
+	}
+	return result
+}
+
+// buildRecordName follows the Prometheus naming convention for the recording rules: level:metric:operations
+func buildRecordName(n parser.Node) string {
+	metric := metricName(n)
+	if len(metric) == 0 {
+		metric = "expr"
+	}
+	var operations []string
+	parser.Inspect(n, func(current parser.Node, _ []parser.Node) error {
+		switch node := current.(type) {
+		case *parser.Call:
+			operation := strings.ToLower(node.Func.Name)
+			for _, arg := range node.Args {
+				if selector, ok := arg.(*parser.MatrixSelector); ok {
+					operation += nonAlphanumericalRegexp.ReplaceAllString(model.Duration(selector.Range).String(), "")
+				}
+			}
+			operations = append(operations, operation)
+		case *parser.AggregateExpr:
+			// sum is the default aggregation, so by convention it is not part of the name.
+			if node.Op != parser.SUM {
+				operations = append(operations, node.Op.String())
+			}
+		}
+		return nil
+	})
+	// walk is going from the outer expression to the inner one, while the name should list the operations in the order they are applied.
+	for i, j := 0, len(operations)-1; i < j; i, j = i+1, j-1 {
+		operations[i], operations[j] = operations[j], operations[i]
+	}
+	if len(operations) == 0 {
+		operations = []string{"recorded"}
+	}
+	name := fmt.Sprintf("%s:%s", metric, strings.Join(operations, "_"))
+	if aggregation, ok := n.(*parser.AggregateExpr); ok && !aggregation.Without && len(aggregation.Grouping) > 0 {
+		name = fmt.Sprintf("%s:%s", strings.Join(aggregation.Grouping, "_"), name)
+	}
+	return name
+}
+
+func uniqueRecordName(name string, existing map[string]bool) string {
+	result := name
+	for i := 2; existing[result]; i++ {
+		result = fmt.Sprintf("%s_%d", name, i)
+	}
+	existing[result] = true
+	return result
+}
+
+// collectQueries returns the Prometheus queries used by the entities. When project is not empty, the entities of the other projects are ignored.
+func collectQueries(entities []modelAPI.Entity, project string) []collectedQuery {
+	var result []collectedQuery
+	for _, entity := range entities {
+		switch e := entity.(type) {
+		case *modelV1.Dashboard:
+			if len(project) > 0 && e.Metadata.Project != project {
+				continue
+			}
+			result = append(result, collectDashboardQueries(e)...)
+		case *modelV1.Variable:
+			if len(project) > 0 && e.Metadata.Project != project {
+				continue
+			}
+			source := analysis.Source{Kind: modelV1.KindVariable, Project: e.Metadata.Project, Name: e.Metadata.Name, Location: "spec"}
+			if listSpec, ok := e.Spec.Spec.(*variableModel.ListSpec); ok {
+				result = appendPluginQuery(result, source, listSpec.Plugin)
+			}
+		case *modelV1.GlobalVariable:
+			source := analysis.Source{Kind: modelV1.KindGlobalVariable, Name: e.Metadata.Name, Location: "spec"}
+			if listSpec, ok := e.Spec.Spec.(*variableModel.ListSpec); ok {
+				result = appendPluginQuery(result, source, listSpec.Plugin)
+			}
+		}
+	}
+	return result
+}
+
+func collectDashboardQueries(d *modelV1.Dashboard) []collectedQuery {
+	var result []collectedQuery
+	newSource := func(location string) analysis.Source {
+		return analysis.Source{Kind: modelV1.KindDashboard, Project: d.Metadata.Project, Name: d.Metadata.Name, Location: location}
+	}
+	for i, v := range d.Spec.Variables {
+		if listSpec, ok := v.Spec.(*dashboardModel.ListVariableSpec); ok {
+			result = appendPluginQuery(result, newSource(fmt.Sprintf("spec.variables[%d]", i)), listSpec.Plugin)
+		}
+	}
+	// the shared queries are collected once, whatever the number of panels referencing them
+	queryKeys := make([]string, 0, len(d.Spec.Queries))
+	for key := range d.Spec.Queries {
+		queryKeys = append(queryKeys, key)
+	}
+	sort.Strings(queryKeys)
+	for _, key := range queryKeys {
+		if query := d.Spec.Queries[key]; query != nil {
+			result = appendPluginQuery(result, newSource(fmt.Sprintf("spec.queries.%s", key)), query.Spec.Plugin)
+		}
+	}
+	// sorting the panel keys so the result is stable
+	panelKeys := make([]string, 0, len(d.Spec.Panels))
+	for key := range d.Spec.Panels {
+		panelKeys = append(panelKeys, key)
+	}
+	sort.Strings(panelKeys)
+	for _, key := range panelKeys {
+		panel := d.Spec.Panels[key]
+		if panel == nil {
+			continue
+		}
+		for i, query := range panel.Spec.Queries {
+			if query.Ref != nil {
+				continue
+			}
+			result = appendPluginQuery(result, newSource(fmt.Sprintf("spec.panels.%s.spec.queries[%d]", key, i)), query.Spec.Plugin)
+		}
+	}
+	return result
+}
+
+func appendPluginQuery(queries []collectedQuery, source analysis.Source, plugin common.Plugin) []collectedQuery {
+	var field string
+	switch plugin.Kind {
+	case prometheusQueryKind:
+		field = "query"
+	case prometheusVariableKind:
+		field = "expr"
+	default:
+		return queries
+	}
+	spec := make(map[string]interface{})
+	// The spec can come from a JSON or a YAML document, so it goes through a YAML serialization that supports both kind of map.
+	data, err := yaml.Marshal(plugin.Spec)
+	if err != nil {
+		return queries
+	}
+	if unmarshalErr := yaml.Unmarshal(data, &spec); unmarshalErr != nil {
+		return queries
+	}
+	if q, ok := spec[field].(string); ok && len(strings.TrimSpace(q)) > 0 {
+		queries = append(queries, collectedQuery{source: source, query: q})
+	}
+	return queries
+}
+
+func formatSource(source analysis.Source) string {
+	if len(source.Project) > 0 {
+		return fmt.Sprintf("%s %s/%s (%s)", source.Kind, source.Project, source.Name, source.Location)
+	}
+	return fmt.Sprintf("%s %s (%s)", source.Kind, source.Name, source.Location)
+}
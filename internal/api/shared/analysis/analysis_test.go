@@ -0,0 +1,165 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analysis
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	testUtils "github.com/perses/perses/internal/test"
+	modelAPI "github.com/perses/perses/pkg/model/api"
+	"github.com/perses/perses/pkg/model/api/analysis"
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func loadEntities(t *testing.T) []modelAPI.Entity {
+	var rawEntities []json.RawMessage
+	testUtils.JSONUnmarshal(testUtils.ReadFile(filepath.Join("testdata", "resources.json")), &rawEntities)
+	dashboard := &modelV1.Dashboard{}
+	testUtils.JSONUnmarshal(rawEntities[0], dashboard)
+	globalVariable := &modelV1.GlobalVariable{}
+	testUtils.JSONUnmarshal(rawEntities[1], globalVariable)
+	return []modelAPI.Entity{dashboard, globalVariable}
+}
+
+func TestAnalyzeEntities(t *testing.T) {
+	dashboardSource := func(location string) analysis.Source {
+		return analysis.Source{Kind: modelV1.KindDashboard, Project: "perses", Name: "api", Location: location}
+	}
+	globalVariableSource := analysis.Source{Kind: modelV1.KindGlobalVariable, Name: "error_jobs", Location: "spec"}
+
+	result := AnalyzeEntities(loadEntities(t), analysis.Query{Rewrite: true})
+	assert.Equal(t, &analysis.Result{
+		Duplicates: []analysis.DuplicatedExpression{
+			{
+				Expr:  `sum by (job) (rate(http_requests_total[5m]))`,
+				Usage: 3,
+				Sources: []analysis.Source{
+					dashboardSource("spec.variables[0]"),
+					dashboardSource("spec.panels.errors.spec.queries[0]"),
+					dashboardSource("spec.panels.requests.spec.queries[0]"),
+				},
+				RecordingRule: &analysis.RecordingRule{
+					Record: "job:http_requests_total:rate5m",
+					Expr:   `sum by (job) (rate(http_requests_total[5m]))`,
+				},
+			},
+			{
+				Expr:  `sum by (job) (rate(http_requests_total{code=~"5.."}[5m]))`,
+				Usage: 2,
+				Sources: []analysis.Source{
+					dashboardSource("spec.panels.errors.spec.queries[0]"),
+					globalVariableSource,
+				},
+				RecordingRule: &analysis.RecordingRule{
+					Record: "job:http_requests_total:rate5m_2",
+					Expr:   `sum by (job) (rate(http_requests_total{code=~"5.."}[5m]))`,
+				},
+			},
+			{
+				Expr:  `rate(http_requests_total{instance="$instance"}[5m])`,
+				Usage: 2,
+				Sources: []analysis.Source{
+					dashboardSource("spec.panels.instance.spec.queries[0]"),
+					dashboardSource("spec.panels.instance.spec.queries[1]"),
+				},
+				Reason: "the expression is using dashboard variables, it cannot be recorded",
+			},
+		},
+		Rewrites: []analysis.Rewrite{
+			{
+				Source:    dashboardSource("spec.variables[0]"),
+				Query:     "sum by (job) (rate(http_requests_total[5m]))",
+				Rewritten: "job:http_requests_total:rate5m",
+			},
+			{
+				Source:    dashboardSource("spec.panels.errors.spec.queries[0]"),
+				Query:     `sum(rate(http_requests_total{code=~"5.."}[5m])) by (job) / sum(rate(http_requests_total[5m])) by (job)`,
+				Rewritten: "job:http_requests_total:rate5m_2 / job:http_requests_total:rate5m",
+			},
+			{
+				Source:    dashboardSource("spec.panels.requests.spec.queries[0]"),
+				Query:     "sum  by(job)(rate(http_requests_total[5m]))",
+				Rewritten: "job:http_requests_total:rate5m",
+			},
+			{
+				Source:    globalVariableSource,
+				Query:     "sum by (job) (rate(http_requests_total{code=~'5..'}[5m])) > 0",
+				Rewritten: "job:http_requests_total:rate5m_2 > 0",
+			},
+		},
+		Errors: []string{
+			`Dashboard perses/api (spec.panels.broken.spec.queries[0]): unable to parse the query "sum(rate(up[5m])": 1:17: parse error: unclosed left parenthesis`,
+		},
+	}, result)
+}
+
+func TestAnalyzeEntitiesWithMinUsage(t *testing.T) {
+	result := AnalyzeEntities(loadEntities(t), analysis.Query{MinUsage: 3})
+	assert.Len(t, result.Duplicates, 1)
+	assert.Equal(t, `sum by (job) (rate(http_requests_total[5m]))`, result.Duplicates[0].Expr)
+	assert.Empty(t, result.Rewrites)
+}
+
+func TestAnalyzeEntitiesWithSharedQueries(t *testing.T) {
+	dashboard := &modelV1.Dashboard{}
+	testUtils.JSONUnmarshal([]byte(`{
+  "kind": "Dashboard",
+  "metadata": {"name": "shared", "project": "perses"},
+  "spec": {
+    "duration": "1h",
+    "queries": {
+      "requests": {
+        "kind": "TimeSeriesQuery",
+        "spec": {"plugin": {"kind": "PrometheusTimeSeriesQuery", "spec": {"query": "sum by (job) (rate(http_requests_total[5m]))"}}}
+      }
+    },
+    "panels": {
+      "graph": {
+        "kind": "Panel",
+        "spec": {
+          "display": {"name": "graph"},
+          "plugin": {"kind": "TimeSeriesChart", "spec": {}},
+          "queries": [{"$ref": "#/spec/queries/requests"}]
+        }
+      },
+      "stat": {
+        "kind": "Panel",
+        "spec": {
+          "display": {"name": "stat"},
+          "plugin": {"kind": "StatChart", "spec": {}},
+          "queries": [
+            {"$ref": "#/spec/queries/requests"},
+            {
+              "kind": "TimeSeriesQuery",
+              "spec": {"plugin": {"kind": "PrometheusTimeSeriesQuery", "spec": {"query": "sum(rate(http_requests_total[5m])) by (job)"}}}
+            }
+          ]
+        }
+      }
+    },
+    "layouts": []
+  }
+}`), dashboard)
+	result := AnalyzeEntities([]modelAPI.Entity{dashboard}, analysis.Query{})
+	// the shared query is counted once, whatever the number of panels referencing it
+	assert.Len(t, result.Duplicates, 1)
+	assert.Equal(t, 2, result.Duplicates[0].Usage)
+	assert.Equal(t, []analysis.Source{
+		{Kind: modelV1.KindDashboard, Project: "perses", Name: "shared", Location: "spec.queries.requests"},
+		{Kind: modelV1.KindDashboard, Project: "perses", Name: "shared", Location: "spec.panels.stat.spec.queries[1]"},
+	}, result.Duplicates[0].Sources)
+}
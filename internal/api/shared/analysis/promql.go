@@ -0,0 +1,240 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analysis
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/promql/parser"
+)
+
+// This file parses the queries with the PromQL parser of Prometheus and prints them in a canonical form, so two queries
+// written differently but meaning the same thing can be compared.
+// The dashboard variables are not valid PromQL, so they are replaced by placeholders before the query is parsed, and
+// restored once it is printed.
+
+// placeholderDurationBase is the first duration used to replace the variables used as a duration. It is unlikely to be
+// written in a query, and each placeholder has its own number of milliseconds, so they are printed differently.
+const placeholderDurationBase = 4242424242 * time.Millisecond
+
+var dashboardVariableRegexp = regexp.MustCompile(`^(\$\{[a-zA-Z0-9_-]+(?::[a-zA-Z]+)?}|\$[a-zA-Z0-9_]+)`)
+
+// expression is a parsed query.
+type expression struct {
+	root parser.Expr
+	// prepared is the query where the variables are replaced by the placeholders.
+	prepared string
+	// variables contains the variable replaced by each placeholder, as printed by the parser.
+	variables map[string]string
+}
+
+func parse(query string) (*expression, error) {
+	prepared, variables, hasBareVariable := replaceVariables(query, false)
+	root, err := parser.ParseExpr(prepared)
+	if err != nil && hasBareVariable {
+		// the variables outside a label matcher or a duration are either a metric, a label or a number, like the
+		// parameter of topk.
+		prepared, variables, _ = replaceVariables(query, true)
+		root, err = parser.ParseExpr(prepared)
+	}
+	if err != nil {
+		return nil, err
+	}
+	e := &expression{root: root, prepared: prepared, variables: variables}
+	normalize(root)
+	return e, nil
+}
+
+// replaceVariables replaces the variables with a placeholder matching the place where they are used: a string in a
+// label matcher, a duration in a range or after offset, and either an identifier or a number anywhere else.
+func replaceVariables(query string, bareAsNumber bool) (string, map[string]string, bool) {
+	var builder strings.Builder
+	variables := make(map[string]string)
+	hasBareVariable := false
+	var quote rune
+	inRange := false
+	for i := 0; i < len(query); i++ {
+		c := rune(query[i])
+		switch {
+		case quote != 0:
+			if c == '\\' && i+1 < len(query) {
+				builder.WriteByte(query[i])
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'' || c == '`':
+			quote = c
+		case c == '[':
+			inRange = true
+		case c == ']':
+			inRange = false
+		case c == '$':
+			variable := dashboardVariableRegexp.FindString(query[i:])
+			if len(variable) == 0 {
+				break
+			}
+			index := len(variables)
+			var placeholder string
+			switch {
+			case quote != 0:
+				placeholder = fmt.Sprintf("__perses_variable_%d__", index)
+			case inRange || strings.HasSuffix(strings.TrimSpace(builder.String()), "offset"):
+				placeholder = model.Duration(placeholderDurationBase + time.Duration(index)*time.Millisecond).String()
+			case bareAsNumber:
+				hasBareVariable = true
+				number := float64(placeholderDurationBase/time.Millisecond) + float64(index)
+				placeholder = strconv.FormatFloat(number, 'f', -1, 64)
+				// the parser prints the numbers with the default format of fmt.
+				variables[fmt.Sprint(number)] = variable
+			default:
+				hasBareVariable = true
+				placeholder = fmt.Sprintf("__perses_variable_%d__", index)
+			}
+			variables[placeholder] = variable
+			builder.WriteString(placeholder)
+			i += len(variable) - 1
+			continue
+		}
+		builder.WriteByte(query[i])
+	}
+	return builder.String(), variables, hasBareVariable
+}
+
+// normalize sorts the labels of the aggregations and of the vector matchings, so the order in which they are written
+// doesn't matter. The label matchers are already sorted when they are printed.
+func normalize(root parser.Node) {
+	parser.Inspect(root, func(node parser.Node, _ []parser.Node) error {
+		switch n := node.(type) {
+		case *parser.AggregateExpr:
+			sort.Strings(n.Grouping)
+		case *parser.BinaryExpr:
+			if n.VectorMatching != nil {
+				sort.Strings(n.VectorMatching.MatchingLabels)
+				sort.Strings(n.VectorMatching.Include)
+			}
+		}
+		return nil
+	})
+}
+
+// String prints the query in a canonical form.
+func (e *expression) String() string {
+	return e.format(e.root)
+}
+
+// format prints a node of the query in a canonical form, with its variables.
+func (e *expression) format(node parser.Node) string {
+	result := node.String()
+	for placeholder, variable := range e.variables {
+		result = strings.ReplaceAll(result, placeholder, variable)
+	}
+	return result
+}
+
+// rewrite prints the query where the nodes whose canonical form is a key of replacements are replaced by the
+// associated metric.
+func (e *expression) rewrite(replacements map[string]string) string {
+	// the query is parsed again, so the expression itself is not modified
+	root, err := parser.ParseExpr(e.prepared)
+	if err != nil {
+		return e.String()
+	}
+	normalize(root)
+	return e.format(e.substitute(root, replacements))
+}
+
+func (e *expression) substitute(expr parser.Expr, replacements map[string]string) parser.Expr {
+	if metric, ok := replacements[e.format(expr)]; ok {
+		return &parser.VectorSelector{
+			Name:          metric,
+			LabelMatchers: []*labels.Matcher{labels.MustNewMatcher(labels.MatchEqual, labels.MetricName, metric)},
+		}
+	}
+	switch n := expr.(type) {
+	case *parser.AggregateExpr:
+		n.Expr = e.substitute(n.Expr, replacements)
+		if n.Param != nil {
+			n.Param = e.substitute(n.Param, replacements)
+		}
+	case *parser.BinaryExpr:
+		n.LHS = e.substitute(n.LHS, replacements)
+		n.RHS = e.substitute(n.RHS, replacements)
+	case *parser.Call:
+		for i, arg := range n.Args {
+			n.Args[i] = e.substitute(arg, replacements)
+		}
+	case *parser.ParenExpr:
+		n.Expr = e.substitute(n.Expr, replacements)
+	case *parser.UnaryExpr:
+		n.Expr = e.substitute(n.Expr, replacements)
+	case *parser.SubqueryExpr:
+		n.Expr = e.substitute(n.Expr, replacements)
+	}
+	return expr
+}
+
+// isRecordable returns true if the node is worth being recorded, i.e. a function call, an aggregation or a binary
+// expression that is working on a range vector.
+func isRecordable(node parser.Node) bool {
+	switch node.(type) {
+	case *parser.Call, *parser.AggregateExpr, *parser.BinaryExpr:
+		return hasRange(node)
+	}
+	return false
+}
+
+// hasRange returns true if the node or one of its children is a range vector selector or a subquery.
+func hasRange(node parser.Node) bool {
+	result := false
+	parser.Inspect(node, func(current parser.Node, _ []parser.Node) error {
+		switch current.(type) {
+		case *parser.MatrixSelector, *parser.SubqueryExpr:
+			result = true
+		}
+		return nil
+	})
+	return result
+}
+
+// metricName returns the first metric name found in the node.
+func metricName(node parser.Node) string {
+	result := ""
+	parser.Inspect(node, func(current parser.Node, _ []parser.Node) error {
+		if len(result) > 0 {
+			return nil
+		}
+		if selector, ok := current.(*parser.VectorSelector); ok {
+			if len(selector.Name) > 0 {
+				result = selector.Name
+				return nil
+			}
+			for _, m := range selector.LabelMatchers {
+				if m.Name == labels.MetricName && m.Type == labels.MatchEqual {
+					result = m.Value
+					return nil
+				}
+			}
+		}
+		return nil
+	})
+	return result
+}
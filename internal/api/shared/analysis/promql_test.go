@@ -0,0 +1,107 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analysis
+
+import (
+	"testing"
+
+	"github.com/prometheus/prometheus/promql/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAndNormalize(t *testing.T) {
+	testSuite := []struct {
+		title    string
+		query    string
+		expected string
+	}{
+		{
+			title:    "simple selector",
+			query:    "up",
+			expected: "up",
+		},
+		{
+			title:    "matchers are sorted",
+			query:    `up{job = 'node', instance=~"demo.*"}`,
+			expected: `up{instance=~"demo.*",job="node"}`,
+		},
+		{
+			title:    "grouping after the aggregation",
+			query:    `sum(rate(http_requests_total{job="api"}[5m])) by (status, code)`,
+			expected: `sum by (code, status) (rate(http_requests_total{job="api"}[5m]))`,
+		},
+		{
+			title:    "grouping before the aggregation",
+			query:    "SUM BY(code,status)(rate(http_requests_total{job=\"api\"}[5m]))",
+			expected: `sum by (code, status) (rate(http_requests_total{job="api"}[5m]))`,
+		},
+		{
+			title:    "binary expression with modifiers",
+			query:    `sum by (job) (rate(a[5m])) / ignoring(code) group_left sum by (job) (rate(b[5m]))`,
+			expected: `sum by (job) (rate(a[5m])) / ignoring (code) group_left () sum by (job) (rate(b[5m]))`,
+		},
+		{
+			title:    "subquery, offset and variables",
+			query:    `max_over_time(rate(x{instance="$instance"}[$__rate_interval])[1h:5m] offset 1d)`,
+			expected: `max_over_time(rate(x{instance="$instance"}[$__rate_interval])[1h:5m] offset 1d)`,
+		},
+		{
+			title:    "number with an exponent",
+			query:    `rate(errors_total[5m]) > 1e-3`,
+			expected: `rate(errors_total[5m]) > 0.001`,
+		},
+		{
+			title:    "operator precedence",
+			query:    `a + b * c`,
+			expected: `a + b * c`,
+		},
+		{
+			title:    "variable used as a number",
+			query:    `topk($limit, rate(x[5m]))`,
+			expected: `topk($limit, rate(x[5m]))`,
+		},
+		{
+			title:    "function with several args",
+			query:    `histogram_quantile(0.99, sum by (le) (rate(http_duration_seconds_bucket[5m])))`,
+			expected: `histogram_quantile(0.99, sum by (le) (rate(http_duration_seconds_bucket[5m])))`,
+		},
+	}
+	for _, test := range testSuite {
+		t.Run(test.title, func(t *testing.T) {
+			n, err := parse(test.query)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, n.String())
+		})
+	}
+}
+
+func TestParseError(t *testing.T) {
+	for _, query := range []string{`up{job="node"`, `sum(rate(x[5m])`, `up{job="node}`} {
+		_, err := parse(query)
+		assert.Error(t, err, query)
+	}
+}
+
+func TestParsePrecedence(t *testing.T) {
+	e, err := parse(`a + b * c`)
+	assert.NoError(t, err)
+	root, ok := e.root.(*parser.BinaryExpr)
+	if assert.True(t, ok) {
+		assert.Equal(t, parser.ItemType(parser.ADD), root.Op)
+		rhs, isBinary := root.RHS.(*parser.BinaryExpr)
+		if assert.True(t, isBinary) {
+			assert.Equal(t, parser.ItemType(parser.MUL), rhs.Op)
+		}
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"github.com/perses/perses/internal/api/config"
+	"github.com/perses/perses/internal/api/shared"
+	databaseModel "github.com/perses/perses/internal/api/shared/database/model"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/sirupsen/logrus"
+)
+
+// Authorization decides whether an authenticated user has a given role on a project or on an organization.
+type Authorization interface {
+	IsEnabled() bool
+	GetPermissions(identity *shared.Identity) []v1.Permission
+	// HasPermission considers the permissions on the project and on its organization.
+	HasPermission(identity *shared.Identity, project string, role v1.Role) bool
+	// HasOrganizationPermission considers the permissions on the organization. The role viewer is also given by any
+	// permission on a project of the organization, since its projects are using the datasources of the organization.
+	HasOrganizationPermission(identity *shared.Identity, organization string, role v1.Role) bool
+	// GetGroupRoles returns the highest role of each group having a permission on the project.
+	GetGroupRoles(project string) map[string]v1.Role
+}
+
+// ProjectFinder finds a project whatever its organization is.
+type ProjectFinder interface {
+	Find(name string) (*v1.Project, error)
+}
+
+type authorization struct {
+	Authorization
+	enabled          bool
+	groupPermissions map[string][]v1.Permission
+	projects         ProjectFinder
+}
+
+// NewAuthorization returns the authorization based on the permissions of the groups. projects is used to find the
+// organization of the projects, when it is nil the permissions on the organizations never apply to the projects.
+func NewAuthorization(conf config.Authorization, projects ProjectFinder) Authorization {
+	groupPermissions := make(map[string][]v1.Permission)
+	for _, group := range conf.Groups {
+		groupPermissions[group.Group] = append(groupPermissions[group.Group], group.Permissions...)
+	}
+	return &authorization{
+		enabled:          conf.IsEnabled(),
+		groupPermissions: groupPermissions,
+		projects:         projects,
+	}
+}
+
+func (a *authorization) IsEnabled() bool {
+	return a.enabled
+}
+
+func (a *authorization) GetPermissions(identity *shared.Identity) []v1.Permission {
+	if identity == nil {
+		return nil
+	}
+	var result []v1.Permission
+	for _, group := range identity.Groups {
+		result = append(result, a.groupPermissions[group]...)
+	}
+	return result
+}
+
+func (a *authorization) HasPermission(identity *shared.Identity, project string, role v1.Role) bool {
+	if !a.enabled {
+		return true
+	}
+	// the organization is only looked up when a permission on an organization could give the role.
+	getOrganization := a.organizationOf(project)
+	for _, permission := range a.GetPermissions(identity) {
+		if permission.Allows(project, role) {
+			return true
+		}
+		if len(permission.Organization) == 0 || project == v1.WildcardProject || !permission.Role.Includes(role) {
+			continue
+		}
+		if organization, ok := getOrganization(); ok && permission.AllowsOrganization(organization, role) {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *authorization) HasOrganizationPermission(identity *shared.Identity, organization string, role v1.Role) bool {
+	if !a.enabled {
+		return true
+	}
+	permissions := a.GetPermissions(identity)
+	for _, permission := range permissions {
+		if permission.AllowsOrganization(organization, role) {
+			return true
+		}
+	}
+	if role != v1.RoleViewer {
+		return false
+	}
+	for _, permission := range permissions {
+		if len(permission.Project) == 0 || permission.Project == v1.WildcardProject {
+			continue
+		}
+		if projectOrganization, ok := a.getOrganization(permission.Project); ok && projectOrganization == organization {
+			return true
+		}
+	}
+	return false
+}
+
+// organizationOf returns a function looking up the organization of the project only once, at its first call.
+func (a *authorization) organizationOf(project string) func() (string, bool) {
+	var organization string
+	var found, done bool
+	return func() (string, bool) {
+		if !done {
+			organization, found = a.getOrganization(project)
+			done = true
+		}
+		return organization, found
+	}
+}
+
+// getOrganization returns the organization of the project, and false when the project cannot be found.
+func (a *authorization) getOrganization(project string) (string, bool) {
+	if a.projects == nil {
+		return "", false
+	}
+	entity, err := a.projects.Find(project)
+	if err != nil {
+		if !databaseModel.IsKeyNotFound(err) {
+			logrus.WithError(err).Errorf("unable to find the organization of the project %q", project)
+		}
+		return "", false
+	}
+	return entity.GetOrganization(), true
+}
+
+func (a *authorization) GetGroupRoles(project string) map[string]v1.Role {
+	result := make(map[string]v1.Role)
+	getOrganization := a.organizationOf(project)
+	for group, permissions := range a.groupPermissions {
+		for _, permission := range permissions {
+			onProject := permission.Project == v1.WildcardProject || (len(permission.Project) > 0 && permission.Project == project)
+			onOrganization := false
+			if len(permission.Organization) > 0 {
+				organization, ok := getOrganization()
+				onOrganization = ok && permission.Organization == organization
+			}
+			if !onProject && !onOrganization {
+				continue
+			}
+			if current, ok := result[group]; !ok || permission.Role.Includes(current) {
+				result[group] = permission.Role
+			}
+		}
+	}
+	return result
+}
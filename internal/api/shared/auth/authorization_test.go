@@ -0,0 +1,98 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"testing"
+
+	"github.com/perses/perses/internal/api/config"
+	"github.com/perses/perses/internal/api/shared"
+	databaseModel "github.com/perses/perses/internal/api/shared/database/model"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHasPermission(t *testing.T) {
+	authorization := NewAuthorization(config.Authorization{
+		Groups: []config.GroupPermissions{
+			{Group: "admins", Permissions: []v1.Permission{{Project: v1.WildcardProject, Role: v1.RoleAdmin}}},
+			{Group: "devs", Permissions: []v1.Permission{{Project: "perses", Role: v1.RoleEditor}}},
+		},
+	}, nil)
+	admin := &shared.Identity{Username: "root", Groups: []string{"admins"}}
+	dev := &shared.Identity{Username: "alice", Groups: []string{"devs"}}
+	assert.True(t, authorization.HasPermission(admin, "other", v1.RoleAdmin))
+	assert.True(t, authorization.HasPermission(dev, "perses", v1.RoleViewer))
+	assert.True(t, authorization.HasPermission(dev, "perses", v1.RoleEditor))
+	assert.False(t, authorization.HasPermission(dev, "perses", v1.RoleAdmin))
+	assert.False(t, authorization.HasPermission(dev, "other", v1.RoleViewer))
+	assert.False(t, authorization.HasPermission(dev, v1.WildcardProject, v1.RoleAdmin))
+}
+
+// fakeProjects contains the project "perses" in the organization "acme" and the project "other" in the default one.
+type fakeProjects struct{}
+
+func (f *fakeProjects) Find(name string) (*v1.Project, error) {
+	switch name {
+	case "perses":
+		return &v1.Project{Kind: v1.KindProject, Metadata: v1.OrganizationMetadata{Metadata: v1.Metadata{Name: name}, Organization: "acme"}}, nil
+	case "other":
+		return &v1.Project{Kind: v1.KindProject, Metadata: v1.OrganizationMetadata{Metadata: v1.Metadata{Name: name}, Organization: v1.DefaultOrganization}}, nil
+	}
+	return nil, &databaseModel.Error{Key: name, Code: databaseModel.ErrorCodeNotFound}
+}
+
+func TestHasPermissionWithOrganization(t *testing.T) {
+	authorization := NewAuthorization(config.Authorization{
+		Groups: []config.GroupPermissions{
+			{Group: "acme-admins", Permissions: []v1.Permission{{Organization: "acme", Role: v1.RoleAdmin}}},
+			{Group: "devs", Permissions: []v1.Permission{{Project: "perses", Role: v1.RoleEditor}}},
+			{Group: "admins", Permissions: []v1.Permission{{Project: v1.WildcardProject, Role: v1.RoleAdmin}}},
+		},
+	}, &fakeProjects{})
+	acmeAdmin := &shared.Identity{Username: "root", Groups: []string{"acme-admins"}}
+	dev := &shared.Identity{Username: "alice", Groups: []string{"devs"}}
+	admin := &shared.Identity{Username: "bob", Groups: []string{"admins"}}
+	assert.True(t, authorization.HasPermission(acmeAdmin, "perses", v1.RoleAdmin))
+	assert.False(t, authorization.HasPermission(acmeAdmin, "other", v1.RoleViewer))
+	assert.False(t, authorization.HasPermission(acmeAdmin, "unknown", v1.RoleViewer))
+	assert.False(t, authorization.HasPermission(acmeAdmin, v1.WildcardProject, v1.RoleViewer))
+
+	assert.True(t, authorization.HasOrganizationPermission(acmeAdmin, "acme", v1.RoleAdmin))
+	assert.False(t, authorization.HasOrganizationPermission(acmeAdmin, v1.DefaultOrganization, v1.RoleViewer))
+	// a permission on a project gives the role viewer on its organization
+	assert.True(t, authorization.HasOrganizationPermission(dev, "acme", v1.RoleViewer))
+	assert.False(t, authorization.HasOrganizationPermission(dev, "acme", v1.RoleEditor))
+	assert.False(t, authorization.HasOrganizationPermission(dev, v1.DefaultOrganization, v1.RoleViewer))
+	assert.True(t, authorization.HasOrganizationPermission(admin, "acme", v1.RoleAdmin))
+
+	assert.Equal(t, map[string]v1.Role{"acme-admins": v1.RoleAdmin, "devs": v1.RoleEditor, "admins": v1.RoleAdmin}, authorization.GetGroupRoles("perses"))
+	assert.Equal(t, map[string]v1.Role{"admins": v1.RoleAdmin}, authorization.GetGroupRoles("other"))
+}
+
+func TestHasPermissionDisabled(t *testing.T) {
+	authorization := NewAuthorization(config.Authorization{}, nil)
+	assert.True(t, authorization.HasPermission(&shared.Identity{Username: "alice"}, "perses", v1.RoleAdmin))
+}
+
+func TestGetGroupRoles(t *testing.T) {
+	authorization := NewAuthorization(config.Authorization{
+		Groups: []config.GroupPermissions{
+			{Group: "admins", Permissions: []v1.Permission{{Project: v1.WildcardProject, Role: v1.RoleAdmin}}},
+			{Group: "devs", Permissions: []v1.Permission{{Project: "perses", Role: v1.RoleViewer}, {Project: "perses", Role: v1.RoleEditor}}},
+			{Group: "others", Permissions: []v1.Permission{{Project: "other", Role: v1.RoleEditor}}},
+		},
+	}, nil)
+	assert.Equal(t, map[string]v1.Role{"admins": v1.RoleAdmin, "devs": v1.RoleEditor}, authorization.GetGroupRoles("perses"))
+}
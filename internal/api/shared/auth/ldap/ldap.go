@@ -0,0 +1,186 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ldap
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"time"
+
+	goldap "github.com/go-ldap/ldap/v3"
+	"github.com/perses/perses/internal/api/config"
+	"github.com/perses/perses/internal/api/shared"
+	promConfig "github.com/prometheus/common/config"
+	"github.com/sirupsen/logrus"
+)
+
+// maxGroupDepth stops the lookup of the nested groups, in case of a deep or a cyclic hierarchy.
+const maxGroupDepth = 10
+
+// ErrInvalidCredentials is returned when the login or the password is wrong.
+var ErrInvalidCredentials = errors.New("invalid credentials")
+
+// Provider authenticates the users against an LDAP directory.
+type Provider interface {
+	// Authenticate checks the credentials and returns the identity of the user, including its groups.
+	Authenticate(login string, password string) (*shared.Identity, error)
+}
+
+type provider struct {
+	Provider
+	conf      config.LDAPProvider
+	tlsConfig *tls.Config
+}
+
+func New(conf config.LDAPProvider) (Provider, error) {
+	u, err := url.Parse(conf.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ldap url %q: %w", conf.URL, err)
+	}
+	tlsConfig := &tls.Config{}
+	if conf.TLSConfig != nil {
+		if tlsConfig, err = promConfig.NewTLSConfig(conf.TLSConfig); err != nil {
+			return nil, fmt.Errorf("invalid ldap tls_config: %w", err)
+		}
+	}
+	// StartTLS requires the name of the server to verify its certificate.
+	if len(tlsConfig.ServerName) == 0 {
+		tlsConfig.ServerName = u.Hostname()
+	}
+	return &provider{
+		conf:      conf,
+		tlsConfig: tlsConfig,
+	}, nil
+}
+
+func (p *provider) Authenticate(login string, password string) (*shared.Identity, error) {
+	// A bind with an empty password is an anonymous bind that would succeed with most of the directories.
+	if len(login) == 0 || len(password) == 0 {
+		return nil, ErrInvalidCredentials
+	}
+	c, err := p.connect()
+	if err != nil {
+		return nil, err
+	}
+	defer c.Close()
+	if bindErr := p.bindServiceAccount(c); bindErr != nil {
+		return nil, bindErr
+	}
+	users, err := c.Search(goldap.NewSearchRequest(
+		p.conf.UserSearch.BaseDN, goldap.ScopeWholeSubtree, goldap.NeverDerefAliases, 2, 0, false,
+		fmt.Sprintf(p.conf.UserSearch.Filter, goldap.EscapeFilter(login)),
+		[]string{p.conf.UserSearch.EmailAttribute},
+		nil,
+	))
+	if err != nil && !goldap.IsErrorWithCode(err, goldap.LDAPResultSizeLimitExceeded) {
+		return nil, fmt.Errorf("unable to search the user %q: %w", login, err)
+	}
+	if users == nil || len(users.Entries) != 1 {
+		logrus.Debugf("none or several users found in the directory for the login %q", login)
+		return nil, ErrInvalidCredentials
+	}
+	user := users.Entries[0]
+	if bindErr := c.Bind(user.DN, password); bindErr != nil {
+		if goldap.IsErrorWithCode(bindErr, goldap.LDAPResultInvalidCredentials) {
+			return nil, ErrInvalidCredentials
+		}
+		return nil, bindErr
+	}
+	identity := &shared.Identity{
+		Username: login,
+		Email:    user.GetEqualFoldAttributeValue(p.conf.UserSearch.EmailAttribute),
+	}
+	if p.conf.GroupSearch == nil {
+		return identity, nil
+	}
+	// the user is not necessarily allowed to look at the groups
+	if bindErr := p.bindServiceAccount(c); bindErr != nil {
+		return nil, bindErr
+	}
+	groups, err := p.searchGroups(c, user.DN)
+	if err != nil {
+		return nil, fmt.Errorf("unable to search the groups of the user %q: %w", login, err)
+	}
+	identity.Groups = groups
+	return identity, nil
+}
+
+func (p *provider) connect() (*goldap.Conn, error) {
+	timeout := time.Duration(p.conf.Timeout)
+	c, err := goldap.DialURL(p.conf.URL, goldap.DialWithDialer(&net.Dialer{Timeout: timeout}), goldap.DialWithTLSConfig(p.tlsConfig))
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to the directory: %w", err)
+	}
+	c.SetTimeout(timeout)
+	if p.conf.StartTLS {
+		if tlsErr := c.StartTLS(p.tlsConfig); tlsErr != nil {
+			_ = c.Close()
+			return nil, tlsErr
+		}
+	}
+	return c, nil
+}
+
+func (p *provider) bindServiceAccount(c *goldap.Conn) error {
+	var err error
+	if len(p.conf.BindDN) == 0 {
+		err = c.UnauthenticatedBind("")
+	} else {
+		err = c.Bind(p.conf.BindDN, string(p.conf.BindPassword))
+	}
+	if err != nil {
+		return fmt.Errorf("unable to bind with the service account: %w", err)
+	}
+	return nil
+}
+
+// searchGroups returns the name of the groups the member is part of. With the nested groups, the groups of the
+// groups are looked up as well, until there is no new group or the maximum depth is reached.
+func (p *provider) searchGroups(c *goldap.Conn, memberDN string) ([]string, error) {
+	var names []string
+	visited := map[string]bool{memberDN: true}
+	members := []string{memberDN}
+	for depth := 0; len(members) > 0 && depth < maxGroupDepth; depth++ {
+		var next []string
+		for _, member := range members {
+			groups, err := c.Search(goldap.NewSearchRequest(
+				p.conf.GroupSearch.BaseDN, goldap.ScopeWholeSubtree, goldap.NeverDerefAliases, 0, 0, false,
+				fmt.Sprintf(p.conf.GroupSearch.Filter, goldap.EscapeFilter(member)),
+				[]string{p.conf.GroupSearch.NameAttribute},
+				nil,
+			))
+			if err != nil {
+				return nil, err
+			}
+			for _, group := range groups.Entries {
+				if visited[group.DN] {
+					continue
+				}
+				visited[group.DN] = true
+				if name := group.GetEqualFoldAttributeValue(p.conf.GroupSearch.NameAttribute); len(name) > 0 {
+					names = append(names, name)
+				}
+				next = append(next, group.DN)
+			}
+		}
+		if !p.conf.GroupSearch.Nested {
+			break
+		}
+		members = next
+	}
+	return names, nil
+}
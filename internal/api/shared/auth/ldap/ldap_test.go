@@ -0,0 +1,176 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ldap
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/perses/perses/internal/api/config"
+	"github.com/perses/perses/internal/api/shared"
+	promConfig "github.com/prometheus/common/config"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	serviceDN = "cn=perses,ou=services,dc=example,dc=org"
+	aliceDN   = "uid=alice,ou=people,dc=example,dc=org"
+)
+
+var (
+	testEntries = map[string]map[string][]string{
+		aliceDN: {
+			"uid":  {"alice"},
+			"mail": {"alice@example.org"},
+		},
+		"uid=bob,ou=people,dc=example,dc=org": {
+			"uid": {"bob"},
+		},
+		"cn=devs,ou=groups,dc=example,dc=org": {
+			"cn":     {"devs"},
+			"member": {aliceDN},
+		},
+		"cn=engineering,ou=groups,dc=example,dc=org": {
+			"cn": {"engineering"},
+			// the cycle must not prevent the lookup to end
+			"member": {"cn=devs,ou=groups,dc=example,dc=org", "cn=staff,ou=groups,dc=example,dc=org"},
+		},
+		"cn=staff,ou=groups,dc=example,dc=org": {
+			"cn":     {"staff"},
+			"member": {"cn=engineering,ou=groups,dc=example,dc=org"},
+		},
+	}
+	testPasswords = map[string]string{
+		serviceDN: "service-password",
+		aliceDN:   "alice-password",
+	}
+)
+
+func newTestConfig(url string, nested bool) config.LDAPProvider {
+	conf := config.LDAPProvider{
+		URL:          url,
+		BindDN:       serviceDN,
+		BindPassword: "service-password",
+		UserSearch:   config.LDAPUserSearch{BaseDN: "ou=people,dc=example,dc=org"},
+		GroupSearch:  &config.LDAPGroupSearch{BaseDN: "ou=groups,dc=example,dc=org", Nested: nested},
+	}
+	_ = conf.Verify()
+	_ = conf.UserSearch.Verify()
+	_ = conf.GroupSearch.Verify()
+	return conf
+}
+
+func TestAuthenticate(t *testing.T) {
+	server := newTestServer(t, testEntries, testPasswords, false)
+	testSuites := []struct {
+		title            string
+		nested           bool
+		login            string
+		password         string
+		expectedIdentity *shared.Identity
+		expectedErr      error
+	}{
+		{
+			title:    "direct groups",
+			login:    "alice",
+			password: "alice-password",
+			expectedIdentity: &shared.Identity{
+				Username: "alice",
+				Email:    "alice@example.org",
+				Groups:   []string{"devs"},
+			},
+		},
+		{
+			title:    "nested groups",
+			nested:   true,
+			login:    "alice",
+			password: "alice-password",
+			expectedIdentity: &shared.Identity{
+				Username: "alice",
+				Email:    "alice@example.org",
+				Groups:   []string{"devs", "engineering", "staff"},
+			},
+		},
+		{
+			title:       "wrong password",
+			login:       "alice",
+			password:    "bob-password",
+			expectedErr: ErrInvalidCredentials,
+		},
+		{
+			title:       "empty password",
+			login:       "alice",
+			expectedErr: ErrInvalidCredentials,
+		},
+		{
+			title:       "unknown user",
+			login:       "carol",
+			password:    "alice-password",
+			expectedErr: ErrInvalidCredentials,
+		},
+		{
+			title:       "filter injection",
+			login:       "*",
+			password:    "alice-password",
+			expectedErr: ErrInvalidCredentials,
+		},
+	}
+	for _, test := range testSuites {
+		t.Run(test.title, func(t *testing.T) {
+			p, err := New(newTestConfig(fmt.Sprintf("ldap://%s", server.address()), test.nested))
+			assert.NoError(t, err)
+			identity, err := p.Authenticate(test.login, test.password)
+			assert.Equal(t, test.expectedErr, err)
+			if test.expectedIdentity != nil {
+				assert.Equal(t, test.expectedIdentity.Username, identity.Username)
+				assert.Equal(t, test.expectedIdentity.Email, identity.Email)
+				assert.ElementsMatch(t, test.expectedIdentity.Groups, identity.Groups)
+			}
+		})
+	}
+}
+
+func TestAuthenticateWithTLS(t *testing.T) {
+	testSuites := []struct {
+		title    string
+		ldaps    bool
+		startTLS bool
+	}{
+		{
+			title: "ldaps",
+			ldaps: true,
+		},
+		{
+			title:    "start tls",
+			startTLS: true,
+		},
+	}
+	for _, test := range testSuites {
+		t.Run(test.title, func(t *testing.T) {
+			server := newTestServer(t, testEntries, testPasswords, test.ldaps)
+			scheme := "ldap"
+			if test.ldaps {
+				scheme = "ldaps"
+			}
+			conf := newTestConfig(fmt.Sprintf("%s://%s", scheme, server.address()), false)
+			conf.StartTLS = test.startTLS
+			conf.TLSConfig = &promConfig.TLSConfig{InsecureSkipVerify: true}
+			p, err := New(conf)
+			assert.NoError(t, err)
+			identity, err := p.Authenticate("alice", "alice-password")
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"devs"}, identity.Groups)
+		})
+	}
+}
@@ -0,0 +1,218 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ldap
+
+import (
+	"bufio"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	goldap "github.com/go-ldap/ldap/v3"
+)
+
+// testServer is an in-process stand-in of an LDAP directory. It supports the simple bind, the search, and StartTLS.
+type testServer struct {
+	listener  net.Listener
+	tlsConfig *tls.Config
+	// entries contains the attributes of the objects by DN
+	entries map[string]map[string][]string
+	// passwords contains the passwords by DN
+	passwords map[string]string
+}
+
+func newTestServer(t *testing.T, entries map[string]map[string][]string, passwords map[string]string, ldaps bool) *testServer {
+	s := &testServer{
+		tlsConfig: newTestTLSConfig(t),
+		entries:   entries,
+		passwords: passwords,
+	}
+	var err error
+	if ldaps {
+		s.listener, err = tls.Listen("tcp", "127.0.0.1:0", s.tlsConfig)
+	} else {
+		s.listener, err = net.Listen("tcp", "127.0.0.1:0")
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = s.listener.Close() })
+	go func() {
+		for {
+			c, acceptErr := s.listener.Accept()
+			if acceptErr != nil {
+				return
+			}
+			go s.serve(c)
+		}
+	}()
+	return s
+}
+
+func (s *testServer) address() string {
+	return s.listener.Addr().String()
+}
+
+func (s *testServer) serve(netConn net.Conn) {
+	defer netConn.Close()
+	reader := bufio.NewReader(netConn)
+	for {
+		message, err := ber.ReadPacket(reader)
+		if err != nil || len(message.Children) < 2 {
+			return
+		}
+		id, _ := message.Children[0].Value.(int64)
+		op := message.Children[1]
+		write := func(response *ber.Packet) {
+			envelope := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "")
+			envelope.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, id, ""))
+			envelope.AppendChild(response)
+			_, _ = netConn.Write(envelope.Bytes())
+		}
+		switch op.Tag {
+		case goldap.ApplicationBindRequest:
+			write(s.bind(op))
+		case goldap.ApplicationSearchRequest:
+			for _, response := range s.search(op) {
+				write(response)
+			}
+		case goldap.ApplicationExtendedRequest:
+			write(testResult(goldap.ApplicationExtendedResponse, goldap.LDAPResultSuccess, ""))
+			tlsConn := tls.Server(netConn, s.tlsConfig)
+			if tlsConn.Handshake() != nil {
+				return
+			}
+			netConn = tlsConn
+			reader = bufio.NewReader(tlsConn)
+		default:
+			// unbind or unsupported operation
+			return
+		}
+	}
+}
+
+func (s *testServer) bind(op *ber.Packet) *ber.Packet {
+	dn := op.Children[1].Data.String()
+	password := op.Children[2].Data.String()
+	if (len(dn) == 0 && len(password) == 0) || (len(password) > 0 && s.passwords[dn] == password) {
+		return testResult(goldap.ApplicationBindResponse, goldap.LDAPResultSuccess, "")
+	}
+	return testResult(goldap.ApplicationBindResponse, goldap.LDAPResultInvalidCredentials, "invalid credentials")
+}
+
+func (s *testServer) search(op *ber.Packet) []*ber.Packet {
+	baseDN := strings.ToLower(op.Children[0].Data.String())
+	filter := op.Children[6]
+	requested := op.Children[7].Children
+	var responses []*ber.Packet
+	for dn, attributes := range s.entries {
+		if !strings.HasSuffix(strings.ToLower(dn), baseDN) || !matchFilter(filter, attributes) {
+			continue
+		}
+		encodedAttributes := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "")
+		for _, attribute := range requested {
+			name := attribute.Data.String()
+			values := attributes[name]
+			if len(values) == 0 {
+				continue
+			}
+			encodedValues := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "")
+			for _, value := range values {
+				encodedValues.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, value, ""))
+			}
+			encodedAttribute := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "")
+			encodedAttribute.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, name, ""))
+			encodedAttribute.AppendChild(encodedValues)
+			encodedAttributes.AppendChild(encodedAttribute)
+		}
+		entry := ber.Encode(ber.ClassApplication, ber.TypeConstructed, goldap.ApplicationSearchResultEntry, nil, "")
+		entry.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, dn, ""))
+		entry.AppendChild(encodedAttributes)
+		responses = append(responses, entry)
+	}
+	return append(responses, testResult(goldap.ApplicationSearchResultDone, goldap.LDAPResultSuccess, ""))
+}
+
+func matchFilter(filter *ber.Packet, attributes map[string][]string) bool {
+	switch filter.Tag {
+	case goldap.FilterAnd:
+		for _, child := range filter.Children {
+			if !matchFilter(child, attributes) {
+				return false
+			}
+		}
+		return true
+	case goldap.FilterOr:
+		for _, child := range filter.Children {
+			if matchFilter(child, attributes) {
+				return true
+			}
+		}
+		return false
+	case goldap.FilterNot:
+		return !matchFilter(filter.Children[0], attributes)
+	case goldap.FilterPresent:
+		return len(attributes[filter.Data.String()]) > 0
+	case goldap.FilterEqualityMatch:
+		for _, value := range attributes[filter.Children[0].Data.String()] {
+			if strings.EqualFold(value, filter.Children[1].Data.String()) {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
+
+func testResult(tag ber.Tag, code uint16, message string) *ber.Packet {
+	result := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "")
+	result.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, int64(code), ""))
+	result.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", ""))
+	result.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, message, ""))
+	return result
+}
+
+func newTestTLSConfig(t *testing.T) *tls.Config {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}},
+		MinVersion:   tls.VersionTLS12,
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/perses/perses/internal/api/config"
+	"github.com/perses/perses/internal/api/shared"
+)
+
+const (
+	// CookieName is the name of the cookie containing the access token, used by the frontend.
+	CookieName = "perses_token"
+	issuer     = "perses"
+)
+
+type claims struct {
+	jwt.StandardClaims
+	Email  string   `json:"email,omitempty"`
+	Groups []string `json:"groups,omitempty"`
+}
+
+// Token delivers and verifies the signed access tokens carrying the identity of the users once they are logged in.
+type Token interface {
+	Sign(identity *shared.Identity) (string, time.Time, error)
+	Parse(token string) (*shared.Identity, error)
+}
+
+type token struct {
+	Token
+	key []byte
+	ttl time.Duration
+}
+
+func NewToken(conf config.Session) Token {
+	return &token{
+		key: []byte(conf.Key),
+		ttl: time.Duration(conf.TTL),
+	}
+}
+
+func (t *token) Sign(identity *shared.Identity) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(t.ttl)
+	signedToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, &claims{
+		StandardClaims: jwt.StandardClaims{
+			Subject:   identity.Username,
+			Issuer:    issuer,
+			IssuedAt:  now.Unix(),
+			ExpiresAt: expiresAt.Unix(),
+		},
+		Email:  identity.Email,
+		Groups: identity.Groups,
+	}).SignedString(t.key)
+	return signedToken, expiresAt, err
+}
+
+func (t *token) Parse(signedToken string) (*shared.Identity, error) {
+	result := &claims{}
+	parser := &jwt.Parser{ValidMethods: []string{jwt.SigningMethodHS256.Name}}
+	if _, err := parser.ParseWithClaims(signedToken, result, func(*jwt.Token) (interface{}, error) {
+		return t.key, nil
+	}); err != nil {
+		return nil, err
+	}
+	if !result.VerifyIssuer(issuer, true) || len(result.Subject) == 0 {
+		return nil, fmt.Errorf("invalid token")
+	}
+	return &shared.Identity{
+		Username: result.Subject,
+		Email:    result.Email,
+		Groups:   result.Groups,
+	}, nil
+}
@@ -0,0 +1,46 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/perses/perses/internal/api/config"
+	"github.com/perses/perses/internal/api/shared"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToken(t *testing.T) {
+	identity := &shared.Identity{Username: "alice", Email: "alice@example.org", Groups: []string{"devs"}}
+	tokenManager := NewToken(config.Session{Key: "01234567890123456789012345678901", TTL: model.Duration(time.Hour)})
+	signedToken, expiresAt, err := tokenManager.Sign(identity)
+	assert.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), expiresAt, time.Minute)
+
+	result, err := tokenManager.Parse(signedToken)
+	assert.NoError(t, err)
+	assert.Equal(t, identity, result)
+
+	otherKey := NewToken(config.Session{Key: "abcdefghijabcdefghijabcdefghijab", TTL: model.Duration(time.Hour)})
+	_, err = otherKey.Parse(signedToken)
+	assert.Error(t, err)
+
+	expired := NewToken(config.Session{Key: "01234567890123456789012345678901", TTL: model.Duration(-time.Minute)})
+	expiredToken, _, err := expired.Sign(identity)
+	assert.NoError(t, err)
+	_, err = tokenManager.Parse(expiredToken)
+	assert.Error(t, err)
+}
@@ -15,25 +15,36 @@ package databaseFile
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/fs"
-	"os"
 	"path"
 	"path/filepath"
 	"reflect"
 	"strings"
+	"sync"
 
 	"github.com/perses/perses/internal/api/config"
+	"github.com/perses/perses/internal/api/interface/v1/project"
 	databaseModel "github.com/perses/perses/internal/api/shared/database/model"
 	modelAPI "github.com/perses/perses/pkg/model/api"
 	modelV1 "github.com/perses/perses/pkg/model/api/v1"
 	"gopkg.in/yaml.v2"
 )
 
-func generateID(kind modelV1.Kind, metadata modelAPI.Metadata) (string, error) {
+func (d *DAO) generateID(kind modelV1.Kind, metadata modelAPI.Metadata) (string, error) {
 	switch m := metadata.(type) {
 	case *modelV1.ProjectMetadata:
-		return fmt.Sprintf("/%s/%s/%s", modelV1.PluralKindMap[kind], m.Project, m.Name), nil
+		// the resources of a project are stored in the folder of its organization.
+		organization, err := d.getOrganization(m.Project)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("/%s/%s/%s/%s", modelV1.PluralKindMap[kind], organization, m.Project, m.Name), nil
+	case *modelV1.OrganizationMetadata:
+		// The organization is empty for the projects created before the organizations exist.
+		// In this case, the path is cleaned when the file is accessed and the project is stored at the root of the folder.
+		return fmt.Sprintf("/%s/%s/%s", modelV1.PluralKindMap[kind], m.Organization, m.Name), nil
 	case *modelV1.Metadata:
 		return fmt.Sprintf("/%s/%s", modelV1.PluralKindMap[kind], m.Name), nil
 	}
@@ -44,10 +55,28 @@ type DAO struct {
 	databaseModel.DAO
 	Folder    string
 	Extension config.FileExtension
+	// storage is the disk when it is not set.
+	storage storage
+	// organizations keeps the organization of the projects already found, by project name.
+	organizations sync.Map
+	// projectMutex makes the verification of the uniqueness of the name of a project and its creation atomic.
+	projectMutex sync.Mutex
+	// writeMutex blocks the writes while a transaction is running.
+	writeMutex sync.RWMutex
+}
+
+// NewInMemoryDAO returns a database keeping the files in memory. It is lost when the process stops, so it is only
+// meant for the tests.
+func NewInMemoryDAO() *DAO {
+	return &DAO{
+		Folder:    "/",
+		Extension: config.JSONExtension,
+		storage:   newMemoryStorage(),
+	}
 }
 
 func (d *DAO) Init() error {
-	return nil
+	return d.migrate()
 }
 
 func (d *DAO) Close() error {
@@ -55,33 +84,63 @@ func (d *DAO) Close() error {
 }
 
 func (d *DAO) Create(entity modelAPI.Entity) error {
-	key, generateIDErr := generateID(modelV1.Kind(entity.GetKind()), entity.GetMetadata())
+	d.writeMutex.RLock()
+	defer d.writeMutex.RUnlock()
+	return d.create(entity)
+}
+
+func (d *DAO) create(entity modelAPI.Entity) error {
+	key, generateIDErr := d.generateID(modelV1.Kind(entity.GetKind()), entity.GetMetadata())
 	if generateIDErr != nil {
 		return generateIDErr
 	}
+	if modelV1.Kind(entity.GetKind()) == modelV1.KindProject {
+		// the name of a project is unique across all the organizations
+		d.projectMutex.Lock()
+		defer d.projectMutex.Unlock()
+		if _, isExist, err := d.findOrganization(entity.GetMetadata().GetName()); err != nil {
+			return err
+		} else if isExist {
+			return &databaseModel.Error{Key: key, Code: databaseModel.ErrorCodeConflict}
+		}
+	}
 	filePath := d.buildPath(key)
-	if _, err := os.Stat(filePath); err == nil {
+	if exist, err := d.getStorage().exists(filePath); err == nil && exist {
 		// The file exists, so we should return a conflict error.
 		return &databaseModel.Error{Key: key, Code: databaseModel.ErrorCodeConflict}
 	}
-	return d.upsert(key, entity)
+	if err := d.upsert(key, entity); err != nil {
+		return err
+	}
+	d.rememberOrganization(entity)
+	return nil
 }
 func (d *DAO) Upsert(entity modelAPI.Entity) error {
-	key, generateIDErr := generateID(modelV1.Kind(entity.GetKind()), entity.GetMetadata())
+	d.writeMutex.RLock()
+	defer d.writeMutex.RUnlock()
+	return d.upsertEntity(entity)
+}
+
+func (d *DAO) upsertEntity(entity modelAPI.Entity) error {
+	key, generateIDErr := d.generateID(modelV1.Kind(entity.GetKind()), entity.GetMetadata())
 	if generateIDErr != nil {
 		return generateIDErr
 	}
-	return d.upsert(key, entity)
+	if err := d.upsert(key, entity); err != nil {
+		return err
+	}
+	d.rememberOrganization(entity)
+	return nil
 }
 func (d *DAO) Get(kind modelV1.Kind, metadata modelAPI.Metadata, entity modelAPI.Entity) error {
-	key, generateIDErr := generateID(kind, metadata)
+	key, generateIDErr := d.generateID(kind, metadata)
 	if generateIDErr != nil {
 		return generateIDErr
 	}
 	filePath := d.buildPath(key)
-	data, err := os.ReadFile(filePath)
+	data, err := d.getStorage().readFile(filePath)
 	if err != nil {
-		if os.IsNotExist(err) {
+		if errors.Is(err, fs.ErrNotExist) {
 			return &databaseModel.Error{Key: key, Code: databaseModel.ErrorCodeNotFound}
 		}
 		return err
@@ -124,13 +183,9 @@ func (d *DAO) Query(query databaseModel.Query, slice interface{}) error {
 	if files, err = d.visit(folder, prefix); err != nil {
 		return err
 	}
-	if len(files) <= 0 {
-		// in case the result is empty, let's initialize the slice just to avoid returning a nil slice
-		sliceElem = reflect.MakeSlice(typeParameter, 0, 0)
-	}
 	for _, file := range files {
 		// now read all file and append them to the final result
-		data, readErr := os.ReadFile(file)
+		data, readErr := d.getStorage().readFile(file)
 		if readErr != nil {
 			return readErr
 		}
@@ -147,6 +202,9 @@ func (d *DAO) Query(query databaseModel.Query, slice interface{}) error {
 		if unmarshalErr := d.unmarshal(data, obj); unmarshalErr != nil {
 			return unmarshalErr
 		}
+		if !databaseModel.MatchTeam(query, obj) {
+			continue
+		}
 		if typeParameter.Elem().Kind() != reflect.Ptr {
 			// In case the type of the slice element is not a pointer,
 			// we should return the value of the pointer created in the previous step.
@@ -155,27 +213,45 @@ func (d *DAO) Query(query databaseModel.Query, slice interface{}) error {
 			sliceElem.Set(reflect.Append(sliceElem, value))
 		}
 	}
+	if sliceElem.Len() == 0 {
+		// in case the result is empty, let's initialize the slice just to avoid returning a nil slice
+		sliceElem = reflect.MakeSlice(typeParameter, 0, 0)
+	}
 	// at the end reset the element of the slice to ensure we didn't disconnect the link between the pointer to the slice and the actual slice
 	result.Elem().Set(sliceElem)
 	return nil
 }
 func (d *DAO) Delete(kind modelV1.Kind, metadata modelAPI.Metadata) error {
-	key, generateIDErr := generateID(kind, metadata)
+	d.writeMutex.RLock()
+	defer d.writeMutex.RUnlock()
+	return d.remove(kind, metadata)
+}
+
+func (d *DAO) remove(kind modelV1.Kind, metadata modelAPI.Metadata) error {
+	key, generateIDErr := d.generateID(kind, metadata)
 	if generateIDErr != nil {
 		return generateIDErr
 	}
 	filePath := d.buildPath(key)
-	err := os.Remove(filePath)
+	err := d.getStorage().remove(filePath)
 	if err != nil {
-		if os.IsNotExist(err) {
+		if errors.Is(err, fs.ErrNotExist) {
 			return &databaseModel.Error{Key: key, Code: databaseModel.ErrorCodeNotFound}
 		}
 		return err
 	}
+	if m, ok := metadata.(*modelV1.OrganizationMetadata); ok && kind == modelV1.KindProject {
+		d.organizations.Delete(m.Name)
+	}
 	return nil
 }
 
 func (d *DAO) DeleteByQuery(query databaseModel.Query) error {
+	d.writeMutex.RLock()
+	defer d.writeMutex.RUnlock()
+	if _, ok := query.(*project.Query); ok {
+		d.forgetOrganizations()
+	}
 	folder, prefix, isExist, err := d.buildQuery(query)
 	if err != nil {
 		return fmt.Errorf("unable to build the query: %s", err)
@@ -184,7 +260,7 @@ func (d *DAO) DeleteByQuery(query databaseModel.Query) error {
 		return nil
 	}
 	if len(prefix) == 0 {
-		return os.RemoveAll(folder)
+		return d.getStorage().removeAll(folder)
 	}
 	// in case there is a prefix file name we need to delete only the files that is matching the prefix and not the folder entirely
 	var files []string
@@ -195,7 +271,7 @@ func (d *DAO) DeleteByQuery(query databaseModel.Query) error {
 		return nil
 	}
 	for _, file := range files {
-		if removeErr := os.Remove(file); removeErr != nil {
+		if removeErr := d.getStorage().remove(file); removeErr != nil {
 			return removeErr
 		}
 	}
@@ -206,16 +282,81 @@ func (d *DAO) HealthCheck() bool {
 	return true
 }
 
+// getOrganization returns the organization of the project. The default organization is returned when the project
+// doesn't exist, or when it has been created before the organizations exist.
+func (d *DAO) getOrganization(project string) (string, error) {
+	organization, isExist, err := d.findOrganization(project)
+	if err != nil {
+		return "", err
+	}
+	if !isExist {
+		return modelV1.DefaultOrganization, nil
+	}
+	return organization, nil
+}
+
+// findOrganization looks for the project in the folder of every organization.
+func (d *DAO) findOrganization(project string) (string, bool, error) {
+	if organization, ok := d.organizations.Load(project); ok {
+		return organization.(string), true, nil
+	}
+	projectFolder := filepath.Clean(path.Join(d.Folder, modelV1.PluralKindMap[modelV1.KindProject]))
+	if isExist, err := d.isFolderExist(projectFolder); err != nil || !isExist {
+		return "", false, err
+	}
+	files, err := d.visit(projectFolder, project)
+	if err != nil {
+		return "", false, err
+	}
+	fileName := fmt.Sprintf("%s.%s", project, d.Extension)
+	for _, file := range files {
+		if filepath.Base(file) != fileName {
+			continue
+		}
+		organization := modelV1.DefaultOrganization
+		if folder := filepath.Dir(file); folder != projectFolder {
+			organization = filepath.Base(folder)
+		}
+		d.organizations.Store(project, organization)
+		return organization, true, nil
+	}
+	return "", false, nil
+}
+
+// rememberOrganization keeps the organization of the project created or updated, so it doesn't have to be searched.
+func (d *DAO) rememberOrganization(entity modelAPI.Entity) {
+	m, ok := entity.GetMetadata().(*modelV1.OrganizationMetadata)
+	if !ok || modelV1.Kind(entity.GetKind()) != modelV1.KindProject {
+		return
+	}
+	organization := m.Organization
+	if len(organization) == 0 {
+		organization = modelV1.DefaultOrganization
+	}
+	d.organizations.Store(m.Name, organization)
+}
+
+func (d *DAO) forgetOrganizations() {
+	d.organizations.Range(func(key, _ any) bool {
+		d.organizations.Delete(key)
+		return true
+	})
+}
+
 func (d *DAO) upsert(key string, entity modelAPI.Entity) error {
 	filePath := d.buildPath(key)
-	if err := os.MkdirAll(filepath.Dir(filePath), 0700); err != nil {
-		return err
-	}
 	data, err := d.marshal(entity)
 	if err != nil {
 		return err
 	}
-	return os.WriteFile(filePath, data, 0600)
+	return d.getStorage().writeFile(filePath, data)
+}
+
+func (d *DAO) getStorage() storage {
+	if d.storage == nil {
+		return &diskStorage{}
+	}
+	return d.storage
 }
 
 func (d *DAO) buildPath(key string) string {
@@ -237,24 +378,20 @@ func (d *DAO) marshal(entity interface{}) ([]byte, error) {
 }
 
 func (d *DAO) visit(rootPath string, prefix string) ([]string, error) {
+	files, err := d.getStorage().walk(rootPath)
+	if err != nil {
+		return nil, err
+	}
 	var result []string
-	err := filepath.Walk(rootPath, func(path string, info fs.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
-		if info.IsDir() {
-			return nil
-		}
-		fileName := info.Name()
+	for _, file := range files {
+		fileName := filepath.Base(file)
 		if filepath.Ext(fileName) != fmt.Sprintf(".%s", d.Extension) {
 			// skip every file that doesn't have the correct extension
-			return nil
+			continue
 		}
 		if len(prefix) == 0 || strings.HasPrefix(fileName, prefix) {
-			result = append(result, path)
+			result = append(result, file)
 		}
-		return nil
-	})
-
-	return result, err
+	}
+	return result, nil
 }
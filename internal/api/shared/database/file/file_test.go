@@ -18,6 +18,7 @@ import (
 	"testing"
 
 	"github.com/perses/perses/internal/api/config"
+	"github.com/perses/perses/internal/api/interface/v1/folder"
 	"github.com/perses/perses/internal/api/interface/v1/project"
 	databaseModel "github.com/perses/perses/internal/api/shared/database/model"
 	modelV1 "github.com/perses/perses/pkg/model/api/v1"
@@ -40,10 +41,8 @@ func newDAO() *DAO {
 func TestDAO_Create(t *testing.T) {
 	d := newDAO()
 	projectEntity := &modelV1.Project{
-		Kind: modelV1.KindProject,
-		Metadata: modelV1.Metadata{
-			Name: "perses",
-		},
+		Kind:     modelV1.KindProject,
+		Metadata: *modelV1.NewOrganizationMetadata(modelV1.DefaultOrganization, "perses"),
 	}
 	assert.NoError(t, d.Create(projectEntity))
 	assert.True(t, databaseModel.IsKeyConflict(d.Create(projectEntity)))
@@ -53,10 +52,8 @@ func TestDAO_Create(t *testing.T) {
 func TestDAO_Upsert(t *testing.T) {
 	d := newDAO()
 	projectEntity := &modelV1.Project{
-		Kind: modelV1.KindProject,
-		Metadata: modelV1.Metadata{
-			Name: "perses",
-		},
+		Kind:     modelV1.KindProject,
+		Metadata: *modelV1.NewOrganizationMetadata(modelV1.DefaultOrganization, "perses"),
 	}
 	assert.NoError(t, d.Upsert(projectEntity))
 	assert.NoError(t, d.Upsert(projectEntity))
@@ -66,10 +63,8 @@ func TestDAO_Upsert(t *testing.T) {
 func TestDAO_Get(t *testing.T) {
 	d := newDAO()
 	projectEntity := &modelV1.Project{
-		Kind: modelV1.KindProject,
-		Metadata: modelV1.Metadata{
-			Name: "perses",
-		},
+		Kind:     modelV1.KindProject,
+		Metadata: *modelV1.NewOrganizationMetadata(modelV1.DefaultOrganization, "perses"),
 	}
 	assert.NoError(t, d.Create(projectEntity))
 	result := &modelV1.Project{}
@@ -81,10 +76,8 @@ func TestDAO_Get(t *testing.T) {
 func TestDAO_Query(t *testing.T) {
 	d := newDAO()
 	projectEntity := &modelV1.Project{
-		Kind: modelV1.KindProject,
-		Metadata: modelV1.Metadata{
-			Name: "perses",
-		},
+		Kind:     modelV1.KindProject,
+		Metadata: *modelV1.NewOrganizationMetadata(modelV1.DefaultOrganization, "perses"),
 	}
 	assert.NoError(t, d.Create(projectEntity))
 	var result []modelV1.Project
@@ -99,10 +92,8 @@ func TestDAO_Query(t *testing.T) {
 func TestDAO_Delete(t *testing.T) {
 	d := newDAO()
 	projectEntity := &modelV1.Project{
-		Kind: modelV1.KindProject,
-		Metadata: modelV1.Metadata{
-			Name: "perses",
-		},
+		Kind:     modelV1.KindProject,
+		Metadata: *modelV1.NewOrganizationMetadata(modelV1.DefaultOrganization, "perses"),
 	}
 	assert.NoError(t, d.Create(projectEntity))
 	assert.NoError(t, d.Delete(modelV1.KindProject, projectEntity.GetMetadata()))
@@ -110,3 +101,97 @@ func TestDAO_Delete(t *testing.T) {
 	assert.True(t, databaseModel.IsKeyNotFound(d.Get(modelV1.KindProject, projectEntity.GetMetadata(), result)))
 	clear(t)
 }
+
+func TestInMemoryDAO(t *testing.T) {
+	d := NewInMemoryDAO()
+	var result []*modelV1.Project
+	assert.NoError(t, d.Query(&project.Query{Organization: "acme"}, &result))
+	assert.Empty(t, result)
+
+	for _, name := range []string{"billing", "monitoring", "network"} {
+		assert.NoError(t, d.Create(&modelV1.Project{
+			Kind:     modelV1.KindProject,
+			Metadata: *modelV1.NewOrganizationMetadata("acme", name),
+		}))
+	}
+	assert.True(t, databaseModel.IsKeyConflict(d.Create(&modelV1.Project{
+		Kind:     modelV1.KindProject,
+		Metadata: *modelV1.NewOrganizationMetadata("acme", "billing"),
+	})))
+	assert.NoError(t, d.Query(&project.Query{Organization: "acme", NamePrefix: "m"}, &result))
+	if assert.Len(t, result, 1) {
+		assert.Equal(t, "monitoring", result[0].Metadata.Name)
+	}
+
+	metadata := modelV1.NewOrganizationMetadata("acme", "billing")
+	assert.NoError(t, d.Delete(modelV1.KindProject, metadata))
+	assert.True(t, databaseModel.IsKeyNotFound(d.Delete(modelV1.KindProject, metadata)))
+	assert.True(t, databaseModel.IsKeyNotFound(d.Get(modelV1.KindProject, metadata, &modelV1.Project{})))
+
+	assert.NoError(t, d.DeleteByQuery(&project.Query{Organization: "acme"}))
+	assert.NoError(t, d.Query(&project.Query{}, &result))
+	assert.Empty(t, result)
+}
+
+func TestProjectResourceStoredInOrganization(t *testing.T) {
+	d := NewInMemoryDAO()
+	assert.NoError(t, d.Create(&modelV1.Project{
+		Kind:     modelV1.KindProject,
+		Metadata: *modelV1.NewOrganizationMetadata("acme", "perses"),
+	}))
+	folderEntity := &modelV1.Folder{
+		Kind:     modelV1.KindFolder,
+		Metadata: *modelV1.NewProjectMetadata("perses", "demo"),
+		Spec:     []modelV1.FolderSpec{{Kind: modelV1.KindDashboard, Name: "home"}},
+	}
+	assert.NoError(t, d.Create(folderEntity))
+	exist, err := d.getStorage().exists("/folders/acme/perses/demo.json")
+	assert.NoError(t, err)
+	assert.True(t, exist)
+
+	var result []*modelV1.Folder
+	assert.NoError(t, d.Query(&folder.Query{Project: "perses"}, &result))
+	assert.Len(t, result, 1)
+}
+
+func TestMigrateProjectResources(t *testing.T) {
+	d := NewInMemoryDAO()
+	assert.NoError(t, d.Create(&modelV1.Project{
+		Kind:     modelV1.KindProject,
+		Metadata: *modelV1.NewOrganizationMetadata("acme", "perses"),
+	}))
+	// the project created before the organizations exist is stored at the root of the folder
+	assert.NoError(t, d.Create(&modelV1.Project{
+		Kind:     modelV1.KindProject,
+		Metadata: *modelV1.NewOrganizationMetadata("", "legacy"),
+	}))
+	assert.NoError(t, d.getStorage().writeFile("/folders/perses/demo.json", []byte(`{"kind":"Folder","metadata":{"name":"demo","project":"perses"},"spec":[{"kind":"Dashboard","name":"home"}]}`)))
+	assert.NoError(t, d.getStorage().writeFile("/folders/legacy/demo.json", []byte(`{"kind":"Folder","metadata":{"name":"demo","project":"legacy"},"spec":[{"kind":"Dashboard","name":"home"}]}`)))
+	// the organizations are searched again, like after a restart
+	d.forgetOrganizations()
+
+	assert.NoError(t, d.Init())
+	files, err := d.getStorage().walk("/folders")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/folders/acme/perses/demo.json", "/folders/default/legacy/demo.json"}, files)
+	assert.NoError(t, d.Get(modelV1.KindFolder, modelV1.NewProjectMetadata("perses", "demo"), &modelV1.Folder{}))
+	// the migration can run again
+	assert.NoError(t, d.Init())
+}
+
+func TestDAO_CreateProjectWithNameUsedInAnotherOrganization(t *testing.T) {
+	d := NewInMemoryDAO()
+	assert.NoError(t, d.Create(&modelV1.Project{
+		Kind:     modelV1.KindProject,
+		Metadata: *modelV1.NewOrganizationMetadata("acme", "perses"),
+	}))
+	assert.True(t, databaseModel.IsKeyConflict(d.Create(&modelV1.Project{
+		Kind:     modelV1.KindProject,
+		Metadata: *modelV1.NewOrganizationMetadata(modelV1.DefaultOrganization, "perses"),
+	})))
+	assert.NoError(t, d.Delete(modelV1.KindProject, modelV1.NewOrganizationMetadata("acme", "perses")))
+	assert.NoError(t, d.Create(&modelV1.Project{
+		Kind:     modelV1.KindProject,
+		Metadata: *modelV1.NewOrganizationMetadata(modelV1.DefaultOrganization, "perses"),
+	}))
+}
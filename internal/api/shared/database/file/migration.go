@@ -0,0 +1,91 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databaseFile
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/sirupsen/logrus"
+)
+
+// projectResourceKinds are the kinds stored in the folder of their project.
+var projectResourceKinds = []modelV1.Kind{
+	modelV1.KindDashboard,
+	modelV1.KindDashboardAccess,
+	modelV1.KindDashboardAlias,
+	modelV1.KindDashboardDraft,
+	modelV1.KindDashboardUsage,
+	modelV1.KindDatasource,
+	modelV1.KindEphemeralDashboard,
+	modelV1.KindFolder,
+	modelV1.KindGlobalDatasourceOverride,
+	modelV1.KindHomeConfig,
+	modelV1.KindPlaylist,
+	modelV1.KindSavedView,
+	modelV1.KindShareToken,
+	modelV1.KindVariable,
+}
+
+// migrate moves the resources stored before the organizations exist, in <kind>/<project>/<name>, into the folder of the
+// organization of their project: <kind>/<organization>/<project>/<name>.
+// The files already moved are one level deeper, so the migration can run at every start.
+func (d *DAO) migrate() error {
+	for _, kind := range projectResourceKinds {
+		kindFolder := filepath.Clean(path.Join(d.Folder, modelV1.PluralKindMap[kind]))
+		isExist, err := d.isFolderExist(kindFolder)
+		if err != nil {
+			return err
+		}
+		if !isExist {
+			continue
+		}
+		files, err := d.visit(kindFolder, "")
+		if err != nil {
+			return err
+		}
+		for _, file := range files {
+			relativePath, relErr := filepath.Rel(kindFolder, file)
+			if relErr != nil {
+				return relErr
+			}
+			parts := strings.Split(filepath.ToSlash(relativePath), "/")
+			if len(parts) != 2 {
+				continue
+			}
+			if moveErr := d.moveToOrganization(kindFolder, parts[0], file, relativePath); moveErr != nil {
+				return moveErr
+			}
+		}
+	}
+	return nil
+}
+
+func (d *DAO) moveToOrganization(kindFolder string, project string, file string, relativePath string) error {
+	organization, err := d.getOrganization(project)
+	if err != nil {
+		return err
+	}
+	data, err := d.getStorage().readFile(file)
+	if err != nil {
+		return err
+	}
+	if writeErr := d.getStorage().writeFile(filepath.Join(kindFolder, organization, relativePath), data); writeErr != nil {
+		return writeErr
+	}
+	logrus.Debugf("file %q moved to the folder of the organization %q", file, organization)
+	return d.getStorage().remove(file)
+}
@@ -15,71 +15,146 @@ package databaseFile
 
 import (
 	"fmt"
-	"os"
 	"path"
 
+	"github.com/perses/perses/internal/api/interface/v1/access"
 	"github.com/perses/perses/internal/api/interface/v1/dashboard"
+	"github.com/perses/perses/internal/api/interface/v1/dashboardalias"
 	"github.com/perses/perses/internal/api/interface/v1/datasource"
+	"github.com/perses/perses/internal/api/interface/v1/draft"
+	"github.com/perses/perses/internal/api/interface/v1/ephemeraldashboard"
 	"github.com/perses/perses/internal/api/interface/v1/folder"
+	"github.com/perses/perses/internal/api/interface/v1/globaldashboard"
 	"github.com/perses/perses/internal/api/interface/v1/globaldatasource"
+	"github.com/perses/perses/internal/api/interface/v1/globaldatasourceoverride"
+	"github.com/perses/perses/internal/api/interface/v1/globalhomeconfig"
 	"github.com/perses/perses/internal/api/interface/v1/globalvariable"
+	"github.com/perses/perses/internal/api/interface/v1/homeconfig"
+	"github.com/perses/perses/internal/api/interface/v1/organization"
+	"github.com/perses/perses/internal/api/interface/v1/organizationdatasource"
+	"github.com/perses/perses/internal/api/interface/v1/organizationvariable"
+	"github.com/perses/perses/internal/api/interface/v1/playlist"
 	"github.com/perses/perses/internal/api/interface/v1/project"
+	"github.com/perses/perses/internal/api/interface/v1/savedview"
+	"github.com/perses/perses/internal/api/interface/v1/sharetoken"
+	"github.com/perses/perses/internal/api/interface/v1/team"
+	"github.com/perses/perses/internal/api/interface/v1/usage"
 	"github.com/perses/perses/internal/api/interface/v1/variable"
 	databaseModel "github.com/perses/perses/internal/api/shared/database/model"
 	v1 "github.com/perses/perses/pkg/model/api/v1"
 	"github.com/sirupsen/logrus"
 )
 
-func isFolderExist(folder string) (bool, error) {
-	_, err := os.Stat(folder)
-	if os.IsNotExist(err) {
-		return false, nil
+func (d *DAO) generateProjectResourceQuery(kind v1.Kind, project string) (string, error) {
+	if len(project) == 0 {
+		// it's used when we query a list of object. It can happen that the project is empty.
+		return path.Join(d.Folder, v1.PluralKindMap[kind]), nil
 	}
+	organization, err := d.getOrganization(project)
 	if err != nil {
-		logrus.WithError(err).Errorf("unexpected error while trying to access to the folder %q", folder)
-		return false, err
+		return "", err
 	}
-	return true, nil
+	return path.Join(d.Folder, v1.PluralKindMap[kind], organization, project), nil
 }
 
-func (d *DAO) generateProjectResourceQuery(kind v1.Kind, project string) string {
-	if len(project) == 0 {
-		// it's used when we query a list of object. It can happen that the project is empty.
+func (d *DAO) generateOrganizationResourceQuery(kind v1.Kind, organization string) string {
+	if len(organization) == 0 {
+		// it's used when we query a list of object across all the organizations.
 		return path.Join(d.Folder, v1.PluralKindMap[kind])
 	}
-	return path.Join(d.Folder, v1.PluralKindMap[kind], project)
+	return path.Join(d.Folder, v1.PluralKindMap[kind], organization)
 }
 
 func (d *DAO) generateResourceQuery(kind v1.Kind) string {
 	return path.Join(d.Folder, v1.PluralKindMap[kind])
 }
 
+func (d *DAO) isFolderExist(folder string) (bool, error) {
+	isExist, err := d.getStorage().exists(folder)
+	if err != nil {
+		logrus.WithError(err).Errorf("unexpected error while trying to access to the folder %q", folder)
+		return false, err
+	}
+	return isExist, nil
+}
+
 func (d *DAO) buildQuery(query databaseModel.Query) (pathFolder string, prefix string, isExist bool, err error) {
 	switch qt := query.(type) {
+	case *access.Query:
+		pathFolder, err = d.generateProjectResourceQuery(v1.KindDashboardAccess, qt.Project)
+		prefix = qt.NamePrefix
+	case *dashboardalias.Query:
+		pathFolder, err = d.generateProjectResourceQuery(v1.KindDashboardAlias, qt.Project)
+		prefix = qt.NamePrefix
 	case *dashboard.Query:
-		pathFolder = d.generateProjectResourceQuery(v1.KindDashboard, qt.Project)
+		pathFolder, err = d.generateProjectResourceQuery(v1.KindDashboard, qt.Project)
 		prefix = qt.NamePrefix
 	case *datasource.Query:
-		pathFolder = d.generateProjectResourceQuery(v1.KindDatasource, qt.Project)
+		pathFolder, err = d.generateProjectResourceQuery(v1.KindDatasource, qt.Project)
+		prefix = qt.NamePrefix
+	case *draft.Query:
+		pathFolder, err = d.generateProjectResourceQuery(v1.KindDashboardDraft, qt.Project)
+		prefix = qt.NamePrefix
+	case *ephemeraldashboard.Query:
+		pathFolder, err = d.generateProjectResourceQuery(v1.KindEphemeralDashboard, qt.Project)
 		prefix = qt.NamePrefix
 	case *folder.Query:
-		pathFolder = d.generateProjectResourceQuery(v1.KindFolder, qt.Project)
+		pathFolder, err = d.generateProjectResourceQuery(v1.KindFolder, qt.Project)
+		prefix = qt.NamePrefix
+	case *globaldashboard.Query:
+		pathFolder = d.generateResourceQuery(v1.KindGlobalDashboard)
 		prefix = qt.NamePrefix
 	case *globaldatasource.Query:
 		pathFolder = d.generateResourceQuery(v1.KindGlobalDatasource)
 		prefix = qt.NamePrefix
+	case *globaldatasourceoverride.Query:
+		pathFolder, err = d.generateProjectResourceQuery(v1.KindGlobalDatasourceOverride, qt.Project)
+		prefix = qt.NamePrefix
+	case *globalhomeconfig.Query:
+		pathFolder = d.generateResourceQuery(v1.KindGlobalHomeConfig)
+		prefix = qt.NamePrefix
 	case *globalvariable.Query:
 		pathFolder = d.generateResourceQuery(v1.KindGlobalVariable)
 		prefix = qt.NamePrefix
+	case *homeconfig.Query:
+		pathFolder, err = d.generateProjectResourceQuery(v1.KindHomeConfig, qt.Project)
+		prefix = qt.NamePrefix
+	case *organization.Query:
+		pathFolder = d.generateResourceQuery(v1.KindOrganization)
+		prefix = qt.NamePrefix
+	case *organizationdatasource.Query:
+		pathFolder = d.generateOrganizationResourceQuery(v1.KindOrganizationDatasource, qt.Organization)
+		prefix = qt.NamePrefix
+	case *organizationvariable.Query:
+		pathFolder = d.generateOrganizationResourceQuery(v1.KindOrganizationVariable, qt.Organization)
+		prefix = qt.NamePrefix
+	case *playlist.Query:
+		pathFolder, err = d.generateProjectResourceQuery(v1.KindPlaylist, qt.Project)
+		prefix = qt.NamePrefix
 	case *project.Query:
-		pathFolder = d.generateResourceQuery(v1.KindProject)
+		pathFolder = d.generateOrganizationResourceQuery(v1.KindProject, qt.Organization)
+		prefix = qt.NamePrefix
+	case *savedview.Query:
+		pathFolder, err = d.generateProjectResourceQuery(v1.KindSavedView, qt.Project)
+		prefix = qt.NamePrefix
+	case *sharetoken.Query:
+		pathFolder, err = d.generateProjectResourceQuery(v1.KindShareToken, qt.Project)
+		prefix = qt.NamePrefix
+	case *team.Query:
+		pathFolder = d.generateResourceQuery(v1.KindTeam)
+		prefix = qt.NamePrefix
+	case *usage.Query:
+		pathFolder, err = d.generateProjectResourceQuery(v1.KindDashboardUsage, qt.Project)
 		prefix = qt.NamePrefix
 	case *variable.Query:
-		pathFolder = d.generateProjectResourceQuery(v1.KindVariable, qt.Project)
+		pathFolder, err = d.generateProjectResourceQuery(v1.KindVariable, qt.Project)
 		prefix = qt.NamePrefix
 	default:
 		return "", "", false, fmt.Errorf("this type of query '%T' is not managed", qt)
 	}
-	isExist, err = isFolderExist(pathFolder)
+	if err != nil {
+		return "", "", false, err
+	}
+	isExist, err = d.isFolderExist(pathFolder)
 	return
 }
@@ -20,6 +20,8 @@ import (
 	"github.com/perses/perses/internal/api/interface/v1/datasource"
 	"github.com/perses/perses/internal/api/interface/v1/folder"
 	"github.com/perses/perses/internal/api/interface/v1/globaldatasource"
+	"github.com/perses/perses/internal/api/interface/v1/organization"
+	"github.com/perses/perses/internal/api/interface/v1/organizationdatasource"
 	"github.com/perses/perses/internal/api/interface/v1/project"
 	databaseModel "github.com/perses/perses/internal/api/shared/database/model"
 	"github.com/stretchr/testify/assert"
@@ -38,7 +40,7 @@ func TestBuildQuery(t *testing.T) {
 				NamePrefix: "meta",
 				Project:    "perses",
 			},
-			expectedPath:       "dashboards/perses",
+			expectedPath:       "dashboards/default/perses",
 			expectedNamePrefix: "meta",
 		},
 		{
@@ -55,7 +57,7 @@ func TestBuildQuery(t *testing.T) {
 				NamePrefix: "meta",
 				Project:    "perses",
 			},
-			expectedPath:       "datasources/perses",
+			expectedPath:       "datasources/default/perses",
 			expectedNamePrefix: "meta",
 		},
 		{
@@ -64,7 +66,7 @@ func TestBuildQuery(t *testing.T) {
 				NamePrefix: "meta",
 				Project:    "perses",
 			},
-			expectedPath:       "folders/perses",
+			expectedPath:       "folders/default/perses",
 			expectedNamePrefix: "meta",
 		},
 		{
@@ -83,6 +85,32 @@ func TestBuildQuery(t *testing.T) {
 			expectedPath:       "projects",
 			expectedNamePrefix: "meta",
 		},
+		{
+			title: "projectQuery with organization",
+			query: &project.Query{
+				NamePrefix:   "meta",
+				Organization: "default",
+			},
+			expectedPath:       "projects/default",
+			expectedNamePrefix: "meta",
+		},
+		{
+			title: "organizationQuery",
+			query: &organization.Query{
+				NamePrefix: "meta",
+			},
+			expectedPath:       "organizations",
+			expectedNamePrefix: "meta",
+		},
+		{
+			title: "organizationDatasourceQuery",
+			query: &organizationdatasource.Query{
+				NamePrefix:   "meta",
+				Organization: "default",
+			},
+			expectedPath:       "organizationdatasources/default",
+			expectedNamePrefix: "meta",
+		},
 	}
 
 	for _, test := range testSuite {
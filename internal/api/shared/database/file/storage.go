@@ -0,0 +1,167 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databaseFile
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// storage is where the files of the database are kept: the disk, or the memory for the tests.
+type storage interface {
+	// exists returns true when the path is a file or a folder.
+	exists(path string) (bool, error)
+	// readFile returns an error matching fs.ErrNotExist when the file doesn't exist.
+	readFile(path string) ([]byte, error)
+	// writeFile creates the parent folders when needed.
+	writeFile(path string, data []byte) error
+	// remove returns an error matching fs.ErrNotExist when the file doesn't exist.
+	remove(path string) error
+	removeAll(path string) error
+	// walk returns the files contained in the folder and in its sub-folders, sorted.
+	walk(root string) ([]string, error)
+}
+
+type diskStorage struct{}
+
+func (d *diskStorage) exists(path string) (bool, error) {
+	_, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (d *diskStorage) readFile(path string) ([]byte, error) {
+	return os.ReadFile(path)
+}
+
+func (d *diskStorage) writeFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+func (d *diskStorage) remove(path string) error {
+	return os.Remove(path)
+}
+
+func (d *diskStorage) removeAll(path string) error {
+	return os.RemoveAll(path)
+}
+
+func (d *diskStorage) walk(root string) ([]string, error) {
+	var result []string
+	err := filepath.Walk(root, func(path string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			result = append(result, path)
+		}
+		return nil
+	})
+	return result, err
+}
+
+// memoryStorage keeps the files in a map. The folders only exist through the files they contain.
+type memoryStorage struct {
+	mutex sync.RWMutex
+	files map[string][]byte
+}
+
+func newMemoryStorage() *memoryStorage {
+	return &memoryStorage{files: make(map[string][]byte)}
+}
+
+func (m *memoryStorage) exists(path string) (bool, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	path = filepath.Clean(path)
+	if _, ok := m.files[path]; ok {
+		return true, nil
+	}
+	for file := range m.files {
+		if isInFolder(file, path) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *memoryStorage) readFile(path string) ([]byte, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	data, ok := m.files[filepath.Clean(path)]
+	if !ok {
+		return nil, &fs.PathError{Op: "open", Path: path, Err: fs.ErrNotExist}
+	}
+	return append([]byte(nil), data...), nil
+}
+
+func (m *memoryStorage) writeFile(path string, data []byte) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.files[filepath.Clean(path)] = append([]byte(nil), data...)
+	return nil
+}
+
+func (m *memoryStorage) remove(path string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	path = filepath.Clean(path)
+	if _, ok := m.files[path]; !ok {
+		return &fs.PathError{Op: "remove", Path: path, Err: fs.ErrNotExist}
+	}
+	delete(m.files, path)
+	return nil
+}
+
+func (m *memoryStorage) removeAll(path string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	path = filepath.Clean(path)
+	for file := range m.files {
+		if file == path || isInFolder(file, path) {
+			delete(m.files, file)
+		}
+	}
+	return nil
+}
+
+func (m *memoryStorage) walk(root string) ([]string, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	root = filepath.Clean(root)
+	var result []string
+	for file := range m.files {
+		if file == root || isInFolder(file, root) {
+			result = append(result, file)
+		}
+	}
+	if len(result) == 0 {
+		return nil, &fs.PathError{Op: "lstat", Path: root, Err: fs.ErrNotExist}
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+func isInFolder(path string, folder string) bool {
+	return strings.HasPrefix(path, strings.TrimSuffix(folder, string(filepath.Separator))+string(filepath.Separator))
+}
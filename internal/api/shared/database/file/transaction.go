@@ -0,0 +1,175 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databaseFile
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+
+	"github.com/perses/perses/internal/api/interface/v1/project"
+	databaseModel "github.com/perses/perses/internal/api/shared/database/model"
+	modelAPI "github.com/perses/perses/pkg/model/api"
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+type fileChange struct {
+	path string
+	// previous is the content of the file before the change. It is nil when the file didn't exist.
+	previous []byte
+	// current is the content of the file after the change. It is nil when the file has been removed.
+	current []byte
+}
+
+// transaction writes in the database while the other writes are blocked, and records the content of the files
+// before and after each change, so they can be restored.
+type transaction struct {
+	databaseModel.DAO
+	dao     *DAO
+	changes []fileChange
+}
+
+// Transaction calls apply while the other writes are blocked. When apply fails, the files it changed are restored,
+// except the ones modified in the meantime, for example by another instance of Perses sharing the folder.
+func (d *DAO) Transaction(apply func(dao databaseModel.DAO) error) error {
+	d.writeMutex.Lock()
+	defer d.writeMutex.Unlock()
+	tx := &transaction{DAO: d, dao: d}
+	if err := apply(tx); err != nil {
+		if rollbackErr := tx.rollback(); rollbackErr != nil {
+			return &databaseModel.RollbackError{Err: err, RollbackErr: rollbackErr}
+		}
+		return err
+	}
+	return nil
+}
+
+func (t *transaction) Create(entity modelAPI.Entity) error {
+	return t.record(modelV1.Kind(entity.GetKind()), entity.GetMetadata(), func() error {
+		return t.dao.create(entity)
+	})
+}
+
+func (t *transaction) Upsert(entity modelAPI.Entity) error {
+	return t.record(modelV1.Kind(entity.GetKind()), entity.GetMetadata(), func() error {
+		return t.dao.upsertEntity(entity)
+	})
+}
+
+func (t *transaction) Delete(kind modelV1.Kind, metadata modelAPI.Metadata) error {
+	return t.record(kind, metadata, func() error {
+		return t.dao.remove(kind, metadata)
+	})
+}
+
+func (t *transaction) DeleteByQuery(query databaseModel.Query) error {
+	if _, ok := query.(*project.Query); ok {
+		t.dao.forgetOrganizations()
+	}
+	folder, prefix, isExist, err := t.dao.buildQuery(query)
+	if err != nil {
+		return fmt.Errorf("unable to build the query: %s", err)
+	}
+	if !isExist {
+		return nil
+	}
+	files, err := t.dao.visit(folder, prefix)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		previous, readErr := t.read(file)
+		if readErr != nil {
+			return readErr
+		}
+		if removeErr := t.dao.getStorage().remove(file); removeErr != nil {
+			return removeErr
+		}
+		t.changes = append(t.changes, fileChange{path: file, previous: previous})
+	}
+	return nil
+}
+
+func (t *transaction) Transaction(_ func(dao databaseModel.DAO) error) error {
+	return fmt.Errorf("nested transactions are not supported")
+}
+
+// record calls write and keeps the content of the file of the resource before and after it.
+func (t *transaction) record(kind modelV1.Kind, metadata modelAPI.Metadata, write func() error) error {
+	key, err := t.dao.generateID(kind, metadata)
+	if err != nil {
+		return err
+	}
+	path := t.dao.buildPath(key)
+	previous, err := t.read(path)
+	if err != nil {
+		return err
+	}
+	if writeErr := write(); writeErr != nil {
+		return writeErr
+	}
+	current, err := t.read(path)
+	if err != nil {
+		return err
+	}
+	t.changes = append(t.changes, fileChange{path: path, previous: previous, current: current})
+	return nil
+}
+
+// rollback restores the files, starting from the last change. A file is only restored when it still contains what
+// the transaction wrote, so a change done in the meantime is never overwritten.
+func (t *transaction) rollback() error {
+	var failures []string
+	for i := len(t.changes) - 1; i >= 0; i-- {
+		c := t.changes[i]
+		stored, err := t.read(c.path)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("unable to read %q: %s", c.path, err))
+			continue
+		}
+		if !bytes.Equal(stored, c.current) {
+			failures = append(failures, fmt.Sprintf("%q has been modified in the meantime", c.path))
+			continue
+		}
+		if c.previous == nil {
+			err = t.dao.getStorage().remove(c.path)
+		} else {
+			err = t.dao.getStorage().writeFile(c.path, c.previous)
+		}
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("unable to restore %q: %s", c.path, err))
+		}
+	}
+	t.changes = nil
+	// the projects may have been restored or removed
+	t.dao.forgetOrganizations()
+	if len(failures) > 0 {
+		return errors.New(strings.Join(failures, ", "))
+	}
+	return nil
+}
+
+// read returns the content of the file, or nil when it doesn't exist.
+func (t *transaction) read(path string) ([]byte, error) {
+	data, err := t.dao.getStorage().readFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return data, nil
+}
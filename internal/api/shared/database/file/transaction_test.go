@@ -0,0 +1,92 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databaseFile
+
+import (
+	"fmt"
+	"testing"
+
+	databaseModel "github.com/perses/perses/internal/api/shared/database/model"
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func newFolder(name string, dashboard string) *modelV1.Folder {
+	return &modelV1.Folder{
+		Kind:     modelV1.KindFolder,
+		Metadata: *modelV1.NewProjectMetadata("perses", name),
+		Spec:     []modelV1.FolderSpec{{Kind: modelV1.KindDashboard, Name: dashboard}},
+	}
+}
+
+func TestTransactionCommit(t *testing.T) {
+	d := NewInMemoryDAO()
+	assert.NoError(t, d.Create(newFolder("old", "home")))
+	err := d.Transaction(func(dao databaseModel.DAO) error {
+		if err := dao.Create(newFolder("new", "home")); err != nil {
+			return err
+		}
+		return dao.Delete(modelV1.KindFolder, modelV1.NewProjectMetadata("perses", "old"))
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, d.Get(modelV1.KindFolder, modelV1.NewProjectMetadata("perses", "new"), &modelV1.Folder{}))
+	assert.True(t, databaseModel.IsKeyNotFound(d.Get(modelV1.KindFolder, modelV1.NewProjectMetadata("perses", "old"), &modelV1.Folder{})))
+}
+
+func TestTransactionRollback(t *testing.T) {
+	d := NewInMemoryDAO()
+	assert.NoError(t, d.Create(newFolder("old", "home")))
+	assert.NoError(t, d.Create(newFolder("other", "home")))
+	failure := fmt.Errorf("failure")
+	err := d.Transaction(func(dao databaseModel.DAO) error {
+		if err := dao.Create(newFolder("new", "home")); err != nil {
+			return err
+		}
+		if err := dao.Delete(modelV1.KindFolder, modelV1.NewProjectMetadata("perses", "old")); err != nil {
+			return err
+		}
+		if err := dao.Upsert(newFolder("other", "overview")); err != nil {
+			return err
+		}
+		return failure
+	})
+	assert.Equal(t, failure, err)
+	assert.True(t, databaseModel.IsKeyNotFound(d.Get(modelV1.KindFolder, modelV1.NewProjectMetadata("perses", "new"), &modelV1.Folder{})))
+	assert.NoError(t, d.Get(modelV1.KindFolder, modelV1.NewProjectMetadata("perses", "old"), &modelV1.Folder{}))
+	result := &modelV1.Folder{}
+	assert.NoError(t, d.Get(modelV1.KindFolder, modelV1.NewProjectMetadata("perses", "other"), result))
+	assert.Equal(t, "home", result.Spec[0].Name)
+}
+
+func TestTransactionRollbackKeepsConcurrentChanges(t *testing.T) {
+	d := NewInMemoryDAO()
+	assert.NoError(t, d.Create(newFolder("demo", "home")))
+	err := d.Transaction(func(dao databaseModel.DAO) error {
+		if err := dao.Upsert(newFolder("demo", "overview")); err != nil {
+			return err
+		}
+		// another instance sharing the folder modifies the file in the meantime
+		if err := d.upsertEntity(newFolder("demo", "concurrent")); err != nil {
+			return err
+		}
+		return fmt.Errorf("failure")
+	})
+	rollbackErr := &databaseModel.RollbackError{}
+	if assert.ErrorAs(t, err, &rollbackErr) {
+		assert.Contains(t, rollbackErr.RollbackErr.Error(), "has been modified in the meantime")
+	}
+	result := &modelV1.Folder{}
+	assert.NoError(t, d.Get(modelV1.KindFolder, modelV1.NewProjectMetadata("perses", "demo"), result))
+	assert.Equal(t, "concurrent", result.Spec[0].Name)
+}
@@ -23,6 +23,29 @@ import (
 type Query interface {
 }
 
+// TeamQuery is implemented by the queries able to filter the resources by their owning team.
+type TeamQuery interface {
+	GetTeam() string
+}
+
+type ownedMetadata interface {
+	IsOwnedBy(team string) bool
+}
+
+// MatchTeam returns false when the query filters the resources by team and the entity is not owned by this team.
+func MatchTeam(query Query, entity interface{}) bool {
+	teamQuery, ok := query.(TeamQuery)
+	if !ok || len(teamQuery.GetTeam()) == 0 {
+		return true
+	}
+	object, isEntity := entity.(modelAPI.Entity)
+	if !isEntity {
+		return false
+	}
+	metadata, isOwned := object.GetMetadata().(ownedMetadata)
+	return isOwned && metadata.IsOwnedBy(teamQuery.GetTeam())
+}
+
 type DAO interface {
 	io.Closer
 	Init() error
@@ -38,3 +61,10 @@ type DAO interface {
 	DeleteByQuery(query Query) error
 	HealthCheck() bool
 }
+
+// Transactional is implemented by the databases able to apply several changes atomically.
+type Transactional interface {
+	// Transaction calls apply with a database whose changes are only kept when apply succeeds. A *RollbackError is
+	// returned when apply fails and its changes cannot be reverted.
+	Transaction(apply func(dao DAO) error) error
+}
@@ -44,3 +44,20 @@ type Error struct {
 func (e *Error) Error() string {
 	return fmt.Sprintf("ErrorCode: %d, key: %s", e.Code, e.Key)
 }
+
+// RollbackError is returned when a transaction failed and its changes could not be reverted, so the database may be
+// left with a part of them.
+type RollbackError struct {
+	// Err is the error that made the transaction fail.
+	Err error
+	// RollbackErr is the error that occurred while reverting the changes.
+	RollbackErr error
+}
+
+func (e *RollbackError) Error() string {
+	return fmt.Sprintf("%s, and the changes could not be reverted: %s", e.Err, e.RollbackErr)
+}
+
+func (e *RollbackError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,180 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package databaseSQL
+
+import (
+	"fmt"
+
+	"github.com/huandu/go-sqlbuilder"
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	tableMigration   = "migration"
+	colAppliedAt     = "applied_at"
+	indexProjectName = "project_name"
+)
+
+// migration is a change of the schema of the tables. Each migration is only applied once, and it is recorded in the
+// table migration once applied.
+type migration struct {
+	// id identifies the migration in the table migration, so it must never change.
+	id    string
+	apply func(d *DAO) error
+}
+
+// migrations is the list of the migrations, in the order they are applied.
+// The migrations are applied after the tables are created, so they must cope with a table already having the new schema.
+var migrations = []migration{
+	{
+		// the project table created before the organizations exist doesn't have the organization column.
+		id:    "add-organization-to-project",
+		apply: (*DAO).addOrganizationToProject,
+	},
+	{
+		// the id of the resources of a project created before the organizations exist doesn't contain the organization.
+		id:    "add-organization-to-project-resources",
+		apply: (*DAO).addOrganizationToProjectResources,
+	},
+	{
+		// the name of a project is unique across all the organizations.
+		id:    "add-unique-project-name",
+		apply: (*DAO).addUniqueProjectName,
+	},
+}
+
+func (d *DAO) migrate() error {
+	createMigrationTable := sqlbuilder.CreateTable(d.generateCompleteTableName(tableMigration)).IfNotExists().
+		Define(colID, "VARCHAR(128)", "NOT NULL", "PRIMARY KEY").
+		Define(colAppliedAt, "TIMESTAMP", "NOT NULL", "DEFAULT CURRENT_TIMESTAMP").
+		String()
+	if _, err := d.DB.Exec(createMigrationTable); err != nil {
+		return err
+	}
+	for _, m := range migrations {
+		applied, err := d.isMigrationApplied(m.id)
+		if err != nil {
+			return err
+		}
+		if applied {
+			continue
+		}
+		logrus.Infof("applying the database migration %q", m.id)
+		if applyErr := m.apply(d); applyErr != nil {
+			return fmt.Errorf("unable to apply the database migration %q: %w", m.id, applyErr)
+		}
+		insert := sqlbuilder.NewInsertBuilder()
+		insert.InsertInto(d.generateCompleteTableName(tableMigration)).Cols(colID).Values(m.id)
+		query, args := insert.Build()
+		if _, insertErr := d.DB.Exec(query, args...); insertErr != nil {
+			return insertErr
+		}
+	}
+	return nil
+}
+
+func (d *DAO) isMigrationApplied(id string) (bool, error) {
+	queryBuilder := sqlbuilder.NewSelectBuilder()
+	queryBuilder.Select(colID).From(d.generateCompleteTableName(tableMigration)).Where(queryBuilder.Equal(colID, id))
+	query, args := queryBuilder.Build()
+	rows, err := d.DB.Query(query, args...)
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+	return rows.Next(), rows.Err()
+}
+
+// hasColumn looks in the information schema whether the column exists in the table.
+func (d *DAO) hasColumn(tableName string, columnName string) (bool, error) {
+	var count int
+	err := d.DB.QueryRow(
+		"SELECT COUNT(*) FROM information_schema.COLUMNS WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ? AND COLUMN_NAME = ?",
+		d.SchemaName, tableName, columnName,
+	).Scan(&count)
+	return count > 0, err
+}
+
+// hasIndex looks in the information schema whether the index exists on the table.
+func (d *DAO) hasIndex(tableName string, indexName string) (bool, error) {
+	var count int
+	err := d.DB.QueryRow(
+		"SELECT COUNT(*) FROM information_schema.STATISTICS WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ? AND INDEX_NAME = ?",
+		d.SchemaName, tableName, indexName,
+	).Scan(&count)
+	return count > 0, err
+}
+
+func (d *DAO) addOrganizationToProject() error {
+	exist, err := d.hasColumn(tableProject, colOrganization)
+	if err != nil || exist {
+		return err
+	}
+	tableName := d.generateCompleteTableName(tableProject)
+	if _, alterErr := d.DB.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s VARCHAR(128) NOT NULL DEFAULT ''", tableName, colOrganization)); alterErr != nil {
+		return alterErr
+	}
+	// the id of the projects becomes <organization>|<name>
+	_, alterErr := d.DB.Exec(fmt.Sprintf("ALTER TABLE %s MODIFY COLUMN %s VARCHAR(256) NOT NULL", tableName, colID))
+	return alterErr
+}
+
+func (d *DAO) addOrganizationToProjectResources() error {
+	projectTableName := d.generateCompleteTableName(tableProject)
+	for _, table := range projectResourceTables {
+		tableName := d.generateCompleteTableName(table)
+		if _, alterErr := d.DB.Exec(fmt.Sprintf("ALTER TABLE %s MODIFY COLUMN %s VARCHAR(512) NOT NULL", tableName, colID)); alterErr != nil {
+			return alterErr
+		}
+		// the id of the resources becomes <organization>|<project>|<name>.
+		// The organization of the projects not moved yet into the default organization is empty.
+		// The ids already containing the organization are skipped, so the migration can be applied again when it was
+		// interrupted before being recorded.
+		update := fmt.Sprintf(
+			"UPDATE %s r SET r.%s = CONCAT(COALESCE(NULLIF((SELECT MAX(p.%s) FROM %s p WHERE p.%s = r.%s), ''), ?), '|', r.%s) WHERE r.%s NOT LIKE CONCAT('%%|', r.%s, '|%%')",
+			tableName, colID, colOrganization, projectTableName, colName, colProject, colID, colID, colProject,
+		)
+		if _, updateErr := d.DB.Exec(update, modelV1.DefaultOrganization); updateErr != nil {
+			return updateErr
+		}
+	}
+	return nil
+}
+
+func (d *DAO) addUniqueProjectName() error {
+	exist, err := d.hasIndex(tableProject, indexProjectName)
+	if err != nil || exist {
+		return err
+	}
+	tableName := d.generateCompleteTableName(tableProject)
+	// The projects created before the organizations exist are moved into the default organization, so they don't
+	// conflict with the unique index. A project already copied into the default organization is only kept there.
+	deleteCopied := fmt.Sprintf(
+		"DELETE l FROM %s l JOIN %s p ON p.%s = l.%s AND p.%s <> '' WHERE l.%s = ''",
+		tableName, tableName, colName, colName, colOrganization, colOrganization,
+	)
+	if _, deleteErr := d.DB.Exec(deleteCopied); deleteErr != nil {
+		return deleteErr
+	}
+	moveLegacy := fmt.Sprintf(
+		"UPDATE %s SET %s = ?, %s = CONCAT(?, '|', %s), %s = JSON_SET(%s, '$.metadata.organization', ?) WHERE %s = ''",
+		tableName, colOrganization, colID, colName, colDoc, colDoc, colOrganization,
+	)
+	if _, updateErr := d.DB.Exec(moveLegacy, modelV1.DefaultOrganization, modelV1.DefaultOrganization, modelV1.DefaultOrganization); updateErr != nil {
+		return updateErr
+	}
+	_, createErr := d.DB.Exec(fmt.Sprintf("CREATE UNIQUE INDEX %s ON %s (%s)", indexProjectName, tableName, colName))
+	return createErr
+}
@@ -18,12 +18,28 @@ import (
 	"fmt"
 
 	"github.com/huandu/go-sqlbuilder"
+	"github.com/perses/perses/internal/api/interface/v1/access"
 	"github.com/perses/perses/internal/api/interface/v1/dashboard"
+	"github.com/perses/perses/internal/api/interface/v1/dashboardalias"
 	"github.com/perses/perses/internal/api/interface/v1/datasource"
+	"github.com/perses/perses/internal/api/interface/v1/draft"
+	"github.com/perses/perses/internal/api/interface/v1/ephemeraldashboard"
 	"github.com/perses/perses/internal/api/interface/v1/folder"
+	"github.com/perses/perses/internal/api/interface/v1/globaldashboard"
 	"github.com/perses/perses/internal/api/interface/v1/globaldatasource"
+	"github.com/perses/perses/internal/api/interface/v1/globaldatasourceoverride"
+	"github.com/perses/perses/internal/api/interface/v1/globalhomeconfig"
 	"github.com/perses/perses/internal/api/interface/v1/globalvariable"
+	"github.com/perses/perses/internal/api/interface/v1/homeconfig"
+	"github.com/perses/perses/internal/api/interface/v1/organization"
+	"github.com/perses/perses/internal/api/interface/v1/organizationdatasource"
+	"github.com/perses/perses/internal/api/interface/v1/organizationvariable"
+	"github.com/perses/perses/internal/api/interface/v1/playlist"
 	"github.com/perses/perses/internal/api/interface/v1/project"
+	"github.com/perses/perses/internal/api/interface/v1/savedview"
+	"github.com/perses/perses/internal/api/interface/v1/sharetoken"
+	"github.com/perses/perses/internal/api/interface/v1/team"
+	"github.com/perses/perses/internal/api/interface/v1/usage"
 	"github.com/perses/perses/internal/api/interface/v1/variable"
 	databaseModel "github.com/perses/perses/internal/api/shared/database/model"
 	modelAPI "github.com/perses/perses/pkg/model/api"
@@ -38,6 +54,14 @@ func generateProjectResourceInsertQuery(tableName string, id string, rowJSONDoc
 		Build()
 }
 
+func generateOrganizationResourceInsertQuery(tableName string, id string, rowJSONDoc []byte, metadata *modelV1.OrganizationMetadata) (string, []interface{}) {
+	return sqlbuilder.NewInsertBuilder().
+		InsertInto(tableName).
+		Cols(colID, colName, colOrganization, colDoc).
+		Values(id, metadata.Name, metadata.Organization, rowJSONDoc).
+		Build()
+}
+
 func generateResourceInsertQuery(tableName string, id string, rowJSONDoc []byte, metadata *modelV1.Metadata) (string, []interface{}) {
 	return sqlbuilder.NewInsertBuilder().
 		InsertInto(tableName).
@@ -60,6 +84,8 @@ func (d *DAO) generateInsertQuery(entity modelAPI.Entity) (string, []interface{}
 	switch m := entity.GetMetadata().(type) {
 	case *modelV1.ProjectMetadata:
 		sql, args = generateProjectResourceInsertQuery(tableName, id, rowJSONDoc, m)
+	case *modelV1.OrganizationMetadata:
+		sql, args = generateOrganizationResourceInsertQuery(tableName, id, rowJSONDoc, m)
 	case *modelV1.Metadata:
 		sql, args = generateResourceInsertQuery(tableName, id, rowJSONDoc, m)
 	}
@@ -83,14 +109,23 @@ func (d *DAO) generateUpdateQuery(entity modelAPI.Entity) (string, []interface{}
 }
 
 func generatSelectQuery(tableName string, project string, name string) (string, []interface{}) {
+	return generateScopedSelectQuery(tableName, colProject, project, name)
+}
+
+func generateOrganizationSelectQuery(tableName string, organization string, name string) (string, []interface{}) {
+	return generateScopedSelectQuery(tableName, colOrganization, organization, name)
+}
+
+// generateScopedSelectQuery selects the resources by name prefix, filtered by the column scoping them (project or organization) when it's set.
+func generateScopedSelectQuery(tableName string, scopeCol string, scope string, name string) (string, []interface{}) {
 	queryBuilder := sqlbuilder.NewSelectBuilder().
 		Select(colDoc).
 		From(tableName)
 	if len(name) > 0 {
 		queryBuilder.Where(queryBuilder.Like(colName, fmt.Sprintf("%s%%", name)))
 	}
-	if len(project) > 0 {
-		queryBuilder.Where(queryBuilder.Equal(colProject, project))
+	if len(scope) > 0 {
+		queryBuilder.Where(queryBuilder.Equal(scopeCol, scope))
 	}
 	return queryBuilder.Build()
 }
@@ -99,18 +134,50 @@ func (d *DAO) buildQuery(query databaseModel.Query) (string, []interface{}, erro
 	var sqlQuery string
 	var args []interface{}
 	switch qt := query.(type) {
+	case *access.Query:
+		sqlQuery, args = generatSelectQuery(d.generateCompleteTableName(tableDashboardAccess), qt.Project, qt.NamePrefix)
+	case *dashboardalias.Query:
+		sqlQuery, args = generatSelectQuery(d.generateCompleteTableName(tableDashboardAlias), qt.Project, qt.NamePrefix)
 	case *dashboard.Query:
 		sqlQuery, args = generatSelectQuery(d.generateCompleteTableName(tableDashboard), qt.Project, qt.NamePrefix)
 	case *datasource.Query:
 		sqlQuery, args = generatSelectQuery(d.generateCompleteTableName(tableDatasource), qt.Project, qt.NamePrefix)
+	case *draft.Query:
+		sqlQuery, args = generatSelectQuery(d.generateCompleteTableName(tableDashboardDraft), qt.Project, qt.NamePrefix)
+	case *ephemeraldashboard.Query:
+		sqlQuery, args = generatSelectQuery(d.generateCompleteTableName(tableEphemeralDashboard), qt.Project, qt.NamePrefix)
 	case *folder.Query:
 		sqlQuery, args = generatSelectQuery(d.generateCompleteTableName(tableFolder), qt.Project, qt.NamePrefix)
+	case *globaldashboard.Query:
+		sqlQuery, args = generatSelectQuery(d.generateCompleteTableName(tableGlobalDashboard), "", qt.NamePrefix)
 	case *globaldatasource.Query:
 		sqlQuery, args = generatSelectQuery(d.generateCompleteTableName(tableGlobalDatasource), "", qt.NamePrefix)
+	case *globaldatasourceoverride.Query:
+		sqlQuery, args = generatSelectQuery(d.generateCompleteTableName(tableGlobalDatasourceOverride), qt.Project, qt.NamePrefix)
+	case *globalhomeconfig.Query:
+		sqlQuery, args = generatSelectQuery(d.generateCompleteTableName(tableGlobalHomeConfig), "", qt.NamePrefix)
 	case *globalvariable.Query:
 		sqlQuery, args = generatSelectQuery(d.generateCompleteTableName(tableGlobalVariable), "", qt.NamePrefix)
+	case *homeconfig.Query:
+		sqlQuery, args = generatSelectQuery(d.generateCompleteTableName(tableHomeConfig), qt.Project, qt.NamePrefix)
+	case *playlist.Query:
+		sqlQuery, args = generatSelectQuery(d.generateCompleteTableName(tablePlaylist), qt.Project, qt.NamePrefix)
+	case *organization.Query:
+		sqlQuery, args = generatSelectQuery(d.generateCompleteTableName(tableOrganization), "", qt.NamePrefix)
+	case *organizationdatasource.Query:
+		sqlQuery, args = generateOrganizationSelectQuery(d.generateCompleteTableName(tableOrganizationDatasource), qt.Organization, qt.NamePrefix)
+	case *organizationvariable.Query:
+		sqlQuery, args = generateOrganizationSelectQuery(d.generateCompleteTableName(tableOrganizationVariable), qt.Organization, qt.NamePrefix)
 	case *project.Query:
-		sqlQuery, args = generatSelectQuery(d.generateCompleteTableName(tableProject), "", qt.NamePrefix)
+		sqlQuery, args = generateOrganizationSelectQuery(d.generateCompleteTableName(tableProject), qt.Organization, qt.NamePrefix)
+	case *savedview.Query:
+		sqlQuery, args = generatSelectQuery(d.generateCompleteTableName(tableSavedView), qt.Project, qt.NamePrefix)
+	case *sharetoken.Query:
+		sqlQuery, args = generatSelectQuery(d.generateCompleteTableName(tableShareToken), qt.Project, qt.NamePrefix)
+	case *team.Query:
+		sqlQuery, args = generatSelectQuery(d.generateCompleteTableName(tableTeam), "", qt.NamePrefix)
+	case *usage.Query:
+		sqlQuery, args = generatSelectQuery(d.generateCompleteTableName(tableDashboardUsage), qt.Project, qt.NamePrefix)
 	case *variable.Query:
 		sqlQuery, args = generatSelectQuery(d.generateCompleteTableName(tableVariable), qt.Project, qt.NamePrefix)
 	default:
@@ -120,13 +187,21 @@ func (d *DAO) buildQuery(query databaseModel.Query) (string, []interface{}, erro
 }
 
 func generateDeleteQuery(tableName string, project string, name string) (string, []interface{}) {
+	return generateScopedDeleteQuery(tableName, colProject, project, name)
+}
+
+func generateOrganizationDeleteQuery(tableName string, organization string, name string) (string, []interface{}) {
+	return generateScopedDeleteQuery(tableName, colOrganization, organization, name)
+}
+
+func generateScopedDeleteQuery(tableName string, scopeCol string, scope string, name string) (string, []interface{}) {
 	queryBuilder := sqlbuilder.NewDeleteBuilder().
 		DeleteFrom(tableName)
 	if len(name) > 0 {
 		queryBuilder.Where(queryBuilder.Like(colName, fmt.Sprintf("%s%%", name)))
 	}
-	if len(project) > 0 {
-		queryBuilder.Where(queryBuilder.Equal(colProject, project))
+	if len(scope) > 0 {
+		queryBuilder.Where(queryBuilder.Equal(scopeCol, scope))
 	}
 	return queryBuilder.Build()
 }
@@ -135,18 +210,50 @@ func (d *DAO) buildDeleteQuery(query databaseModel.Query) (string, []interface{}
 	var sqlQuery string
 	var args []interface{}
 	switch qt := query.(type) {
+	case *access.Query:
+		sqlQuery, args = generateDeleteQuery(d.generateCompleteTableName(tableDashboardAccess), qt.Project, qt.NamePrefix)
+	case *dashboardalias.Query:
+		sqlQuery, args = generateDeleteQuery(d.generateCompleteTableName(tableDashboardAlias), qt.Project, qt.NamePrefix)
 	case *dashboard.Query:
 		sqlQuery, args = generateDeleteQuery(d.generateCompleteTableName(tableDashboard), qt.Project, qt.NamePrefix)
 	case *datasource.Query:
 		sqlQuery, args = generateDeleteQuery(d.generateCompleteTableName(tableDatasource), qt.Project, qt.NamePrefix)
+	case *draft.Query:
+		sqlQuery, args = generateDeleteQuery(d.generateCompleteTableName(tableDashboardDraft), qt.Project, qt.NamePrefix)
+	case *ephemeraldashboard.Query:
+		sqlQuery, args = generateDeleteQuery(d.generateCompleteTableName(tableEphemeralDashboard), qt.Project, qt.NamePrefix)
 	case *folder.Query:
 		sqlQuery, args = generateDeleteQuery(d.generateCompleteTableName(tableFolder), qt.Project, qt.NamePrefix)
+	case *globaldashboard.Query:
+		sqlQuery, args = generateDeleteQuery(d.generateCompleteTableName(tableGlobalDashboard), "", qt.NamePrefix)
 	case *globaldatasource.Query:
 		sqlQuery, args = generateDeleteQuery(d.generateCompleteTableName(tableGlobalDatasource), "", qt.NamePrefix)
+	case *globaldatasourceoverride.Query:
+		sqlQuery, args = generateDeleteQuery(d.generateCompleteTableName(tableGlobalDatasourceOverride), qt.Project, qt.NamePrefix)
+	case *globalhomeconfig.Query:
+		sqlQuery, args = generateDeleteQuery(d.generateCompleteTableName(tableGlobalHomeConfig), "", qt.NamePrefix)
 	case *globalvariable.Query:
 		sqlQuery, args = generateDeleteQuery(d.generateCompleteTableName(tableGlobalVariable), "", qt.NamePrefix)
+	case *homeconfig.Query:
+		sqlQuery, args = generateDeleteQuery(d.generateCompleteTableName(tableHomeConfig), qt.Project, qt.NamePrefix)
+	case *playlist.Query:
+		sqlQuery, args = generateDeleteQuery(d.generateCompleteTableName(tablePlaylist), qt.Project, qt.NamePrefix)
+	case *organization.Query:
+		sqlQuery, args = generateDeleteQuery(d.generateCompleteTableName(tableOrganization), "", qt.NamePrefix)
+	case *organizationdatasource.Query:
+		sqlQuery, args = generateOrganizationDeleteQuery(d.generateCompleteTableName(tableOrganizationDatasource), qt.Organization, qt.NamePrefix)
+	case *organizationvariable.Query:
+		sqlQuery, args = generateOrganizationDeleteQuery(d.generateCompleteTableName(tableOrganizationVariable), qt.Organization, qt.NamePrefix)
 	case *project.Query:
-		sqlQuery, args = generateDeleteQuery(d.generateCompleteTableName(tableProject), "", qt.NamePrefix)
+		sqlQuery, args = generateOrganizationDeleteQuery(d.generateCompleteTableName(tableProject), qt.Organization, qt.NamePrefix)
+	case *savedview.Query:
+		sqlQuery, args = generateDeleteQuery(d.generateCompleteTableName(tableSavedView), qt.Project, qt.NamePrefix)
+	case *sharetoken.Query:
+		sqlQuery, args = generateDeleteQuery(d.generateCompleteTableName(tableShareToken), qt.Project, qt.NamePrefix)
+	case *team.Query:
+		sqlQuery, args = generateDeleteQuery(d.generateCompleteTableName(tableTeam), "", qt.NamePrefix)
+	case *usage.Query:
+		sqlQuery, args = generateDeleteQuery(d.generateCompleteTableName(tableDashboardUsage), qt.Project, qt.NamePrefix)
 	case *variable.Query:
 		sqlQuery, args = generateDeleteQuery(d.generateCompleteTableName(tableVariable), qt.Project, qt.NamePrefix)
 	default:
@@ -64,3 +64,9 @@ func TestGenerateProjectResourceSelectQuery(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerateOrganizationResourceSelectQuery(t *testing.T) {
+	sqlQuery, args := generateOrganizationSelectQuery("perses.project", "default", "foo")
+	assert.Equal(t, "SELECT doc FROM perses.project WHERE name LIKE ? AND organization = ?", sqlQuery)
+	assert.Equal(t, []interface{}{"foo%", "default"}, args)
+}
@@ -16,9 +16,11 @@ package databaseSQL
 import (
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"reflect"
 
+	"github.com/go-sql-driver/mysql"
 	"github.com/huandu/go-sqlbuilder"
 	databaseModel "github.com/perses/perses/internal/api/shared/database/model"
 	modelAPI "github.com/perses/perses/pkg/model/api"
@@ -27,34 +29,86 @@ import (
 )
 
 const (
-	tableGlobalDatasource = "globaldatasource"
-	tableGlobalVariable   = "globalvariable"
-	tableProject          = "project"
-	tableDashboard        = "dashboard"
-	tableFolder           = "folder"
-	tableDatasource       = "datasource"
-	tableVariable         = "variable"
-
-	colID      = "id"
-	colDoc     = "doc"
-	colName    = "name"
-	colProject = "project"
+	tableGlobalDatasource         = "globaldatasource"
+	tableGlobalDatasourceOverride = "globaldatasourceoverride"
+	tableGlobalDashboard          = "globaldashboard"
+	tableGlobalHomeConfig         = "globalhomeconfig"
+	tableGlobalVariable           = "globalvariable"
+	tableOrganization             = "organization"
+	tableProject                  = "project"
+	tableTeam                     = "team"
+	tableDashboard                = "dashboard"
+	tableDashboardAccess          = "dashboardaccess"
+	tableDashboardAlias           = "dashboardalias"
+	tableDashboardDraft           = "dashboarddraft"
+	tableDashboardUsage           = "dashboardusage"
+	tableFolder                   = "folder"
+	tableDatasource               = "datasource"
+	tableEphemeralDashboard       = "ephemeraldashboard"
+	tableHomeConfig               = "homeconfig"
+	tableOrganizationDatasource   = "organizationdatasource"
+	tableOrganizationVariable     = "organizationvariable"
+	tablePlaylist                 = "playlist"
+	tableSavedView                = "savedview"
+	tableShareToken               = "sharetoken"
+	tableVariable                 = "variable"
+
+	colID           = "id"
+	colDoc          = "doc"
+	colName         = "name"
+	colOrganization = "organization"
+	colProject      = "project"
 )
 
+// errDuplicateEntry is the code of the MySQL error returned when a row breaks a unique constraint.
+const errDuplicateEntry = 1062
+
 func getTableName(kind modelV1.Kind) (string, error) {
 	switch kind {
 	case modelV1.KindDashboard:
 		return tableDashboard, nil
+	case modelV1.KindDashboardAccess:
+		return tableDashboardAccess, nil
+	case modelV1.KindDashboardAlias:
+		return tableDashboardAlias, nil
+	case modelV1.KindDashboardDraft:
+		return tableDashboardDraft, nil
+	case modelV1.KindDashboardUsage:
+		return tableDashboardUsage, nil
 	case modelV1.KindDatasource:
 		return tableDatasource, nil
+	case modelV1.KindEphemeralDashboard:
+		return tableEphemeralDashboard, nil
 	case modelV1.KindFolder:
 		return tableFolder, nil
+	case modelV1.KindGlobalDashboard:
+		return tableGlobalDashboard, nil
 	case modelV1.KindGlobalDatasource:
 		return tableGlobalDatasource, nil
+	case modelV1.KindGlobalDatasourceOverride:
+		return tableGlobalDatasourceOverride, nil
+	case modelV1.KindGlobalHomeConfig:
+		return tableGlobalHomeConfig, nil
 	case modelV1.KindGlobalVariable:
 		return tableGlobalVariable, nil
+	case modelV1.KindHomeConfig:
+		return tableHomeConfig, nil
+	case modelV1.KindOrganization:
+		return tableOrganization, nil
+	case modelV1.KindOrganizationDatasource:
+		return tableOrganizationDatasource, nil
+	case modelV1.KindOrganizationVariable:
+		return tableOrganizationVariable, nil
+	case modelV1.KindPlaylist:
+		return tablePlaylist, nil
 	case modelV1.KindProject:
 		return tableProject, nil
+	case modelV1.KindSavedView:
+		return tableSavedView, nil
+	case modelV1.KindShareToken:
+		return tableShareToken, nil
+	case modelV1.KindTeam:
+		return tableTeam, nil
 	case modelV1.KindVariable:
 		return tableVariable, nil
 	default:
@@ -62,32 +116,73 @@ func getTableName(kind modelV1.Kind) (string, error) {
 	}
 }
 
-func generateID(metadata modelAPI.Metadata) (string, error) {
+// projectResourceTables are the tables of the resources belonging to a project.
+var projectResourceTables = []string{
+	tableDashboard,
+	tableDashboardAccess,
+	tableDashboardAlias,
+	tableDashboardDraft,
+	tableDashboardUsage,
+	tableFolder,
+	tableDatasource,
+	tableEphemeralDashboard,
+	tableGlobalDatasourceOverride,
+	tableHomeConfig,
+	tablePlaylist,
+	tableSavedView,
+	tableShareToken,
+	tableVariable,
+}
+
+func (d *DAO) generateID(metadata modelAPI.Metadata) (string, error) {
 	switch m := metadata.(type) {
 	case *modelV1.ProjectMetadata:
-		return fmt.Sprintf("%s|%s", m.Project, m.Name), nil
+		organization, err := d.getOrganization(m.Project)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s|%s|%s", organization, m.Project, m.Name), nil
+	case *modelV1.OrganizationMetadata:
+		if len(m.Organization) == 0 {
+			// the projects created before the organizations exist are only identified by their name.
+			return m.Name, nil
+		}
+		return fmt.Sprintf("%s|%s", m.Organization, m.Name), nil
 	case *modelV1.Metadata:
 		return m.Name, nil
 	}
 	return "", fmt.Errorf("metadata %T not managed", metadata)
 }
 
+// queryer is the part of sql.DB and sql.Tx used to read and write the resources.
+type queryer interface {
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
 type DAO struct {
 	databaseModel.DAO
 	DB         *sql.DB
 	SchemaName string
+	// tx is set when the DAO is used in a transaction. The resources are then read and written through it.
+	tx *sql.Tx
 }
 
 func (d *DAO) Init() error {
 	tables := []string{
 		d.createResourceTable(tableGlobalDatasource),
+		d.createResourceTable(tableGlobalDashboard),
+		d.createResourceTable(tableGlobalHomeConfig),
 		d.createResourceTable(tableGlobalVariable),
-		d.createResourceTable(tableProject),
+		d.createResourceTable(tableOrganization),
+		d.createResourceTable(tableTeam),
 
-		d.createProjectResourceTable(tableDashboard),
-		d.createProjectResourceTable(tableFolder),
-		d.createProjectResourceTable(tableDatasource),
-		d.createProjectResourceTable(tableVariable),
+		d.createOrganizationResourceTable(tableProject),
+		d.createOrganizationResourceTable(tableOrganizationDatasource),
+		d.createOrganizationResourceTable(tableOrganizationVariable),
+	}
+	for _, table := range projectResourceTables {
+		tables = append(tables, d.createProjectResourceTable(table))
 	}
 
 	for _, table := range tables {
@@ -95,7 +190,7 @@ func (d *DAO) Init() error {
 			return err
 		}
 	}
-	return nil
+	return d.migrate()
 }
 
 func (d *DAO) createResourceTable(tableName string) string {
@@ -108,13 +203,22 @@ func (d *DAO) createResourceTable(tableName string) string {
 
 func (d *DAO) createProjectResourceTable(tableName string) string {
 	return sqlbuilder.CreateTable(d.generateCompleteTableName(tableName)).IfNotExists().
-		Define(colID, "VARCHAR(256)", "NOT NULL", "PRIMARY KEY").
+		Define(colID, "VARCHAR(512)", "NOT NULL", "PRIMARY KEY").
 		Define(colName, "VARCHAR(128)", "NOT NULL").
 		Define(colProject, "VARCHAR(128)", "NOT NULL").
 		Define(colDoc, "JSON", "NOT NULL").
 		String()
 }
 
+func (d *DAO) createOrganizationResourceTable(tableName string) string {
+	return sqlbuilder.CreateTable(d.generateCompleteTableName(tableName)).IfNotExists().
+		Define(colID, "VARCHAR(256)", "NOT NULL", "PRIMARY KEY").
+		Define(colName, "VARCHAR(128)", "NOT NULL").
+		Define(colOrganization, "VARCHAR(128)", "NOT NULL").
+		Define(colDoc, "JSON", "NOT NULL").
+		String()
+}
+
 func (d *DAO) createTable(query string) error {
 	r, e := d.DB.Query(query)
 	if e != nil {
@@ -141,8 +245,13 @@ func (d *DAO) Create(entity modelAPI.Entity) error {
 		return queryErr
 	}
 
-	createQuery, createErr := d.DB.Query(sqlQuery, args...)
+	createQuery, createErr := d.getQueryer().Query(sqlQuery, args...)
 	if createErr != nil {
+		var mysqlErr *mysql.MySQLError
+		if errors.As(createErr, &mysqlErr) && mysqlErr.Number == errDuplicateEntry {
+			// a unique index, like the name of the projects, rejects the resource
+			return &databaseModel.Error{Key: id, Code: databaseModel.ErrorCodeConflict}
+		}
 		return createErr
 	}
 	return createQuery.Close()
@@ -164,7 +273,7 @@ func (d *DAO) Upsert(entity modelAPI.Entity) error {
 	if queryGeneratorErr != nil {
 		return queryGeneratorErr
 	}
-	upsertQuery, upsertErr := d.DB.Query(sqlQuery, args...)
+	upsertQuery, upsertErr := d.getQueryer().Query(sqlQuery, args...)
 	if upsertErr != nil {
 		return upsertErr
 	}
@@ -211,7 +320,7 @@ func (d *DAO) Query(query databaseModel.Query, slice interface{}) error {
 	if buildQueryErr != nil {
 		return fmt.Errorf("unable to build the query: %s", buildQueryErr)
 	}
-	rows, runQueryErr := d.DB.Query(q, args...)
+	rows, runQueryErr := d.getQueryer().Query(q, args...)
 	if runQueryErr != nil {
 		return runQueryErr
 	}
@@ -234,6 +343,9 @@ func (d *DAO) Query(query databaseModel.Query, slice interface{}) error {
 		if unmarshalErr := json.Unmarshal([]byte(rowJSONDoc), obj); unmarshalErr != nil {
 			return unmarshalErr
 		}
+		if !databaseModel.MatchTeam(query, obj) {
+			continue
+		}
 		if typeParameter.Elem().Kind() != reflect.Ptr {
 			// In case the type of the slice element is not a pointer,
 			// we should return the value of the pointer created in the previous step.
@@ -269,7 +381,7 @@ func (d *DAO) Delete(kind modelV1.Kind, metadata modelAPI.Metadata) error {
 	deleteBuilder.Where(deleteBuilder.Equal(colID, id))
 	sqlQuery, args := deleteBuilder.Build()
 
-	deleteQuery, err := d.DB.Query(sqlQuery, args...)
+	deleteQuery, err := d.getQueryer().Query(sqlQuery, args...)
 	if err != nil {
 		return err
 	}
@@ -281,13 +393,31 @@ func (d *DAO) DeleteByQuery(query databaseModel.Query) error {
 	if buildQueryErr != nil {
 		return fmt.Errorf("unable to build the query: %s", buildQueryErr)
 	}
-	rows, runQueryErr := d.DB.Query(q, args...)
+	rows, runQueryErr := d.getQueryer().Query(q, args...)
 	if runQueryErr != nil {
 		return runQueryErr
 	}
 	return rows.Close()
 }
 
+// Transaction runs apply in a database transaction that is committed when apply succeeds and rolled back otherwise.
+func (d *DAO) Transaction(apply func(dao databaseModel.DAO) error) error {
+	if d.tx != nil {
+		return fmt.Errorf("nested transactions are not supported")
+	}
+	tx, err := d.DB.Begin()
+	if err != nil {
+		return err
+	}
+	if applyErr := apply(&DAO{DB: d.DB, SchemaName: d.SchemaName, tx: tx}); applyErr != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			return &databaseModel.RollbackError{Err: applyErr, RollbackErr: rollbackErr}
+		}
+		return applyErr
+	}
+	return tx.Commit()
+}
+
 func (d *DAO) HealthCheck() bool {
 	if err := d.DB.Ping(); err != nil {
 		logrus.WithError(err).Error("unable to ping the database")
@@ -296,12 +426,19 @@ func (d *DAO) HealthCheck() bool {
 	return true
 }
 
+func (d *DAO) getQueryer() queryer {
+	if d.tx != nil {
+		return d.tx
+	}
+	return d.DB
+}
+
 func (d *DAO) getIDAndTableName(kind modelV1.Kind, metadata modelAPI.Metadata) (string, string, error) {
 	tableName, tableErr := getTableName(kind)
 	if tableErr != nil {
 		return "", "", tableErr
 	}
-	id, generateIDErr := generateID(metadata)
+	id, generateIDErr := d.generateID(metadata)
 	if generateIDErr != nil {
 		return "", "", generateIDErr
 	}
@@ -313,6 +450,24 @@ func (d *DAO) generateCompleteTableName(tableName string) string {
 	return fmt.Sprintf("%s.%s", d.SchemaName, tableName)
 }
 
+// getOrganization returns the organization of the project. The default organization is returned when the project
+// doesn't exist, or when it has been created before the organizations exist.
+func (d *DAO) getOrganization(project string) (string, error) {
+	queryBuilder := sqlbuilder.NewSelectBuilder()
+	queryBuilder.Select(fmt.Sprintf("MAX(%s)", colOrganization)).
+		From(d.generateCompleteTableName(tableProject)).
+		Where(queryBuilder.Equal(colName, project))
+	sqlQuery, args := queryBuilder.Build()
+	var organization sql.NullString
+	if err := d.getQueryer().QueryRow(sqlQuery, args...).Scan(&organization); err != nil {
+		return "", err
+	}
+	if len(organization.String) == 0 {
+		return modelV1.DefaultOrganization, nil
+	}
+	return organization.String, nil
+}
+
 func (d *DAO) exists(kind modelV1.Kind, metadata modelAPI.Metadata) (string, bool, error) {
 	id, query, queryErr := d.get(kind, metadata)
 	if queryErr != nil {
@@ -334,6 +489,6 @@ func (d *DAO) get(kind modelV1.Kind, metadata modelAPI.Metadata) (string, *sql.R
 	queryBuilder.Where(queryBuilder.Equal(colID, id))
 	sqlQuery, args := queryBuilder.Build()
 
-	rows, err := d.DB.Query(sqlQuery, args...)
+	rows, err := d.getQueryer().Query(sqlQuery, args...)
 	return id, rows, err
 }
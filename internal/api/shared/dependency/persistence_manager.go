@@ -15,49 +15,113 @@ package dependency
 
 import (
 	"github.com/perses/perses/internal/api/config"
+	accessImpl "github.com/perses/perses/internal/api/impl/v1/access"
 	dashboardImpl "github.com/perses/perses/internal/api/impl/v1/dashboard"
+	dashboardAliasImpl "github.com/perses/perses/internal/api/impl/v1/dashboardalias"
 	datasourceImpl "github.com/perses/perses/internal/api/impl/v1/datasource"
+	draftImpl "github.com/perses/perses/internal/api/impl/v1/draft"
+	ephemeralDashboardImpl "github.com/perses/perses/internal/api/impl/v1/ephemeraldashboard"
 	folderImpl "github.com/perses/perses/internal/api/impl/v1/folder"
+	globalDashboardImpl "github.com/perses/perses/internal/api/impl/v1/globaldashboard"
 	globalDatasourceImpl "github.com/perses/perses/internal/api/impl/v1/globaldatasource"
+	globalDatasourceOverrideImpl "github.com/perses/perses/internal/api/impl/v1/globaldatasourceoverride"
+	globalHomeConfigImpl "github.com/perses/perses/internal/api/impl/v1/globalhomeconfig"
 	globalVariableImpl "github.com/perses/perses/internal/api/impl/v1/globalvariable"
 	healthImpl "github.com/perses/perses/internal/api/impl/v1/health"
+	homeConfigImpl "github.com/perses/perses/internal/api/impl/v1/homeconfig"
+	organizationImpl "github.com/perses/perses/internal/api/impl/v1/organization"
+	organizationDatasourceImpl "github.com/perses/perses/internal/api/impl/v1/organizationdatasource"
+	organizationVariableImpl "github.com/perses/perses/internal/api/impl/v1/organizationvariable"
+	playlistImpl "github.com/perses/perses/internal/api/impl/v1/playlist"
 	projectImpl "github.com/perses/perses/internal/api/impl/v1/project"
+	savedViewImpl "github.com/perses/perses/internal/api/impl/v1/savedview"
+	shareTokenImpl "github.com/perses/perses/internal/api/impl/v1/sharetoken"
+	teamImpl "github.com/perses/perses/internal/api/impl/v1/team"
+	usageImpl "github.com/perses/perses/internal/api/impl/v1/usage"
 	variableImpl "github.com/perses/perses/internal/api/impl/v1/variable"
+	"github.com/perses/perses/internal/api/interface/v1/access"
 	"github.com/perses/perses/internal/api/interface/v1/dashboard"
+	"github.com/perses/perses/internal/api/interface/v1/dashboardalias"
 	"github.com/perses/perses/internal/api/interface/v1/datasource"
+	"github.com/perses/perses/internal/api/interface/v1/draft"
+	"github.com/perses/perses/internal/api/interface/v1/ephemeraldashboard"
 	"github.com/perses/perses/internal/api/interface/v1/folder"
+	"github.com/perses/perses/internal/api/interface/v1/globaldashboard"
 	"github.com/perses/perses/internal/api/interface/v1/globaldatasource"
+	"github.com/perses/perses/internal/api/interface/v1/globaldatasourceoverride"
+	"github.com/perses/perses/internal/api/interface/v1/globalhomeconfig"
 	"github.com/perses/perses/internal/api/interface/v1/globalvariable"
 	"github.com/perses/perses/internal/api/interface/v1/health"
+	"github.com/perses/perses/internal/api/interface/v1/homeconfig"
+	"github.com/perses/perses/internal/api/interface/v1/organization"
+	"github.com/perses/perses/internal/api/interface/v1/organizationdatasource"
+	"github.com/perses/perses/internal/api/interface/v1/organizationvariable"
+	"github.com/perses/perses/internal/api/interface/v1/playlist"
 	"github.com/perses/perses/internal/api/interface/v1/project"
+	"github.com/perses/perses/internal/api/interface/v1/savedview"
+	"github.com/perses/perses/internal/api/interface/v1/sharetoken"
+	"github.com/perses/perses/internal/api/interface/v1/team"
+	"github.com/perses/perses/internal/api/interface/v1/usage"
 	"github.com/perses/perses/internal/api/interface/v1/variable"
 	"github.com/perses/perses/internal/api/shared/database"
 	databaseModel "github.com/perses/perses/internal/api/shared/database/model"
 )
 
 type PersistenceManager interface {
+	GetAccess() access.DAO
 	GetDashboard() dashboard.DAO
+	GetDashboardAlias() dashboardalias.DAO
 	GetDatasource() datasource.DAO
+	GetDraft() draft.DAO
+	GetEphemeralDashboard() ephemeraldashboard.DAO
 	GetFolder() folder.DAO
+	GetGlobalDashboard() globaldashboard.DAO
 	GetGlobalDatasource() globaldatasource.DAO
+	GetGlobalDatasourceOverride() globaldatasourceoverride.DAO
+	GetGlobalHomeConfig() globalhomeconfig.DAO
 	GetGlobalVariable() globalvariable.DAO
 	GetHealth() health.DAO
+	GetHomeConfig() homeconfig.DAO
+	GetOrganization() organization.DAO
+	GetOrganizationDatasource() organizationdatasource.DAO
+	GetOrganizationVariable() organizationvariable.DAO
 	GetPersesDAO() databaseModel.DAO
+	GetPlaylist() playlist.DAO
 	GetProject() project.DAO
+	GetSavedView() savedview.DAO
+	GetShareToken() sharetoken.DAO
+	GetTeam() team.DAO
+	GetUsage() usage.DAO
 	GetVariable() variable.DAO
 }
 
 type persistence struct {
 	PersistenceManager
-	dashboard        dashboard.DAO
-	datasource       datasource.DAO
-	folder           folder.DAO
-	globalDatasource globaldatasource.DAO
-	globalVariable   globalvariable.DAO
-	health           health.DAO
-	perses           databaseModel.DAO
-	project          project.DAO
-	variable         variable.DAO
+	access                   access.DAO
+	dashboard                dashboard.DAO
+	dashboardAlias           dashboardalias.DAO
+	datasource               datasource.DAO
+	draft                    draft.DAO
+	ephemeralDashboard       ephemeraldashboard.DAO
+	folder                   folder.DAO
+	globalDashboard          globaldashboard.DAO
+	globalDatasource         globaldatasource.DAO
+	globalDatasourceOverride globaldatasourceoverride.DAO
+	globalHomeConfig         globalhomeconfig.DAO
+	globalVariable           globalvariable.DAO
+	health                   health.DAO
+	homeConfig               homeconfig.DAO
+	organization             organization.DAO
+	organizationDatasource   organizationdatasource.DAO
+	organizationVariable     organizationvariable.DAO
+	perses                   databaseModel.DAO
+	playlist                 playlist.DAO
+	project                  project.DAO
+	savedView                savedview.DAO
+	shareToken               sharetoken.DAO
+	team                     team.DAO
+	usage                    usage.DAO
+	variable                 variable.DAO
 }
 
 func NewPersistenceManager(conf config.Database) (PersistenceManager, error) {
@@ -65,43 +129,108 @@ func NewPersistenceManager(conf config.Database) (PersistenceManager, error) {
 	if err != nil {
 		return nil, err
 	}
+	return NewPersistenceManagerFromDAO(persesDAO)
+}
+
+// NewPersistenceManagerFromDAO builds the persistence manager on top of the given database instead of the one configured.
+func NewPersistenceManagerFromDAO(persesDAO databaseModel.DAO) (PersistenceManager, error) {
+	accessDAO := accessImpl.NewDAO(persesDAO)
 	dashboardDAO := dashboardImpl.NewDAO(persesDAO)
+	dashboardAliasDAO := dashboardAliasImpl.NewDAO(persesDAO)
 	datasourceDAO := datasourceImpl.NewDAO(persesDAO)
+	draftDAO := draftImpl.NewDAO(persesDAO)
+	ephemeralDashboardDAO := ephemeralDashboardImpl.NewDAO(persesDAO)
 	folderDAO := folderImpl.NewDAO(persesDAO)
+	globalDashboardDAO := globalDashboardImpl.NewDAO(persesDAO)
 	globalDatatasourceDAO := globalDatasourceImpl.NewDAO(persesDAO)
+	globalDatasourceOverrideDAO := globalDatasourceOverrideImpl.NewDAO(persesDAO)
+	globalHomeConfigDAO := globalHomeConfigImpl.NewDAO(persesDAO)
 	globalVariableDAO := globalVariableImpl.NewDAO(persesDAO)
 	healthDAO := healthImpl.NewDAO(persesDAO)
+	homeConfigDAO := homeConfigImpl.NewDAO(persesDAO)
+	organizationDAO := organizationImpl.NewDAO(persesDAO)
+	organizationDatasourceDAO := organizationDatasourceImpl.NewDAO(persesDAO)
+	organizationVariableDAO := organizationVariableImpl.NewDAO(persesDAO)
+	playlistDAO := playlistImpl.NewDAO(persesDAO)
 	projectDAO := projectImpl.NewDAO(persesDAO)
+	savedViewDAO := savedViewImpl.NewDAO(persesDAO)
+	shareTokenDAO := shareTokenImpl.NewDAO(persesDAO)
+	teamDAO := teamImpl.NewDAO(persesDAO)
+	usageDAO := usageImpl.NewDAO(persesDAO)
 	variableDAO := variableImpl.NewDAO(persesDAO)
 	return &persistence{
-		dashboard:        dashboardDAO,
-		datasource:       datasourceDAO,
-		folder:           folderDAO,
-		globalDatasource: globalDatatasourceDAO,
-		globalVariable:   globalVariableDAO,
-		health:           healthDAO,
-		perses:           persesDAO,
-		project:          projectDAO,
-		variable:         variableDAO,
+		access:                   accessDAO,
+		dashboard:                dashboardDAO,
+		dashboardAlias:           dashboardAliasDAO,
+		datasource:               datasourceDAO,
+		draft:                    draftDAO,
+		ephemeralDashboard:       ephemeralDashboardDAO,
+		folder:                   folderDAO,
+		globalDashboard:          globalDashboardDAO,
+		globalDatasource:         globalDatatasourceDAO,
+		globalDatasourceOverride: globalDatasourceOverrideDAO,
+		globalHomeConfig:         globalHomeConfigDAO,
+		globalVariable:           globalVariableDAO,
+		health:                   healthDAO,
+		homeConfig:               homeConfigDAO,
+		organization:             organizationDAO,
+		organizationDatasource:   organizationDatasourceDAO,
+		organizationVariable:     organizationVariableDAO,
+		perses:                   persesDAO,
+		playlist:                 playlistDAO,
+		project:                  projectDAO,
+		savedView:                savedViewDAO,
+		shareToken:               shareTokenDAO,
+		team:                     teamDAO,
+		usage:                    usageDAO,
+		variable:                 variableDAO,
 	}, nil
 }
 
+func (p *persistence) GetAccess() access.DAO {
+	return p.access
+}
+
 func (p *persistence) GetDashboard() dashboard.DAO {
 	return p.dashboard
 }
 
+func (p *persistence) GetDashboardAlias() dashboardalias.DAO {
+	return p.dashboardAlias
+}
+
 func (p *persistence) GetDatasource() datasource.DAO {
 	return p.datasource
 }
 
+func (p *persistence) GetDraft() draft.DAO {
+	return p.draft
+}
+
+func (p *persistence) GetEphemeralDashboard() ephemeraldashboard.DAO {
+	return p.ephemeralDashboard
+}
+
 func (p *persistence) GetFolder() folder.DAO {
 	return p.folder
 }
 
+func (p *persistence) GetGlobalDashboard() globaldashboard.DAO {
+	return p.globalDashboard
+}
+
 func (p *persistence) GetGlobalDatasource() globaldatasource.DAO {
 	return p.globalDatasource
 }
 
+func (p *persistence) GetGlobalDatasourceOverride() globaldatasourceoverride.DAO {
+	return p.globalDatasourceOverride
+}
+
+func (p *persistence) GetGlobalHomeConfig() globalhomeconfig.DAO {
+	return p.globalHomeConfig
+}
+
 func (p *persistence) GetGlobalVariable() globalvariable.DAO {
 	return p.globalVariable
 }
@@ -110,14 +239,50 @@ func (p *persistence) GetHealth() health.DAO {
 	return p.health
 }
 
+func (p *persistence) GetHomeConfig() homeconfig.DAO {
+	return p.homeConfig
+}
+
+func (p *persistence) GetOrganization() organization.DAO {
+	return p.organization
+}
+
+func (p *persistence) GetOrganizationDatasource() organizationdatasource.DAO {
+	return p.organizationDatasource
+}
+
+func (p *persistence) GetOrganizationVariable() organizationvariable.DAO {
+	return p.organizationVariable
+}
+
 func (p *persistence) GetPersesDAO() databaseModel.DAO {
 	return p.perses
 }
 
+func (p *persistence) GetPlaylist() playlist.DAO {
+	return p.playlist
+}
+
 func (p *persistence) GetProject() project.DAO {
 	return p.project
 }
 
+func (p *persistence) GetSavedView() savedview.DAO {
+	return p.savedView
+}
+
+func (p *persistence) GetShareToken() sharetoken.DAO {
+	return p.shareToken
+}
+
+func (p *persistence) GetTeam() team.DAO {
+	return p.team
+}
+
+func (p *persistence) GetUsage() usage.DAO {
+	return p.usage
+}
+
 func (p *persistence) GetVariable() variable.DAO {
 	return p.variable
 }
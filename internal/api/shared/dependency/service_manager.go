@@ -17,51 +17,128 @@ package dependency
 
 import (
 	"github.com/perses/perses/internal/api/config"
+	accessImpl "github.com/perses/perses/internal/api/impl/v1/access"
 	dashboardImpl "github.com/perses/perses/internal/api/impl/v1/dashboard"
 	datasourceImpl "github.com/perses/perses/internal/api/impl/v1/datasource"
+	draftImpl "github.com/perses/perses/internal/api/impl/v1/draft"
+	ephemeralDashboardImpl "github.com/perses/perses/internal/api/impl/v1/ephemeraldashboard"
 	folderImpl "github.com/perses/perses/internal/api/impl/v1/folder"
+	globalDashboardImpl "github.com/perses/perses/internal/api/impl/v1/globaldashboard"
 	globalDatasourceImpl "github.com/perses/perses/internal/api/impl/v1/globaldatasource"
+	globalDatasourceOverrideImpl "github.com/perses/perses/internal/api/impl/v1/globaldatasourceoverride"
+	globalHomeConfigImpl "github.com/perses/perses/internal/api/impl/v1/globalhomeconfig"
 	globalVariableImpl "github.com/perses/perses/internal/api/impl/v1/globalvariable"
 	healthImpl "github.com/perses/perses/internal/api/impl/v1/health"
+	homeConfigImpl "github.com/perses/perses/internal/api/impl/v1/homeconfig"
+	organizationImpl "github.com/perses/perses/internal/api/impl/v1/organization"
+	organizationDatasourceImpl "github.com/perses/perses/internal/api/impl/v1/organizationdatasource"
+	organizationVariableImpl "github.com/perses/perses/internal/api/impl/v1/organizationvariable"
+	playlistImpl "github.com/perses/perses/internal/api/impl/v1/playlist"
 	projectImpl "github.com/perses/perses/internal/api/impl/v1/project"
+	savedViewImpl "github.com/perses/perses/internal/api/impl/v1/savedview"
+	shareTokenImpl "github.com/perses/perses/internal/api/impl/v1/sharetoken"
+	teamImpl "github.com/perses/perses/internal/api/impl/v1/team"
+	usageImpl "github.com/perses/perses/internal/api/impl/v1/usage"
 	variableImpl "github.com/perses/perses/internal/api/impl/v1/variable"
+	"github.com/perses/perses/internal/api/interface/v1/access"
 	"github.com/perses/perses/internal/api/interface/v1/dashboard"
 	"github.com/perses/perses/internal/api/interface/v1/datasource"
+	"github.com/perses/perses/internal/api/interface/v1/draft"
+	"github.com/perses/perses/internal/api/interface/v1/ephemeraldashboard"
 	"github.com/perses/perses/internal/api/interface/v1/folder"
+	"github.com/perses/perses/internal/api/interface/v1/globaldashboard"
 	"github.com/perses/perses/internal/api/interface/v1/globaldatasource"
+	"github.com/perses/perses/internal/api/interface/v1/globaldatasourceoverride"
+	"github.com/perses/perses/internal/api/interface/v1/globalhomeconfig"
 	"github.com/perses/perses/internal/api/interface/v1/globalvariable"
 	"github.com/perses/perses/internal/api/interface/v1/health"
+	"github.com/perses/perses/internal/api/interface/v1/homeconfig"
+	"github.com/perses/perses/internal/api/interface/v1/organization"
+	"github.com/perses/perses/internal/api/interface/v1/organizationdatasource"
+	"github.com/perses/perses/internal/api/interface/v1/organizationvariable"
+	"github.com/perses/perses/internal/api/interface/v1/playlist"
 	"github.com/perses/perses/internal/api/interface/v1/project"
+	"github.com/perses/perses/internal/api/interface/v1/savedview"
+	"github.com/perses/perses/internal/api/interface/v1/sharetoken"
+	"github.com/perses/perses/internal/api/interface/v1/team"
+	"github.com/perses/perses/internal/api/interface/v1/usage"
 	"github.com/perses/perses/internal/api/interface/v1/variable"
+	"github.com/perses/perses/internal/api/shared/analysis"
+	"github.com/perses/perses/internal/api/shared/auth"
+	"github.com/perses/perses/internal/api/shared/auth/ldap"
+	databaseModel "github.com/perses/perses/internal/api/shared/database/model"
 	"github.com/perses/perses/internal/api/shared/migrate"
+	"github.com/perses/perses/internal/api/shared/rename"
 	"github.com/perses/perses/internal/api/shared/schemas"
 )
 
 type ServiceManager interface {
+	GetAnalysis() analysis.Analysis
+	GetAuthorization() auth.Authorization
+	GetAccess() access.Service
 	GetDashboard() dashboard.Service
 	GetDatasource() datasource.Service
+	GetDraft() draft.Service
+	GetEphemeralDashboard() ephemeraldashboard.Service
 	GetFolder() folder.Service
+	GetGlobalDashboard() globaldashboard.Service
 	GetGlobalDatasource() globaldatasource.Service
+	GetGlobalDatasourceOverride() globaldatasourceoverride.Service
+	GetGlobalHomeConfig() globalhomeconfig.Service
 	GetGlobalVariable() globalvariable.Service
 	GetHealth() health.Service
+	GetHomeConfig() homeconfig.Service
+	// GetLDAP returns nil when the LDAP authentication is not configured.
+	GetLDAP() ldap.Provider
 	GetMigration() migrate.Migration
+	GetOrganization() organization.Service
+	GetOrganizationDatasource() organizationdatasource.Service
+	GetOrganizationVariable() organizationvariable.Service
+	GetPlaylist() playlist.Service
 	GetProject() project.Service
+	GetRename() rename.Rename
 	GetSchemas() schemas.Schemas
+	GetSavedView() savedview.Service
+	GetShareToken() sharetoken.Service
+	GetTeam() team.Service
+	// GetToken returns nil when there is no key configured to sign the access tokens.
+	GetToken() auth.Token
+	GetUsage() usage.Service
 	GetVariable() variable.Service
 }
 
 type service struct {
 	ServiceManager
-	dashboard        dashboard.Service
-	datasource       datasource.Service
-	folder           folder.Service
-	globalDatasource globaldatasource.Service
-	globalVariable   globalvariable.Service
-	health           health.Service
-	migrate          migrate.Migration
-	project          project.Service
-	schemas          schemas.Schemas
-	variable         variable.Service
+	access                   access.Service
+	analysis                 analysis.Analysis
+	authorization            auth.Authorization
+	dashboard                dashboard.Service
+	datasource               datasource.Service
+	draft                    draft.Service
+	ephemeralDashboard       ephemeraldashboard.Service
+	folder                   folder.Service
+	globalDashboard          globaldashboard.Service
+	globalDatasource         globaldatasource.Service
+	globalDatasourceOverride globaldatasourceoverride.Service
+	globalHomeConfig         globalhomeconfig.Service
+	globalVariable           globalvariable.Service
+	health                   health.Service
+	homeConfig               homeconfig.Service
+	ldap                     ldap.Provider
+	migrate                  migrate.Migration
+	organization             organization.Service
+	organizationDatasource   organizationdatasource.Service
+	organizationVariable     organizationvariable.Service
+	playlist                 playlist.Service
+	project                  project.Service
+	rename                   rename.Rename
+	schemas                  schemas.Schemas
+	savedView                savedview.Service
+	shareToken               sharetoken.Service
+	team                     team.Service
+	token                    auth.Token
+	usage                    usage.Service
+	variable                 variable.Service
 }
 
 func NewServiceManager(dao PersistenceManager, conf config.Config) (ServiceManager, error) {
@@ -69,32 +146,100 @@ func NewServiceManager(dao PersistenceManager, conf config.Config) (ServiceManag
 	if err != nil {
 		return nil, err
 	}
+	return NewServiceManagerWithSchemas(dao, conf, schemasService)
+}
+
+// NewServiceManagerWithSchemas builds the service manager with the given schemas instead of the ones loaded from the
+// folders configured.
+func NewServiceManagerWithSchemas(dao PersistenceManager, conf config.Config, schemasService schemas.Schemas) (ServiceManager, error) {
 	migrateService, err := migrate.New(conf.Schemas)
 	if err != nil {
 		return nil, err
 	}
-	dashboardService := dashboardImpl.NewService(dao.GetDashboard(), schemasService)
+	var ldapProvider ldap.Provider
+	if conf.Authentication.LDAP != nil {
+		if ldapProvider, err = ldap.New(*conf.Authentication.LDAP); err != nil {
+			return nil, err
+		}
+	}
+	var token auth.Token
+	if len(conf.Authentication.Session.Key) > 0 {
+		token = auth.NewToken(conf.Authentication.Session)
+	}
+	analysisService := analysis.New(dao.GetDashboard(), dao.GetVariable(), dao.GetGlobalVariable())
+	authorization := auth.NewAuthorization(conf.Authorization, dao.GetProject())
+	accessService := accessImpl.NewService(dao.GetAccess(), dao.GetDashboard(), dao.GetDatasource(), authorization)
+	homeConfigService := homeConfigImpl.NewService(dao.GetHomeConfig(), dao.GetGlobalHomeConfig(), dao.GetDashboard())
+	dashboardService := dashboardImpl.NewService(dao.GetDashboard(), dao.GetDraft(), dao.GetAccess(), dao.GetShareToken(), dao.GetDashboardAlias(), homeConfigService, schemasService)
 	datasourceService := datasourceImpl.NewService(dao.GetDatasource(), schemasService)
+	draftService := draftImpl.NewService(dao.GetDraft(), dao.GetDashboard(), dashboardService, dao.GetProject(), schemasService, conf.Draft.RequireReview)
+	ephemeralDashboardService := ephemeralDashboardImpl.NewService(dao.GetEphemeralDashboard(), schemasService)
 	folderService := folderImpl.NewService(dao.GetFolder())
 	variableService := variableImpl.NewService(dao.GetVariable(), schemasService)
-	globalDatasourceService := globalDatasourceImpl.NewService(dao.GetGlobalDatasource(), schemasService)
+	globalDashboardService := globalDashboardImpl.NewService(dao.GetGlobalDashboard(), dao.GetProject(), schemasService)
+	globalDatasourceService := globalDatasourceImpl.NewService(dao.GetGlobalDatasource(), dao.GetGlobalDatasourceOverride(), dao.GetProject(), schemasService)
+	globalDatasourceOverrideService := globalDatasourceOverrideImpl.NewService(dao.GetGlobalDatasourceOverride(), dao.GetGlobalDatasource(), schemasService)
+	globalHomeConfigService := globalHomeConfigImpl.NewService(dao.GetGlobalHomeConfig(), dao.GetDashboard())
 	globalVariableService := globalVariableImpl.NewService(dao.GetGlobalVariable(), schemasService)
 	healthService := healthImpl.NewService(dao.GetHealth())
-	projectService := projectImpl.NewService(dao.GetProject(), dao.GetFolder(), dao.GetDatasource(), dao.GetDashboard(), dao.GetVariable())
+	organizationService := organizationImpl.NewService(dao.GetOrganization(), dao.GetProject(), dao.GetOrganizationDatasource(), dao.GetOrganizationVariable())
+	organizationDatasourceService := organizationDatasourceImpl.NewService(dao.GetOrganizationDatasource(), dao.GetOrganization(), schemasService)
+	organizationVariableService := organizationVariableImpl.NewService(dao.GetOrganizationVariable(), dao.GetOrganization(), schemasService)
+	playlistService := playlistImpl.NewService(dao.GetPlaylist(), dao.GetDashboard())
+	projectService := projectImpl.NewService(dao.GetProject(), dao.GetOrganization(), dao.GetFolder(), dao.GetDatasource(), dao.GetDashboard(), dao.GetDashboardAlias(), dao.GetVariable(), dao.GetDraft(), dao.GetEphemeralDashboard(), dao.GetUsage(), dao.GetAccess(), dao.GetShareToken(), dao.GetPlaylist(), dao.GetSavedView(), dao.GetHomeConfig(), dao.GetGlobalDatasourceOverride(), homeConfigService)
+	renameService := rename.New(dao, func(persesDAO databaseModel.DAO) (rename.Persistence, error) {
+		return NewPersistenceManagerFromDAO(persesDAO)
+	})
+	savedViewService := savedViewImpl.NewService(dao.GetSavedView(), dao.GetDashboard())
+	shareTokenService := shareTokenImpl.NewService(dao.GetShareToken(), dao.GetDashboard(), dao.GetProject(), dao.GetGlobalDatasource(), dao.GetOrganizationDatasource(), accessService)
+	teamService := teamImpl.NewService(dao.GetTeam())
+	usageService := usageImpl.NewService(dao.GetUsage(), dao.GetDashboard())
 	return &service{
-		dashboard:        dashboardService,
-		datasource:       datasourceService,
-		folder:           folderService,
-		globalDatasource: globalDatasourceService,
-		globalVariable:   globalVariableService,
-		health:           healthService,
-		migrate:          migrateService,
-		project:          projectService,
-		schemas:          schemasService,
-		variable:         variableService,
+		access:                   accessService,
+		analysis:                 analysisService,
+		authorization:            authorization,
+		dashboard:                dashboardService,
+		datasource:               datasourceService,
+		draft:                    draftService,
+		ephemeralDashboard:       ephemeralDashboardService,
+		folder:                   folderService,
+		globalDashboard:          globalDashboardService,
+		globalDatasource:         globalDatasourceService,
+		globalDatasourceOverride: globalDatasourceOverrideService,
+		globalHomeConfig:         globalHomeConfigService,
+		globalVariable:           globalVariableService,
+		health:                   healthService,
+		homeConfig:               homeConfigService,
+		ldap:                     ldapProvider,
+		migrate:                  migrateService,
+		organization:             organizationService,
+		organizationDatasource:   organizationDatasourceService,
+		organizationVariable:     organizationVariableService,
+		playlist:                 playlistService,
+		project:                  projectService,
+		rename:                   renameService,
+		schemas:                  schemasService,
+		savedView:                savedViewService,
+		shareToken:               shareTokenService,
+		team:                     teamService,
+		token:                    token,
+		usage:                    usageService,
+		variable:                 variableService,
 	}, nil
 }
 
+func (s *service) GetAccess() access.Service {
+	return s.access
+}
+
+func (s *service) GetAnalysis() analysis.Analysis {
+	return s.analysis
+}
+
+func (s *service) GetAuthorization() auth.Authorization {
+	return s.authorization
+}
+
 func (s *service) GetDashboard() dashboard.Service {
 	return s.dashboard
 }
@@ -103,14 +248,34 @@ func (s *service) GetDatasource() datasource.Service {
 	return s.datasource
 }
 
+func (s *service) GetDraft() draft.Service {
+	return s.draft
+}
+
+func (s *service) GetEphemeralDashboard() ephemeraldashboard.Service {
+	return s.ephemeralDashboard
+}
+
 func (s *service) GetFolder() folder.Service {
 	return s.folder
 }
 
+func (s *service) GetGlobalDashboard() globaldashboard.Service {
+	return s.globalDashboard
+}
+
 func (s *service) GetGlobalDatasource() globaldatasource.Service {
 	return s.globalDatasource
 }
 
+func (s *service) GetGlobalDatasourceOverride() globaldatasourceoverride.Service {
+	return s.globalDatasourceOverride
+}
+
+func (s *service) GetGlobalHomeConfig() globalhomeconfig.Service {
+	return s.globalHomeConfig
+}
+
 func (s *service) GetGlobalVariable() globalvariable.Service {
 	return s.globalVariable
 }
@@ -119,18 +284,66 @@ func (s *service) GetHealth() health.Service {
 	return s.health
 }
 
+func (s *service) GetHomeConfig() homeconfig.Service {
+	return s.homeConfig
+}
+
+func (s *service) GetLDAP() ldap.Provider {
+	return s.ldap
+}
+
 func (s *service) GetMigration() migrate.Migration {
 	return s.migrate
 }
 
+func (s *service) GetOrganization() organization.Service {
+	return s.organization
+}
+
+func (s *service) GetOrganizationDatasource() organizationdatasource.Service {
+	return s.organizationDatasource
+}
+
+func (s *service) GetOrganizationVariable() organizationvariable.Service {
+	return s.organizationVariable
+}
+
+func (s *service) GetPlaylist() playlist.Service {
+	return s.playlist
+}
+
 func (s *service) GetProject() project.Service {
 	return s.project
 }
 
+func (s *service) GetRename() rename.Rename {
+	return s.rename
+}
+
 func (s *service) GetSchemas() schemas.Schemas {
 	return s.schemas
 }
 
+func (s *service) GetSavedView() savedview.Service {
+	return s.savedView
+}
+
+func (s *service) GetShareToken() sharetoken.Service {
+	return s.shareToken
+}
+
+func (s *service) GetTeam() team.Service {
+	return s.team
+}
+
+func (s *service) GetToken() auth.Token {
+	return s.token
+}
+
+func (s *service) GetUsage() usage.Service {
+	return s.usage
+}
+
 func (s *service) GetVariable() variable.Service {
 	return s.variable
 }
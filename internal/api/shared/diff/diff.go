@@ -0,0 +1,115 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+// Compute returns the list of differences between the two documents.
+// Both documents are compared through their JSON representation. The path of each change starts with the given root.
+func Compute(root string, published interface{}, draft interface{}) ([]v1.Change, error) {
+	publishedValue, err := toGeneric(published)
+	if err != nil {
+		return nil, err
+	}
+	draftValue, err := toGeneric(draft)
+	if err != nil {
+		return nil, err
+	}
+	changes := make([]v1.Change, 0)
+	compare(root, publishedValue, draftValue, &changes)
+	return changes, nil
+}
+
+func toGeneric(document interface{}) (interface{}, error) {
+	data, err := json.Marshal(document)
+	if err != nil {
+		return nil, err
+	}
+	var result interface{}
+	return result, json.Unmarshal(data, &result)
+}
+
+func compare(path string, published interface{}, draft interface{}, changes *[]v1.Change) {
+	switch p := published.(type) {
+	case map[string]interface{}:
+		if d, ok := draft.(map[string]interface{}); ok {
+			compareMap(path, p, d, changes)
+			return
+		}
+	case []interface{}:
+		if d, ok := draft.([]interface{}); ok {
+			compareSlice(path, p, d, changes)
+			return
+		}
+	}
+	if !reflect.DeepEqual(published, draft) {
+		*changes = append(*changes, v1.Change{Kind: v1.ChangeModified, Path: path, Published: published, Draft: draft})
+	}
+}
+
+func compareMap(path string, published map[string]interface{}, draft map[string]interface{}, changes *[]v1.Change) {
+	keys := make(map[string]bool, len(published)+len(draft))
+	for k := range published {
+		keys[k] = true
+	}
+	for k := range draft {
+		keys[k] = true
+	}
+	sortedKeys := make([]string, 0, len(keys))
+	for k := range keys {
+		sortedKeys = append(sortedKeys, k)
+	}
+	sort.Strings(sortedKeys)
+	for _, k := range sortedKeys {
+		subPath := joinPath(path, k)
+		p, inPublished := published[k]
+		d, inDraft := draft[k]
+		switch {
+		case !inPublished:
+			*changes = append(*changes, v1.Change{Kind: v1.ChangeAdded, Path: subPath, Draft: d})
+		case !inDraft:
+			*changes = append(*changes, v1.Change{Kind: v1.ChangeRemoved, Path: subPath, Published: p})
+		default:
+			compare(subPath, p, d, changes)
+		}
+	}
+}
+
+func compareSlice(path string, published []interface{}, draft []interface{}, changes *[]v1.Change) {
+	for i := 0; i < len(published) || i < len(draft); i++ {
+		subPath := fmt.Sprintf("%s[%d]", path, i)
+		switch {
+		case i >= len(published):
+			*changes = append(*changes, v1.Change{Kind: v1.ChangeAdded, Path: subPath, Draft: draft[i]})
+		case i >= len(draft):
+			*changes = append(*changes, v1.Change{Kind: v1.ChangeRemoved, Path: subPath, Published: published[i]})
+		default:
+			compare(subPath, published[i], draft[i], changes)
+		}
+	}
+}
+
+func joinPath(path string, key string) string {
+	if len(path) == 0 {
+		return key
+	}
+	return fmt.Sprintf("%s.%s", path, key)
+}
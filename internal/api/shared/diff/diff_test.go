@@ -0,0 +1,71 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"testing"
+
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompute(t *testing.T) {
+	testSuite := []struct {
+		title     string
+		published interface{}
+		draft     interface{}
+		result    []v1.Change
+	}{
+		{
+			title:     "same documents",
+			published: map[string]interface{}{"a": 1, "b": []string{"c"}},
+			draft:     map[string]interface{}{"a": 1, "b": []string{"c"}},
+			result:    []v1.Change{},
+		},
+		{
+			title:     "field added, removed and modified",
+			published: map[string]interface{}{"a": 1, "b": "old", "c": map[string]interface{}{"d": true}},
+			draft:     map[string]interface{}{"b": "new", "c": map[string]interface{}{"d": true, "e": "f"}},
+			result: []v1.Change{
+				{Kind: v1.ChangeRemoved, Path: "spec.a", Published: float64(1)},
+				{Kind: v1.ChangeModified, Path: "spec.b", Published: "old", Draft: "new"},
+				{Kind: v1.ChangeAdded, Path: "spec.c.e", Draft: "f"},
+			},
+		},
+		{
+			title:     "list changed",
+			published: map[string]interface{}{"queries": []string{"up", "down"}},
+			draft:     map[string]interface{}{"queries": []string{"up{job='a'}", "down", "left"}},
+			result: []v1.Change{
+				{Kind: v1.ChangeModified, Path: "spec.queries[0]", Published: "up", Draft: "up{job='a'}"},
+				{Kind: v1.ChangeAdded, Path: "spec.queries[2]", Draft: "left"},
+			},
+		},
+		{
+			title:     "type changed",
+			published: map[string]interface{}{"a": []string{"b"}},
+			draft:     map[string]interface{}{"a": "b"},
+			result: []v1.Change{
+				{Kind: v1.ChangeModified, Path: "spec.a", Published: []interface{}{"b"}, Draft: "b"},
+			},
+		},
+	}
+	for _, test := range testSuite {
+		t.Run(test.title, func(t *testing.T) {
+			result, err := Compute("spec", test.published, test.draft)
+			assert.NoError(t, err)
+			assert.Equal(t, test.result, result)
+		})
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package shared
+
+import (
+	"github.com/labstack/echo/v4"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+const (
+	identityContextKey          = "perses.identity"
+	permissionCheckerContextKey = "perses.permission_checker"
+	shareTokenContextKey        = "perses.share_token"
+)
+
+// ShareTokenHeader is the header used by the anonymous users to present a share token.
+const ShareTokenHeader = "X-Perses-Share-Token"
+
+// Identity describes the user sending the request.
+type Identity struct {
+	Username string
+	Email    string
+	Groups   []string
+}
+
+// SetIdentity attaches the identity of the user to the request.
+func SetIdentity(ctx echo.Context, identity *Identity) {
+	ctx.Set(identityContextKey, identity)
+}
+
+// GetIdentity returns the identity of the user sending the request. It returns nil when the request is anonymous.
+func GetIdentity(ctx echo.Context) *Identity {
+	identity, _ := ctx.Get(identityContextKey).(*Identity)
+	return identity
+}
+
+// PermissionChecker decides whether an identity has a given role on a project or on an organization.
+type PermissionChecker interface {
+	HasPermission(identity *Identity, project string, role v1.Role) bool
+	HasOrganizationPermission(identity *Identity, organization string, role v1.Role) bool
+}
+
+// SetPermissionChecker attaches to the request the checker used to verify the permissions of the user.
+// It is only set when the authorization is enabled.
+func SetPermissionChecker(ctx echo.Context, checker PermissionChecker) {
+	ctx.Set(permissionCheckerContextKey, checker)
+}
+
+// HasProjectPermission returns true when the user sending the request has the role on the project. It is used by the
+// requests that are not scoped to a project in their path, like the creation of a resource whose project is given by
+// the body, or the lists across all the projects. It always returns true when the authorization is not enabled.
+func HasProjectPermission(ctx echo.Context, project string, role v1.Role) bool {
+	checker, ok := ctx.Get(permissionCheckerContextKey).(PermissionChecker)
+	if !ok {
+		return true
+	}
+	identity := GetIdentity(ctx)
+	return identity != nil && checker.HasPermission(identity, project, role)
+}
+
+// HasOrganizationPermission returns true when the user sending the request has the role on the organization. It always
+// returns true when the authorization is not enabled.
+func HasOrganizationPermission(ctx echo.Context, organization string, role v1.Role) bool {
+	checker, ok := ctx.Get(permissionCheckerContextKey).(PermissionChecker)
+	if !ok {
+		return true
+	}
+	identity := GetIdentity(ctx)
+	return identity != nil && checker.HasOrganizationPermission(identity, organization, role)
+}
+
+// SetShareToken attaches to the request the share token presented by an anonymous user.
+func SetShareToken(ctx echo.Context, shareToken *v1.ShareToken) {
+	ctx.Set(shareTokenContextKey, shareToken)
+}
+
+// GetShareToken returns the valid share token presented with the request, or nil if there is none.
+func GetShareToken(ctx echo.Context) *v1.ShareToken {
+	shareToken, _ := ctx.Get(shareTokenContextKey).(*v1.ShareToken)
+	return shareToken
+}
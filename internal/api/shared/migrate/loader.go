@@ -90,9 +90,14 @@ func (c *migCuePart) getPlaceholder() string {
 }
 
 func (c *migCuePart) buildListOfConditions() (string, error) {
-	files, err := os.ReadDir(c.schemasPath)
-	if err != nil {
-		return "", err
+	var files []os.DirEntry
+	// a part without schemas path (e.g. the transformations not provided to the CLI) only contains the default conditional
+	if len(c.schemasPath) > 0 {
+		var err error
+		files, err = os.ReadDir(c.schemasPath)
+		if err != nil {
+			return "", err
+		}
 	}
 
 	var listOfConditions strings.Builder
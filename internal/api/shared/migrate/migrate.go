@@ -63,6 +63,10 @@ const (
 		}
 	`
 	queryPlaceholderText = "%(conditional_timeseries_queries)"
+
+	// the Grafana transformations without Perses equivalent are converted to an empty plugin, which is then dropped
+	transformationDefaultValue    = ""
+	transformationPlaceholderText = "%(conditional_transformations)"
 )
 
 func ReplaceInputValue(input map[string]string, grafanaDashboard string) string {
@@ -103,6 +107,12 @@ func New(schemasConf config.Schemas) (Migration, error) {
 				defaultValue:    queryDefaultValue,
 				placeholderText: queryPlaceholderText,
 			},
+			&migCuePart{
+				context:         cueContext,
+				schemasPath:     schemasConf.TransformationsPath,
+				defaultValue:    transformationDefaultValue,
+				placeholderText: transformationPlaceholderText,
+			},
 		},
 	}
 	if err := m.init(); err != nil {
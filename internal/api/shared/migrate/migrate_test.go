@@ -46,6 +46,24 @@ func TestMigrate(t *testing.T) {
 			expectedPersesDashboardFile: "old_grafana_panels_perses_dashboard.json",
 			expectedErrorStr:            "",
 		},
+		{
+			title:                       "dashboard with query options (time shift, relative time, min interval, max data points)",
+			inputGrafanaDashboardFile:   "query_options_grafana_dashboard.json",
+			expectedPersesDashboardFile: "query_options_perses_dashboard.json",
+			expectedErrorStr:            "",
+		},
+		{
+			title:                       "dashboard with transformations (calculate field, filter by value, merge, reduce)",
+			inputGrafanaDashboardFile:   "transformations_grafana_dashboard.json",
+			expectedPersesDashboardFile: "transformations_perses_dashboard.json",
+			expectedErrorStr:            "",
+		},
+		{
+			title:                       "dashboard with Loki (label values variable, logs panel & log queries)",
+			inputGrafanaDashboardFile:   "loki_grafana_dashboard.json",
+			expectedPersesDashboardFile: "loki_perses_dashboard.json",
+			expectedErrorStr:            "",
+		},
 	}
 
 	for _, test := range testSuite {
@@ -56,9 +74,10 @@ func TestMigrate(t *testing.T) {
 			projectPath := testUtils.GetRepositoryPath()
 			svc, err := New(config.Schemas{
 				// use the real schemas for these tests
-				PanelsPath:    filepath.Join(projectPath, config.DefaultPanelsPath),
-				QueriesPath:   filepath.Join(projectPath, config.DefaultQueriesPath),
-				VariablesPath: filepath.Join(projectPath, config.DefaultVariablesPath),
+				PanelsPath:          filepath.Join(projectPath, config.DefaultPanelsPath),
+				QueriesPath:         filepath.Join(projectPath, config.DefaultQueriesPath),
+				VariablesPath:       filepath.Join(projectPath, config.DefaultVariablesPath),
+				TransformationsPath: filepath.Join(projectPath, config.DefaultTransformationsPath),
 			})
 			assert.NoError(t, err)
 
@@ -0,0 +1,197 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rename
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+
+	"github.com/perses/perses/pkg/model/api"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+// variableReferenceRegexp matches the two syntaxes used to reference a variable: $name and ${name...}
+var variableReferenceRegexp = regexp.MustCompile(`\$\{([a-zA-Z0-9_-]+)|\$([a-zA-Z0-9_-]+)`)
+
+// getDashboardSpec returns the definition of the dashboard carried by the entity, or nil when there is none.
+func getDashboardSpec(entity api.Entity) *v1.DashboardSpec {
+	switch e := entity.(type) {
+	case *v1.Dashboard:
+		return &e.Spec
+	case *v1.DashboardDraft:
+		return &e.Spec.Dashboard
+	case *v1.EphemeralDashboard:
+		return &e.Spec.Dashboard
+	case *v1.GlobalDashboard:
+		return &e.Spec
+	}
+	return nil
+}
+
+// getVariableSpec returns the spec of the variable, whatever its scope is.
+func getVariableSpec(entity api.Entity) *v1.VariableSpec {
+	switch e := entity.(type) {
+	case *v1.Variable:
+		return &e.Spec
+	case *v1.OrganizationVariable:
+		return &e.Spec
+	case *v1.GlobalVariable:
+		return &e.Spec
+	}
+	return nil
+}
+
+// getProject returns the project of the entity, or an empty string when the entity doesn't belong to a project.
+func getProject(entity api.Entity) string {
+	if metadata, ok := entity.GetMetadata().(*v1.ProjectMetadata); ok {
+		return metadata.Project
+	}
+	return ""
+}
+
+// definesDatasource returns true when the dashboard has its own datasource with the given name and kind, hiding the
+// datasources with the same name defined at a higher level.
+func definesDatasource(spec *v1.DashboardSpec, kind string, name string) bool {
+	datasource, ok := spec.Datasources[name]
+	return ok && datasource != nil && datasource.Plugin.Kind == kind
+}
+
+// definesVariable returns true when the dashboard has its own variable with the given name, hiding the variables
+// with the same name defined at a higher level.
+func definesVariable(spec *v1.DashboardSpec, name string) bool {
+	for _, variable := range spec.Variables {
+		if variable.Spec.GetName() == name {
+			return true
+		}
+	}
+	return false
+}
+
+// renameDatasourceReferences rewrites the datasource selectors pointing to the datasource having the given name and
+// the given plugin kind. It returns true when at least one selector has been rewritten.
+func renameDatasourceReferences(value interface{}, kind string, name string, newName string) (bool, error) {
+	return rewrite(value, func(node interface{}) (interface{}, bool) {
+		object, isObject := node.(map[string]interface{})
+		if !isObject {
+			return node, false
+		}
+		selector, isSelector := object["datasource"].(map[string]interface{})
+		if !isSelector || selector["kind"] != kind || selector["name"] != name {
+			return node, false
+		}
+		selector["name"] = newName
+		return node, true
+	})
+}
+
+// renameVariableReferences rewrites the references to the variable ($name or ${name}) in every string.
+// It returns true when at least one reference has been rewritten.
+func renameVariableReferences(value interface{}, name string, newName string) (bool, error) {
+	return rewriteStrings(value, func(str string) string {
+		return variableReferenceRegexp.ReplaceAllStringFunc(str, func(match string) string {
+			submatches := variableReferenceRegexp.FindStringSubmatch(match)
+			if submatches[1] == name {
+				return "${" + newName
+			}
+			if submatches[2] == name {
+				return "$" + newName
+			}
+			return match
+		})
+	})
+}
+
+// renameDashboardLinks rewrites the links to the dashboard (/projects/<project>/dashboards/<name>) in every string.
+// It returns true when at least one link has been rewritten.
+func renameDashboardLinks(value interface{}, project string, name string, newName string) (bool, error) {
+	linkRegexp := regexp.MustCompile(fmt.Sprintf(`(/projects/%s/dashboards/)%s([^a-zA-Z0-9_.:-]|$)`, regexp.QuoteMeta(project), regexp.QuoteMeta(name)))
+	replacement := fmt.Sprintf("${1}%s${2}", newName)
+	return rewriteStrings(value, func(str string) string {
+		return linkRegexp.ReplaceAllString(str, replacement)
+	})
+}
+
+// renameFolderDashboard makes the references of the folder to the dashboard point to its new name.
+func renameFolderDashboard(specs []v1.FolderSpec, name string, newName string) bool {
+	changed := false
+	for i := range specs {
+		if specs[i].Kind == v1.KindDashboard && specs[i].Name == name {
+			specs[i].Name = newName
+			changed = true
+		} else if specs[i].Kind == v1.KindFolder {
+			changed = renameFolderDashboard(specs[i].Spec, name, newName) || changed
+		}
+	}
+	return changed
+}
+
+func rewriteStrings(value interface{}, replace func(str string) string) (bool, error) {
+	return rewrite(value, func(node interface{}) (interface{}, bool) {
+		str, isString := node.(string)
+		if !isString {
+			return node, false
+		}
+		result := replace(str)
+		return result, result != str
+	})
+}
+
+// rewrite decodes value into a generic JSON document, applies visit on every node of the document, and decodes back
+// the result into value when something has changed.
+func rewrite(value interface{}, visit func(node interface{}) (interface{}, bool)) (bool, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return false, err
+	}
+	var document interface{}
+	if unmarshalErr := json.Unmarshal(data, &document); unmarshalErr != nil {
+		return false, unmarshalErr
+	}
+	result, changed := walk(document, visit)
+	if !changed {
+		return false, nil
+	}
+	data, err = json.Marshal(result)
+	if err != nil {
+		return false, err
+	}
+	// reset the value, otherwise the maps decoded would be merged with the existing ones
+	target := reflect.ValueOf(value).Elem()
+	target.Set(reflect.Zero(target.Type()))
+	return true, json.Unmarshal(data, value)
+}
+
+func walk(node interface{}, visit func(node interface{}) (interface{}, bool)) (interface{}, bool) {
+	changed := false
+	switch n := node.(type) {
+	case map[string]interface{}:
+		for key, child := range n {
+			if result, childChanged := walk(child, visit); childChanged {
+				n[key] = result
+				changed = true
+			}
+		}
+	case []interface{}:
+		for i, child := range n {
+			if result, childChanged := walk(child, visit); childChanged {
+				n[i] = result
+				changed = true
+			}
+		}
+	}
+	result, visited := visit(node)
+	return result, visited || changed
+}
@@ -0,0 +1,90 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rename
+
+import (
+	"path/filepath"
+	"testing"
+
+	testUtils "github.com/perses/perses/internal/test"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func loadDashboard() *v1.Dashboard {
+	dashboard := &v1.Dashboard{}
+	testUtils.JSONUnmarshal(testUtils.ReadFile(filepath.Join("testdata", "dashboard.json")), dashboard)
+	return dashboard
+}
+
+func getQuery(dashboard *v1.Dashboard) map[string]interface{} {
+	return dashboard.Spec.Panels["cpu"].Spec.Queries[0].Spec.Plugin.Spec.(map[string]interface{})
+}
+
+func TestRenameDatasourceReferences(t *testing.T) {
+	dashboard := loadDashboard()
+	changed, err := renameDatasourceReferences(&dashboard.Spec, "TempoDatasource", "prom", "prometheus")
+	assert.NoError(t, err)
+	assert.False(t, changed)
+
+	changed, err = renameDatasourceReferences(&dashboard.Spec, "PrometheusDatasource", "prom", "prometheus")
+	assert.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, map[string]interface{}{"kind": "PrometheusDatasource", "name": "prometheus"}, getQuery(dashboard)["datasource"])
+	variableJSON := string(testUtils.JSONMarshalStrict(dashboard.Spec.Variables[0]))
+	assert.Contains(t, variableJSON, `"name":"prometheus"`)
+	assert.Equal(t, "instance", dashboard.Spec.Variables[0].Spec.GetName())
+	assert.True(t, definesVariable(&dashboard.Spec, "instance"))
+}
+
+func TestRenameVariableReferences(t *testing.T) {
+	dashboard := loadDashboard()
+	changed, err := renameVariableReferences(&dashboard.Spec, "unknown", "other")
+	assert.NoError(t, err)
+	assert.False(t, changed)
+
+	changed, err = renameVariableReferences(&dashboard.Spec, "job", "service")
+	assert.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, `rate(node_cpu_seconds_total{job="$service",instance="$instance",pod="$jobs"}[5m])`, getQuery(dashboard)["query"])
+	assert.Equal(t, "CPU of ${service}", dashboard.Spec.Panels["cpu"].Spec.Display.Name)
+	assert.Contains(t, string(testUtils.JSONMarshalStrict(dashboard.Spec.Variables[0])), `up{job=\"$service\"}`)
+}
+
+func TestRenameDashboardLinks(t *testing.T) {
+	dashboard := loadDashboard()
+	changed, err := renameDashboardLinks(&dashboard.Spec, "demo", "overview", "summary")
+	assert.NoError(t, err)
+	assert.False(t, changed)
+
+	changed, err = renameDashboardLinks(&dashboard.Spec, "perses", "overview", "summary")
+	assert.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, map[string]interface{}{
+		"text": "[Overview](/projects/perses/dashboards/summary?refresh=1m) [Overview details](/projects/perses/dashboards/overview-details)",
+	}, dashboard.Spec.Panels["links"].Spec.Plugin.Spec)
+}
+
+func TestRenameFolderDashboard(t *testing.T) {
+	spec := []v1.FolderSpec{
+		{Kind: v1.KindDashboard, Name: "overview"},
+		{Kind: v1.KindFolder, Name: "nodes", Spec: []v1.FolderSpec{{Kind: v1.KindDashboard, Name: "nodes"}}},
+	}
+	assert.False(t, renameFolderDashboard(spec, "unknown", "other"))
+	assert.True(t, renameFolderDashboard(spec, "nodes", "node-exporter"))
+	assert.Equal(t, []v1.FolderSpec{
+		{Kind: v1.KindDashboard, Name: "overview"},
+		{Kind: v1.KindFolder, Name: "nodes", Spec: []v1.FolderSpec{{Kind: v1.KindDashboard, Name: "node-exporter"}}},
+	}, spec)
+}
@@ -0,0 +1,729 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rename
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/interface/v1/access"
+	"github.com/perses/perses/internal/api/interface/v1/dashboard"
+	"github.com/perses/perses/internal/api/interface/v1/dashboardalias"
+	"github.com/perses/perses/internal/api/interface/v1/datasource"
+	"github.com/perses/perses/internal/api/interface/v1/draft"
+	"github.com/perses/perses/internal/api/interface/v1/ephemeraldashboard"
+	"github.com/perses/perses/internal/api/interface/v1/folder"
+	"github.com/perses/perses/internal/api/interface/v1/globaldashboard"
+	"github.com/perses/perses/internal/api/interface/v1/globaldatasource"
+	"github.com/perses/perses/internal/api/interface/v1/globaldatasourceoverride"
+	"github.com/perses/perses/internal/api/interface/v1/globalhomeconfig"
+	"github.com/perses/perses/internal/api/interface/v1/globalvariable"
+	"github.com/perses/perses/internal/api/interface/v1/homeconfig"
+	"github.com/perses/perses/internal/api/interface/v1/organizationdatasource"
+	"github.com/perses/perses/internal/api/interface/v1/organizationvariable"
+	"github.com/perses/perses/internal/api/interface/v1/playlist"
+	"github.com/perses/perses/internal/api/interface/v1/project"
+	"github.com/perses/perses/internal/api/interface/v1/savedview"
+	"github.com/perses/perses/internal/api/interface/v1/sharetoken"
+	"github.com/perses/perses/internal/api/interface/v1/usage"
+	"github.com/perses/perses/internal/api/interface/v1/variable"
+	"github.com/perses/perses/internal/api/shared"
+	databaseModel "github.com/perses/perses/internal/api/shared/database/model"
+	"github.com/perses/perses/pkg/model/api"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/sirupsen/logrus"
+)
+
+// Persistence is the part of the persistence manager used to find the references to the resources renamed.
+type Persistence interface {
+	GetAccess() access.DAO
+	GetDashboard() dashboard.DAO
+	GetDashboardAlias() dashboardalias.DAO
+	GetDatasource() datasource.DAO
+	GetDraft() draft.DAO
+	GetEphemeralDashboard() ephemeraldashboard.DAO
+	GetFolder() folder.DAO
+	GetGlobalDashboard() globaldashboard.DAO
+	GetGlobalDatasource() globaldatasource.DAO
+	GetGlobalDatasourceOverride() globaldatasourceoverride.DAO
+	GetGlobalHomeConfig() globalhomeconfig.DAO
+	GetGlobalVariable() globalvariable.DAO
+	GetHomeConfig() homeconfig.DAO
+	GetOrganizationDatasource() organizationdatasource.DAO
+	GetOrganizationVariable() organizationvariable.DAO
+	GetPersesDAO() databaseModel.DAO
+	GetPlaylist() playlist.DAO
+	GetProject() project.DAO
+	GetSavedView() savedview.DAO
+	GetShareToken() sharetoken.DAO
+	GetUsage() usage.DAO
+	GetVariable() variable.DAO
+}
+
+// Rename gives a new name to a resource and rewrites the references to it, so the rename doesn't break anything.
+// When something goes wrong, the changes already done are reverted.
+type Rename interface {
+	Dashboard(admitter shared.Admitter, project string, name string, request *v1.Rename) (*v1.Dashboard, error)
+	Datasource(admitter shared.Admitter, project string, name string, request *v1.Rename) (*v1.Datasource, error)
+	Variable(admitter shared.Admitter, project string, name string, request *v1.Rename) (*v1.Variable, error)
+	GlobalDatasource(admitter shared.Admitter, name string, request *v1.Rename) (*v1.GlobalDatasource, error)
+	GlobalVariable(admitter shared.Admitter, name string, request *v1.Rename) (*v1.GlobalVariable, error)
+}
+
+type renamer struct {
+	Rename
+	persistence Persistence
+	// newPersistence gives access to the resources through the database of a transaction.
+	newPersistence func(dao databaseModel.DAO) (Persistence, error)
+	// mutex serializes the renames, so two of them never rewrite the same references at the same time.
+	mutex sync.Mutex
+}
+
+// New returns the service renaming the resources. The database must support the transactions, so a rename is
+// applied entirely or not at all.
+func New(persistence Persistence, newPersistence func(dao databaseModel.DAO) (Persistence, error)) Rename {
+	return &renamer{
+		persistence:    persistence,
+		newPersistence: newPersistence,
+	}
+}
+
+func (r *renamer) Dashboard(admitter shared.Admitter, project string, name string, request *v1.Rename) (*v1.Dashboard, error) {
+	if err := checkRequest(v1.KindDashboard, name, request); err != nil {
+		return nil, err
+	}
+	err := r.run(admitter, func(r *renamer, tx *transaction) error {
+		dao := r.persistence.GetDashboard()
+		entity, err := dao.Get(project, name)
+		if err != nil {
+			return err
+		}
+		_, getErr := dao.Get(project, request.Name)
+		if availableErr := checkAvailable(v1.KindDashboard, request.Name, getErr); availableErr != nil {
+			return availableErr
+		}
+		if _, moveErr := tx.move(entity, request.Name); moveErr != nil {
+			return moveErr
+		}
+		if moveErr := r.moveDashboardResources(tx, project, name, request.Name); moveErr != nil {
+			return moveErr
+		}
+		if renameErr := r.renameDashboardReferences(tx, project, name, request.Name); renameErr != nil {
+			return renameErr
+		}
+		return r.updateDashboardAliases(tx, project, name, request)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.persistence.GetDashboard().Get(project, request.Name)
+}
+
+func (r *renamer) Datasource(admitter shared.Admitter, project string, name string, request *v1.Rename) (*v1.Datasource, error) {
+	if err := checkRequest(v1.KindDatasource, name, request); err != nil {
+		return nil, err
+	}
+	err := r.run(admitter, func(r *renamer, tx *transaction) error {
+		dao := r.persistence.GetDatasource()
+		entity, err := dao.Get(project, name)
+		if err != nil {
+			return err
+		}
+		_, getErr := dao.Get(project, request.Name)
+		if availableErr := checkAvailable(v1.KindDatasource, request.Name, getErr); availableErr != nil {
+			return availableErr
+		}
+		if _, moveErr := tx.move(entity, request.Name); moveErr != nil {
+			return moveErr
+		}
+		return r.renameDatasourceReferences(tx, project, &scope{}, entity.Spec.Plugin.Kind, name, request.Name)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.persistence.GetDatasource().Get(project, request.Name)
+}
+
+func (r *renamer) Variable(admitter shared.Admitter, project string, name string, request *v1.Rename) (*v1.Variable, error) {
+	if err := checkRequest(v1.KindVariable, name, request); err != nil {
+		return nil, err
+	}
+	err := r.run(admitter, func(r *renamer, tx *transaction) error {
+		dao := r.persistence.GetVariable()
+		entity, err := dao.Get(project, name)
+		if err != nil {
+			return err
+		}
+		_, getErr := dao.Get(project, request.Name)
+		if availableErr := checkAvailable(v1.KindVariable, request.Name, getErr); availableErr != nil {
+			return availableErr
+		}
+		if _, moveErr := tx.move(entity, request.Name); moveErr != nil {
+			return moveErr
+		}
+		return r.renameVariableReferences(tx, project, &scope{}, name, request.Name)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.persistence.GetVariable().Get(project, request.Name)
+}
+
+func (r *renamer) GlobalDatasource(admitter shared.Admitter, name string, request *v1.Rename) (*v1.GlobalDatasource, error) {
+	if err := checkRequest(v1.KindGlobalDatasource, name, request); err != nil {
+		return nil, err
+	}
+	err := r.run(admitter, func(r *renamer, tx *transaction) error {
+		dao := r.persistence.GetGlobalDatasource()
+		entity, err := dao.Get(name)
+		if err != nil {
+			return err
+		}
+		_, getErr := dao.Get(request.Name)
+		if availableErr := checkAvailable(v1.KindGlobalDatasource, request.Name, getErr); availableErr != nil {
+			return availableErr
+		}
+		if _, moveErr := tx.move(entity, request.Name); moveErr != nil {
+			return moveErr
+		}
+		kind := entity.Spec.Plugin.Kind
+		s, err := r.datasourceScope(kind, name)
+		if err != nil {
+			return err
+		}
+		if renameErr := r.renameDatasourceReferences(tx, "", s, kind, name, request.Name); renameErr != nil {
+			return renameErr
+		}
+		return r.moveOverrides(tx, name, request.Name)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.persistence.GetGlobalDatasource().Get(request.Name)
+}
+
+func (r *renamer) GlobalVariable(admitter shared.Admitter, name string, request *v1.Rename) (*v1.GlobalVariable, error) {
+	if err := checkRequest(v1.KindGlobalVariable, name, request); err != nil {
+		return nil, err
+	}
+	err := r.run(admitter, func(r *renamer, tx *transaction) error {
+		dao := r.persistence.GetGlobalVariable()
+		entity, err := dao.Get(name)
+		if err != nil {
+			return err
+		}
+		_, getErr := dao.Get(request.Name)
+		if availableErr := checkAvailable(v1.KindGlobalVariable, request.Name, getErr); availableErr != nil {
+			return availableErr
+		}
+		if _, moveErr := tx.move(entity, request.Name); moveErr != nil {
+			return moveErr
+		}
+		s, err := r.variableScope(name)
+		if err != nil {
+			return err
+		}
+		return r.renameVariableReferences(tx, "", s, name, request.Name)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.persistence.GetGlobalVariable().Get(request.Name)
+}
+
+// run executes apply in a database transaction. The renamer given to apply reads the resources through this
+// transaction, so it sees the changes already done. The changes are sent to the admission webhooks with admitter before
+// the transaction is committed.
+func (r *renamer) run(admitter shared.Admitter, apply func(r *renamer, tx *transaction) error) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	transactional, ok := r.persistence.GetPersesDAO().(databaseModel.Transactional)
+	if !ok {
+		return echo.NewHTTPError(http.StatusNotImplemented, "the database doesn't support the transactions required to rename a resource")
+	}
+	err := transactional.Transaction(func(dao databaseModel.DAO) error {
+		persistence, err := r.newPersistence(dao)
+		if err != nil {
+			return err
+		}
+		return apply(&renamer{persistence: persistence}, newTransaction(dao, admitter))
+	})
+	var rollbackErr *databaseModel.RollbackError
+	if errors.As(err, &rollbackErr) {
+		logrus.WithError(rollbackErr).Error("the rename failed and its changes could not be reverted")
+		return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("the rename failed and its changes could not be entirely reverted: %s", rollbackErr.RollbackErr))
+	}
+	return err
+}
+
+// moveDashboardResources renames the resources named after the dashboard: its grants, its draft and its usage statistics.
+func (r *renamer) moveDashboardResources(tx *transaction, project string, name string, newName string) error {
+	var entities []api.Entity
+	collect := func(entity api.Entity, err error) error {
+		if err == nil {
+			entities = append(entities, entity)
+			return nil
+		}
+		if databaseModel.IsKeyNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if err := collect(r.persistence.GetAccess().Get(project, name)); err != nil {
+		return err
+	}
+	if err := collect(r.persistence.GetDraft().Get(project, name)); err != nil {
+		return err
+	}
+	if err := collect(r.persistence.GetUsage().Get(project, name)); err != nil {
+		return err
+	}
+	for _, entity := range entities {
+		if _, err := tx.move(entity, newName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renameDashboardReferences rewrites the resources referencing the dashboard by its name: the share tokens, the saved
+// views, the playlists and the folders of the project, the home configs, and the links in the other dashboards.
+func (r *renamer) renameDashboardReferences(tx *transaction, project string, name string, newName string) error {
+	shareTokens, err := r.persistence.GetShareToken().List(&sharetoken.Query{Project: project})
+	if err != nil {
+		return err
+	}
+	for _, entity := range shareTokens {
+		if entity.Spec.Dashboard != name {
+			continue
+		}
+		if rewriteErr := tx.rewrite(entity, func(e api.Entity) (bool, error) {
+			e.(*v1.ShareToken).Spec.Dashboard = newName
+			return true, nil
+		}); rewriteErr != nil {
+			return rewriteErr
+		}
+	}
+	savedViews, err := r.persistence.GetSavedView().List(&savedview.Query{Project: project})
+	if err != nil {
+		return err
+	}
+	for _, entity := range savedViews {
+		if entity.Spec.Dashboard != name {
+			continue
+		}
+		if rewriteErr := tx.rewrite(entity, func(e api.Entity) (bool, error) {
+			e.(*v1.SavedView).Spec.Dashboard = newName
+			return true, nil
+		}); rewriteErr != nil {
+			return rewriteErr
+		}
+	}
+	playlists, err := r.persistence.GetPlaylist().List(&playlist.Query{Project: project})
+	if err != nil {
+		return err
+	}
+	for _, entity := range playlists {
+		if rewriteErr := tx.rewrite(entity, func(e api.Entity) (bool, error) {
+			changed := false
+			items := e.(*v1.Playlist).Spec.Items
+			for i := range items {
+				if items[i].Dashboard == name {
+					items[i].Dashboard = newName
+					changed = true
+				}
+			}
+			return changed, nil
+		}); rewriteErr != nil {
+			return rewriteErr
+		}
+	}
+	folders, err := r.persistence.GetFolder().List(&folder.Query{Project: project})
+	if err != nil {
+		return err
+	}
+	for _, entity := range folders {
+		if rewriteErr := tx.rewrite(entity, func(e api.Entity) (bool, error) {
+			return renameFolderDashboard(e.(*v1.Folder).Spec, name, newName), nil
+		}); rewriteErr != nil {
+			return rewriteErr
+		}
+	}
+	homeConfigs, err := r.persistence.GetHomeConfig().List(&homeconfig.Query{})
+	if err != nil {
+		return err
+	}
+	for _, entity := range homeConfigs {
+		if rewriteErr := tx.rewrite(entity, func(e api.Entity) (bool, error) {
+			return e.(*v1.HomeConfig).Spec.RenameDashboard(project, name, newName), nil
+		}); rewriteErr != nil {
+			return rewriteErr
+		}
+	}
+	globalHomeConfigs, err := r.persistence.GetGlobalHomeConfig().List(&globalhomeconfig.Query{})
+	if err != nil {
+		return err
+	}
+	for _, entity := range globalHomeConfigs {
+		if rewriteErr := tx.rewrite(entity, func(e api.Entity) (bool, error) {
+			return e.(*v1.GlobalHomeConfig).Spec.RenameDashboard(project, name, newName), nil
+		}); rewriteErr != nil {
+			return rewriteErr
+		}
+	}
+	return r.rewriteDashboards(tx, "", func(_ api.Entity, spec *v1.DashboardSpec) (bool, error) {
+		return renameDashboardLinks(spec, project, name, newName)
+	})
+}
+
+// updateDashboardAliases makes the aliases of the dashboard point to its new name and, when it is requested, keeps its
+// previous name as a new alias.
+func (r *renamer) updateDashboardAliases(tx *transaction, project string, name string, request *v1.Rename) error {
+	aliases, err := r.persistence.GetDashboardAlias().List(&dashboardalias.Query{Project: project})
+	if err != nil {
+		return err
+	}
+	for _, entity := range aliases {
+		if entity.Metadata.Name == request.Name {
+			// the alias is superseded by the dashboard having now this name
+			if deleteErr := tx.delete(entity); deleteErr != nil {
+				return deleteErr
+			}
+			continue
+		}
+		if entity.Spec.Dashboard != name {
+			continue
+		}
+		if rewriteErr := tx.rewrite(entity, func(e api.Entity) (bool, error) {
+			e.(*v1.DashboardAlias).Spec.Dashboard = request.Name
+			return true, nil
+		}); rewriteErr != nil {
+			return rewriteErr
+		}
+	}
+	if !request.KeepAlias {
+		return nil
+	}
+	alias := &v1.DashboardAlias{
+		Kind:     v1.KindDashboardAlias,
+		Metadata: *v1.NewProjectMetadata(project, name),
+		Spec:     v1.DashboardAliasSpec{Dashboard: request.Name},
+	}
+	alias.Metadata.CreateNow()
+	return tx.create(alias)
+}
+
+// renameDatasourceReferences rewrites the datasource selectors of the dashboards and of the variables of the project
+// (every project when empty, as well as the organization and the global resources), except the ones hidden by a
+// datasource having the same name.
+func (r *renamer) renameDatasourceReferences(tx *transaction, project string, s *scope, kind string, name string, newName string) error {
+	if err := r.rewriteDashboards(tx, project, func(entity api.Entity, spec *v1.DashboardSpec) (bool, error) {
+		if s.hides(getProject(entity)) || definesDatasource(spec, kind, name) {
+			return false, nil
+		}
+		return renameDatasourceReferences(spec, kind, name, newName)
+	}); err != nil {
+		return err
+	}
+	return r.rewriteVariables(tx, project, s, func(spec *v1.VariableSpec) (bool, error) {
+		return renameDatasourceReferences(spec, kind, name, newName)
+	})
+}
+
+// renameVariableReferences rewrites the references to the variable in the dashboards and in the variables of the
+// project (every project when empty, as well as the organization and the global resources), except the ones hidden by
+// a variable having the same name. The values of the variable saved in the views and in the share tokens are kept.
+func (r *renamer) renameVariableReferences(tx *transaction, project string, s *scope, name string, newName string) error {
+	// the dashboards using the variable renamed, as <project>/<dashboard>
+	dashboards := make(map[string]bool)
+	if err := r.rewriteDashboards(tx, project, func(entity api.Entity, spec *v1.DashboardSpec) (bool, error) {
+		if s.hides(getProject(entity)) || definesVariable(spec, name) {
+			return false, nil
+		}
+		if d, isDashboard := entity.(*v1.Dashboard); isDashboard {
+			dashboards[fmt.Sprintf("%s/%s", d.Metadata.Project, d.Metadata.Name)] = true
+		}
+		return renameVariableReferences(spec, name, newName)
+	}); err != nil {
+		return err
+	}
+	if err := r.rewriteVariables(tx, project, s, func(spec *v1.VariableSpec) (bool, error) {
+		return renameVariableReferences(spec, name, newName)
+	}); err != nil {
+		return err
+	}
+	savedViews, err := r.persistence.GetSavedView().List(&savedview.Query{Project: project})
+	if err != nil {
+		return err
+	}
+	for _, entity := range savedViews {
+		if !dashboards[fmt.Sprintf("%s/%s", entity.Metadata.Project, entity.Spec.Dashboard)] {
+			continue
+		}
+		if rewriteErr := tx.rewrite(entity, func(e api.Entity) (bool, error) {
+			variables := e.(*v1.SavedView).Spec.Variables
+			value, ok := variables[name]
+			if !ok {
+				return false, nil
+			}
+			delete(variables, name)
+			variables[newName] = value
+			return true, nil
+		}); rewriteErr != nil {
+			return rewriteErr
+		}
+	}
+	shareTokens, err := r.persistence.GetShareToken().List(&sharetoken.Query{Project: project})
+	if err != nil {
+		return err
+	}
+	for _, entity := range shareTokens {
+		if !dashboards[fmt.Sprintf("%s/%s", entity.Metadata.Project, entity.Spec.Dashboard)] {
+			continue
+		}
+		if rewriteErr := tx.rewrite(entity, func(e api.Entity) (bool, error) {
+			variables := e.(*v1.ShareToken).Spec.Variables
+			value, ok := variables[name]
+			if !ok {
+				return false, nil
+			}
+			delete(variables, name)
+			variables[newName] = value
+			return true, nil
+		}); rewriteErr != nil {
+			return rewriteErr
+		}
+	}
+	return nil
+}
+
+// moveOverrides renames the overrides of the global datasource. The overrides already having the new name were not
+// overriding anything, they are removed so they don't start to apply to the datasource renamed.
+func (r *renamer) moveOverrides(tx *transaction, name string, newName string) error {
+	overrides, err := r.persistence.GetGlobalDatasourceOverride().List(&globaldatasourceoverride.Query{})
+	if err != nil {
+		return err
+	}
+	for _, entity := range overrides {
+		if entity.Metadata.Name == newName {
+			if deleteErr := tx.delete(entity); deleteErr != nil {
+				return deleteErr
+			}
+		}
+	}
+	for _, entity := range overrides {
+		if entity.Metadata.Name == name {
+			if _, moveErr := tx.move(entity, newName); moveErr != nil {
+				return moveErr
+			}
+		}
+	}
+	return nil
+}
+
+// rewriteDashboards applies edit on the definition of the dashboards, the drafts and the ephemeral dashboards of the
+// project. When project is empty, the ones of every project are considered, as well as the global dashboards.
+func (r *renamer) rewriteDashboards(tx *transaction, project string, edit func(entity api.Entity, spec *v1.DashboardSpec) (bool, error)) error {
+	var entities []api.Entity
+	dashboards, err := r.persistence.GetDashboard().List(&dashboard.Query{Project: project})
+	if err != nil {
+		return err
+	}
+	for _, entity := range dashboards {
+		entities = append(entities, entity)
+	}
+	drafts, err := r.persistence.GetDraft().List(&draft.Query{Project: project})
+	if err != nil {
+		return err
+	}
+	for _, entity := range drafts {
+		entities = append(entities, entity)
+	}
+	ephemeralDashboards, err := r.persistence.GetEphemeralDashboard().List(&ephemeraldashboard.Query{Project: project})
+	if err != nil {
+		return err
+	}
+	for _, entity := range ephemeralDashboards {
+		entities = append(entities, entity)
+	}
+	if len(project) == 0 {
+		globalDashboards, listErr := r.persistence.GetGlobalDashboard().List(&globaldashboard.Query{})
+		if listErr != nil {
+			return listErr
+		}
+		for _, entity := range globalDashboards {
+			entities = append(entities, entity)
+		}
+	}
+	for _, entity := range entities {
+		if rewriteErr := tx.rewrite(entity, func(e api.Entity) (bool, error) {
+			return edit(e, getDashboardSpec(e))
+		}); rewriteErr != nil {
+			return rewriteErr
+		}
+	}
+	return nil
+}
+
+// rewriteVariables applies edit on the spec of the variables of the project not hidden by the scope. When project is
+// empty, the variables of every project are considered, as well as the organization and the global variables.
+func (r *renamer) rewriteVariables(tx *transaction, project string, s *scope, edit func(spec *v1.VariableSpec) (bool, error)) error {
+	var entities []api.Entity
+	variables, err := r.persistence.GetVariable().List(&variable.Query{Project: project})
+	if err != nil {
+		return err
+	}
+	for _, entity := range variables {
+		if !s.hides(entity.Metadata.Project) {
+			entities = append(entities, entity)
+		}
+	}
+	if len(project) == 0 {
+		organizationVariables, listErr := r.persistence.GetOrganizationVariable().List(&organizationvariable.Query{})
+		if listErr != nil {
+			return listErr
+		}
+		for _, entity := range organizationVariables {
+			if !s.organizations[entity.Metadata.Organization] {
+				entities = append(entities, entity)
+			}
+		}
+		globalVariables, listErr := r.persistence.GetGlobalVariable().List(&globalvariable.Query{})
+		if listErr != nil {
+			return listErr
+		}
+		for _, entity := range globalVariables {
+			entities = append(entities, entity)
+		}
+	}
+	for _, entity := range entities {
+		if rewriteErr := tx.rewrite(entity, func(e api.Entity) (bool, error) {
+			return edit(getVariableSpec(e))
+		}); rewriteErr != nil {
+			return rewriteErr
+		}
+	}
+	return nil
+}
+
+// datasourceScope returns the projects and the organizations having their own datasource with the name and the kind
+// of the global datasource.
+func (r *renamer) datasourceScope(kind string, name string) (*scope, error) {
+	s, err := r.newScope()
+	if err != nil {
+		return nil, err
+	}
+	datasources, err := r.persistence.GetDatasource().List(&datasource.Query{NamePrefix: name})
+	if err != nil {
+		return nil, err
+	}
+	for _, entity := range datasources {
+		if entity.Metadata.Name == name && entity.Spec.Plugin.Kind == kind {
+			s.projects[entity.Metadata.Project] = true
+		}
+	}
+	organizationDatasources, err := r.persistence.GetOrganizationDatasource().List(&organizationdatasource.Query{NamePrefix: name})
+	if err != nil {
+		return nil, err
+	}
+	for _, entity := range organizationDatasources {
+		if entity.Metadata.Name == name && entity.Spec.Plugin.Kind == kind {
+			s.organizations[entity.Metadata.Organization] = true
+		}
+	}
+	return s, nil
+}
+
+// variableScope returns the projects and the organizations having their own variable with the name of the global
+// variable.
+func (r *renamer) variableScope(name string) (*scope, error) {
+	s, err := r.newScope()
+	if err != nil {
+		return nil, err
+	}
+	variables, err := r.persistence.GetVariable().List(&variable.Query{NamePrefix: name})
+	if err != nil {
+		return nil, err
+	}
+	for _, entity := range variables {
+		if entity.Metadata.Name == name {
+			s.projects[entity.Metadata.Project] = true
+		}
+	}
+	organizationVariables, err := r.persistence.GetOrganizationVariable().List(&organizationvariable.Query{NamePrefix: name})
+	if err != nil {
+		return nil, err
+	}
+	for _, entity := range organizationVariables {
+		if entity.Metadata.Name == name {
+			s.organizations[entity.Metadata.Organization] = true
+		}
+	}
+	return s, nil
+}
+
+func (r *renamer) newScope() (*scope, error) {
+	projects, err := r.persistence.GetProject().List(&project.Query{})
+	if err != nil {
+		return nil, err
+	}
+	s := &scope{
+		projects:      make(map[string]bool),
+		organizations: make(map[string]bool),
+		organization:  make(map[string]string),
+	}
+	for _, entity := range projects {
+		s.organization[entity.Metadata.Name] = entity.Metadata.Organization
+	}
+	return s, nil
+}
+
+// scope is telling which projects and organizations have their own resource with the name of the global resource
+// renamed. The references made in these projects and organizations are pointing to their own resource, so they are
+// kept as they are.
+type scope struct {
+	projects      map[string]bool
+	organizations map[string]bool
+	// organization is the organization of each project
+	organization map[string]string
+}
+
+func (s *scope) hides(project string) bool {
+	return s.projects[project] || s.organizations[s.organization[project]]
+}
+
+func checkRequest(kind v1.Kind, name string, request *v1.Rename) error {
+	if request.Name == name {
+		return shared.HandleBadRequestError(fmt.Sprintf("the %s is already named %q", kind, name))
+	}
+	if request.KeepAlias && kind != v1.KindDashboard {
+		return shared.HandleBadRequestError(fmt.Sprintf("keep_alias is not supported by the %s", kind))
+	}
+	return nil
+}
+
+// checkAvailable returns an error when the new name is already used. err is the error returned when getting the
+// resource with the new name.
+func checkAvailable(kind v1.Kind, name string, err error) error {
+	if err == nil {
+		return echo.NewHTTPError(http.StatusConflict, fmt.Sprintf("a %s named %q already exists", kind, name))
+	}
+	if databaseModel.IsKeyNotFound(err) {
+		return nil
+	}
+	return err
+}
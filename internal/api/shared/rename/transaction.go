@@ -0,0 +1,112 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rename
+
+import (
+	"encoding/json"
+
+	"github.com/perses/perses/internal/api/shared"
+	databaseModel "github.com/perses/perses/internal/api/shared/database/model"
+	"github.com/perses/perses/pkg/model/api"
+	"github.com/perses/perses/pkg/model/api/admission"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+// transaction writes the changes of a rename in the database transaction given by the DAO, so the database
+// reverts them when the rename fails in the middle. Every change is sent to the admission webhooks before being
+// written: a webhook refusing one of them reverts the whole rename.
+type transaction struct {
+	dao      databaseModel.DAO
+	admitter shared.Admitter
+}
+
+func newTransaction(dao databaseModel.DAO, admitter shared.Admitter) *transaction {
+	return &transaction{dao: dao, admitter: admitter}
+}
+
+func (t *transaction) create(entity api.Entity) error {
+	if err := t.admitter.Admit(admission.OperationCreate, entity, nil, nil); err != nil {
+		return err
+	}
+	return t.dao.Create(entity)
+}
+
+func (t *transaction) update(current api.Entity, old api.Entity) error {
+	if err := t.admitter.Admit(admission.OperationUpdate, current, old, nil); err != nil {
+		return err
+	}
+	return t.dao.Upsert(current)
+}
+
+func (t *transaction) delete(entity api.Entity) error {
+	kind := v1.Kind(entity.GetKind())
+	if err := t.admitter.AdmitDeletion(kind, func() (api.Entity, error) { return entity, nil }); err != nil {
+		return err
+	}
+	return t.dao.Delete(kind, entity.GetMetadata())
+}
+
+// move gives a new name to the entity. The entity with the new name is returned.
+func (t *transaction) move(entity api.Entity, newName string) (api.Entity, error) {
+	renamed, err := copyEntity(entity)
+	if err != nil {
+		return nil, err
+	}
+	metadata := getMetadata(renamed)
+	metadata.Name = newName
+	metadata.Update(*metadata)
+	if createErr := t.create(renamed); createErr != nil {
+		return nil, createErr
+	}
+	return renamed, t.delete(entity)
+}
+
+// rewrite applies edit on a copy of the entity. The copy is saved when edit returns true.
+func (t *transaction) rewrite(entity api.Entity, edit func(entity api.Entity) (bool, error)) error {
+	result, err := copyEntity(entity)
+	if err != nil {
+		return err
+	}
+	changed, err := edit(result)
+	if err != nil || !changed {
+		return err
+	}
+	metadata := getMetadata(result)
+	metadata.Update(*metadata)
+	return t.update(result, entity)
+}
+
+func copyEntity(entity api.Entity) (api.Entity, error) {
+	result, err := v1.GetStruct(v1.Kind(entity.GetKind()))
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(entity)
+	if err != nil {
+		return nil, err
+	}
+	return result, json.Unmarshal(data, result)
+}
+
+func getMetadata(entity api.Entity) *v1.Metadata {
+	switch metadata := entity.GetMetadata().(type) {
+	case *v1.ProjectMetadata:
+		return &metadata.Metadata
+	case *v1.OrganizationMetadata:
+		return &metadata.Metadata
+	case *v1.Metadata:
+		return metadata
+	}
+	return nil
+}
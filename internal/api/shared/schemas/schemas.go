@@ -66,9 +66,11 @@ type Schemas interface {
 	ValidateDatasource(plugin common.Plugin) error
 	ValidatePanels(panels map[string]*modelV1.Panel) error
 	ValidatePanel(plugin common.Plugin, panelName string) error
+	ValidateQuery(plugin common.Plugin) error
 	ValidateGlobalVariable(v modelV1.VariableSpec) error
 	ValidateDashboardVariables([]dashboard.Variable) error
 	ValidateVariable(plugin common.Plugin, varName string) error
+	ValidateTransformation(plugin common.Plugin, panelName string) error
 	GetLoaders() []Loader
 }
 
@@ -116,6 +118,15 @@ func New(conf config.Schemas) (Schemas, error) {
 		loaders = append(loaders, vars)
 		s.vars = vars
 	}
+	if len(conf.TransformationsPath) != 0 {
+		transformations := &cueDefs{
+			context:     ctx,
+			schemas:     &sync.Map{},
+			schemasPath: conf.TransformationsPath,
+		}
+		loaders = append(loaders, transformations)
+		s.transformations = transformations
+	}
 	s.loaders = loaders
 	if err := s.init(); err != nil {
 		return nil, err
@@ -125,12 +136,13 @@ func New(conf config.Schemas) (Schemas, error) {
 
 type sch struct {
 	Schemas
-	context *cue.Context
-	panels  *cueDefs
-	dts     *cueDefs
-	vars    *cueDefs
-	queries *cueDefs
-	loaders []Loader
+	context         *cue.Context
+	panels          *cueDefs
+	dts             *cueDefs
+	vars            *cueDefs
+	queries         *cueDefs
+	transformations *cueDefs
+	loaders         []Loader
 }
 
 func (s *sch) GetLoaders() []Loader {
@@ -161,10 +173,20 @@ func (s *sch) ValidatePanels(panels map[string]*modelV1.Panel) error {
 			return err
 		}
 		for _, query := range panel.Spec.Queries {
+			// the references to the shared queries are checked when the dashboard is decoded, and the shared queries are
+			// validated on their own
+			if query.Ref != nil {
+				continue
+			}
 			if err := s.ValidateQuery(query.Spec.Plugin); err != nil {
 				return err
 			}
 		}
+		for _, transformation := range panel.Spec.Transformations {
+			if err := s.ValidateTransformation(transformation, panelName); err != nil {
+				return err
+			}
+		}
 	}
 	logrus.Debug("All panels are valid")
 	return nil
@@ -175,6 +197,10 @@ func (s *sch) ValidatePanel(plugin common.Plugin, panelName string) error {
 }
 
 func (s *sch) ValidateQuery(plugin common.Plugin) error {
+	if s.queries == nil {
+		logrus.Warning("query schemas are not loaded")
+		return nil
+	}
 	return s.validatePlugin(plugin, "query", "", s.queries)
 }
 
@@ -225,6 +251,14 @@ func (s *sch) ValidateVariable(plugin common.Plugin, variableName string) error
 	return s.validatePlugin(plugin, "variable", variableName, s.vars)
 }
 
+func (s *sch) ValidateTransformation(plugin common.Plugin, panelName string) error {
+	if s.transformations == nil {
+		logrus.Warning("transformation schemas are not loaded")
+		return nil
+	}
+	return s.validatePlugin(plugin, "transformation", panelName, s.transformations)
+}
+
 func (s *sch) validatePlugin(plugin common.Plugin, modelKind string, modelName string, cueDefs *cueDefs) error {
 	pluginData, err := plugin.JSONMarshal()
 	if err != nil {
@@ -14,29 +14,36 @@
 package shared
 
 import (
+	"fmt"
 	"net/http"
+	"reflect"
 
 	"github.com/labstack/echo/v4"
 	databaseModel "github.com/perses/perses/internal/api/shared/database/model"
 	"github.com/perses/perses/pkg/model/api"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
 )
 
 type Parameters struct {
-	Project string
-	Name    string
+	Organization string
+	Project      string
+	Name         string
 }
 
 func extractParameters(ctx echo.Context) Parameters {
 	return Parameters{
-		Project: GetProjectParameter(ctx),
-		Name:    getNameParameter(ctx),
+		Organization: GetOrganizationParameter(ctx),
+		Project:      GetProjectParameter(ctx),
+		Name:         getNameParameter(ctx),
 	}
 }
 
+// ToolboxService is the service behind the toolbox. The writes receive the admitter of the user sending the request,
+// the service calls it once the resource is validated.
 type ToolboxService interface {
-	Create(entity api.Entity) (interface{}, error)
-	Update(entity api.Entity, parameters Parameters) (interface{}, error)
-	Delete(parameters Parameters) error
+	Create(admitter Admitter, entity api.Entity) (interface{}, error)
+	Update(admitter Admitter, entity api.Entity, parameters Parameters) (interface{}, error)
+	Delete(admitter Admitter, parameters Parameters) error
 	Get(parameters Parameters) (interface{}, error)
 	List(q databaseModel.Query, parameters Parameters) (interface{}, error)
 }
@@ -66,7 +73,18 @@ func (t *toolbox) Create(ctx echo.Context, entity api.Entity) error {
 	if err := t.bind(ctx, entity); err != nil {
 		return err
 	}
-	newEntity, err := t.service.Create(entity)
+	// When the project is not part of the path, it comes from the body, and so it is only known once the body is decoded.
+	if metadata, ok := entity.GetMetadata().(*v1.ProjectMetadata); ok && len(GetProjectParameter(ctx)) == 0 {
+		role := v1.RoleEditor
+		if v1.Kind(entity.GetKind()) == v1.KindGlobalDatasourceOverride {
+			// an override changes a datasource shared with the other projects
+			role = v1.RoleAdmin
+		}
+		if !HasProjectPermission(ctx, metadata.Project, role) {
+			return echo.NewHTTPError(http.StatusForbidden, fmt.Sprintf("the role %s on the project %q is required", role, metadata.Project))
+		}
+	}
+	newEntity, err := t.service.Create(NewAdmitter(ctx), entity)
 	if err != nil {
 		return err
 	}
@@ -78,7 +96,7 @@ func (t *toolbox) Update(ctx echo.Context, entity api.Entity) error {
 		return err
 	}
 	parameters := extractParameters(ctx)
-	newEntity, err := t.service.Update(entity, parameters)
+	newEntity, err := t.service.Update(NewAdmitter(ctx), entity, parameters)
 	if err != nil {
 		return err
 	}
@@ -87,7 +105,7 @@ func (t *toolbox) Update(ctx echo.Context, entity api.Entity) error {
 
 func (t *toolbox) Delete(ctx echo.Context) error {
 	parameters := extractParameters(ctx)
-	if err := t.service.Delete(parameters); err != nil {
+	if err := t.service.Delete(NewAdmitter(ctx), parameters); err != nil {
 		return err
 	}
 	return ctx.NoContent(http.StatusNoContent)
@@ -111,9 +129,46 @@ func (t *toolbox) List(ctx echo.Context, q databaseModel.Query) error {
 	if err != nil {
 		return err
 	}
+	if len(parameters.Project) == 0 {
+		result = filterReadableEntities(ctx, result)
+	}
 	return ctx.JSON(http.StatusOK, result)
 }
 
+// filterReadableEntities removes from the list the resources of the projects the user is not allowed to read.
+// The resources that don't belong to a project are kept.
+func filterReadableEntities(ctx echo.Context, list interface{}) interface{} {
+	value := reflect.ValueOf(list)
+	if value.Kind() != reflect.Slice {
+		return list
+	}
+	result := reflect.MakeSlice(value.Type(), 0, value.Len())
+	for i := 0; i < value.Len(); i++ {
+		item := value.Index(i)
+		if project, ok := item.Interface().(*v1.Project); ok && !HasProjectPermission(ctx, project.Metadata.Name, v1.RoleViewer) {
+			continue
+		}
+		if entity, ok := item.Interface().(api.Entity); ok {
+			if metadata, isProject := entity.GetMetadata().(*v1.ProjectMetadata); isProject && !HasProjectPermission(ctx, metadata.Project, v1.RoleViewer) {
+				continue
+			}
+		}
+		result = reflect.Append(result, item)
+	}
+	return result.Interface()
+}
+
+// FilterByProjectPermission keeps the items of the list belonging to a project on which the user has the role.
+func FilterByProjectPermission[T any](ctx echo.Context, list []T, role v1.Role, getProject func(T) string) []T {
+	result := make([]T, 0, len(list))
+	for _, item := range list {
+		if HasProjectPermission(ctx, getProject(item), role) {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
 func (t *toolbox) bind(ctx echo.Context, entity api.Entity) error {
 	if err := ctx.Bind(entity); err != nil {
 		return HandleBadRequestError(err.Error())
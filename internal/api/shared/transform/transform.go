@@ -0,0 +1,452 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package transform is the evaluation engine of the panel transformations. It must produce the same output as the
+// implementation of the UI (ui/plugin-system/src/model/transformations.ts).
+package transform
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/perses/perses/pkg/model/api/transform"
+	"github.com/perses/perses/pkg/model/api/v1/common"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	calculationFirst      = "First"
+	calculationLast       = "Last"
+	calculationLastNumber = "LastNumber"
+	calculationMean       = "Mean"
+	calculationSum        = "Sum"
+)
+
+type renameLabelsSpec struct {
+	// Labels maps the current name of a label to its new name. An empty new name removes the label.
+	Labels map[string]string `yaml:"labels"`
+}
+
+type filterByValueSpec struct {
+	Operator string  `yaml:"operator"`
+	Value    float64 `yaml:"value"`
+	// Calculation is optional. When it is set, the series are filtered according to their calculated value.
+	// Otherwise, the data points are filtered one by one.
+	Calculation string `yaml:"calculation"`
+}
+
+type binaryOperationSpec struct {
+	// Left and Right are the index of the queries used as operands.
+	Left     int    `yaml:"left"`
+	Right    int    `yaml:"right"`
+	Operator string `yaml:"operator"`
+	// Name is the name of the series computed. By default, it is "<left name> <operator> <right name>".
+	Name string `yaml:"name"`
+	// KeepOperands keeps the results of the operands. The result of the operation is then added after the other ones.
+	KeepOperands bool `yaml:"keep_operands"`
+}
+
+type reduceToTableSpec struct {
+	Calculations []string `yaml:"calculations"`
+}
+
+// Apply applies the transformations in order on the data of a panel.
+func Apply(transformations []common.Plugin, data transform.Data) (*transform.Data, error) {
+	result := &data
+	for i, transformation := range transformations {
+		var err error
+		switch transformation.Kind {
+		case transform.KindMergeSeries:
+			result = mergeSeries(result)
+		case transform.KindRenameLabels:
+			spec := &renameLabelsSpec{}
+			if err = decodeSpec(transformation.Spec, spec); err == nil {
+				result = renameLabels(result, spec)
+			}
+		case transform.KindFilterByValue:
+			spec := &filterByValueSpec{}
+			if err = decodeSpec(transformation.Spec, spec); err == nil {
+				result, err = filterByValue(result, spec)
+			}
+		case transform.KindBinaryOperation:
+			spec := &binaryOperationSpec{}
+			if err = decodeSpec(transformation.Spec, spec); err == nil {
+				result, err = binaryOperation(result, spec)
+			}
+		case transform.KindReduceToTable:
+			spec := &reduceToTableSpec{}
+			if err = decodeSpec(transformation.Spec, spec); err == nil {
+				result, err = reduceToTable(result, spec)
+			}
+		default:
+			err = fmt.Errorf("unknown kind %q", transformation.Kind)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("transformation %d: %w", i, err)
+		}
+	}
+	return result, nil
+}
+
+func mergeSeries(data *transform.Data) *transform.Data {
+	if len(data.Results) == 0 {
+		return data
+	}
+	merged := []transform.Series{}
+	for _, r := range data.Results {
+		for _, series := range r.Series {
+			index := -1
+			for j := range merged {
+				if merged[j].Name == series.Name && sameLabels(merged[j].Labels, series.Labels) {
+					index = j
+					break
+				}
+			}
+			if index < 0 {
+				merged = append(merged, transform.Series{Name: series.Name, Labels: series.Labels, Values: series.Values})
+				continue
+			}
+			merged[index].Values = mergePoints(merged[index].Values, series.Values)
+		}
+	}
+	return &transform.Data{Results: []transform.Result{{Series: merged}}, Table: data.Table}
+}
+
+// mergePoints returns the points of both lists sorted by timestamp.
+// When both lists have a point at the same time, the one of the second list is kept.
+func mergePoints(a []transform.Point, b []transform.Point) []transform.Point {
+	byTimestamp := make(map[int64]transform.Point, len(a)+len(b))
+	for _, p := range a {
+		byTimestamp[p.Timestamp] = p
+	}
+	for _, p := range b {
+		byTimestamp[p.Timestamp] = p
+	}
+	result := make([]transform.Point, 0, len(byTimestamp))
+	for _, p := range byTimestamp {
+		result = append(result, p)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Timestamp < result[j].Timestamp
+	})
+	return result
+}
+
+func renameLabels(data *transform.Data, spec *renameLabelsSpec) *transform.Data {
+	result := &transform.Data{Table: data.Table}
+	for _, r := range data.Results {
+		newResult := transform.Result{Series: []transform.Series{}}
+		for _, series := range r.Series {
+			newResult.Series = append(newResult.Series, transform.Series{
+				Name:   series.Name,
+				Labels: renameSeriesLabels(series.Labels, spec.Labels),
+				Values: series.Values,
+			})
+		}
+		result.Results = append(result.Results, newResult)
+	}
+	return result
+}
+
+// renameSeriesLabels renames the labels. When a renamed label is colliding with an existing one, the renamed label
+// wins. When two labels are renamed with the same name, the last one in alphabetical order wins.
+func renameSeriesLabels(labels map[string]string, renaming map[string]string) map[string]string {
+	if len(labels) == 0 {
+		return labels
+	}
+	result := make(map[string]string, len(labels))
+	var renamed []string
+	for name, value := range labels {
+		if _, ok := renaming[name]; ok {
+			renamed = append(renamed, name)
+			continue
+		}
+		result[name] = value
+	}
+	sort.Strings(renamed)
+	for _, name := range renamed {
+		if newName := renaming[name]; len(newName) > 0 {
+			result[newName] = labels[name]
+		}
+	}
+	return result
+}
+
+func filterByValue(data *transform.Data, spec *filterByValueSpec) (*transform.Data, error) {
+	compare, err := getComparison(spec.Operator)
+	if err != nil {
+		return nil, err
+	}
+	var calculate func([]transform.Point) (float64, bool)
+	if len(spec.Calculation) > 0 {
+		if calculate, err = getCalculation(spec.Calculation); err != nil {
+			return nil, err
+		}
+	}
+	result := &transform.Data{Table: data.Table}
+	for _, r := range data.Results {
+		newResult := transform.Result{Series: []transform.Series{}}
+		for _, series := range r.Series {
+			if calculate != nil {
+				if value, ok := calculate(series.Values); ok && compare(value, spec.Value) {
+					newResult.Series = append(newResult.Series, series)
+				}
+				continue
+			}
+			values := []transform.Point{}
+			for _, p := range series.Values {
+				if p.Value != nil && compare(*p.Value, spec.Value) {
+					values = append(values, p)
+				}
+			}
+			newResult.Series = append(newResult.Series, transform.Series{Name: series.Name, Labels: series.Labels, Values: values})
+		}
+		result.Results = append(result.Results, newResult)
+	}
+	return result, nil
+}
+
+func binaryOperation(data *transform.Data, spec *binaryOperationSpec) (*transform.Data, error) {
+	if spec.Left < 0 || spec.Left >= len(data.Results) {
+		return nil, fmt.Errorf("there is no result for the query %d", spec.Left)
+	}
+	if spec.Right < 0 || spec.Right >= len(data.Results) {
+		return nil, fmt.Errorf("there is no result for the query %d", spec.Right)
+	}
+	operate, err := getOperation(spec.Operator)
+	if err != nil {
+		return nil, err
+	}
+	left := data.Results[spec.Left].Series
+	right := data.Results[spec.Right].Series
+	computed := transform.Result{Series: []transform.Series{}}
+	for _, l := range left {
+		for _, r := range right {
+			// a single series on each side is always matching, otherwise the series are matched by labels
+			if !(len(left) == 1 && len(right) == 1) && !sameLabels(l.Labels, r.Labels) {
+				continue
+			}
+			name := spec.Name
+			if len(name) == 0 {
+				name = fmt.Sprintf("%s %s %s", l.Name, spec.Operator, r.Name)
+			}
+			computed.Series = append(computed.Series, transform.Series{
+				Name:   name,
+				Labels: l.Labels,
+				Values: operatePoints(l.Values, r.Values, operate),
+			})
+		}
+	}
+	result := &transform.Data{Table: data.Table}
+	if spec.KeepOperands {
+		result.Results = append(append(result.Results, data.Results...), computed)
+		return result, nil
+	}
+	for i, r := range data.Results {
+		switch i {
+		case spec.Left:
+			result.Results = append(result.Results, computed)
+		case spec.Right:
+		default:
+			result.Results = append(result.Results, r)
+		}
+	}
+	return result, nil
+}
+
+// operatePoints applies the operation on the points having the same timestamp on both side.
+func operatePoints(left []transform.Point, right []transform.Point, operate func(float64, float64) float64) []transform.Point {
+	rightByTimestamp := make(map[int64]transform.Point, len(right))
+	for _, p := range right {
+		rightByTimestamp[p.Timestamp] = p
+	}
+	result := []transform.Point{}
+	for _, l := range left {
+		r, ok := rightByTimestamp[l.Timestamp]
+		if !ok {
+			continue
+		}
+		if l.Value == nil || r.Value == nil {
+			result = append(result, transform.Point{Timestamp: l.Timestamp})
+			continue
+		}
+		result = append(result, transform.NewPoint(l.Timestamp, operate(*l.Value, *r.Value)))
+	}
+	return result
+}
+
+func reduceToTable(data *transform.Data, spec *reduceToTableSpec) (*transform.Data, error) {
+	if len(spec.Calculations) == 0 {
+		return nil, fmt.Errorf("at least one calculation is required")
+	}
+	calculations := make([]func([]transform.Point) (float64, bool), 0, len(spec.Calculations))
+	for _, name := range spec.Calculations {
+		calculate, err := getCalculation(name)
+		if err != nil {
+			return nil, err
+		}
+		calculations = append(calculations, calculate)
+	}
+	labelSet := make(map[string]bool)
+	for _, r := range data.Results {
+		for _, series := range r.Series {
+			for name := range series.Labels {
+				labelSet[name] = true
+			}
+		}
+	}
+	labels := make([]string, 0, len(labelSet))
+	for name := range labelSet {
+		labels = append(labels, name)
+	}
+	sort.Strings(labels)
+	table := &transform.Table{Columns: append(append([]string{"name"}, labels...), spec.Calculations...), Rows: [][]interface{}{}}
+	for _, r := range data.Results {
+		for _, series := range r.Series {
+			row := []interface{}{series.Name}
+			for _, name := range labels {
+				row = append(row, series.Labels[name])
+			}
+			for _, calculate := range calculations {
+				// JSON has no infinite value, it is serialized as null like JSON.stringify does in the UI
+				if value, ok := calculate(series.Values); ok && !math.IsInf(value, 0) {
+					row = append(row, value)
+				} else {
+					row = append(row, nil)
+				}
+			}
+			table.Rows = append(table.Rows, row)
+		}
+	}
+	return &transform.Data{Results: []transform.Result{}, Table: table}, nil
+}
+
+func getComparison(operator string) (func(float64, float64) bool, error) {
+	switch operator {
+	case ">":
+		return func(a, b float64) bool { return a > b }, nil
+	case ">=":
+		return func(a, b float64) bool { return a >= b }, nil
+	case "<":
+		return func(a, b float64) bool { return a < b }, nil
+	case "<=":
+		return func(a, b float64) bool { return a <= b }, nil
+	case "==":
+		return func(a, b float64) bool { return a == b }, nil
+	case "!=":
+		return func(a, b float64) bool { return a != b }, nil
+	}
+	return nil, fmt.Errorf("operator %q is not supported", operator)
+}
+
+func getOperation(operator string) (func(float64, float64) float64, error) {
+	switch operator {
+	case "+":
+		return func(a, b float64) float64 { return a + b }, nil
+	case "-":
+		return func(a, b float64) float64 { return a - b }, nil
+	case "*":
+		return func(a, b float64) float64 { return a * b }, nil
+	case "/":
+		// a division by zero gives an infinite value that is then considered as missing
+		return func(a, b float64) float64 { return a / b }, nil
+	}
+	return nil, fmt.Errorf("operator %q is not supported", operator)
+}
+
+// getCalculation returns the function computing the calculation. Like in the UI, a missing value is considered as NaN,
+// and the function returns false when the result is NaN. An infinite result is kept.
+func getCalculation(name string) (func([]transform.Point) (float64, bool), error) {
+	var calculate func([]transform.Point) float64
+	switch name {
+	case calculationFirst:
+		calculate = func(values []transform.Point) float64 {
+			if len(values) == 0 {
+				return math.NaN()
+			}
+			return getValue(values[0])
+		}
+	case calculationLast:
+		calculate = func(values []transform.Point) float64 {
+			if len(values) == 0 {
+				return math.NaN()
+			}
+			return getValue(values[len(values)-1])
+		}
+	case calculationLastNumber:
+		calculate = func(values []transform.Point) float64 {
+			for i := len(values) - 1; i >= 0; i-- {
+				if v := getValue(values[i]); !math.IsNaN(v) {
+					return v
+				}
+			}
+			return math.NaN()
+		}
+	case calculationMean:
+		calculate = func(values []transform.Point) float64 {
+			if len(values) == 0 {
+				return math.NaN()
+			}
+			return sum(values) / float64(len(values))
+		}
+	case calculationSum:
+		calculate = func(values []transform.Point) float64 {
+			if len(values) == 0 {
+				return math.NaN()
+			}
+			return sum(values)
+		}
+	default:
+		return nil, fmt.Errorf("calculation %q is not supported", name)
+	}
+	return func(values []transform.Point) (float64, bool) {
+		v := calculate(values)
+		return v, !math.IsNaN(v)
+	}, nil
+}
+
+func sum(values []transform.Point) float64 {
+	result := 0.0
+	for _, p := range values {
+		result += getValue(p)
+	}
+	return result
+}
+
+func getValue(p transform.Point) float64 {
+	if p.Value == nil {
+		return math.NaN()
+	}
+	return *p.Value
+}
+
+func sameLabels(a map[string]string, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if value, ok := b[k]; !ok || value != v {
+			return false
+		}
+	}
+	return true
+}
+
+func decodeSpec(spec interface{}, obj interface{}) error {
+	data, err := yaml.Marshal(spec)
+	if err != nil {
+		return err
+	}
+	return yaml.UnmarshalStrict(data, obj)
+}
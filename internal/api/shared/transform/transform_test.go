@@ -0,0 +1,64 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	testUtils "github.com/perses/perses/internal/test"
+	"github.com/perses/perses/pkg/model/api/transform"
+	"github.com/perses/perses/pkg/model/api/v1/common"
+	"github.com/stretchr/testify/assert"
+)
+
+// goldenFile contains the cases tested against both the engine and the implementation of the UI, so they are sure to
+// produce the same output.
+type goldenFile struct {
+	Data  transform.Data `json:"data"`
+	Cases []struct {
+		Title string `json:"title"`
+		// Data replaces the default data of the file when it is set.
+		Data            *transform.Data `json:"data"`
+		Transformations []common.Plugin `json:"transformations"`
+		Expected        json.RawMessage `json:"expected"`
+		Error           string          `json:"error"`
+	} `json:"cases"`
+}
+
+func TestApply(t *testing.T) {
+	golden := &goldenFile{}
+	content := testUtils.ReadFile(filepath.Join(testUtils.GetRepositoryPath(), "ui", "plugin-system", "src", "model", "transformations.golden.json"))
+	if err := json.Unmarshal(content, golden); err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range golden.Cases {
+		t.Run(test.Title, func(t *testing.T) {
+			data := golden.Data
+			if test.Data != nil {
+				data = *test.Data
+			}
+			result, err := Apply(test.Transformations, data)
+			if len(test.Error) > 0 {
+				assert.EqualError(t, err, test.Error)
+				return
+			}
+			assert.NoError(t, err)
+			output, err := json.Marshal(result)
+			assert.NoError(t, err)
+			assert.JSONEq(t, string(test.Expected), string(output))
+		})
+	}
+}
@@ -24,21 +24,35 @@ import (
 )
 
 const (
-	ParamName            = "name"
-	ParamProject         = "project"
-	APIV1Prefix          = "/api/v1"
-	PathDashboard        = "dashboards"
-	PathDatasource       = "datasources"
-	PathFolder           = "folders"
-	PathGlobalDatasource = "globaldatasources"
-	PathGlobalVariable   = "globalvariables"
-	PathProject          = "projects"
-	PathVariable         = "variables"
+	ParamName                    = "name"
+	ParamOrganization            = "organization"
+	ParamProject                 = "project"
+	APIV1Prefix                  = "/api/v1"
+	PathDashboard                = "dashboards"
+	PathDatasource               = "datasources"
+	PathDraft                    = "drafts"
+	PathEphemeralDashboard       = "ephemeraldashboards"
+	PathFolder                   = "folders"
+	PathGlobalDashboard          = "globaldashboards"
+	PathGlobalDatasource         = "globaldatasources"
+	PathGlobalDatasourceOverride = "globaldatasourceoverrides"
+	PathGlobalHomeConfig         = "globalhomeconfigs"
+	PathGlobalVariable           = "globalvariables"
+	PathHome                     = "home"
+	PathHomeConfig               = "homeconfigs"
+	PathOrganization             = "organizations"
+	PathPlaylist                 = "playlists"
+	PathProject                  = "projects"
+	PathSavedView                = "savedviews"
+	PathShareToken               = "sharetokens"
+	PathTeam                     = "teams"
+	PathUsage                    = "usage"
+	PathVariable                 = "variables"
 )
 
 // ProjectResourcePathList is containing the list of the resource path that are part of a project.
 var ProjectResourcePathList = []string{
-	PathDashboard, PathDatasource, PathFolder, PathVariable,
+	PathDashboard, PathDatasource, PathEphemeralDashboard, PathFolder, PathGlobalDatasourceOverride, PathHomeConfig, PathPlaylist, PathSavedView, PathVariable,
 }
 
 func getNameParameter(ctx echo.Context) string {
@@ -49,6 +63,10 @@ func GetProjectParameter(ctx echo.Context) string {
 	return ctx.Param(ParamProject)
 }
 
+func GetOrganizationParameter(ctx echo.Context) string {
+	return ctx.Param(ParamOrganization)
+}
+
 // validateMetadataVersusParameter is the generic method used to validate provided metadata against the parameters in the context
 //   - If the parameter in the context is empty, no checks are performed => OK
 //   - Else
@@ -70,13 +88,55 @@ func validateMetadataVersusParameter(ctx echo.Context, paramName string, metadat
 }
 
 func validateMetadata(ctx echo.Context, metadata api.Metadata) error {
+	switch met := metadata.(type) {
+	case *v1.ProjectMetadata:
+		if err := validateMetadataVersusParameter(ctx, ParamProject, &met.Project); err != nil {
+			return err
+		}
+	case *v1.OrganizationMetadata:
+		if err := validateMetadataVersusParameter(ctx, ParamOrganization, &met.Organization); err != nil {
+			return err
+		}
+	}
+	return validateMetadataContent(metadata)
+}
+
+// validateMetadataContent checks the name, the owners and the labels of the resource.
+func validateMetadataContent(metadata api.Metadata) error {
 	if err := common.ValidateID(metadata.GetName()); err != nil {
 		return err
 	}
 	switch met := metadata.(type) {
 	case *v1.ProjectMetadata:
-		if err := validateMetadataVersusParameter(ctx, ParamProject, &met.Project); err != nil {
-			return err
+		return validateOwnersAndLabels(&met.Metadata)
+	case *v1.OrganizationMetadata:
+		return validateOwnersAndLabels(&met.Metadata)
+	case *v1.Metadata:
+		return validateOwnersAndLabels(met)
+	}
+	return nil
+}
+
+func validateOwnersAndLabels(metadata *v1.Metadata) error {
+	if err := validateOwners(metadata.Owners); err != nil {
+		return err
+	}
+	return validateLabels(metadata.Labels)
+}
+
+func validateOwners(owners []string) error {
+	for _, owner := range owners {
+		if err := common.ValidateID(owner); err != nil {
+			return fmt.Errorf("invalid owner %q: %s", owner, err)
+		}
+	}
+	return nil
+}
+
+func validateLabels(labels map[string]string) error {
+	for key := range labels {
+		if err := common.ValidateID(key); err != nil {
+			return fmt.Errorf("invalid label %q: %s", key, err)
 		}
 	}
 	return nil
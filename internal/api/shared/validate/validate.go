@@ -24,28 +24,41 @@ import (
 )
 
 func Dashboard(entity *modelV1.Dashboard, sch schemas.Schemas) error {
-	if _, err := dashboard.BuildVariableOrder(entity.Spec.Variables); err != nil {
+	return dashboardSpec(entity.Spec, sch)
+}
+
+func GlobalDashboard(entity *modelV1.GlobalDashboard, sch schemas.Schemas) error {
+	return dashboardSpec(entity.Spec, sch)
+}
+
+func dashboardSpec(spec modelV1.DashboardSpec, sch schemas.Schemas) error {
+	if _, err := dashboard.BuildVariableOrder(spec.Variables); err != nil {
 		return err
 	}
 	if sch != nil {
-		if err := sch.ValidateDashboardVariables(entity.Spec.Variables); err != nil {
+		if err := sch.ValidateDashboardVariables(spec.Variables); err != nil {
 			return err
 		}
-		if err := sch.ValidatePanels(entity.Spec.Panels); err != nil {
+		if err := sch.ValidatePanels(spec.Panels); err != nil {
 			return err
 		}
+		for _, query := range spec.Queries {
+			if err := sch.ValidateQuery(query.Spec.Plugin); err != nil {
+				return err
+			}
+		}
 	}
-	if len(entity.Spec.Datasources) > 0 {
+	if len(spec.Datasources) > 0 {
 		defaultDTS := make(map[string]bool)
-		for _, spec := range entity.Spec.Datasources {
-			if err := validateDTSPlugin(spec.Plugin, sch); err != nil {
+		for _, dtsSpec := range spec.Datasources {
+			if err := validateDTSPlugin(dtsSpec.Plugin, sch); err != nil {
 				return err
 			}
-			if spec.Default {
-				if defaultDTS[spec.Plugin.Kind] {
-					return fmt.Errorf("there is already a default datasource defined for the kind %q", spec.Plugin.Kind)
+			if dtsSpec.Default {
+				if defaultDTS[dtsSpec.Plugin.Kind] {
+					return fmt.Errorf("there is already a default datasource defined for the kind %q", dtsSpec.Plugin.Kind)
 				}
-				defaultDTS[spec.Plugin.Kind] = true
+				defaultDTS[dtsSpec.Plugin.Kind] = true
 			}
 		}
 	}
@@ -73,6 +86,10 @@ func validateUnicityOfDefaultDTS[T modelV1.DatasourceInterface](entity T, list [
 	}
 	entityPluginKind := spec.Plugin.Kind
 	for _, dts := range list {
+		if dts.GetMetadata().GetName() == entity.GetMetadata().GetName() {
+			// it is the datasource being updated
+			continue
+		}
 		dtsSpec := dts.GetDTSSpec()
 		if dtsSpec.Default && dtsSpec.Plugin.Kind == entityPluginKind {
 			return fmt.Errorf("datasource %q cannot be a default %q because there is already one defined named %q", entity.GetMetadata().GetName(), entityPluginKind, dts.GetMetadata().GetName())
@@ -21,6 +21,7 @@ import (
 	"github.com/perses/perses/internal/api/shared/schemas"
 	testUtils "github.com/perses/perses/internal/test"
 	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/model/api/v1/common"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -47,9 +48,10 @@ func TestDashboard(t *testing.T) {
 			projectPath := testUtils.GetRepositoryPath()
 			schemasService, schErr := schemas.New(config.Schemas{
 				// use the real schemas for these tests
-				PanelsPath:    filepath.Join(projectPath, config.DefaultPanelsPath),
-				QueriesPath:   filepath.Join(projectPath, config.DefaultQueriesPath),
-				VariablesPath: filepath.Join(projectPath, config.DefaultVariablesPath),
+				PanelsPath:          filepath.Join(projectPath, config.DefaultPanelsPath),
+				QueriesPath:         filepath.Join(projectPath, config.DefaultQueriesPath),
+				VariablesPath:       filepath.Join(projectPath, config.DefaultVariablesPath),
+				TransformationsPath: filepath.Join(projectPath, config.DefaultTransformationsPath),
 			})
 			if schErr != nil {
 				t.Fatal(schErr)
@@ -68,3 +70,21 @@ func TestDashboard(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateUnicityOfDefaultDTS(t *testing.T) {
+	newDatasource := func(name string, isDefault bool) *modelV1.Datasource {
+		return &modelV1.Datasource{
+			Kind:     modelV1.KindDatasource,
+			Metadata: *modelV1.NewProjectMetadata("perses", name),
+			Spec: modelV1.DatasourceSpec{
+				Default: isDefault,
+				Plugin:  common.Plugin{Kind: "PrometheusDatasource"},
+			},
+		}
+	}
+	existing := []*modelV1.Datasource{newDatasource("prometheus", true), newDatasource("thanos", false)}
+	// updating the default datasource
+	assert.NoError(t, validateUnicityOfDefaultDTS(newDatasource("prometheus", true), existing))
+	assert.NoError(t, validateUnicityOfDefaultDTS(newDatasource("thanos", false), existing))
+	assert.Error(t, validateUnicityOfDefaultDTS(newDatasource("thanos", true), existing))
+}
@@ -0,0 +1,187 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generate
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/perses/perses/internal/api/shared/alert"
+	persesCMD "github.com/perses/perses/internal/cli/cmd"
+	"github.com/perses/perses/internal/cli/config"
+	"github.com/perses/perses/internal/cli/file"
+	"github.com/perses/perses/internal/cli/opt"
+	"github.com/perses/perses/internal/cli/output"
+	"github.com/perses/perses/pkg/client/api"
+	modelAlert "github.com/perses/perses/pkg/model/api/alert"
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+type option struct {
+	persesCMD.Option
+	opt.FileOption
+	opt.ProjectOption
+	opt.OutputOption
+	writer        io.Writer
+	dashboardName string
+	rawVariables  []string
+	variables     map[string]string
+	rulesFile     string
+	online        bool
+	apiClient     api.ClientInterface
+}
+
+func (o *option) Complete(args []string) error {
+	if len(args) > 1 {
+		return fmt.Errorf("you cannot have more than one argument for the command 'alerts generate'")
+	}
+	if len(args) == 1 {
+		o.dashboardName = args[0]
+	}
+	if outputErr := o.OutputOption.Complete(); outputErr != nil {
+		return outputErr
+	}
+	if err := o.completeVariables(); err != nil {
+		return err
+	}
+	if len(o.dashboardName) > 0 {
+		if projectErr := o.ProjectOption.Complete(); projectErr != nil {
+			return projectErr
+		}
+	}
+	if len(o.dashboardName) > 0 || o.online {
+		apiClient, err := config.Global.GetAPIClient()
+		if err != nil {
+			return err
+		}
+		o.apiClient = apiClient
+	}
+	return nil
+}
+
+func (o *option) completeVariables() error {
+	if len(o.rawVariables) == 0 {
+		return nil
+	}
+	o.variables = make(map[string]string, len(o.rawVariables))
+	for _, v := range o.rawVariables {
+		kv := strings.SplitN(v, "=", 2)
+		if len(kv) != 2 || len(kv[0]) == 0 {
+			return fmt.Errorf("variable %q is not valid. Syntax supported is VariableName=VariableValue", v)
+		}
+		o.variables[kv[0]] = kv[1]
+	}
+	return nil
+}
+
+func (o *option) Validate() error {
+	if len(o.dashboardName) == 0 && len(o.File) == 0 {
+		return fmt.Errorf("please specify the name of the dashboard or use the flag --file")
+	}
+	if len(o.dashboardName) > 0 && len(o.File) > 0 {
+		return fmt.Errorf("you cannot use the flag --file and a dashboard name at the same time")
+	}
+	return nil
+}
+
+func (o *option) Execute() error {
+	request := &modelAlert.Generate{Variables: o.variables}
+	if len(o.dashboardName) > 0 {
+		dashboard, err := o.apiClient.V1().Dashboard(o.Project).Get(o.dashboardName)
+		if err != nil {
+			return err
+		}
+		request.Dashboard = dashboard
+	} else {
+		dashboard := &modelV1.Dashboard{}
+		if err := file.Unmarshal(o.File, dashboard); err != nil {
+			return err
+		}
+		request.Dashboard = dashboard
+	}
+	if len(o.rulesFile) > 0 {
+		rules := &modelAlert.RuleFile{}
+		if err := file.Unmarshal(o.rulesFile, rules); err != nil {
+			return err
+		}
+		request.Rules = rules
+	}
+	var result *modelAlert.GenerateResult
+	var err error
+	if o.online {
+		result, err = o.apiClient.GenerateAlerts(request)
+	} else {
+		result, err = alert.Generate(request)
+	}
+	if err != nil {
+		return err
+	}
+	for _, warning := range result.Warnings {
+		logrus.Warning(warning)
+	}
+	if request.Rules == nil {
+		// Only the rule file is printed, so the output can be used directly by Prometheus.
+		return output.Handle(o.writer, o.Output, result.RuleFile)
+	}
+	if len(result.Drift) == 0 {
+		return output.HandleString(o.writer, "the alerting rules are in sync with the dashboard")
+	}
+	return output.Handle(o.writer, o.Output, result.Drift)
+}
+
+func (o *option) SetWriter(writer io.Writer) {
+	o.writer = writer
+}
+
+func NewCMD() *cobra.Command {
+	o := &option{}
+	cmd := &cobra.Command{
+		Use:   "generate [DASHBOARD_NAME]",
+		Short: "Generate the Prometheus alerting rules from the thresholds of a dashboard",
+		Long: `
+Generate a Prometheus rule group from the panels that define thresholds.
+Only the panels with the annotation "perses.dev/alert" set to "true" are considered.
+Each threshold step becomes a rule, and the name of the step is used as the severity.
+
+The following panel annotations can be used to tune the rules:
+  * perses.dev/alert-for: the 'for' duration of the rules (default 5m)
+  * perses.dev/alert-name: the name of the alert (default is the name of the panel)
+  * perses.dev/alert-operator: the comparison operator between the query and the threshold (default >)
+  * perses.dev/alert-labels: additional labels with the syntax key1=value1,key2=value2
+`,
+		Example: `
+# Generate the rules from a dashboard stored in a file
+percli alerts generate -f ./dashboard.json
+
+# Generate the rules from a dashboard stored in the API and set the value of a variable
+percli alerts generate nodeExporter --project perses --var instance=demo:9100
+
+# Report the drift between the current rules and the dashboard
+percli alerts generate -f ./dashboard.json --rules ./rules.yaml
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return persesCMD.Run(o, cmd, args)
+		},
+	}
+	opt.AddFileFlags(cmd, &o.FileOption)
+	opt.AddProjectFlags(cmd, &o.ProjectOption)
+	opt.AddOutputFlags(cmd, &o.OutputOption)
+	cmd.Flags().StringArrayVar(&o.rawVariables, "var", o.rawVariables, "Value of a dashboard variable. Syntax supported is VariableName=VariableValue")
+	cmd.Flags().StringVar(&o.rulesFile, "rules", "", "Path to an existing Prometheus rule file. When set, the drift between the rules and the dashboard is reported instead of the rules")
+	cmd.Flags().BoolVar(&o.online, "online", false, "When enable, it requests the API to generate the rules")
+	return cmd
+}
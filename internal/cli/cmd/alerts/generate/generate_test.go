@@ -0,0 +1,90 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generate
+
+import (
+	"testing"
+
+	cmdTest "github.com/perses/perses/internal/cli/test"
+)
+
+func TestGenerateCMD(t *testing.T) {
+	testSuite := []cmdTest.Suite{
+		{
+			Title:           "no dashboard provided",
+			Args:            []string{},
+			IsErrorExpected: true,
+			ExpectedMessage: "please specify the name of the dashboard or use the flag --file",
+		},
+		{
+			Title:           "too many args",
+			Args:            []string{"node", "another"},
+			IsErrorExpected: true,
+			ExpectedMessage: "you cannot have more than one argument for the command 'alerts generate'",
+		},
+		{
+			Title:           "invalid variable",
+			Args:            []string{"-f", "../../../test/sample_resources/dashboard_with_alerts.json", "--var", "mode"},
+			IsErrorExpected: true,
+			ExpectedMessage: `variable "mode" is not valid. Syntax supported is VariableName=VariableValue`,
+		},
+		{
+			Title:           "generate rules from a file",
+			Args:            []string{"-f", "../../../test/sample_resources/dashboard_with_alerts.json", "--var", "mode=idle", "--var", "instance=prod:9100"},
+			IsErrorExpected: false,
+			ExpectedMessage: `groups:
+- name: perses-perses-node
+  rules:
+  - alert: HighCPUUsage
+    expr: (sum(rate(node_cpu_seconds_total{instance="prod:9100",mode!="idle"}[5m])))
+      > 160
+    for: 10m
+    labels:
+      perses_dashboard: node
+      perses_panel: cpu
+      perses_project: perses
+      severity: warning
+      team: infra
+    annotations:
+      description: CPU usage of the instance
+      summary: CPU usage
+  - alert: HighCPUUsage
+    expr: (sum(rate(node_cpu_seconds_total{instance="prod:9100",mode!="idle"}[5m])))
+      > 180
+    for: 10m
+    labels:
+      perses_dashboard: node
+      perses_panel: cpu
+      perses_project: perses
+      severity: critical
+      team: infra
+    annotations:
+      description: CPU usage of the instance
+      summary: CPU usage
+  - alert: idle
+    expr: (node_cpu_seconds_total{mode="idle"}) > 1
+    for: 5m
+    labels:
+      perses_dashboard: node
+      perses_panel: idle
+      perses_project: perses
+      severity: step-0
+    annotations:
+      summary: Idle
+
+`,
+		},
+	}
+	cmdTest.ExecuteSuiteTest(t, NewCMD, testSuite)
+}
@@ -0,0 +1,165 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyze
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/perses/perses/internal/api/shared/analysis"
+	persesCMD "github.com/perses/perses/internal/cli/cmd"
+	"github.com/perses/perses/internal/cli/config"
+	"github.com/perses/perses/internal/cli/file"
+	"github.com/perses/perses/internal/cli/opt"
+	"github.com/perses/perses/internal/cli/output"
+	"github.com/perses/perses/pkg/client/api"
+	modelAnalysis "github.com/perses/perses/pkg/model/api/analysis"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+type option struct {
+	persesCMD.Option
+	opt.FileOption
+	opt.ProjectOption
+	opt.OutputOption
+	writer    io.Writer
+	minUsage  int
+	rewrite   bool
+	apiClient api.ClientInterface
+}
+
+func (o *option) Complete(args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("no args are supported by the command 'analyze'")
+	}
+	// Complete the output only if it has been set by the user
+	if len(o.Output) > 0 {
+		if outputErr := o.OutputOption.Complete(); outputErr != nil {
+			return outputErr
+		}
+	}
+	if len(o.File) == 0 {
+		// Without file, the resources are analyzed by the API.
+		apiClient, err := config.Global.GetAPIClient()
+		if err != nil {
+			return err
+		}
+		o.apiClient = apiClient
+	}
+	return nil
+}
+
+func (o *option) Validate() error {
+	if o.minUsage < 0 {
+		return fmt.Errorf("--min-usage cannot be negative")
+	}
+	return nil
+}
+
+func (o *option) Execute() error {
+	query := modelAnalysis.Query{
+		Project:  o.Project,
+		MinUsage: o.minUsage,
+		Rewrite:  o.rewrite,
+	}
+	var result *modelAnalysis.Result
+	if len(o.File) > 0 {
+		entities, err := file.UnmarshalEntity(o.File)
+		if err != nil {
+			return err
+		}
+		result = analysis.AnalyzeEntities(entities, query)
+	} else {
+		var err error
+		result, err = o.apiClient.AnalyzeQueries(&query)
+		if err != nil {
+			return err
+		}
+	}
+	for _, err := range result.Errors {
+		logrus.Warning(err)
+	}
+	if len(o.Output) > 0 {
+		return output.Handle(o.writer, o.Output, result)
+	}
+	if len(result.Duplicates) == 0 {
+		return output.HandleString(o.writer, "no duplicated expression found")
+	}
+	output.HandlerTable(o.writer, []string{"USAGE", "EXPRESSION", "RECORD"}, buildDuplicateMatrix(result.Duplicates))
+	if len(result.Rewrites) > 0 {
+		if err := output.HandleString(o.writer, ""); err != nil {
+			return err
+		}
+		output.HandlerTable(o.writer, []string{"KIND", "PROJECT", "NAME", "LOCATION", "REWRITTEN QUERY"}, buildRewriteMatrix(result.Rewrites))
+	}
+	return nil
+}
+
+func (o *option) SetWriter(writer io.Writer) {
+	o.writer = writer
+}
+
+func buildDuplicateMatrix(duplicates []modelAnalysis.DuplicatedExpression) [][]string {
+	var data [][]string
+	for _, duplicate := range duplicates {
+		record := "-"
+		if duplicate.RecordingRule != nil {
+			record = duplicate.RecordingRule.Record
+		}
+		data = append(data, []string{strconv.Itoa(duplicate.Usage), duplicate.Expr, record})
+	}
+	return data
+}
+
+func buildRewriteMatrix(rewrites []modelAnalysis.Rewrite) [][]string {
+	var data [][]string
+	for _, rewrite := range rewrites {
+		data = append(data, []string{string(rewrite.Source.Kind), rewrite.Source.Project, rewrite.Source.Name, rewrite.Source.Location, rewrite.Rewritten})
+	}
+	return data
+}
+
+func NewCMD() *cobra.Command {
+	o := &option{}
+	cmd := &cobra.Command{
+		Use:   "analyze",
+		Short: "Find the Prometheus expressions used several times and suggest recording rules",
+		Long: `
+Parse all Prometheus queries used in the dashboards and in the variables, normalize them and group the identical
+expressions (the full query or a sub-expression). The expressions are ranked by usage, and a recording rule is
+suggested for each of them.
+`,
+		Example: `
+# Analyze the queries of all projects
+percli analyze
+
+# Analyze the queries of a project and print the plan to rewrite the dashboards with the recording rules
+percli analyze --project perses --rewrite
+
+# Analyze the resources contained in a file without any remote server
+percli analyze -f ./resources.json -ojson
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return persesCMD.Run(o, cmd, args)
+		},
+	}
+	opt.AddFileFlags(cmd, &o.FileOption)
+	opt.AddProjectFlags(cmd, &o.ProjectOption)
+	opt.AddOutputFlags(cmd, &o.OutputOption)
+	cmd.Flags().IntVar(&o.minUsage, "min-usage", modelAnalysis.DefaultMinUsage, "Minimum number of times an expression must be used to be reported")
+	cmd.Flags().BoolVar(&o.rewrite, "rewrite", false, "If present, print how the queries should be rewritten to use the suggested recording rules")
+	return cmd
+}
@@ -0,0 +1,50 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analyze
+
+import (
+	"testing"
+
+	cmdTest "github.com/perses/perses/internal/cli/test"
+)
+
+func TestAnalyzeCMD(t *testing.T) {
+	testSuite := []cmdTest.Suite{
+		{
+			Title:           "use args",
+			Args:            []string{"whatever"},
+			IsErrorExpected: true,
+			ExpectedMessage: "no args are supported by the command 'analyze'",
+		},
+		{
+			Title:           "not connected to any API",
+			Args:            []string{},
+			IsErrorExpected: true,
+			ExpectedMessage: "you are not connected to any API",
+		},
+		{
+			Title:           "negative min usage",
+			Args:            []string{"-f", "../../test/sample_resources/duplicated_queries.json", "--min-usage", "-1"},
+			IsErrorExpected: true,
+			ExpectedMessage: "--min-usage cannot be negative",
+		},
+		{
+			Title:           "no expression used enough",
+			Args:            []string{"-f", "../../test/sample_resources/duplicated_queries.json", "--min-usage", "10"},
+			IsErrorExpected: false,
+			ExpectedMessage: "no duplicated expression found\n",
+		},
+	}
+	cmdTest.ExecuteSuiteTest(t, NewCMD, testSuite)
+}
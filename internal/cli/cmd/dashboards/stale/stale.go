@@ -0,0 +1,136 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stale
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	persesCMD "github.com/perses/perses/internal/cli/cmd"
+	"github.com/perses/perses/internal/cli/config"
+	"github.com/perses/perses/internal/cli/opt"
+	"github.com/perses/perses/internal/cli/output"
+	"github.com/perses/perses/pkg/client/api"
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/spf13/cobra"
+)
+
+const defaultDays = 90
+
+type option struct {
+	persesCMD.Option
+	opt.ProjectOption
+	opt.OutputOption
+	writer    io.Writer
+	days      int
+	apiClient api.ClientInterface
+}
+
+func (o *option) Complete(args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("no args are supported by the command 'dashboards stale'")
+	}
+	// Complete the output only if it has been set by the user
+	if len(o.Output) > 0 {
+		if outputErr := o.OutputOption.Complete(); outputErr != nil {
+			return outputErr
+		}
+	}
+	apiClient, err := config.Global.GetAPIClient()
+	if err != nil {
+		return err
+	}
+	o.apiClient = apiClient
+	return nil
+}
+
+func (o *option) Validate() error {
+	if o.days <= 0 {
+		return fmt.Errorf("--days must be greater than 0")
+	}
+	return nil
+}
+
+func (o *option) Execute() error {
+	usages, err := o.apiClient.V1().Usage().Dashboards(&modelV1.DashboardUsageQuery{
+		Project: o.Project,
+		Days:    o.days,
+	})
+	if err != nil {
+		return err
+	}
+	staleDashboards := filterStale(usages, time.Now().UTC().AddDate(0, 0, -o.days))
+	if len(o.Output) > 0 {
+		return output.Handle(o.writer, o.Output, staleDashboards)
+	}
+	if len(staleDashboards) == 0 {
+		return output.HandleString(o.writer, fmt.Sprintf("every dashboard has been viewed during the last %d days", o.days))
+	}
+	output.HandlerTable(o.writer, []string{"PROJECT", "DASHBOARD", "LAST VIEW"}, buildMatrix(staleDashboards))
+	return nil
+}
+
+func (o *option) SetWriter(writer io.Writer) {
+	o.writer = writer
+}
+
+// filterStale returns the dashboards not viewed since the given time.
+func filterStale(usages []*modelV1.DashboardUsageSummary, since time.Time) []*modelV1.DashboardUsageSummary {
+	result := make([]*modelV1.DashboardUsageSummary, 0)
+	for _, usage := range usages {
+		if usage.LastViewedAt == nil || usage.LastViewedAt.Before(since) {
+			result = append(result, usage)
+		}
+	}
+	return result
+}
+
+func buildMatrix(usages []*modelV1.DashboardUsageSummary) [][]string {
+	var data [][]string
+	for _, usage := range usages {
+		lastView := "never"
+		if usage.LastViewedAt != nil {
+			lastView = output.FormatTime(*usage.LastViewedAt)
+		}
+		data = append(data, []string{usage.Project, usage.Dashboard, lastView})
+	}
+	return data
+}
+
+func NewCMD() *cobra.Command {
+	o := &option{}
+	cmd := &cobra.Command{
+		Use:   "stale",
+		Short: "List the dashboards that have not been viewed for a while",
+		Long: `
+List the dashboards that have not been viewed during the given number of days, including the ones never viewed.
+A view is recorded each time a dashboard is retrieved from the API or opened in the UI.
+`,
+		Example: `
+# List the dashboards of all projects not viewed during the last 90 days
+percli dashboards stale
+
+# List the dashboards of a project not viewed during the last 30 days
+percli dashboards stale --days 30 --project perses
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return persesCMD.Run(o, cmd, args)
+		},
+	}
+	opt.AddProjectFlags(cmd, &o.ProjectOption)
+	opt.AddOutputFlags(cmd, &o.OutputOption)
+	cmd.Flags().IntVar(&o.days, "days", defaultDays, "Number of days without any view after which a dashboard is considered as stale")
+	return cmd
+}
@@ -0,0 +1,54 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stale
+
+import (
+	"testing"
+	"time"
+
+	cmdTest "github.com/perses/perses/internal/cli/test"
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStaleCMD(t *testing.T) {
+	testSuite := []cmdTest.Suite{
+		{
+			Title:           "use args",
+			Args:            []string{"whatever"},
+			IsErrorExpected: true,
+			ExpectedMessage: "no args are supported by the command 'dashboards stale'",
+		},
+		{
+			Title:           "not connected to any API",
+			Args:            []string{},
+			IsErrorExpected: true,
+			ExpectedMessage: "you are not connected to any API",
+		},
+	}
+	cmdTest.ExecuteSuiteTest(t, NewCMD, testSuite)
+}
+
+func TestFilterStale(t *testing.T) {
+	now := time.Now().UTC()
+	recent := now.AddDate(0, 0, -2)
+	old := now.AddDate(0, 0, -100)
+	usages := []*modelV1.DashboardUsageSummary{
+		{Project: "perses", Dashboard: "recent", LastViewedAt: &recent, Views: 3},
+		{Project: "perses", Dashboard: "old", LastViewedAt: &old},
+		{Project: "perses", Dashboard: "never"},
+	}
+	result := filterStale(usages, now.AddDate(0, 0, -90))
+	assert.Equal(t, []*modelV1.DashboardUsageSummary{usages[1], usages[2]}, result)
+}
@@ -62,10 +62,8 @@ func TestDescribeCMD(t *testing.T) {
 			IsErrorExpected: false,
 			ExpectedMessage: string(test.JSONMarshalStrict(
 				&modelV1.Project{
-					Kind: modelV1.KindProject,
-					Metadata: modelV1.Metadata{
-						Name: "perses",
-					},
+					Kind:     modelV1.KindProject,
+					Metadata: *modelV1.NewOrganizationMetadata(modelV1.DefaultOrganization, "perses"),
 				})) + "\n",
 		},
 		{
@@ -75,10 +73,8 @@ func TestDescribeCMD(t *testing.T) {
 			IsErrorExpected: false,
 			ExpectedMessage: string(test.YAMLMarshalStrict(
 				&modelV1.Project{
-					Kind: modelV1.KindProject,
-					Metadata: modelV1.Metadata{
-						Name: "perses",
-					},
+					Kind:     modelV1.KindProject,
+					Metadata: *modelV1.NewOrganizationMetadata(modelV1.DefaultOrganization, "perses"),
 				})) + "\n",
 		},
 		{
@@ -34,14 +34,15 @@ import (
 type option struct {
 	persesCMD.Option
 	opt.FileOption
-	writer             io.Writer
-	chartsSchemas      string
-	queriesSchemas     string
-	datasourcesSchemas string
-	variablesSchemas   string
-	online             bool
-	sch                schemas.Schemas
-	apiClient          api.ClientInterface
+	writer                 io.Writer
+	chartsSchemas          string
+	queriesSchemas         string
+	datasourcesSchemas     string
+	variablesSchemas       string
+	transformationsSchemas string
+	online                 bool
+	sch                    schemas.Schemas
+	apiClient              api.ClientInterface
 }
 
 func (o *option) Complete(args []string) error {
@@ -51,10 +52,11 @@ func (o *option) Complete(args []string) error {
 	if (len(o.chartsSchemas) > 0 && len(o.queriesSchemas) > 0) || len(o.datasourcesSchemas) > 0 || len(o.variablesSchemas) > 0 {
 		var err error
 		o.sch, err = schemas.New(apiConfig.Schemas{
-			PanelsPath:      o.chartsSchemas,
-			QueriesPath:     o.queriesSchemas,
-			DatasourcesPath: o.datasourcesSchemas,
-			VariablesPath:   o.variablesSchemas,
+			PanelsPath:          o.chartsSchemas,
+			QueriesPath:         o.queriesSchemas,
+			DatasourcesPath:     o.datasourcesSchemas,
+			VariablesPath:       o.variablesSchemas,
+			TransformationsPath: o.transformationsSchemas,
 		})
 		if err != nil {
 			return err
@@ -104,6 +106,14 @@ func (o *option) validate(objects []modelAPI.Entity) error {
 			} else if err := validate.Dashboard(entity, o.sch); err != nil {
 				return fmt.Errorf("unexpected error in dashboard %q: %w", entity.Metadata.Name, err)
 			}
+		case *modelV1.GlobalDashboard:
+			if o.online {
+				if err := o.apiClient.Validate().GlobalDashboard(entity); err != nil {
+					return err
+				}
+			} else if err := validate.GlobalDashboard(entity, o.sch); err != nil {
+				return fmt.Errorf("unexpected error in global dashboard %q: %w", entity.Metadata.Name, err)
+			}
 
 		case *modelV1.GlobalDatasource:
 			if o.online {
@@ -173,6 +183,7 @@ percli lint -f ./resources.json --online
 	cmd.Flags().StringVar(&o.queriesSchemas, "schemas.queries", "", "Path to the CUE schemas for chart queries.")
 	cmd.Flags().StringVar(&o.datasourcesSchemas, "schemas.datasources", "", "Path to the CUE schemas for the datasources")
 	cmd.Flags().StringVar(&o.variablesSchemas, "schemas.variables", "", "Path to the CUE schemas for the dashboard variables")
+	cmd.Flags().StringVar(&o.transformationsSchemas, "schemas.transformations", "", "Path to the CUE schemas for the panel transformations")
 	cmd.Flags().BoolVar(&o.online, "online", false, "When enable, it can request the API to make additional validation")
 
 	cmd.MarkFlagsRequiredTogether("schemas.charts", "schemas.queries")
@@ -182,5 +193,6 @@ percli lint -f ./resources.json --online
 	cmd.MarkFlagsMutuallyExclusive("schemas.queries", "online")
 	cmd.MarkFlagsMutuallyExclusive("schemas.datasources", "online")
 	cmd.MarkFlagsMutuallyExclusive("schemas.variables", "online")
+	cmd.MarkFlagsMutuallyExclusive("schemas.transformations", "online")
 	return cmd
 }
@@ -20,7 +20,9 @@ import (
 
 	"github.com/perses/perses/internal/cli/cmd"
 	"github.com/perses/perses/internal/cli/config"
+	"github.com/perses/perses/pkg/client/api"
 	"github.com/perses/perses/pkg/client/perseshttp"
+	modelAPI "github.com/perses/perses/pkg/model/api"
 	"github.com/spf13/cobra"
 )
 
@@ -29,6 +31,8 @@ type option struct {
 	writer      io.Writer
 	url         string
 	insecureTLS bool
+	username    string
+	password    string
 }
 
 func (o *option) Complete(args []string) error {
@@ -43,15 +47,30 @@ func (o *option) Validate() error {
 	if _, err := url.Parse(o.url); err != nil {
 		return err
 	}
+	if (len(o.username) > 0) != (len(o.password) > 0) {
+		return fmt.Errorf("--username and --password must be used together")
+	}
 	return nil
 }
 
 func (o *option) Execute() error {
+	restConfig := perseshttp.RestConfigClient{
+		URL:         o.url,
+		InsecureTLS: o.insecureTLS,
+	}
+	if len(o.username) > 0 {
+		restClient, err := perseshttp.NewFromConfig(restConfig)
+		if err != nil {
+			return err
+		}
+		response, err := api.NewWithClient(restClient).LDAPLogin(&modelAPI.Auth{Login: o.username, Password: o.password})
+		if err != nil {
+			return err
+		}
+		restConfig.Token = response.AccessToken
+	}
 	return config.Write(&config.Config{
-		RestClientConfig: perseshttp.RestConfigClient{
-			URL:         o.url,
-			InsecureTLS: o.insecureTLS,
-		},
+		RestClientConfig: restConfig,
 	})
 }
 
@@ -67,11 +86,16 @@ func NewCMD() *cobra.Command {
 		Example: `
 # Log in to the given server
 percli login https://perses.dev
+
+# Log in to the given server with your LDAP credentials
+percli login https://perses.dev --username jdoe --password '<password>'
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return persesCMD.Run(o, cmd, args)
 		},
 	}
 	cmd.Flags().BoolVar(&o.insecureTLS, "insecure-skip-tls-verify", o.insecureTLS, "If true the server's certificate will not be checked for validity. This will make your HTTPS connections insecure.")
+	cmd.Flags().StringVar(&o.username, "username", o.username, "The login used to authenticate against the LDAP directory configured on the server.")
+	cmd.Flags().StringVar(&o.password, "password", o.password, "The password used to authenticate against the LDAP directory configured on the server.")
 	return cmd
 }
@@ -38,15 +38,16 @@ type option struct {
 	persesCMD.Option
 	opt.FileOption
 	opt.OutputOption
-	writer           io.Writer
-	rowInput         []string
-	input            map[string]string
-	chartsSchemas    string
-	queriesSchemas   string
-	variablesSchemas string
-	online           bool
-	mig              migrate.Migration
-	apiClient        api.ClientInterface
+	writer                 io.Writer
+	rowInput               []string
+	input                  map[string]string
+	chartsSchemas          string
+	queriesSchemas         string
+	variablesSchemas       string
+	transformationsSchemas string
+	online                 bool
+	mig                    migrate.Migration
+	apiClient              api.ClientInterface
 }
 
 func (o *option) Complete(args []string) error {
@@ -60,9 +61,10 @@ func (o *option) Complete(args []string) error {
 	if (len(o.chartsSchemas) > 0 && len(o.queriesSchemas) > 0) || len(o.variablesSchemas) > 0 {
 		var err error
 		o.mig, err = migrate.New(apiConfig.Schemas{
-			PanelsPath:    o.chartsSchemas,
-			QueriesPath:   o.queriesSchemas,
-			VariablesPath: o.variablesSchemas,
+			PanelsPath:          o.chartsSchemas,
+			QueriesPath:         o.queriesSchemas,
+			VariablesPath:       o.variablesSchemas,
+			TransformationsPath: o.transformationsSchemas,
 		})
 		if err != nil {
 			return err
@@ -155,6 +157,7 @@ percli migrate -f ./dashboard.json --input=DS_PROMETHEUS=PrometheusDemo --online
 	cmd.Flags().StringVar(&o.chartsSchemas, "schemas.charts", "", "Path to the CUE schemas for dasbhoard charts.")
 	cmd.Flags().StringVar(&o.queriesSchemas, "schemas.queries", "", "Path to the CUE schemas for chart queries.")
 	cmd.Flags().StringVar(&o.variablesSchemas, "schemas.variables", "", "Path to the CUE schemas for the dashboard variables")
+	cmd.Flags().StringVar(&o.transformationsSchemas, "schemas.transformations", "", "Path to the CUE schemas for the panel transformations")
 	cmd.Flags().BoolVar(&o.online, "online", false, "When enable, it can request the API to use it to perform the migration")
 
 	// when online flag is used, the CLI will call the endpoint /migrate that will then use the schema from the server.
@@ -162,5 +165,6 @@ percli migrate -f ./dashboard.json --input=DS_PROMETHEUS=PrometheusDemo --online
 	cmd.MarkFlagsMutuallyExclusive("schemas.charts", "online")
 	cmd.MarkFlagsMutuallyExclusive("schemas.queries", "online")
 	cmd.MarkFlagsMutuallyExclusive("schemas.variables", "online")
+	cmd.MarkFlagsMutuallyExclusive("schemas.transformations", "online")
 	return cmd
 }
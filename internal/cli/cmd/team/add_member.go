@@ -0,0 +1,97 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package team
+
+import (
+	"fmt"
+	"io"
+
+	persesCMD "github.com/perses/perses/internal/cli/cmd"
+	"github.com/perses/perses/internal/cli/config"
+	"github.com/perses/perses/internal/cli/output"
+	"github.com/perses/perses/pkg/client/api"
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/spf13/cobra"
+)
+
+type addMemberOption struct {
+	persesCMD.Option
+	writer    io.Writer
+	team      string
+	username  string
+	email     string
+	apiClient api.ClientInterface
+}
+
+func (o *addMemberOption) Complete(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("the name of the team must be provided as the only argument")
+	}
+	o.team = args[0]
+	apiClient, err := config.Global.GetAPIClient()
+	if err != nil {
+		return err
+	}
+	o.apiClient = apiClient
+	return nil
+}
+
+func (o *addMemberOption) Validate() error {
+	if len(o.username) == 0 {
+		return fmt.Errorf("--username is mandatory")
+	}
+	return nil
+}
+
+func (o *addMemberOption) Execute() error {
+	team, err := o.apiClient.V1().Team().Get(o.team)
+	if err != nil {
+		return err
+	}
+	for _, member := range team.Spec.Members {
+		if member.Username == o.username {
+			return fmt.Errorf("%q is already a member of the team %q", o.username, o.team)
+		}
+	}
+	team.Spec.Members = append(team.Spec.Members, modelV1.TeamMember{
+		Username: o.username,
+		Email:    o.email,
+	})
+	if _, updateErr := o.apiClient.V1().Team().Update(team); updateErr != nil {
+		return updateErr
+	}
+	return output.HandleString(o.writer, fmt.Sprintf("%q has been added to the team %q", o.username, o.team))
+}
+
+func (o *addMemberOption) SetWriter(writer io.Writer) {
+	o.writer = writer
+}
+
+func newAddMemberCMD() *cobra.Command {
+	o := &addMemberOption{}
+	cmd := &cobra.Command{
+		Use:   "add-member NAME",
+		Short: "Add a member to a team",
+		Example: `
+# Add the user 'alice' to the team 'observability'
+percli team add-member observability --username alice --email alice@example.com
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return persesCMD.Run(o, cmd, args)
+		},
+	}
+	cmd.Flags().StringVar(&o.username, "username", "", "Name used by the user to log in")
+	cmd.Flags().StringVar(&o.email, "email", "", "Email of the user")
+	return cmd
+}
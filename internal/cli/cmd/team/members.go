@@ -0,0 +1,98 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package team
+
+import (
+	"fmt"
+	"io"
+
+	persesCMD "github.com/perses/perses/internal/cli/cmd"
+	"github.com/perses/perses/internal/cli/config"
+	"github.com/perses/perses/internal/cli/opt"
+	"github.com/perses/perses/internal/cli/output"
+	"github.com/perses/perses/pkg/client/api"
+	"github.com/spf13/cobra"
+)
+
+type membersOption struct {
+	persesCMD.Option
+	opt.OutputOption
+	writer    io.Writer
+	team      string
+	apiClient api.ClientInterface
+}
+
+func (o *membersOption) Complete(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("the name of the team must be provided as the only argument")
+	}
+	o.team = args[0]
+	// Complete the output only if it has been set by the user
+	if len(o.Output) > 0 {
+		if outputErr := o.OutputOption.Complete(); outputErr != nil {
+			return outputErr
+		}
+	}
+	apiClient, err := config.Global.GetAPIClient()
+	if err != nil {
+		return err
+	}
+	o.apiClient = apiClient
+	return nil
+}
+
+func (o *membersOption) Validate() error {
+	return nil
+}
+
+func (o *membersOption) Execute() error {
+	team, err := o.apiClient.V1().Team().Get(o.team)
+	if err != nil {
+		return err
+	}
+	if len(o.Output) > 0 {
+		return output.Handle(o.writer, o.Output, team.Spec.Members)
+	}
+	var data [][]string
+	for _, member := range team.Spec.Members {
+		data = append(data, []string{member.Username, member.Email})
+	}
+	output.HandlerTable(o.writer, []string{"USERNAME", "EMAIL"}, data)
+	return nil
+}
+
+func (o *membersOption) SetWriter(writer io.Writer) {
+	o.writer = writer
+}
+
+func newMembersCMD() *cobra.Command {
+	o := &membersOption{}
+	cmd := &cobra.Command{
+		Use:   "members NAME",
+		Short: "List the members of a team",
+		Long: `
+List the members declared in a team.
+The users that are members through the external groups of the team are not listed.
+`,
+		Example: `
+# List the members of the team 'observability'
+percli team members observability
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return persesCMD.Run(o, cmd, args)
+		},
+	}
+	opt.AddOutputFlags(cmd, &o.OutputOption)
+	return cmd
+}
@@ -0,0 +1,96 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package team
+
+import (
+	"fmt"
+	"io"
+
+	persesCMD "github.com/perses/perses/internal/cli/cmd"
+	"github.com/perses/perses/internal/cli/config"
+	"github.com/perses/perses/internal/cli/output"
+	"github.com/perses/perses/pkg/client/api"
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/spf13/cobra"
+)
+
+type removeMemberOption struct {
+	persesCMD.Option
+	writer    io.Writer
+	team      string
+	username  string
+	apiClient api.ClientInterface
+}
+
+func (o *removeMemberOption) Complete(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("the name of the team must be provided as the only argument")
+	}
+	o.team = args[0]
+	apiClient, err := config.Global.GetAPIClient()
+	if err != nil {
+		return err
+	}
+	o.apiClient = apiClient
+	return nil
+}
+
+func (o *removeMemberOption) Validate() error {
+	if len(o.username) == 0 {
+		return fmt.Errorf("--username is mandatory")
+	}
+	return nil
+}
+
+func (o *removeMemberOption) Execute() error {
+	team, err := o.apiClient.V1().Team().Get(o.team)
+	if err != nil {
+		return err
+	}
+	members := make([]modelV1.TeamMember, 0, len(team.Spec.Members))
+	for _, member := range team.Spec.Members {
+		if member.Username != o.username {
+			members = append(members, member)
+		}
+	}
+	if len(members) == len(team.Spec.Members) {
+		return fmt.Errorf("%q is not a member of the team %q", o.username, o.team)
+	}
+	team.Spec.Members = members
+	if _, updateErr := o.apiClient.V1().Team().Update(team); updateErr != nil {
+		return updateErr
+	}
+	return output.HandleString(o.writer, fmt.Sprintf("%q has been removed from the team %q", o.username, o.team))
+}
+
+func (o *removeMemberOption) SetWriter(writer io.Writer) {
+	o.writer = writer
+}
+
+func newRemoveMemberCMD() *cobra.Command {
+	o := &removeMemberOption{}
+	cmd := &cobra.Command{
+		Use:   "remove-member NAME",
+		Short: "Remove a member from a team",
+		Example: `
+# Remove the user 'alice' from the team 'observability'
+percli team remove-member observability --username alice
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return persesCMD.Run(o, cmd, args)
+		},
+	}
+	cmd.Flags().StringVar(&o.username, "username", "", "Name used by the user to log in")
+	return cmd
+}
@@ -0,0 +1,33 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package team
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func NewCMD() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "team",
+		Short: "Manage the members of the teams",
+		Long: `
+Manage the members of the teams.
+To create, update or delete a team, use the commands 'apply', 'get' and 'delete' with the kind Team.
+`,
+	}
+	cmd.AddCommand(newMembersCMD())
+	cmd.AddCommand(newAddMemberCMD())
+	cmd.AddCommand(newRemoveMemberCMD())
+	return cmd
+}
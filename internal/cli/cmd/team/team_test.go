@@ -0,0 +1,84 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package team
+
+import (
+	"testing"
+
+	cmdTest "github.com/perses/perses/internal/cli/test"
+	fakeapi "github.com/perses/perses/pkg/client/fake/api"
+)
+
+func TestTeamCMD(t *testing.T) {
+	testSuite := []cmdTest.Suite{
+		{
+			Title:           "no team provided",
+			Args:            []string{"members"},
+			IsErrorExpected: true,
+			ExpectedMessage: "the name of the team must be provided as the only argument",
+		},
+		{
+			Title:           "not connected to any API",
+			Args:            []string{"members", "observability"},
+			IsErrorExpected: true,
+			ExpectedMessage: "you are not connected to any API",
+		},
+		{
+			Title:           "list the members in json",
+			Args:            []string{"members", "observability", "-ojson"},
+			APIClient:       fakeapi.New(),
+			IsErrorExpected: false,
+			ExpectedMessage: `[{"username":"alice","email":"alice@perses.dev"},{"username":"bob"}]
+`,
+		},
+		{
+			Title:           "add a member without username",
+			Args:            []string{"add-member", "observability"},
+			APIClient:       fakeapi.New(),
+			IsErrorExpected: true,
+			ExpectedMessage: "--username is mandatory",
+		},
+		{
+			Title:           "add a member",
+			Args:            []string{"add-member", "observability", "--username", "carol"},
+			APIClient:       fakeapi.New(),
+			IsErrorExpected: false,
+			ExpectedMessage: `"carol" has been added to the team "observability"
+`,
+		},
+		{
+			Title:           "add an existing member",
+			Args:            []string{"add-member", "observability", "--username", "alice"},
+			APIClient:       fakeapi.New(),
+			IsErrorExpected: true,
+			ExpectedMessage: `"alice" is already a member of the team "observability"`,
+		},
+		{
+			Title:           "remove a member",
+			Args:            []string{"remove-member", "observability", "--username", "bob"},
+			APIClient:       fakeapi.New(),
+			IsErrorExpected: false,
+			ExpectedMessage: `"bob" has been removed from the team "observability"
+`,
+		},
+		{
+			Title:           "remove an unknown member",
+			Args:            []string{"remove-member", "platform", "--username", "bob"},
+			APIClient:       fakeapi.New(),
+			IsErrorExpected: true,
+			ExpectedMessage: `"bob" is not a member of the team "platform"`,
+		},
+	}
+	cmdTest.ExecuteSuiteTest(t, NewCMD, testSuite)
+}
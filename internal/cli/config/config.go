@@ -136,6 +136,8 @@ func Write(config *Config) error {
 			previousConf.RestClientConfig.InsecureTLS = config.RestClientConfig.InsecureTLS
 			if len(config.RestClientConfig.URL) > 0 {
 				previousConf.RestClientConfig.URL = config.RestClientConfig.URL
+				// logging in again replaces the token delivered by the previous session
+				previousConf.RestClientConfig.Token = config.RestClientConfig.Token
 			}
 			if len(config.Project) > 0 {
 				previousConf.Project = config.Project
@@ -46,6 +46,14 @@ var resources = []resource{
 			"datasources",
 		},
 	},
+	{
+		kind:      modelV1.KindEphemeralDashboard,
+		shortTerm: "edash",
+		aliases: []string{
+			"ephemeralDashboards",
+			"edashs",
+		},
+	},
 	{
 		kind:      modelV1.KindFolder,
 		shortTerm: "fld",
@@ -54,6 +62,14 @@ var resources = []resource{
 			"flds",
 		},
 	},
+	{
+		kind:      modelV1.KindGlobalDashboard,
+		shortTerm: "gdash",
+		aliases: []string{
+			"globalDashboards",
+			"gdashs",
+		},
+	},
 	{
 		kind:      modelV1.KindGlobalDatasource,
 		shortTerm: "gdts",
@@ -61,6 +77,20 @@ var resources = []resource{
 			"globalDatasources",
 		},
 	},
+	{
+		kind:      modelV1.KindGlobalDatasourceOverride,
+		shortTerm: "gdtso",
+		aliases: []string{
+			"globalDatasourceOverrides",
+		},
+	},
+	{
+		kind:      modelV1.KindGlobalHomeConfig,
+		shortTerm: "ghc",
+		aliases: []string{
+			"globalHomeConfigs",
+		},
+	},
 	{
 		kind:      modelV1.KindGlobalVariable,
 		shortTerm: "gv",
@@ -71,12 +101,49 @@ var resources = []resource{
 			"gvs",
 		},
 	},
+	{
+		kind:      modelV1.KindHomeConfig,
+		shortTerm: "hc",
+		aliases: []string{
+			"homeConfigs",
+		},
+	},
+	{
+		kind:      modelV1.KindOrganization,
+		shortTerm: "org",
+		aliases: []string{
+			"organizations",
+			"orgs",
+		},
+	},
+	{
+		kind:      modelV1.KindPlaylist,
+		shortTerm: "pl",
+		aliases: []string{
+			"playlists",
+			"pls",
+		},
+	},
 	{
 		kind: modelV1.KindProject,
 		aliases: []string{
 			"projects",
 		},
 	},
+	{
+		kind:      modelV1.KindSavedView,
+		shortTerm: "sv",
+		aliases: []string{
+			"savedViews",
+			"svs",
+		},
+	},
+	{
+		kind: modelV1.KindTeam,
+		aliases: []string{
+			"teams",
+		},
+	},
 	{
 		kind:      modelV1.KindVariable,
 		shortTerm: "var",
@@ -101,7 +168,7 @@ func HandleSuccessMessage(writer io.Writer, kind modelV1.Kind, project string, g
 // Returns false otherwise.
 func IsGlobal(kind modelV1.Kind) bool {
 	switch kind {
-	case modelV1.KindProject, modelV1.KindGlobalDatasource, modelV1.KindGlobalVariable:
+	case modelV1.KindOrganization, modelV1.KindProject, modelV1.KindGlobalDashboard, modelV1.KindGlobalDatasource, modelV1.KindGlobalHomeConfig, modelV1.KindGlobalVariable, modelV1.KindTeam:
 		return true
 	default:
 		return false
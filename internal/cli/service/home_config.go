@@ -0,0 +1,72 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"strconv"
+
+	"github.com/perses/perses/internal/cli/output"
+	v1 "github.com/perses/perses/pkg/client/api/v1"
+	modelAPI "github.com/perses/perses/pkg/model/api"
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+type homeConfig struct {
+	Service
+	apiClient v1.HomeConfigInterface
+}
+
+func (h *homeConfig) CreateResource(entity modelAPI.Entity) (modelAPI.Entity, error) {
+	return h.apiClient.Create(entity.(*modelV1.HomeConfig))
+}
+
+func (h *homeConfig) UpdateResource(entity modelAPI.Entity) (modelAPI.Entity, error) {
+	return h.apiClient.Update(entity.(*modelV1.HomeConfig))
+}
+
+func (h *homeConfig) ListResource(prefix string) ([]modelAPI.Entity, error) {
+	return convertToEntityIfNoError(h.apiClient.List(prefix))
+}
+
+func (h *homeConfig) GetResource(name string) (modelAPI.Entity, error) {
+	return h.apiClient.Get(name)
+}
+
+func (h *homeConfig) DeleteResource(name string) error {
+	return h.apiClient.Delete(name)
+}
+
+func (h *homeConfig) BuildMatrix(hits []modelAPI.Entity) [][]string {
+	var data [][]string
+	for _, hit := range hits {
+		entity := hit.(*modelV1.HomeConfig)
+		line := []string{
+			entity.Metadata.Name,
+			entity.Metadata.Project,
+			strconv.Itoa(len(entity.Spec.ImportantDashboards)),
+			output.FormatTime(entity.Metadata.UpdatedAt),
+		}
+		data = append(data, line)
+	}
+	return data
+}
+
+func (h *homeConfig) GetColumHeader() []string {
+	return []string{
+		"NAME",
+		"PROJECT",
+		"IMPORTANT_DASHBOARDS",
+		"AGE",
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"strconv"
+
+	"github.com/perses/perses/internal/cli/output"
+	v1 "github.com/perses/perses/pkg/client/api/v1"
+	modelAPI "github.com/perses/perses/pkg/model/api"
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+type organization struct {
+	Service
+	apiClient v1.OrganizationInterface
+}
+
+func (o *organization) CreateResource(entity modelAPI.Entity) (modelAPI.Entity, error) {
+	return o.apiClient.Create(entity.(*modelV1.Organization))
+}
+
+func (o *organization) UpdateResource(entity modelAPI.Entity) (modelAPI.Entity, error) {
+	return o.apiClient.Update(entity.(*modelV1.Organization))
+}
+
+func (o *organization) ListResource(prefix string) ([]modelAPI.Entity, error) {
+	return convertToEntityIfNoError(o.apiClient.List(prefix))
+}
+
+func (o *organization) GetResource(name string) (modelAPI.Entity, error) {
+	return o.apiClient.Get(name)
+}
+
+func (o *organization) DeleteResource(name string) error {
+	return o.apiClient.Delete(name)
+}
+
+func (o *organization) BuildMatrix(hits []modelAPI.Entity) [][]string {
+	var data [][]string
+	for _, hit := range hits {
+		entity := hit.(*modelV1.Organization)
+		line := []string{
+			entity.Metadata.Name,
+			strconv.FormatBool(entity.Spec.Settings.Readonly),
+			output.FormatTime(entity.Metadata.UpdatedAt),
+		}
+		data = append(data, line)
+	}
+	return data
+}
+
+func (o *organization) GetColumHeader() []string {
+	return []string{
+		"NAME",
+		"READONLY",
+		"AGE",
+	}
+}
@@ -51,6 +51,7 @@ func (p *project) BuildMatrix(hits []modelAPI.Entity) [][]string {
 		entity := hit.(*modelV1.Project)
 		line := []string{
 			entity.Metadata.Name,
+			entity.Metadata.Organization,
 			output.FormatTime(entity.Metadata.UpdatedAt),
 		}
 		data = append(data, line)
@@ -61,6 +62,7 @@ func (p *project) BuildMatrix(hits []modelAPI.Entity) [][]string {
 func (p *project) GetColumHeader() []string {
 	return []string{
 		"NAME",
+		"ORGANIZATION",
 		"AGE",
 	}
 }
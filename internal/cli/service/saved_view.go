@@ -0,0 +1,78 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"strconv"
+
+	"github.com/perses/perses/internal/cli/output"
+	v1 "github.com/perses/perses/pkg/client/api/v1"
+	modelAPI "github.com/perses/perses/pkg/model/api"
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+type savedView struct {
+	Service
+	apiClient v1.SavedViewInterface
+}
+
+func (s *savedView) CreateResource(entity modelAPI.Entity) (modelAPI.Entity, error) {
+	return s.apiClient.Create(entity.(*modelV1.SavedView))
+}
+
+func (s *savedView) UpdateResource(entity modelAPI.Entity) (modelAPI.Entity, error) {
+	return s.apiClient.Update(entity.(*modelV1.SavedView))
+}
+
+func (s *savedView) ListResource(prefix string) ([]modelAPI.Entity, error) {
+	return convertToEntityIfNoError(s.apiClient.List(prefix))
+}
+
+func (s *savedView) GetResource(name string) (modelAPI.Entity, error) {
+	return s.apiClient.Get(name)
+}
+
+func (s *savedView) DeleteResource(name string) error {
+	return s.apiClient.Delete(name)
+}
+
+func (s *savedView) BuildMatrix(hits []modelAPI.Entity) [][]string {
+	var data [][]string
+	for _, hit := range hits {
+		entity := hit.(*modelV1.SavedView)
+		broken := false
+		if entity.Status != nil {
+			broken = entity.Status.Broken
+		}
+		line := []string{
+			entity.Metadata.Name,
+			entity.Metadata.Project,
+			entity.Spec.Dashboard,
+			strconv.FormatBool(broken),
+			output.FormatTime(entity.Metadata.UpdatedAt),
+		}
+		data = append(data, line)
+	}
+	return data
+}
+
+func (s *savedView) GetColumHeader() []string {
+	return []string{
+		"NAME",
+		"PROJECT",
+		"DASHBOARD",
+		"BROKEN",
+		"AGE",
+	}
+}
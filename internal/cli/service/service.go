@@ -52,22 +52,58 @@ func New(kind modelV1.Kind, projectName string, apiClient api.ClientInterface) (
 		return &datasource{
 			apiClient: apiClient.V1().Datasource(projectName),
 		}, nil
+	case modelV1.KindEphemeralDashboard:
+		return &ephemeralDashboard{
+			apiClient: apiClient.V1().EphemeralDashboard(projectName),
+		}, nil
 	case modelV1.KindFolder:
 		return &folder{
 			apiClient: apiClient.V1().Folder(projectName),
 		}, nil
+	case modelV1.KindGlobalDashboard:
+		return &globalDashboard{
+			apiClient: apiClient.V1().GlobalDashboard(),
+		}, nil
 	case modelV1.KindGlobalDatasource:
 		return &globalDatasource{
 			apiClient: apiClient.V1().GlobalDatasource(),
 		}, nil
+	case modelV1.KindGlobalDatasourceOverride:
+		return &globalDatasourceOverride{
+			apiClient: apiClient.V1().GlobalDatasourceOverride(projectName),
+		}, nil
+	case modelV1.KindGlobalHomeConfig:
+		return &globalHomeConfig{
+			apiClient: apiClient.V1().GlobalHomeConfig(),
+		}, nil
 	case modelV1.KindGlobalVariable:
 		return &globalVariable{
 			apiClient: apiClient.V1().GlobalVariable(),
 		}, nil
+	case modelV1.KindHomeConfig:
+		return &homeConfig{
+			apiClient: apiClient.V1().HomeConfig(projectName),
+		}, nil
+	case modelV1.KindOrganization:
+		return &organization{
+			apiClient: apiClient.V1().Organization(),
+		}, nil
+	case modelV1.KindPlaylist:
+		return &playlist{
+			apiClient: apiClient.V1().Playlist(projectName),
+		}, nil
 	case modelV1.KindProject:
 		return &project{
 			apiClient: apiClient.V1().Project(),
 		}, nil
+	case modelV1.KindSavedView:
+		return &savedView{
+			apiClient: apiClient.V1().SavedView(projectName),
+		}, nil
+	case modelV1.KindTeam:
+		return &team{
+			apiClient: apiClient.V1().Team(),
+		}, nil
 	case modelV1.KindVariable:
 		return &variable{
 			apiClient: apiClient.V1().Variable(projectName),
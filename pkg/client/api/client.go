@@ -17,6 +17,9 @@ import (
 	v1 "github.com/perses/perses/pkg/client/api/v1"
 	"github.com/perses/perses/pkg/client/perseshttp"
 	"github.com/perses/perses/pkg/model/api"
+	"github.com/perses/perses/pkg/model/api/alert"
+	"github.com/perses/perses/pkg/model/api/analysis"
+	"github.com/perses/perses/pkg/model/api/transform"
 	modelV1 "github.com/perses/perses/pkg/model/api/v1"
 )
 
@@ -24,6 +27,10 @@ type ClientInterface interface {
 	RESTClient() *perseshttp.RESTClient
 	V1() v1.ClientInterface
 	Migrate(body *api.Migrate) (*modelV1.Dashboard, error)
+	GenerateAlerts(body *alert.Generate) (*alert.GenerateResult, error)
+	AnalyzeQueries(query *analysis.Query) (*analysis.Result, error)
+	ApplyTransformations(body *transform.Apply) (*transform.Data, error)
+	LDAPLogin(body *api.Auth) (*api.AuthResponse, error)
 	Validate() ValidateInterface
 }
 
@@ -57,6 +64,50 @@ func (c *client) Migrate(body *api.Migrate) (*modelV1.Dashboard, error) {
 	return result, err
 }
 
+func (c *client) GenerateAlerts(body *alert.Generate) (*alert.GenerateResult, error) {
+	result := &alert.GenerateResult{}
+	err := c.restClient.Post().
+		APIVersion("").
+		Resource("alerts/generate").
+		Body(body).
+		Do().
+		Object(result)
+	return result, err
+}
+
+func (c *client) AnalyzeQueries(query *analysis.Query) (*analysis.Result, error) {
+	result := &analysis.Result{}
+	err := c.restClient.Get().
+		APIVersion("").
+		Resource("analysis/queries").
+		Query(query).
+		Do().
+		Object(result)
+	return result, err
+}
+
+func (c *client) ApplyTransformations(body *transform.Apply) (*transform.Data, error) {
+	result := &transform.Data{}
+	err := c.restClient.Post().
+		APIVersion("").
+		Resource("transformations/apply").
+		Body(body).
+		Do().
+		Object(result)
+	return result, err
+}
+
+func (c *client) LDAPLogin(body *api.Auth) (*api.AuthResponse, error) {
+	result := &api.AuthResponse{}
+	err := c.restClient.Post().
+		APIVersion("").
+		Resource("auth/providers/ldap/login").
+		Body(body).
+		Do().
+		Object(result)
+	return result, err
+}
+
 func (c *client) Validate() ValidateInterface {
 	return newValidate(c.restClient)
 }
@@ -23,11 +23,24 @@ type ClientInterface interface {
 	RESTClient() *perseshttp.RESTClient
 	Dashboard(project string) DashboardInterface
 	Datasource(project string) DatasourceInterface
+	Draft(project string) DraftInterface
+	EphemeralDashboard(project string) EphemeralDashboardInterface
 	Folder(project string) FolderInterface
+	GlobalDashboard() GlobalDashboardInterface
 	GlobalDatasource() GlobalDatasourceInterface
+	GlobalDatasourceOverride(project string) GlobalDatasourceOverrideInterface
+	GlobalHomeConfig() GlobalHomeConfigInterface
 	GlobalVariable() GlobalVariableInterface
 	Health() HealthInterface
+	HomeConfig(project string) HomeConfigInterface
+	Organization() OrganizationInterface
+	OrganizationDatasource(organization string) OrganizationDatasourceInterface
+	OrganizationVariable(organization string) OrganizationVariableInterface
+	Playlist(project string) PlaylistInterface
 	Project() ProjectInterface
+	SavedView(project string) SavedViewInterface
+	Team() TeamInterface
+	Usage() UsageInterface
 	Variable(project string) VariableInterface
 }
 
@@ -54,14 +67,34 @@ func (c *client) Datasource(project string) DatasourceInterface {
 	return newDatasource(c.restClient, project)
 }
 
+func (c *client) Draft(project string) DraftInterface {
+	return newDraft(c.restClient, project)
+}
+
+func (c *client) EphemeralDashboard(project string) EphemeralDashboardInterface {
+	return newEphemeralDashboard(c.restClient, project)
+}
+
 func (c *client) Folder(project string) FolderInterface {
 	return newFolder(c.restClient, project)
 }
 
+func (c *client) GlobalDashboard() GlobalDashboardInterface {
+	return newGlobalDashboard(c.restClient)
+}
+
 func (c *client) GlobalDatasource() GlobalDatasourceInterface {
 	return newGlobalDatasource(c.restClient)
 }
 
+func (c *client) GlobalDatasourceOverride(project string) GlobalDatasourceOverrideInterface {
+	return newGlobalDatasourceOverride(c.restClient, project)
+}
+
+func (c *client) GlobalHomeConfig() GlobalHomeConfigInterface {
+	return newGlobalHomeConfig(c.restClient)
+}
+
 func (c *client) GlobalVariable() GlobalVariableInterface {
 	return newGlobalVariable(c.restClient)
 }
@@ -70,10 +103,42 @@ func (c *client) Health() HealthInterface {
 	return newHealth(c.restClient)
 }
 
+func (c *client) HomeConfig(project string) HomeConfigInterface {
+	return newHomeConfig(c.restClient, project)
+}
+
+func (c *client) Organization() OrganizationInterface {
+	return newOrganization(c.restClient)
+}
+
+func (c *client) OrganizationDatasource(organization string) OrganizationDatasourceInterface {
+	return newOrganizationDatasource(c.restClient, organization)
+}
+
+func (c *client) OrganizationVariable(organization string) OrganizationVariableInterface {
+	return newOrganizationVariable(c.restClient, organization)
+}
+
+func (c *client) Playlist(project string) PlaylistInterface {
+	return newPlaylist(c.restClient, project)
+}
+
 func (c *client) Project() ProjectInterface {
 	return newProject(c.restClient)
 }
 
+func (c *client) SavedView(project string) SavedViewInterface {
+	return newSavedView(c.restClient, project)
+}
+
+func (c *client) Team() TeamInterface {
+	return newTeam(c.restClient)
+}
+
+func (c *client) Usage() UsageInterface {
+	return newUsage(c.restClient)
+}
+
 func (c *client) Variable(project string) VariableInterface {
 	return newVariable(c.restClient, project)
 }
@@ -11,11 +11,14 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-// Code generated. DO NOT EDIT
-
 package v1
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
 	"github.com/perses/perses/pkg/client/perseshttp"
 	v1 "github.com/perses/perses/pkg/model/api/v1"
 )
@@ -25,6 +28,11 @@ const dashboardResource = "dashboards"
 type DashboardInterface interface {
 	Create(entity *v1.Dashboard) (*v1.Dashboard, error)
 	Update(entity *v1.Dashboard) (*v1.Dashboard, error)
+	// Merge updates the dashboard with the changes done between base and entity, even if the dashboard has been
+	// modified in the meantime. When the changes are conflicting, the error returned is a *v1.MergeConflicts.
+	Merge(base *v1.Dashboard, entity *v1.Dashboard) (*v1.Dashboard, error)
+	// Rename moves the dashboard to a new name and rewrites the references to it (folders, home configs, links, ...).
+	Rename(name string, request *v1.Rename) (*v1.Dashboard, error)
 	Delete(name string) error
 	// Get is returning an unique Dashboard.
 	// As such name is the exact value of Dashboard.metadata.name. It cannot be empty.
@@ -71,6 +79,37 @@ func (c *dashboard) Update(entity *v1.Dashboard) (*v1.Dashboard, error) {
 	return result, err
 }
 
+func (c *dashboard) Merge(base *v1.Dashboard, entity *v1.Dashboard) (*v1.Dashboard, error) {
+	result := &v1.Dashboard{}
+	err := c.client.Put().
+		Resource(dashboardResource).
+		Name(fmt.Sprintf("%s/merge", entity.Metadata.Name)).
+		Project(c.project).
+		Body(&v1.DashboardMerge{Base: base, Dashboard: entity}).
+		Do().
+		Object(result)
+	var requestErr *perseshttp.RequestError
+	if errors.As(err, &requestErr) && requestErr.StatusCode == http.StatusConflict {
+		conflicts := &v1.MergeConflicts{}
+		if unmarshalErr := json.Unmarshal(requestErr.Body, conflicts); unmarshalErr == nil && len(conflicts.Conflicts) > 0 {
+			return nil, conflicts
+		}
+	}
+	return result, err
+}
+
+func (c *dashboard) Rename(name string, request *v1.Rename) (*v1.Dashboard, error) {
+	result := &v1.Dashboard{}
+	err := c.client.Post().
+		Resource(dashboardResource).
+		Name(fmt.Sprintf("%s/rename", name)).
+		Project(c.project).
+		Body(request).
+		Do().
+		Object(result)
+	return result, err
+}
+
 func (c *dashboard) Delete(name string) error {
 	return c.client.Delete().
 		Resource(dashboardResource).
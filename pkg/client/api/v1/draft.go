@@ -0,0 +1,164 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"fmt"
+
+	"github.com/perses/perses/pkg/client/perseshttp"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+const draftResource = "drafts"
+
+type DraftInterface interface {
+	// Save creates or updates the draft of the dashboard. The published dashboard is not modified.
+	Save(entity *v1.Dashboard) (*v1.DashboardDraft, error)
+	// Discard deletes the draft of the dashboard.
+	Discard(name string) error
+	// Get returns the draft of the dashboard. As such name is the exact name of the dashboard.
+	Get(name string) (*v1.DashboardDraft, error)
+	// prefix is a prefix of the name of the dashboards to search for.
+	// It can be empty in case you want to get the full list of drafts available
+	List(prefix string) ([]*v1.DashboardDraft, error)
+	// Preview returns the dashboard as it will be once the draft is published.
+	Preview(name string) (*v1.Dashboard, error)
+	// Diff returns the differences between the published dashboard and its draft.
+	Diff(name string) (*v1.DashboardDraftDiff, error)
+	RequestReview(name string) (*v1.DashboardDraft, error)
+	Approve(name string) (*v1.DashboardDraft, error)
+	// Publish replaces the published dashboard with its draft.
+	Publish(name string) (*v1.Dashboard, error)
+}
+
+type draft struct {
+	DraftInterface
+	client  *perseshttp.RESTClient
+	project string
+}
+
+func newDraft(client *perseshttp.RESTClient, project string) DraftInterface {
+	return &draft{
+		client:  client,
+		project: project,
+	}
+}
+
+func (c *draft) Save(entity *v1.Dashboard) (*v1.DashboardDraft, error) {
+	result := &v1.DashboardDraft{}
+	err := c.client.Put().
+		Resource(dashboardResource).
+		Name(draftPath(entity.Metadata.Name, "")).
+		Project(c.project).
+		Body(entity).
+		Do().
+		Object(result)
+	return result, err
+}
+
+func (c *draft) Discard(name string) error {
+	return c.client.Delete().
+		Resource(dashboardResource).
+		Name(draftPath(name, "")).
+		Project(c.project).
+		Do().
+		Error()
+}
+
+func (c *draft) Get(name string) (*v1.DashboardDraft, error) {
+	result := &v1.DashboardDraft{}
+	err := c.client.Get().
+		Resource(dashboardResource).
+		Name(draftPath(name, "")).
+		Project(c.project).
+		Do().
+		Object(result)
+	return result, err
+}
+
+func (c *draft) List(prefix string) ([]*v1.DashboardDraft, error) {
+	var result []*v1.DashboardDraft
+	err := c.client.Get().
+		Resource(draftResource).
+		Query(&query{
+			name: prefix,
+		}).
+		Project(c.project).
+		Do().
+		Object(&result)
+	return result, err
+}
+
+func (c *draft) Preview(name string) (*v1.Dashboard, error) {
+	result := &v1.Dashboard{}
+	err := c.client.Get().
+		Resource(dashboardResource).
+		Name(draftPath(name, "preview")).
+		Project(c.project).
+		Do().
+		Object(result)
+	return result, err
+}
+
+func (c *draft) Diff(name string) (*v1.DashboardDraftDiff, error) {
+	result := &v1.DashboardDraftDiff{}
+	err := c.client.Get().
+		Resource(dashboardResource).
+		Name(draftPath(name, "diff")).
+		Project(c.project).
+		Do().
+		Object(result)
+	return result, err
+}
+
+func (c *draft) RequestReview(name string) (*v1.DashboardDraft, error) {
+	result := &v1.DashboardDraft{}
+	err := c.client.Post().
+		Resource(dashboardResource).
+		Name(draftPath(name, "review")).
+		Project(c.project).
+		Do().
+		Object(result)
+	return result, err
+}
+
+func (c *draft) Approve(name string) (*v1.DashboardDraft, error) {
+	result := &v1.DashboardDraft{}
+	err := c.client.Post().
+		Resource(dashboardResource).
+		Name(draftPath(name, "approve")).
+		Project(c.project).
+		Do().
+		Object(result)
+	return result, err
+}
+
+func (c *draft) Publish(name string) (*v1.Dashboard, error) {
+	result := &v1.Dashboard{}
+	err := c.client.Post().
+		Resource(dashboardResource).
+		Name(draftPath(name, "publish")).
+		Project(c.project).
+		Do().
+		Object(result)
+	return result, err
+}
+
+// draftPath returns the path to the draft of the dashboard, followed by the given action if not empty.
+func draftPath(name string, action string) string {
+	if len(action) == 0 {
+		return fmt.Sprintf("%s/draft", name)
+	}
+	return fmt.Sprintf("%s/draft/%s", name, action)
+}
@@ -0,0 +1,103 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"github.com/perses/perses/pkg/client/perseshttp"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+const organizationDatasourceResource = "datasources"
+
+type OrganizationDatasourceInterface interface {
+	Create(entity *v1.OrganizationDatasource) (*v1.OrganizationDatasource, error)
+	Update(entity *v1.OrganizationDatasource) (*v1.OrganizationDatasource, error)
+	Delete(name string) error
+	// Get is returning an unique OrganizationDatasource.
+	// As such name is the exact value of OrganizationDatasource.metadata.name. It cannot be empty.
+	// If you want to perform a research by prefix, please use the method List
+	Get(name string) (*v1.OrganizationDatasource, error)
+	// prefix is a prefix of the OrganizationDatasource.metadata.name to search for.
+	// It can be empty in case you want to get the full list of OrganizationDatasource available
+	List(prefix string) ([]*v1.OrganizationDatasource, error)
+}
+
+type organizationDatasource struct {
+	OrganizationDatasourceInterface
+	client       *perseshttp.RESTClient
+	organization string
+}
+
+func newOrganizationDatasource(client *perseshttp.RESTClient, organization string) OrganizationDatasourceInterface {
+	return &organizationDatasource{
+		client:       client,
+		organization: organization,
+	}
+}
+
+func (c *organizationDatasource) Create(entity *v1.OrganizationDatasource) (*v1.OrganizationDatasource, error) {
+	result := &v1.OrganizationDatasource{}
+	err := c.client.Post().
+		Resource(organizationDatasourceResource).
+		Organization(c.organization).
+		Body(entity).
+		Do().
+		Object(result)
+	return result, err
+}
+
+func (c *organizationDatasource) Update(entity *v1.OrganizationDatasource) (*v1.OrganizationDatasource, error) {
+	result := &v1.OrganizationDatasource{}
+	err := c.client.Put().
+		Resource(organizationDatasourceResource).
+		Name(entity.Metadata.Name).
+		Organization(c.organization).
+		Body(entity).
+		Do().
+		Object(result)
+	return result, err
+}
+
+func (c *organizationDatasource) Delete(name string) error {
+	return c.client.Delete().
+		Resource(organizationDatasourceResource).
+		Name(name).
+		Organization(c.organization).
+		Do().
+		Error()
+}
+
+func (c *organizationDatasource) Get(name string) (*v1.OrganizationDatasource, error) {
+	result := &v1.OrganizationDatasource{}
+	err := c.client.Get().
+		Resource(organizationDatasourceResource).
+		Name(name).
+		Organization(c.organization).
+		Do().
+		Object(result)
+	return result, err
+}
+
+func (c *organizationDatasource) List(prefix string) ([]*v1.OrganizationDatasource, error) {
+	var result []*v1.OrganizationDatasource
+	err := c.client.Get().
+		Resource(organizationDatasourceResource).
+		Query(&query{
+			name: prefix,
+		}).
+		Organization(c.organization).
+		Do().
+		Object(&result)
+	return result, err
+}
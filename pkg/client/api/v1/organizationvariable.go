@@ -0,0 +1,103 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"github.com/perses/perses/pkg/client/perseshttp"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+const organizationVariableResource = "variables"
+
+type OrganizationVariableInterface interface {
+	Create(entity *v1.OrganizationVariable) (*v1.OrganizationVariable, error)
+	Update(entity *v1.OrganizationVariable) (*v1.OrganizationVariable, error)
+	Delete(name string) error
+	// Get is returning an unique OrganizationVariable.
+	// As such name is the exact value of OrganizationVariable.metadata.name. It cannot be empty.
+	// If you want to perform a research by prefix, please use the method List
+	Get(name string) (*v1.OrganizationVariable, error)
+	// prefix is a prefix of the OrganizationVariable.metadata.name to search for.
+	// It can be empty in case you want to get the full list of OrganizationVariable available
+	List(prefix string) ([]*v1.OrganizationVariable, error)
+}
+
+type organizationVariable struct {
+	OrganizationVariableInterface
+	client       *perseshttp.RESTClient
+	organization string
+}
+
+func newOrganizationVariable(client *perseshttp.RESTClient, organization string) OrganizationVariableInterface {
+	return &organizationVariable{
+		client:       client,
+		organization: organization,
+	}
+}
+
+func (c *organizationVariable) Create(entity *v1.OrganizationVariable) (*v1.OrganizationVariable, error) {
+	result := &v1.OrganizationVariable{}
+	err := c.client.Post().
+		Resource(organizationVariableResource).
+		Organization(c.organization).
+		Body(entity).
+		Do().
+		Object(result)
+	return result, err
+}
+
+func (c *organizationVariable) Update(entity *v1.OrganizationVariable) (*v1.OrganizationVariable, error) {
+	result := &v1.OrganizationVariable{}
+	err := c.client.Put().
+		Resource(organizationVariableResource).
+		Name(entity.Metadata.Name).
+		Organization(c.organization).
+		Body(entity).
+		Do().
+		Object(result)
+	return result, err
+}
+
+func (c *organizationVariable) Delete(name string) error {
+	return c.client.Delete().
+		Resource(organizationVariableResource).
+		Name(name).
+		Organization(c.organization).
+		Do().
+		Error()
+}
+
+func (c *organizationVariable) Get(name string) (*v1.OrganizationVariable, error) {
+	result := &v1.OrganizationVariable{}
+	err := c.client.Get().
+		Resource(organizationVariableResource).
+		Name(name).
+		Organization(c.organization).
+		Do().
+		Object(result)
+	return result, err
+}
+
+func (c *organizationVariable) List(prefix string) ([]*v1.OrganizationVariable, error) {
+	var result []*v1.OrganizationVariable
+	err := c.client.Get().
+		Resource(organizationVariableResource).
+		Query(&query{
+			name: prefix,
+		}).
+		Organization(c.organization).
+		Do().
+		Object(&result)
+	return result, err
+}
@@ -0,0 +1,61 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"fmt"
+
+	"github.com/perses/perses/pkg/client/perseshttp"
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+const usageResource = "usage"
+
+type UsageInterface interface {
+	// Dashboards returns the usage statistics of the dashboards, including the ones that have never been viewed.
+	Dashboards(query *v1.DashboardUsageQuery) ([]*v1.DashboardUsageSummary, error)
+	// View records a view of the dashboard.
+	View(project string, dashboard string) error
+}
+
+type usage struct {
+	UsageInterface
+	client *perseshttp.RESTClient
+}
+
+func newUsage(client *perseshttp.RESTClient) UsageInterface {
+	return &usage{
+		client: client,
+	}
+}
+
+func (c *usage) Dashboards(query *v1.DashboardUsageQuery) ([]*v1.DashboardUsageSummary, error) {
+	var result []*v1.DashboardUsageSummary
+	err := c.client.Get().
+		Resource(usageResource).
+		Name(dashboardResource).
+		Query(query).
+		Do().
+		Object(&result)
+	return result, err
+}
+
+func (c *usage) View(project string, dashboard string) error {
+	return c.client.Post().
+		Resource(dashboardResource).
+		Name(fmt.Sprintf("%s/view", dashboard)).
+		Project(project).
+		Do().
+		Error()
+}
@@ -20,6 +20,7 @@ import (
 
 type ValidateInterface interface {
 	Dashboard(entity *v1.Dashboard) error
+	GlobalDashboard(entity *v1.GlobalDashboard) error
 	Datasource(entity *v1.Datasource) error
 	GlobalDatasource(entity *v1.GlobalDatasource) error
 	Variable(entity *v1.Variable) error
@@ -43,6 +44,15 @@ func (c *validate) Dashboard(entity *v1.Dashboard) error {
 		Do().
 		Error()
 }
+func (c *validate) GlobalDashboard(entity *v1.GlobalDashboard) error {
+	return c.client.Post().
+		APIVersion("").
+		Resource("validate/globaldashboards").
+		Body(entity).
+		Do().
+		Error()
+}
+
 func (c *validate) Datasource(entity *v1.Datasource) error {
 	return c.client.Post().
 		APIVersion("").
@@ -47,3 +47,7 @@ func (c *client) Health() v1.HealthInterface {
 func (c *client) Project() v1.ProjectInterface {
 	return &project{}
 }
+
+func (c *client) Team() v1.TeamInterface {
+	return &team{}
+}
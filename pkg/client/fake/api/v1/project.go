@@ -23,22 +23,16 @@ import (
 func ProjectList(prefix string) []*modelV1.Project {
 	initialList := []*modelV1.Project{
 		{
-			Kind: modelV1.KindProject,
-			Metadata: modelV1.Metadata{
-				Name: "perses",
-			},
+			Kind:     modelV1.KindProject,
+			Metadata: *modelV1.NewOrganizationMetadata(modelV1.DefaultOrganization, "perses"),
 		},
 		{
-			Kind: modelV1.KindProject,
-			Metadata: modelV1.Metadata{
-				Name: "Amadeus",
-			},
+			Kind:     modelV1.KindProject,
+			Metadata: *modelV1.NewOrganizationMetadata(modelV1.DefaultOrganization, "Amadeus"),
 		},
 		{
-			Kind: modelV1.KindProject,
-			Metadata: modelV1.Metadata{
-				Name: "Chronosphere",
-			},
+			Kind:     modelV1.KindProject,
+			Metadata: *modelV1.NewOrganizationMetadata(modelV1.DefaultOrganization, "Chronosphere"),
 		},
 	}
 	var result []*modelV1.Project
@@ -68,10 +62,8 @@ func (c *project) Delete(_ string) error {
 
 func (c *project) Get(name string) (*modelV1.Project, error) {
 	return &modelV1.Project{
-		Kind: modelV1.KindProject,
-		Metadata: modelV1.Metadata{
-			Name: name,
-		},
+		Kind:     modelV1.KindProject,
+		Metadata: *modelV1.NewOrganizationMetadata(modelV1.DefaultOrganization, name),
 	}, nil
 }
 
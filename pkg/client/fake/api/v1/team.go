@@ -0,0 +1,90 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fakev1
+
+import (
+	"strings"
+
+	v1 "github.com/perses/perses/pkg/client/api/v1"
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+func TeamList(prefix string) []*modelV1.Team {
+	initialList := []*modelV1.Team{
+		{
+			Kind: modelV1.KindTeam,
+			Metadata: modelV1.Metadata{
+				Name: "observability",
+			},
+			Spec: modelV1.TeamSpec{
+				Members: []modelV1.TeamMember{
+					{
+						Username: "alice",
+						Email:    "alice@perses.dev",
+					},
+					{
+						Username: "bob",
+					},
+				},
+			},
+		},
+		{
+			Kind: modelV1.KindTeam,
+			Metadata: modelV1.Metadata{
+				Name: "platform",
+			},
+		},
+	}
+	var result []*modelV1.Team
+	for _, t := range initialList {
+		if len(prefix) == 0 || strings.HasPrefix(t.Metadata.Name, prefix) {
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+type team struct {
+	v1.TeamInterface
+}
+
+func (c *team) Create(entity *modelV1.Team) (*modelV1.Team, error) {
+	return entity, nil
+}
+
+func (c *team) Update(entity *modelV1.Team) (*modelV1.Team, error) {
+	return entity, nil
+}
+
+func (c *team) Delete(_ string) error {
+	return nil
+}
+
+func (c *team) Get(name string) (*modelV1.Team, error) {
+	for _, t := range TeamList(name) {
+		if t.Metadata.Name == name {
+			return t, nil
+		}
+	}
+	return &modelV1.Team{
+		Kind: modelV1.KindTeam,
+		Metadata: modelV1.Metadata{
+			Name: name,
+		},
+	}, nil
+}
+
+func (c *team) List(prefix string) ([]*modelV1.Team, error) {
+	return TeamList(prefix), nil
+}
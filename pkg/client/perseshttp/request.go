@@ -53,9 +53,10 @@ type Request struct {
 	apiPrefix  string // it's the api prefix such as /api
 	apiVersion string
 	// Resource
-	project  string
-	resource string
-	name     string
+	organization string
+	project      string
+	resource     string
+	name         string
 
 	queryParam url.Values
 	body       io.Reader
@@ -94,6 +95,12 @@ func (r *Request) Project(project string) *Request {
 	return r
 }
 
+// Organization set the organization where the resource must be defined. It cannot be used at the same time with Request.Project
+func (r *Request) Organization(organization string) *Request {
+	r.organization = organization
+	return r
+}
+
 // Resource set the resource that the client want to access (like project, prometheusRule ...etc.)
 func (r *Request) Resource(resource string) *Request {
 	r.resource = resource
@@ -261,6 +268,9 @@ func (r *Request) buildPath() (string, error) {
 	if len(r.project) > 0 {
 		// Project address
 		path.WriteString(fmt.Sprintf("/projects/%s", r.project))
+	} else if len(r.organization) > 0 {
+		// Organization address
+		path.WriteString(fmt.Sprintf("/organizations/%s", r.organization))
 	}
 
 	// Resource type (mandatory)
@@ -282,6 +292,8 @@ type RequestError struct {
 	Message    string
 	StatusCode int
 	Err        error
+	// Body is the raw body of the response, for the errors giving more details than a message.
+	Body []byte
 }
 
 func (re *RequestError) Error() string {
@@ -345,6 +357,7 @@ func (r *Response) Error() error {
 			}
 		}
 		e.StatusCode = r.statusCode
+		e.Body = r.body
 	}
 
 	if e.Err != nil || e.StatusCode > 0 || len(e.Message) > 0 {
@@ -79,6 +79,17 @@ func TestRequest_BuildPath(t *testing.T) {
 			expectedResult: "/api/v1/projects/perses/prometheusrules",
 			expectedError:  false,
 		},
+		{
+			title: "Path using Organizations path",
+			request: &Request{
+				apiPrefix:    defaultAPIPrefix,
+				apiVersion:   defaultAPIVersion,
+				organization: "default",
+				resource:     "datasources",
+			},
+			expectedResult: "/api/v1/organizations/default/datasources",
+			expectedError:  false,
+		},
 	}
 	for _, test := range testSuites {
 		t.Run(test.title, func(t *testing.T) {
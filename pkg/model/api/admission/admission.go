@@ -0,0 +1,66 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package admission
+
+import (
+	"encoding/json"
+
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+type Operation string
+
+const (
+	OperationCreate Operation = "CREATE"
+	OperationUpdate Operation = "UPDATE"
+	OperationDelete Operation = "DELETE"
+)
+
+// UserInfo describes the user sending the request.
+type UserInfo struct {
+	Username string   `json:"username" yaml:"username"`
+	Email    string   `json:"email,omitempty" yaml:"email,omitempty"`
+	Groups   []string `json:"groups,omitempty" yaml:"groups,omitempty"`
+}
+
+// Request is the body sent to the admission webhooks.
+type Request struct {
+	Kind      v1.Kind   `json:"kind" yaml:"kind"`
+	Operation Operation `json:"operation" yaml:"operation"`
+	// User is the user sending the request. It is not set when the request is anonymous.
+	User *UserInfo `json:"user,omitempty" yaml:"user,omitempty"`
+	// Object is the candidate resource. It is not set when the resource is deleted.
+	Object json.RawMessage `json:"object,omitempty" yaml:"object,omitempty"`
+	// OldObject is the resource currently stored. It is not set when the resource is created.
+	OldObject json.RawMessage `json:"old_object,omitempty" yaml:"old_object,omitempty"`
+}
+
+// PatchOperation is an operation of a JSON patch as described in the RFC 6902.
+type PatchOperation struct {
+	// Op is one of add, remove, replace, move, copy or test.
+	Op   string `json:"op" yaml:"op"`
+	Path string `json:"path" yaml:"path"`
+	// From is only used by the operations move and copy.
+	From  string          `json:"from,omitempty" yaml:"from,omitempty"`
+	Value json.RawMessage `json:"value,omitempty" yaml:"value,omitempty"`
+}
+
+// Response is the body expected from the admission webhooks.
+type Response struct {
+	Allowed bool `json:"allowed" yaml:"allowed"`
+	// Message explains why the request is rejected. It is returned to the user.
+	Message string `json:"message,omitempty" yaml:"message,omitempty"`
+	// Patch is applied to the candidate resource. It is only considered when returned by a mutating webhook.
+	Patch []PatchOperation `json:"patch,omitempty" yaml:"patch,omitempty"`
+}
@@ -0,0 +1,125 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package alert
+
+import (
+	"encoding/json"
+	"fmt"
+
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+const (
+	// AnnotationEnabled is the panel annotation used to opt in the alert rule generation. The value must be "true".
+	AnnotationEnabled = "perses.dev/alert"
+	// AnnotationFor overrides the `for` duration of the generated rules. Default value is DefaultFor.
+	AnnotationFor = "perses.dev/alert-for"
+	// AnnotationName overrides the name of the generated alerts. Default value is the name of the panel.
+	AnnotationName = "perses.dev/alert-name"
+	// AnnotationOperator is the comparison operator used between the query and the threshold. Default is ">".
+	AnnotationOperator = "perses.dev/alert-operator"
+	// AnnotationLabels is a comma separated list of key=value added as labels to the generated rules.
+	AnnotationLabels = "perses.dev/alert-labels"
+)
+
+const (
+	DefaultFor      = "5m"
+	DefaultOperator = ">"
+)
+
+const (
+	LabelProject   = "perses_project"
+	LabelDashboard = "perses_dashboard"
+	LabelPanel     = "perses_panel"
+	LabelQuery     = "perses_query"
+	LabelSeverity  = "severity"
+)
+
+// Rule is a Prometheus alerting rule as described in the Prometheus rule file.
+type Rule struct {
+	Alert       string            `json:"alert" yaml:"alert"`
+	Expr        string            `json:"expr" yaml:"expr"`
+	For         string            `json:"for,omitempty" yaml:"for,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty" yaml:"annotations,omitempty"`
+}
+
+// RuleGroup is a Prometheus rule group.
+type RuleGroup struct {
+	Name  string `json:"name" yaml:"name"`
+	Rules []Rule `json:"rules" yaml:"rules"`
+}
+
+// RuleFile is the content of a Prometheus rule file.
+type RuleFile struct {
+	Groups []RuleGroup `json:"groups" yaml:"groups"`
+}
+
+type DriftKind string
+
+const (
+	// DriftMissing means the rule is generated from the dashboard, but it doesn't exist in the current rules.
+	DriftMissing DriftKind = "Missing"
+	// DriftOrphaned means the rule exists in the current rules but nothing in the dashboard is generating it anymore.
+	DriftOrphaned DriftKind = "Orphaned"
+	// DriftChanged means the rule exists on both side but with a different content.
+	DriftChanged DriftKind = "Changed"
+)
+
+// Drift describes a difference between the rules generated from a dashboard and the existing rules.
+type Drift struct {
+	Kind     DriftKind `json:"kind" yaml:"kind"`
+	Group    string    `json:"group" yaml:"group"`
+	Alert    string    `json:"alert" yaml:"alert"`
+	Severity string    `json:"severity,omitempty" yaml:"severity,omitempty"`
+	// Fields contains the list of the fields that are different. Only set when Kind is equal to DriftChanged.
+	Fields []string `json:"fields,omitempty" yaml:"fields,omitempty"`
+}
+
+// Generate is the body of the request used to generate the alert rules from a dashboard.
+type Generate struct {
+	Dashboard *v1.Dashboard `json:"dashboard" yaml:"dashboard"`
+	// Variables is used to set or to override the value of the variables used in the queries.
+	Variables map[string]string `json:"variables,omitempty" yaml:"variables,omitempty"`
+	// Rules is optional. When set, the generated rules are compared with it and the differences are reported.
+	Rules *RuleFile `json:"rules,omitempty" yaml:"rules,omitempty"`
+}
+
+func (g *Generate) UnmarshalJSON(data []byte) error {
+	var tmp Generate
+	type plain Generate
+	if err := json.Unmarshal(data, (*plain)(&tmp)); err != nil {
+		return err
+	}
+	if err := (&tmp).validate(); err != nil {
+		return err
+	}
+	*g = tmp
+	return nil
+}
+
+func (g *Generate) validate() error {
+	if g.Dashboard == nil {
+		return fmt.Errorf("dashboard cannot be empty")
+	}
+	return nil
+}
+
+// GenerateResult is the result of the alert rules generation.
+type GenerateResult struct {
+	RuleFile `json:",inline" yaml:",inline"`
+	// Warnings contains the reasons why some panels have been skipped.
+	Warnings []string `json:"warnings,omitempty" yaml:"warnings,omitempty"`
+	Drift    []Drift  `json:"drift,omitempty" yaml:"drift,omitempty"`
+}
@@ -0,0 +1,92 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analysis
+
+import (
+	"net/url"
+	"strconv"
+
+	v1 "github.com/perses/perses/pkg/model/api/v1"
+)
+
+const DefaultMinUsage = 2
+
+// Query is the list of parameters that can be used to tune the analysis of the queries.
+type Query struct {
+	// Project restricts the analysis to the given project. The global variables are always considered.
+	Project string `query:"project"`
+	// MinUsage is the minimum number of times an expression must be used to be reported.
+	MinUsage int `query:"min_usage"`
+	// Rewrite when true will compute the plan to rewrite the queries with the suggested recording rules.
+	Rewrite bool `query:"rewrite"`
+}
+
+func (q *Query) GetValues() url.Values {
+	values := make(url.Values)
+	if len(q.Project) > 0 {
+		values.Set("project", q.Project)
+	}
+	if q.MinUsage > 0 {
+		values.Set("min_usage", strconv.Itoa(q.MinUsage))
+	}
+	if q.Rewrite {
+		values.Set("rewrite", "true")
+	}
+	return values
+}
+
+// Source is the place where a query is defined.
+type Source struct {
+	Kind    v1.Kind `json:"kind" yaml:"kind"`
+	Project string  `json:"project,omitempty" yaml:"project,omitempty"`
+	Name    string  `json:"name" yaml:"name"`
+	// Location is the path to the query in the resource. For example: spec.panels.cpu.spec.queries[0]
+	Location string `json:"location" yaml:"location"`
+}
+
+// RecordingRule is a Prometheus recording rule.
+type RecordingRule struct {
+	Record string            `json:"record" yaml:"record"`
+	Expr   string            `json:"expr" yaml:"expr"`
+	Labels map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+}
+
+// DuplicatedExpression is an expression used several times across the dashboards and the variables.
+type DuplicatedExpression struct {
+	// Expr is the expression in a canonical form.
+	Expr string `json:"expr" yaml:"expr"`
+	// Usage is the number of times the expression is used.
+	Usage   int      `json:"usage" yaml:"usage"`
+	Sources []Source `json:"sources" yaml:"sources"`
+	// RecordingRule is the suggested rule to precompute the expression.
+	// It is not set when the expression cannot be recorded, Reason explains why.
+	RecordingRule *RecordingRule `json:"recording_rule,omitempty" yaml:"recording_rule,omitempty"`
+	Reason        string         `json:"reason,omitempty" yaml:"reason,omitempty"`
+}
+
+// Rewrite describes how a query should be changed to use the suggested recording rules.
+type Rewrite struct {
+	Source    Source `json:"source" yaml:"source"`
+	Query     string `json:"query" yaml:"query"`
+	Rewritten string `json:"rewritten" yaml:"rewritten"`
+}
+
+// Result is the result of the analysis. The duplicated expressions are sorted by usage, the most used first.
+type Result struct {
+	Duplicates []DuplicatedExpression `json:"duplicates" yaml:"duplicates"`
+	// Rewrites is only set when requested.
+	Rewrites []Rewrite `json:"rewrites,omitempty" yaml:"rewrites,omitempty"`
+	// Errors contains the queries that cannot be parsed.
+	Errors []string `json:"errors,omitempty" yaml:"errors,omitempty"`
+}
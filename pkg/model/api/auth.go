@@ -0,0 +1,56 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Auth is the body of the request used to log in with a login and a password.
+type Auth struct {
+	Login    string `json:"login" yaml:"login"`
+	Password string `json:"password" yaml:"password"`
+}
+
+func (a *Auth) UnmarshalJSON(data []byte) error {
+	var tmp Auth
+	type plain Auth
+	if err := json.Unmarshal(data, (*plain)(&tmp)); err != nil {
+		return err
+	}
+	if err := (&tmp).validate(); err != nil {
+		return err
+	}
+	*a = tmp
+	return nil
+}
+
+func (a *Auth) validate() error {
+	if len(a.Login) == 0 {
+		return fmt.Errorf("login cannot be empty")
+	}
+	if len(a.Password) == 0 {
+		return fmt.Errorf("password cannot be empty")
+	}
+	return nil
+}
+
+// AuthResponse contains the access token delivered once logged in.
+// It must be sent in the header Authorization: Bearer <access_token>.
+type AuthResponse struct {
+	AccessToken string    `json:"access_token" yaml:"access_token"`
+	ExpiresAt   time.Time `json:"expires_at" yaml:"expires_at"`
+}
@@ -0,0 +1,102 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"github.com/perses/perses/pkg/model/api/v1/common"
+)
+
+const (
+	// KindMergeSeries merges the results of all the queries into a single result.
+	// The series having the same name and the same labels are merged into a single series.
+	KindMergeSeries = "MergeSeries"
+	// KindRenameLabels renames the labels of every series.
+	KindRenameLabels = "RenameLabels"
+	// KindFilterByValue keeps the data points (or the series when a calculation is set) matching a condition.
+	KindFilterByValue = "FilterByValue"
+	// KindBinaryOperation computes a new result from the series of two queries, for example a ratio A/B.
+	KindBinaryOperation = "BinaryOperation"
+	// KindReduceToTable reduces every series to a row of a table.
+	KindReduceToTable = "ReduceToTable"
+)
+
+// Point is the value of a series at a given time.
+// Like in the UI, it is serialized as a tuple [timestamp, value] where the timestamp is in milliseconds.
+// Value is nil when the value is missing.
+type Point struct {
+	Timestamp int64
+	Value     *float64
+}
+
+func NewPoint(timestamp int64, value float64) Point {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return Point{Timestamp: timestamp}
+	}
+	return Point{Timestamp: timestamp, Value: &value}
+}
+
+func (p Point) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]interface{}{p.Timestamp, p.Value})
+}
+
+func (p *Point) UnmarshalJSON(data []byte) error {
+	var tuple []*float64
+	if err := json.Unmarshal(data, &tuple); err != nil {
+		return err
+	}
+	if len(tuple) != 2 || tuple[0] == nil {
+		return fmt.Errorf("a point must be a tuple [timestamp, value]")
+	}
+	p.Timestamp = int64(*tuple[0])
+	p.Value = tuple[1]
+	return nil
+}
+
+// Series is a time series returned by a query.
+type Series struct {
+	Name   string            `json:"name" yaml:"name"`
+	Labels map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+	Values []Point           `json:"values" yaml:"values"`
+}
+
+// Result contains the series returned by a query.
+type Result struct {
+	Series []Series `json:"series" yaml:"series"`
+}
+
+// Table is the result of the transformation ReduceToTable.
+// Each row contains the name of the series, the value of each label and the value of each calculation
+// (nil when it cannot be calculated).
+type Table struct {
+	Columns []string        `json:"columns" yaml:"columns"`
+	Rows    [][]interface{} `json:"rows" yaml:"rows"`
+}
+
+// Data is the data displayed by a panel.
+type Data struct {
+	// Results contains the result of each query, in the order of the queries of the panel.
+	Results []Result `json:"results" yaml:"results"`
+	// Table is set once the series have been reduced to a table. Results is then empty.
+	Table *Table `json:"table,omitempty" yaml:"table,omitempty"`
+}
+
+// Apply is the body of the request used to apply the transformations of a panel on the results of its queries.
+type Apply struct {
+	Transformations []common.Plugin `json:"transformations" yaml:"transformations"`
+	Data            Data            `json:"data" yaml:"data"`
+}
@@ -24,10 +24,96 @@ import (
 	"github.com/prometheus/common/model"
 )
 
+// QueryOptions change the way the queries are executed.
+// When they are set on a panel, they apply to every query of the panel. A query can override them with its own options.
+type QueryOptions struct {
+	// TimeShift moves the time range of the queries in the past. For example, "1w" compares the data with the week before.
+	TimeShift model.Duration `json:"time_shift,omitempty" yaml:"time_shift,omitempty"`
+	// RelativeTime replaces the time range of the dashboard by the last RelativeTime.
+	RelativeTime model.Duration `json:"relative_time,omitempty" yaml:"relative_time,omitempty"`
+	// MinStep is the lower bound of the interval between two data points.
+	MinStep model.Duration `json:"min_step,omitempty" yaml:"min_step,omitempty"`
+	// MaxDataPoints is the maximum number of data points returned for each series.
+	MaxDataPoints int `json:"max_data_points,omitempty" yaml:"max_data_points,omitempty"`
+	// HideFromLegend removes the series returned by the queries from the legend.
+	HideFromLegend bool `json:"hide_from_legend,omitempty" yaml:"hide_from_legend,omitempty"`
+}
+
+func (o *QueryOptions) UnmarshalJSON(data []byte) error {
+	var tmp QueryOptions
+	type plain QueryOptions
+	if err := json.Unmarshal(data, (*plain)(&tmp)); err != nil {
+		return err
+	}
+	if err := (&tmp).validate(); err != nil {
+		return err
+	}
+	*o = tmp
+	return nil
+}
+
+func (o *QueryOptions) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var tmp QueryOptions
+	type plain QueryOptions
+	if err := unmarshal((*plain)(&tmp)); err != nil {
+		return err
+	}
+	if err := (&tmp).validate(); err != nil {
+		return err
+	}
+	*o = tmp
+	return nil
+}
+
+func (o *QueryOptions) validate() error {
+	if o.MaxDataPoints < 0 {
+		return fmt.Errorf("max_data_points cannot be negative")
+	}
+	if o.RelativeTime > 0 && o.MinStep > o.RelativeTime {
+		return fmt.Errorf("min_step cannot be greater than relative_time")
+	}
+	return nil
+}
+
+// Merge returns the options resulting of the given options applied over the current ones.
+// Both options can be nil.
+func (o *QueryOptions) Merge(override *QueryOptions) *QueryOptions {
+	if o == nil {
+		return override
+	}
+	if override == nil {
+		return o
+	}
+	result := *o
+	if override.TimeShift > 0 {
+		result.TimeShift = override.TimeShift
+	}
+	if override.RelativeTime > 0 {
+		result.RelativeTime = override.RelativeTime
+	}
+	if override.MinStep > 0 {
+		result.MinStep = override.MinStep
+	}
+	if override.MaxDataPoints > 0 {
+		result.MaxDataPoints = override.MaxDataPoints
+	}
+	if override.HideFromLegend {
+		result.HideFromLegend = true
+	}
+	return &result
+}
+
 type PanelSpec struct {
 	Display common.Display `json:"display" yaml:"display"`
 	Plugin  common.Plugin  `json:"plugin" yaml:"plugin"`
-	Queries []Query        `json:"queries,omitempty" yaml:"queries,omitempty"`
+	// Options are the query options applied to every query of the panel.
+	Options *QueryOptions `json:"options,omitempty" yaml:"options,omitempty"`
+	Queries []Query       `json:"queries,omitempty" yaml:"queries,omitempty"`
+	// Transformations are applied in order to the results of the queries before they are displayed.
+	Transformations []common.Plugin `json:"transformations,omitempty" yaml:"transformations,omitempty"`
+	// Annotations is a free key/value map that can be used by tooling around the dashboard.
+	// For example, the alert rule generator is looking at the annotations prefixed by "perses.dev/alert".
+	Annotations map[string]string `json:"annotations,omitempty" yaml:"annotations,omitempty"`
 }
 
 type Panel struct {
@@ -38,22 +124,120 @@ type Panel struct {
 type Query struct {
 	Kind string    `json:"kind" yaml:"kind"`
 	Spec QuerySpec `json:"spec" yaml:"spec"`
+	// Ref is set when the query is a reference to a query shared in the dashboard, like {"$ref": "#/spec/queries/<name>"}.
+	// Kind and Spec are then empty, use Resolve to get the query referenced.
+	Ref *common.JSONRef `json:"-" yaml:"-"`
+}
+
+func (q Query) MarshalJSON() ([]byte, error) {
+	if q.Ref != nil {
+		return json.Marshal(q.Ref)
+	}
+	type plain Query
+	return json.Marshal(plain(q))
+}
+
+func (q Query) MarshalYAML() (interface{}, error) {
+	if q.Ref != nil {
+		return q.Ref, nil
+	}
+	type plain Query
+	return plain(q), nil
+}
+
+func (q *Query) UnmarshalJSON(data []byte) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	if _, isRef := fields["$ref"]; isRef {
+		ref := &common.JSONRef{}
+		if err := json.Unmarshal(data, ref); err != nil {
+			return err
+		}
+		*q = Query{Ref: ref}
+		return nil
+	}
+	var tmp Query
+	type plain Query
+	if err := json.Unmarshal(data, (*plain)(&tmp)); err != nil {
+		return err
+	}
+	*q = tmp
+	return nil
+}
+
+func (q *Query) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var fields map[string]interface{}
+	if err := unmarshal(&fields); err != nil {
+		return err
+	}
+	if _, isRef := fields["$ref"]; isRef {
+		ref := &common.JSONRef{}
+		if err := unmarshal(ref); err != nil {
+			return err
+		}
+		*q = Query{Ref: ref}
+		return nil
+	}
+	var tmp Query
+	type plain Query
+	if err := unmarshal((*plain)(&tmp)); err != nil {
+		return err
+	}
+	*q = tmp
+	return nil
+}
+
+// Resolve returns the query shared in the dashboard when the query is a reference, the query itself otherwise.
+// A reference is resolved when the dashboard is unmarshalled. If it is not, Resolve returns the reference.
+func (q *Query) Resolve() *Query {
+	if q.Ref != nil {
+		if shared, ok := q.Ref.Object.(*Query); ok {
+			return shared
+		}
+	}
+	return q
 }
 
 type QuerySpec struct {
 	Plugin common.Plugin `json:"plugin" yaml:"plugin"`
+	// Options override the query options of the panel for this query.
+	Options *QueryOptions `json:"options,omitempty" yaml:"options,omitempty"`
+}
+
+// GetQueries returns the queries of the panel, the references to the shared queries being resolved.
+func (p *PanelSpec) GetQueries() []Query {
+	result := make([]Query, 0, len(p.Queries))
+	for i := range p.Queries {
+		result = append(result, *p.Queries[i].Resolve())
+	}
+	return result
+}
+
+// GetQueryOptions returns the options of the query at the given index, once merged with the options of the panel.
+func (p *PanelSpec) GetQueryOptions(index int) *QueryOptions {
+	if index < 0 || index >= len(p.Queries) {
+		return p.Options
+	}
+	return p.Options.Merge(p.Queries[index].Resolve().Spec.Options)
 }
 
 type DashboardSpec struct {
 	Display *common.Display `json:"display,omitempty" yaml:"display,omitempty"`
+	// Tags is a list of keywords used to search and to select the dashboards, for example in a playlist.
+	Tags []string `json:"tags,omitempty" yaml:"tags,omitempty"`
 	// Datasources is an optional list of datasource definition.
 	Datasources map[string]*DatasourceSpec `json:"datasources,omitempty" yaml:"datasources,omitempty"`
 	// Duration is the default time you would like to use to looking in the past when getting data to fill the
 	// dashboard
 	Duration  model.Duration       `json:"duration" yaml:"duration"`
 	Variables []dashboard.Variable `json:"variables,omitempty" yaml:"variables,omitempty"`
-	Panels    map[string]*Panel    `json:"panels" yaml:"panels"`
-	Layouts   []dashboard.Layout   `json:"layouts" yaml:"layouts"`
+	// Queries are the queries shared by several panels. A panel uses one of them with a JSON reference in its list of
+	// queries, like {"$ref": "#/spec/queries/<name>"}.
+	Queries map[string]*Query  `json:"queries,omitempty" yaml:"queries,omitempty"`
+	Panels  map[string]*Panel  `json:"panels" yaml:"panels"`
+	Layouts []dashboard.Layout `json:"layouts" yaml:"layouts"`
 }
 
 func (d *DashboardSpec) UnmarshalJSON(data []byte) error {
@@ -100,9 +284,30 @@ func (d *DashboardSpec) validate() error {
 			return err
 		}
 	}
+	for queryKey, query := range d.Queries {
+		if err := common.ValidateID(queryKey); err != nil {
+			return err
+		}
+		if query == nil {
+			return fmt.Errorf("the shared query %q cannot be empty", queryKey)
+		}
+		if query.Ref != nil {
+			return fmt.Errorf("the shared query %q cannot be a reference", queryKey)
+		}
+	}
 	return nil
 }
 
+// HasTag returns true when the dashboard is tagged with the given tag.
+func (d *DashboardSpec) HasTag(tag string) bool {
+	for _, t := range d.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
 type Dashboard struct {
 	Kind     Kind            `json:"kind" yaml:"kind"`
 	Metadata ProjectMetadata `json:"metadata" yaml:"metadata"`
@@ -154,12 +359,12 @@ func (d *Dashboard) validate() error {
 	if reflect.DeepEqual(d.Spec, DashboardSpec{}) {
 		return fmt.Errorf("spec cannot be empty")
 	}
-	return d.verifyAndSetJSONReferences()
+	return d.Spec.verifyAndSetJSONReferences()
 }
 
 // verifyAndSetJSONRef will check that each JSON Reference are pointing to an existing object and will set the related pointer in the JSONRef.Object
-func (d *Dashboard) verifyAndSetJSONReferences() error {
-	for _, layout := range d.Spec.Layouts {
+func (d *DashboardSpec) verifyAndSetJSONReferences() error {
+	for _, layout := range d.Layouts {
 		switch spec := layout.Spec.(type) {
 		case *dashboard.GridLayoutSpec:
 			for _, item := range spec.Items {
@@ -170,11 +375,27 @@ func (d *Dashboard) verifyAndSetJSONReferences() error {
 
 		}
 	}
+	for _, panel := range d.Panels {
+		if panel == nil {
+			continue
+		}
+		for _, query := range panel.Spec.Queries {
+			if query.Ref == nil {
+				continue
+			}
+			if err := d.checkAndSetRef(query.Ref); err != nil {
+				return err
+			}
+			if _, ok := query.Ref.Object.(*Query); !ok {
+				return fmt.Errorf("reference %q used as a query is not pointing to a query", query.Ref.Ref)
+			}
+		}
+	}
 	return nil
 }
 
-func (d *Dashboard) checkAndSetRef(ref *common.JSONRef) error {
-	// ref.Path should like that [ "spec", "panels", <name> ].
+func (d *DashboardSpec) checkAndSetRef(ref *common.JSONRef) error {
+	// ref.Path should like that [ "spec", "panels", <name> ] or [ "spec", "queries", <name> ].
 	// So if the array is not equal to three then the reference is wrong.
 	if len(ref.Path) != 3 {
 		return fmt.Errorf("reference %q is pointing to the void", ref.Ref)
@@ -184,13 +405,88 @@ func (d *Dashboard) checkAndSetRef(ref *common.JSONRef) error {
 	}
 	switch ref.Path[1] {
 	case "panels":
-		obj, ok := d.Spec.Panels[ref.Path[2]]
+		obj, ok := d.Panels[ref.Path[2]]
 		if !ok {
 			return fmt.Errorf("there is no existing panel called %q in the current dashboard", ref.Path[2])
 		}
 		ref.Object = obj
+	case "queries":
+		obj, ok := d.Queries[ref.Path[2]]
+		if !ok {
+			return fmt.Errorf("there is no existing query called %q in the current dashboard", ref.Path[2])
+		}
+		ref.Object = obj
 	default:
 		return fmt.Errorf("%q is not a known object", ref.Path[1])
 	}
 	return nil
 }
+
+// GlobalDashboard is a dashboard available in every project.
+// When it is viewed from a project, the datasources are resolved against the datasources of this project.
+type GlobalDashboard struct {
+	Kind     Kind          `json:"kind" yaml:"kind"`
+	Metadata Metadata      `json:"metadata" yaml:"metadata"`
+	Spec     DashboardSpec `json:"spec" yaml:"spec"`
+}
+
+func (d *GlobalDashboard) GetMetadata() modelAPI.Metadata {
+	return &d.Metadata
+}
+
+func (d *GlobalDashboard) GetKind() string {
+	return string(d.Kind)
+}
+
+func (d *GlobalDashboard) GetSpec() interface{} {
+	return d.Spec
+}
+
+func (d *GlobalDashboard) UnmarshalJSON(data []byte) error {
+	var tmp GlobalDashboard
+	type plain GlobalDashboard
+	if err := json.Unmarshal(data, (*plain)(&tmp)); err != nil {
+		return err
+	}
+	if err := (&tmp).validate(); err != nil {
+		return err
+	}
+	*d = tmp
+	return nil
+}
+
+func (d *GlobalDashboard) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var tmp GlobalDashboard
+	type plain GlobalDashboard
+	if err := unmarshal((*plain)(&tmp)); err != nil {
+		return err
+	}
+	if err := (&tmp).validate(); err != nil {
+		return err
+	}
+	*d = tmp
+	return nil
+}
+
+func (d *GlobalDashboard) validate() error {
+	if d.Kind != KindGlobalDashboard {
+		return fmt.Errorf("invalid kind: %q for a GlobalDashboard type", d.Kind)
+	}
+	if reflect.DeepEqual(d.Spec, DashboardSpec{}) {
+		return fmt.Errorf("spec cannot be empty")
+	}
+	return d.Spec.verifyAndSetJSONReferences()
+}
+
+// InProject returns the global dashboard as a dashboard of the given project,
+// so the datasources and the variables are resolved like for any dashboard of the project.
+func (d *GlobalDashboard) InProject(project string) *Dashboard {
+	return &Dashboard{
+		Kind: KindDashboard,
+		Metadata: ProjectMetadata{
+			Metadata: d.Metadata,
+			Project:  project,
+		},
+		Spec: d.Spec,
+	}
+}
@@ -18,11 +18,20 @@ import (
 	"reflect"
 	"regexp"
 	"strconv"
+	"strings"
 
 	"github.com/perses/perses/pkg/model/api/v1/common"
 )
 
-var variableTemplateSyntaxRegexp = regexp.MustCompile(`\$([a-zA-Z0-9_-]+)`)
+var (
+	variableTemplateSyntaxRegexp = regexp.MustCompile(`\$([a-zA-Z0-9_-]+)`)
+	// logQLTemplateRegexp matches the Go templates used by the LogQL stages `line_format` and `label_format`.
+	// The `$` found in these templates are template variables (e.g. `{{ $level := .level }}`) and not Perses variables.
+	logQLTemplateRegexp = regexp.MustCompile(`(?s){{.*?}}`)
+)
+
+// lokiPluginPrefix is the prefix of the kind of the Loki plugins, which all use LogQL.
+const lokiPluginPrefix = "Loki"
 
 type VariableGroup struct {
 	Variables []string
@@ -89,11 +98,17 @@ func buildVariableDependencies(variables []Variable) (map[string][]string, error
 
 func findAllVariableUsedInPlugin(plugin common.Plugin) [][]string {
 	var matches [][]string
-	findAllVariableUsed(reflect.ValueOf(plugin.Spec), &matches)
+	parse := parseVariableUsed
+	if strings.HasPrefix(plugin.Kind, lokiPluginPrefix) {
+		parse = parseLogQLVariableUsed
+	}
+	findAllVariableUsed(reflect.ValueOf(plugin.Spec), parse, &matches)
 	return matches
 }
 
-func findAllVariableUsed(v reflect.Value, matches *[][]string) {
+// findAllVariableUsed looks for the variables used in every string found in v. The strings are parsed with the
+// given function, as the way to find a variable depends on the language of the query.
+func findAllVariableUsed(v reflect.Value, parse func(string) [][]string, matches *[][]string) {
 	if len(v.Type().PkgPath()) > 0 {
 		// the field is not exported, so no need to look at it as we won't be able to set it in a later stage
 		return
@@ -102,44 +117,44 @@ func findAllVariableUsed(v reflect.Value, matches *[][]string) {
 
 	switch v.Kind() {
 	case reflect.Map:
-		findVariableInMap(v, matches)
+		findVariableInMap(v, parse, matches)
 	case reflect.Slice:
-		findVariableInSlice(v, matches)
+		findVariableInSlice(v, parse, matches)
 	case reflect.Struct:
-		findVariableInStruct(v, matches)
+		findVariableInStruct(v, parse, matches)
 	}
 }
 
-func findVariableInMap(v reflect.Value, matches *[][]string) {
+func findVariableInMap(v reflect.Value, parse func(string) [][]string, matches *[][]string) {
 	// It's not possible that a variable is used a key in a map.
 	// Simply because the key is supposed to be the name of a field in a proper struct.
 	// Map here is the generic struct that represents the JSON / Yaml file
 	for _, key := range v.MapKeys() {
-		extractVariableInStringOrInSomethingElse(v.MapIndex(key), matches)
+		extractVariableInStringOrInSomethingElse(v.MapIndex(key), parse, matches)
 	}
 }
 
-func findVariableInSlice(v reflect.Value, matches *[][]string) {
+func findVariableInSlice(v reflect.Value, parse func(string) [][]string, matches *[][]string) {
 	for i := 0; i < v.Len(); i++ {
-		extractVariableInStringOrInSomethingElse(v.Index(i), matches)
+		extractVariableInStringOrInSomethingElse(v.Index(i), parse, matches)
 	}
 }
 
-func findVariableInStruct(v reflect.Value, matches *[][]string) {
+func findVariableInStruct(v reflect.Value, parse func(string) [][]string, matches *[][]string) {
 	// Same logic than for the map, we are only looking for the value and not the field itself.
 	for i := 0; i < v.NumField(); i++ {
-		extractVariableInStringOrInSomethingElse(v.Field(i), matches)
+		extractVariableInStringOrInSomethingElse(v.Field(i), parse, matches)
 	}
 }
 
-func extractVariableInStringOrInSomethingElse(v reflect.Value, matches *[][]string) {
+func extractVariableInStringOrInSomethingElse(v reflect.Value, parse func(string) [][]string, matches *[][]string) {
 	// It's highly possible, the value is a pointer or an interface.
 	// As we are not interested in these two type, we want to move forward and see what is behind the pointer / interface.
 	v = common.GetReflectNextElem(v)
 	if v.Kind() == reflect.String {
-		*matches = append(*matches, parseVariableUsed(v.String())...)
+		*matches = append(*matches, parse(v.String())...)
 	}
-	findAllVariableUsed(v, matches)
+	findAllVariableUsed(v, parse, matches)
 }
 
 func parseVariableUsed(str string) [][]string {
@@ -159,6 +174,11 @@ func parseVariableUsed(str string) [][]string {
 	return result
 }
 
+// parseLogQLVariableUsed is parseVariableUsed for LogQL: the templates of the formatting stages are ignored.
+func parseLogQLVariableUsed(str string) [][]string {
+	return parseVariableUsed(logQLTemplateRegexp.ReplaceAllString(str, ""))
+}
+
 type node struct {
 	name     string
 	children map[string]*node
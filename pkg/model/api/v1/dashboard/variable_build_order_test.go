@@ -324,6 +324,42 @@ func TestBuildVariableDependencies(t *testing.T) {
 				},
 			},
 		},
+		{
+			title: "loki variable ignoring the template variables of LogQL",
+			variables: []Variable{
+				{
+					Kind: variable.KindList,
+					Spec: &ListVariableSpec{
+						ListSpec: variable.ListSpec{
+							Plugin: common.Plugin{
+								Kind: "LokiLabelValuesVariable",
+								Spec: map[string]interface{}{
+									"label_name": "pod",
+									"matchers": []interface{}{
+										`{namespace="$namespace"} | logfmt | line_format "{{ $level := .level }}{{ $level }}: {{ .msg }}"`,
+									},
+								},
+							},
+						},
+						Name: "pod",
+					},
+				},
+				{
+					Kind: variable.KindText,
+					Spec: &TextVariableSpec{
+						TextSpec: variable.TextSpec{
+							Value: "default",
+						},
+						Name: "namespace",
+					},
+				},
+			},
+			result: map[string][]string{
+				"pod": {
+					"namespace",
+				},
+			},
+		},
 	}
 	for _, test := range testSuite {
 		t.Run(test.title, func(t *testing.T) {
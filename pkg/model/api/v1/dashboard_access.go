@@ -0,0 +1,152 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+
+	modelAPI "github.com/perses/perses/pkg/model/api"
+)
+
+// DashboardGrant gives a role on a single dashboard to a user or to a group.
+// Only one of User or Group can be set.
+type DashboardGrant struct {
+	User  string `json:"user,omitempty" yaml:"user,omitempty"`
+	Group string `json:"group,omitempty" yaml:"group,omitempty"`
+	// Role is the role given on the dashboard. Only RoleViewer and RoleEditor can be granted.
+	Role Role `json:"role" yaml:"role"`
+}
+
+func (g *DashboardGrant) validate() error {
+	if len(g.User) == 0 && len(g.Group) == 0 {
+		return fmt.Errorf("a grant must target a user or a group")
+	}
+	if len(g.User) > 0 && len(g.Group) > 0 {
+		return fmt.Errorf("a grant cannot target a user and a group at the same time")
+	}
+	if g.Role != RoleViewer && g.Role != RoleEditor {
+		return fmt.Errorf("only the roles %q and %q can be granted on a dashboard", RoleViewer, RoleEditor)
+	}
+	return nil
+}
+
+type DashboardAccessSpec struct {
+	Grants []DashboardGrant `json:"grants" yaml:"grants"`
+}
+
+func (s *DashboardAccessSpec) UnmarshalJSON(data []byte) error {
+	var tmp DashboardAccessSpec
+	type plain DashboardAccessSpec
+	if err := json.Unmarshal(data, (*plain)(&tmp)); err != nil {
+		return err
+	}
+	if err := (&tmp).validate(); err != nil {
+		return err
+	}
+	*s = tmp
+	return nil
+}
+
+func (s *DashboardAccessSpec) validate() error {
+	for i := range s.Grants {
+		if err := s.Grants[i].validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetRole returns the highest role granted to the user or to one of its groups. It returns an empty role when nothing is granted.
+func (s *DashboardAccessSpec) GetRole(username string, groups []string) Role {
+	var result Role
+	for _, grant := range s.Grants {
+		if !grant.matches(username, groups) {
+			continue
+		}
+		if len(result) == 0 || grant.Role.Includes(result) {
+			result = grant.Role
+		}
+	}
+	return result
+}
+
+func (g *DashboardGrant) matches(username string, groups []string) bool {
+	if len(g.User) > 0 {
+		return g.User == username
+	}
+	for _, group := range groups {
+		if group == g.Group {
+			return true
+		}
+	}
+	return false
+}
+
+// DashboardAccess is storing the grants of a dashboard. They are evaluated in addition to the permissions on the project.
+// It is named after the dashboard and is managed through the dashboard, so it is not exposed like the other resources.
+type DashboardAccess struct {
+	Kind     Kind                `json:"kind" yaml:"kind"`
+	Metadata ProjectMetadata     `json:"metadata" yaml:"metadata"`
+	Spec     DashboardAccessSpec `json:"spec" yaml:"spec"`
+}
+
+func (d *DashboardAccess) GetMetadata() modelAPI.Metadata {
+	return &d.Metadata
+}
+
+func (d *DashboardAccess) GetKind() string {
+	return string(d.Kind)
+}
+
+func (d *DashboardAccess) GetSpec() interface{} {
+	return d.Spec
+}
+
+func (d *DashboardAccess) UnmarshalJSON(data []byte) error {
+	var tmp DashboardAccess
+	type plain DashboardAccess
+	if err := json.Unmarshal(data, (*plain)(&tmp)); err != nil {
+		return err
+	}
+	if err := (&tmp).validate(); err != nil {
+		return err
+	}
+	*d = tmp
+	return nil
+}
+
+func (d *DashboardAccess) validate() error {
+	if d.Kind != KindDashboardAccess {
+		return fmt.Errorf("invalid kind: %q for a DashboardAccess type", d.Kind)
+	}
+	return nil
+}
+
+type AccessSource string
+
+const (
+	// AccessSourceProject means the access comes from a permission on the project.
+	AccessSourceProject AccessSource = "Project"
+	// AccessSourceDashboard means the access comes from a grant on the dashboard.
+	AccessSourceDashboard AccessSource = "Dashboard"
+)
+
+// DashboardAccessEntry describes who has access to a dashboard and why.
+type DashboardAccessEntry struct {
+	User   string       `json:"user,omitempty" yaml:"user,omitempty"`
+	Group  string       `json:"group,omitempty" yaml:"group,omitempty"`
+	Role   Role         `json:"role" yaml:"role"`
+	Source AccessSource `json:"source" yaml:"source"`
+}
@@ -0,0 +1,65 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnmarshalDashboardAccessSpecError(t *testing.T) {
+	testSuite := []struct {
+		title string
+		jason string
+		err   error
+	}{
+		{
+			title: "grant without user or group",
+			jason: `{"grants": [{"role": "viewer"}]}`,
+			err:   fmt.Errorf("a grant must target a user or a group"),
+		},
+		{
+			title: "grant with a user and a group",
+			jason: `{"grants": [{"user": "jdoe", "group": "partners", "role": "viewer"}]}`,
+			err:   fmt.Errorf("a grant cannot target a user and a group at the same time"),
+		},
+		{
+			title: "admin cannot be granted",
+			jason: `{"grants": [{"user": "jdoe", "role": "admin"}]}`,
+			err:   fmt.Errorf("only the roles \"viewer\" and \"editor\" can be granted on a dashboard"),
+		},
+	}
+	for _, test := range testSuite {
+		t.Run(test.title, func(t *testing.T) {
+			result := &DashboardAccessSpec{}
+			assert.Equal(t, test.err, json.Unmarshal([]byte(test.jason), result))
+		})
+	}
+}
+
+func TestDashboardAccessSpec_GetRole(t *testing.T) {
+	spec := DashboardAccessSpec{
+		Grants: []DashboardGrant{
+			{Group: "partners", Role: RoleViewer},
+			{User: "jdoe", Role: RoleEditor},
+			{Group: "support", Role: RoleViewer},
+		},
+	}
+	assert.Equal(t, RoleEditor, spec.GetRole("jdoe", []string{"partners"}))
+	assert.Equal(t, RoleViewer, spec.GetRole("alice", []string{"support"}))
+	assert.Equal(t, Role(""), spec.GetRole("alice", []string{"devs"}))
+}
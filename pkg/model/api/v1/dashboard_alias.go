@@ -0,0 +1,69 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+
+	modelAPI "github.com/perses/perses/pkg/model/api"
+)
+
+type DashboardAliasSpec struct {
+	// Dashboard is the name of the dashboard of the project the alias is redirecting to.
+	Dashboard string `json:"dashboard" yaml:"dashboard"`
+}
+
+// DashboardAlias is the previous name of a renamed dashboard.
+// It is named after the previous name and is maintained by the server, so it is not exposed like the other resources.
+type DashboardAlias struct {
+	Kind     Kind               `json:"kind" yaml:"kind"`
+	Metadata ProjectMetadata    `json:"metadata" yaml:"metadata"`
+	Spec     DashboardAliasSpec `json:"spec" yaml:"spec"`
+}
+
+func (d *DashboardAlias) GetMetadata() modelAPI.Metadata {
+	return &d.Metadata
+}
+
+func (d *DashboardAlias) GetKind() string {
+	return string(d.Kind)
+}
+
+func (d *DashboardAlias) GetSpec() interface{} {
+	return d.Spec
+}
+
+func (d *DashboardAlias) UnmarshalJSON(data []byte) error {
+	var tmp DashboardAlias
+	type plain DashboardAlias
+	if err := json.Unmarshal(data, (*plain)(&tmp)); err != nil {
+		return err
+	}
+	if err := (&tmp).validate(); err != nil {
+		return err
+	}
+	*d = tmp
+	return nil
+}
+
+func (d *DashboardAlias) validate() error {
+	if d.Kind != KindDashboardAlias {
+		return fmt.Errorf("invalid kind: %q for a DashboardAlias type", d.Kind)
+	}
+	if len(d.Spec.Dashboard) == 0 {
+		return fmt.Errorf("spec.dashboard cannot be empty")
+	}
+	return nil
+}
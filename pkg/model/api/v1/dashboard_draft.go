@@ -0,0 +1,136 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+
+	modelAPI "github.com/perses/perses/pkg/model/api"
+)
+
+type DraftStatus string
+
+const (
+	// DraftStatusDraft is the status of a draft being edited.
+	DraftStatusDraft DraftStatus = "Draft"
+	// DraftStatusInReview is the status of a draft waiting for an approval.
+	DraftStatusInReview DraftStatus = "InReview"
+	// DraftStatusApproved is the status of a draft that has been approved and can be published.
+	DraftStatusApproved DraftStatus = "Approved"
+)
+
+var draftStatusMap = map[DraftStatus]bool{
+	DraftStatusDraft:    true,
+	DraftStatusInReview: true,
+	DraftStatusApproved: true,
+}
+
+type DashboardDraftSpec struct {
+	Status DraftStatus `json:"status" yaml:"status"`
+	// BaseVersion is the version of the published dashboard the draft has been created from.
+	// It is not set when the dashboard has never been published.
+	BaseVersion *uint64 `json:"base_version,omitempty" yaml:"base_version,omitempty"`
+	// Base is the spec of the published dashboard the draft has been created from. It is used to merge the draft with
+	// the changes published in the meantime.
+	Base *DashboardSpec `json:"base,omitempty" yaml:"base,omitempty"`
+	// Dashboard is the draft revision of the dashboard.
+	Dashboard DashboardSpec `json:"dashboard" yaml:"dashboard"`
+	// Author is the user who saved the draft the last time. It is empty when the authentication is not enabled.
+	Author string `json:"author,omitempty" yaml:"author,omitempty"`
+	// Reviewer is the user who approved the draft.
+	Reviewer string `json:"reviewer,omitempty" yaml:"reviewer,omitempty"`
+}
+
+// DashboardDraft is the pending revision of a dashboard.
+// It is named after the dashboard and is stored separately, so it is never returned with the published dashboards.
+type DashboardDraft struct {
+	Kind     Kind               `json:"kind" yaml:"kind"`
+	Metadata ProjectMetadata    `json:"metadata" yaml:"metadata"`
+	Spec     DashboardDraftSpec `json:"spec" yaml:"spec"`
+}
+
+func (d *DashboardDraft) GetMetadata() modelAPI.Metadata {
+	return &d.Metadata
+}
+
+func (d *DashboardDraft) GetKind() string {
+	return string(d.Kind)
+}
+
+func (d *DashboardDraft) GetSpec() interface{} {
+	return d.Spec
+}
+
+func (d *DashboardDraft) UnmarshalJSON(data []byte) error {
+	var tmp DashboardDraft
+	type plain DashboardDraft
+	if err := json.Unmarshal(data, (*plain)(&tmp)); err != nil {
+		return err
+	}
+	if err := (&tmp).validate(); err != nil {
+		return err
+	}
+	*d = tmp
+	return nil
+}
+
+func (d *DashboardDraft) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var tmp DashboardDraft
+	type plain DashboardDraft
+	if err := unmarshal((*plain)(&tmp)); err != nil {
+		return err
+	}
+	if err := (&tmp).validate(); err != nil {
+		return err
+	}
+	*d = tmp
+	return nil
+}
+
+func (d *DashboardDraft) validate() error {
+	if d.Kind != KindDashboardDraft {
+		return fmt.Errorf("invalid kind: %q for a DashboardDraft type", d.Kind)
+	}
+	if _, ok := draftStatusMap[d.Spec.Status]; !ok {
+		return fmt.Errorf("unknown draft status %q", d.Spec.Status)
+	}
+	return nil
+}
+
+type ChangeKind string
+
+const (
+	ChangeAdded    ChangeKind = "Added"
+	ChangeRemoved  ChangeKind = "Removed"
+	ChangeModified ChangeKind = "Modified"
+)
+
+// Change is a difference between two versions of a document.
+type Change struct {
+	Kind ChangeKind `json:"kind" yaml:"kind"`
+	// Path is the path to the value that changed. For example: spec.panels.cpu.spec.queries[0]
+	Path string `json:"path" yaml:"path"`
+	// Published is the value in the published dashboard. Not set when Kind is equal to ChangeAdded.
+	Published interface{} `json:"published,omitempty" yaml:"published,omitempty"`
+	// Draft is the value in the draft. Not set when Kind is equal to ChangeRemoved.
+	Draft interface{} `json:"draft,omitempty" yaml:"draft,omitempty"`
+}
+
+// DashboardDraftDiff is the list of differences between a draft and the published dashboard.
+type DashboardDraftDiff struct {
+	// Outdated is true when the published dashboard has been modified since the draft has been created.
+	Outdated bool     `json:"outdated" yaml:"outdated"`
+	Changes  []Change `json:"changes" yaml:"changes"`
+}
@@ -0,0 +1,90 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// DashboardMerge is the body of the request used to update a dashboard with a three-way merge.
+// The changes done between Base and Dashboard are applied on the version currently stored.
+type DashboardMerge struct {
+	// Base is the version of the dashboard the client started from.
+	Base *Dashboard `json:"base" yaml:"base"`
+	// Dashboard is the version modified by the client.
+	Dashboard *Dashboard `json:"dashboard" yaml:"dashboard"`
+}
+
+func (d *DashboardMerge) UnmarshalJSON(data []byte) error {
+	var tmp DashboardMerge
+	type plain DashboardMerge
+	if err := json.Unmarshal(data, (*plain)(&tmp)); err != nil {
+		return err
+	}
+	if err := (&tmp).validate(); err != nil {
+		return err
+	}
+	*d = tmp
+	return nil
+}
+
+func (d *DashboardMerge) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var tmp DashboardMerge
+	type plain DashboardMerge
+	if err := unmarshal((*plain)(&tmp)); err != nil {
+		return err
+	}
+	if err := (&tmp).validate(); err != nil {
+		return err
+	}
+	*d = tmp
+	return nil
+}
+
+func (d *DashboardMerge) validate() error {
+	if d.Base == nil {
+		return fmt.Errorf("base cannot be empty")
+	}
+	if d.Dashboard == nil {
+		return fmt.Errorf("dashboard cannot be empty")
+	}
+	return nil
+}
+
+// MergeConflict is a value of the dashboard modified differently by the client and in the version stored.
+// A value that doesn't exist in a version, because it has been removed or not added yet, is not set.
+type MergeConflict struct {
+	// Path is the location of the value in the dashboard. The panels are designated by their key, the variables by
+	// their name and the items of the layouts by the panel they reference. For example:
+	// spec.panels.cpu.spec.display.name, spec.variables[name=job] or spec.layouts[0].spec.items[ref=#/spec/panels/cpu]
+	Path   string `json:"path" yaml:"path"`
+	Reason string `json:"reason,omitempty" yaml:"reason,omitempty"`
+	// Base is the value in the version the client started from.
+	Base json.RawMessage `json:"base,omitempty" yaml:"base,omitempty"`
+	// Local is the value in the version modified by the client.
+	Local json.RawMessage `json:"local,omitempty" yaml:"local,omitempty"`
+	// Stored is the value in the version currently stored.
+	Stored json.RawMessage `json:"stored,omitempty" yaml:"stored,omitempty"`
+}
+
+// MergeConflicts is the body of the response when the three-way merge cannot be done automatically.
+type MergeConflicts struct {
+	Message   string          `json:"message" yaml:"message"`
+	Conflicts []MergeConflict `json:"conflicts" yaml:"conflicts"`
+}
+
+func (m *MergeConflicts) Error() string {
+	return m.Message
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/perses/perses/pkg/model/api/v1/variable"
 	"github.com/prometheus/common/model"
 	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
 )
 
 type TimeSeriesSpec struct {
@@ -527,6 +528,141 @@ func TestUnmarshalDashboardError(t *testing.T) {
 `,
 			err: fmt.Errorf("dashboard.spec.panels cannot be empty"),
 		},
+		{
+			title: "negative max data points",
+			jason: `
+{
+  "kind": "Dashboard",
+  "metadata": {
+    "name": "test",
+    "project": "perses"
+  },
+  "spec": {
+    "panels": {
+      "cpu": {
+        "kind": "Panel",
+        "spec": {
+          "display": {"name": "CPU"},
+          "plugin": {"kind": "TimeSeriesChart", "spec": {}},
+          "options": {"max_data_points": -1}
+        }
+      }
+    }
+  }
+}
+`,
+			err: fmt.Errorf("max_data_points cannot be negative"),
+		},
+		{
+			title: "min step greater than the relative time of a query",
+			jason: `
+{
+  "kind": "Dashboard",
+  "metadata": {
+    "name": "test",
+    "project": "perses"
+  },
+  "spec": {
+    "panels": {
+      "cpu": {
+        "kind": "Panel",
+        "spec": {
+          "display": {"name": "CPU"},
+          "plugin": {"kind": "TimeSeriesChart", "spec": {}},
+          "queries": [
+            {
+              "kind": "TimeSeriesQuery",
+              "spec": {
+                "plugin": {"kind": "PrometheusTimeSeriesQuery", "spec": {"query": "up"}},
+                "options": {"relative_time": "1h", "min_step": "2h"}
+              }
+            }
+          ]
+        }
+      }
+    }
+  }
+}
+`,
+			err: fmt.Errorf("min_step cannot be greater than relative_time"),
+		},
+		{
+			title: "reference to an unknown shared query",
+			jason: `
+{
+  "kind": "Dashboard",
+  "metadata": {
+    "name": "test",
+    "project": "perses"
+  },
+  "spec": {
+    "panels": {
+      "cpu": {
+        "kind": "Panel",
+        "spec": {
+          "display": {"name": "CPU"},
+          "plugin": {"kind": "TimeSeriesChart", "spec": {}},
+          "queries": [{"$ref": "#/spec/queries/cpu"}]
+        }
+      }
+    }
+  }
+}
+`,
+			err: fmt.Errorf("there is no existing query called \"cpu\" in the current dashboard"),
+		},
+		{
+			title: "reference to a panel used as a query",
+			jason: `
+{
+  "kind": "Dashboard",
+  "metadata": {
+    "name": "test",
+    "project": "perses"
+  },
+  "spec": {
+    "panels": {
+      "cpu": {
+        "kind": "Panel",
+        "spec": {
+          "display": {"name": "CPU"},
+          "plugin": {"kind": "TimeSeriesChart", "spec": {}},
+          "queries": [{"$ref": "#/spec/panels/cpu"}]
+        }
+      }
+    }
+  }
+}
+`,
+			err: fmt.Errorf("reference \"#/spec/panels/cpu\" used as a query is not pointing to a query"),
+		},
+		{
+			title: "shared query being a reference",
+			jason: `
+{
+  "kind": "Dashboard",
+  "metadata": {
+    "name": "test",
+    "project": "perses"
+  },
+  "spec": {
+    "queries": {
+      "cpu": {"$ref": "#/spec/queries/cpu"}
+    },
+    "panels": {
+      "cpu": {
+        "kind": "Panel",
+        "spec": {
+          "display": {"name": "CPU"},
+          "plugin": {"kind": "TimeSeriesChart", "spec": {}}
+        }
+      }
+    }
+  }
+}
+`,
+			err: fmt.Errorf("the shared query \"cpu\" cannot be a reference"),
+		},
 	}
 	for _, test := range testSuite {
 		t.Run(test.title, func(t *testing.T) {
@@ -535,3 +671,149 @@ func TestUnmarshalDashboardError(t *testing.T) {
 		})
 	}
 }
+
+func TestPanelSpecGetQueryOptions(t *testing.T) {
+	week := model.Duration(7 * 24 * time.Hour)
+	panel := PanelSpec{
+		Options: &QueryOptions{TimeShift: week, MaxDataPoints: 500},
+		Queries: []Query{
+			{Kind: "TimeSeriesQuery"},
+			{Kind: "TimeSeriesQuery", Spec: QuerySpec{Options: &QueryOptions{MaxDataPoints: 100, HideFromLegend: true}}},
+		},
+	}
+	assert.Equal(t, &QueryOptions{TimeShift: week, MaxDataPoints: 500}, panel.GetQueryOptions(0))
+	assert.Equal(t, &QueryOptions{TimeShift: week, MaxDataPoints: 100, HideFromLegend: true}, panel.GetQueryOptions(1))
+
+	panel.Options = nil
+	assert.Nil(t, panel.GetQueryOptions(0))
+	assert.Equal(t, &QueryOptions{MaxDataPoints: 100, HideFromLegend: true}, panel.GetQueryOptions(1))
+}
+
+func TestDashboardSharedQueries(t *testing.T) {
+	jason := `{
+  "kind": "Dashboard",
+  "metadata": {
+    "name": "test",
+    "project": "perses",
+    "created_at": "0001-01-01T00:00:00Z",
+    "updated_at": "0001-01-01T00:00:00Z",
+    "version": 0
+  },
+  "spec": {
+    "duration": "1h",
+    "queries": {
+      "requests": {
+        "kind": "TimeSeriesQuery",
+        "spec": {
+          "plugin": {"kind": "PrometheusTimeSeriesQuery", "spec": {"query": "sum(rate(http_requests_total[5m]))"}},
+          "options": {"min_step": "1m"}
+        }
+      }
+    },
+    "panels": {
+      "requests": {
+        "kind": "Panel",
+        "spec": {
+          "display": {"name": "Requests"},
+          "plugin": {"kind": "TimeSeriesChart", "spec": {}},
+          "queries": [
+            {"$ref": "#/spec/queries/requests"},
+            {
+              "kind": "TimeSeriesQuery",
+              "spec": {
+                "plugin": {"kind": "PrometheusTimeSeriesQuery", "spec": {"query": "up"}}
+              }
+            }
+          ]
+        }
+      }
+    },
+    "layouts": []
+  }
+}`
+	result := &Dashboard{}
+	assert.NoError(t, json.Unmarshal([]byte(jason), result))
+	panel := result.Spec.Panels["requests"].Spec
+	shared := result.Spec.Queries["requests"]
+	assert.Same(t, shared, panel.Queries[0].Resolve())
+	assert.Equal(t, &panel.Queries[1], panel.Queries[1].Resolve())
+	queries := panel.GetQueries()
+	assert.Equal(t, 2, len(queries))
+	assert.Equal(t, *shared, queries[0])
+	assert.Equal(t, &QueryOptions{MinStep: model.Duration(time.Minute)}, panel.GetQueryOptions(0))
+
+	// the references are kept when the dashboard is encoded
+	data, err := json.Marshal(result)
+	assert.NoError(t, err)
+	assert.JSONEq(t, jason, string(data))
+	yamlData, err := yaml.Marshal(result)
+	assert.NoError(t, err)
+	fromYAML := &Dashboard{}
+	assert.NoError(t, yaml.Unmarshal(yamlData, fromYAML))
+	assert.Same(t, fromYAML.Spec.Queries["requests"], fromYAML.Spec.Panels["requests"].Spec.Queries[0].Resolve())
+}
+
+func TestUnmarshalGlobalDashboard(t *testing.T) {
+	jason := `
+{
+  "kind": "GlobalDashboard",
+  "metadata": {
+    "name": "cluster"
+  },
+  "spec": {
+    "duration": "1h",
+    "panels": {
+      "cpu": {
+        "kind": "Panel",
+        "spec": {
+          "display": {"name": "CPU"},
+          "plugin": {"kind": "TimeSeriesChart", "spec": {}}
+        }
+      }
+    },
+    "layouts": [
+      {
+        "kind": "Grid",
+        "spec": {
+          "items": [
+            {"x": 0, "y": 0, "width": 3, "height": 4, "content": {"$ref": "#/spec/panels/cpu"}}
+          ]
+        }
+      }
+    ]
+  }
+}
+`
+	result := &GlobalDashboard{}
+	assert.NoError(t, json.Unmarshal([]byte(jason), result))
+	dash := result.InProject("perses")
+	assert.Equal(t, KindDashboard, dash.Kind)
+	assert.Equal(t, "perses", dash.Metadata.Project)
+	assert.Equal(t, "cluster", dash.Metadata.Name)
+	assert.Equal(t, result.Spec.Panels["cpu"], dash.Spec.Panels["cpu"])
+}
+
+func TestUnmarshalGlobalDashboardError(t *testing.T) {
+	testSuite := []struct {
+		title string
+		jason string
+		err   error
+	}{
+		{
+			title: "wrong kind",
+			jason: `{"kind": "Dashboard", "metadata": {"name": "cluster"}, "spec": {"panels": {"cpu": {"kind": "Panel"}}}}`,
+			err:   fmt.Errorf("invalid kind: \"Dashboard\" for a GlobalDashboard type"),
+		},
+		{
+			title: "reference to an unknown panel",
+			jason: `{"kind": "GlobalDashboard", "metadata": {"name": "cluster"}, "spec": {"panels": {"cpu": {"kind": "Panel"}}, "layouts": [{"kind": "Grid", "spec": {"items": [{"content": {"$ref": "#/spec/panels/memory"}}]}}]}}`,
+			err:   fmt.Errorf("there is no existing panel called \"memory\" in the current dashboard"),
+		},
+	}
+	for _, test := range testSuite {
+		t.Run(test.title, func(t *testing.T) {
+			result := GlobalDashboard{}
+			assert.Equal(t, test.err, json.Unmarshal([]byte(test.jason), &result))
+		})
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	modelAPI "github.com/perses/perses/pkg/model/api"
+)
+
+const (
+	// UsageDayLayout is the layout used to format the days in DashboardUsageSpec.Views.
+	UsageDayLayout   = "2006-01-02"
+	DefaultUsageDays = 30
+)
+
+type DashboardUsageSpec struct {
+	LastViewedAt time.Time `json:"last_viewed_at" yaml:"last_viewed_at"`
+	// Views is the number of views of the dashboard per day.
+	// The key is the day (UTC) formatted with the layout UsageDayLayout.
+	Views map[string]uint64 `json:"views" yaml:"views"`
+}
+
+// DashboardUsage is storing the usage statistics of a dashboard.
+// It is named after the dashboard and is maintained by the server, so it is not exposed like the other resources.
+type DashboardUsage struct {
+	Kind     Kind               `json:"kind" yaml:"kind"`
+	Metadata ProjectMetadata    `json:"metadata" yaml:"metadata"`
+	Spec     DashboardUsageSpec `json:"spec" yaml:"spec"`
+}
+
+func (d *DashboardUsage) GetMetadata() modelAPI.Metadata {
+	return &d.Metadata
+}
+
+func (d *DashboardUsage) GetKind() string {
+	return string(d.Kind)
+}
+
+func (d *DashboardUsage) GetSpec() interface{} {
+	return d.Spec
+}
+
+func (d *DashboardUsage) UnmarshalJSON(data []byte) error {
+	var tmp DashboardUsage
+	type plain DashboardUsage
+	if err := json.Unmarshal(data, (*plain)(&tmp)); err != nil {
+		return err
+	}
+	if err := (&tmp).validate(); err != nil {
+		return err
+	}
+	*d = tmp
+	return nil
+}
+
+func (d *DashboardUsage) validate() error {
+	if d.Kind != KindDashboardUsage {
+		return fmt.Errorf("invalid kind: %q for a DashboardUsage type", d.Kind)
+	}
+	return nil
+}
+
+// DashboardUsageQuery is the list of parameters that can be used to filter the usage statistics of the dashboards.
+type DashboardUsageQuery struct {
+	// Project is the exact name of the project. It can be empty to get the statistics of every project.
+	Project string `query:"project"`
+	// Days is the number of days (including today) used to count the views. Default value is DefaultUsageDays.
+	Days int `query:"days"`
+}
+
+func (q *DashboardUsageQuery) GetValues() url.Values {
+	values := make(url.Values)
+	if len(q.Project) > 0 {
+		values.Set("project", q.Project)
+	}
+	if q.Days > 0 {
+		values.Set("days", strconv.Itoa(q.Days))
+	}
+	return values
+}
+
+// DashboardUsageSummary is the usage statistics of a dashboard returned by the API.
+type DashboardUsageSummary struct {
+	Project   string `json:"project" yaml:"project"`
+	Dashboard string `json:"dashboard" yaml:"dashboard"`
+	// LastViewedAt is not set when the dashboard has never been viewed.
+	LastViewedAt *time.Time `json:"last_viewed_at,omitempty" yaml:"last_viewed_at,omitempty"`
+	// Views is the number of views during the requested period.
+	Views uint64 `json:"views" yaml:"views"`
+}
@@ -167,3 +167,63 @@ func (d *Datasource) GetDTSSpec() DatasourceSpec {
 func (d *Datasource) GetSpec() interface{} {
 	return d.Spec
 }
+
+// OrganizationDatasource is a datasource shared to the projects of an organization.
+// Any Dashboard of these projects can reference it.
+type OrganizationDatasource struct {
+	Kind     Kind                 `json:"kind" yaml:"kind"`
+	Metadata OrganizationMetadata `json:"metadata" yaml:"metadata"`
+	Spec     DatasourceSpec       `json:"spec" yaml:"spec"`
+}
+
+func (d *OrganizationDatasource) UnmarshalJSON(data []byte) error {
+	var tmp OrganizationDatasource
+	type plain OrganizationDatasource
+	if err := json.Unmarshal(data, (*plain)(&tmp)); err != nil {
+		return err
+	}
+	if err := (&tmp).validate(); err != nil {
+		return err
+	}
+	*d = tmp
+	return nil
+}
+
+func (d *OrganizationDatasource) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var tmp OrganizationDatasource
+	type plain OrganizationDatasource
+	if err := unmarshal((*plain)(&tmp)); err != nil {
+		return err
+	}
+	if err := (&tmp).validate(); err != nil {
+		return err
+	}
+	*d = tmp
+	return nil
+}
+
+func (d *OrganizationDatasource) validate() error {
+	if d.Kind != KindOrganizationDatasource {
+		return fmt.Errorf("invalid kind: %q for an OrganizationDatasource type", d.Kind)
+	}
+	if reflect.DeepEqual(d.Spec, DatasourceSpec{}) {
+		return fmt.Errorf("spec cannot be empty")
+	}
+	return nil
+}
+
+func (d *OrganizationDatasource) GetMetadata() modelAPI.Metadata {
+	return &d.Metadata
+}
+
+func (d *OrganizationDatasource) GetKind() string {
+	return string(d.Kind)
+}
+
+func (d *OrganizationDatasource) GetDTSSpec() DatasourceSpec {
+	return d.Spec
+}
+
+func (d *OrganizationDatasource) GetSpec() interface{} {
+	return d.Spec
+}
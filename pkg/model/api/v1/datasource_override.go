@@ -0,0 +1,254 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+
+	modelAPI "github.com/perses/perses/pkg/model/api"
+	"github.com/perses/perses/pkg/model/api/v1/common"
+)
+
+// overridablePluginFields are the only fields of the spec of the plugin that a project can override: the URL and the
+// headers of the proxy, like a tenant header. The other fields, like the allowed endpoints or the secret, would let a
+// project reach more than what the global datasource allows.
+var overridablePluginFields = map[string]interface{}{
+	"proxy": map[string]interface{}{
+		"spec": map[string]interface{}{
+			"url":     true,
+			"headers": true,
+		},
+	},
+}
+
+type PluginOverride struct {
+	// Spec is merged over the spec of the plugin as a JSON merge patch (RFC 7396): the objects are merged key by key,
+	// a null value removes the key and any other value replaces the previous one.
+	Spec interface{} `json:"spec" yaml:"spec"`
+}
+
+type GlobalDatasourceOverrideSpec struct {
+	// Display replaces the display of the GlobalDatasource when it is set.
+	Display *common.Display `json:"display,omitempty" yaml:"display,omitempty"`
+	// Plugin is merged over the plugin of the GlobalDatasource. The kind of the plugin cannot be overridden.
+	Plugin *PluginOverride `json:"plugin,omitempty" yaml:"plugin,omitempty"`
+}
+
+// GlobalDatasourceOverride is a partial spec merged over the GlobalDatasource having the same name, for the dashboards
+// and the proxy of a project. It is used for example to give a different URL or tenant header to a project.
+type GlobalDatasourceOverride struct {
+	Kind     Kind                         `json:"kind" yaml:"kind"`
+	Metadata ProjectMetadata              `json:"metadata" yaml:"metadata"`
+	Spec     GlobalDatasourceOverrideSpec `json:"spec" yaml:"spec"`
+}
+
+func (d *GlobalDatasourceOverride) UnmarshalJSON(data []byte) error {
+	var tmp GlobalDatasourceOverride
+	type plain GlobalDatasourceOverride
+	if err := json.Unmarshal(data, (*plain)(&tmp)); err != nil {
+		return err
+	}
+	if err := (&tmp).validate(); err != nil {
+		return err
+	}
+	*d = tmp
+	return nil
+}
+
+func (d *GlobalDatasourceOverride) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var tmp GlobalDatasourceOverride
+	type plain GlobalDatasourceOverride
+	if err := unmarshal((*plain)(&tmp)); err != nil {
+		return err
+	}
+	if err := (&tmp).validate(); err != nil {
+		return err
+	}
+	*d = tmp
+	return nil
+}
+
+func (d *GlobalDatasourceOverride) validate() error {
+	if d.Kind != KindGlobalDatasourceOverride {
+		return fmt.Errorf("invalid kind: %q for a GlobalDatasourceOverride type", d.Kind)
+	}
+	if d.Spec.Display == nil && d.Spec.Plugin == nil {
+		return fmt.Errorf("spec cannot be empty")
+	}
+	if d.Spec.Plugin != nil {
+		return d.Spec.Plugin.validate()
+	}
+	return nil
+}
+
+func (p *PluginOverride) validate() error {
+	var patch interface{}
+	if err := toJSONValue(p.Spec, &patch); err != nil {
+		return err
+	}
+	return checkOverridableFields(patch, overridablePluginFields, "")
+}
+
+func checkOverridableFields(patch interface{}, allowed map[string]interface{}, path string) error {
+	patchObject, isObject := patch.(map[string]interface{})
+	if !isObject {
+		if len(path) == 0 {
+			return fmt.Errorf("the spec of the plugin must be an object")
+		}
+		return fmt.Errorf("%q cannot be replaced, only its fields can be overridden", path)
+	}
+	for key, value := range patchObject {
+		fieldPath := key
+		if len(path) > 0 {
+			fieldPath = path + "." + key
+		}
+		allowedField, ok := allowed[key]
+		if !ok {
+			return fmt.Errorf("%q cannot be overridden, only proxy.spec.url and proxy.spec.headers can", fieldPath)
+		}
+		if allowedChildren, hasChildren := allowedField.(map[string]interface{}); hasChildren {
+			if err := checkOverridableFields(value, allowedChildren, fieldPath); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (d *GlobalDatasourceOverride) GetMetadata() modelAPI.Metadata {
+	return &d.Metadata
+}
+
+func (d *GlobalDatasourceOverride) GetKind() string {
+	return string(d.Kind)
+}
+
+func (d *GlobalDatasourceOverride) GetSpec() interface{} {
+	return d.Spec
+}
+
+// Override returns a copy of the datasource with the override merged over its spec.
+func (d *GlobalDatasource) Override(override GlobalDatasourceOverrideSpec) (*GlobalDatasource, error) {
+	result := &GlobalDatasource{
+		Kind:     d.Kind,
+		Metadata: d.Metadata,
+		Spec:     d.Spec,
+	}
+	if override.Display != nil {
+		result.Spec.Display = override.Display
+	}
+	if override.Plugin != nil {
+		if err := override.Plugin.validate(); err != nil {
+			return nil, err
+		}
+		pluginSpec, err := mergePatch(d.Spec.Plugin.Spec, override.Plugin.Spec)
+		if err != nil {
+			return nil, fmt.Errorf("unable to merge the spec of the plugin: %w", err)
+		}
+		var original interface{}
+		if err := toJSONValue(d.Spec.Plugin.Spec, &original); err != nil {
+			return nil, err
+		}
+		if proxySpec, ok := lookupObject(pluginSpec, "proxy", "spec"); ok && proxySpec["url"] != lookupValue(original, "proxy", "spec", "url") {
+			// The headers and the secret of the global datasource are credentials for its URL, they must not be sent to
+			// another host. Only the headers given by the override are kept.
+			delete(proxySpec, "secret")
+			delete(proxySpec, "headers")
+			if headers, hasHeaders := lookupObject(toPlainValue(override.Plugin.Spec), "proxy", "spec", "headers"); hasHeaders {
+				proxySpec["headers"] = removeNullValues(headers)
+			}
+		}
+		result.Spec.Plugin = common.Plugin{Kind: d.Spec.Plugin.Kind, Spec: pluginSpec}
+	}
+	return result, nil
+}
+
+func toPlainValue(value interface{}) interface{} {
+	var result interface{}
+	if err := toJSONValue(value, &result); err != nil {
+		return nil
+	}
+	return result
+}
+
+func lookupValue(value interface{}, keys ...string) interface{} {
+	for _, key := range keys {
+		object, isObject := value.(map[string]interface{})
+		if !isObject {
+			return nil
+		}
+		value = object[key]
+	}
+	return value
+}
+
+func lookupObject(value interface{}, keys ...string) (map[string]interface{}, bool) {
+	object, isObject := lookupValue(value, keys...).(map[string]interface{})
+	return object, isObject
+}
+
+func removeNullValues(object map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(object))
+	for key, value := range object {
+		if value != nil {
+			result[key] = value
+		}
+	}
+	return result
+}
+
+// mergePatch applies the JSON merge patch (RFC 7396) on the document. The values are compared once encoded in JSON,
+// so they can be of any type.
+func mergePatch(document interface{}, patch interface{}) (interface{}, error) {
+	var documentValue, patchValue interface{}
+	if err := toJSONValue(document, &documentValue); err != nil {
+		return nil, err
+	}
+	if err := toJSONValue(patch, &patchValue); err != nil {
+		return nil, err
+	}
+	return applyMergePatch(documentValue, patchValue), nil
+}
+
+func toJSONValue(value interface{}, result *interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, result)
+}
+
+func applyMergePatch(document interface{}, patch interface{}) interface{} {
+	patchObject, isObject := patch.(map[string]interface{})
+	if !isObject {
+		return patch
+	}
+	documentObject, isObject := document.(map[string]interface{})
+	if !isObject {
+		documentObject = make(map[string]interface{})
+	}
+	result := make(map[string]interface{}, len(documentObject))
+	for key, value := range documentObject {
+		result[key] = value
+	}
+	for key, value := range patchObject {
+		if value == nil {
+			delete(result, key)
+			continue
+		}
+		result[key] = applyMergePatch(result[key], value)
+	}
+	return result
+}
@@ -0,0 +1,218 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/perses/perses/pkg/model/api/v1/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnmarshalJSONGlobalDatasourceOverride(t *testing.T) {
+	data := `
+{
+  "kind": "GlobalDatasourceOverride",
+  "metadata": {
+    "name": "prometheus",
+    "project": "perses"
+  },
+  "spec": {}
+}
+`
+	result := &GlobalDatasourceOverride{}
+	assert.EqualError(t, json.Unmarshal([]byte(data), result), "spec cannot be empty")
+}
+
+func TestGlobalDatasource_Override(t *testing.T) {
+	globalDatasource := &GlobalDatasource{
+		Kind:     KindGlobalDatasource,
+		Metadata: Metadata{Name: "prometheus"},
+		Spec: DatasourceSpec{
+			Default: true,
+			Plugin: common.Plugin{
+				Kind: "PrometheusDatasource",
+				Spec: map[string]interface{}{
+					"proxy": map[string]interface{}{
+						"kind": "HTTPProxy",
+						"spec": map[string]interface{}{
+							"url":     "https://prometheus.demo.do.prometheus.io",
+							"headers": map[string]interface{}{"X-Scope-OrgID": "default", "X-Debug": "true"},
+						},
+					},
+				},
+			},
+		},
+	}
+	override := GlobalDatasourceOverrideSpec{
+		Display: &common.Display{Name: "Prometheus of the team"},
+		Plugin: &PluginOverride{
+			Spec: map[string]interface{}{
+				"proxy": map[string]interface{}{
+					"spec": map[string]interface{}{
+						"url":     "https://prometheus.team.example.com",
+						"headers": map[string]interface{}{"X-Scope-OrgID": "team", "X-Debug": nil},
+					},
+				},
+			},
+		},
+	}
+	result, err := globalDatasource.Override(override)
+	assert.NoError(t, err)
+	assert.Equal(t, &GlobalDatasource{
+		Kind:     KindGlobalDatasource,
+		Metadata: Metadata{Name: "prometheus"},
+		Spec: DatasourceSpec{
+			Display: &common.Display{Name: "Prometheus of the team"},
+			Default: true,
+			Plugin: common.Plugin{
+				Kind: "PrometheusDatasource",
+				Spec: map[string]interface{}{
+					"proxy": map[string]interface{}{
+						"kind": "HTTPProxy",
+						"spec": map[string]interface{}{
+							"url":     "https://prometheus.team.example.com",
+							"headers": map[string]interface{}{"X-Scope-OrgID": "team"},
+						},
+					},
+				},
+			},
+		},
+	}, result)
+	// the datasource itself is not modified
+	assert.Nil(t, globalDatasource.Spec.Display)
+}
+
+func TestGlobalDatasource_OverrideURLDropsTheCredentials(t *testing.T) {
+	globalDatasource := &GlobalDatasource{
+		Kind:     KindGlobalDatasource,
+		Metadata: Metadata{Name: "prometheus"},
+		Spec: DatasourceSpec{
+			Plugin: common.Plugin{
+				Kind: "PrometheusDatasource",
+				Spec: map[string]interface{}{
+					"proxy": map[string]interface{}{
+						"kind": "HTTPProxy",
+						"spec": map[string]interface{}{
+							"url":     "https://prometheus.demo.do.prometheus.io",
+							"headers": map[string]interface{}{"Authorization": "Bearer secret"},
+							"secret":  "prometheus-credentials",
+						},
+					},
+				},
+			},
+		},
+	}
+	testSuites := []struct {
+		title        string
+		override     map[string]interface{}
+		expectedSpec map[string]interface{}
+	}{
+		{
+			title: "same URL keeps the credentials",
+			override: map[string]interface{}{
+				"headers": map[string]interface{}{"X-Scope-OrgID": "team"},
+			},
+			expectedSpec: map[string]interface{}{
+				"url":     "https://prometheus.demo.do.prometheus.io",
+				"headers": map[string]interface{}{"Authorization": "Bearer secret", "X-Scope-OrgID": "team"},
+				"secret":  "prometheus-credentials",
+			},
+		},
+		{
+			title: "other URL drops the credentials",
+			override: map[string]interface{}{
+				"url": "https://attacker.example.com",
+			},
+			expectedSpec: map[string]interface{}{
+				"url": "https://attacker.example.com",
+			},
+		},
+		{
+			title: "other URL only keeps the headers of the override",
+			override: map[string]interface{}{
+				"url":     "https://prometheus.team.example.com",
+				"headers": map[string]interface{}{"X-Scope-OrgID": "team"},
+			},
+			expectedSpec: map[string]interface{}{
+				"url":     "https://prometheus.team.example.com",
+				"headers": map[string]interface{}{"X-Scope-OrgID": "team"},
+			},
+		},
+	}
+	for _, test := range testSuites {
+		t.Run(test.title, func(t *testing.T) {
+			result, err := globalDatasource.Override(GlobalDatasourceOverrideSpec{
+				Plugin: &PluginOverride{Spec: map[string]interface{}{"proxy": map[string]interface{}{"spec": test.override}}},
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, map[string]interface{}{
+				"proxy": map[string]interface{}{
+					"kind": "HTTPProxy",
+					"spec": test.expectedSpec,
+				},
+			}, result.Spec.Plugin.Spec)
+		})
+	}
+}
+
+func TestUnmarshalJSONGlobalDatasourceOverrideFields(t *testing.T) {
+	testSuites := []struct {
+		title       string
+		plugin      string
+		expectedErr string
+	}{
+		{
+			title:  "url and headers",
+			plugin: `{"proxy": {"spec": {"url": "https://prometheus.team.example.com", "headers": {"X-Scope-OrgID": "team", "X-Debug": null}}}}`,
+		},
+		{
+			title:       "allowed endpoints",
+			plugin:      `{"proxy": {"spec": {"allowed_endpoints": [{"endpoint_pattern": "/api/v1/admin/.*", "method": "POST"}]}}}`,
+			expectedErr: `"proxy.spec.allowed_endpoints" cannot be overridden, only proxy.spec.url and proxy.spec.headers can`,
+		},
+		{
+			title:       "secret",
+			plugin:      `{"proxy": {"spec": {"secret": "other-credentials"}}}`,
+			expectedErr: `"proxy.spec.secret" cannot be overridden, only proxy.spec.url and proxy.spec.headers can`,
+		},
+		{
+			title:       "kind of the proxy",
+			plugin:      `{"proxy": {"kind": "SQLProxy"}}`,
+			expectedErr: `"proxy.kind" cannot be overridden, only proxy.spec.url and proxy.spec.headers can`,
+		},
+		{
+			title:       "whole proxy",
+			plugin:      `{"proxy": null}`,
+			expectedErr: `"proxy" cannot be replaced, only its fields can be overridden`,
+		},
+		{
+			title:       "other field of the plugin",
+			plugin:      `{"directUrl": "https://attacker.example.com"}`,
+			expectedErr: `"directUrl" cannot be overridden, only proxy.spec.url and proxy.spec.headers can`,
+		},
+	}
+	for _, test := range testSuites {
+		t.Run(test.title, func(t *testing.T) {
+			data := `{"kind": "GlobalDatasourceOverride", "metadata": {"name": "prometheus", "project": "perses"}, "spec": {"plugin": {"spec": ` + test.plugin + `}}}`
+			err := json.Unmarshal([]byte(data), &GlobalDatasourceOverride{})
+			if len(test.expectedErr) == 0 {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.expectedErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	modelAPI "github.com/perses/perses/pkg/model/api"
+	"github.com/prometheus/common/model"
+)
+
+type EphemeralDashboardSpec struct {
+	// TTL is the time to live of the dashboard. When set, it is used to compute ExpireAt each time the dashboard is
+	// created or updated.
+	TTL model.Duration `json:"ttl,omitempty" yaml:"ttl,omitempty"`
+	// ExpireAt is the time after which the dashboard is deleted.
+	ExpireAt  time.Time     `json:"expire_at,omitempty" yaml:"expire_at,omitempty"`
+	Dashboard DashboardSpec `json:"dashboard" yaml:"dashboard"`
+}
+
+// EphemeralDashboard is a dashboard automatically deleted once it has expired.
+type EphemeralDashboard struct {
+	Kind     Kind                   `json:"kind" yaml:"kind"`
+	Metadata ProjectMetadata        `json:"metadata" yaml:"metadata"`
+	Spec     EphemeralDashboardSpec `json:"spec" yaml:"spec"`
+}
+
+func (d *EphemeralDashboard) GetMetadata() modelAPI.Metadata {
+	return &d.Metadata
+}
+
+func (d *EphemeralDashboard) GetKind() string {
+	return string(d.Kind)
+}
+
+func (d *EphemeralDashboard) GetSpec() interface{} {
+	return d.Spec
+}
+
+func (d *EphemeralDashboard) UnmarshalJSON(data []byte) error {
+	var tmp EphemeralDashboard
+	type plain EphemeralDashboard
+	if err := json.Unmarshal(data, (*plain)(&tmp)); err != nil {
+		return err
+	}
+	if err := (&tmp).validate(); err != nil {
+		return err
+	}
+	*d = tmp
+	return nil
+}
+
+func (d *EphemeralDashboard) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var tmp EphemeralDashboard
+	type plain EphemeralDashboard
+	if err := unmarshal((*plain)(&tmp)); err != nil {
+		return err
+	}
+	if err := (&tmp).validate(); err != nil {
+		return err
+	}
+	*d = tmp
+	return nil
+}
+
+func (d *EphemeralDashboard) validate() error {
+	if d.Kind != KindEphemeralDashboard {
+		return fmt.Errorf("invalid kind: %q for an EphemeralDashboard type", d.Kind)
+	}
+	if d.Spec.TTL <= 0 && d.Spec.ExpireAt.IsZero() {
+		return fmt.Errorf("spec.ttl or spec.expire_at must be set")
+	}
+	return nil
+}
+
+// ToDashboard returns the dashboard described by the ephemeral dashboard.
+func (d *EphemeralDashboard) ToDashboard() *Dashboard {
+	return &Dashboard{
+		Kind:     KindDashboard,
+		Metadata: d.Metadata,
+		Spec:     d.Spec.Dashboard,
+	}
+}
+
+// EphemeralDashboardExtension is the body of the request used to extend the time to live of an ephemeral dashboard.
+type EphemeralDashboardExtension struct {
+	// TTL is the duration added to the expiry time of the dashboard.
+	// If the dashboard has already expired, the duration is added to the current time.
+	TTL model.Duration `json:"ttl" yaml:"ttl"`
+}
+
+func (e *EphemeralDashboardExtension) UnmarshalJSON(data []byte) error {
+	var tmp EphemeralDashboardExtension
+	type plain EphemeralDashboardExtension
+	if err := json.Unmarshal(data, (*plain)(&tmp)); err != nil {
+		return err
+	}
+	if tmp.TTL <= 0 {
+		return fmt.Errorf("ttl must be greater than 0")
+	}
+	*e = tmp
+	return nil
+}
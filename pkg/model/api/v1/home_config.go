@@ -0,0 +1,222 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+
+	modelAPI "github.com/perses/perses/pkg/model/api"
+)
+
+// DashboardSelector is the reference to a dashboard of a project.
+type DashboardSelector struct {
+	// Project correspond to the name of the project (dashboard.metadata.project)
+	Project string `json:"project" yaml:"project"`
+	// Dashboard correspond to the name of the dashboard (dashboard.metadata.name)
+	Dashboard string `json:"dashboard" yaml:"dashboard"`
+}
+
+type HomeConfigSpec struct {
+	// ImportantDashboards is the list of the dashboards highlighted on the home page.
+	ImportantDashboards []DashboardSelector `json:"important_dashboards,omitempty" yaml:"important_dashboards,omitempty"`
+	// Information is a markdown content displayed on the home page.
+	Information string `json:"information,omitempty" yaml:"information,omitempty"`
+}
+
+func (h *HomeConfigSpec) UnmarshalJSON(data []byte) error {
+	var tmp HomeConfigSpec
+	type plain HomeConfigSpec
+	if err := json.Unmarshal(data, (*plain)(&tmp)); err != nil {
+		return err
+	}
+	if err := (&tmp).validate(); err != nil {
+		return err
+	}
+	*h = tmp
+	return nil
+}
+
+func (h *HomeConfigSpec) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var tmp HomeConfigSpec
+	type plain HomeConfigSpec
+	if err := unmarshal((*plain)(&tmp)); err != nil {
+		return err
+	}
+	if err := (&tmp).validate(); err != nil {
+		return err
+	}
+	*h = tmp
+	return nil
+}
+
+func (h *HomeConfigSpec) validate() error {
+	for i, selector := range h.ImportantDashboards {
+		if len(selector.Dashboard) == 0 {
+			return fmt.Errorf("important_dashboards[%d].dashboard cannot be empty", i)
+		}
+	}
+	return nil
+}
+
+// RemoveDashboard removes the references to the dashboard. When dashboard is empty, the references to every
+// dashboard of the project are removed. It returns true when at least one reference has been removed.
+func (h *HomeConfigSpec) RemoveDashboard(project string, dashboard string) bool {
+	result := make([]DashboardSelector, 0, len(h.ImportantDashboards))
+	for _, selector := range h.ImportantDashboards {
+		if selector.Project == project && (len(dashboard) == 0 || selector.Dashboard == dashboard) {
+			continue
+		}
+		result = append(result, selector)
+	}
+	if len(result) == len(h.ImportantDashboards) {
+		return false
+	}
+	h.ImportantDashboards = result
+	return true
+}
+
+// RenameDashboard makes the references to the dashboard point to its new name. It returns true when at least one
+// reference has been changed.
+func (h *HomeConfigSpec) RenameDashboard(project string, dashboard string, newName string) bool {
+	changed := false
+	for i, selector := range h.ImportantDashboards {
+		if selector.Project == project && selector.Dashboard == dashboard {
+			h.ImportantDashboards[i].Dashboard = newName
+			changed = true
+		}
+	}
+	return changed
+}
+
+// Override returns the spec where the fields set in the override replace the ones of the current spec.
+func (h HomeConfigSpec) Override(override HomeConfigSpec) HomeConfigSpec {
+	if len(override.ImportantDashboards) > 0 {
+		h.ImportantDashboards = override.ImportantDashboards
+	}
+	if len(override.Information) > 0 {
+		h.Information = override.Information
+	}
+	return h
+}
+
+// GlobalHomeConfig is the configuration of the home page. There is at most one, and it can be overridden per project by a HomeConfig.
+type GlobalHomeConfig struct {
+	Kind     Kind           `json:"kind" yaml:"kind"`
+	Metadata Metadata       `json:"metadata" yaml:"metadata"`
+	Spec     HomeConfigSpec `json:"spec" yaml:"spec"`
+}
+
+func (h *GlobalHomeConfig) GetMetadata() modelAPI.Metadata {
+	return &h.Metadata
+}
+
+func (h *GlobalHomeConfig) GetKind() string {
+	return string(h.Kind)
+}
+
+func (h *GlobalHomeConfig) GetSpec() interface{} {
+	return h.Spec
+}
+
+func (h *GlobalHomeConfig) UnmarshalJSON(data []byte) error {
+	var tmp GlobalHomeConfig
+	type plain GlobalHomeConfig
+	if err := json.Unmarshal(data, (*plain)(&tmp)); err != nil {
+		return err
+	}
+	if err := (&tmp).validate(); err != nil {
+		return err
+	}
+	*h = tmp
+	return nil
+}
+
+func (h *GlobalHomeConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var tmp GlobalHomeConfig
+	type plain GlobalHomeConfig
+	if err := unmarshal((*plain)(&tmp)); err != nil {
+		return err
+	}
+	if err := (&tmp).validate(); err != nil {
+		return err
+	}
+	*h = tmp
+	return nil
+}
+
+func (h *GlobalHomeConfig) validate() error {
+	if h.Kind != KindGlobalHomeConfig {
+		return fmt.Errorf("invalid kind: %q for a GlobalHomeConfig type", h.Kind)
+	}
+	for i, selector := range h.Spec.ImportantDashboards {
+		if len(selector.Project) == 0 {
+			return fmt.Errorf("important_dashboards[%d].project cannot be empty", i)
+		}
+	}
+	return nil
+}
+
+// HomeConfig overrides the GlobalHomeConfig for a project. There is at most one per project.
+// The important dashboards without project are the dashboards of the project of the HomeConfig.
+type HomeConfig struct {
+	Kind     Kind            `json:"kind" yaml:"kind"`
+	Metadata ProjectMetadata `json:"metadata" yaml:"metadata"`
+	Spec     HomeConfigSpec  `json:"spec" yaml:"spec"`
+}
+
+func (h *HomeConfig) GetMetadata() modelAPI.Metadata {
+	return &h.Metadata
+}
+
+func (h *HomeConfig) GetKind() string {
+	return string(h.Kind)
+}
+
+func (h *HomeConfig) GetSpec() interface{} {
+	return h.Spec
+}
+
+func (h *HomeConfig) UnmarshalJSON(data []byte) error {
+	var tmp HomeConfig
+	type plain HomeConfig
+	if err := json.Unmarshal(data, (*plain)(&tmp)); err != nil {
+		return err
+	}
+	if err := (&tmp).validate(); err != nil {
+		return err
+	}
+	*h = tmp
+	return nil
+}
+
+func (h *HomeConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var tmp HomeConfig
+	type plain HomeConfig
+	if err := unmarshal((*plain)(&tmp)); err != nil {
+		return err
+	}
+	if err := (&tmp).validate(); err != nil {
+		return err
+	}
+	*h = tmp
+	return nil
+}
+
+func (h *HomeConfig) validate() error {
+	if h.Kind != KindHomeConfig {
+		return fmt.Errorf("invalid kind: %q for a HomeConfig type", h.Kind)
+	}
+	return nil
+}
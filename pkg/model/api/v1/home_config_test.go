@@ -0,0 +1,93 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnmarshalHomeConfigError(t *testing.T) {
+	testSuite := []struct {
+		title  string
+		jason  string
+		entity interface{}
+		err    error
+	}{
+		{
+			title:  "selector without dashboard",
+			jason:  `{"kind": "HomeConfig", "metadata": {"name": "home", "project": "perses"}, "spec": {"important_dashboards": [{"project": "perses"}]}}`,
+			entity: &HomeConfig{},
+			err:    fmt.Errorf("important_dashboards[0].dashboard cannot be empty"),
+		},
+		{
+			title:  "global selector without project",
+			jason:  `{"kind": "GlobalHomeConfig", "metadata": {"name": "home"}, "spec": {"important_dashboards": [{"project": "perses", "dashboard": "Demo"}, {"dashboard": "Demo"}]}}`,
+			entity: &GlobalHomeConfig{},
+			err:    fmt.Errorf("important_dashboards[1].project cannot be empty"),
+		},
+	}
+	for _, test := range testSuite {
+		t.Run(test.title, func(t *testing.T) {
+			assert.Equal(t, test.err, json.Unmarshal([]byte(test.jason), test.entity))
+		})
+	}
+}
+
+func TestHomeConfigSpecRemoveDashboard(t *testing.T) {
+	spec := HomeConfigSpec{
+		ImportantDashboards: []DashboardSelector{
+			{Project: "perses", Dashboard: "Demo"},
+			{Project: "perses", Dashboard: "Nodes"},
+			{Project: "testing", Dashboard: "Demo"},
+		},
+	}
+	assert.False(t, spec.RemoveDashboard("unknown", "Demo"))
+	assert.True(t, spec.RemoveDashboard("perses", "Demo"))
+	assert.Equal(t, []DashboardSelector{{Project: "perses", Dashboard: "Nodes"}, {Project: "testing", Dashboard: "Demo"}}, spec.ImportantDashboards)
+	assert.True(t, spec.RemoveDashboard("perses", ""))
+	assert.Equal(t, []DashboardSelector{{Project: "testing", Dashboard: "Demo"}}, spec.ImportantDashboards)
+}
+
+func TestHomeConfigSpecRenameDashboard(t *testing.T) {
+	spec := HomeConfigSpec{
+		ImportantDashboards: []DashboardSelector{
+			{Project: "perses", Dashboard: "Demo"},
+			{Project: "perses", Dashboard: "Nodes"},
+			{Project: "testing", Dashboard: "Demo"},
+		},
+	}
+	assert.False(t, spec.RenameDashboard("unknown", "Demo", "Overview"))
+	assert.True(t, spec.RenameDashboard("perses", "Demo", "Overview"))
+	assert.Equal(t, []DashboardSelector{
+		{Project: "perses", Dashboard: "Overview"},
+		{Project: "perses", Dashboard: "Nodes"},
+		{Project: "testing", Dashboard: "Demo"},
+	}, spec.ImportantDashboards)
+}
+
+func TestHomeConfigSpecOverride(t *testing.T) {
+	global := HomeConfigSpec{
+		ImportantDashboards: []DashboardSelector{{Project: "perses", Dashboard: "Demo"}},
+		Information:         "# Hello",
+	}
+	override := HomeConfigSpec{Information: "# Hello perses"}
+	assert.Equal(t, HomeConfigSpec{
+		ImportantDashboards: []DashboardSelector{{Project: "perses", Dashboard: "Demo"}},
+		Information:         "# Hello perses",
+	}, global.Override(override))
+}
@@ -23,33 +23,81 @@ import (
 type Kind string
 
 const (
-	KindDashboard        Kind = "Dashboard"
-	KindDatasource       Kind = "Datasource"
-	KindFolder           Kind = "Folder"
-	KindGlobalDatasource Kind = "GlobalDatasource"
-	KindGlobalVariable   Kind = "GlobalVariable"
-	KindProject          Kind = "Project"
-	KindVariable         Kind = "Variable"
+	KindDashboard                Kind = "Dashboard"
+	KindDashboardAccess          Kind = "DashboardAccess"
+	KindDashboardAlias           Kind = "DashboardAlias"
+	KindDashboardDraft           Kind = "DashboardDraft"
+	KindDashboardUsage           Kind = "DashboardUsage"
+	KindDatasource               Kind = "Datasource"
+	KindEphemeralDashboard       Kind = "EphemeralDashboard"
+	KindFolder                   Kind = "Folder"
+	KindGlobalDashboard          Kind = "GlobalDashboard"
+	KindGlobalDatasource         Kind = "GlobalDatasource"
+	KindGlobalDatasourceOverride Kind = "GlobalDatasourceOverride"
+	KindGlobalHomeConfig         Kind = "GlobalHomeConfig"
+	KindGlobalVariable           Kind = "GlobalVariable"
+	KindHomeConfig               Kind = "HomeConfig"
+	KindOrganization             Kind = "Organization"
+	KindOrganizationDatasource   Kind = "OrganizationDatasource"
+	KindOrganizationVariable     Kind = "OrganizationVariable"
+	KindPlaylist                 Kind = "Playlist"
+	KindProject                  Kind = "Project"
+	KindSavedView                Kind = "SavedView"
+	KindShareToken               Kind = "ShareToken"
+	KindTeam                     Kind = "Team"
+	KindVariable                 Kind = "Variable"
 )
 
 var KindMap = map[Kind]bool{
-	KindDashboard:        true,
-	KindDatasource:       true,
-	KindFolder:           true,
-	KindGlobalDatasource: true,
-	KindGlobalVariable:   true,
-	KindProject:          true,
-	KindVariable:         true,
+	KindDashboard:                true,
+	KindDashboardAccess:          true,
+	KindDashboardAlias:           true,
+	KindDashboardDraft:           true,
+	KindDashboardUsage:           true,
+	KindDatasource:               true,
+	KindEphemeralDashboard:       true,
+	KindFolder:                   true,
+	KindGlobalDashboard:          true,
+	KindGlobalDatasource:         true,
+	KindGlobalDatasourceOverride: true,
+	KindGlobalHomeConfig:         true,
+	KindGlobalVariable:           true,
+	KindHomeConfig:               true,
+	KindOrganization:             true,
+	KindOrganizationDatasource:   true,
+	KindOrganizationVariable:     true,
+	KindPlaylist:                 true,
+	KindProject:                  true,
+	KindSavedView:                true,
+	KindShareToken:               true,
+	KindTeam:                     true,
+	KindVariable:                 true,
 }
 
 var PluralKindMap = map[Kind]string{
-	KindDashboard:        "dashboards",
-	KindDatasource:       "datasources",
-	KindFolder:           "folders",
-	KindGlobalDatasource: "globaldatasources",
-	KindGlobalVariable:   "globalvariables",
-	KindProject:          "projects",
-	KindVariable:         "variables",
+	KindDashboard:                "dashboards",
+	KindDashboardAccess:          "dashboardaccesses",
+	KindDashboardAlias:           "dashboardaliases",
+	KindDashboardDraft:           "dashboarddrafts",
+	KindDashboardUsage:           "dashboardusages",
+	KindDatasource:               "datasources",
+	KindEphemeralDashboard:       "ephemeraldashboards",
+	KindFolder:                   "folders",
+	KindGlobalDashboard:          "globaldashboards",
+	KindGlobalDatasource:         "globaldatasources",
+	KindGlobalDatasourceOverride: "globaldatasourceoverrides",
+	KindGlobalHomeConfig:         "globalhomeconfigs",
+	KindGlobalVariable:           "globalvariables",
+	KindHomeConfig:               "homeconfigs",
+	KindOrganization:             "organizations",
+	KindOrganizationDatasource:   "organizationdatasources",
+	KindOrganizationVariable:     "organizationvariables",
+	KindPlaylist:                 "playlists",
+	KindProject:                  "projects",
+	KindSavedView:                "savedviews",
+	KindShareToken:               "sharetokens",
+	KindTeam:                     "teams",
+	KindVariable:                 "variables",
 }
 
 func (k *Kind) UnmarshalJSON(data []byte) error {
@@ -93,16 +141,48 @@ func GetStruct(kind Kind) (modelAPI.Entity, error) {
 	switch kind {
 	case KindDashboard:
 		return &Dashboard{}, nil
+	case KindDashboardAccess:
+		return &DashboardAccess{}, nil
+	case KindDashboardAlias:
+		return &DashboardAlias{}, nil
+	case KindDashboardDraft:
+		return &DashboardDraft{}, nil
+	case KindDashboardUsage:
+		return &DashboardUsage{}, nil
 	case KindDatasource:
 		return &Datasource{}, nil
+	case KindEphemeralDashboard:
+		return &EphemeralDashboard{}, nil
 	case KindFolder:
 		return &Folder{}, nil
+	case KindGlobalDashboard:
+		return &GlobalDashboard{}, nil
 	case KindGlobalDatasource:
 		return &GlobalDatasource{}, nil
+	case KindGlobalDatasourceOverride:
+		return &GlobalDatasourceOverride{}, nil
+	case KindGlobalHomeConfig:
+		return &GlobalHomeConfig{}, nil
 	case KindGlobalVariable:
 		return &GlobalVariable{}, nil
+	case KindHomeConfig:
+		return &HomeConfig{}, nil
+	case KindOrganization:
+		return &Organization{}, nil
+	case KindOrganizationDatasource:
+		return &OrganizationDatasource{}, nil
+	case KindOrganizationVariable:
+		return &OrganizationVariable{}, nil
+	case KindPlaylist:
+		return &Playlist{}, nil
 	case KindProject:
 		return &Project{}, nil
+	case KindSavedView:
+		return &SavedView{}, nil
+	case KindShareToken:
+		return &ShareToken{}, nil
+	case KindTeam:
+		return &Team{}, nil
 	case KindVariable:
 		return &Variable{}, nil
 	default:
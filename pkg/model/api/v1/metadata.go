@@ -28,6 +28,10 @@ type Metadata struct {
 	CreatedAt time.Time `json:"created_at" yaml:"created_at"`
 	UpdatedAt time.Time `json:"updated_at" yaml:"updated_at"`
 	Version   uint64    `json:"version" yaml:"version"`
+	// Owners is the list of the teams owning the resource. Each team is referenced by its name.
+	Owners []string `json:"owners,omitempty" yaml:"owners,omitempty"`
+	// Labels is a free key/value map used to select the resources, for example in a playlist.
+	Labels map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
 }
 
 func (m *Metadata) CreateNow() {
@@ -49,6 +53,26 @@ func (m *Metadata) GetName() string {
 	return m.Name
 }
 
+// IsOwnedBy returns true when the team is one of the owners of the resource.
+func (m *Metadata) IsOwnedBy(team string) bool {
+	for _, owner := range m.Owners {
+		if owner == team {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchLabels returns true when the resource has every label of the selector with the same value.
+func (m *Metadata) MatchLabels(selector map[string]string) bool {
+	for key, value := range selector {
+		if current, ok := m.Labels[key]; !ok || current != value {
+			return false
+		}
+	}
+	return true
+}
+
 func NewProjectMetadata(project string, name string) *ProjectMetadata {
 	return &ProjectMetadata{
 		Metadata: Metadata{
@@ -71,3 +95,26 @@ func (m *ProjectMetadata) GetName() string {
 func (m *ProjectMetadata) Update(previous ProjectMetadata) {
 	m.Metadata.Update(previous.Metadata)
 }
+
+func NewOrganizationMetadata(organization string, name string) *OrganizationMetadata {
+	return &OrganizationMetadata{
+		Metadata: Metadata{
+			Name: name,
+		},
+		Organization: organization,
+	}
+}
+
+// OrganizationMetadata is the metadata struct for resources that belongs to an organization.
+type OrganizationMetadata struct {
+	Metadata     `json:",inline" yaml:",inline"`
+	Organization string `json:"organization" yaml:"organization"`
+}
+
+func (m *OrganizationMetadata) GetName() string {
+	return m.Name
+}
+
+func (m *OrganizationMetadata) Update(previous OrganizationMetadata) {
+	m.Metadata.Update(previous.Metadata)
+}
@@ -83,3 +83,11 @@ func TestProjectMetadata_UpdateVersion(t *testing.T) {
 	}
 	assert.Equal(t, m.Version, uint64(10))
 }
+
+func TestMetadata_MatchLabels(t *testing.T) {
+	m := Metadata{Name: "test", Labels: map[string]string{"team": "network", "env": "production"}}
+	assert.True(t, m.MatchLabels(map[string]string{"team": "network"}))
+	assert.True(t, m.MatchLabels(nil))
+	assert.False(t, m.MatchLabels(map[string]string{"team": "network", "env": "staging"}))
+	assert.False(t, m.MatchLabels(map[string]string{"region": "eu"}))
+}
@@ -0,0 +1,91 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+
+	modelAPI "github.com/perses/perses/pkg/model/api"
+	"github.com/perses/perses/pkg/model/api/v1/common"
+)
+
+// DefaultOrganization is the organization of the projects created without organization,
+// and of the projects created before the organizations exist.
+const DefaultOrganization = "default"
+
+type OrganizationSettings struct {
+	// Readonly when true forbids any modification of the projects of the organization and of their resources,
+	// as well as of the datasources and of the variables of the organization.
+	// The organization itself can still be modified, to remove this setting.
+	Readonly bool `json:"readonly,omitempty" yaml:"readonly,omitempty"`
+}
+
+type OrganizationSpec struct {
+	Display  *common.Display      `json:"display,omitempty" yaml:"display,omitempty"`
+	Settings OrganizationSettings `json:"settings,omitempty" yaml:"settings,omitempty"`
+}
+
+// Organization is the tenancy layer above the projects. Each project belongs to exactly one organization.
+// The datasources and the variables of an organization are only available to its projects.
+type Organization struct {
+	Kind     Kind             `json:"kind" yaml:"kind"`
+	Metadata Metadata         `json:"metadata" yaml:"metadata"`
+	Spec     OrganizationSpec `json:"spec,omitempty" yaml:"spec,omitempty"`
+}
+
+func (o *Organization) GetMetadata() modelAPI.Metadata {
+	return &o.Metadata
+}
+
+func (o *Organization) GetKind() string {
+	return string(o.Kind)
+}
+
+func (o *Organization) GetSpec() interface{} {
+	return o.Spec
+}
+
+func (o *Organization) UnmarshalJSON(data []byte) error {
+	var tmp Organization
+	type plain Organization
+	if err := json.Unmarshal(data, (*plain)(&tmp)); err != nil {
+		return err
+	}
+	if err := (&tmp).validate(); err != nil {
+		return err
+	}
+	*o = tmp
+	return nil
+}
+
+func (o *Organization) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var tmp Organization
+	type plain Organization
+	if err := unmarshal((*plain)(&tmp)); err != nil {
+		return err
+	}
+	if err := (&tmp).validate(); err != nil {
+		return err
+	}
+	*o = tmp
+	return nil
+}
+
+func (o *Organization) validate() error {
+	if o.Kind != KindOrganization {
+		return fmt.Errorf("invalid kind: %q for an Organization type", o.Kind)
+	}
+	return nil
+}
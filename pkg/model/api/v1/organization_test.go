@@ -0,0 +1,67 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnmarshalOrganization(t *testing.T) {
+	jason := `
+{
+  "kind": "Organization",
+  "metadata": {
+    "name": "acme"
+  },
+  "spec": {
+    "settings": {
+      "readonly": true
+    }
+  }
+}
+`
+	expected := &Organization{
+		Kind: KindOrganization,
+		Metadata: Metadata{
+			Name: "acme",
+		},
+		Spec: OrganizationSpec{
+			Settings: OrganizationSettings{
+				Readonly: true,
+			},
+		},
+	}
+	result := &Organization{}
+	assert.NoError(t, json.Unmarshal([]byte(jason), result))
+	assert.Equal(t, expected, result)
+}
+
+func TestUnmarshalOrganizationError(t *testing.T) {
+	jason := `{"kind": "Project", "metadata": {"name": "acme"}}`
+	result := &Organization{}
+	assert.Equal(t, fmt.Errorf("invalid kind: \"Project\" for an Organization type"), json.Unmarshal([]byte(jason), result))
+}
+
+func TestUnmarshalProjectWithoutOrganization(t *testing.T) {
+	// the projects created before the organizations exist don't have any organization
+	jason := `{"kind": "Project", "metadata": {"name": "perses"}}`
+	result := &Project{}
+	assert.NoError(t, json.Unmarshal([]byte(jason), result))
+	assert.Equal(t, "perses", result.Metadata.Name)
+	assert.Empty(t, result.Metadata.Organization)
+}
@@ -0,0 +1,110 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// WildcardProject is used in a permission to target every project.
+const WildcardProject = "*"
+
+// Role is the level of access on a project. Each role includes the access of the previous one.
+type Role string
+
+const (
+	// RoleViewer can read the resources and query the datasources.
+	RoleViewer Role = "viewer"
+	// RoleEditor can also create, update and delete the resources.
+	RoleEditor Role = "editor"
+	// RoleAdmin can also update and delete the project itself. On the WildcardProject, it can manage the global resources.
+	RoleAdmin Role = "admin"
+)
+
+var roleLevel = map[Role]int{
+	RoleViewer: 1,
+	RoleEditor: 2,
+	RoleAdmin:  3,
+}
+
+func (r *Role) UnmarshalJSON(data []byte) error {
+	var tmp Role
+	type plain Role
+	if err := json.Unmarshal(data, (*plain)(&tmp)); err != nil {
+		return err
+	}
+	if err := (&tmp).validate(); err != nil {
+		return err
+	}
+	*r = tmp
+	return nil
+}
+
+func (r *Role) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var tmp Role
+	type plain Role
+	if err := unmarshal((*plain)(&tmp)); err != nil {
+		return err
+	}
+	if err := (&tmp).validate(); err != nil {
+		return err
+	}
+	*r = tmp
+	return nil
+}
+
+func (r *Role) validate() error {
+	if _, ok := roleLevel[*r]; !ok {
+		return fmt.Errorf("unknown role %q, it should be one of %q, %q or %q", *r, RoleViewer, RoleEditor, RoleAdmin)
+	}
+	return nil
+}
+
+// Includes returns true when the role gives at least the access of the other role.
+func (r Role) Includes(other Role) bool {
+	return roleLevel[r] > 0 && roleLevel[r] >= roleLevel[other]
+}
+
+// Permission grants a role on a project, or on every project of an organization.
+type Permission struct {
+	// Project is the name of the project, or WildcardProject for all of them.
+	Project string `json:"project,omitempty" yaml:"project,omitempty"`
+	// Organization grants the role on every project of the organization, as well as on its datasources and its
+	// variables. It cannot be set with Project.
+	Organization string `json:"organization,omitempty" yaml:"organization,omitempty"`
+	Role         Role   `json:"role" yaml:"role"`
+}
+
+// Validate checks the permission targets either a project or an organization.
+func (p Permission) Validate() error {
+	if len(p.Project) == 0 && len(p.Organization) == 0 {
+		return fmt.Errorf("a permission must target a project or an organization")
+	}
+	if len(p.Project) > 0 && len(p.Organization) > 0 {
+		return fmt.Errorf("a permission cannot target both the project %q and the organization %q", p.Project, p.Organization)
+	}
+	return nil
+}
+
+// Allows returns true when the permission gives at least the role on the project.
+// The permissions on an organization are not considered since the organization of the project is not known here.
+func (p Permission) Allows(project string, role Role) bool {
+	return len(p.Project) > 0 && (p.Project == WildcardProject || p.Project == project) && p.Role.Includes(role)
+}
+
+// AllowsOrganization returns true when the permission gives at least the role on the organization.
+func (p Permission) AllowsOrganization(organization string, role Role) bool {
+	return (p.Project == WildcardProject || (len(p.Organization) > 0 && p.Organization == organization)) && p.Role.Includes(role)
+}
@@ -0,0 +1,172 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+
+	modelAPI "github.com/perses/perses/pkg/model/api"
+	"github.com/perses/perses/pkg/model/api/v1/common"
+	"github.com/prometheus/common/model"
+)
+
+// PlaylistItem selects the dashboards of the project to display. Exactly one of Dashboard, Tag or Labels must be set.
+type PlaylistItem struct {
+	// Dashboard is the name of a dashboard of the project.
+	Dashboard string `json:"dashboard,omitempty" yaml:"dashboard,omitempty"`
+	// Tag selects every dashboard of the project having this tag. The dashboards are sorted by name.
+	Tag string `json:"tag,omitempty" yaml:"tag,omitempty"`
+	// Labels selects every dashboard of the project having all these labels. The dashboards are sorted by name.
+	Labels map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+	// Interval is the time each dashboard selected is displayed. Default is the interval of the playlist.
+	Interval model.Duration `json:"interval,omitempty" yaml:"interval,omitempty"`
+	// Variables overrides the value of some variables of the dashboards selected.
+	Variables map[string]string `json:"variables,omitempty" yaml:"variables,omitempty"`
+}
+
+func (p *PlaylistItem) validate() error {
+	selectors := 0
+	if len(p.Dashboard) > 0 {
+		selectors++
+	}
+	if len(p.Tag) > 0 {
+		selectors++
+	}
+	if len(p.Labels) > 0 {
+		selectors++
+	}
+	if selectors != 1 {
+		return fmt.Errorf("exactly one of dashboard, tag or labels must be set")
+	}
+	if p.Interval < 0 {
+		return fmt.Errorf("interval cannot be negative")
+	}
+	return nil
+}
+
+type PlaylistSpec struct {
+	Display *common.Display `json:"display,omitempty" yaml:"display,omitempty"`
+	// Interval is the default time each dashboard is displayed.
+	Interval model.Duration `json:"interval" yaml:"interval"`
+	Items    []PlaylistItem `json:"items" yaml:"items"`
+}
+
+func (p *PlaylistSpec) UnmarshalJSON(data []byte) error {
+	var tmp PlaylistSpec
+	type plain PlaylistSpec
+	if err := json.Unmarshal(data, (*plain)(&tmp)); err != nil {
+		return err
+	}
+	if err := (&tmp).validate(); err != nil {
+		return err
+	}
+	*p = tmp
+	return nil
+}
+
+func (p *PlaylistSpec) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var tmp PlaylistSpec
+	type plain PlaylistSpec
+	if err := unmarshal((*plain)(&tmp)); err != nil {
+		return err
+	}
+	if err := (&tmp).validate(); err != nil {
+		return err
+	}
+	*p = tmp
+	return nil
+}
+
+func (p *PlaylistSpec) validate() error {
+	if p.Interval <= 0 {
+		return fmt.Errorf("interval must be set")
+	}
+	if len(p.Items) == 0 {
+		return fmt.Errorf("items cannot be empty")
+	}
+	for i := range p.Items {
+		if err := p.Items[i].validate(); err != nil {
+			return fmt.Errorf("item %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// GetInterval returns the time the dashboards selected by the item are displayed.
+func (p *PlaylistSpec) GetInterval(item PlaylistItem) model.Duration {
+	if item.Interval > 0 {
+		return item.Interval
+	}
+	return p.Interval
+}
+
+// Playlist is a list of dashboards of a project displayed one after the other, for example on a wall screen.
+type Playlist struct {
+	Kind     Kind            `json:"kind" yaml:"kind"`
+	Metadata ProjectMetadata `json:"metadata" yaml:"metadata"`
+	Spec     PlaylistSpec    `json:"spec" yaml:"spec"`
+}
+
+func (p *Playlist) GetMetadata() modelAPI.Metadata {
+	return &p.Metadata
+}
+
+func (p *Playlist) GetKind() string {
+	return string(p.Kind)
+}
+
+func (p *Playlist) GetSpec() interface{} {
+	return p.Spec
+}
+
+func (p *Playlist) UnmarshalJSON(data []byte) error {
+	var tmp Playlist
+	type plain Playlist
+	if err := json.Unmarshal(data, (*plain)(&tmp)); err != nil {
+		return err
+	}
+	if err := (&tmp).validate(); err != nil {
+		return err
+	}
+	*p = tmp
+	return nil
+}
+
+func (p *Playlist) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var tmp Playlist
+	type plain Playlist
+	if err := unmarshal((*plain)(&tmp)); err != nil {
+		return err
+	}
+	if err := (&tmp).validate(); err != nil {
+		return err
+	}
+	*p = tmp
+	return nil
+}
+
+func (p *Playlist) validate() error {
+	if p.Kind != KindPlaylist {
+		return fmt.Errorf("invalid kind: %q for a Playlist type", p.Kind)
+	}
+	return nil
+}
+
+// PlaylistEntry is a dashboard to display, once the items of a playlist have been resolved.
+type PlaylistEntry struct {
+	Dashboard string            `json:"dashboard" yaml:"dashboard"`
+	Interval  model.Duration    `json:"interval" yaml:"interval"`
+	Variables map[string]string `json:"variables,omitempty" yaml:"variables,omitempty"`
+}
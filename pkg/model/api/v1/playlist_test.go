@@ -0,0 +1,80 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+)
+
+func TestUnmarshalPlaylistSpecError(t *testing.T) {
+	testSuite := []struct {
+		title string
+		jason string
+		err   error
+	}{
+		{
+			title: "no interval",
+			jason: `{"items": [{"dashboard": "incident"}]}`,
+			err:   fmt.Errorf("interval must be set"),
+		},
+		{
+			title: "no item",
+			jason: `{"interval": "1m", "items": []}`,
+			err:   fmt.Errorf("items cannot be empty"),
+		},
+		{
+			title: "item without selector",
+			jason: `{"interval": "1m", "items": [{"interval": "30s"}]}`,
+			err:   fmt.Errorf("item 0: exactly one of dashboard, tag or labels must be set"),
+		},
+		{
+			title: "item with several selectors",
+			jason: `{"interval": "1m", "items": [{"dashboard": "incident"}, {"tag": "noc", "labels": {"team": "network"}}]}`,
+			err:   fmt.Errorf("item 1: exactly one of dashboard, tag or labels must be set"),
+		},
+	}
+	for _, test := range testSuite {
+		t.Run(test.title, func(t *testing.T) {
+			result := &PlaylistSpec{}
+			assert.Equal(t, test.err.Error(), json.Unmarshal([]byte(test.jason), result).Error())
+		})
+	}
+}
+
+func TestUnmarshalPlaylistYAML(t *testing.T) {
+	data := `
+kind: "Playlist"
+metadata:
+  name: "noc"
+  project: "perses"
+spec:
+  interval: "1m"
+  items:
+    - dashboard: "incident"
+      interval: "30s"
+      variables:
+        env: "production"
+    - tag: "noc"
+`
+	result := &Playlist{}
+	assert.NoError(t, yaml.Unmarshal([]byte(data), result))
+	assert.Equal(t, "30s", result.Spec.GetInterval(result.Spec.Items[0]).String())
+	assert.Equal(t, "1m", result.Spec.GetInterval(result.Spec.Items[1]).String())
+	assert.Equal(t, map[string]string{"env": "production"}, result.Spec.Items[0].Variables)
+}
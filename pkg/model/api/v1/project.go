@@ -23,10 +23,12 @@ import (
 type ProjectSpec struct {
 }
 
+// Project is a group of dashboards and of the resources used by them. It belongs to an organization.
+// The name of a project is unique across all the organizations.
 type Project struct {
-	Kind     Kind        `json:"kind" yaml:"kind"`
-	Metadata Metadata    `json:"metadata" yaml:"metadata"`
-	Spec     ProjectSpec `json:"spec,omitempty" yaml:"spec,omitempty"`
+	Kind     Kind                 `json:"kind" yaml:"kind"`
+	Metadata OrganizationMetadata `json:"metadata" yaml:"metadata"`
+	Spec     ProjectSpec          `json:"spec,omitempty" yaml:"spec,omitempty"`
 }
 
 func (p *Project) GetMetadata() modelAPI.Metadata {
@@ -67,6 +69,15 @@ func (p *Project) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	return nil
 }
 
+// GetOrganization returns the organization of the project. The projects created before the organizations exist belong
+// to the DefaultOrganization.
+func (p *Project) GetOrganization() string {
+	if len(p.Metadata.Organization) == 0 {
+		return DefaultOrganization
+	}
+	return p.Metadata.Organization
+}
+
 func (p *Project) validate() error {
 	if p.Kind != KindProject {
 		return fmt.Errorf("invalid kind: %q for a Project type", p.Kind)
@@ -0,0 +1,59 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"encoding/json"
+
+	"github.com/perses/perses/pkg/model/api/v1/common"
+)
+
+// Rename is the request to give a new name to a resource. The references to the resource are rewritten accordingly.
+type Rename struct {
+	// Name is the new name of the resource.
+	Name string `json:"name" yaml:"name"`
+	// KeepAlias keeps the previous name as an alias of the renamed resource, so the links using the previous name
+	// keep working. Only the dashboards support it.
+	KeepAlias bool `json:"keep_alias,omitempty" yaml:"keep_alias,omitempty"`
+}
+
+func (r *Rename) UnmarshalJSON(data []byte) error {
+	var tmp Rename
+	type plain Rename
+	if err := json.Unmarshal(data, (*plain)(&tmp)); err != nil {
+		return err
+	}
+	if err := (&tmp).validate(); err != nil {
+		return err
+	}
+	*r = tmp
+	return nil
+}
+
+func (r *Rename) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var tmp Rename
+	type plain Rename
+	if err := unmarshal((*plain)(&tmp)); err != nil {
+		return err
+	}
+	if err := (&tmp).validate(); err != nil {
+		return err
+	}
+	*r = tmp
+	return nil
+}
+
+func (r *Rename) validate() error {
+	return common.ValidateID(r.Name)
+}
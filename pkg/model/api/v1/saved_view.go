@@ -0,0 +1,273 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	modelAPI "github.com/perses/perses/pkg/model/api"
+	"github.com/perses/perses/pkg/model/api/v1/common"
+	"github.com/perses/perses/pkg/model/api/v1/dashboard"
+	"github.com/perses/perses/pkg/model/api/v1/variable"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// VariableAllValue is the value used to select every value of a list variable allowing it.
+	VariableAllValue = "$__all"
+	// staticListVariableKind is the kind of the plugin of the list variables whose values are known in advance.
+	staticListVariableKind = "StaticListVariable"
+)
+
+type SavedViewSpec struct {
+	Display *common.Display `json:"display,omitempty" yaml:"display,omitempty"`
+	// Dashboard is the name of the dashboard of the project the view applies to.
+	Dashboard string `json:"dashboard" yaml:"dashboard"`
+	// Variables is the value selected for each variable. A list variable allowing multiple values accepts a list.
+	Variables map[string]*variable.DefaultValue `json:"variables,omitempty" yaml:"variables,omitempty"`
+	TimeRange *TimeRange                        `json:"time_range,omitempty" yaml:"time_range,omitempty"`
+}
+
+func (s *SavedViewSpec) UnmarshalJSON(data []byte) error {
+	var tmp SavedViewSpec
+	type plain SavedViewSpec
+	if err := json.Unmarshal(data, (*plain)(&tmp)); err != nil {
+		return err
+	}
+	if err := (&tmp).validate(); err != nil {
+		return err
+	}
+	*s = tmp
+	return nil
+}
+
+func (s *SavedViewSpec) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var tmp SavedViewSpec
+	type plain SavedViewSpec
+	if err := unmarshal((*plain)(&tmp)); err != nil {
+		return err
+	}
+	if err := (&tmp).validate(); err != nil {
+		return err
+	}
+	*s = tmp
+	return nil
+}
+
+func (s *SavedViewSpec) validate() error {
+	if len(s.Dashboard) == 0 {
+		return fmt.Errorf("dashboard cannot be empty")
+	}
+	if len(s.Variables) == 0 && s.TimeRange == nil {
+		return fmt.Errorf("variables or time_range must be set")
+	}
+	for name, value := range s.Variables {
+		if value == nil || (len(value.SingleValue) == 0 && len(value.SliceValues) == 0) {
+			return fmt.Errorf("the value of the variable %q cannot be empty", name)
+		}
+	}
+	if s.TimeRange != nil {
+		return s.TimeRange.validate()
+	}
+	return nil
+}
+
+// CheckDashboard returns the reasons why the view cannot be applied on the dashboard, sorted by variable.
+// The values of the list variables can only be verified when the values are static.
+func (s *SavedViewSpec) CheckDashboard(dash *Dashboard) []string {
+	variables := make(map[string]dashboard.Variable, len(dash.Spec.Variables))
+	for _, v := range dash.Spec.Variables {
+		variables[v.Spec.GetName()] = v
+	}
+	names := make([]string, 0, len(s.Variables))
+	for name := range s.Variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var result []string
+	for _, name := range names {
+		v, ok := variables[name]
+		if !ok {
+			result = append(result, fmt.Sprintf("variable %q doesn't exist in the dashboard", name))
+			continue
+		}
+		if err := checkVariableValue(v, s.Variables[name]); err != nil {
+			result = append(result, fmt.Sprintf("variable %q: %s", name, err))
+		}
+	}
+	return result
+}
+
+// ApplyOn replaces the default value of the variables of the dashboard by the ones selected in the view.
+// The duration of the dashboard is replaced too when the time range of the view is relative.
+// The view must have been checked against the dashboard before.
+func (s *SavedViewSpec) ApplyOn(dash *Dashboard) {
+	for _, v := range dash.Spec.Variables {
+		value, ok := s.Variables[v.Spec.GetName()]
+		if !ok {
+			continue
+		}
+		switch spec := v.Spec.(type) {
+		case *dashboard.ListVariableSpec:
+			spec.DefaultValue = value
+		case *dashboard.TextVariableSpec:
+			spec.Value = value.SingleValue
+		}
+	}
+	if s.TimeRange != nil && s.TimeRange.Duration > 0 {
+		dash.Spec.Duration = s.TimeRange.Duration
+	}
+}
+
+func checkVariableValue(v dashboard.Variable, value *variable.DefaultValue) error {
+	switch spec := v.Spec.(type) {
+	case *dashboard.TextVariableSpec:
+		if len(value.SliceValues) > 0 {
+			return fmt.Errorf("a text variable accepts a single value")
+		}
+	case *dashboard.ListVariableSpec:
+		values := value.SliceValues
+		if len(value.SingleValue) > 0 {
+			values = []string{value.SingleValue}
+		}
+		if len(values) > 1 && !spec.AllowMultiple {
+			return fmt.Errorf("multiple values are not allowed")
+		}
+		options, isStatic := getStaticValues(spec.Plugin)
+		for _, val := range values {
+			if val == VariableAllValue {
+				if !spec.AllowAllValue {
+					return fmt.Errorf("the value %q is not allowed", VariableAllValue)
+				}
+				continue
+			}
+			if isStatic && !options[val] {
+				return fmt.Errorf("%q is not one of the values of the variable", val)
+			}
+		}
+	}
+	return nil
+}
+
+// staticListValue is a value of a StaticListVariable. It is either a string or an object {"value": ..., "label": ...}.
+type staticListValue string
+
+func (v *staticListValue) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err == nil {
+		*v = staticListValue(s)
+		return nil
+	}
+	var obj struct {
+		Value string `yaml:"value"`
+	}
+	if err := unmarshal(&obj); err != nil {
+		return err
+	}
+	*v = staticListValue(obj.Value)
+	return nil
+}
+
+// getStaticValues returns the values of the plugin when it is a StaticListVariable.
+func getStaticValues(plugin common.Plugin) (map[string]bool, bool) {
+	if plugin.Kind != staticListVariableKind {
+		return nil, false
+	}
+	// The spec can come from a JSON or a YAML document, so it goes through a YAML serialization that supports both kind of map.
+	data, err := yaml.Marshal(plugin.Spec)
+	if err != nil {
+		return nil, false
+	}
+	var spec struct {
+		Values []staticListValue `yaml:"values"`
+	}
+	if unmarshalErr := yaml.Unmarshal(data, &spec); unmarshalErr != nil {
+		return nil, false
+	}
+	result := make(map[string]bool, len(spec.Values))
+	for _, val := range spec.Values {
+		result[string(val)] = true
+	}
+	return result, true
+}
+
+type SavedViewStatus struct {
+	// Broken is true when the dashboard has changed and the view cannot be applied anymore.
+	Broken bool `json:"broken" yaml:"broken"`
+	// Errors explains why the view is broken.
+	Errors []string `json:"errors,omitempty" yaml:"errors,omitempty"`
+}
+
+// SavedView is a named preset of variable values and time range for a dashboard.
+type SavedView struct {
+	Kind     Kind            `json:"kind" yaml:"kind"`
+	Metadata ProjectMetadata `json:"metadata" yaml:"metadata"`
+	Spec     SavedViewSpec   `json:"spec" yaml:"spec"`
+	// Status is computed by the server each time the view is returned. It is ignored when the view is saved.
+	Status *SavedViewStatus `json:"status,omitempty" yaml:"status,omitempty"`
+}
+
+func (s *SavedView) GetMetadata() modelAPI.Metadata {
+	return &s.Metadata
+}
+
+func (s *SavedView) GetKind() string {
+	return string(s.Kind)
+}
+
+func (s *SavedView) GetSpec() interface{} {
+	return s.Spec
+}
+
+func (s *SavedView) UnmarshalJSON(data []byte) error {
+	var tmp SavedView
+	type plain SavedView
+	if err := json.Unmarshal(data, (*plain)(&tmp)); err != nil {
+		return err
+	}
+	if err := (&tmp).validate(); err != nil {
+		return err
+	}
+	*s = tmp
+	return nil
+}
+
+func (s *SavedView) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var tmp SavedView
+	type plain SavedView
+	if err := unmarshal((*plain)(&tmp)); err != nil {
+		return err
+	}
+	if err := (&tmp).validate(); err != nil {
+		return err
+	}
+	*s = tmp
+	return nil
+}
+
+func (s *SavedView) validate() error {
+	if s.Kind != KindSavedView {
+		return fmt.Errorf("invalid kind: %q for a SavedView type", s.Kind)
+	}
+	return nil
+}
+
+// SavedViewDashboard is the dashboard once a saved view has been applied on it.
+type SavedViewDashboard struct {
+	Dashboard *Dashboard `json:"dashboard" yaml:"dashboard"`
+	// TimeRange is the time range of the view. When it is absolute, it cannot be expressed in the dashboard itself.
+	TimeRange *TimeRange `json:"time_range,omitempty" yaml:"time_range,omitempty"`
+}
@@ -0,0 +1,164 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/perses/perses/pkg/model/api/v1/common"
+	"github.com/perses/perses/pkg/model/api/v1/dashboard"
+	"github.com/perses/perses/pkg/model/api/v1/variable"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+)
+
+func newSavedViewTestDashboard() *Dashboard {
+	return &Dashboard{
+		Kind:     KindDashboard,
+		Metadata: ProjectMetadata{Metadata: Metadata{Name: "incident"}, Project: "perses"},
+		Spec: DashboardSpec{
+			Duration: model.Duration(time.Hour),
+			Variables: []dashboard.Variable{
+				{
+					Kind: variable.KindList,
+					Spec: &dashboard.ListVariableSpec{
+						Name: "interval",
+						ListSpec: variable.ListSpec{
+							Plugin: common.Plugin{
+								Kind: "StaticListVariable",
+								Spec: map[string]interface{}{"values": []interface{}{"1m", map[string]interface{}{"value": "5m"}}},
+							},
+						},
+					},
+				},
+				{
+					Kind: variable.KindList,
+					Spec: &dashboard.ListVariableSpec{
+						Name: "instance",
+						ListSpec: variable.ListSpec{
+							AllowMultiple: true,
+							Plugin:        common.Plugin{Kind: "PrometheusLabelValuesVariable"},
+						},
+					},
+				},
+				{
+					Kind: variable.KindText,
+					Spec: &dashboard.TextVariableSpec{Name: "text", TextSpec: variable.TextSpec{Value: "test"}},
+				},
+			},
+		},
+	}
+}
+
+func TestUnmarshalSavedViewSpecError(t *testing.T) {
+	testSuite := []struct {
+		title string
+		jason string
+		err   error
+	}{
+		{
+			title: "no dashboard",
+			jason: `{"variables": {"env": "production"}}`,
+			err:   fmt.Errorf("dashboard cannot be empty"),
+		},
+		{
+			title: "nothing saved",
+			jason: `{"dashboard": "incident"}`,
+			err:   fmt.Errorf("variables or time_range must be set"),
+		},
+		{
+			title: "empty value",
+			jason: `{"dashboard": "incident", "variables": {"env": []}}`,
+			err:   fmt.Errorf("the value of the variable \"env\" cannot be empty"),
+		},
+		{
+			title: "invalid time range",
+			jason: `{"dashboard": "incident", "time_range": {"duration": "1h", "start": "2023-01-01T00:00:00Z"}}`,
+			err:   fmt.Errorf("time_range.duration cannot be used with time_range.start or time_range.end"),
+		},
+	}
+	for _, test := range testSuite {
+		t.Run(test.title, func(t *testing.T) {
+			result := &SavedViewSpec{}
+			assert.Equal(t, test.err.Error(), json.Unmarshal([]byte(test.jason), result).Error())
+		})
+	}
+}
+
+func TestSavedViewSpecCheckDashboard(t *testing.T) {
+	testSuite := []struct {
+		title     string
+		variables string
+		result    []string
+	}{
+		{
+			title:     "compatible values",
+			variables: `{"interval": "5m", "instance": ["a", "b"], "text": "foo"}`,
+		},
+		{
+			title:     "unknown variable",
+			variables: `{"env": "production"}`,
+			result:    []string{`variable "env" doesn't exist in the dashboard`},
+		},
+		{
+			title:     "value not in the static list",
+			variables: `{"interval": "10m"}`,
+			result:    []string{`variable "interval": "10m" is not one of the values of the variable`},
+		},
+		{
+			title:     "multiple values not allowed",
+			variables: `{"interval": ["1m", "5m"], "text": ["foo", "bar"]}`,
+			result: []string{
+				`variable "interval": multiple values are not allowed`,
+				`variable "text": a text variable accepts a single value`,
+			},
+		},
+		{
+			title:     "all value not allowed",
+			variables: `{"instance": "$__all"}`,
+			result:    []string{`variable "instance": the value "$__all" is not allowed`},
+		},
+	}
+	for _, test := range testSuite {
+		t.Run(test.title, func(t *testing.T) {
+			spec := &SavedViewSpec{}
+			assert.NoError(t, json.Unmarshal([]byte(fmt.Sprintf(`{"dashboard": "incident", "variables": %s}`, test.variables)), spec))
+			assert.Equal(t, test.result, spec.CheckDashboard(newSavedViewTestDashboard()))
+		})
+	}
+}
+
+func TestSavedViewSpecApplyOn(t *testing.T) {
+	data := `
+dashboard: "incident"
+variables:
+  interval: "5m"
+  instance: ["a", "b"]
+  text: "foo"
+time_range:
+  duration: "15m"
+`
+	spec := &SavedViewSpec{}
+	assert.NoError(t, yaml.Unmarshal([]byte(data), spec))
+	dash := newSavedViewTestDashboard()
+	spec.ApplyOn(dash)
+	assert.Equal(t, "5m", dash.Spec.Variables[0].Spec.(*dashboard.ListVariableSpec).DefaultValue.SingleValue)
+	assert.Equal(t, []string{"a", "b"}, dash.Spec.Variables[1].Spec.(*dashboard.ListVariableSpec).DefaultValue.SliceValues)
+	assert.Equal(t, "foo", dash.Spec.Variables[2].Spec.(*dashboard.TextVariableSpec).Value)
+	assert.Equal(t, "15m", dash.Spec.Duration.String())
+}
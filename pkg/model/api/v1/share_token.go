@@ -0,0 +1,128 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	modelAPI "github.com/perses/perses/pkg/model/api"
+	"github.com/prometheus/common/model"
+)
+
+// DefaultShareRateLimit is the number of requests per minute allowed by default with a share token.
+const DefaultShareRateLimit = 300
+
+type ShareTokenSpec struct {
+	// Dashboard is the name of the dashboard in the project that can be read with the token.
+	Dashboard string `json:"dashboard" yaml:"dashboard"`
+	// Variables fixes the value of some variables of the dashboard.
+	Variables map[string]string `json:"variables,omitempty" yaml:"variables,omitempty"`
+	TimeRange *TimeRange        `json:"time_range,omitempty" yaml:"time_range,omitempty"`
+	// TTL is the time to live of the token. When set, it is used to compute ExpireAt when the token is created.
+	TTL model.Duration `json:"ttl,omitempty" yaml:"ttl,omitempty"`
+	// ExpireAt is the time after which the token is not valid anymore.
+	ExpireAt time.Time `json:"expire_at,omitempty" yaml:"expire_at,omitempty"`
+	// RateLimit is the maximum number of requests per minute made with the token. Default is DefaultShareRateLimit.
+	RateLimit int `json:"rate_limit,omitempty" yaml:"rate_limit,omitempty"`
+	// SecretHash is the SHA-256 of the secret part of the token. It is never returned by the API.
+	SecretHash string `json:"secret_hash,omitempty" yaml:"secret_hash,omitempty"`
+}
+
+func (s *ShareTokenSpec) UnmarshalJSON(data []byte) error {
+	var tmp ShareTokenSpec
+	type plain ShareTokenSpec
+	if err := json.Unmarshal(data, (*plain)(&tmp)); err != nil {
+		return err
+	}
+	if err := (&tmp).validate(); err != nil {
+		return err
+	}
+	*s = tmp
+	return nil
+}
+
+func (s *ShareTokenSpec) validate() error {
+	if s.TTL <= 0 && s.ExpireAt.IsZero() {
+		return fmt.Errorf("ttl or expire_at must be set")
+	}
+	if s.RateLimit < 0 {
+		return fmt.Errorf("rate_limit cannot be negative")
+	}
+	if s.TimeRange != nil {
+		return s.TimeRange.validate()
+	}
+	return nil
+}
+
+// IsExpired returns true when the token cannot be used anymore.
+func (s *ShareTokenSpec) IsExpired(now time.Time) bool {
+	return !s.ExpireAt.After(now)
+}
+
+// ShareToken gives an anonymous read-only access to a single dashboard.
+// The name of the token is generated by the server, like its secret.
+type ShareToken struct {
+	Kind     Kind            `json:"kind" yaml:"kind"`
+	Metadata ProjectMetadata `json:"metadata" yaml:"metadata"`
+	Spec     ShareTokenSpec  `json:"spec" yaml:"spec"`
+}
+
+func (s *ShareToken) GetMetadata() modelAPI.Metadata {
+	return &s.Metadata
+}
+
+func (s *ShareToken) GetKind() string {
+	return string(s.Kind)
+}
+
+func (s *ShareToken) GetSpec() interface{} {
+	return s.Spec
+}
+
+func (s *ShareToken) UnmarshalJSON(data []byte) error {
+	var tmp ShareToken
+	type plain ShareToken
+	if err := json.Unmarshal(data, (*plain)(&tmp)); err != nil {
+		return err
+	}
+	if err := (&tmp).validate(); err != nil {
+		return err
+	}
+	*s = tmp
+	return nil
+}
+
+func (s *ShareToken) validate() error {
+	if s.Kind != KindShareToken {
+		return fmt.Errorf("invalid kind: %q for a ShareToken type", s.Kind)
+	}
+	return nil
+}
+
+// ShareTokenCreation is returned when a token is created. It is the only time the token itself is returned.
+type ShareTokenCreation struct {
+	// Token must be presented to access the dashboard.
+	Token      string      `json:"token" yaml:"token"`
+	ShareToken *ShareToken `json:"share_token" yaml:"share_token"`
+}
+
+// SharedDashboard is the dashboard returned to the anonymous users presenting a share token.
+type SharedDashboard struct {
+	Dashboard *Dashboard        `json:"dashboard" yaml:"dashboard"`
+	Variables map[string]string `json:"variables,omitempty" yaml:"variables,omitempty"`
+	TimeRange *TimeRange        `json:"time_range,omitempty" yaml:"time_range,omitempty"`
+	ExpireAt  time.Time         `json:"expire_at" yaml:"expire_at"`
+}
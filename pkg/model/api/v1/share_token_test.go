@@ -0,0 +1,69 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnmarshalShareTokenSpecError(t *testing.T) {
+	testSuite := []struct {
+		title string
+		jason string
+		err   error
+	}{
+		{
+			title: "no expiry",
+			jason: `{"dashboard": "incident"}`,
+			err:   fmt.Errorf("ttl or expire_at must be set"),
+		},
+		{
+			title: "negative rate limit",
+			jason: `{"dashboard": "incident", "ttl": "1d", "rate_limit": -1}`,
+			err:   fmt.Errorf("rate_limit cannot be negative"),
+		},
+		{
+			title: "duration and start",
+			jason: `{"dashboard": "incident", "ttl": "1d", "time_range": {"duration": "1h", "start": "2023-06-01T00:00:00Z"}}`,
+			err:   fmt.Errorf("time_range.duration cannot be used with time_range.start or time_range.end"),
+		},
+		{
+			title: "start without end",
+			jason: `{"dashboard": "incident", "ttl": "1d", "time_range": {"start": "2023-06-01T00:00:00Z"}}`,
+			err:   fmt.Errorf("time_range.duration or both time_range.start and time_range.end must be set"),
+		},
+		{
+			title: "start after end",
+			jason: `{"dashboard": "incident", "ttl": "1d", "time_range": {"start": "2023-06-02T00:00:00Z", "end": "2023-06-01T00:00:00Z"}}`,
+			err:   fmt.Errorf("time_range.start must be before time_range.end"),
+		},
+	}
+	for _, test := range testSuite {
+		t.Run(test.title, func(t *testing.T) {
+			result := &ShareTokenSpec{}
+			assert.Equal(t, test.err, json.Unmarshal([]byte(test.jason), result))
+		})
+	}
+}
+
+func TestShareTokenSpec_IsExpired(t *testing.T) {
+	now := time.Now()
+	assert.False(t, (&ShareTokenSpec{ExpireAt: now.Add(time.Minute)}).IsExpired(now))
+	assert.True(t, (&ShareTokenSpec{ExpireAt: now}).IsExpired(now))
+}
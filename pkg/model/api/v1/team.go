@@ -0,0 +1,130 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+
+	modelAPI "github.com/perses/perses/pkg/model/api"
+	"github.com/perses/perses/pkg/model/api/v1/common"
+)
+
+type TeamMember struct {
+	// Username is the name used by the user to log in.
+	Username string `json:"username" yaml:"username"`
+	Email    string `json:"email,omitempty" yaml:"email,omitempty"`
+}
+
+// TeamContact describes how to reach the team.
+type TeamContact struct {
+	Email string `json:"email,omitempty" yaml:"email,omitempty"`
+	// Chat is the channel of the team in the chat application. For example: #team-observability
+	Chat string `json:"chat,omitempty" yaml:"chat,omitempty"`
+	// URL is a link to the page of the team, its runbooks or its on-call schedule.
+	URL string `json:"url,omitempty" yaml:"url,omitempty"`
+}
+
+type TeamSpec struct {
+	Display *common.Display `json:"display,omitempty" yaml:"display,omitempty"`
+	Members []TeamMember    `json:"members,omitempty" yaml:"members,omitempty"`
+	// ExternalGroups is the list of the groups provided by the authentication (for example the LDAP groups)
+	// whose members are also members of the team.
+	ExternalGroups []string     `json:"external_groups,omitempty" yaml:"external_groups,omitempty"`
+	Contact        *TeamContact `json:"contact,omitempty" yaml:"contact,omitempty"`
+}
+
+// IsMember returns true when the user is a member of the team, directly or through one of its groups.
+func (t *TeamSpec) IsMember(username string, groups []string) bool {
+	for _, member := range t.Members {
+		if member.Username == username {
+			return true
+		}
+	}
+	for _, externalGroup := range t.ExternalGroups {
+		for _, group := range groups {
+			if externalGroup == group {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Team is a group of users owning resources. The resources reference their owning teams in metadata.owners.
+type Team struct {
+	Kind     Kind     `json:"kind" yaml:"kind"`
+	Metadata Metadata `json:"metadata" yaml:"metadata"`
+	Spec     TeamSpec `json:"spec" yaml:"spec"`
+}
+
+func (t *Team) GetMetadata() modelAPI.Metadata {
+	return &t.Metadata
+}
+
+func (t *Team) GetKind() string {
+	return string(t.Kind)
+}
+
+func (t *Team) GetSpec() interface{} {
+	return t.Spec
+}
+
+func (t *Team) UnmarshalJSON(data []byte) error {
+	var tmp Team
+	type plain Team
+	if err := json.Unmarshal(data, (*plain)(&tmp)); err != nil {
+		return err
+	}
+	if err := (&tmp).validate(); err != nil {
+		return err
+	}
+	*t = tmp
+	return nil
+}
+
+func (t *Team) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var tmp Team
+	type plain Team
+	if err := unmarshal((*plain)(&tmp)); err != nil {
+		return err
+	}
+	if err := (&tmp).validate(); err != nil {
+		return err
+	}
+	*t = tmp
+	return nil
+}
+
+func (t *Team) validate() error {
+	if t.Kind != KindTeam {
+		return fmt.Errorf("invalid kind: %q for a Team type", t.Kind)
+	}
+	usernames := make(map[string]bool, len(t.Spec.Members))
+	for _, member := range t.Spec.Members {
+		if len(member.Username) == 0 {
+			return fmt.Errorf("username of a member cannot be empty")
+		}
+		if usernames[member.Username] {
+			return fmt.Errorf("%q is declared several times as a member of the team", member.Username)
+		}
+		usernames[member.Username] = true
+	}
+	for _, group := range t.Spec.ExternalGroups {
+		if len(group) == 0 {
+			return fmt.Errorf("an external group cannot be empty")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnmarshalTeam(t *testing.T) {
+	jason := `
+{
+  "kind": "Team",
+  "metadata": {
+    "name": "observability",
+    "owners": ["sre"]
+  },
+  "spec": {
+    "members": [
+      {
+        "username": "alice",
+        "email": "alice@perses.dev"
+      }
+    ],
+    "external_groups": ["cn=observability,ou=groups,dc=perses,dc=dev"],
+    "contact": {
+      "chat": "#observability"
+    }
+  }
+}
+`
+	expected := &Team{
+		Kind: KindTeam,
+		Metadata: Metadata{
+			Name:   "observability",
+			Owners: []string{"sre"},
+		},
+		Spec: TeamSpec{
+			Members: []TeamMember{
+				{
+					Username: "alice",
+					Email:    "alice@perses.dev",
+				},
+			},
+			ExternalGroups: []string{"cn=observability,ou=groups,dc=perses,dc=dev"},
+			Contact: &TeamContact{
+				Chat: "#observability",
+			},
+		},
+	}
+	result := &Team{}
+	assert.NoError(t, json.Unmarshal([]byte(jason), result))
+	assert.Equal(t, expected, result)
+	assert.True(t, result.Metadata.IsOwnedBy("sre"))
+	assert.False(t, result.Metadata.IsOwnedBy("observability"))
+}
+
+func TestUnmarshalTeamError(t *testing.T) {
+	testSuite := []struct {
+		title string
+		jason string
+		err   error
+	}{
+		{
+			title: "wrong kind",
+			jason: `{"kind": "Project", "metadata": {"name": "observability"}, "spec": {}}`,
+			err:   fmt.Errorf("invalid kind: \"Project\" for a Team type"),
+		},
+		{
+			title: "member without username",
+			jason: `{"kind": "Team", "metadata": {"name": "observability"}, "spec": {"members": [{"email": "alice@perses.dev"}]}}`,
+			err:   fmt.Errorf("username of a member cannot be empty"),
+		},
+		{
+			title: "member declared twice",
+			jason: `{"kind": "Team", "metadata": {"name": "observability"}, "spec": {"members": [{"username": "alice"}, {"username": "alice"}]}}`,
+			err:   fmt.Errorf("\"alice\" is declared several times as a member of the team"),
+		},
+	}
+	for _, test := range testSuite {
+		t.Run(test.title, func(t *testing.T) {
+			result := &Team{}
+			assert.Equal(t, test.err, json.Unmarshal([]byte(test.jason), result))
+		})
+	}
+}
+
+func TestTeamSpec_IsMember(t *testing.T) {
+	spec := TeamSpec{
+		Members:        []TeamMember{{Username: "alice"}},
+		ExternalGroups: []string{"sre"},
+	}
+	assert.True(t, spec.IsMember("alice", nil))
+	assert.True(t, spec.IsMember("bob", []string{"dev", "sre"}))
+	assert.False(t, spec.IsMember("bob", []string{"dev"}))
+}
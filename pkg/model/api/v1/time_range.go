@@ -0,0 +1,45 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/prometheus/common/model"
+)
+
+// TimeRange is the time range used to display a dashboard. Either Duration, or Start and End, are set.
+type TimeRange struct {
+	Start *time.Time `json:"start,omitempty" yaml:"start,omitempty"`
+	End   *time.Time `json:"end,omitempty" yaml:"end,omitempty"`
+	// Duration is used to look in the past from now, like DashboardSpec.Duration.
+	Duration model.Duration `json:"duration,omitempty" yaml:"duration,omitempty"`
+}
+
+func (t *TimeRange) validate() error {
+	if t.Duration > 0 {
+		if t.Start != nil || t.End != nil {
+			return fmt.Errorf("time_range.duration cannot be used with time_range.start or time_range.end")
+		}
+		return nil
+	}
+	if t.Start == nil || t.End == nil {
+		return fmt.Errorf("time_range.duration or both time_range.start and time_range.end must be set")
+	}
+	if !t.Start.Before(*t.End) {
+		return fmt.Errorf("time_range.start must be before time_range.end")
+	}
+	return nil
+}
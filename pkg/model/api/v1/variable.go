@@ -104,3 +104,22 @@ func (v *Variable) GetKind() string {
 func (v *Variable) GetSpec() interface{} {
 	return v.Spec
 }
+
+// OrganizationVariable is a variable that can be used by every project of an organization.
+type OrganizationVariable struct {
+	Kind     Kind                 `json:"kind" yaml:"kind"`
+	Metadata OrganizationMetadata `json:"metadata" yaml:"metadata"`
+	Spec     VariableSpec         `json:"spec" yaml:"spec"`
+}
+
+func (v *OrganizationVariable) GetMetadata() modelAPI.Metadata {
+	return &v.Metadata
+}
+
+func (v *OrganizationVariable) GetKind() string {
+	return string(v.Kind)
+}
+
+func (v *OrganizationVariable) GetSpec() interface{} {
+	return v.Spec
+}
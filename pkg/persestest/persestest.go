@@ -0,0 +1,242 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package persestest starts a Perses server in the current process, to test the tools built on top of the API.
+//
+//	func TestMyTool(t *testing.T) {
+//		srv := persestest.New(t, persestest.WithFixtures("testdata/dashboards.yaml"))
+//		dashboards, err := srv.Client.V1().Dashboard("perses").List("")
+//		...
+//	}
+//
+// The server listens on a random port of the loopback interface. By default, the resources are kept in memory and
+// the plugins are validated with the schemas provided with Perses.
+package persestest
+
+import (
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/perses/perses/internal/api/config"
+	databaseFile "github.com/perses/perses/internal/api/shared/database/file"
+	"github.com/perses/perses/internal/cli/file"
+	"github.com/perses/perses/internal/cli/resource"
+	"github.com/perses/perses/internal/cli/service"
+	"github.com/perses/perses/pkg/client/api"
+	"github.com/perses/perses/pkg/client/perseshttp"
+	modelAPI "github.com/perses/perses/pkg/model/api"
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/perses/perses/pkg/server"
+	"github.com/perses/perses/schemas"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type options struct {
+	databaseFolder string
+	fixtures       []string
+	entities       []modelAPI.Entity
+	configure      []func(conf *server.Config)
+	serverOptions  []server.Option
+	headers        map[string]string
+}
+
+// Option customizes the test server.
+type Option func(o *options)
+
+// WithFileDatabase stores the resources in the folder instead of the memory.
+func WithFileDatabase(folder string) Option {
+	return func(o *options) {
+		o.databaseFolder = folder
+	}
+}
+
+// WithFixtures loads the resources described in the files once the server is started. A file contains one resource
+// or a list of resources, in YAML or in JSON, like the files accepted by `percli apply`.
+func WithFixtures(files ...string) Option {
+	return func(o *options) {
+		o.fixtures = append(o.fixtures, files...)
+	}
+}
+
+// WithEntities loads the resources once the server is started, after the fixtures.
+func WithEntities(entities ...modelAPI.Entity) Option {
+	return func(o *options) {
+		o.entities = append(o.entities, entities...)
+	}
+}
+
+// WithConfig modifies the configuration of the server. The database and the schemas are already set when it is called.
+func WithConfig(configure func(conf *server.Config)) Option {
+	return func(o *options) {
+		o.configure = append(o.configure, configure)
+	}
+}
+
+// WithServerOptions passes options to the server, for example an authenticator or additional endpoints.
+func WithServerOptions(opts ...server.Option) Option {
+	return func(o *options) {
+		o.serverOptions = append(o.serverOptions, opts...)
+	}
+}
+
+// WithClientHeaders sets headers on every request sent by the client, for example to be identified by an authenticator.
+func WithClientHeaders(headers map[string]string) Option {
+	return func(o *options) {
+		o.headers = headers
+	}
+}
+
+// Server is a Perses server running in the current process.
+type Server struct {
+	// URL is the address of the server, for example http://127.0.0.1:40123.
+	URL string
+	// Client is connected to the server.
+	Client     api.ClientInterface
+	server     *server.Server
+	httpServer *httptest.Server
+	tmpFolder  string
+}
+
+// New starts a server that is stopped at the end of the test. The test fails when the server cannot be started or
+// when a fixture cannot be loaded.
+func New(t testing.TB, opts ...Option) *Server {
+	t.Helper()
+	s, err := Start(opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(s.Close)
+	return s
+}
+
+// Start starts a server outside of a test, for example in TestMain. It must be stopped with Close.
+func Start(opts ...Option) (*Server, error) {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	tmpFolder, err := os.MkdirTemp("", "perses-test-")
+	if err != nil {
+		return nil, err
+	}
+	s := &Server{tmpFolder: tmpFolder}
+	if startErr := s.start(o); startErr != nil {
+		s.Close()
+		return nil, startErr
+	}
+	return s, nil
+}
+
+func (s *Server) start(o *options) error {
+	if err := schemas.Extract(s.tmpFolder); err != nil {
+		return fmt.Errorf("unable to extract the schemas: %w", err)
+	}
+	schemasFolder := filepath.Join(s.tmpFolder, "schemas")
+	conf := server.Config{
+		Schemas: config.Schemas{
+			PanelsPath:          filepath.Join(schemasFolder, "panels"),
+			QueriesPath:         filepath.Join(schemasFolder, "queries"),
+			DatasourcesPath:     filepath.Join(schemasFolder, "datasources"),
+			VariablesPath:       filepath.Join(schemasFolder, "variables"),
+			TransformationsPath: filepath.Join(schemasFolder, "transformations"),
+		},
+	}
+	serverOptions := []server.Option{server.WithPrometheusRegisterer(prometheus.NewRegistry())}
+	if len(o.databaseFolder) > 0 {
+		conf.Database.File = &config.File{Folder: o.databaseFolder, Extension: config.JSONExtension}
+	} else {
+		serverOptions = append(serverOptions, server.WithDAO(databaseFile.NewInMemoryDAO()))
+	}
+	for _, configure := range o.configure {
+		configure(&conf)
+	}
+	srv, err := server.New(conf, append(serverOptions, o.serverOptions...)...)
+	if err != nil {
+		return err
+	}
+	s.server = srv
+	handler, err := srv.Handler()
+	if err != nil {
+		return err
+	}
+	s.httpServer = httptest.NewServer(handler)
+	s.URL = s.httpServer.URL
+	restClient, err := perseshttp.NewFromConfig(perseshttp.RestConfigClient{URL: s.URL, Headers: o.headers})
+	if err != nil {
+		return err
+	}
+	s.Client = api.NewWithClient(restClient)
+	if loadErr := s.LoadFixtures(o.fixtures...); loadErr != nil {
+		return loadErr
+	}
+	return s.Apply(o.entities...)
+}
+
+// LoadFixtures creates or updates the resources described in the files.
+func (s *Server) LoadFixtures(files ...string) error {
+	for _, f := range files {
+		entities, err := file.UnmarshalEntity(f)
+		if err != nil {
+			return fmt.Errorf("unable to read the fixture %q: %w", f, err)
+		}
+		if applyErr := s.Apply(entities...); applyErr != nil {
+			return fmt.Errorf("unable to load the fixture %q: %w", f, applyErr)
+		}
+	}
+	return nil
+}
+
+// Apply creates or updates the resources through the API, in order. The project of a resource must be created before it.
+func (s *Server) Apply(entities ...modelAPI.Entity) error {
+	for _, entity := range entities {
+		kind := modelV1.Kind(entity.GetKind())
+		name := entity.GetMetadata().GetName()
+		svc, err := service.New(kind, resource.GetProject(entity.GetMetadata(), ""), s.Client)
+		if err != nil {
+			return err
+		}
+		_, getErr := svc.GetResource(name)
+		switch {
+		case errors.Is(getErr, perseshttp.RequestNotFoundError):
+			_, err = svc.CreateResource(entity)
+		case getErr != nil:
+			err = getErr
+		default:
+			_, err = svc.UpdateResource(entity)
+		}
+		if err != nil {
+			return fmt.Errorf("unable to apply the %s %q: %w", kind, name, err)
+		}
+	}
+	return nil
+}
+
+// DAO returns the database of the server, to check or to modify the resources without going through the API.
+func (s *Server) DAO() server.DAO {
+	return s.server.DAO()
+}
+
+// Close stops the server and removes the temporary files.
+func (s *Server) Close() {
+	if s.httpServer != nil {
+		s.httpServer.Close()
+	}
+	if s.server != nil {
+		_ = s.server.Close()
+	}
+	_ = os.RemoveAll(s.tmpFolder)
+}
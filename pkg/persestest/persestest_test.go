@@ -0,0 +1,61 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package persestest
+
+import (
+	"testing"
+
+	modelV1 "github.com/perses/perses/pkg/model/api/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer(t *testing.T) {
+	srv := New(t,
+		WithFixtures("testdata/fixtures.yaml"),
+		WithEntities(&modelV1.Project{
+			Kind:     modelV1.KindProject,
+			Metadata: *modelV1.NewOrganizationMetadata(modelV1.DefaultOrganization, "empty"),
+		}),
+	)
+
+	projects, err := srv.Client.V1().Project().List("")
+	assert.NoError(t, err)
+	assert.Len(t, projects, 2)
+
+	dashboard, err := srv.Client.V1().Dashboard("perses").Get("readme")
+	assert.NoError(t, err)
+	assert.Contains(t, dashboard.Spec.Panels, "text")
+
+	// the fixtures are applied, so they can be loaded again
+	assert.NoError(t, srv.LoadFixtures("testdata/fixtures.yaml"))
+
+	// the plugins are validated with the schemas provided with Perses
+	datasource, err := srv.Client.V1().Datasource("perses").Get("prometheus")
+	assert.NoError(t, err)
+	datasource.Spec.Plugin.Kind = "UnknownDatasource"
+	_, err = srv.Client.V1().Datasource("perses").Update(datasource)
+	assert.Error(t, err)
+}
+
+func TestServerWithFileDatabase(t *testing.T) {
+	folder := t.TempDir()
+	srv := New(t, WithFileDatabase(folder), WithFixtures("testdata/fixtures.yaml"))
+	srv.Close()
+
+	// the resources are still there when a server is restarted on the same folder
+	srv = New(t, WithFileDatabase(folder))
+	dashboard, err := srv.Client.V1().Dashboard("perses").Get("readme")
+	assert.NoError(t, err)
+	assert.Equal(t, "readme", dashboard.Metadata.Name)
+}
@@ -0,0 +1,214 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package server allows to embed Perses in another Go application. The server can be extended with additional
+// endpoints and middlewares, and its database, its CUE schemas and its authentication can be replaced.
+//
+//	conf, err := server.ResolveConfig("config.yaml")
+//	if err != nil {
+//		return err
+//	}
+//	srv, err := server.New(conf,
+//		server.WithAPI(myAPI),
+//		server.WithMiddleware(myMiddleware),
+//		server.WithAuthenticator(myAuthenticator),
+//	)
+//	if err != nil {
+//		return err
+//	}
+//	srv.Start()
+package server
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/perses/common/app"
+	echoUtils "github.com/perses/common/echo"
+	"github.com/perses/perses/internal/api/config"
+	"github.com/perses/perses/internal/api/core"
+	"github.com/perses/perses/internal/api/shared"
+	"github.com/perses/perses/internal/api/shared/database"
+	databaseModel "github.com/perses/perses/internal/api/shared/database/model"
+	"github.com/perses/perses/internal/api/shared/dependency"
+	"github.com/perses/perses/internal/api/shared/schemas"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+)
+
+// Config is the configuration of Perses, as read from its configuration file.
+type Config = config.Config
+
+// DAO is the database of Perses. It must return a *DatabaseError with the code ErrorCodeNotFound when a resource
+// doesn't exist, and with the code ErrorCodeConflict when a resource already exists.
+type DAO = databaseModel.DAO
+
+// DatabaseQuery is the filter passed to DAO.Query and DAO.DeleteByQuery.
+type DatabaseQuery = databaseModel.Query
+
+// DatabaseError is the error returned by the DAO.
+type DatabaseError = databaseModel.Error
+
+const (
+	ErrorCodeNotFound = databaseModel.ErrorCodeNotFound
+	ErrorCodeConflict = databaseModel.ErrorCodeConflict
+)
+
+// Schemas validates the plugins used by the resources.
+type Schemas = schemas.Schemas
+
+// SchemaLoader is a set of schemas reloaded periodically and each time a file changes in its folder.
+type SchemaLoader = schemas.Loader
+
+// API is a set of endpoints registered in the HTTP server.
+type API = echoUtils.Register
+
+// Identity is the user sending the request.
+type Identity = shared.Identity
+
+// Authenticator identifies the user sending the request. It returns a nil identity when it cannot identify the
+// user, so the next authentication methods are tried. An error rejects the request.
+type Authenticator func(c echo.Context) (*Identity, error)
+
+// Option customizes the server.
+type Option func(s *Server)
+
+// WithBanner sets the banner printed when the server starts.
+func WithBanner(banner string) Option {
+	return func(s *Server) {
+		s.banner = banner
+	}
+}
+
+// WithDAO replaces the database configured. Use NewDAO to decorate the database configured.
+func WithDAO(dao DAO) Option {
+	return func(s *Server) {
+		s.options.DAO = dao
+	}
+}
+
+// WithSchemas replaces the CUE schemas loaded from the folders configured. Use NewSchemas to load the schemas from
+// other folders.
+func WithSchemas(sch Schemas) Option {
+	return func(s *Server) {
+		s.options.Schemas = sch
+	}
+}
+
+// WithAPI registers additional endpoints. They are protected by the authentication like the API of Perses when they
+// are under /api.
+func WithAPI(apis ...API) Option {
+	return func(s *Server) {
+		s.options.APIs = append(s.options.APIs, apis...)
+	}
+}
+
+// WithMiddleware adds middlewares executed on every request after the authentication, so the identity of the user is
+// available with GetIdentity.
+func WithMiddleware(middlewares ...echo.MiddlewareFunc) Option {
+	return func(s *Server) {
+		s.options.Middlewares = append(s.options.Middlewares, middlewares...)
+	}
+}
+
+// WithAuthenticator adds a way to identify the users. The authenticators are tried in order, before the authentication
+// methods configured. Once an authenticator is set, the requests without identity are rejected.
+func WithAuthenticator(authenticators ...Authenticator) Option {
+	return func(s *Server) {
+		for _, authenticate := range authenticators {
+			s.options.Authenticators = append(s.options.Authenticators, authenticate)
+		}
+	}
+}
+
+// WithPrometheusRegisterer sets the registry of the metrics of the HTTP server. Default is the global registry of
+// the Prometheus client.
+func WithPrometheusRegisterer(registerer prometheus.Registerer) Option {
+	return func(s *Server) {
+		s.registerer = registerer
+	}
+}
+
+// ResolveConfig reads the configuration from the file, when set, and from the environment variables.
+func ResolveConfig(configFile string) (Config, error) {
+	return config.Resolve(configFile)
+}
+
+// NewDAO returns the database described in the configuration.
+func NewDAO(conf Config) (DAO, error) {
+	return database.New(conf.Database)
+}
+
+// NewSchemas loads the CUE schemas from the folders described in the configuration.
+func NewSchemas(conf Config) (Schemas, error) {
+	return schemas.New(conf.Schemas)
+}
+
+// GetIdentity returns the user sending the request, or nil when the authentication is disabled.
+func GetIdentity(c echo.Context) *Identity {
+	return shared.GetIdentity(c)
+}
+
+// Server is an instance of Perses.
+type Server struct {
+	banner             string
+	registerer         prometheus.Registerer
+	options            core.Options
+	runner             *app.Runner
+	persistenceManager dependency.PersistenceManager
+}
+
+// New builds the server. The database is initialized, but nothing is served before Start is called.
+func New(conf Config, opts ...Option) (*Server, error) {
+	s := &Server{}
+	for _, opt := range opts {
+		opt(s)
+	}
+	runner, persistenceManager, err := core.NewWithOptions(conf, s.banner, s.options)
+	if err != nil {
+		return nil, err
+	}
+	if s.registerer != nil {
+		runner.HTTPServerBuilder().PrometheusRegisterer(s.registerer)
+	}
+	s.runner = runner
+	s.persistenceManager = persistenceManager
+	return s, nil
+}
+
+// Start serves the API and the frontend, and runs the periodic tasks. It blocks until the process receives a
+// termination signal, then closes the connection to the database.
+func (s *Server) Start() {
+	defer func() {
+		if err := s.Close(); err != nil {
+			logrus.WithError(err).Error("unable to close the connection to the database")
+		}
+	}()
+	s.runner.Start()
+}
+
+// Handler returns the HTTP handler serving the API and the frontend, so the server can be mounted in an existing
+// HTTP server. The periodic tasks are not run in this case. It must be called only once, and not with Start.
+func (s *Server) Handler() (http.Handler, error) {
+	return s.runner.HTTPServerBuilder().BuildHandler()
+}
+
+// DAO returns the database used by the server.
+func (s *Server) DAO() DAO {
+	return s.persistenceManager.GetPersesDAO()
+}
+
+// Close closes the connection to the database.
+func (s *Server) Close() error {
+	return s.persistenceManager.GetPersesDAO().Close()
+}
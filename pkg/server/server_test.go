@@ -0,0 +1,140 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/perses/perses/internal/api/config"
+	"github.com/perses/perses/internal/test"
+	modelAPI "github.com/perses/perses/pkg/model/api"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+)
+
+type whoamiAPI struct{}
+
+func (a *whoamiAPI) RegisterRoute(e *echo.Echo) {
+	e.GET("/api/whoami", func(c echo.Context) error {
+		return c.String(http.StatusOK, GetIdentity(c).Username)
+	})
+}
+
+// countingDAO decorates the database to count the resources created.
+type countingDAO struct {
+	DAO
+	created int32
+}
+
+func (d *countingDAO) Create(entity modelAPI.Entity) error {
+	atomic.AddInt32(&d.created, 1)
+	return d.DAO.Create(entity)
+}
+
+func newConfig(t *testing.T) Config {
+	projectPath := test.GetRepositoryPath()
+	return Config{
+		Database: config.Database{
+			File: &config.File{Folder: t.TempDir(), Extension: config.JSONExtension},
+		},
+		Schemas: config.Schemas{
+			PanelsPath:          filepath.Join(projectPath, config.DefaultPanelsPath),
+			QueriesPath:         filepath.Join(projectPath, config.DefaultQueriesPath),
+			DatasourcesPath:     filepath.Join(projectPath, config.DefaultDatasourcesPath),
+			VariablesPath:       filepath.Join(projectPath, config.DefaultVariablesPath),
+			TransformationsPath: filepath.Join(projectPath, config.DefaultTransformationsPath),
+		},
+	}
+}
+
+func TestServer(t *testing.T) {
+	conf := newConfig(t)
+	dao, err := NewDAO(conf)
+	assert.NoError(t, err)
+	counting := &countingDAO{DAO: dao}
+	sch, err := NewSchemas(conf)
+	assert.NoError(t, err)
+
+	srv, err := New(conf,
+		WithDAO(counting),
+		WithSchemas(sch),
+		WithPrometheusRegisterer(prometheus.NewRegistry()),
+		WithAPI(&whoamiAPI{}),
+		WithAuthenticator(func(c echo.Context) (*Identity, error) {
+			if c.Request().Header.Get("X-Api-Key") == "secret" {
+				return &Identity{Username: "robot"}, nil
+			}
+			return nil, nil
+		}),
+		WithMiddleware(func(next echo.HandlerFunc) echo.HandlerFunc {
+			return func(c echo.Context) error {
+				c.Response().Header().Set("X-Platform", "embedded")
+				return next(c)
+			}
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Close()
+	assert.Equal(t, DAO(counting), srv.DAO())
+	handler, err := srv.Handler()
+	if err != nil {
+		t.Fatal(err)
+	}
+	httpServer := httptest.NewServer(handler)
+	defer httpServer.Close()
+
+	do := func(method string, path string, body string, apiKey string) *http.Response {
+		req, reqErr := http.NewRequest(method, httpServer.URL+path, strings.NewReader(body))
+		assert.NoError(t, reqErr)
+		req.Header.Set("Content-Type", "application/json")
+		if len(apiKey) > 0 {
+			req.Header.Set("X-Api-Key", apiKey)
+		}
+		resp, doErr := http.DefaultClient.Do(req)
+		if doErr != nil {
+			t.Fatal(doErr)
+		}
+		return resp
+	}
+
+	// the authenticator is required
+	resp := do(http.MethodGet, "/api/whoami", "", "")
+	resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	// the additional endpoint knows the identity and goes through the additional middleware
+	resp = do(http.MethodGet, "/api/whoami", "", "secret")
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "robot", string(body))
+	assert.Equal(t, "embedded", resp.Header.Get("X-Platform"))
+
+	// the API of Perses uses the database injected
+	before := atomic.LoadInt32(&counting.created)
+	resp = do(http.MethodPost, "/api/v1/projects", `{"kind":"Project","metadata":{"name":"embedded"}}`, "secret")
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, before+1, atomic.LoadInt32(&counting.created))
+}
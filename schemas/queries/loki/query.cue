@@ -0,0 +1,30 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loki
+
+import (
+	"github.com/perses/perses/schemas/datasources/loki"
+)
+
+kind: "LokiLogQuery"
+spec: close({
+	datasource?: {
+		kind: loki.kind
+	}
+	// query is a LogQL query returning log lines, like `{app="foo"} |= "error"`
+	query: string
+	// limit is the maximum number of log lines returned
+	limit?:     int & >0
+	direction?: "backward" | "forward"
+})
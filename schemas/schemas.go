@@ -0,0 +1,59 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package schemas embeds the CUE schemas of the plugins provided with Perses, so they are available to the Go
+// programs built outside of this repository.
+package schemas
+
+import (
+	"embed"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// FS contains the folders common, datasources, panels, queries, transformations and variables.
+//
+//go:embed common datasources panels queries transformations variables
+var FS embed.FS
+
+// cueModule is the CUE module the schemas are part of. The schemas import each other through it.
+const cueModule = `module: "github.com/perses/perses"
+`
+
+// Extract writes the schemas in the folder, in the layout of this repository: the CUE module in <folder>/cue.mod and
+// the schemas in <folder>/schemas. The paths of the schemas to configure are then <folder>/schemas/panels,
+// <folder>/schemas/queries, <folder>/schemas/datasources, <folder>/schemas/variables and
+// <folder>/schemas/transformations.
+func Extract(folder string) error {
+	if err := os.MkdirAll(filepath.Join(folder, "cue.mod"), 0700); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(folder, "cue.mod", "module.cue"), []byte(cueModule), 0600); err != nil {
+		return err
+	}
+	return fs.WalkDir(FS, ".", func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(folder, "schemas", filepath.FromSlash(path))
+		if entry.IsDir() {
+			return os.MkdirAll(target, 0700)
+		}
+		data, readErr := FS.ReadFile(path)
+		if readErr != nil {
+			return readErr
+		}
+		return os.WriteFile(target, data, 0600)
+	})
+}
@@ -61,6 +61,9 @@ func validateAllSchemas(sch schemas.Schemas) {
 	validateSchemas(config.DefaultVariablesPath, func(plugin common.Plugin, name string) error {
 		return sch.ValidateVariable(plugin, name)
 	})
+	validateSchemas(config.DefaultTransformationsPath, func(plugin common.Plugin, name string) error {
+		return sch.ValidateTransformation(plugin, name)
+	})
 }
 
 func validateAllDashboards(sch schemas.Schemas) {
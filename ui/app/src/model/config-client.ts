@@ -12,10 +12,7 @@
 // limitations under the License.
 
 import { useQuery, UseQueryOptions } from '@tanstack/react-query';
-import { DashboardSelector, fetchJson } from '@perses-dev/core';
-import { useMemo } from 'react';
-import { marked } from 'marked';
-import * as DOMPurify from 'dompurify';
+import { fetchJson } from '@perses-dev/core';
 import { useSnackbar } from '@perses-dev/components';
 import buildURL from './url-builder';
 
@@ -26,14 +23,13 @@ export interface ConfigSchemasModel {
   queries_path: string;
   datasources_path: string;
   variables_path: string;
+  transformations_path: string;
   interval: string;
 }
 
 export interface ConfigModel {
   readonly: boolean;
   schemas: ConfigSchemasModel;
-  important_dashboards: DashboardSelector[];
-  information: string;
 }
 
 type ConfigOptions = Omit<UseQueryOptions<ConfigModel, Error>, 'queryKey' | 'queryFn'>;
@@ -57,19 +53,3 @@ export function useIsReadonly() {
   }
   return data.readonly;
 }
-
-export function useImportantDashboardSelectors() {
-  const { exceptionSnackbar } = useSnackbar();
-  const { data, isLoading } = useConfig({ onError: exceptionSnackbar });
-  return { data: data?.important_dashboards || [], isLoading: isLoading };
-}
-
-export function useInformation() {
-  const { exceptionSnackbar } = useSnackbar();
-  const { data, isLoading } = useConfig({ onError: exceptionSnackbar });
-
-  const html = useMemo(() => marked.parse(data?.information || '', { gfm: true }), [data?.information]);
-  const sanitizedHTML = useMemo(() => DOMPurify.sanitize(html), [html]);
-
-  return { data: sanitizedHTML, isLoading: isLoading };
-}
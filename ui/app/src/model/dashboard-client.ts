@@ -17,7 +17,7 @@ import { useMemo } from 'react';
 import { useNavHistory } from '../context/DashboardNavHistory';
 import { HTTPHeader, HTTPMethodDELETE, HTTPMethodGET, HTTPMethodPOST, HTTPMethodPUT } from './http';
 import buildURL from './url-builder';
-import { useImportantDashboardSelectors } from './config-client';
+import { useImportantDashboardSelectors } from './home-client';
 
 export const resource = 'dashboards';
 
@@ -17,6 +17,11 @@ import LRUCache from 'lru-cache';
 import { fetchDatasourceList, fetchGlobalDatasourceList } from './datasource-client';
 
 export class HTTPDatasourceAPI implements DatasourceApi {
+  /**
+   * @param project when set, the global datasources are resolved with the overrides of this project.
+   */
+  constructor(private readonly project?: string) {}
+
   getDatasource(
     project: string,
     selector: DatasourceSelector
@@ -35,12 +40,17 @@ export class HTTPDatasourceAPI implements DatasourceApi {
   getGlobalDatasource(
     selector: DatasourceSelector
   ): Promise<{ resource: GlobalDatasource; proxyUrl: string } | undefined> {
-    return fetchGlobalDatasourceList(selector.kind, selector.name ? undefined : true, selector.name).then((list) => {
+    return fetchGlobalDatasourceList(
+      selector.kind,
+      selector.name ? undefined : true,
+      selector.name,
+      this.project
+    ).then((list) => {
       // hopefully it should return at most one element
       if (list[0] !== undefined) {
         return {
           resource: list[0],
-          proxyUrl: getProxyUrl(list[0]),
+          proxyUrl: getProxyUrl(list[0], this.project),
         };
       }
     });
@@ -51,7 +61,7 @@ export class HTTPDatasourceAPI implements DatasourceApi {
   }
 
   listGlobalDatasources(pluginKind?: string): Promise<GlobalDatasource[]> {
-    return fetchGlobalDatasourceList(pluginKind);
+    return fetchGlobalDatasourceList(pluginKind, undefined, undefined, this.project);
   }
 }
 
@@ -146,10 +156,16 @@ class Cache {
 export class CachedDatasourceAPI implements DatasourceApi {
   private readonly client: DatasourceApi;
   private readonly cache: Cache;
+  private readonly project?: string;
 
-  constructor(client: DatasourceApi) {
+  /**
+   * @param project must be the same project as the one given to the client, so that the global datasources served
+   * from the cache are proxied with the overrides of this project.
+   */
+  constructor(client: DatasourceApi, project?: string) {
     this.client = client;
     this.cache = new Cache();
+    this.project = project;
   }
 
   getDatasource(
@@ -183,7 +199,7 @@ export class CachedDatasourceAPI implements DatasourceApi {
   ): Promise<{ resource: GlobalDatasource; proxyUrl: string } | undefined> {
     const { resource, keyExist } = this.cache.getGlobalDatasource(selector);
     if (resource) {
-      return Promise.resolve({ resource: resource, proxyUrl: getProxyUrl(resource) });
+      return Promise.resolve({ resource: resource, proxyUrl: getProxyUrl(resource, this.project) });
     }
     if (keyExist) {
       return Promise.resolve(undefined);
@@ -213,11 +229,14 @@ export class CachedDatasourceAPI implements DatasourceApi {
   }
 }
 
-// Helper function for getting a proxy URL from a datasource or global datasource
-function getProxyUrl(datasource: Datasource | GlobalDatasource) {
+// Helper function for getting a proxy URL from a datasource or global datasource.
+// When a project is given, a global datasource is proxied with the overrides of this project.
+function getProxyUrl(datasource: Datasource | GlobalDatasource, project?: string) {
   let url = `/proxy`;
   if (datasource.kind === 'Datasource') {
     url += `/projects/${encodeURIComponent(datasource.metadata.project)}`;
+  } else if (project !== undefined) {
+    url += `/projects/${encodeURIComponent(project)}`;
   }
   url += `/${datasource.kind.toLowerCase()}s/${encodeURIComponent(datasource.metadata.name)}`;
   return url;
@@ -40,9 +40,13 @@ export function fetchDatasourceList(project: string, kind?: string, defaultDatas
   return fetchJson<Datasource[]>(url);
 }
 
-export function fetchGlobalDatasourceList(kind?: string, defaultDatasource?: boolean, name?: string) {
+/**
+ * When a project is given, the global datasources are returned with the overrides of this project applied.
+ */
+export function fetchGlobalDatasourceList(kind?: string, defaultDatasource?: boolean, name?: string, project?: string) {
   const url = buildURL({
     resource: globalDatasourceResource,
+    project: project,
     queryParams: buildDatasourceQueryParameters(kind, defaultDatasource, name),
   });
   return fetchJson<GlobalDatasource[]>(url);
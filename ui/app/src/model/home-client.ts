@@ -0,0 +1,60 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+
+import { useQuery, UseQueryOptions } from '@tanstack/react-query';
+import { DashboardSelector, fetchJson } from '@perses-dev/core';
+import { useMemo } from 'react';
+import { marked } from 'marked';
+import * as DOMPurify from 'dompurify';
+import { useSnackbar } from '@perses-dev/components';
+import buildURL from './url-builder';
+
+const resource = 'home';
+
+export interface HomeModel {
+  important_dashboards?: DashboardSelector[];
+  information?: string;
+}
+
+type HomeOptions = Omit<UseQueryOptions<HomeModel, Error>, 'queryKey' | 'queryFn'>;
+
+/**
+ * Gets the configuration of the home page. It is the global home config overridden by the one of the project if provided.
+ */
+export function useHome(project?: string, options?: HomeOptions) {
+  return useQuery<HomeModel, Error>(
+    [resource, project],
+    () => {
+      const url = buildURL({ resource: resource, project: project });
+      return fetchJson<HomeModel>(url);
+    },
+    options
+  );
+}
+
+export function useImportantDashboardSelectors() {
+  const { exceptionSnackbar } = useSnackbar();
+  const { data, isLoading } = useHome(undefined, { onError: exceptionSnackbar });
+  return { data: data?.important_dashboards || [], isLoading: isLoading };
+}
+
+export function useInformation() {
+  const { exceptionSnackbar } = useSnackbar();
+  const { data, isLoading } = useHome(undefined, { onError: exceptionSnackbar });
+
+  const html = useMemo(() => marked.parse(data?.information || '', { gfm: true }), [data?.information]);
+  const sanitizedHTML = useMemo(() => DOMPurify.sanitize(html), [html]);
+
+  return { data: sanitizedHTML, isLoading: isLoading };
+}
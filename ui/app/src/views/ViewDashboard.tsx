@@ -51,7 +51,7 @@ function ViewDashboard() {
 
   const navigate = useNavigate();
   const { successSnackbar, exceptionSnackbar, warningSnackbar } = useSnackbar();
-  const [datasourceApi] = useState(() => new CachedDatasourceAPI(new HTTPDatasourceAPI()));
+  const [datasourceApi] = useState(() => new CachedDatasourceAPI(new HTTPDatasourceAPI(projectName), projectName));
   useEffect(() => {
     // warm up the caching of the datasources
     datasourceApi.listDatasources(projectName);
@@ -13,10 +13,10 @@
 
 import { Card, CardContent, CircularProgress, Stack } from '@mui/material';
 import InformationIcon from 'mdi-material-ui/Information';
-import { useInformation } from '../../model/config-client';
+import { useInformation } from '../../model/home-client';
 
 /*
- * Information section is displayed if there is information provided by the config
+ * Information section is displayed if there is information provided by the home configuration
  */
 export function InformationSection() {
   const { data, isLoading } = useInformation();
@@ -15,6 +15,7 @@ import { DatasourceSpec } from './datasource';
 import { LayoutDefinition } from './layout';
 import { PanelDefinition } from './panels';
 import { ProjectMetadata } from './resource';
+import { QueryDefinition } from './query';
 import { DurationString } from './time';
 import { VariableDefinition } from './variables';
 import { Display } from './display';
@@ -27,10 +28,13 @@ export interface DashboardResource {
 
 export interface DashboardSpec {
   display?: Display;
+  tags?: string[];
   datasources?: Record<string, DatasourceSpec>;
   duration: DurationString;
   variables: VariableDefinition[];
   layouts: LayoutDefinition[];
+  // Queries shared by several panels. Once saved, the panels refer to them with a QueryRef.
+  queries?: Record<string, QueryDefinition>;
   panels: Record<string, PanelDefinition>;
 }
 
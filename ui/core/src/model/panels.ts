@@ -13,7 +13,7 @@
 
 import { Definition, UnknownSpec } from './definitions';
 import { Display } from './display';
-import { QueryDefinition } from './query';
+import { QueryDefinition, QueryOptions } from './query';
 export interface PanelDefinition<PluginSpec = UnknownSpec> extends Definition<PanelSpec<PluginSpec>> {
   kind: 'Panel';
 }
@@ -21,7 +21,10 @@ export interface PanelDefinition<PluginSpec = UnknownSpec> extends Definition<Pa
 export interface PanelSpec<PluginSpec> {
   display: Display;
   plugin: Definition<PluginSpec>;
+  options?: QueryOptions;
   queries?: QueryDefinition[];
+  // applied in order to the results of the queries
+  transformations?: Array<Definition<UnknownSpec>>;
 }
 
 /**
@@ -12,9 +12,23 @@
 // limitations under the License.
 
 import { Definition, UnknownSpec } from './definitions';
+import { DurationString } from './time';
+
+/**
+ * Options changing the way the queries are executed. When they are set on a panel, they apply to every query of the
+ * panel. A query can override them with its own options.
+ */
+export interface QueryOptions {
+  time_shift?: DurationString;
+  relative_time?: DurationString;
+  min_step?: DurationString;
+  max_data_points?: number;
+  hide_from_legend?: boolean;
+}
 
 interface QuerySpec<PluginSpec> {
   plugin: Definition<PluginSpec>;
+  options?: QueryOptions;
 }
 /**
  * A generic query definition interface that can be extended to support more than just TimeSeriesQuery
@@ -25,3 +39,23 @@ export interface QueryDefinition<Kind = QueryType, PluginSpec = UnknownSpec> {
 }
 
 export type QueryType = 'TimeSeriesQuery';
+
+/**
+ * A reference to a query shared at the dashboard level, used in place of a QueryDefinition in the queries of a panel.
+ */
+export interface QueryRef {
+  $ref: `#/spec/queries/${string}`;
+}
+
+/**
+ * Returns the options of a query once merged with the options of its panel.
+ */
+export function mergeQueryOptions(panelOptions?: QueryOptions, queryOptions?: QueryOptions): QueryOptions | undefined {
+  if (panelOptions === undefined) {
+    return queryOptions;
+  }
+  if (queryOptions === undefined) {
+    return panelOptions;
+  }
+  return { ...panelOptions, ...queryOptions };
+}
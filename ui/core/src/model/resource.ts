@@ -16,6 +16,8 @@ export interface Metadata {
   created_at?: string;
   updated_at?: string;
   version?: number;
+  owners?: string[];
+  labels?: Record<string, string>;
 }
 
 export interface ProjectMetadata extends Metadata {
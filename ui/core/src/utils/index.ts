@@ -16,5 +16,6 @@ export * from './fetch';
 export * from './is-empty-object';
 export * from './memo';
 export * from './panel-refs';
+export * from './query-refs';
 export * from './time-series-data';
 export * from './text';
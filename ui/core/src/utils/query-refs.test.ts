@@ -0,0 +1,62 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import { PanelDefinition, QueryDefinition } from '../model';
+import { createQueryRef, getQueryKeyFromRef, resolveQueryRefs, restoreQueryRefs } from './query-refs';
+
+const requests: QueryDefinition = {
+  kind: 'TimeSeriesQuery',
+  spec: { plugin: { kind: 'PrometheusTimeSeriesQuery', spec: { query: 'sum(rate(http_requests_total[5m]))' } } },
+};
+
+const errors: QueryDefinition = {
+  kind: 'TimeSeriesQuery',
+  spec: { plugin: { kind: 'PrometheusTimeSeriesQuery', spec: { query: 'sum(rate(http_errors_total[5m]))' } } },
+};
+
+function createPanel(queries: unknown[]): PanelDefinition {
+  return {
+    kind: 'Panel',
+    spec: {
+      display: { name: 'Requests' },
+      plugin: { kind: 'TimeSeriesChart', spec: {} },
+      queries: queries as QueryDefinition[],
+    },
+  };
+}
+
+describe('query-refs', () => {
+  it('should get the key of a shared query from its reference', () => {
+    expect(getQueryKeyFromRef(createQueryRef('requests'))).toEqual('requests');
+  });
+
+  it('should resolve the references and restore them', () => {
+    const panels = { chart: createPanel([createQueryRef('requests'), errors]) };
+    const resolved = resolveQueryRefs(panels, { requests });
+    expect(resolved.chart?.spec.queries).toEqual([requests, errors]);
+    expect(restoreQueryRefs(resolved, { requests })).toEqual(panels);
+  });
+
+  it('should not restore the reference of a query edited in a panel', () => {
+    const edited = { ...requests, spec: { ...requests.spec, options: { min_step: '1m' } } };
+    const panels = { chart: createPanel([edited]) };
+    expect(restoreQueryRefs(panels, { requests })).toEqual(panels);
+  });
+
+  it('should fail to resolve an unknown shared query', () => {
+    const panels = { chart: createPanel([createQueryRef('unknown')]) };
+    expect(() => resolveQueryRefs(panels, { requests })).toThrow(
+      'Could not resolve queries reference #/spec/queries/unknown'
+    );
+  });
+});
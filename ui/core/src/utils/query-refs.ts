@@ -0,0 +1,106 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import isEqual from 'lodash/isEqual';
+import { PanelDefinition, QueryDefinition, QueryRef } from '../model';
+
+// Currently, query refs are prefixed with `#/spec/queries/`. If that format changes, we'll need to update the code in
+// here relying on it being that format.
+const REF_PREFIX = '#/spec/queries/';
+
+/**
+ * Returns true when the given query of a panel is a reference to a shared query.
+ */
+export function isQueryRef(query: QueryDefinition | QueryRef): query is QueryRef {
+  return '$ref' in query;
+}
+
+/**
+ * Gets the key of a shared query from a QueryRef.
+ */
+export function getQueryKeyFromRef(queryRef: QueryRef) {
+  return queryRef.$ref.substring(REF_PREFIX.length);
+}
+
+/**
+ * Creates a QueryRef for the shared query with the given key.
+ */
+export function createQueryRef(queryKey: string): QueryRef {
+  return { $ref: `${REF_PREFIX}${queryKey}` };
+}
+
+/**
+ * Replaces the references to the shared queries found in the panels by the queries themselves, so the rest of the
+ * dashboard doesn't have to know about them.
+ */
+export function resolveQueryRefs(
+  panels: Record<string, PanelDefinition>,
+  queries?: Record<string, QueryDefinition>
+): Record<string, PanelDefinition> {
+  if (queries === undefined) {
+    return panels;
+  }
+  const resolved: Record<string, PanelDefinition> = {};
+  for (const [panelKey, panel] of Object.entries(panels)) {
+    const panelQueries: Array<QueryDefinition | QueryRef> | undefined = panel.spec.queries;
+    if (panelQueries === undefined) {
+      resolved[panelKey] = panel;
+      continue;
+    }
+    resolved[panelKey] = {
+      ...panel,
+      spec: {
+        ...panel.spec,
+        queries: panelQueries.map((query) => {
+          if (!isQueryRef(query)) {
+            return query;
+          }
+          const shared = queries[getQueryKeyFromRef(query)];
+          if (shared === undefined) {
+            throw new Error(`Could not resolve queries reference ${query.$ref}`);
+          }
+          return shared;
+        }),
+      },
+    };
+  }
+  return resolved;
+}
+
+/**
+ * Does the opposite of resolveQueryRefs: the queries of the panels that are still equal to a shared query are replaced
+ * by a reference to it. A query that was edited in a panel is kept as is and is no longer shared.
+ */
+export function restoreQueryRefs(
+  panels: Record<string, PanelDefinition>,
+  queries?: Record<string, QueryDefinition>
+): Record<string, PanelDefinition> {
+  if (queries === undefined) {
+    return panels;
+  }
+  const sharedQueries = Object.entries(queries);
+  const restored: Record<string, PanelDefinition> = {};
+  for (const [panelKey, panel] of Object.entries(panels)) {
+    if (panel.spec.queries === undefined) {
+      restored[panelKey] = panel;
+      continue;
+    }
+    const panelQueries = panel.spec.queries.map((query) => {
+      const shared = sharedQueries.find(([, sharedQuery]) => isEqual(sharedQuery, query));
+      return shared === undefined ? query : createQueryRef(shared[0]);
+    });
+    // The references only exist in the saved dashboard, which is why they don't appear in the type of the panels.
+    restored[panelKey] = { ...panel, spec: { ...panel.spec, queries: panelQueries as QueryDefinition[] } };
+  }
+  return restored;
+}
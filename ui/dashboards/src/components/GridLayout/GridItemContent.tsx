@@ -11,7 +11,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-import { QueryDefinition } from '@perses-dev/core';
+import { QueryDefinition, mergeQueryOptions } from '@perses-dev/core';
 import { DataQueriesProvider } from '@perses-dev/plugin-system';
 import { PanelGroupItemId, useEditMode, usePanel, usePanelActions } from '../../context';
 import { useSuggestedStepMs } from '../../utils';
@@ -29,7 +29,7 @@ export function GridItemContent(props: GridItemContentProps) {
   const { panelGroupItemId, width } = props;
   const panelDefinition = usePanel(panelGroupItemId);
   const {
-    spec: { queries },
+    spec: { queries, options, transformations },
   } = panelDefinition;
   const { isEditMode } = useEditMode();
   const { openEditPanel, openDeletePanelDialog, duplicatePanel } = usePanelActions(panelGroupItemId);
@@ -53,9 +53,15 @@ export function GridItemContent(props: GridItemContentProps) {
       spec: query.spec.plugin.spec,
     };
   });
+  const queryOptions = queryDefinitions.map((query: QueryDefinition) => mergeQueryOptions(options, query.spec.options));
 
   return (
-    <DataQueriesProvider definitions={definitions} options={{ suggestedStepMs }}>
+    <DataQueriesProvider
+      definitions={definitions}
+      queryOptions={queryOptions}
+      transformations={transformations}
+      options={{ suggestedStepMs }}
+    >
       <Panel definition={panelDefinition} editHandlers={editHandlers} />
     </DataQueriesProvider>
   );
@@ -13,7 +13,7 @@
 
 import { useRef } from 'react';
 import { Box } from '@mui/material';
-import { QueryDefinition } from '@perses-dev/core';
+import { QueryDefinition, mergeQueryOptions } from '@perses-dev/core';
 import { DataQueriesProvider } from '@perses-dev/plugin-system';
 import { PanelEditorValues } from '../../context';
 import { Panel } from '../Panel';
@@ -45,10 +45,18 @@ export function PanelPreview({ panelDefinition }: Pick<PanelEditorValues, 'panel
         };
       })
     : [];
+  const queryOptions = queries.map((query: QueryDefinition) =>
+    mergeQueryOptions(panelDefinition.spec.options, query.spec.options)
+  );
 
   return (
     <Box ref={boxRef} height={PANEL_PREVIEW_HEIGHT}>
-      <DataQueriesProvider definitions={definitions} options={{ suggestedStepMs }}>
+      <DataQueriesProvider
+        definitions={definitions}
+        queryOptions={queryOptions}
+        transformations={panelDefinition.spec.transformations}
+        options={{ suggestedStepMs }}
+      >
         <Panel definition={panelDefinition} />
       </DataQueriesProvider>
     </Box>
@@ -17,7 +17,14 @@ import { devtools } from 'zustand/middleware';
 import { immer } from 'zustand/middleware/immer';
 import { shallow } from 'zustand/shallow';
 import { createContext, ReactNode, useCallback, useContext, useEffect, useState } from 'react';
-import { DashboardResource, Display, ProjectMetadata, DurationString } from '@perses-dev/core';
+import {
+  DashboardResource,
+  Display,
+  ProjectMetadata,
+  DurationString,
+  QueryDefinition,
+  resolveQueryRefs,
+} from '@perses-dev/core';
 import { usePlugin, usePluginRegistry } from '@perses-dev/plugin-system';
 import { createPanelGroupEditorSlice, PanelGroupEditorSlice } from './panel-group-editor-slice';
 import { convertLayoutsToPanelGroups, createPanelGroupSlice, PanelGroupSlice } from './panel-group-slice';
@@ -48,6 +55,7 @@ export interface DashboardStoreState
   metadata: ProjectMetadata;
   duration: DurationString;
   display?: Display;
+  queries?: Record<string, QueryDefinition>;
 }
 
 export interface DashboardStoreProps {
@@ -104,7 +112,7 @@ function initStore(props: DashboardProviderProps) {
   } = props;
 
   const {
-    spec: { display, duration },
+    spec: { display, duration, queries },
     metadata,
   } = dashboardResource;
 
@@ -115,6 +123,8 @@ function initStore(props: DashboardProviderProps) {
   // Set fallbacks in case the frontend is used with a non-Perses backend
   layouts = layouts ?? [];
   panels = panels ?? {};
+  // The panels of the store always hold their queries, the references to the shared queries are restored on save
+  panels = resolveQueryRefs(panels, queries);
 
   const store = createStore<DashboardStoreState>()(
     immer(
@@ -137,13 +147,15 @@ function initStore(props: DashboardProviderProps) {
           metadata,
           display,
           duration,
+          queries,
           isEditMode: !!isEditMode,
           setEditMode: (isEditMode: boolean) => set({ isEditMode }),
-          setDashboard: ({ metadata, spec: { display, panels = {}, layouts = [], duration } }) => {
+          setDashboard: ({ metadata, spec: { display, panels = {}, layouts = [], duration, queries } }) => {
             set((state) => {
               state.metadata = metadata;
               state.display = display;
-              state.panels = panels;
+              state.queries = queries;
+              state.panels = resolveQueryRefs(panels, queries);
               const { panelGroups, panelGroupOrder } = convertLayoutsToPanelGroups(layouts);
               state.panelGroups = panelGroups;
               state.panelGroupOrder = panelGroupOrder;
@@ -11,7 +11,7 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-import { createPanelRef, DashboardResource, GridDefinition } from '@perses-dev/core';
+import { createPanelRef, DashboardResource, GridDefinition, restoreQueryRefs } from '@perses-dev/core';
 import { PanelGroupDefinition, PanelGroupId, useDashboardStore } from './DashboardProvider';
 import { useTemplateVariableActions, useTemplateVariableDefinitions } from './TemplateVariableProvider';
 
@@ -24,15 +24,19 @@ export function useDashboard() {
     metadata,
     display,
     duration,
-  } = useDashboardStore(({ panels, panelGroups, panelGroupOrder, setDashboard, metadata, display, duration }) => ({
-    panels,
-    panelGroups,
-    panelGroupOrder,
-    setDashboard,
-    metadata,
-    display,
-    duration,
-  }));
+    queries,
+  } = useDashboardStore(
+    ({ panels, panelGroups, panelGroupOrder, setDashboard, metadata, display, duration, queries }) => ({
+      panels,
+      panelGroups,
+      panelGroupOrder,
+      setDashboard,
+      metadata,
+      display,
+      duration,
+      queries,
+    })
+  );
   const { setVariableDefinitions } = useTemplateVariableActions();
   const variables = useTemplateVariableDefinitions();
   const layouts = convertPanelGroupsToLayouts(panelGroups, panelGroupOrder);
@@ -42,7 +46,8 @@ export function useDashboard() {
     metadata,
     spec: {
       display,
-      panels,
+      queries,
+      panels: restoreQueryRefs(panels, queries),
       layouts,
       variables,
       duration,
@@ -186,7 +186,7 @@ export function TimeSeriesChartPanel(props: TimeSeriesChartProps) {
         if (showTimeSeries) {
           graphData.timeSeries.push(lineSeries);
         }
-        if (legend && graphData.legendItems) {
+        if (legend && graphData.legendItems && !result.options?.hide_from_legend) {
           graphData.legendItems.push({
             id: seriesId, // Avoids duplicate key console errors when there are duplicate series names
             label: formattedSeriesName,
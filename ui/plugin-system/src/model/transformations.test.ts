@@ -0,0 +1,59 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import { Definition, TimeSeries, UnknownSpec } from '@perses-dev/core';
+import { TransformedData, applyTransformations } from './transformations';
+// The same cases are tested against the evaluation engine of the server (internal/api/shared/transform), so both are
+// sure to produce the same output.
+import golden from './transformations.golden.json';
+
+// ServerData is the format of the data used by the server, where the results are not tagged with the index of their query.
+interface ServerData {
+  results: Array<{ series: TimeSeries[] }>;
+  table?: TransformedData['table'];
+}
+
+interface GoldenCase {
+  title: string;
+  // data replaces the default data of the file when it is set
+  data?: ServerData;
+  transformations: Array<Definition<UnknownSpec>>;
+  expected?: ServerData;
+  error?: string;
+}
+
+function fromServerData(data: ServerData): TransformedData {
+  return { results: data.results.map((result, i) => ({ query: i, series: result.series })) };
+}
+
+// toServerData drops the index of the queries and serializes the data like the server does, so an infinite value
+// becomes null.
+function toServerData(data: TransformedData): ServerData {
+  return JSON.parse(
+    JSON.stringify({ results: data.results.map((result) => ({ series: result.series })), table: data.table })
+  );
+}
+
+describe('applyTransformations', () => {
+  const defaultData = golden.data as unknown as ServerData;
+  for (const test of golden.cases as unknown as GoldenCase[]) {
+    it(test.title, () => {
+      const data = fromServerData(test.data ?? defaultData);
+      if (test.error !== undefined) {
+        expect(() => applyTransformations(test.transformations, data)).toThrow(test.error);
+        return;
+      }
+      expect(toServerData(applyTransformations(test.transformations, data))).toEqual(test.expected);
+    });
+  }
+});
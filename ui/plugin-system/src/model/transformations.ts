@@ -0,0 +1,305 @@
+// Copyright 2023 The Perses Authors
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import { Definition, Labels, TimeSeries, TimeSeriesValueTuple, UnknownSpec } from '@perses-dev/core';
+import { CalculationsMap, CalculationType } from './calculations';
+
+// The transformations must produce the same output as the evaluation engine of the server
+// (internal/api/shared/transform/transform.go). The formatted name of a series, only known by the UI, is kept unless
+// the series is computed by the transformation.
+
+export interface TransformedResult {
+  // index of the query the result is coming from
+  query: number;
+  series: TimeSeries[];
+}
+
+export interface TransformedTable {
+  columns: string[];
+  rows: Array<Array<string | number | null>>;
+}
+
+export interface TransformedData {
+  results: TransformedResult[];
+  table?: TransformedTable;
+}
+
+interface RenameLabelsSpec {
+  labels: Record<string, string>;
+}
+
+interface FilterByValueSpec {
+  operator: ComparisonOperator;
+  value: number;
+  calculation?: CalculationType;
+}
+
+interface BinaryOperationSpec {
+  left: number;
+  right: number;
+  operator: BinaryOperator;
+  name?: string;
+  keep_operands?: boolean;
+}
+
+interface ReduceToTableSpec {
+  calculations: CalculationType[];
+}
+
+type ComparisonOperator = '>' | '>=' | '<' | '<=' | '==' | '!=';
+type BinaryOperator = '+' | '-' | '*' | '/';
+
+const comparisons: Record<ComparisonOperator, (a: number, b: number) => boolean> = {
+  '>': (a, b) => a > b,
+  '>=': (a, b) => a >= b,
+  '<': (a, b) => a < b,
+  '<=': (a, b) => a <= b,
+  '==': (a, b) => a === b,
+  '!=': (a, b) => a !== b,
+};
+
+const operations: Record<BinaryOperator, (a: number, b: number) => number> = {
+  '+': (a, b) => a + b,
+  '-': (a, b) => a - b,
+  '*': (a, b) => a * b,
+  '/': (a, b) => a / b,
+};
+
+/**
+ * Applies the transformations in order on the results of the queries of a panel.
+ * Throws an error when a transformation is not valid.
+ */
+export function applyTransformations(
+  transformations: Array<Definition<UnknownSpec>>,
+  data: TransformedData
+): TransformedData {
+  return transformations.reduce((result, transformation, i) => {
+    try {
+      return applyTransformation(transformation, result);
+    } catch (e) {
+      throw new Error(`transformation ${i}: ${(e as Error).message}`);
+    }
+  }, data);
+}
+
+function applyTransformation(transformation: Definition<UnknownSpec>, data: TransformedData): TransformedData {
+  switch (transformation.kind) {
+    case 'MergeSeries':
+      return mergeSeries(data);
+    case 'RenameLabels':
+      return renameLabels(data, transformation.spec as unknown as RenameLabelsSpec);
+    case 'FilterByValue':
+      return filterByValue(data, transformation.spec as unknown as FilterByValueSpec);
+    case 'BinaryOperation':
+      return binaryOperation(data, transformation.spec as unknown as BinaryOperationSpec);
+    case 'ReduceToTable':
+      return reduceToTable(data, transformation.spec as unknown as ReduceToTableSpec);
+  }
+  throw new Error(`unknown kind "${transformation.kind}"`);
+}
+
+function mergeSeries(data: TransformedData): TransformedData {
+  if (data.results.length === 0) {
+    return data;
+  }
+  const merged: TimeSeries[] = [];
+  for (const result of data.results) {
+    for (const series of result.series) {
+      const existing = merged.find((s) => s.name === series.name && sameLabels(s.labels, series.labels));
+      if (existing === undefined) {
+        merged.push({ ...series });
+        continue;
+      }
+      existing.values = mergeValues(existing.values, series.values);
+    }
+  }
+  return { results: [{ query: 0, series: merged }], table: data.table };
+}
+
+// mergeValues returns the values of both lists sorted by timestamp.
+// When both lists have a value at the same time, the one of the second list is kept.
+function mergeValues(a: TimeSeriesValueTuple[], b: TimeSeriesValueTuple[]): TimeSeriesValueTuple[] {
+  const byTimestamp = new Map<number, TimeSeriesValueTuple>();
+  [...a, ...b].forEach((value) => byTimestamp.set(value[0], value));
+  return Array.from(byTimestamp.values()).sort((x, y) => x[0] - y[0]);
+}
+
+function renameLabels(data: TransformedData, spec: RenameLabelsSpec): TransformedData {
+  return {
+    results: data.results.map((result) => ({
+      query: result.query,
+      series: result.series.map((series) => ({ ...series, labels: renameSeriesLabels(series.labels, spec.labels) })),
+    })),
+    table: data.table,
+  };
+}
+
+// renameSeriesLabels renames the labels. When a renamed label is colliding with an existing one, the renamed label
+// wins. When two labels are renamed with the same name, the last one in alphabetical order wins.
+function renameSeriesLabels(labels: Labels | undefined, renaming: Record<string, string>): Labels | undefined {
+  if (labels === undefined || Object.keys(labels).length === 0) {
+    return labels;
+  }
+  const result: Labels = {};
+  const renamed: string[] = [];
+  for (const [name, value] of Object.entries(labels)) {
+    if (renaming[name] !== undefined) {
+      renamed.push(name);
+      continue;
+    }
+    result[name] = value;
+  }
+  for (const name of renamed.sort()) {
+    const newName = renaming[name];
+    if (newName) {
+      result[newName] = labels[name] as string;
+    }
+  }
+  return result;
+}
+
+function filterByValue(data: TransformedData, spec: FilterByValueSpec): TransformedData {
+  const compare = comparisons[spec.operator];
+  if (compare === undefined) {
+    throw new Error(`operator "${spec.operator}" is not supported`);
+  }
+  const calculate = spec.calculation === undefined ? undefined : getCalculation(spec.calculation);
+  return {
+    results: data.results.map((result) => {
+      const series: TimeSeries[] = [];
+      for (const s of result.series) {
+        if (calculate !== undefined) {
+          const value = calculate(s.values);
+          if (value !== undefined && compare(value, spec.value)) {
+            series.push(s);
+          }
+          continue;
+        }
+        const values = s.values.filter(([, value]) => value !== null && compare(value, spec.value));
+        series.push({ ...s, values });
+      }
+      return { query: result.query, series };
+    }),
+    table: data.table,
+  };
+}
+
+function binaryOperation(data: TransformedData, spec: BinaryOperationSpec): TransformedData {
+  const leftResult = data.results[spec.left];
+  if (leftResult === undefined) {
+    throw new Error(`there is no result for the query ${spec.left}`);
+  }
+  const rightResult = data.results[spec.right];
+  if (rightResult === undefined) {
+    throw new Error(`there is no result for the query ${spec.right}`);
+  }
+  const operate = operations[spec.operator];
+  if (operate === undefined) {
+    throw new Error(`operator "${spec.operator}" is not supported`);
+  }
+  const left = leftResult.series;
+  const right = rightResult.series;
+  const computed: TransformedResult = { query: leftResult.query, series: [] };
+  for (const l of left) {
+    for (const r of right) {
+      // a single series on each side is always matching, otherwise the series are matched by labels
+      if (!(left.length === 1 && right.length === 1) && !sameLabels(l.labels, r.labels)) {
+        continue;
+      }
+      computed.series.push({
+        name: spec.name || `${l.name} ${spec.operator} ${r.name}`,
+        labels: l.labels,
+        values: operateValues(l.values, r.values, operate),
+      });
+    }
+  }
+  if (spec.keep_operands) {
+    return { results: [...data.results, computed], table: data.table };
+  }
+  const results: TransformedResult[] = [];
+  data.results.forEach((result, i) => {
+    if (i === spec.left) {
+      results.push(computed);
+    } else if (i !== spec.right) {
+      results.push(result);
+    }
+  });
+  return { results, table: data.table };
+}
+
+// operateValues applies the operation on the values having the same timestamp on both side.
+// An infinite result (e.g. a division by zero) is considered as missing.
+function operateValues(
+  left: TimeSeriesValueTuple[],
+  right: TimeSeriesValueTuple[],
+  operate: (a: number, b: number) => number
+): TimeSeriesValueTuple[] {
+  const rightByTimestamp = new Map(right.map((value) => [value[0], value[1]]));
+  const result: TimeSeriesValueTuple[] = [];
+  for (const [timestamp, l] of left) {
+    const r = rightByTimestamp.get(timestamp);
+    if (r === undefined) {
+      continue;
+    }
+    if (l === null || r === null) {
+      result.push([timestamp, null]);
+      continue;
+    }
+    const value = operate(l, r);
+    result.push([timestamp, isFinite(value) ? value : null]);
+  }
+  return result;
+}
+
+function reduceToTable(data: TransformedData, spec: ReduceToTableSpec): TransformedData {
+  if (spec.calculations === undefined || spec.calculations.length === 0) {
+    throw new Error('at least one calculation is required');
+  }
+  const calculations = spec.calculations.map(getCalculation);
+  const labelSet = new Set<string>();
+  data.results.forEach((result) =>
+    result.series.forEach((series) => Object.keys(series.labels ?? {}).forEach((name) => labelSet.add(name)))
+  );
+  const labels = Array.from(labelSet).sort();
+  const table: TransformedTable = { columns: ['name', ...labels, ...spec.calculations], rows: [] };
+  for (const result of data.results) {
+    for (const series of result.series) {
+      table.rows.push([
+        series.name,
+        ...labels.map((name) => series.labels?.[name] ?? ''),
+        ...calculations.map((calculate) => calculate(series.values) ?? null),
+      ]);
+    }
+  }
+  return { results: [], table };
+}
+
+// getCalculation returns the function computing the calculation. It returns undefined when the result is not a number.
+function getCalculation(name: CalculationType): (values: TimeSeriesValueTuple[]) => number | undefined {
+  const calculate = CalculationsMap[name];
+  if (calculate === undefined) {
+    throw new Error(`calculation "${name}" is not supported`);
+  }
+  return (values) => {
+    const value = calculate(values);
+    return value === undefined || isNaN(value) ? undefined : value;
+  };
+}
+
+function sameLabels(a: Labels | undefined, b: Labels | undefined): boolean {
+  const aKeys = Object.keys(a ?? {});
+  if (aKeys.length !== Object.keys(b ?? {}).length) {
+    return false;
+  }
+  return aKeys.every((key) => b?.[key] === a?.[key]);
+}
@@ -13,6 +13,7 @@
 
 import { createContext, useCallback, useContext, useMemo } from 'react';
 import { Definition, TimeSeriesQueryDefinition, UnknownSpec } from '@perses-dev/core';
+import { TransformedData, applyTransformations } from '../../model';
 import { useTimeSeriesQueries } from '../time-series-queries';
 import { DataQueriesProviderProps, QueryData, UseDataQueryResults } from './model';
 
@@ -32,25 +33,27 @@ export function useDataQueriesContext() {
 }
 
 export function DataQueriesProvider(props: DataQueriesProviderProps) {
-  const { definitions, options, children } = props;
+  const { definitions, queryOptions, transformations, options, children } = props;
 
   // For now we will map each query plugin definition to TimeSeriesQueryDefinition
   // Later on when we add support for other query types,
   // we will have to map each query maps to the correct QueryDefinition
   const timeSeriesQueries = definitions.map(
-    (definition) =>
+    (definition, i) =>
       ({
         kind: 'TimeSeriesQuery',
         spec: {
           plugin: definition,
+          options: queryOptions?.[i],
         },
       } as TimeSeriesQueryDefinition)
   );
   const results = useTimeSeriesQueries(timeSeriesQueries, options);
 
-  const data = results.map(({ data, isFetching, isLoading, refetch, error }, i) => {
+  let data = results.map(({ data, isFetching, isLoading, refetch, error }, i) => {
     return {
       definition: definitions[i],
+      options: queryOptions?.[i],
       data,
       isFetching,
       isLoading,
@@ -59,6 +62,26 @@ export function DataQueriesProvider(props: DataQueriesProviderProps) {
     } as QueryData<Definition<UnknownSpec>>;
   });
 
+  // The transformations are applied once all the queries have returned their data. Each transformed result keeps the
+  // definition of the query it is coming from.
+  let transformed: TransformedData | undefined = undefined;
+  let transformationError: unknown = undefined;
+  const isDataReady = data.every((result) => result.data !== undefined);
+  if (transformations !== undefined && transformations.length > 0 && isDataReady) {
+    try {
+      transformed = applyTransformations(transformations, {
+        results: data.map((result, i) => ({ query: i, series: result.data?.series ?? [] })),
+      });
+      const queries = data;
+      data = transformed.results.map(({ query, series }) => {
+        const result = queries[query] as QueryData<Definition<UnknownSpec>>;
+        return { ...result, data: { ...result.data, series } };
+      });
+    } catch (error) {
+      transformationError = error;
+    }
+  }
+
   const refetchAll = useCallback(() => {
     results.forEach((result) => result.refetch());
   }, [results]);
@@ -69,9 +92,10 @@ export function DataQueriesProvider(props: DataQueriesProviderProps) {
       isFetching: results.some((result) => result.isFetching),
       isLoading: results.some((result) => result.isLoading),
       refetchAll,
-      errors: results.map((result) => result.error),
+      errors: [...results.map((result) => result.error), ...(transformationError ? [transformationError] : [])],
+      table: transformed?.table,
     };
-  }, [data, results, refetchAll]);
+  }, [data, results, refetchAll, transformed, transformationError]);
 
   return <DataQueriesContext.Provider value={ctx}>{children}</DataQueriesContext.Provider>;
 }
@@ -11,16 +11,21 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-import { Definition, TimeSeriesData, UnknownSpec } from '@perses-dev/core';
+import { Definition, QueryOptions, TimeSeriesData, UnknownSpec } from '@perses-dev/core';
+import { TransformedTable } from '../../model';
 
-type QueryOptions = Record<string, unknown>;
+type DataQueriesOptions = Record<string, unknown>;
 
 interface DataQueriesDefinitions<QueryPluginDefinition> {
   definitions: QueryPluginDefinition[];
 }
 export interface DataQueriesProviderProps<QueryPluginDefinition = Definition<UnknownSpec>>
   extends DataQueriesDefinitions<QueryPluginDefinition> {
-  options?: QueryOptions;
+  // the query options of each definition, in the same order as the definitions
+  queryOptions?: Array<QueryOptions | undefined>;
+  // the transformations of the panel, applied once all the queries have returned their data
+  transformations?: Array<Definition<UnknownSpec>>;
+  options?: DataQueriesOptions;
   children?: React.ReactNode;
 }
 
@@ -30,11 +35,14 @@ export interface UseDataQueryResults<QueryPluginDefinition = Definition<UnknownS
   isFetching: boolean;
   isLoading: boolean;
   errors: unknown[];
+  // the table produced by a ReduceToTable transformation
+  table?: TransformedTable;
 }
 
 export interface QueryData<QueryPluginDefinition> {
   data?: TimeSeriesData;
   definition: QueryPluginDefinition;
+  options?: QueryOptions;
   error: unknown;
   isFetching: boolean;
   isLoading: boolean;
@@ -12,7 +12,16 @@
 // limitations under the License.
 
 import { useQuery, useQueries, useQueryClient, Query, QueryCache, QueryKey } from '@tanstack/react-query';
-import { TimeSeriesQueryDefinition, UnknownSpec, TimeSeriesData } from '@perses-dev/core';
+import { sub } from 'date-fns';
+import {
+  TimeSeriesQueryDefinition,
+  UnknownSpec,
+  TimeSeriesData,
+  QueryOptions,
+  DurationString,
+  parseDurationString,
+  TimeSeriesValueTuple,
+} from '@perses-dev/core';
 import { TimeSeriesDataQuery, TimeSeriesQueryContext, TimeSeriesQueryPlugin } from '../model';
 import { VariableStateMap, useTemplateVariableValues } from './template-variables';
 import { useTimeRange } from './TimeRangeProvider';
@@ -74,6 +83,60 @@ function getQueryOptions({
   };
 }
 
+function getDurationMs(duration: DurationString, end: Date) {
+  return end.valueOf() - sub(end, parseDurationString(duration)).valueOf();
+}
+
+/**
+ * Applies the query options to the context given to the plugin: the time range is replaced by the relative time and
+ * moved by the time shift, and the step is bounded by the min step and the max data points.
+ */
+function applyQueryOptions(context: TimeSeriesQueryContext, options?: QueryOptions): TimeSeriesQueryContext {
+  if (options === undefined) {
+    return context;
+  }
+  let { start, end } = context.timeRange;
+  if (options.relative_time !== undefined) {
+    start = new Date(end.valueOf() - getDurationMs(options.relative_time, end));
+  }
+  if (options.time_shift !== undefined) {
+    const shiftMs = getDurationMs(options.time_shift, end);
+    start = new Date(start.valueOf() - shiftMs);
+    end = new Date(end.valueOf() - shiftMs);
+  }
+  let suggestedStepMs = context.suggestedStepMs;
+  if (options.min_step !== undefined) {
+    suggestedStepMs = Math.max(suggestedStepMs ?? 0, getDurationMs(options.min_step, end));
+  }
+  if (options.max_data_points !== undefined && options.max_data_points > 0) {
+    const rangeMs = end.valueOf() - start.valueOf();
+    suggestedStepMs = Math.max(suggestedStepMs ?? 0, Math.ceil(rangeMs / options.max_data_points));
+  }
+  return { ...context, timeRange: { start, end }, suggestedStepMs };
+}
+
+/**
+ * Moves the data of a shifted query back to the time range of the dashboard, so it can be compared with the other
+ * queries.
+ */
+function unshiftTimeSeriesData(data: TimeSeriesData, context: TimeSeriesQueryContext, options?: QueryOptions) {
+  if (options?.time_shift === undefined) {
+    return data;
+  }
+  const shiftMs = getDurationMs(options.time_shift, context.timeRange.end);
+  return {
+    ...data,
+    timeRange: data.timeRange && {
+      start: new Date(data.timeRange.start.valueOf() + shiftMs),
+      end: new Date(data.timeRange.end.valueOf() + shiftMs),
+    },
+    series: data.series.map((series) => ({
+      ...series,
+      values: series.values.map(([timestamp, value]): TimeSeriesValueTuple => [timestamp + shiftMs, value]),
+    })),
+  };
+}
+
 /**
  * Runs a time series query using a plugin and returns the results.
  */
@@ -85,14 +148,18 @@ export const useTimeSeriesQuery = (definition: TimeSeriesQueryDefinition, option
   return useQuery({
     enabled: queryEnabled,
     queryKey: queryKey,
-    queryFn: () => {
+    queryFn: async () => {
       // The 'enabled' option should prevent this from happening, but make TypeScript happy by checking
       if (plugin === undefined) {
         throw new Error('Expected plugin to be loaded');
       }
       // Keep options out of query key so we don't re-run queries because suggested step changes
       const ctx: TimeSeriesQueryContext = { ...context, suggestedStepMs: options?.suggestedStepMs };
-      return plugin.getTimeSeriesData(definition.spec.plugin.spec, ctx);
+      const data = await plugin.getTimeSeriesData(
+        definition.spec.plugin.spec,
+        applyQueryOptions(ctx, definition.spec.options)
+      );
+      return unshiftTimeSeriesData(data, ctx, definition.spec.options);
     },
   });
 };
@@ -120,8 +187,11 @@ export function useTimeSeriesQueries(definitions: TimeSeriesQueryDefinition[], o
           // Keep options out of query key so we don't re-run queries because suggested step changes
           const ctx: TimeSeriesQueryContext = { ...context, suggestedStepMs: options?.suggestedStepMs };
           const plugin = await getPlugin(TIME_SERIES_QUERY_KEY, definition.spec.plugin.kind);
-          const data = await plugin.getTimeSeriesData(definition.spec.plugin.spec, ctx);
-          return data;
+          const data = await plugin.getTimeSeriesData(
+            definition.spec.plugin.spec,
+            applyQueryOptions(ctx, definition.spec.options)
+          );
+          return unshiftTimeSeriesData(data, ctx, definition.spec.options);
         },
       };
     }),